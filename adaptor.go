@@ -35,6 +35,16 @@ type DigitalPinOptioner interface {
 	SetPollForEdgeDetection(pollInterval time.Duration, pollQuitChan chan struct{}) (changed bool)
 }
 
+// PinConfig describes the intended usage of a pin, see the PinSetupper interface of the gpio package.
+type PinConfig struct {
+	// Pin is the id of the pin, like used for DigitalRead() and DigitalWrite()
+	Pin string
+	// Output defines the direction, true for output and false for input
+	Output bool
+	// InitialValue is written on setup of an output pin
+	InitialValue int
+}
+
 // DigitalPinOptionApplier is the interface to apply options to change pin behavior immediately
 type DigitalPinOptionApplier interface {
 	// ApplyOptions apply all given options to the pin immediately
@@ -169,6 +179,14 @@ type I2cSystemDevicer interface {
 	Close() error
 }
 
+// I2cSMBusBlockReader is an optional interface of a i2c bus at system level, for devices which determine the length of
+// a block by itself, e.g. smart batteries. The packet error code (PEC) is only sent by the device if requested.
+type I2cSMBusBlockReader interface {
+	// ReadSMBusBlockData must be implemented as the sequence:
+	// "S Addr Wr [A] Comm [A] Sr Addr Rd [A] [Count] A [Data] A [Data] A ... A [Data] (A [PEC]) NA P"
+	ReadSMBusBlockData(address int, reg uint8, pec bool) ([]byte, error)
+}
+
 // SpiSystemDevicer is the interface to a SPI bus at system level.
 type SpiSystemDevicer interface {
 	TxRx(tx []byte, rx []byte) error
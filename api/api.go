@@ -129,6 +129,7 @@ func (a *API) AddC3PIORoutes() {
 	mcpCommandRoute := "/api/commands/:command"
 	robotDeviceCommandRoute := "/api/robots/:robot/devices/:device/commands/:command"
 	robotCommandRoute := "/api/robots/:robot/commands/:command"
+	robotDeviceValueRoute := "/api/robots/:robot/devices/:device/value"
 
 	a.Get("/api/commands", a.mcpCommands)
 	a.Get(mcpCommandRoute, a.executeMcpCommand)
@@ -140,10 +141,15 @@ func (a *API) AddC3PIORoutes() {
 	a.Post(robotCommandRoute, a.executeRobotCommand)
 	a.Get("/api/robots/:robot/devices", a.robotDevices)
 	a.Get("/api/robots/:robot/devices/:device", a.robotDevice)
+	a.Get("/api/robots/:robot/events", a.robotEvents)
 	a.Get("/api/robots/:robot/devices/:device/events/:event", a.robotDeviceEvent)
 	a.Get("/api/robots/:robot/devices/:device/commands", a.robotDeviceCommands)
 	a.Get(robotDeviceCommandRoute, a.executeRobotDeviceCommand)
 	a.Post(robotDeviceCommandRoute, a.executeRobotDeviceCommand)
+	a.Get(robotDeviceValueRoute, a.robotDeviceValue)
+	a.Post(robotDeviceValueRoute, a.setRobotDeviceValue)
+	a.Put(robotDeviceValueRoute, a.setRobotDeviceValue)
+	a.Get("/api/robots/:robot/actuators", a.robotActuators)
 	a.Get("/api/robots/:robot/connections", a.robotConnections)
 	a.Get("/api/robots/:robot/connections/:connection", a.robotConnection)
 	a.Get("/api/", a.mcp)
@@ -194,9 +200,12 @@ func (a *API) robeaux(res http.ResponseWriter, req *http.Request) {
 }
 
 // mcp returns MCP route handler.
-// Writes JSON with gobot representation
+// Writes JSON with gobot representation and the embedded gobot version
 func (a *API) mcp(res http.ResponseWriter, req *http.Request) {
-	a.writeJSON(map[string]interface{}{"MCP": gobot.NewJSONMaster(a.master)}, res)
+	a.writeJSON(map[string]interface{}{
+		"MCP":   gobot.NewJSONMaster(a.master),
+		"build": gobot.CurrentBuildInfo(),
+	}, res)
 }
 
 // mcpCommands returns commands route handler.
@@ -260,6 +269,20 @@ func (a *API) robotDevice(res http.ResponseWriter, req *http.Request) {
 }
 
 func (a *API) robotDeviceEvent(res http.ResponseWriter, req *http.Request) {
+	device, err := a.eventerFor(req.URL.Query().Get(":robot"), req.URL.Query().Get(":device"))
+	if err != nil {
+		a.writeJSON(map[string]interface{}{"error": err.Error()}, res)
+		return
+	}
+
+	event := device.Event(req.URL.Query().Get(":event"))
+	if len(event) == 0 {
+		a.writeJSON(map[string]interface{}{
+			"error": "No Event found with the name " + req.URL.Query().Get(":event"),
+		}, res)
+		return
+	}
+
 	f, _ := res.(http.Flusher)
 
 	dataChan := make(chan string)
@@ -268,35 +291,80 @@ func (a *API) robotDeviceEvent(res http.ResponseWriter, req *http.Request) {
 	res.Header().Set("Cache-Control", "no-cache")
 	res.Header().Set("Connection", "keep-alive")
 
-	device := a.master.Robot(req.URL.Query().Get(":robot")).
-		Device(req.URL.Query().Get(":device"))
-
-	//nolint:forcetypeassert // no error return value, so there is no better way
-	if event := a.master.Robot(req.URL.Query().Get(":robot")).
-		Device(req.URL.Query().Get(":device")).(gobot.Eventer).
-		Event(req.URL.Query().Get(":event")); len(event) > 0 {
-		//nolint:forcetypeassert // no error return value, so there is no better way
-		if err := device.(gobot.Eventer).On(event, func(data interface{}) {
-			d, _ := json.Marshal(data)
-			dataChan <- string(d)
-		}); err != nil {
-			panic(err)
+	if err := device.On(event, func(data interface{}) {
+		d, _ := json.Marshal(data)
+		dataChan <- string(d)
+	}); err != nil {
+		panic(err)
+	}
+
+	for {
+		select {
+		case data := <-dataChan:
+			fmt.Fprintf(res, "data: %v\n\n", data)
+			f.Flush()
+		case <-req.Context().Done():
+			log.Println("Closing connection")
+			return
 		}
+	}
+}
+
+// robotEvents returns the event stream route handler, scoped to the given robot.
+// Writes all events of the robot and its devices, each with the name of the source and the event.
+func (a *API) robotEvents(res http.ResponseWriter, req *http.Request) {
+	robot := a.master.Robot(req.URL.Query().Get(":robot"))
+	if robot == nil {
+		a.writeJSON(map[string]interface{}{"error": "No Robot found with the name " + req.URL.Query().Get(":robot")}, res)
+		return
+	}
+
+	f, _ := res.(http.Flusher)
+
+	dataChan := make(chan string)
+	done := make(chan struct{})
+	defer close(done)
+
+	res.Header().Set("Content-Type", "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
 
-		for {
-			select {
-			case data := <-dataChan:
-				fmt.Fprintf(res, "data: %v\n\n", data)
-				f.Flush()
-			case <-req.Context().Done():
-				log.Println("Closing connection")
-				return
+	forward := func(source string, eventer gobot.Eventer) {
+		events := eventer.Subscribe()
+		go func() {
+			defer eventer.Unsubscribe(events)
+			for {
+				select {
+				case evt := <-events:
+					d, _ := json.Marshal(map[string]interface{}{"source": source, "event": evt.Name, "data": evt.Data})
+					select {
+					case dataChan <- string(d):
+					case <-done:
+						return
+					}
+				case <-done:
+					return
+				}
 			}
+		}()
+	}
+
+	forward(robot.Name, robot)
+	robot.Devices().Each(func(d gobot.Device) {
+		if eventer, ok := d.(gobot.Eventer); ok {
+			forward(d.Name(), eventer)
+		}
+	})
+
+	for {
+		select {
+		case data := <-dataChan:
+			fmt.Fprintf(res, "data: %v\n\n", data)
+			f.Flush()
+		case <-req.Context().Done():
+			log.Println("Closing connection")
+			return
 		}
-	} else {
-		a.writeJSON(map[string]interface{}{
-			"error": "No Event found with the name " + req.URL.Query().Get(":event"),
-		}, res)
 	}
 }
 
@@ -310,6 +378,59 @@ func (a *API) robotDeviceCommands(res http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// robotActuators returns actuators route handler.
+// Writes JSON with the representation of all robot devices, which implements the actuator interface
+func (a *API) robotActuators(res http.ResponseWriter, req *http.Request) {
+	if robot := a.master.Robot(req.URL.Query().Get(":robot")); robot != nil {
+		jsonDevices := []*gobot.JSONDevice{}
+		robot.Devices().Each(func(d gobot.Device) {
+			if _, ok := d.(gobot.Actuator); ok {
+				jsonDevices = append(jsonDevices, gobot.NewJSONDevice(d))
+			}
+		})
+		a.writeJSON(map[string]interface{}{"actuators": jsonDevices}, res)
+	} else {
+		a.writeJSON(map[string]interface{}{"error": "No Robot found with the name " + req.URL.Query().Get(":robot")}, res)
+	}
+}
+
+// robotDeviceValue returns device value route handler.
+// Writes JSON with the value and range of the robot device, if it is an actuator
+func (a *API) robotDeviceValue(res http.ResponseWriter, req *http.Request) {
+	actuator, err := a.actuatorFor(req.URL.Query().Get(":robot"), req.URL.Query().Get(":device"))
+	if err != nil {
+		a.writeJSON(map[string]interface{}{"error": err.Error()}, res)
+		return
+	}
+
+	a.writeJSON(map[string]interface{}{"actuator": gobot.NewJSONActuator(actuator)}, res)
+}
+
+// setRobotDeviceValue commands the robot device to the value given by the request body, if it is an actuator.
+// Writes JSON with the value and range of the robot device afterwards.
+func (a *API) setRobotDeviceValue(res http.ResponseWriter, req *http.Request) {
+	actuator, err := a.actuatorFor(req.URL.Query().Get(":robot"), req.URL.Query().Get(":device"))
+	if err != nil {
+		a.writeJSON(map[string]interface{}{"error": err.Error()}, res)
+		return
+	}
+
+	body := struct {
+		Value *float64 `json:"value"`
+	}{}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil || body.Value == nil {
+		a.writeJSON(map[string]interface{}{"error": "Invalid or missing value"}, res)
+		return
+	}
+
+	if err := actuator.SetValue(*body.Value); err != nil {
+		a.writeJSON(map[string]interface{}{"error": err.Error()}, res)
+		return
+	}
+
+	a.writeJSON(map[string]interface{}{"actuator": gobot.NewJSONActuator(actuator)}, res)
+}
+
 // robotConnections returns connections route handler
 // writes JSON with robot connections representation
 func (a *API) robotConnections(res http.ResponseWriter, req *http.Request) {
@@ -344,19 +465,19 @@ func (a *API) executeMcpCommand(res http.ResponseWriter, req *http.Request) {
 
 // executeRobotDeviceCommand calls a device command associated to requested route
 func (a *API) executeRobotDeviceCommand(res http.ResponseWriter, req *http.Request) {
-	if _, err := a.jsonDeviceFor(req.URL.Query().Get(":robot"),
-		req.URL.Query().Get(":device")); err != nil {
+	device, err := a.deviceFor(req.URL.Query().Get(":robot"), req.URL.Query().Get(":device"))
+	if err != nil {
 		a.writeJSON(map[string]interface{}{"error": err.Error()}, res)
-	} else {
-		a.executeCommand(
-			//nolint:forcetypeassert // no error return value, so there is no better way
-			a.master.Robot(req.URL.Query().Get(":robot")).
-				Device(req.URL.Query().Get(":device")).(gobot.Commander).
-				Command(req.URL.Query().Get(":command")),
-			res,
-			req,
-		)
+		return
 	}
+
+	commander, ok := device.(gobot.Commander)
+	if !ok {
+		a.writeJSON(map[string]interface{}{"error": "Unknown Command"}, res)
+		return
+	}
+
+	a.executeCommand(commander.Command(req.URL.Query().Get(":command")), res, req)
 }
 
 // executeRobotCommand calls a robot command associated to requested route
@@ -416,16 +537,63 @@ func (a *API) jsonRobotFor(name string) (*gobot.JSONRobot, error) {
 	return nil, fmt.Errorf("No Robot found with the name %s", name)
 }
 
-func (a *API) jsonDeviceFor(robot string, name string) (*gobot.JSONDevice, error) {
-	if device := a.master.Robot(robot).Device(name); device != nil {
-		return gobot.NewJSONDevice(device), nil
+func (a *API) deviceFor(robot string, name string) (gobot.Device, error) {
+	r := a.master.Robot(robot)
+	if r == nil {
+		return nil, fmt.Errorf("No Robot found with the name %s", robot)
+	}
+
+	if device := r.Device(name); device != nil {
+		return device, nil
 	}
 
 	return nil, fmt.Errorf("No Device found with the name %s", name)
 }
 
+func (a *API) jsonDeviceFor(robot string, name string) (*gobot.JSONDevice, error) {
+	device, err := a.deviceFor(robot, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return gobot.NewJSONDevice(device), nil
+}
+
+func (a *API) eventerFor(robot string, name string) (gobot.Eventer, error) {
+	device, err := a.deviceFor(robot, name)
+	if err != nil {
+		return nil, err
+	}
+
+	eventer, ok := device.(gobot.Eventer)
+	if !ok {
+		return nil, fmt.Errorf("Device %s has no events", name)
+	}
+
+	return eventer, nil
+}
+
+func (a *API) actuatorFor(robot string, name string) (gobot.Actuator, error) {
+	device, err := a.deviceFor(robot, name)
+	if err != nil {
+		return nil, err
+	}
+
+	actuator, ok := device.(gobot.Actuator)
+	if !ok {
+		return nil, fmt.Errorf("Device %s is not an actuator", name)
+	}
+
+	return actuator, nil
+}
+
 func (a *API) jsonConnectionFor(robot string, name string) (*gobot.JSONConnection, error) {
-	if connection := a.master.Robot(robot).Connection(name); connection != nil {
+	r := a.master.Robot(robot)
+	if r == nil {
+		return nil, fmt.Errorf("No Robot found with the name %s", robot)
+	}
+
+	if connection := r.Connection(name); connection != nil {
 		return gobot.NewJSONConnection(connection), nil
 	}
 
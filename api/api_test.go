@@ -9,10 +9,12 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
 )
@@ -96,6 +98,9 @@ func TestMcp(t *testing.T) {
 	_ = json.NewDecoder(response.Body).Decode(&body)
 	assert.NotNil(t, body["MCP"].(map[string]interface{})["robots"])
 	assert.NotNil(t, body["MCP"].(map[string]interface{})["commands"])
+	build := body["build"].(map[string]interface{})
+	assert.Equal(t, gobot.Version(), build["version"])
+	assert.Equal(t, runtime.Version(), build["goVersion"])
 }
 
 func TestMcpCommands(t *testing.T) {
@@ -138,6 +143,27 @@ func TestExecuteMcpCommand(t *testing.T) {
 	assert.Equal(t, "Unknown Command", body.(map[string]interface{})["error"])
 }
 
+func TestExecuteMcpCommand_rejected(t *testing.T) {
+	// arrange
+	a := initTestAPI()
+	a.master.Commander.(gobot.CommandPolicySetter).SetCommandPolicy(gobot.CommandReject, time.Hour)
+	execute := func() string {
+		request, _ := http.NewRequest("GET",
+			"/api/commands/TestFunction",
+			bytes.NewBufferString(`{"message":"Beep Boop"}`),
+		)
+		request.Header.Add("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		a.ServeHTTP(response, request)
+		return response.Body.String()
+	}
+	require.Equal(t, `{"result":"hey Beep Boop"}`, execute())
+	// act
+	got := execute()
+	// assert
+	assert.Equal(t, `{"result":{"err":"command rejected by rate limit"}}`, got)
+}
+
 func TestRobots(t *testing.T) {
 	a := initTestAPI()
 	request, _ := http.NewRequest("GET", "/api/robots", nil)
@@ -189,6 +215,28 @@ func TestRobotDevices(t *testing.T) {
 	assert.Equal(t, "No Robot found with the name UnknownRobot1", body["error"])
 }
 
+func TestRobotDevices_labels(t *testing.T) {
+	a := initTestAPI()
+	a.master.Robot("Robot1").Device("Device1").(gobot.Labeler).SetLabel("axis", "x")
+
+	request, _ := http.NewRequest("GET", "/api/robots/Robot1/devices", nil)
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+
+	var body map[string]interface{}
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	devices := body["devices"].([]interface{})
+	require.Len(t, devices, 3)
+	for _, device := range devices {
+		device := device.(map[string]interface{})
+		if device["name"] == "Device1" {
+			assert.Equal(t, map[string]interface{}{"axis": "x"}, device["labels"])
+		} else {
+			assert.NotContains(t, device, "labels")
+		}
+	}
+}
+
 func TestRobotCommands(t *testing.T) {
 	a := initTestAPI()
 
@@ -337,6 +385,92 @@ func TestExecuteRobotDeviceCommand(t *testing.T) {
 	assert.Equal(t, "No Device found with the name UnknownDevice1", body.(map[string]interface{})["error"])
 }
 
+func TestRobotActuators(t *testing.T) {
+	a := initTestAPI()
+	actuator := newTestActuatorDriver(newTestAdaptor("Connection1", "/dev/null"), "Actuator1", "5")
+	actuator.value = 42
+	a.master.Robot("Robot1").AddDevice(actuator)
+
+	// known robot
+	request, _ := http.NewRequest("GET", "/api/robots/Robot1/actuators", nil)
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+
+	var body map[string]interface{}
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	actuators := body["actuators"].([]interface{})
+	assert.Len(t, actuators, 1)
+	device := actuators[0].(map[string]interface{})
+	assert.Equal(t, "Actuator1", device["name"])
+	assert.Equal(t, map[string]interface{}{"min": 0.0, "max": 100.0, "value": 42.0}, device["actuator"])
+
+	// unknown robot
+	request, _ = http.NewRequest("GET", "/api/robots/UnknownRobot1/actuators", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "No Robot found with the name UnknownRobot1", body["error"])
+}
+
+func TestRobotDeviceValue(t *testing.T) {
+	a := initTestAPI()
+	actuator := newTestActuatorDriver(newTestAdaptor("Connection1", "/dev/null"), "Actuator1", "5")
+	a.master.Robot("Robot1").AddDevice(actuator)
+
+	// set the value
+	request, _ := http.NewRequest("PUT", "/api/robots/Robot1/devices/Actuator1/value",
+		bytes.NewBufferString(`{"value":12.5}`))
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+
+	var body map[string]interface{}
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, map[string]interface{}{"min": 0.0, "max": 100.0, "value": 12.5}, body["actuator"])
+	assert.InDelta(t, 12.5, actuator.value, 0.0)
+
+	// get the value
+	request, _ = http.NewRequest("GET", "/api/robots/Robot1/devices/Actuator1/value", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	body = nil
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, map[string]interface{}{"min": 0.0, "max": 100.0, "value": 12.5}, body["actuator"])
+
+	// value out of range
+	request, _ = http.NewRequest("POST", "/api/robots/Robot1/devices/Actuator1/value",
+		bytes.NewBufferString(`{"value":101}`))
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	body = nil
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "value 101 out of range", body["error"])
+
+	// missing value
+	request, _ = http.NewRequest("POST", "/api/robots/Robot1/devices/Actuator1/value",
+		bytes.NewBufferString(`{"val":1}`))
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	body = nil
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "Invalid or missing value", body["error"])
+
+	// no actuator
+	request, _ = http.NewRequest("GET", "/api/robots/Robot1/devices/Device1/value", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	body = nil
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "Device Device1 is not an actuator", body["error"])
+
+	// unknown device
+	request, _ = http.NewRequest("GET", "/api/robots/Robot1/devices/UnknownDevice1/value", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	body = nil
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "No Device found with the name UnknownDevice1", body["error"])
+}
+
 func TestRobotConnections(t *testing.T) {
 	a := initTestAPI()
 
@@ -429,6 +563,117 @@ func TestRobotDeviceEvent(t *testing.T) {
 	var body map[string]interface{}
 	_ = json.NewDecoder(response.Body).Decode(&body)
 	assert.Equal(t, "No Event found with the name UnknownEvent", body["error"])
+
+	// unknown robot
+	response, _ = http.Get(server.URL + "/api/robots/UnknownRobot1/devices/Device1/events/TestEvent")
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "No Robot found with the name UnknownRobot1", body["error"])
+
+	// unknown device
+	response, _ = http.Get(server.URL + "/api/robots/Robot1/devices/UnknownDevice1/events/TestEvent")
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "No Device found with the name UnknownDevice1", body["error"])
+}
+
+func TestMultipleRobotsScoping(t *testing.T) {
+	// arrange
+	log.SetOutput(NullReadWriteCloser{})
+	g := gobot.NewMaster()
+	a := NewAPI(g)
+	a.start = func(m *API) {}
+	a.Start()
+	for _, name := range []string{"Alpha", "Beta"} {
+		robotName := name
+		r := newTestRobot(robotName)
+		r.Device("Device1").(gobot.Commander).AddCommand("Owner", func(params map[string]interface{}) interface{} {
+			return robotName
+		})
+		g.AddRobot(r)
+	}
+	g.Robot("Beta").AddDevice(newTestDriver(newTestAdaptor("Connection4", "/dev/null"), "BetaOnly", "4"))
+
+	// act & assert: command dispatch
+	for _, name := range []string{"Alpha", "Beta"} {
+		var body map[string]interface{}
+		request, _ := http.NewRequest("POST", "/api/robots/"+name+"/devices/Device1/commands/Owner",
+			bytes.NewBufferString(`{}`))
+		request.Header.Add("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		a.ServeHTTP(response, request)
+		_ = json.NewDecoder(response.Body).Decode(&body)
+		assert.Equal(t, name, body["result"])
+	}
+
+	// act & assert: device listing
+	var devices map[string][]map[string]interface{}
+	request, _ := http.NewRequest("GET", "/api/robots/Alpha/devices", nil)
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	_ = json.NewDecoder(response.Body).Decode(&devices)
+	assert.Len(t, devices["devices"], 3)
+
+	request, _ = http.NewRequest("GET", "/api/robots/Beta/devices", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	_ = json.NewDecoder(response.Body).Decode(&devices)
+	assert.Len(t, devices["devices"], 4)
+	assert.Equal(t, "BetaOnly", devices["devices"][3]["name"])
+
+	// act & assert: device of other robot is not found
+	var body map[string]interface{}
+	request, _ = http.NewRequest("GET", "/api/robots/Alpha/devices/BetaOnly", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "No Device found with the name BetaOnly", body["error"])
+
+	// act & assert: unknown robot
+	request, _ = http.NewRequest("POST", "/api/robots/Gamma/devices/Device1/commands/Owner",
+		bytes.NewBufferString(`{}`))
+	request.Header.Add("Content-Type", "application/json")
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "No Robot found with the name Gamma", body["error"])
+}
+
+func TestRobotEvents(t *testing.T) {
+	a := initTestAPI()
+	server := httptest.NewServer(a)
+	defer server.Close()
+
+	// known robot
+	respc := make(chan *http.Response, 1)
+	go func() {
+		resp, _ := http.Get(server.URL + "/api/robots/Robot2/events")
+		respc <- resp
+	}()
+
+	go func() {
+		time.Sleep(time.Millisecond * 5)
+		// event of another robot must not be streamed
+		a.master.Robot("Robot1").Device("Device1").(gobot.Eventer).Publish("TestEvent", "robot1-data")
+		time.Sleep(time.Millisecond * 5)
+		a.master.Robot("Robot2").Device("Device2").(gobot.Eventer).Publish("TestEvent", "robot2-data")
+	}()
+
+	select {
+	case resp := <-respc:
+		reader := bufio.NewReader(resp.Body)
+		data, _ := reader.ReadString('\n')
+		assert.Equal(t, "data: {\"data\":\"robot2-data\",\"event\":\"TestEvent\",\"source\":\"Device2\"}\n", data)
+	case <-time.After(200 * time.Millisecond):
+		t.Error("Not receiving data")
+	}
+
+	server.CloseClientConnections()
+
+	// unknown robot
+	response, _ := http.Get(server.URL + "/api/robots/UnknownRobot1/events")
+
+	var body map[string]interface{}
+	_ = json.NewDecoder(response.Body).Decode(&body)
+	assert.Equal(t, "No Robot found with the name UnknownRobot1", body["error"])
 }
 
 func TestAPIRouter(t *testing.T) {
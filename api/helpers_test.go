@@ -27,6 +27,7 @@ type testDriver struct {
 	connection gobot.Connection
 	gobot.Commander
 	gobot.Eventer
+	gobot.Labeler
 }
 
 func (t *testDriver) Start() error                 { return nil }
@@ -43,6 +44,7 @@ func newTestDriver(adaptor *testAdaptor, name string, pin string) *testDriver {
 		pin:        pin,
 		Eventer:    gobot.NewEventer(),
 		Commander:  gobot.NewCommander(),
+		Labeler:    gobot.NewLabeler(),
 	}
 
 	t.AddEvent("TestEvent")
@@ -103,3 +105,24 @@ func newTestRobot(name string) *gobot.Robot {
 	})
 	return r
 }
+
+type testActuatorDriver struct {
+	*testDriver
+	value float64
+}
+
+func (t *testActuatorDriver) SetValue(val float64) error {
+	if val < 0 || val > 100 {
+		return fmt.Errorf("value %v out of range", val)
+	}
+	t.value = val
+	return nil
+}
+
+func (t *testActuatorDriver) GetValue() float64 { return t.value }
+
+func (t *testActuatorDriver) ValueRange() (float64, float64) { return 0, 100 }
+
+func newTestActuatorDriver(adaptor *testAdaptor, name string, pin string) *testActuatorDriver {
+	return &testActuatorDriver{testDriver: newTestDriver(adaptor, name, pin)}
+}
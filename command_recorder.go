@@ -0,0 +1,148 @@
+package gobot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RecordedCommand is a command of an actuator, which was recorded by the CommandRecorder.
+type RecordedCommand struct {
+	Device   string        `json:"device"`
+	Value    float64       `json:"value"`
+	Previous float64       `json:"previous"` // the value of the actuator before the command, used for undo
+	Offset   time.Duration `json:"offset"`   // the time since the first recorded command
+}
+
+// CommandRecorder executes and records the commands of actuators (see [gobot.Actuator]), e.g. servo angles or motor
+// speeds, together with their point in time. The recorded sequence can be replayed with the original timing, e.g.
+// for teach-and-repeat, and the last commands can be undone by commanding the prior value again.
+type CommandRecorder struct {
+	actuators map[string]Actuator
+	commands  []RecordedCommand
+	start     time.Time
+	mutex     sync.Mutex
+}
+
+// NewCommandRecorder returns a new CommandRecorder without actuators, see AddActuator().
+func NewCommandRecorder() *CommandRecorder {
+	return &CommandRecorder{
+		actuators: make(map[string]Actuator),
+	}
+}
+
+// AddActuator adds the given device, which needs to implement the [gobot.Actuator] interface, to the recorder. The
+// device is addressed by its name.
+func (r *CommandRecorder) AddActuator(d Device) error {
+	actuator, ok := d.(Actuator)
+	if !ok {
+		return fmt.Errorf("device '%s' is not an actuator", d.Name())
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.actuators[d.Name()]; exists {
+		return fmt.Errorf("actuator '%s' was already added", d.Name())
+	}
+	r.actuators[d.Name()] = actuator
+
+	return nil
+}
+
+// SetValue commands the actuator with the given name to the given value and records the command on success.
+func (r *CommandRecorder) SetValue(name string, val float64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	actuator, ok := r.actuators[name]
+	if !ok {
+		return fmt.Errorf("No actuator found with the name %s", name)
+	}
+
+	previous := actuator.GetValue()
+	if err := actuator.SetValue(val); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if len(r.commands) == 0 {
+		r.start = now
+	}
+	r.commands = append(r.commands, RecordedCommand{
+		Device:   name,
+		Value:    val,
+		Previous: previous,
+		Offset:   now.Sub(r.start),
+	})
+
+	return nil
+}
+
+// Commands returns a copy of all recorded commands in the order of execution.
+func (r *CommandRecorder) Commands() []RecordedCommand {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]RecordedCommand{}, r.commands...)
+}
+
+// Clear removes all recorded commands, the actuators are kept.
+func (r *CommandRecorder) Clear() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.commands = nil
+}
+
+// Undo reverts the last recorded command by commanding the prior value of the actuator and removes the command from
+// the recording. If the revert fails, the command stays recorded.
+func (r *CommandRecorder) Undo() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.commands) == 0 {
+		return fmt.Errorf("no recorded command to undo")
+	}
+
+	last := r.commands[len(r.commands)-1]
+	if err := r.actuators[last.Device].SetValue(last.Previous); err != nil {
+		return fmt.Errorf("undo of command for '%s' failed: %w", last.Device, err)
+	}
+	r.commands = r.commands[:len(r.commands)-1]
+
+	return nil
+}
+
+// Replay executes all recorded commands again with the original timing, starting immediately with the first one. The
+// replay is stopped with an error, if a command fails or the given context is done. Replayed commands are not
+// recorded again.
+func (r *CommandRecorder) Replay(ctx context.Context) error {
+	r.mutex.Lock()
+	commands := append([]RecordedCommand{}, r.commands...)
+	actuators := make(map[string]Actuator, len(r.actuators))
+	for name, actuator := range r.actuators {
+		actuators[name] = actuator
+	}
+	r.mutex.Unlock()
+
+	start := time.Now()
+	for _, cmd := range commands {
+		if wait := cmd.Offset - time.Since(start); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		} else if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := actuators[cmd.Device].SetValue(cmd.Value); err != nil {
+			return fmt.Errorf("replay of command for '%s' failed: %w", cmd.Device, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package gobot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServoDriver struct {
+	*testDriver
+	angle   float64
+	written []float64
+	failing bool
+}
+
+func newTestServoDriver(name string) *testServoDriver {
+	return &testServoDriver{testDriver: newTestDriver(newTestAdaptor("Connection1", "/dev/null"), name, "0")}
+}
+
+func (s *testServoDriver) SetValue(val float64) error {
+	if s.failing {
+		return errors.New("write error")
+	}
+	s.angle = val
+	s.written = append(s.written, val)
+	return nil
+}
+
+func (s *testServoDriver) GetValue() float64              { return s.angle }
+func (s *testServoDriver) ValueRange() (float64, float64) { return 0, 180 }
+
+func TestCommandRecorder(t *testing.T) {
+	// arrange
+	pan := newTestServoDriver("pan")
+	pan.angle = 90
+	tilt := newTestServoDriver("tilt")
+	r := NewCommandRecorder()
+	require.NoError(t, r.AddActuator(pan))
+	require.NoError(t, r.AddActuator(tilt))
+	// act & assert: record
+	require.NoError(t, r.SetValue("pan", 45))
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, r.SetValue("tilt", 30))
+	require.NoError(t, r.SetValue("pan", 120))
+	commands := r.Commands()
+	require.Len(t, commands, 3)
+	assert.Equal(t, "pan", commands[0].Device)
+	assert.InDelta(t, 45.0, commands[0].Value, 0.0)
+	assert.InDelta(t, 90.0, commands[0].Previous, 0.0)
+	assert.Equal(t, time.Duration(0), commands[0].Offset)
+	assert.Equal(t, "tilt", commands[1].Device)
+	assert.GreaterOrEqual(t, commands[1].Offset, 20*time.Millisecond)
+	assert.InDelta(t, 45.0, commands[2].Previous, 0.0)
+	// act & assert: undo
+	require.NoError(t, r.Undo())
+	assert.InDelta(t, 45.0, pan.angle, 0.0)
+	assert.Len(t, r.Commands(), 2)
+	// act & assert: replay
+	pan.written, tilt.written = nil, nil
+	start := time.Now()
+	require.NoError(t, r.Replay(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	assert.Equal(t, []float64{45}, pan.written)
+	assert.Equal(t, []float64{30}, tilt.written)
+	assert.Len(t, r.Commands(), 2)
+	// act & assert: clear
+	r.Clear()
+	assert.Empty(t, r.Commands())
+	require.EqualError(t, r.Undo(), "no recorded command to undo")
+}
+
+func TestCommandRecorder_errors(t *testing.T) {
+	// arrange
+	pan := newTestServoDriver("pan")
+	r := NewCommandRecorder()
+	// act & assert
+	require.EqualError(t, r.AddActuator(newTestDriver(newTestAdaptor("Connection1", "/dev/null"), "led", "1")),
+		"device 'led' is not an actuator")
+	require.NoError(t, r.AddActuator(pan))
+	require.EqualError(t, r.AddActuator(pan), "actuator 'pan' was already added")
+	require.EqualError(t, r.SetValue("tilt", 10), "No actuator found with the name tilt")
+	pan.failing = true
+	require.EqualError(t, r.SetValue("pan", 10), "write error")
+	assert.Empty(t, r.Commands())
+	pan.failing = false
+	require.NoError(t, r.SetValue("pan", 10))
+	pan.failing = true
+	require.EqualError(t, r.Undo(), "undo of command for 'pan' failed: write error")
+	assert.Len(t, r.Commands(), 1)
+	require.EqualError(t, r.Replay(context.Background()), "replay of command for 'pan' failed: write error")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, r.Replay(ctx), context.Canceled)
+}
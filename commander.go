@@ -1,7 +1,38 @@
 package gobot
 
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// CommandPolicy defines, how a command is executed, while another command of the same commander is in progress.
+type CommandPolicy int
+
+const (
+	// CommandConcurrent executes each command immediately, also in parallel to a running one (default)
+	CommandConcurrent CommandPolicy = iota
+	// CommandSerialize executes the commands one after another, a new command waits until the running one is done
+	CommandSerialize
+	// CommandReject rejects a new command with ErrCommandInProgress, while another one is running, see SetCommandPolicy()
+	CommandReject
+)
+
+var (
+	// ErrCommandInProgress is the reason of a command, which was rejected because another command is running
+	ErrCommandInProgress = errors.New("another command is in progress")
+	// ErrCommandRateLimited is the reason of a command, which was rejected because the min. interval is not elapsed
+	ErrCommandRateLimited = errors.New("command rejected by rate limit")
+)
+
 type commander struct {
 	commands map[string]func(map[string]interface{}) interface{}
+	// values for the execution policy, the commands are not wrapped for the default policy
+	policy      CommandPolicy
+	minInterval time.Duration
+	lastStart   time.Time
+	slot        chan struct{} // filled while a command is running, used by CommandSerialize and CommandReject
+	mutex       sync.Mutex
 }
 
 // Commander is the interface which describes the behaviour for a Driver or Adaptor
@@ -15,24 +46,122 @@ type Commander interface {
 	AddCommand(name string, command func(map[string]interface{}) interface{})
 }
 
+// CommandPolicySetter is the optional interface of a Commander, which can limit the execution of its commands. It is
+// implemented by the Commander of NewCommander(), so it is available for all drivers and adaptors, which embed it.
+type CommandPolicySetter interface {
+	// SetCommandPolicy sets the policy for commands, which are called while another command is running, and the min.
+	// interval between the start of two commands. See [gobot.CommandPolicy].
+	SetCommandPolicy(policy CommandPolicy, minInterval time.Duration)
+}
+
 // NewCommander returns a new Commander.
 func NewCommander() Commander {
 	return &commander{
 		commands: make(map[string]func(map[string]interface{}) interface{}),
+		slot:     make(chan struct{}, 1),
 	}
 }
 
 // Command returns the command interface when passed a valid command name
 func (c *commander) Command(name string) func(map[string]interface{}) interface{} {
-	return c.commands[name]
+	command, ok := c.commands[name]
+	if !ok || c.unlimited() {
+		return command
+	}
+
+	return c.limit(command)
 }
 
 // Commands returns the entire map of valid commands
 func (c *commander) Commands() map[string]func(map[string]interface{}) interface{} {
-	return c.commands
+	if c.unlimited() {
+		return c.commands
+	}
+
+	commands := make(map[string]func(map[string]interface{}) interface{}, len(c.commands))
+	for name, command := range c.commands {
+		commands[name] = c.limit(command)
+	}
+
+	return commands
 }
 
 // AddCommand adds a new command, when passed a command name and the command interface.
 func (c *commander) AddCommand(name string, command func(map[string]interface{}) interface{}) {
 	c.commands[name] = command
 }
+
+// SetCommandPolicy sets the policy for commands, which are called while another command is running, e.g. to prevent
+// overlapping movements of a motor, which are requested by the API. With a min. interval greater than zero, a
+// command is rejected with ErrCommandRateLimited, if the interval since the start of the last command is not elapsed.
+// For CommandSerialize the command is delayed instead. The result of a rejected command is a map with the message of
+// the error at the key "err", e.g. {"err": "another command is in progress"}, so it is visible in the API.
+func (c *commander) SetCommandPolicy(policy CommandPolicy, minInterval time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.policy = policy
+	c.minInterval = minInterval
+}
+
+// unlimited returns true for the default policy, which needs no wrapping of the commands
+func (c *commander) unlimited() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.policy == CommandConcurrent && c.minInterval <= 0
+}
+
+// limit wraps the given command, so the execution policy is applied on each call
+func (c *commander) limit(command func(map[string]interface{}) interface{}) func(map[string]interface{}) interface{} {
+	return func(params map[string]interface{}) interface{} {
+		release, err := c.acquire()
+		if err != nil {
+			return map[string]interface{}{"err": err.Error()}
+		}
+		defer release()
+
+		return command(params)
+	}
+}
+
+// acquire applies the execution policy before the start of a command and returns the function to call after the
+// command is finished
+func (c *commander) acquire() (func(), error) {
+	c.mutex.Lock()
+	policy := c.policy
+	c.mutex.Unlock()
+
+	switch policy {
+	case CommandSerialize:
+		c.slot <- struct{}{} // waits until the running command is finished
+		c.mutex.Lock()
+		wait := c.minInterval - time.Since(c.lastStart)
+		c.mutex.Unlock()
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+	case CommandReject:
+		select {
+		case c.slot <- struct{}{}:
+		default:
+			return nil, ErrCommandInProgress
+		}
+	}
+
+	release := func() {}
+	if policy == CommandSerialize || policy == CommandReject {
+		release = func() { <-c.slot }
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if policy != CommandSerialize && time.Since(c.lastStart) < c.minInterval {
+		release()
+		return nil, ErrCommandRateLimited
+	}
+	c.lastStart = time.Now()
+
+	return release, nil
+}
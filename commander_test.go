@@ -1,9 +1,13 @@
 package gobot
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCommander(t *testing.T) {
@@ -18,3 +22,162 @@ func TestCommander(t *testing.T) {
 	assert.NotNil(t, c.Command("test"))
 	assert.Nil(t, c.Command("booyeah"))
 }
+
+// testBareCommander implements only the mandatory methods of the Commander interface
+type testBareCommander struct{}
+
+func (c *testBareCommander) Command(string) func(map[string]interface{}) interface{} { return nil }
+
+func (c *testBareCommander) Commands() map[string]func(map[string]interface{}) interface{} {
+	return nil
+}
+
+func (c *testBareCommander) AddCommand(string, func(map[string]interface{}) interface{}) {}
+
+func requireCommandPolicySetter(t *testing.T, c Commander) CommandPolicySetter {
+	t.Helper()
+
+	s, ok := c.(CommandPolicySetter)
+	require.True(t, ok, "commander does not implement CommandPolicySetter")
+	return s
+}
+
+func TestCommanderPolicySetterIsOptional(t *testing.T) {
+	// arrange
+	var bare Commander = &testBareCommander{}
+	// act
+	_, bareOk := bare.(CommandPolicySetter)
+	_, defaultOk := NewCommander().(CommandPolicySetter)
+	// assert
+	assert.False(t, bareOk)
+	assert.True(t, defaultOk)
+}
+
+// rejected returns the result of a command, which was rejected with the given error
+func rejected(err error) interface{} {
+	return map[string]interface{}{"err": err.Error()}
+}
+
+func TestCommanderSetCommandPolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy        CommandPolicy
+		minInterval   time.Duration
+		wantResults   []interface{}
+		wantMaxActive int32
+	}{
+		"concurrent": {
+			policy:        CommandConcurrent,
+			wantResults:   []interface{}{"done", "done", "done"},
+			wantMaxActive: 3,
+		},
+		"serialize": {
+			policy:        CommandSerialize,
+			wantResults:   []interface{}{"done", "done", "done"},
+			wantMaxActive: 1,
+		},
+		"reject": {
+			policy:        CommandReject,
+			wantResults:   []interface{}{"done", rejected(ErrCommandInProgress), rejected(ErrCommandInProgress)},
+			wantMaxActive: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			var active, maxActive int32
+			started := make(chan struct{}, 3)
+			c := NewCommander()
+			c.AddCommand("move", func(map[string]interface{}) interface{} {
+				n := atomic.AddInt32(&active, 1)
+				defer atomic.AddInt32(&active, -1)
+				for {
+					old := atomic.LoadInt32(&maxActive)
+					if n <= old || atomic.CompareAndSwapInt32(&maxActive, old, n) {
+						break
+					}
+				}
+				started <- struct{}{}
+				time.Sleep(30 * time.Millisecond)
+				return "done"
+			})
+			requireCommandPolicySetter(t, c).SetCommandPolicy(tc.policy, tc.minInterval)
+			// act: the first command is running, when the others are invoked
+			results := make([]interface{}, 3)
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[0] = c.Command("move")(nil)
+			}()
+			<-started
+			for i := 1; i < 3; i++ {
+				i := i
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					results[i] = c.Command("move")(nil)
+				}()
+			}
+			wg.Wait()
+			// assert
+			assert.Equal(t, tc.wantResults, results)
+			assert.Equal(t, tc.wantMaxActive, maxActive)
+		})
+	}
+}
+
+func TestCommanderSetCommandPolicy_minInterval(t *testing.T) {
+	tests := map[string]struct {
+		policy      CommandPolicy
+		wantResults []interface{}
+		wantMinTime time.Duration
+	}{
+		"concurrent_rejects": {
+			policy:      CommandConcurrent,
+			wantResults: []interface{}{"done", rejected(ErrCommandRateLimited), rejected(ErrCommandRateLimited)},
+		},
+		"reject_rejects": {
+			policy:      CommandReject,
+			wantResults: []interface{}{"done", rejected(ErrCommandRateLimited), rejected(ErrCommandRateLimited)},
+		},
+		"serialize_delays": {
+			policy:      CommandSerialize,
+			wantResults: []interface{}{"done", "done", "done"},
+			wantMinTime: 100 * time.Millisecond,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			c := NewCommander()
+			c.AddCommand("move", func(map[string]interface{}) interface{} {
+				return "done"
+			})
+			requireCommandPolicySetter(t, c).SetCommandPolicy(tc.policy, 50*time.Millisecond)
+			start := time.Now()
+			// act: the calls from the map of commands are also limited
+			var results []interface{}
+			results = append(results, c.Command("move")(nil))
+			results = append(results, c.Commands()["move"](nil))
+			results = append(results, c.Command("move")(nil))
+			// assert
+			assert.Equal(t, tc.wantResults, results)
+			assert.GreaterOrEqual(t, time.Since(start), tc.wantMinTime)
+		})
+	}
+}
+
+func TestCommanderSetCommandPolicy_reset(t *testing.T) {
+	// arrange
+	c := NewCommander()
+	command := func(map[string]interface{}) interface{} { return "done" }
+	c.AddCommand("move", command)
+	requireCommandPolicySetter(t, c).SetCommandPolicy(CommandReject, time.Hour)
+	require.Equal(t, "done", c.Command("move")(nil))
+	require.Equal(t, rejected(ErrCommandRateLimited), c.Command("move")(nil))
+	// act
+	requireCommandPolicySetter(t, c).SetCommandPolicy(CommandConcurrent, 0)
+	// assert
+	assert.Equal(t, "done", c.Command("move")(nil))
+	assert.Equal(t, "done", c.Commands()["move"](nil))
+}
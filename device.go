@@ -9,10 +9,25 @@ import (
 
 // JSONDevice is a JSON representation of a Device.
 type JSONDevice struct {
-	Name       string   `json:"name"`
-	Driver     string   `json:"driver"`
-	Connection string   `json:"connection"`
-	Commands   []string `json:"commands"`
+	Name       string            `json:"name"`
+	Driver     string            `json:"driver"`
+	Connection string            `json:"connection"`
+	Commands   []string          `json:"commands"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Actuator   *JSONActuator     `json:"actuator,omitempty"`
+}
+
+// JSONActuator is a JSON representation of the value and range of an Actuator.
+type JSONActuator struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Value float64 `json:"value"`
+}
+
+// NewJSONActuator returns a JSONActuator given an Actuator.
+func NewJSONActuator(actuator Actuator) *JSONActuator {
+	minVal, maxVal := actuator.ValueRange()
+	return &JSONActuator{Min: minVal, Max: maxVal, Value: actuator.GetValue()}
 }
 
 // NewJSONDevice returns a JSONDevice given a Device.
@@ -31,6 +46,14 @@ func NewJSONDevice(device Device) *JSONDevice {
 			jsonDevice.Commands = append(jsonDevice.Commands, command)
 		}
 	}
+	if labeler, ok := device.(Labeler); ok {
+		if labels := labeler.Labels(); len(labels) > 0 {
+			jsonDevice.Labels = labels
+		}
+	}
+	if actuator, ok := device.(Actuator); ok {
+		jsonDevice.Actuator = NewJSONActuator(actuator)
+	}
 	return jsonDevice
 }
 
@@ -54,6 +77,11 @@ func (d *Devices) Each(f func(Device)) {
 
 // Start calls Start on each Device in d
 func (d *Devices) Start() error {
+	return d.start(nil)
+}
+
+// start calls Start on each Device in d and the given function for each device, which fails to start
+func (d *Devices) start(onError func(Device)) error {
 	log.Println("Starting devices...")
 	var err error
 	for _, device := range *d {
@@ -66,6 +94,9 @@ func (d *Devices) Start() error {
 		log.Println(info + "...")
 		if derr := device.Start(); derr != nil {
 			err = multierror.Append(err, derr)
+			if onError != nil {
+				onError(device)
+			}
 		}
 	}
 	return err
@@ -19,3 +19,22 @@ type Driver interface {
 type Pinner interface {
 	Pin() string
 }
+
+// Actuator is the interface that describes a driver which can be commanded to a value or position within a
+// declared range, e.g. the angle of a servo or the speed of a motor. This makes it possible to render generic
+// controls (e.g. sliders) for such drivers.
+type Actuator interface {
+	// SetValue commands the actuator to the given value, which needs to be within the declared range
+	SetValue(val float64) error
+	// GetValue returns the last commanded value of the actuator
+	GetValue() float64
+	// ValueRange returns the minimum and maximum value which can be commanded
+	ValueRange() (min float64, max float64)
+}
+
+// Restartable is an optional interface for drivers, which can report whether they can be started again after a halt.
+// Drivers which do not implement this interface are expected to support a restart.
+type Restartable interface {
+	// Restartable returns true, if the driver can be started again after a halt
+	Restartable() bool
+}
@@ -16,6 +16,12 @@ const (
 	Value = "value"
 	// Vibration event
 	Vibration = "vibration"
+	// Rate event
+	Rate = "rate"
+	// LineFollowerLine event
+	LineFollowerLine = "line"
+	// LineFollowerLineLost event
+	LineFollowerLineLost = "line_lost"
 )
 
 // AnalogReader interface represents an Adaptor which has AnalogRead capabilities
@@ -50,6 +56,7 @@ type driver struct {
 	afterStart func() error
 	beforeHalt func() error
 	gobot.Commander
+	gobot.Labeler
 	mutex *sync.Mutex // e.g. used to prevent data race between cyclic and single shot write/read to values and scaler
 }
 
@@ -61,6 +68,7 @@ func newDriver(a interface{}, name string) *driver {
 		afterStart: func() error { return nil },
 		beforeHalt: func() error { return nil },
 		Commander:  gobot.NewCommander(),
+		Labeler:    gobot.NewLabeler(),
 		mutex:      &sync.Mutex{},
 	}
 
@@ -2,11 +2,18 @@ package aio
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"gobot.io/x/gobot/v2"
 )
 
+const (
+	sensorDefaultRateSmoothing    = 0.3
+	sensorDefaultADCResolution    = 10  // bits, e.g. Arduino Uno
+	sensorDefaultReferenceVoltage = 5.0 // volt
+)
+
 // sensorOptionApplier needs to be implemented by each configurable option type
 type sensorOptionApplier interface {
 	apply(cfg *sensorConfiguration)
@@ -14,8 +21,13 @@ type sensorOptionApplier interface {
 
 // sensorConfiguration contains all changeable attributes of the driver.
 type sensorConfiguration struct {
-	readInterval time.Duration
-	scale        func(input int) (value float64)
+	readInterval  time.Duration
+	scale         func(input int) (value float64)
+	rateSmoothing float64
+	rateThreshold float64
+	// values for conversion of the raw value to a voltage
+	adcResolution    int
+	referenceVoltage float64
 }
 
 // sensorReadIntervalOption is the type for applying another read interval to the configuration
@@ -26,6 +38,12 @@ type sensorScaleOption struct {
 	scaler func(input int) (value float64)
 }
 
+// sensorRateSmoothingOption is the type for applying another smoothing factor for the rate of change
+type sensorRateSmoothingOption float64
+
+// sensorRateThresholdOption is the type for applying a threshold for the rate event
+type sensorRateThresholdOption float64
+
 // AnalogSensorDriver represents an analog sensor
 type AnalogSensorDriver struct {
 	*driver
@@ -36,6 +54,15 @@ type AnalogSensorDriver struct {
 	lastRawValue int
 	lastValue    float64
 	analogRead   func() (int, float64, error)
+	// values already published by the cyclic reading or polling
+	publishedRawValue int
+	publishedValue    float64
+	// values for calculation of the rate of change
+	lastRateTime  time.Time
+	lastRateValue float64
+	rateInit      bool
+	rateValid     bool
+	rate          float64
 }
 
 // NewAnalogSensorDriver returns a new driver for analog sensors, given an AnalogReader and pin.
@@ -47,18 +74,30 @@ type AnalogSensorDriver struct {
 //	"WithName"
 //	"WithSensorCyclicRead"
 //	"WithSensorScaler"
+//	"WithSensorRateSmoothing"
+//	"WithSensorRateThreshold"
 //
 // Adds the following API Commands:
 //
-//	"Read"    - See AnalogDriverSensor.Read
-//	"ReadRaw" - See AnalogDriverSensor.ReadRaw
+//	"Read"         - See AnalogDriverSensor.Read
+//	"ReadRaw"      - See AnalogDriverSensor.ReadRaw
+//	"RateOfChange" - See AnalogDriverSensor.RateOfChange
 func NewAnalogSensorDriver(a AnalogReader, pin string, opts ...interface{}) *AnalogSensorDriver {
 	d := &AnalogSensorDriver{
-		driver:    newDriver(a, "AnalogSensor"),
-		sensorCfg: &sensorConfiguration{scale: func(input int) float64 { return float64(input) }},
-		pin:       pin,
-		Eventer:   gobot.NewEventer(), // needed early due to grove vibration sensor driver
+		driver: newDriver(a, "AnalogSensor"),
+		sensorCfg: &sensorConfiguration{
+			scale:            func(input int) float64 { return float64(input) },
+			rateSmoothing:    sensorDefaultRateSmoothing,
+			adcResolution:    sensorDefaultADCResolution,
+			referenceVoltage: sensorDefaultReferenceVoltage,
+		},
+		pin:     pin,
+		Eventer: gobot.NewEventer(), // needed early due to grove vibration sensor driver
 	}
+	d.AddEvent(Data)
+	d.AddEvent(Value)
+	d.AddEvent(Rate)
+	d.AddEvent(Error)
 	d.afterStart = d.initialize
 	d.beforeHalt = d.shutdown
 	d.analogRead = d.analogSensorRead
@@ -87,6 +126,10 @@ func NewAnalogSensorDriver(a AnalogReader, pin string, opts ...interface{}) *Ana
 		return map[string]interface{}{"val": val, "err": err}
 	})
 
+	d.AddCommand("RateOfChange", func(params map[string]interface{}) interface{} {
+		return map[string]interface{}{"val": d.RateOfChange()}
+	})
+
 	return d
 }
 
@@ -100,6 +143,19 @@ func WithSensorScaler(scaler func(input int) (value float64)) sensorOptionApplie
 	return sensorScaleOption{scaler: scaler}
 }
 
+// WithSensorRateSmoothing change the smoothing factor of the rate of change from default 0.3 to the given value. The
+// value needs to be in the range (0, 1], where 1 means no smoothing, otherwise the constructor panics. The rate is
+// smoothed by an exponential moving average to prevent the amplification of noise by the derivative.
+func WithSensorRateSmoothing(factor float64) sensorOptionApplier {
+	return sensorRateSmoothingOption(factor)
+}
+
+// WithSensorRateThreshold activates the "rate" event for the cyclic reading. The event is published with the current
+// rate of change, if the absolute value of the rate is equal or above the given threshold.
+func WithSensorRateThreshold(threshold float64) sensorOptionApplier {
+	return sensorRateThresholdOption(threshold)
+}
+
 // SetScaler substitute the default 1:1 return value function by a new scaling function
 // If the scaler is not changed after initialization, prefer to use [aio.WithSensorScaler] instead.
 func (a *AnalogSensorDriver) SetScaler(scaler func(int) float64) {
@@ -109,6 +165,50 @@ func (a *AnalogSensorDriver) SetScaler(scaler func(int) float64) {
 	WithSensorScaler(scaler).apply(a.sensorCfg)
 }
 
+// SetADCResolution sets the resolution in bits of the analog-digital converter, which is used for the conversion of
+// the raw value to a voltage, see Voltage(). The default is 10 bits.
+func (a *AnalogSensorDriver) SetADCResolution(bits int) error {
+	if bits < 1 || bits > 32 {
+		return fmt.Errorf("ADC resolution (%d) must be between 1 and 32 bits", bits)
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.sensorCfg.adcResolution = bits
+	return nil
+}
+
+// SetReferenceVoltage sets the reference voltage in volt of the analog-digital converter, which is used for the
+// conversion of the raw value to a voltage, see Voltage(). The default is 5V.
+func (a *AnalogSensorDriver) SetReferenceVoltage(v float64) error {
+	if v <= 0 {
+		return fmt.Errorf("reference voltage (%v) must be greater than zero", v)
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.sensorCfg.referenceVoltage = v
+	return nil
+}
+
+// Voltage returns the current reading from the sensor converted to volt, independent of the current scaler. The
+// conversion is done by the ADC resolution and the reference voltage, so the maximum raw value corresponds to the
+// reference voltage.
+func (a *AnalogSensorDriver) Voltage() (float64, error) {
+	rawValue, _, err := a.analogRead()
+	if err != nil {
+		return 0, err
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	maxRaw := math.Exp2(float64(a.sensorCfg.adcResolution)) - 1
+	return float64(rawValue) * a.sensorCfg.referenceVoltage / maxRaw, nil
+}
+
 // Pin returns the AnalogSensorDrivers pin
 func (a *AnalogSensorDriver) Pin() string { return a.pin }
 
@@ -132,6 +232,15 @@ func (a *AnalogSensorDriver) Value() float64 {
 	return a.lastValue
 }
 
+// RateOfChange returns the smoothed rate of change of the scaled value per second. At least two reads are needed for a
+// valid value, otherwise zero is returned.
+func (a *AnalogSensorDriver) RateOfChange() float64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.rate
+}
+
 // RawValue returns the last read raw value from the sensor
 func (a *AnalogSensorDriver) RawValue() int {
 	a.mutex.Lock()
@@ -140,22 +249,59 @@ func (a *AnalogSensorDriver) RawValue() int {
 	return a.lastRawValue
 }
 
-// initialize the AnalogSensorDriver and if the cyclic reading is active, reads the sensor at the given interval.
+// Poll reads the sensor once and publishes the events like the cyclic reading. This allows the polling of the sensor
+// by a [gobot.PollGroup], usually without the cyclic reading, see [aio.WithSensorCyclicRead].
+//
 // Emits the Events:
 //
 //	Data int - Event is emitted on change and represents the current raw reading from the sensor.
 //	Value float64 - Event is emitted on change and represents the current reading from the sensor.
+//	Rate float64 - Event is emitted if the threshold is reached, see [aio.WithSensorRateThreshold].
 //	Error error - Event is emitted on error reading from the sensor.
+func (a *AnalogSensorDriver) Poll() error {
+	rawValue, value, err := a.analogRead()
+	if err != nil {
+		a.Publish(a.Event(Error), err)
+		return err
+	}
+
+	a.mutex.Lock()
+	rawChanged := rawValue != a.publishedRawValue && rawValue != -1
+	if rawChanged {
+		a.publishedRawValue = rawValue
+	}
+	valueChanged := value != a.publishedValue && value != -1
+	if valueChanged {
+		a.publishedValue = value
+	}
+	a.mutex.Unlock()
+
+	if rawChanged {
+		a.Publish(a.Event(Data), rawValue)
+	}
+	if valueChanged {
+		a.Publish(a.Event(Value), value)
+	}
+	if a.sensorCfg.rateThreshold > 0 {
+		if rate := a.RateOfChange(); math.Abs(rate) >= a.sensorCfg.rateThreshold {
+			a.Publish(a.Event(Rate), rate)
+		}
+	}
+
+	return nil
+}
+
+// initialize the AnalogSensorDriver and if the cyclic reading is active, reads the sensor at the given interval.
+// The events are the same like for [AnalogSensorDriver.Poll].
 func (a *AnalogSensorDriver) initialize() error {
+	a.publishedRawValue = 0
+	a.publishedValue = 0
+
 	if a.sensorCfg.readInterval == 0 {
 		// cyclic reading deactivated
 		return nil
 	}
 
-	a.AddEvent(Data)
-	a.AddEvent(Value)
-	a.AddEvent(Error)
-
 	// A small buffer is needed to prevent mutex-channel-deadlock between Halt() and analogRead().
 	// This can happen, if the shutdown is in progress (mutex passed) and the go routine is calling
 	// the analogRead() in between, before the halt can be evaluated by the select statement.
@@ -165,27 +311,13 @@ func (a *AnalogSensorDriver) initialize() error {
 	// statement.
 	a.halt = make(chan struct{}, 1)
 
-	oldRawValue := 0
-	oldValue := 0.0
 	go func() {
 		timer := time.NewTimer(a.sensorCfg.readInterval)
 		timer.Stop()
 
 		for {
 			// please note, that this ensures the first read is done immediately, but has drawbacks, see notes above
-			rawValue, value, err := a.analogRead()
-			if err != nil {
-				a.Publish(a.Event(Error), err)
-			} else {
-				if rawValue != oldRawValue && rawValue != -1 {
-					a.Publish(a.Event(Data), rawValue)
-					oldRawValue = rawValue
-				}
-				if value != oldValue && value != -1 {
-					a.Publish(a.Event(Value), value)
-					oldValue = value
-				}
-			}
+			_ = a.Poll() // the error is already published
 
 			timer.Reset(a.sensorCfg.readInterval) // ensure that after each read is a wait, independent of duration of read
 			select {
@@ -227,9 +359,36 @@ func (a *AnalogSensorDriver) analogSensorRead() (int, float64, error) {
 
 	a.lastRawValue = rawValue
 	a.lastValue = a.sensorCfg.scale(a.lastRawValue)
+	a.updateRate(a.lastValue, time.Now())
 	return a.lastRawValue, a.lastValue, nil
 }
 
+// updateRate calculates the smoothed rate of change by the given value and timestamp. The mutex needs to be locked by
+// the caller.
+func (a *AnalogSensorDriver) updateRate(value float64, t time.Time) {
+	if !a.rateInit {
+		a.lastRateTime = t
+		a.lastRateValue = value
+		a.rateInit = true
+		return
+	}
+
+	dt := t.Sub(a.lastRateTime).Seconds()
+	if dt <= 0 {
+		return
+	}
+
+	rate := (value - a.lastRateValue) / dt
+	if a.rateValid {
+		rate = a.sensorCfg.rateSmoothing*rate + (1-a.sensorCfg.rateSmoothing)*a.rate
+	}
+
+	a.rate = rate
+	a.rateValid = true
+	a.lastRateTime = t
+	a.lastRateValue = value
+}
+
 func (o sensorReadIntervalOption) String() string {
 	return "read interval option for analog sensors"
 }
@@ -238,6 +397,14 @@ func (o sensorScaleOption) String() string {
 	return "scaler option for analog sensors"
 }
 
+func (o sensorRateSmoothingOption) String() string {
+	return "rate smoothing option for analog sensors"
+}
+
+func (o sensorRateThresholdOption) String() string {
+	return "rate threshold option for analog sensors"
+}
+
 func (o sensorReadIntervalOption) apply(cfg *sensorConfiguration) {
 	cfg.readInterval = time.Duration(o)
 }
@@ -246,6 +413,17 @@ func (o sensorScaleOption) apply(cfg *sensorConfiguration) {
 	cfg.scale = o.scaler
 }
 
+func (o sensorRateSmoothingOption) apply(cfg *sensorConfiguration) {
+	if o <= 0 || o > 1 {
+		panic(fmt.Sprintf("the smoothing factor of the rate (%v) needs to be in the range (0, 1]", float64(o)))
+	}
+	cfg.rateSmoothing = float64(o)
+}
+
+func (o sensorRateThresholdOption) apply(cfg *sensorConfiguration) {
+	cfg.rateThreshold = float64(o)
+}
+
 // AnalogSensorLinearScaler creates a linear scaler function from the given values.
 func AnalogSensorLinearScaler(fromMin, fromMax int, toMin, toMax float64) func(int) float64 {
 	m := (toMax - toMin) / float64(fromMax-fromMin)
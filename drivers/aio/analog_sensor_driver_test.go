@@ -14,7 +14,10 @@ import (
 	"gobot.io/x/gobot/v2"
 )
 
-var _ gobot.Driver = (*AnalogSensorDriver)(nil)
+var (
+	_ gobot.Driver = (*AnalogSensorDriver)(nil)
+	_ gobot.Poller = (*AnalogSensorDriver)(nil)
+)
 
 func TestNewAnalogSensorDriver(t *testing.T) {
 	// arrange
@@ -40,6 +43,8 @@ func TestNewAnalogSensorDriver(t *testing.T) {
 	require.NotNil(t, d.sensorCfg)
 	assert.Equal(t, time.Duration(0), d.sensorCfg.readInterval)
 	assert.NotNil(t, d.sensorCfg.scale)
+	assert.InDelta(t, 0.3, d.sensorCfg.rateSmoothing, 0.0)
+	assert.InDelta(t, 0.0, d.sensorCfg.rateThreshold, 0.0)
 }
 
 func TestNewAnalogSensorDriver_options(t *testing.T) {
@@ -72,6 +77,118 @@ func TestAnalogSensor_WithSensorScaler(t *testing.T) {
 	assert.InDelta(t, 1.5, cfg.scale(3), 0.0)
 }
 
+func TestAnalogSensor_WithSensorRateOptions(t *testing.T) {
+	// arrange
+	cfg := sensorConfiguration{}
+	// act & assert
+	WithSensorRateSmoothing(0.5).apply(&cfg)
+	assert.InDelta(t, 0.5, cfg.rateSmoothing, 0.0)
+	WithSensorRateSmoothing(1).apply(&cfg)
+	assert.InDelta(t, 1.0, cfg.rateSmoothing, 0.0)
+	assert.PanicsWithValue(t, "the smoothing factor of the rate (1.5) needs to be in the range (0, 1]",
+		func() { WithSensorRateSmoothing(1.5).apply(&cfg) })
+	assert.PanicsWithValue(t, "the smoothing factor of the rate (0) needs to be in the range (0, 1]",
+		func() { NewAnalogSensorDriver(newAioTestAdaptor(), "1", WithSensorRateSmoothing(0)) })
+	assert.PanicsWithValue(t, "the smoothing factor of the rate (-1) needs to be in the range (0, 1]",
+		func() { WithSensorRateSmoothing(-1).apply(&cfg) })
+	assert.InDelta(t, 1.0, cfg.rateSmoothing, 0.0)
+	WithSensorRateThreshold(2.5).apply(&cfg)
+	assert.InDelta(t, 2.5, cfg.rateThreshold, 0.0)
+}
+
+func TestAnalogSensor_updateRate(t *testing.T) {
+	tests := map[string]struct {
+		smoothing float64
+		values    []float64
+		want      float64
+	}{
+		"no_value": {
+			smoothing: 1,
+			want:      0,
+		},
+		"one_value": {
+			smoothing: 1,
+			values:    []float64{5},
+			want:      0,
+		},
+		"ramp_up_no_smoothing": {
+			smoothing: 1,
+			values:    []float64{0, 1, 2, 3, 4},
+			want:      10, // 1 per 100 ms
+		},
+		"ramp_down_no_smoothing": {
+			smoothing: 1,
+			values:    []float64{100, 98, 96, 94},
+			want:      -20,
+		},
+		"ramp_up_smoothed": {
+			smoothing: 0.3,
+			values:    []float64{0, 1, 2, 3, 4},
+			want:      10, // the rate is constant, so smoothing has no effect
+		},
+		"ramp_with_noise_no_smoothing": {
+			smoothing: 1,
+			values:    []float64{0, 1.5, 2, 3.5, 4},
+			want:      5,
+		},
+		"ramp_with_noise_smoothed": {
+			smoothing: 0.5,
+			values:    []float64{0, 1.5, 2, 3.5, 4},
+			// 15, 0.5*5+0.5*15=10, 0.5*15+0.5*10=12.5, 0.5*5+0.5*12.5=8.75
+			want: 8.75,
+		},
+		"ramp_stopped_smoothed": {
+			smoothing: 0.5,
+			values:    []float64{0, 1, 2, 2, 2},
+			// 10, 10, 0.5*0+0.5*10=5, 2.5
+			want: 2.5,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewAnalogSensorDriver(newAioTestAdaptor(), "1", WithSensorRateSmoothing(tc.smoothing))
+			start := time.Now()
+			// act
+			for i, v := range tc.values {
+				d.updateRate(v, start.Add(time.Duration(i)*100*time.Millisecond))
+			}
+			// assert
+			assert.InDelta(t, tc.want, d.RateOfChange(), 1e-9)
+		})
+	}
+}
+
+func TestAnalogSensor_WithSensorRateThreshold(t *testing.T) {
+	// arrange: a ramp of 50 per read with a read interval of 10 ms, so the rate is ~5000 per second
+	a := newAioTestAdaptor()
+	var mtx sync.Mutex
+	var readReturn int
+	a.analogReadFunc = func() (int, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		readReturn += 50
+		return readReturn, nil
+	}
+	d := NewAnalogSensorDriver(a, "1", WithSensorCyclicRead(10*time.Millisecond), WithSensorRateThreshold(1000))
+	rateChan := make(chan float64, 1)
+	// act
+	require.NoError(t, d.Start())
+	_ = d.Once(d.Event(Rate), func(data interface{}) {
+		rateChan <- data.(float64)
+	})
+	// assert
+	select {
+	case rate := <-rateChan:
+		assert.GreaterOrEqual(t, rate, 1000.0)
+		assert.Less(t, rate, 5100.0) // the interval can only be longer in tests
+	case <-time.After(time.Second):
+		t.Errorf("AnalogSensor Event \"Rate\" was not published")
+	}
+	require.NoError(t, d.Halt())
+	assert.Greater(t, d.Command("RateOfChange")(nil).(map[string]interface{})["val"].(float64), 0.0)
+}
+
 func TestAnalogSensorDriverReadRaw(t *testing.T) {
 	tests := map[string]struct {
 		simulateReadErr bool
@@ -113,6 +230,66 @@ func TestAnalogSensorDriverReadRaw_AnalogWriteNotSupported(t *testing.T) {
 	assert.Equal(t, 0, got)
 }
 
+func TestAnalogSensorVoltage(t *testing.T) {
+	tests := map[string]struct {
+		bits    int
+		vRef    float64
+		input   int
+		want    float64
+		wantErr string
+	}{
+		"default_5V_10bit_max":  {input: 1023, want: 5},
+		"10bit_3.3V_zero":       {bits: 10, vRef: 3.3, input: 0, want: 0},
+		"10bit_3.3V_half":       {bits: 10, vRef: 3.3, input: 512, want: 1.6516129032258065},
+		"10bit_3.3V_max":        {bits: 10, vRef: 3.3, input: 1023, want: 3.3},
+		"12bit_3.3V_quarter":    {bits: 12, vRef: 3.3, input: 1024, want: 0.8252014652014652},
+		"12bit_3.3V_half":       {bits: 12, vRef: 3.3, input: 2048, want: 1.6504029304029304},
+		"12bit_3.3V_max":        {bits: 12, vRef: 3.3, input: 4095, want: 3.3},
+		"error_resolution":      {bits: 33, vRef: 3.3, wantErr: "ADC resolution (33) must be between 1 and 32 bits"},
+		"error_reference_volts": {bits: 12, vRef: -1, wantErr: "reference voltage (-1) must be greater than zero"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newAioTestAdaptor()
+			d := NewAnalogSensorDriver(a, "7", WithSensorScaler(AnalogSensorLinearScaler(0, 1023, 0, 100)))
+			input := tc.input
+			a.analogReadFunc = func() (int, error) {
+				return input, nil
+			}
+			// act
+			var err error
+			if tc.bits != 0 {
+				err = d.SetADCResolution(tc.bits)
+			}
+			if err == nil && tc.vRef != 0 {
+				err = d.SetReferenceVoltage(tc.vRef)
+			}
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			got, err := d.Voltage()
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, got, 1e-12)
+		})
+	}
+}
+
+func TestAnalogSensorVoltage_error(t *testing.T) {
+	// arrange
+	a := newAioTestAdaptor()
+	d := NewAnalogSensorDriver(a, "7")
+	a.simulateReadError = true
+	// act
+	got, err := d.Voltage()
+	// assert
+	require.EqualError(t, err, "read error")
+	assert.InDelta(t, 0.0, got, 0.0)
+}
+
 func TestAnalogSensorRead_SetScaler(t *testing.T) {
 	// the input scales per default from 0...255
 	tests := map[string]struct {
@@ -231,6 +408,51 @@ func TestAnalogSensor_WithSensorCyclicRead(t *testing.T) {
 	}
 }
 
+func TestAnalogSensorPoll_byPollGroup(t *testing.T) {
+	// arrange: the sensor is polled by the group instead of the cyclic reading
+	a := newAioTestAdaptor()
+	reads := []int{100, 100, -1, 200}
+	a.analogReadFunc = func() (int, error) {
+		val := reads[0]
+		reads = reads[1:]
+		if val < 0 {
+			return 0, fmt.Errorf("analog read error")
+		}
+		return val, nil
+	}
+	d := NewAnalogSensorDriver(a, "1", WithSensorScaler(func(input int) float64 { return float64(input) / 10 }))
+	require.NoError(t, d.Start())
+	events := d.Subscribe()
+	g := gobot.NewPollGroup(time.Hour)
+	g.Add(d)
+	var got []string
+	next := func() {
+		select {
+		case evt := <-events:
+			got = append(got, fmt.Sprintf("%s=%v", evt.Name, evt.Data))
+		case <-time.After(time.Second):
+			t.Errorf("AnalogSensor event was not published")
+		}
+	}
+	// act & assert: the events are the same like for the cyclic reading
+	assert.Equal(t, 0, g.PollAll())
+	next()
+	next()
+	assert.Equal(t, 0, g.PollAll()) // unchanged value, no event
+	assert.Equal(t, 1, g.PollAll())
+	next()
+	assert.Equal(t, 0, g.PollAll())
+	next()
+	next()
+	assert.Equal(t, []string{"data=100", "value=10", "error=analog read error", "data=200", "value=20"}, got)
+	select {
+	case evt := <-events:
+		t.Errorf("unexpected event %s", evt.Name)
+	case <-time.After(10 * time.Millisecond):
+	}
+	require.NoError(t, d.Halt())
+}
+
 func TestAnalogSensorHalt_WithSensorCyclicRead(t *testing.T) {
 	// arrange
 	d := NewAnalogSensorDriver(newAioTestAdaptor(), "1", WithSensorCyclicRead(10*time.Millisecond))
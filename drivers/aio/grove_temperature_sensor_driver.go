@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 // GroveTemperatureSensorDriver represents a temperature sensor
@@ -48,3 +49,9 @@ func NewGroveTemperatureSensorDriver(a AnalogReader, pin string, opts ...interfa
 func (t *TemperatureSensorDriver) Temperature() float64 {
 	return t.Value()
 }
+
+// TemperatureMeasurement returns the last read temperature from the sensor in °C together with its unit. The scaler
+// needs to convert to °C, which is true for all scalers of the driver.
+func (t *TemperatureSensorDriver) TemperatureMeasurement() measurement.Measurement {
+	return measurement.New(t.Temperature(), measurement.Celsius)
+}
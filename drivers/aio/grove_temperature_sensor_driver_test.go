@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 var _ gobot.Driver = (*GroveTemperatureSensorDriver)(nil)
@@ -121,3 +122,21 @@ func TestGroveTemperatureSensor_publishesTemperatureInCelsius(t *testing.T) {
 
 	assert.InDelta(t, 31.61532462352477, d.Temperature(), 0.0)
 }
+
+func TestTemperatureSensorTemperatureMeasurement(t *testing.T) {
+	// arrange
+	a := newAioTestAdaptor()
+	d := NewGroveTemperatureSensorDriver(a, "1")
+	a.analogReadFunc = func() (int, error) {
+		return 585, nil
+	}
+	_, err := d.Read()
+	require.NoError(t, err)
+	// act
+	got := d.TemperatureMeasurement()
+	// assert
+	assert.Equal(t, measurement.New(31.61532462352477, measurement.Celsius), got)
+	fahrenheit, err := got.Fahrenheit()
+	require.NoError(t, err)
+	assert.InDelta(t, 88.90758432234459, fahrenheit, 1e-9)
+}
@@ -0,0 +1,393 @@
+package aio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const lineFollowerDefaultThreshold = 512 // half of a 10 bit ADC, e.g. Arduino Uno
+
+// lineFollowerOptionApplier needs to be implemented by each configurable option type
+type lineFollowerOptionApplier interface {
+	apply(cfg *lineFollowerConfiguration)
+}
+
+// lineFollowerConfiguration contains all changeable attributes of the driver.
+type lineFollowerConfiguration struct {
+	readInterval time.Duration
+	digital      bool
+	lightLine    bool
+	threshold    int
+}
+
+// lineFollowerReadIntervalOption is the type for applying another read interval to the configuration
+type lineFollowerReadIntervalOption time.Duration
+
+// lineFollowerDigitalOption is the type for applying the digital reading of the sensors to the configuration
+type lineFollowerDigitalOption bool
+
+// lineFollowerLightLineOption is the type for applying a light line on a dark ground to the configuration
+type lineFollowerLightLineOption bool
+
+// lineFollowerThresholdOption is the type for applying another threshold of all sensors to the configuration
+type lineFollowerThresholdOption int
+
+// digitalReader interface represents an Adaptor which has DigitalRead capabilities, the same like
+// gpio.DigitalReader, which can not be used here to prevent an import cycle
+type digitalReader interface {
+	DigitalRead(pin string) (val int, err error)
+}
+
+// LineFollowerDriver represents an array of reflectance sensors, e.g. IR sensors, of a line-following robot. The
+// sensors needs to be given from left to right. The line position error is the weighted mean of the sensor
+// positions, which are above the line. It is zero, if the line is centered under the array, negative if the line is
+// on the left side and positive if the line is on the right side. The unit is the distance between two sensors.
+type LineFollowerDriver struct {
+	*driver
+	lineCfg *lineFollowerConfiguration
+	gobot.Eventer
+	pins       []string
+	thresholds []int
+	calMin     []int
+	calMax     []int
+	lastError  float64
+	lost       bool
+	halt       chan struct{}
+}
+
+// NewLineFollowerDriver returns a new driver for an array of reflectance sensors, given an AnalogReader (or a
+// DigitalReader, see [aio.WithLineFollowerDigital]) and the pins from left to right. By default, the reading of a
+// sensor is greater for a dark line than for the light ground and the threshold is 512 for all sensors.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithLineFollowerCyclicRead"
+//	"WithLineFollowerDigital"
+//	"WithLineFollowerLightLine"
+//	"WithLineFollowerThreshold"
+//
+// Adds the following API Commands:
+//
+//	"ReadLine"  - See LineFollowerDriver.ReadLine
+//	"Calibrate" - See LineFollowerDriver.Calibrate
+func NewLineFollowerDriver(a gobot.Connection, pins []string, opts ...interface{}) *LineFollowerDriver {
+	d := &LineFollowerDriver{
+		driver:  newDriver(a, "LineFollower"),
+		lineCfg: &lineFollowerConfiguration{threshold: lineFollowerDefaultThreshold},
+		Eventer: gobot.NewEventer(),
+		pins:    pins,
+		lost:    true,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case lineFollowerOptionApplier:
+			o.apply(d.lineCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	if len(pins) == 0 {
+		panic(fmt.Sprintf("at least one sensor pin is needed for '%s'", d.driverCfg.name))
+	}
+
+	d.thresholds = make([]int, len(pins))
+	for i := range d.thresholds {
+		d.thresholds[i] = d.lineCfg.threshold
+	}
+
+	d.AddEvent(LineFollowerLine)
+	d.AddEvent(LineFollowerLineLost)
+	d.AddEvent(Error)
+
+	d.AddCommand("ReadLine", func(params map[string]interface{}) interface{} {
+		val, found, err := d.ReadLine()
+		return map[string]interface{}{"val": val, "found": found, "err": err}
+	})
+	d.AddCommand("Calibrate", func(params map[string]interface{}) interface{} {
+		return d.Calibrate()
+	})
+
+	return d
+}
+
+// WithLineFollowerCyclicRead add a asynchronous cyclic reading functionality to the driver with the given read
+// interval. The line events are published after each read.
+func WithLineFollowerCyclicRead(interval time.Duration) lineFollowerOptionApplier {
+	return lineFollowerReadIntervalOption(interval)
+}
+
+// WithLineFollowerDigital reads the sensors by DigitalRead() instead of AnalogRead(), e.g. for sensor modules with
+// a comparator. A sensor is above the line, if it reads 1 (or 0 for a light line). The thresholds are not used.
+func WithLineFollowerDigital() lineFollowerOptionApplier {
+	return lineFollowerDigitalOption(true)
+}
+
+// WithLineFollowerLightLine is used for a light line on a dark ground. The reading of a sensor above the line is
+// below the threshold in this case.
+func WithLineFollowerLightLine() lineFollowerOptionApplier {
+	return lineFollowerLightLineOption(true)
+}
+
+// WithLineFollowerThreshold change the threshold of all sensors from default 512 to the given value. Different
+// thresholds for each sensor can be set by SetThresholds() or determined by Calibrate().
+func WithLineFollowerThreshold(threshold int) lineFollowerOptionApplier {
+	return lineFollowerThresholdOption(threshold)
+}
+
+// Pins returns the pins of the sensors from left to right.
+func (d *LineFollowerDriver) Pins() []string { return d.pins }
+
+// Thresholds returns the current threshold of each sensor.
+func (d *LineFollowerDriver) Thresholds() []int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]int(nil), d.thresholds...)
+}
+
+// SetThresholds sets the threshold of each sensor, the count needs to match the count of sensors.
+func (d *LineFollowerDriver) SetThresholds(thresholds []int) error {
+	if len(thresholds) != len(d.pins) {
+		return fmt.Errorf("count of thresholds (%d) does not match the count of sensors (%d) for '%s'",
+			len(thresholds), len(d.pins), d.driverCfg.name)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.thresholds = append([]int(nil), thresholds...)
+
+	return nil
+}
+
+// Calibrate reads all sensors once and records the min. and max. value of each sensor since the last reset. The
+// threshold of each sensor is set to the middle between both values. Call this repeatedly, while the sensor array is
+// moved over the line and the ground.
+func (d *LineFollowerDriver) Calibrate() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	values, err := d.readSensors()
+	if err != nil {
+		return err
+	}
+
+	if d.calMin == nil {
+		d.calMin = append([]int(nil), values...)
+		d.calMax = append([]int(nil), values...)
+	}
+
+	for i, v := range values {
+		if v < d.calMin[i] {
+			d.calMin[i] = v
+		}
+		if v > d.calMax[i] {
+			d.calMax[i] = v
+		}
+		d.thresholds[i] = (d.calMin[i] + d.calMax[i]) / 2
+	}
+
+	return nil
+}
+
+// ResetCalibration discards the recorded values of Calibrate(). The thresholds are not changed.
+func (d *LineFollowerDriver) ResetCalibration() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.calMin = nil
+	d.calMax = nil
+}
+
+// ReadLine reads all sensors and returns the line position error and true, if the line was detected. If no sensor is
+// above the line, the last error is returned with false, so the robot can turn back to the side, where the line was
+// lost.
+func (d *LineFollowerDriver) ReadLine() (float64, bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	values, err := d.readSensors()
+	if err != nil {
+		return 0, false, err
+	}
+
+	thresholds := d.thresholds
+	if d.lineCfg.digital {
+		// a value of 1 is above the threshold of a dark line and 0 is below the threshold of a light line
+		thresholds = make([]int, len(values))
+		if d.lineCfg.lightLine {
+			for i := range thresholds {
+				thresholds[i] = 1
+			}
+		}
+	}
+
+	lineError, found := lineFollowerError(values, thresholds, d.lineCfg.lightLine)
+	if found {
+		d.lastError = lineError
+	}
+	d.lost = !found
+
+	return d.lastError, found, nil
+}
+
+// LineLost returns true, if the line was not detected by the last read.
+func (d *LineFollowerDriver) LineLost() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.lost
+}
+
+// initialize the LineFollowerDriver and if the cyclic reading is active, reads the sensors at the given interval.
+// Emits the Events:
+//
+//	"line" float64 - Event is emitted after each read with detected line and represents the line position error.
+//	"line_lost" float64 - Event is emitted once, if the line was lost and represents the last line position error.
+//	Error error - Event is emitted on error reading from the sensors.
+func (d *LineFollowerDriver) initialize() error {
+	if d.lineCfg.readInterval == 0 {
+		// cyclic reading deactivated
+		return nil
+	}
+
+	d.halt = make(chan struct{})
+	go d.cyclicRead(d.halt)
+
+	return nil
+}
+
+// shutdown stops the cyclic reading, if running.
+func (d *LineFollowerDriver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+	return nil
+}
+
+func (d *LineFollowerDriver) cyclicRead(halt chan struct{}) {
+	ticker := time.NewTicker(d.lineCfg.readInterval)
+	defer ticker.Stop()
+
+	wasLost := false
+	for {
+		select {
+		case <-halt:
+			return
+		case <-ticker.C:
+			lineError, found, err := d.ReadLine()
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+				continue
+			}
+			if found {
+				d.Publish(d.Event(LineFollowerLine), lineError)
+			} else if !wasLost {
+				d.Publish(d.Event(LineFollowerLineLost), lineError)
+			}
+			wasLost = !found
+		}
+	}
+}
+
+// readSensors reads the values of all sensors. The mutex needs to be locked by the caller.
+func (d *LineFollowerDriver) readSensors() ([]int, error) {
+	values := make([]int, len(d.pins))
+
+	if d.lineCfg.digital {
+		reader, ok := d.connection.(digitalReader)
+		if !ok {
+			return nil, fmt.Errorf("DigitalRead is not supported by the platform '%s'", d.Connection().Name())
+		}
+		for i, pin := range d.pins {
+			val, err := reader.DigitalRead(pin)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = val
+		}
+
+		return values, nil
+	}
+
+	reader, ok := d.connection.(AnalogReader)
+	if !ok {
+		return nil, fmt.Errorf("AnalogRead is not supported by the platform '%s'", d.Connection().Name())
+	}
+	for i, pin := range d.pins {
+		val, err := reader.AnalogRead(pin)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = val
+	}
+
+	return values, nil
+}
+
+// lineFollowerError calculates the weighted mean of the sensor positions relative to the center of the array. The
+// weight of each sensor is the distance of the value to its threshold, only sensors above the line are taken into
+// account. Returns false, if no sensor is above the line.
+func lineFollowerError(values []int, thresholds []int, lightLine bool) (float64, bool) {
+	center := float64(len(values)-1) / 2
+	var sum, weights float64
+	for i, v := range values {
+		weight := v - thresholds[i]
+		if lightLine {
+			weight = -weight
+		}
+		if weight <= 0 {
+			continue
+		}
+		sum += float64(weight) * (float64(i) - center)
+		weights += float64(weight)
+	}
+
+	if weights == 0 {
+		return 0, false
+	}
+
+	return sum / weights, true
+}
+
+func (o lineFollowerReadIntervalOption) String() string {
+	return "read interval option for line follower"
+}
+
+func (o lineFollowerDigitalOption) String() string {
+	return "digital option for line follower"
+}
+
+func (o lineFollowerLightLineOption) String() string {
+	return "light line option for line follower"
+}
+
+func (o lineFollowerThresholdOption) String() string {
+	return "threshold option for line follower"
+}
+
+func (o lineFollowerReadIntervalOption) apply(cfg *lineFollowerConfiguration) {
+	cfg.readInterval = time.Duration(o)
+}
+
+func (o lineFollowerDigitalOption) apply(cfg *lineFollowerConfiguration) {
+	cfg.digital = bool(o)
+}
+
+func (o lineFollowerLightLineOption) apply(cfg *lineFollowerConfiguration) {
+	cfg.lightLine = bool(o)
+}
+
+func (o lineFollowerThresholdOption) apply(cfg *lineFollowerConfiguration) {
+	cfg.threshold = int(o)
+}
@@ -0,0 +1,299 @@
+package aio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*LineFollowerDriver)(nil)
+
+// lineFollowerTestAdaptor returns the value of each pin for analog and digital reads
+type lineFollowerTestAdaptor struct {
+	aioTestBareAdaptor
+	mtx     sync.Mutex
+	values  map[string]int
+	readErr error
+}
+
+func newLineFollowerTestAdaptor(values ...int) *lineFollowerTestAdaptor {
+	a := &lineFollowerTestAdaptor{}
+	a.set(values...)
+	return a
+}
+
+// set the values of the pins "0", "1", ...
+func (a *lineFollowerTestAdaptor) set(values ...int) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.values = make(map[string]int)
+	for i, v := range values {
+		a.values[string(rune('0'+i))] = v
+	}
+}
+
+func (a *lineFollowerTestAdaptor) AnalogRead(pin string) (int, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	return a.values[pin], a.readErr
+}
+
+func (a *lineFollowerTestAdaptor) DigitalRead(pin string) (int, error) {
+	return a.AnalogRead(pin)
+}
+
+var lineFollowerTestPins = []string{"0", "1", "2", "3", "4"}
+
+func TestNewLineFollowerDriver(t *testing.T) {
+	// arrange
+	a := newLineFollowerTestAdaptor()
+	// act
+	d := NewLineFollowerDriver(a, lineFollowerTestPins)
+	// assert
+	assert.IsType(t, &LineFollowerDriver{}, d)
+	// assert: driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "LineFollower"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: driver specific attributes
+	assert.NotNil(t, d.Eventer)
+	assert.Equal(t, lineFollowerTestPins, d.Pins())
+	assert.Equal(t, []int{512, 512, 512, 512, 512}, d.Thresholds())
+	assert.True(t, d.LineLost())
+}
+
+func TestNewLineFollowerDriver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const myName = "line"
+	panicFunc := func() {
+		NewLineFollowerDriver(newLineFollowerTestAdaptor(), lineFollowerTestPins, WithName("crazy"),
+			WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewLineFollowerDriver(newLineFollowerTestAdaptor(), lineFollowerTestPins, WithName(myName),
+		WithLineFollowerCyclicRead(time.Second), WithLineFollowerDigital(), WithLineFollowerLightLine(),
+		WithLineFollowerThreshold(300))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t,
+		&lineFollowerConfiguration{readInterval: time.Second, digital: true, lightLine: true, threshold: 300}, d.lineCfg)
+	assert.Equal(t, []int{300, 300, 300, 300, 300}, d.Thresholds())
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+	assert.PanicsWithValue(t, "at least one sensor pin is needed for 'line'", func() {
+		NewLineFollowerDriver(newLineFollowerTestAdaptor(), nil, WithName(myName))
+	})
+}
+
+func TestLineFollowerReadLine(t *testing.T) {
+	tests := map[string]struct {
+		opts      []interface{}
+		values    []int
+		wantError float64
+		wantFound bool
+	}{
+		"centered": {
+			values:    []int{100, 100, 900, 100, 100},
+			wantError: 0,
+			wantFound: true,
+		},
+		"left_edge": {
+			values:    []int{900, 100, 100, 100, 100},
+			wantError: -2,
+			wantFound: true,
+		},
+		"right_edge": {
+			values:    []int{100, 100, 100, 100, 900},
+			wantError: 2,
+			wantFound: true,
+		},
+		"between_sensors_weighted": {
+			// weights 388 and 88 at the positions 0 and 1
+			values:    []int{100, 100, 900, 600, 100},
+			wantError: 88.0 / 476.0,
+			wantFound: true,
+		},
+		"between_two_equal_sensors": {
+			values:    []int{100, 800, 800, 100, 100},
+			wantError: -0.5,
+			wantFound: true,
+		},
+		"lost": {
+			values:    []int{100, 100, 100, 100, 100},
+			wantError: 0,
+			wantFound: false,
+		},
+		"light_line": {
+			opts:      []interface{}{WithLineFollowerLightLine()},
+			values:    []int{900, 900, 900, 100, 900},
+			wantError: 1,
+			wantFound: true,
+		},
+		"digital": {
+			opts:      []interface{}{WithLineFollowerDigital()},
+			values:    []int{0, 0, 0, 1, 1},
+			wantError: 1.5,
+			wantFound: true,
+		},
+		"digital_light_line": {
+			opts:      []interface{}{WithLineFollowerDigital(), WithLineFollowerLightLine()},
+			values:    []int{0, 1, 1, 1, 1},
+			wantError: -2,
+			wantFound: true,
+		},
+		"digital_lost": {
+			opts:      []interface{}{WithLineFollowerDigital()},
+			values:    []int{0, 0, 0, 0, 0},
+			wantError: 0,
+			wantFound: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newLineFollowerTestAdaptor(tc.values...)
+			d := NewLineFollowerDriver(a, lineFollowerTestPins, tc.opts...)
+			// act
+			got, found, err := d.ReadLine()
+			// assert
+			require.NoError(t, err)
+			assert.InDelta(t, tc.wantError, got, 0.0001)
+			assert.Equal(t, tc.wantFound, found)
+			assert.Equal(t, !tc.wantFound, d.LineLost())
+		})
+	}
+}
+
+func TestLineFollowerReadLine_keepLastErrorOnLost(t *testing.T) {
+	// arrange
+	a := newLineFollowerTestAdaptor(100, 100, 100, 900, 100)
+	d := NewLineFollowerDriver(a, lineFollowerTestPins)
+	got, found, err := d.ReadLine()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.InDelta(t, 1.0, got, 0.0)
+	a.set(100, 100, 100, 100, 100)
+	// act
+	got, found, err = d.ReadLine()
+	// assert
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.InDelta(t, 1.0, got, 0.0)
+}
+
+func TestLineFollowerReadLine_error(t *testing.T) {
+	tests := map[string]struct {
+		adaptor gobot.Connection
+		opts    []interface{}
+		wantErr string
+	}{
+		"read_error": {
+			adaptor: &lineFollowerTestAdaptor{readErr: errors.New("read error")},
+			wantErr: "read error",
+		},
+		"analog_unsupported": {
+			adaptor: &aioTestBareAdaptor{},
+			wantErr: "AnalogRead is not supported by the platform 'bare'",
+		},
+		"digital_unsupported": {
+			adaptor: &aioTestBareAdaptor{},
+			opts:    []interface{}{WithLineFollowerDigital()},
+			wantErr: "DigitalRead is not supported by the platform 'bare'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewLineFollowerDriver(tc.adaptor, lineFollowerTestPins, tc.opts...)
+			// act
+			_, _, err := d.ReadLine()
+			// assert
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestLineFollowerSetThresholds(t *testing.T) {
+	// arrange
+	a := newLineFollowerTestAdaptor(100, 100, 300, 100, 100)
+	d := NewLineFollowerDriver(a, lineFollowerTestPins, WithName("line"))
+	// act & assert
+	require.EqualError(t, d.SetThresholds([]int{1, 2}),
+		"count of thresholds (2) does not match the count of sensors (5) for 'line'")
+	require.NoError(t, d.SetThresholds([]int{200, 200, 200, 200, 200}))
+	assert.Equal(t, []int{200, 200, 200, 200, 200}, d.Thresholds())
+	got, found, err := d.ReadLine()
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.InDelta(t, 0.0, got, 0.0)
+}
+
+func TestLineFollowerCalibrate(t *testing.T) {
+	// arrange
+	a := newLineFollowerTestAdaptor(100, 120, 80, 110, 90)
+	d := NewLineFollowerDriver(a, lineFollowerTestPins)
+	// act
+	require.NoError(t, d.Calibrate())
+	a.set(700, 800, 900, 600, 500)
+	require.NoError(t, d.Calibrate())
+	a.set(300, 300, 300, 300, 300) // within the range, so no change
+	require.NoError(t, d.Calibrate())
+	// assert
+	assert.Equal(t, []int{400, 460, 490, 355, 295}, d.Thresholds())
+	// act & assert: after reset the calibration starts from scratch
+	d.ResetCalibration()
+	a.set(0, 0, 0, 0, 0)
+	require.NoError(t, d.Calibrate())
+	assert.Equal(t, []int{0, 0, 0, 0, 0}, d.Thresholds())
+}
+
+func TestLineFollowerCyclicRead(t *testing.T) {
+	// arrange
+	a := newLineFollowerTestAdaptor(100, 900, 100, 100, 100)
+	d := NewLineFollowerDriver(a, lineFollowerTestPins, WithLineFollowerCyclicRead(time.Millisecond))
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	// act
+	require.NoError(t, d.Start())
+	defer func() { _ = d.Halt() }()
+	// assert: line event with error
+	nextEvent := func() *gobot.Event {
+		select {
+		case evt := <-events:
+			return evt
+		case <-time.After(time.Second):
+			require.Fail(t, "event was not published")
+		}
+		return &gobot.Event{}
+	}
+	evt := nextEvent()
+	assert.Equal(t, LineFollowerLine, evt.Name)
+	assert.InDelta(t, -1.0, evt.Data, 0.0)
+	// act & assert: line lost event only once with the last error
+	a.set(100, 100, 100, 100, 100)
+	for evt.Name == LineFollowerLine {
+		evt = nextEvent()
+	}
+	assert.Equal(t, LineFollowerLineLost, evt.Name)
+	assert.InDelta(t, -1.0, evt.Data, 0.0)
+	select {
+	case evt := <-events:
+		assert.Fail(t, "unexpected event", "%v", evt)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
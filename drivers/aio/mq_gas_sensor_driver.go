@@ -0,0 +1,310 @@
+package aio
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// mqGasOptionApplier needs to be implemented by each configurable option type
+type mqGasOptionApplier interface {
+	apply(cfg *mqGasConfiguration)
+}
+
+// MQGasCurve contains the parameters of the log-log curve from the data sheet, which maps the ratio Rs/Ro to the
+// concentration of the gas in ppm by "ppm = A * (Rs/Ro)^B". B is negative for all known sensors of the series.
+type MQGasCurve struct {
+	A float64
+	B float64
+}
+
+// mqGasConfiguration contains all changeable attributes of the driver.
+type mqGasConfiguration struct {
+	maxValue       int     // raw value at reference voltage, e.g. 1023 for a 10-bit ADC
+	loadResistance float64 // RL in kOhm
+	cleanAirRatio  float64 // Rs/Ro in clean air, taken from the data sheet
+	ro             float64 // sensor resistance in clean air in kOhm
+	curves         map[string]MQGasCurve
+}
+
+// mqGasMaxValueOption is the type for applying another maximum raw value to the configuration
+type mqGasMaxValueOption int
+
+// mqGasLoadResistanceOption is the type for applying another load resistance to the configuration
+type mqGasLoadResistanceOption float64
+
+// mqGasCleanAirRatioOption is the type for applying another clean air ratio to the configuration
+type mqGasCleanAirRatioOption float64
+
+// mqGasRoOption is the type for applying a known Ro to the configuration
+type mqGasRoOption float64
+
+// mqGasCurveOption is the type for applying an additional gas curve to the configuration
+type mqGasCurveOption struct {
+	gas   string
+	curve MQGasCurve
+}
+
+// MQGasSensorDriver represents a gas sensor of the MQ series (e.g. MQ-2, MQ-135). The sensor is a resistor, which
+// value decreases with rising concentration of the gas. It is wired in series with the load resistor RL, the voltage
+// over RL is connected to the analog input.
+//
+// Please note: the sensors contain a heater and need a warm-up time before the readings are stable. The data sheets
+// recommend a burn-in of 24..48 hours for new sensors and some minutes after each power on. The calibration of Ro
+// should be done after the warm-up in clean air.
+type MQGasSensorDriver struct {
+	*AnalogSensorDriver
+	mqGasCfg *mqGasConfiguration
+}
+
+// NewMQGasSensorDriver returns a new driver for a gas sensor of the MQ series, given an AnalogReader and pin.
+// Without further options no curve is known, so [aio.WithMQGasCurve] is needed to get values by PPM().
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithSensorCyclicRead"
+//	"WithMQGasMaxValue"
+//	"WithMQGasLoadResistance"
+//	"WithMQGasCleanAirRatio"
+//	"WithMQGasRo"
+//	"WithMQGasCurve"
+//
+// Adds the following API Commands:
+//
+//	"Read"        - See AnalogDriverSensor.Read
+//	"ReadRaw"     - See AnalogDriverSensor.ReadRaw
+//	"CalibrateRo" - See MQGasSensorDriver.CalibrateRo
+//	"PPM"         - See MQGasSensorDriver.PPM, needs the parameter "gas"
+func NewMQGasSensorDriver(a AnalogReader, pin string, opts ...interface{}) *MQGasSensorDriver {
+	return newMQGasSensorDriver(a, pin, "MQGasSensor", 1, map[string]MQGasCurve{}, opts...)
+}
+
+// NewMQ2GasSensorDriver returns a new driver for the MQ-2 gas sensor, given an AnalogReader and pin. The curves for
+// "LPG", "CO", "Alcohol", "H2" and "Propane" are preset.
+//
+// Supported options and API Commands: see [aio.NewMQGasSensorDriver]
+func NewMQ2GasSensorDriver(a AnalogReader, pin string, opts ...interface{}) *MQGasSensorDriver {
+	curves := map[string]MQGasCurve{
+		"LPG":     {A: 574.25, B: -2.222},
+		"CO":      {A: 36974, B: -3.109},
+		"Alcohol": {A: 3616.1, B: -2.675},
+		"H2":      {A: 987.99, B: -2.162},
+		"Propane": {A: 658.71, B: -2.168},
+	}
+	return newMQGasSensorDriver(a, pin, "MQ2GasSensor", 9.83, curves, opts...)
+}
+
+// NewMQ135GasSensorDriver returns a new driver for the MQ-135 air quality sensor, given an AnalogReader and pin.
+// The curves for "CO", "Alcohol", "CO2", "Toluene", "NH4" and "Acetone" are preset.
+//
+// Supported options and API Commands: see [aio.NewMQGasSensorDriver]
+func NewMQ135GasSensorDriver(a AnalogReader, pin string, opts ...interface{}) *MQGasSensorDriver {
+	curves := map[string]MQGasCurve{
+		"CO":      {A: 605.18, B: -3.937},
+		"Alcohol": {A: 77.255, B: -3.18},
+		"CO2":     {A: 110.47, B: -2.862},
+		"Toluene": {A: 44.947, B: -3.445},
+		"NH4":     {A: 102.2, B: -2.473},
+		"Acetone": {A: 34.668, B: -3.369},
+	}
+	return newMQGasSensorDriver(a, pin, "MQ135GasSensor", 3.6, curves, opts...)
+}
+
+func newMQGasSensorDriver(
+	a AnalogReader,
+	pin string,
+	name string,
+	cleanAirRatio float64,
+	curves map[string]MQGasCurve,
+	opts ...interface{},
+) *MQGasSensorDriver {
+	d := &MQGasSensorDriver{
+		AnalogSensorDriver: NewAnalogSensorDriver(a, pin),
+		mqGasCfg: &mqGasConfiguration{
+			maxValue:       1023,
+			loadResistance: 10,
+			cleanAirRatio:  cleanAirRatio,
+			curves:         curves,
+		},
+	}
+	d.driverCfg.name = gobot.DefaultName(name)
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case sensorOptionApplier:
+			o.apply(d.sensorCfg)
+		case mqGasOptionApplier:
+			o.apply(d.mqGasCfg)
+		case time.Duration:
+			// TODO this is only for backward compatibility and will be removed after version 2.x
+			d.sensorCfg.readInterval = o
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddCommand("CalibrateRo", func(params map[string]interface{}) interface{} {
+		val, err := d.CalibrateRo()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	d.AddCommand("PPM", func(params map[string]interface{}) interface{} {
+		gas, _ := params["gas"].(string)
+		val, err := d.PPM(gas)
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return d
+}
+
+// WithMQGasMaxValue substitute the default maximum raw value of 1023, which is read at the reference voltage.
+func WithMQGasMaxValue(maxValue int) mqGasOptionApplier {
+	return mqGasMaxValueOption(maxValue)
+}
+
+// WithMQGasLoadResistance substitute the default load resistance RL of 10 kOhm.
+func WithMQGasLoadResistance(kOhm float64) mqGasOptionApplier {
+	return mqGasLoadResistanceOption(kOhm)
+}
+
+// WithMQGasCleanAirRatio substitute the ratio Rs/Ro in clean air, which is used by CalibrateRo().
+func WithMQGasCleanAirRatio(ratio float64) mqGasOptionApplier {
+	return mqGasCleanAirRatioOption(ratio)
+}
+
+// WithMQGasRo sets a known sensor resistance Ro in kOhm, e.g. from a former call of CalibrateRo().
+func WithMQGasRo(kOhm float64) mqGasOptionApplier {
+	return mqGasRoOption(kOhm)
+}
+
+// WithMQGasCurve adds or replaces the curve for the given gas.
+func WithMQGasCurve(gas string, curve MQGasCurve) mqGasOptionApplier {
+	return mqGasCurveOption{gas: gas, curve: curve}
+}
+
+// Rs reads the sensor and returns the current sensor resistance in kOhm.
+func (d *MQGasSensorDriver) Rs() (float64, error) {
+	raw, err := d.ReadRaw()
+	if err != nil {
+		return 0, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.mqGasCfg.rs(raw), nil
+}
+
+// Ro returns the sensor resistance in clean air in kOhm. The value is zero, if not calibrated before.
+func (d *MQGasSensorDriver) Ro() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.mqGasCfg.ro
+}
+
+// CalibrateRo reads the sensor resistance and calculates Ro by the clean air ratio. The sensor must be warmed up and
+// placed in clean air. The calculated value is stored and returned in kOhm.
+func (d *MQGasSensorDriver) CalibrateRo() (float64, error) {
+	rs, err := d.Rs()
+	if err != nil {
+		return 0, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.mqGasCfg.cleanAirRatio <= 0 {
+		return 0, fmt.Errorf("clean air ratio (%v) must be greater than zero", d.mqGasCfg.cleanAirRatio)
+	}
+
+	d.mqGasCfg.ro = rs / d.mqGasCfg.cleanAirRatio
+	return d.mqGasCfg.ro, nil
+}
+
+// PPM reads the sensor and returns the concentration of the given gas in ppm.
+func (d *MQGasSensorDriver) PPM(gas string) (float64, error) {
+	rs, err := d.Rs()
+	if err != nil {
+		return 0, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	curve, ok := d.mqGasCfg.curves[gas]
+	if !ok {
+		return 0, fmt.Errorf("no curve known for gas '%s' of '%s'", gas, d.driverCfg.name)
+	}
+
+	if d.mqGasCfg.ro <= 0 {
+		return 0, fmt.Errorf("Ro is not calibrated for '%s'", d.driverCfg.name)
+	}
+
+	return curve.ppm(rs / d.mqGasCfg.ro), nil
+}
+
+// rs calculates the sensor resistance from the raw value, which is related to the voltage over the load resistor
+func (cfg *mqGasConfiguration) rs(raw int) float64 {
+	//      sensor      RL
+	// vRef o--|=/=|--o--|==|-----| GND
+	//                |-> raw <-|
+	if raw > cfg.maxValue {
+		raw = cfg.maxValue
+	}
+	if raw < 1 {
+		// prevent division by zero, the resistance is very high in this case
+		raw = 1
+	}
+	return cfg.loadResistance * float64(cfg.maxValue-raw) / float64(raw)
+}
+
+// ppm maps the given ratio Rs/Ro to the concentration in ppm
+func (c MQGasCurve) ppm(ratio float64) float64 {
+	return c.A * math.Pow(ratio, c.B)
+}
+
+func (o mqGasMaxValueOption) String() string {
+	return "maximum value option for MQ gas sensors"
+}
+
+func (o mqGasLoadResistanceOption) String() string {
+	return "load resistance option for MQ gas sensors"
+}
+
+func (o mqGasCleanAirRatioOption) String() string {
+	return "clean air ratio option for MQ gas sensors"
+}
+
+func (o mqGasRoOption) String() string {
+	return "Ro option for MQ gas sensors"
+}
+
+func (o mqGasCurveOption) String() string {
+	return "curve option for MQ gas sensors"
+}
+
+func (o mqGasMaxValueOption) apply(cfg *mqGasConfiguration) {
+	cfg.maxValue = int(o)
+}
+
+func (o mqGasLoadResistanceOption) apply(cfg *mqGasConfiguration) {
+	cfg.loadResistance = float64(o)
+}
+
+func (o mqGasCleanAirRatioOption) apply(cfg *mqGasConfiguration) {
+	cfg.cleanAirRatio = float64(o)
+}
+
+func (o mqGasRoOption) apply(cfg *mqGasConfiguration) {
+	cfg.ro = float64(o)
+}
+
+func (o mqGasCurveOption) apply(cfg *mqGasConfiguration) {
+	cfg.curves[o.gas] = o.curve
+}
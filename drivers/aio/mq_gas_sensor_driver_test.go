@@ -0,0 +1,184 @@
+package aio
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMQGasSensorDriver(t *testing.T) {
+	// arrange
+	const pin = "5"
+	a := newAioTestAdaptor()
+	// act
+	d := NewMQGasSensorDriver(a, pin)
+	// assert
+	assert.IsType(t, &MQGasSensorDriver{}, d)
+	assert.True(t, strings.HasPrefix(d.Name(), "MQGasSensor"))
+	assert.Equal(t, pin, d.Pin())
+	require.NotNil(t, d.mqGasCfg)
+	assert.Equal(t, 1023, d.mqGasCfg.maxValue)
+	assert.InDelta(t, 10.0, d.mqGasCfg.loadResistance, 0.0)
+	assert.InDelta(t, 1.0, d.mqGasCfg.cleanAirRatio, 0.0)
+	assert.InDelta(t, 0.0, d.mqGasCfg.ro, 0.0)
+	assert.Empty(t, d.mqGasCfg.curves)
+	assert.NotNil(t, d.Command("CalibrateRo"))
+	assert.NotNil(t, d.Command("PPM"))
+}
+
+func TestNewMQ2AndMQ135GasSensorDriver(t *testing.T) {
+	d2 := NewMQ2GasSensorDriver(newAioTestAdaptor(), "1")
+	assert.True(t, strings.HasPrefix(d2.Name(), "MQ2GasSensor"))
+	assert.InDelta(t, 9.83, d2.mqGasCfg.cleanAirRatio, 0.0)
+	assert.Contains(t, d2.mqGasCfg.curves, "LPG")
+	d135 := NewMQ135GasSensorDriver(newAioTestAdaptor(), "1")
+	assert.True(t, strings.HasPrefix(d135.Name(), "MQ135GasSensor"))
+	assert.InDelta(t, 3.6, d135.mqGasCfg.cleanAirRatio, 0.0)
+	assert.Contains(t, d135.mqGasCfg.curves, "CO2")
+}
+
+func TestNewMQGasSensorDriver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const (
+		myName     = "smoke detector"
+		cycReadDur = 10 * time.Millisecond
+	)
+	curve := MQGasCurve{A: 1, B: -1}
+	panicFunc := func() {
+		NewMQGasSensorDriver(newAioTestAdaptor(), "1", WithName("crazy"),
+			WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewMQGasSensorDriver(newAioTestAdaptor(), "1", WithName(myName), WithSensorCyclicRead(cycReadDur),
+		WithMQGasMaxValue(4095), WithMQGasLoadResistance(1), WithMQGasCleanAirRatio(4.4), WithMQGasRo(2.5),
+		WithMQGasCurve("test", curve))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, cycReadDur, d.sensorCfg.readInterval)
+	assert.Equal(t, 4095, d.mqGasCfg.maxValue)
+	assert.InDelta(t, 1.0, d.mqGasCfg.loadResistance, 0.0)
+	assert.InDelta(t, 4.4, d.mqGasCfg.cleanAirRatio, 0.0)
+	assert.InDelta(t, 2.5, d.Ro(), 0.0)
+	assert.Equal(t, curve, d.mqGasCfg.curves["test"])
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestMQGasSensorRs(t *testing.T) {
+	tests := map[string]struct {
+		input int
+		want  float64
+	}{
+		"zero":            {input: 0, want: 10220},
+		"quarter":         {input: 256, want: 29.9609375},
+		"half":            {input: 512, want: 9.98046875},
+		"max":             {input: 1023, want: 0},
+		"bigger_than_max": {input: 1024, want: 0},
+	}
+	a := newAioTestAdaptor()
+	d := NewMQGasSensorDriver(a, "1")
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a.analogReadFunc = func() (int, error) {
+				return tc.input, nil
+			}
+			// act
+			got, err := d.Rs()
+			// assert
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+}
+
+func TestMQGasSensorRs_error(t *testing.T) {
+	// arrange
+	a := newAioTestAdaptor()
+	a.simulateReadError = true
+	d := NewMQGasSensorDriver(a, "1")
+	// act
+	_, err := d.Rs()
+	// assert
+	require.EqualError(t, err, "read error")
+}
+
+func TestMQGasSensorCalibrateRo(t *testing.T) {
+	// arrange
+	a := newAioTestAdaptor()
+	a.analogReadFunc = func() (int, error) {
+		return 93, nil // Rs = 100 kOhm
+	}
+	d := NewMQ2GasSensorDriver(a, "1")
+	// act
+	got, err := d.CalibrateRo()
+	// assert
+	require.NoError(t, err)
+	assert.InDelta(t, 100/9.83, got, 1e-9)
+	assert.InDelta(t, got, d.Ro(), 0.0)
+}
+
+func TestMQGasSensorPPM(t *testing.T) {
+	tests := map[string]struct {
+		gas     string
+		input   int
+		ro      float64
+		want    float64
+		wantErr string
+	}{
+		"ratio_1": {
+			gas:   "test",
+			input: 512, // Rs ~ 9.98 kOhm
+			ro:    9.98046875,
+			want:  100,
+		},
+		"ratio_0.5": {
+			gas:   "test",
+			input: 512,
+			ro:    2 * 9.98046875,
+			want:  400,
+		},
+		"ratio_2": {
+			gas:   "test",
+			input: 512,
+			ro:    9.98046875 / 2,
+			want:  25,
+		},
+		"error_unknown_gas": {
+			gas:     "unknown",
+			input:   512,
+			ro:      10,
+			wantErr: "no curve known for gas 'unknown' of 'MQ'",
+		},
+		"error_not_calibrated": {
+			gas:     "test",
+			input:   512,
+			wantErr: "Ro is not calibrated for 'MQ'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newAioTestAdaptor()
+			a.analogReadFunc = func() (int, error) {
+				return tc.input, nil
+			}
+			d := NewMQGasSensorDriver(a, "1", WithName("MQ"), WithMQGasRo(tc.ro),
+				WithMQGasCurve("test", MQGasCurve{A: 100, B: -2}))
+			// act
+			got, err := d.PPM(tc.gas)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+}
@@ -8,7 +8,13 @@ import (
 	"gobot.io/x/gobot/v2"
 )
 
-const kelvinOffset = 273.15
+const (
+	kelvinOffset = 273.15
+
+	temperatureSensorLM35MilliVoltPerDegree  = 10.0
+	temperatureSensorTMP36MilliVoltPerDegree = 10.0
+	temperatureSensorTMP36OffsetMilliVolt    = 500.0
+)
 
 // TemperatureSensorNtcConf contains all attributes to calculate key parameters of a NTC thermistor.
 type TemperatureSensorNtcConf struct {
@@ -27,7 +33,8 @@ type TemperatureSensorDriver struct {
 }
 
 // NewTemperatureSensorDriver is a driver for analog temperature sensors, given an AnalogReader and pin.
-// Linear scaling and NTC scaling is supported.
+// Linear scaling, NTC scaling and the conversion for LM35 and TMP36 is supported. With cyclic reading, the
+// temperature in °C is published by the "Value" event.
 //
 // Supported options: see [aio.NewAnalogSensorDriver]
 // Adds the following API Commands: see [aio.NewAnalogSensorDriver]
@@ -70,6 +77,54 @@ func (t *TemperatureSensorDriver) SetLinearScaler(fromMin, fromMax int, toMin, t
 	t.SetScaler(AnalogSensorLinearScaler(fromMin, fromMax, toMin, toMax))
 }
 
+// SetLM35Scaler sets a function for scaling the read value of a LM35 sensor to °C, see
+// [aio.TemperatureSensorLM35Scaler]. If the scaler is not changed after initialization, prefer to use
+// [aio.WithSensorScaler] instead.
+func (t *TemperatureSensorDriver) SetLM35Scaler(vRef float64, maxRaw int) {
+	t.SetScaler(TemperatureSensorLM35Scaler(vRef, maxRaw))
+}
+
+// SetTMP36Scaler sets a function for scaling the read value of a TMP36 sensor to °C, see
+// [aio.TemperatureSensorTMP36Scaler]. If the scaler is not changed after initialization, prefer to use
+// [aio.WithSensorScaler] instead.
+func (t *TemperatureSensorDriver) SetTMP36Scaler(vRef float64, maxRaw int) {
+	t.SetScaler(TemperatureSensorTMP36Scaler(vRef, maxRaw))
+}
+
+// TemperatureSensorLM35Scaler creates a function for scaling the read value of a LM35 sensor (10mV/°C, 0mV at 0°C)
+// to °C. The vRef is the reference voltage of the ADC in volt and maxRaw the read value at this voltage, e.g. 1023 for
+// a 10-bit ADC.
+func TemperatureSensorLM35Scaler(vRef float64, maxRaw int) func(input int) (value float64) {
+	return TemperatureSensorVoltageScaler(vRef, maxRaw, 0, temperatureSensorLM35MilliVoltPerDegree)
+}
+
+// TemperatureSensorTMP36Scaler creates a function for scaling the read value of a TMP36 sensor (10mV/°C, 500mV at
+// 0°C) to °C. The vRef is the reference voltage of the ADC in volt and maxRaw the read value at this voltage, e.g.
+// 1023 for a 10-bit ADC.
+func TemperatureSensorTMP36Scaler(vRef float64, maxRaw int) func(input int) (value float64) {
+	return TemperatureSensorVoltageScaler(vRef, maxRaw, temperatureSensorTMP36OffsetMilliVolt,
+		temperatureSensorTMP36MilliVoltPerDegree)
+}
+
+// TemperatureSensorVoltageScaler creates a function for scaling the read value of sensors with a linear voltage
+// output to °C. The read value is converted to millivolt by the reference voltage vRef (in volt) and the read value
+// maxRaw at this voltage. The temperature is calculated by "(mV - offsetMilliVolt) / milliVoltPerDegree".
+// Negative read values are treated as zero.
+func TemperatureSensorVoltageScaler(
+	vRef float64,
+	maxRaw int,
+	offsetMilliVolt float64,
+	milliVoltPerDegree float64,
+) func(input int) (value float64) {
+	return (func(input int) float64 {
+		if input < 0 {
+			input = 0
+		}
+		milliVolt := float64(input) * vRef * 1000 / float64(maxRaw)
+		return (milliVolt - offsetMilliVolt) / milliVoltPerDegree
+	})
+}
+
 // TemperatureSensorNtcScaler creates a function for typical NTC scaling the read value.
 // The read value is related to the voltage over the thermistor in an series connection to a resistor.
 // If the thermistor is connected to ground, the reverse flag must be set to true.
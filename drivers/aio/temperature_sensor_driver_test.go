@@ -128,6 +128,59 @@ func TestTemperatureSensorDriver_LinearScaler(t *testing.T) {
 	}
 }
 
+func TestTemperatureSensorRead_LM35AndTMP36Scaler(t *testing.T) {
+	tests := map[string]struct {
+		scaler func(input int) float64
+		input  int
+		want   float64
+	}{
+		"lm35_negative_input": {scaler: TemperatureSensorLM35Scaler(1.1, 1100), input: -1, want: 0},
+		"lm35_T0C":            {scaler: TemperatureSensorLM35Scaler(1.1, 1100), input: 0, want: 0},
+		"lm35_T25C_1V1":       {scaler: TemperatureSensorLM35Scaler(1.1, 1100), input: 250, want: 25},
+		"lm35_T100C_1V1":      {scaler: TemperatureSensorLM35Scaler(1.1, 1100), input: 1000, want: 100},
+		"lm35_5V_10bit":       {scaler: TemperatureSensorLM35Scaler(5, 1023), input: 51, want: 24.926686217008797},
+		"tmp36_T-50C":         {scaler: TemperatureSensorTMP36Scaler(3.3, 3300), input: 0, want: -50},
+		"tmp36_T-10C":         {scaler: TemperatureSensorTMP36Scaler(3.3, 3300), input: 400, want: -10},
+		"tmp36_T25C_3V3":      {scaler: TemperatureSensorTMP36Scaler(3.3, 3300), input: 750, want: 25},
+		"tmp36_5V_10bit":      {scaler: TemperatureSensorTMP36Scaler(5, 1024), input: 154, want: 25.1953125},
+		"tmp36_3V3_12bit":     {scaler: TemperatureSensorTMP36Scaler(3.3, 4095), input: 1241, want: 50.0073260073260},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newAioTestAdaptor()
+			d := NewTemperatureSensorDriver(a, "4", WithSensorScaler(tc.scaler))
+			a.analogReadFunc = func() (int, error) {
+				return tc.input, nil
+			}
+			// act
+			got, err := d.Read()
+			// assert
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+}
+
+func TestTemperatureSensorSetLM35AndTMP36Scaler(t *testing.T) {
+	// arrange
+	a := newAioTestAdaptor()
+	a.analogReadFunc = func() (int, error) {
+		return 750, nil
+	}
+	d := NewTemperatureSensorDriver(a, "4")
+	// act & assert
+	d.SetLM35Scaler(3.3, 3300)
+	got, err := d.Read()
+	require.NoError(t, err)
+	assert.InDelta(t, 75.0, got, 1e-9)
+	// act & assert
+	d.SetTMP36Scaler(3.3, 3300)
+	got, err = d.Read()
+	require.NoError(t, err)
+	assert.InDelta(t, 25.0, got, 1e-9)
+}
+
 func TestTemperatureSensorWithSensorCyclicRead_PublishesTemperatureInCelsius(t *testing.T) {
 	// arrange
 	sem := make(chan bool)
@@ -0,0 +1,122 @@
+package capability
+
+import (
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/drivers/serial"
+	"gobot.io/x/gobot/v2/drivers/spi"
+)
+
+// Capability is the name of a feature, which can be provided by an adaptor. This is the same type like used by
+// gpio.RequireCapabilities(), so the capabilities of the gpio package can be used here and vice versa.
+type Capability = gpio.Capability
+
+// Capabilities of an adaptor, the name equals the implemented interface
+const (
+	DigitalRead  = gpio.DigitalReaderCapability // gpio.DigitalReader
+	DigitalWrite = gpio.DigitalWriterCapability // gpio.DigitalWriter
+	PWM          = gpio.PwmWriterCapability     // gpio.PwmWriter
+	Servo        = gpio.ServoWriterCapability   // gpio.ServoWriter
+
+	AnalogRead  Capability = "AnalogReader"       // aio.AnalogReader
+	AnalogWrite Capability = "AnalogWriter"       // aio.AnalogWriter
+	I2C         Capability = "I2cConnector"       // i2c.Connector
+	SPI         Capability = "SpiConnector"       // spi.Connector
+	Serial      Capability = "SerialReaderWriter" // serial.SerialReader and serial.SerialWriter
+	// DigitalPins gives access to the pins, e.g. to apply options for edge detection (interrupts) or debouncing
+	DigitalPins Capability = "DigitalPinnerProvider" // gobot.DigitalPinnerProvider
+	PWMPins     Capability = "PWMPinnerProvider"     // gobot.PWMPinnerProvider
+)
+
+// all capabilities in the order of Of()
+var all = []Capability{
+	DigitalRead, DigitalWrite, PWM, Servo, AnalogRead, AnalogWrite, I2C, SPI, Serial, DigitalPins, PWMPins,
+}
+
+// Of returns all capabilities of the given adaptor. The result is empty for a nil adaptor.
+func Of(a gobot.Adaptor) []Capability {
+	var caps []Capability
+	for _, c := range all {
+		if Supports(a, c) {
+			caps = append(caps, c)
+		}
+	}
+
+	return caps
+}
+
+// Supports returns true, if the adaptor provides all given capabilities. Unknown capabilities are never supported.
+// All capabilities of the gpio package are supported, e.g. gpio.PwmPolaritySetterCapability.
+func Supports(a gobot.Adaptor, caps ...Capability) bool {
+	for _, c := range caps {
+		if !supports(a, c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SupportsDigitalRead returns true, if the adaptor can read digital pins.
+func SupportsDigitalRead(a gobot.Adaptor) bool { return supports(a, DigitalRead) }
+
+// SupportsDigitalWrite returns true, if the adaptor can write digital pins.
+func SupportsDigitalWrite(a gobot.Adaptor) bool { return supports(a, DigitalWrite) }
+
+// SupportsPWM returns true, if the adaptor can write PWM values.
+func SupportsPWM(a gobot.Adaptor) bool { return supports(a, PWM) }
+
+// SupportsServo returns true, if the adaptor can write servo angles.
+func SupportsServo(a gobot.Adaptor) bool { return supports(a, Servo) }
+
+// SupportsAnalogRead returns true, if the adaptor can read analog pins.
+func SupportsAnalogRead(a gobot.Adaptor) bool { return supports(a, AnalogRead) }
+
+// SupportsAnalogWrite returns true, if the adaptor can write analog pins.
+func SupportsAnalogWrite(a gobot.Adaptor) bool { return supports(a, AnalogWrite) }
+
+// SupportsI2C returns true, if the adaptor provides connections to I2C devices.
+func SupportsI2C(a gobot.Adaptor) bool { return supports(a, I2C) }
+
+// SupportsSPI returns true, if the adaptor provides connections to SPI devices.
+func SupportsSPI(a gobot.Adaptor) bool { return supports(a, SPI) }
+
+// SupportsSerial returns true, if the adaptor can read and write a serial port.
+func SupportsSerial(a gobot.Adaptor) bool { return supports(a, Serial) }
+
+// SupportsDigitalPins returns true, if the adaptor gives access to its digital pins. This is needed e.g. to apply
+// options for edge detection (interrupts), but the options are not supported by all system drivers, see
+// gobot.DigitalPinOptioner.
+func SupportsDigitalPins(a gobot.Adaptor) bool { return supports(a, DigitalPins) }
+
+// SupportsPWMPins returns true, if the adaptor gives access to its PWM pins, e.g. to change the period.
+func SupportsPWMPins(a gobot.Adaptor) bool { return supports(a, PWMPins) }
+
+func supports(a gobot.Adaptor, c Capability) bool {
+	var ok bool
+	switch c {
+	case AnalogRead:
+		_, ok = a.(aio.AnalogReader)
+	case AnalogWrite:
+		_, ok = a.(aio.AnalogWriter)
+	case I2C:
+		_, ok = a.(i2c.Connector)
+	case SPI:
+		_, ok = a.(spi.Connector)
+	case Serial:
+		_, okRead := a.(serial.SerialReader)
+		_, okWrite := a.(serial.SerialWriter)
+		ok = okRead && okWrite
+	case DigitalPins:
+		_, ok = a.(gobot.DigitalPinnerProvider)
+	case PWMPins:
+		_, ok = a.(gobot.PWMPinnerProvider)
+	default:
+		// the capabilities of the gpio package, unknown ones are never supported
+		ok = gpio.RequireCapabilities(a, c) == nil
+	}
+
+	return ok
+}
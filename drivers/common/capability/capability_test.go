@@ -0,0 +1,153 @@
+package capability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/drivers/spi"
+)
+
+// bareAdaptor implements only the gobot.Adaptor interface
+type bareAdaptor struct{}
+
+func (a *bareAdaptor) Name() string    { return "bare" }
+func (a *bareAdaptor) SetName(string)  {}
+func (a *bareAdaptor) Connect() error  { return nil }
+func (a *bareAdaptor) Finalize() error { return nil }
+
+// boardAdaptor provides most capabilities like a single board computer, but the SPI connector is incomplete
+type boardAdaptor struct{ bareAdaptor }
+
+func (a *boardAdaptor) DigitalRead(string) (int, error)                   { return 0, nil }
+func (a *boardAdaptor) DigitalWrite(string, byte) error                   { return nil }
+func (a *boardAdaptor) PwmWrite(string, byte) error                       { return nil }
+func (a *boardAdaptor) DigitalPin(string) (gobot.DigitalPinner, error)    { return nil, nil }
+func (a *boardAdaptor) GetI2cConnection(int, int) (i2c.Connection, error) { return nil, nil }
+func (a *boardAdaptor) DefaultI2cBus() int                                { return 0 }
+func (a *boardAdaptor) ServoWrite(string, byte) error                     { return nil }
+func (a *boardAdaptor) AnalogRead(string) (int, error)                    { return 0, nil }
+func (a *boardAdaptor) PWMPin(string) (gobot.PWMPinner, error)            { return nil, nil }
+func (a *boardAdaptor) GetSpiConnection(int, int, int, int, int64) (spi.Connection, error) {
+	return nil, nil
+}
+
+// busAdaptor provides only the buses, like an USB to I2C/SPI bridge
+type busAdaptor struct{ bareAdaptor }
+
+func (a *busAdaptor) GetI2cConnection(int, int) (i2c.Connection, error) { return nil, nil }
+func (a *busAdaptor) DefaultI2cBus() int                                { return 1 }
+func (a *busAdaptor) GetSpiConnection(int, int, int, int, int64) (spi.Connection, error) {
+	return nil, nil
+}
+func (a *busAdaptor) SpiDefaultBusNumber() int  { return 0 }
+func (a *busAdaptor) SpiDefaultChipNumber() int { return 0 }
+func (a *busAdaptor) SpiDefaultMode() int       { return 0 }
+func (a *busAdaptor) SpiDefaultBitCount() int   { return 8 }
+func (a *busAdaptor) SpiDefaultMaxSpeed() int64 { return 500000 }
+
+// serialAdaptor can write and read a serial port, but has only analog write additionally
+type serialAdaptor struct{ bareAdaptor }
+
+func (a *serialAdaptor) SerialRead([]byte) (int, error)  { return 0, nil }
+func (a *serialAdaptor) SerialWrite([]byte) (int, error) { return 0, nil }
+func (a *serialAdaptor) AnalogWrite(string, int) error   { return nil }
+
+// serialWriteOnlyAdaptor can only write to a serial port
+type serialWriteOnlyAdaptor struct{ bareAdaptor }
+
+func (a *serialWriteOnlyAdaptor) SerialWrite([]byte) (int, error) { return 0, nil }
+
+func TestOf(t *testing.T) {
+	tests := map[string]struct {
+		adaptor gobot.Adaptor
+		want    []Capability
+	}{
+		"board": {
+			adaptor: &boardAdaptor{},
+			want:    []Capability{DigitalRead, DigitalWrite, PWM, Servo, AnalogRead, I2C, DigitalPins, PWMPins},
+		},
+		"bus": {
+			adaptor: &busAdaptor{},
+			want:    []Capability{I2C, SPI},
+		},
+		"serial": {
+			adaptor: &serialAdaptor{},
+			want:    []Capability{AnalogWrite, Serial},
+		},
+		"serial_write_only": {
+			adaptor: &serialWriteOnlyAdaptor{},
+		},
+		"bare": {
+			adaptor: &bareAdaptor{},
+		},
+		"nil": {},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got := Of(tc.adaptor)
+			// assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSupports(t *testing.T) {
+	// arrange
+	a := &boardAdaptor{}
+	// act & assert
+	assert.True(t, Supports(a))
+	assert.True(t, Supports(a, DigitalWrite))
+	assert.True(t, Supports(a, DigitalWrite, PWM, I2C))
+	assert.False(t, Supports(a, DigitalWrite, SPI))
+	assert.False(t, Supports(a, Capability("unknown")))
+	assert.False(t, Supports(nil, DigitalRead))
+}
+
+func TestSupports_gpioCapabilities(t *testing.T) {
+	// arrange
+	a := &boardAdaptor{}
+	// act & assert: the capabilities are shared with the gpio package
+	assert.True(t, Supports(a, gpio.DigitalWriterCapability, gpio.PwmWriterCapability))
+	assert.False(t, Supports(a, gpio.PwmPolaritySetterCapability))
+	require.NoError(t, gpio.RequireCapabilities(a, DigitalRead, DigitalWrite, PWM, Servo))
+	require.EqualError(t, gpio.RequireCapabilities(&bareAdaptor{}, DigitalWrite),
+		"adaptor 'bare' is missing the capabilities: DigitalWriter")
+}
+
+func TestSupportsHelpers(t *testing.T) {
+	helpers := map[Capability]func(gobot.Adaptor) bool{
+		DigitalRead:  SupportsDigitalRead,
+		DigitalWrite: SupportsDigitalWrite,
+		PWM:          SupportsPWM,
+		Servo:        SupportsServo,
+		AnalogRead:   SupportsAnalogRead,
+		AnalogWrite:  SupportsAnalogWrite,
+		I2C:          SupportsI2C,
+		SPI:          SupportsSPI,
+		Serial:       SupportsSerial,
+		DigitalPins:  SupportsDigitalPins,
+		PWMPins:      SupportsPWMPins,
+	}
+	adaptors := map[string]gobot.Adaptor{
+		"board":             &boardAdaptor{},
+		"bus":               &busAdaptor{},
+		"serial":            &serialAdaptor{},
+		"serial_write_only": &serialWriteOnlyAdaptor{},
+		"bare":              &bareAdaptor{},
+	}
+	assert.Len(t, helpers, len(all))
+	for name, a := range adaptors {
+		t.Run(name, func(t *testing.T) {
+			for c, helper := range helpers {
+				// act & assert: each helper is consistent with the detected capabilities
+				assert.Equal(t, Supports(a, c), helper(a), "capability %s", c)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+/*
+Package capability provides the discovery of the capabilities of an adaptor, e.g. whether PWM or an I2C bus is
+supported, so generic code can adapt to the given platform without type assertions to the interfaces of the driver
+packages. The capabilities are detected by the implemented interfaces, e.g.
+
+	if capability.SupportsPWM(adaptor) {
+		led := gpio.NewLedDriver(adaptor, "7")
+		...
+	}
+
+To check the capabilities needed by a gpio driver on its start, see gpio.RequireCapabilities(). The capabilities of
+both packages are of the same type, so they can be mixed.
+*/
+package capability // import "gobot.io/x/gobot/v2/drivers/common/capability"
@@ -0,0 +1,77 @@
+package fusion
+
+import (
+	"fmt"
+	"math"
+)
+
+// ComplementaryFilter estimates the roll and pitch angle by combining the integrated angular rate of the gyroscope,
+// which is precise in short term but drifts, with the angles of the gravity vector measured by the accelerometer,
+// which are stable in long term but noisy and disturbed by linear accelerations.
+type ComplementaryFilter struct {
+	alpha       float64
+	roll        float64
+	pitch       float64
+	initialized bool
+}
+
+// NewComplementaryFilter creates a new filter with the given blend coefficient within 0..1, which is the weight of the
+// gyroscope. A typical value is 0.98. The time constant of the filter is "alpha * dt / (1 - alpha)", e.g. 0.49s for a
+// time step of 10ms. The first update takes over the angles of the accelerometer.
+func NewComplementaryFilter(alpha float64) *ComplementaryFilter {
+	if alpha < 0 || alpha > 1 {
+		panic(fmt.Sprintf("blend coefficient (%v) of complementary filter must be within 0..1", alpha))
+	}
+
+	return &ComplementaryFilter{alpha: alpha}
+}
+
+// Alpha returns the blend coefficient.
+func (f *ComplementaryFilter) Alpha() float64 {
+	return f.alpha
+}
+
+// Update takes the acceleration (ax, ay, az), the angular rate (gx, gy, gz) in rad/s and the time step in seconds
+// since the last update and returns the new estimation of roll (around x) and pitch (around y) in radians. The yaw
+// rate (gz) can not be corrected by the gravity vector, so it is not used.
+//
+//nolint:nonamedreturns // is sufficient here
+func (f *ComplementaryFilter) Update(ax, ay, az, gx, gy, gz, dt float64) (roll float64, pitch float64) {
+	accRoll, accPitch := AccelerationAngles(ax, ay, az)
+
+	if !f.initialized {
+		f.roll, f.pitch = accRoll, accPitch
+		f.initialized = true
+
+		return f.roll, f.pitch
+	}
+
+	f.roll = f.alpha*(f.roll+gx*dt) + (1-f.alpha)*accRoll
+	f.pitch = f.alpha*(f.pitch+gy*dt) + (1-f.alpha)*accPitch
+
+	return f.roll, f.pitch
+}
+
+// Angles returns the current estimation of roll and pitch in radians.
+//
+//nolint:nonamedreturns // is sufficient here
+func (f *ComplementaryFilter) Angles() (roll float64, pitch float64) {
+	return f.roll, f.pitch
+}
+
+// Reset discards the estimation, so the next update takes over the angles of the accelerometer.
+func (f *ComplementaryFilter) Reset() {
+	f.roll, f.pitch = 0, 0
+	f.initialized = false
+}
+
+// AccelerationAngles returns the roll (around x) and pitch (around y) angle in radians of the gravity vector, given by
+// the acceleration of a resting sensor. A flat sensor measures the gravity on the z-axis only.
+//
+//nolint:nonamedreturns // is sufficient here
+func AccelerationAngles(ax, ay, az float64) (roll float64, pitch float64) {
+	roll = math.Atan2(ay, az)
+	pitch = math.Atan2(-ax, math.Sqrt(ay*ay+az*az))
+
+	return roll, pitch
+}
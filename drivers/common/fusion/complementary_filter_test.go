@@ -0,0 +1,135 @@
+package fusion
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const deg = math.Pi / 180
+
+// gravity returns the acceleration of a resting sensor, which is tilted by the given roll and pitch angle
+func gravity(roll, pitch float64) (float64, float64, float64) {
+	return -math.Sin(pitch), math.Sin(roll) * math.Cos(pitch), math.Cos(roll) * math.Cos(pitch)
+}
+
+func TestNewComplementaryFilter(t *testing.T) {
+	// act
+	f := NewComplementaryFilter(0.98)
+	// assert
+	assert.InDelta(t, 0.98, f.Alpha(), 0.0)
+	assert.False(t, f.initialized)
+	assert.PanicsWithValue(t, "blend coefficient (1.5) of complementary filter must be within 0..1", func() {
+		NewComplementaryFilter(1.5)
+	})
+}
+
+func TestAccelerationAngles(t *testing.T) {
+	tests := map[string]struct {
+		roll  float64
+		pitch float64
+	}{
+		"flat":           {},
+		"roll_positive":  {roll: 30 * deg},
+		"roll_negative":  {roll: -45 * deg},
+		"pitch_positive": {pitch: 20 * deg},
+		"pitch_negative": {pitch: -60 * deg},
+		"combined":       {roll: 10 * deg, pitch: -25 * deg},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			roll, pitch := AccelerationAngles(gravity(tc.roll, tc.pitch))
+			// assert
+			assert.InDelta(t, tc.roll, roll, 1e-9)
+			assert.InDelta(t, tc.pitch, pitch, 1e-9)
+		})
+	}
+}
+
+func TestComplementaryFilterUpdate(t *testing.T) {
+	const dt = 0.01
+	tests := map[string]struct {
+		roll      func(tm float64) float64 // true roll angle over time
+		pitch     func(tm float64) float64 // true pitch angle over time
+		gyroBias  float64
+		wantRoll  float64
+		wantPitch float64
+		tolerance float64
+	}{
+		"static_tilt": {
+			roll:      func(float64) float64 { return 30 * deg },
+			pitch:     func(float64) float64 { return -15 * deg },
+			wantRoll:  30 * deg,
+			wantPitch: -15 * deg,
+			tolerance: 1e-9,
+		},
+		"static_tilt_with_gyro_bias": {
+			// the error is limited to "bias * time constant" instead of growing over time
+			roll:      func(float64) float64 { return 20 * deg },
+			pitch:     func(float64) float64 { return 10 * deg },
+			gyroBias:  0.02,
+			wantRoll:  20 * deg,
+			wantPitch: 10 * deg,
+			tolerance: 0.6 * deg,
+		},
+		"rotation_tracked": {
+			roll:      func(tm float64) float64 { return 0.5 * tm },
+			pitch:     func(tm float64) float64 { return -0.25 * tm },
+			wantRoll:  0.5,
+			wantPitch: -0.25,
+			tolerance: 0.3 * deg,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			f := NewComplementaryFilter(0.98)
+			var roll, pitch float64
+			// act: synthetic samples for 1 second
+			for i := 0; i <= 100; i++ {
+				tm := float64(i) * dt
+				rollRate := (tc.roll(tm+dt/2) - tc.roll(tm-dt/2)) / dt
+				pitchRate := (tc.pitch(tm+dt/2) - tc.pitch(tm-dt/2)) / dt
+				ax, ay, az := gravity(tc.roll(tm), tc.pitch(tm))
+				roll, pitch = f.Update(ax, ay, az, rollRate+tc.gyroBias, pitchRate+tc.gyroBias, 0, dt)
+			}
+			// assert
+			assert.InDelta(t, tc.wantRoll, roll, tc.tolerance)
+			assert.InDelta(t, tc.wantPitch, pitch, tc.tolerance)
+			gotRoll, gotPitch := f.Angles()
+			assert.InDelta(t, roll, gotRoll, 0.0)
+			assert.InDelta(t, pitch, gotPitch, 0.0)
+		})
+	}
+}
+
+func TestComplementaryFilterUpdate_suppressAccelerationSpike(t *testing.T) {
+	// arrange
+	f := NewComplementaryFilter(0.98)
+	ax, ay, az := gravity(0, 0)
+	f.Update(ax, ay, az, 0, 0, 0, 0.01)
+	// act: a short linear acceleration to the side, the accelerometer reports 45 degree roll
+	roll, _ := f.Update(0, 1, 1, 0, 0, 0, 0.01)
+	// assert
+	assert.InDelta(t, 0.02*45*deg, roll, 1e-9)
+}
+
+func TestComplementaryFilterReset(t *testing.T) {
+	// arrange
+	f := NewComplementaryFilter(0.98)
+	f.Update(restingSample(10*deg, 0))
+	// act
+	f.Reset()
+	roll, pitch := f.Update(restingSample(-5*deg, 5*deg))
+	// assert
+	assert.InDelta(t, -5*deg, roll, 1e-9)
+	assert.InDelta(t, 5*deg, pitch, 1e-9)
+}
+
+// restingSample returns the arguments of an update for a resting sensor
+func restingSample(roll, pitch float64) (float64, float64, float64, float64, float64, float64, float64) {
+	ax, ay, az := gravity(roll, pitch)
+	return ax, ay, az, 0, 0, 0, 0.01
+}
@@ -0,0 +1,9 @@
+/*
+Package fusion provides sensor-agnostic helpers to combine the samples of an accelerometer and a gyroscope to a stable
+orientation, e.g. the tilt angles of a balancing robot.
+
+The inputs are plain float values, so each IMU driver can be used, e.g. the MPU6050. The acceleration can be given in
+any unit, only the direction is evaluated. The angular rate needs to be given in radians per second and the time step
+in seconds. All angles are returned in radians.
+*/
+package fusion // import "gobot.io/x/gobot/v2/drivers/common/fusion"
@@ -0,0 +1,106 @@
+package fusion
+
+import (
+	"fmt"
+	"math"
+)
+
+// MadgwickFilter estimates the orientation as quaternion by the gradient descent algorithm of Sebastian Madgwick,
+// using an accelerometer and a gyroscope (IMU version, without magnetometer). In contrast to the ComplementaryFilter,
+// large angles and the coupling of the axis are handled correctly. Without magnetometer the yaw drifts.
+//
+// paper:
+// https://x-io.co.uk/downloads/madgwick_internal_report.pdf
+type MadgwickFilter struct {
+	beta float64
+	q    [4]float64 // w, x, y, z
+}
+
+// NewMadgwickFilter creates a new filter with the given gain, which weights the correction by the accelerometer
+// against the integration of the gyroscope. A typical value is 0.1, a greater value converges faster, but is more
+// disturbed by linear accelerations. The filter starts with the orientation of a flat sensor.
+func NewMadgwickFilter(beta float64) *MadgwickFilter {
+	if beta < 0 {
+		panic(fmt.Sprintf("gain (%v) of madgwick filter must not be negative", beta))
+	}
+
+	return &MadgwickFilter{beta: beta, q: [4]float64{1, 0, 0, 0}}
+}
+
+// Beta returns the gain of the filter.
+func (f *MadgwickFilter) Beta() float64 {
+	return f.beta
+}
+
+// Update takes the acceleration (ax, ay, az), the angular rate (gx, gy, gz) in rad/s and the time step in seconds
+// since the last update and returns the new estimation of roll (around x) and pitch (around y) in radians. If the
+// acceleration is zero, e.g. in free fall, only the gyroscope is integrated.
+//
+//nolint:nonamedreturns // is sufficient here
+func (f *MadgwickFilter) Update(ax, ay, az, gx, gy, gz, dt float64) (roll float64, pitch float64) {
+	q0, q1, q2, q3 := f.q[0], f.q[1], f.q[2], f.q[3]
+
+	// rate of change of the quaternion from the gyroscope
+	qDot0 := 0.5 * (-q1*gx - q2*gy - q3*gz)
+	qDot1 := 0.5 * (q0*gx + q2*gz - q3*gy)
+	qDot2 := 0.5 * (q0*gy - q1*gz + q3*gx)
+	qDot3 := 0.5 * (q0*gz + q1*gy - q2*gx)
+
+	if norm := math.Sqrt(ax*ax + ay*ay + az*az); norm > 0 {
+		ax, ay, az = ax/norm, ay/norm, az/norm
+
+		// gradient of the objective function, which is the difference between the measured direction of the
+		// gravity and the direction given by the estimated orientation
+		s0 := 4*q0*q2*q2 + 2*q2*ax + 4*q0*q1*q1 - 2*q1*ay
+		s1 := 4*q1*q3*q3 - 2*q3*ax + 4*q0*q0*q1 - 2*q0*ay - 4*q1 + 8*q1*q1*q1 + 8*q1*q2*q2 + 4*q1*az
+		s2 := 4*q0*q0*q2 + 2*q0*ax + 4*q2*q3*q3 - 2*q3*ay - 4*q2 + 8*q2*q1*q1 + 8*q2*q2*q2 + 4*q2*az
+		s3 := 4*q1*q1*q3 - 2*q1*ax + 4*q2*q2*q3 - 2*q2*ay
+
+		if sNorm := math.Sqrt(s0*s0 + s1*s1 + s2*s2 + s3*s3); sNorm > 0 {
+			qDot0 -= f.beta * s0 / sNorm
+			qDot1 -= f.beta * s1 / sNorm
+			qDot2 -= f.beta * s2 / sNorm
+			qDot3 -= f.beta * s3 / sNorm
+		}
+	}
+
+	q0 += qDot0 * dt
+	q1 += qDot1 * dt
+	q2 += qDot2 * dt
+	q3 += qDot3 * dt
+
+	norm := math.Sqrt(q0*q0 + q1*q1 + q2*q2 + q3*q3)
+	f.q = [4]float64{q0 / norm, q1 / norm, q2 / norm, q3 / norm}
+
+	return f.Angles()
+}
+
+// Angles returns the current estimation of roll and pitch in radians.
+//
+//nolint:nonamedreturns // is sufficient here
+func (f *MadgwickFilter) Angles() (roll float64, pitch float64) {
+	q0, q1, q2, q3 := f.q[0], f.q[1], f.q[2], f.q[3]
+
+	roll = math.Atan2(2*(q0*q1+q2*q3), 1-2*(q1*q1+q2*q2))
+	pitch = math.Asin(math.Max(-1, math.Min(1, 2*(q0*q2-q3*q1))))
+
+	return roll, pitch
+}
+
+// Yaw returns the current estimation of the yaw (around z) in radians. Without magnetometer, this is only the
+// integrated angular rate, so it drifts.
+func (f *MadgwickFilter) Yaw() float64 {
+	q0, q1, q2, q3 := f.q[0], f.q[1], f.q[2], f.q[3]
+
+	return math.Atan2(2*(q0*q3+q1*q2), 1-2*(q2*q2+q3*q3))
+}
+
+// Quaternion returns the current estimation of the orientation as quaternion (w, x, y, z).
+func (f *MadgwickFilter) Quaternion() [4]float64 {
+	return f.q
+}
+
+// Reset sets the orientation back to a flat sensor.
+func (f *MadgwickFilter) Reset() {
+	f.q = [4]float64{1, 0, 0, 0}
+}
@@ -0,0 +1,96 @@
+package fusion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMadgwickFilter(t *testing.T) {
+	// act
+	f := NewMadgwickFilter(0.1)
+	// assert
+	assert.InDelta(t, 0.1, f.Beta(), 0.0)
+	assert.Equal(t, [4]float64{1, 0, 0, 0}, f.Quaternion())
+	assert.PanicsWithValue(t, "gain (-1) of madgwick filter must not be negative", func() {
+		NewMadgwickFilter(-1)
+	})
+}
+
+func TestMadgwickFilterUpdate_convergeToTilt(t *testing.T) {
+	tests := map[string]struct {
+		roll  float64
+		pitch float64
+	}{
+		"flat":     {},
+		"roll":     {roll: 30 * deg},
+		"pitch":    {pitch: -20 * deg},
+		"combined": {roll: -40 * deg, pitch: 15 * deg},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			f := NewMadgwickFilter(0.1)
+			ax, ay, az := gravity(tc.roll, tc.pitch)
+			var roll, pitch float64
+			// act: 30 seconds of resting sensor, starting from flat orientation
+			for i := 0; i < 3000; i++ {
+				roll, pitch = f.Update(ax, ay, az, 0, 0, 0, 0.01)
+			}
+			// assert
+			assert.InDelta(t, tc.roll, roll, 0.5*deg)
+			assert.InDelta(t, tc.pitch, pitch, 0.5*deg)
+			// the yaw is not observable by the gravity, so it can change during convergence of combined angles
+		})
+	}
+}
+
+func TestMadgwickFilterUpdate_integrateYaw(t *testing.T) {
+	// arrange
+	f := NewMadgwickFilter(0.1)
+	ax, ay, az := gravity(0, 0)
+	// act: rotate flat with 0.5 rad/s for 1 second
+	for i := 0; i < 100; i++ {
+		f.Update(ax, ay, az, 0, 0, 0.5, 0.01)
+	}
+	// assert
+	assert.InDelta(t, 0.5, f.Yaw(), 0.1*deg)
+	roll, pitch := f.Angles()
+	assert.InDelta(t, 0.0, roll, 0.1*deg)
+	assert.InDelta(t, 0.0, pitch, 0.1*deg)
+}
+
+func TestMadgwickFilterUpdate_trackRoll(t *testing.T) {
+	// arrange
+	f := NewMadgwickFilter(0.1)
+	// act: roll with 0.5 rad/s for 1 second, the accelerometer follows the rotation
+	var roll float64
+	for i := 1; i <= 100; i++ {
+		ax, ay, az := gravity(0.5*float64(i)*0.01, 0)
+		roll, _ = f.Update(ax, ay, az, 0.5, 0, 0, 0.01)
+	}
+	// assert
+	assert.InDelta(t, 0.5, roll, 0.5*deg)
+}
+
+func TestMadgwickFilterUpdate_freeFall(t *testing.T) {
+	// arrange
+	f := NewMadgwickFilter(0.1)
+	// act: no acceleration, only the gyroscope is integrated
+	for i := 0; i < 100; i++ {
+		f.Update(0, 0, 0, 0.2, 0, 0, 0.01)
+	}
+	// assert
+	roll, _ := f.Angles()
+	assert.InDelta(t, 0.2, roll, 1e-6)
+}
+
+func TestMadgwickFilterReset(t *testing.T) {
+	// arrange
+	f := NewMadgwickFilter(0.1)
+	f.Update(0, 0, 0, 0.2, 0.1, 0, 0.1)
+	// act
+	f.Reset()
+	// assert
+	assert.Equal(t, [4]float64{1, 0, 0, 0}, f.Quaternion())
+}
@@ -0,0 +1,12 @@
+/*
+Package measurement provides a value type, which carries the unit of a sensor reading, so temperatures and distances
+can be passed around without losing the information whether e.g. °C or °F, cm or mm is meant.
+
+Drivers of temperature and distance sensors return such values by their "*Measurement" methods, additionally to the
+methods with plain float values. The value can be converted to each unit of the same quantity, e.g.
+
+	m, err := sensor.TemperatureMeasurement()
+	...
+	fahrenheit, err := m.Fahrenheit()
+*/
+package measurement // import "gobot.io/x/gobot/v2/drivers/common/measurement"
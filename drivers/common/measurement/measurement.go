@@ -0,0 +1,165 @@
+package measurement
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const kelvinOffset = 273.15
+
+// Unit is the unit of a measured value.
+type Unit int
+
+// Units of temperatures
+const (
+	Celsius Unit = iota + 1
+	Fahrenheit
+	Kelvin
+)
+
+// Units of lengths, e.g. distances
+const (
+	Meter Unit = iota + 10
+	Centimeter
+	Millimeter
+)
+
+// quantity is the kind of the measured value, only units of the same quantity can be converted into each other
+type quantity int
+
+const (
+	quantityUnknown quantity = iota
+	quantityTemperature
+	quantityLength
+)
+
+// Measurement is a measured value together with its unit.
+type Measurement struct {
+	Value float64
+	Unit  Unit
+}
+
+// New creates a new measurement with the given value and unit.
+func New(value float64, unit Unit) Measurement {
+	return Measurement{Value: value, Unit: unit}
+}
+
+// String returns the symbol of the unit, e.g. "°C" or "mm".
+func (u Unit) String() string {
+	switch u {
+	case Celsius:
+		return "°C"
+	case Fahrenheit:
+		return "°F"
+	case Kelvin:
+		return "K"
+	case Meter:
+		return "m"
+	case Centimeter:
+		return "cm"
+	case Millimeter:
+		return "mm"
+	default:
+		return fmt.Sprintf("unknown unit (%d)", int(u))
+	}
+}
+
+// String returns the value with the symbol of the unit, e.g. "21.5 °C".
+func (m Measurement) String() string {
+	return strconv.FormatFloat(m.Value, 'f', -1, 64) + " " + m.Unit.String()
+}
+
+// Convert returns the measurement in the given unit. An error is returned, if the unit is not of the same quantity,
+// e.g. a temperature can not be converted to a length.
+func (m Measurement) Convert(unit Unit) (Measurement, error) {
+	if m.Unit.quantity() == quantityUnknown || m.Unit.quantity() != unit.quantity() {
+		return Measurement{}, fmt.Errorf("'%s' can not be converted to '%s'", m.Unit, unit)
+	}
+
+	// convert to the base unit (°C or m) and from there to the wanted unit
+	base := m.Unit.toBase(m.Value)
+
+	return Measurement{Value: unit.fromBase(base), Unit: unit}, nil
+}
+
+// Celsius returns the temperature in °C.
+func (m Measurement) Celsius() (float64, error) {
+	return m.valueIn(Celsius)
+}
+
+// Fahrenheit returns the temperature in °F.
+func (m Measurement) Fahrenheit() (float64, error) {
+	return m.valueIn(Fahrenheit)
+}
+
+// Kelvin returns the temperature in K.
+func (m Measurement) Kelvin() (float64, error) {
+	return m.valueIn(Kelvin)
+}
+
+// Meters returns the length in m.
+func (m Measurement) Meters() (float64, error) {
+	return m.valueIn(Meter)
+}
+
+// Centimeters returns the length in cm.
+func (m Measurement) Centimeters() (float64, error) {
+	return m.valueIn(Centimeter)
+}
+
+// Millimeters returns the length in mm.
+func (m Measurement) Millimeters() (float64, error) {
+	return m.valueIn(Millimeter)
+}
+
+func (m Measurement) valueIn(unit Unit) (float64, error) {
+	converted, err := m.Convert(unit)
+	if err != nil {
+		return 0, err
+	}
+
+	return converted.Value, nil
+}
+
+func (u Unit) quantity() quantity {
+	switch u {
+	case Celsius, Fahrenheit, Kelvin:
+		return quantityTemperature
+	case Meter, Centimeter, Millimeter:
+		return quantityLength
+	default:
+		return quantityUnknown
+	}
+}
+
+// toBase converts the value of the unit to °C or m
+func (u Unit) toBase(value float64) float64 {
+	switch u {
+	case Fahrenheit:
+		return (value - 32) * 5 / 9
+	case Kelvin:
+		return value - kelvinOffset
+	case Centimeter:
+		return value / 100
+	case Millimeter:
+		return value / 1000
+	default:
+		return value
+	}
+}
+
+// fromBase converts the value in °C or m to the unit
+func (u Unit) fromBase(value float64) float64 {
+	switch u {
+	case Fahrenheit:
+		return value*9/5 + 32
+	case Kelvin:
+		return value + kelvinOffset
+	case Centimeter:
+		return value * 100
+	case Millimeter:
+		return value * 1000
+	default:
+		return value
+	}
+}
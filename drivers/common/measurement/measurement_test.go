@@ -0,0 +1,122 @@
+package measurement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	// act
+	m := New(21.5, Celsius)
+	// assert
+	assert.Equal(t, Measurement{Value: 21.5, Unit: Celsius}, m)
+	assert.Equal(t, "21.5 °C", m.String())
+}
+
+func TestUnitString(t *testing.T) {
+	tests := map[Unit]string{
+		Celsius:    "°C",
+		Fahrenheit: "°F",
+		Kelvin:     "K",
+		Meter:      "m",
+		Centimeter: "cm",
+		Millimeter: "mm",
+		Unit(0):    "unknown unit (0)",
+	}
+	for unit, want := range tests {
+		t.Run(want, func(t *testing.T) {
+			assert.Equal(t, want, unit.String())
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := map[string]struct {
+		from    Measurement
+		to      Unit
+		want    float64
+		wantErr string
+	}{
+		"celsius_to_fahrenheit": {from: New(100, Celsius), to: Fahrenheit, want: 212},
+		"celsius_to_kelvin":     {from: New(-273.15, Celsius), to: Kelvin, want: 0},
+		"fahrenheit_to_celsius": {from: New(-40, Fahrenheit), to: Celsius, want: -40},
+		"fahrenheit_to_kelvin":  {from: New(32, Fahrenheit), to: Kelvin, want: 273.15},
+		"kelvin_to_celsius":     {from: New(300, Kelvin), to: Celsius, want: 26.85},
+		"kelvin_to_fahrenheit":  {from: New(373.15, Kelvin), to: Fahrenheit, want: 212},
+		"celsius_to_celsius":    {from: New(21.5, Celsius), to: Celsius, want: 21.5},
+		"meter_to_centimeter":   {from: New(1.25, Meter), to: Centimeter, want: 125},
+		"meter_to_millimeter":   {from: New(0.5, Meter), to: Millimeter, want: 500},
+		"centimeter_to_meter":   {from: New(42, Centimeter), to: Meter, want: 0.42},
+		"centimeter_to_mm":      {from: New(4.2, Centimeter), to: Millimeter, want: 42},
+		"millimeter_to_meter":   {from: New(1500, Millimeter), to: Meter, want: 1.5},
+		"millimeter_to_cm":      {from: New(15, Millimeter), to: Centimeter, want: 1.5},
+		"error_temperature_to_length": {
+			from:    New(20, Celsius),
+			to:      Meter,
+			wantErr: "'°C' can not be converted to 'm'",
+		},
+		"error_length_to_temperature": {
+			from:    New(20, Millimeter),
+			to:      Kelvin,
+			wantErr: "'mm' can not be converted to 'K'",
+		},
+		"error_unknown_unit": {
+			from:    Measurement{Value: 1},
+			to:      Meter,
+			wantErr: "'unknown unit (0)' can not be converted to 'm'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := tc.from.Convert(tc.to)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Equal(t, Measurement{}, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.to, got.Unit)
+			assert.InDelta(t, tc.want, got.Value, 1e-9)
+		})
+	}
+}
+
+func TestTemperatureHelpers(t *testing.T) {
+	// arrange
+	m := New(25, Celsius)
+	// act
+	celsius, errC := m.Celsius()
+	fahrenheit, errF := m.Fahrenheit()
+	kelvin, errK := m.Kelvin()
+	_, errM := m.Meters()
+	// assert
+	require.NoError(t, errC)
+	require.NoError(t, errF)
+	require.NoError(t, errK)
+	assert.InDelta(t, 25.0, celsius, 1e-9)
+	assert.InDelta(t, 77.0, fahrenheit, 1e-9)
+	assert.InDelta(t, 298.15, kelvin, 1e-9)
+	require.EqualError(t, errM, "'°C' can not be converted to 'm'")
+}
+
+func TestLengthHelpers(t *testing.T) {
+	// arrange
+	m := New(123, Millimeter)
+	// act
+	meters, errM := m.Meters()
+	centimeters, errCm := m.Centimeters()
+	millimeters, errMm := m.Millimeters()
+	_, errC := m.Celsius()
+	// assert
+	require.NoError(t, errM)
+	require.NoError(t, errCm)
+	require.NoError(t, errMm)
+	assert.InDelta(t, 0.123, meters, 1e-9)
+	assert.InDelta(t, 12.3, centimeters, 1e-9)
+	assert.InDelta(t, 123.0, millimeters, 1e-9)
+	require.EqualError(t, errC, "'mm' can not be converted to '°C'")
+}
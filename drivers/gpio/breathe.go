@@ -0,0 +1,109 @@
+package gpio
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// breatheDefaultInterval is the default time between two updates of the level, which gives a smooth curve for the
+// human eye
+const breatheDefaultInterval = 20 * time.Millisecond
+
+// Breathe generates a sinusoidal PWM level, e.g. for the breathing effect of a LED or a smoothly swelling speed of a
+// motor. The level is written by a callback, so it can be used for each PWM capable driver, e.g.
+// "NewBreathe(led.Brightness, 2*time.Second, 0, 255)" or "NewBreathe(motor.SetSpeed, 10*time.Second, 50, 200)".
+type Breathe struct {
+	setLevel func(level byte) error
+	period   time.Duration
+	minLevel byte
+	maxLevel byte
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewBreathe creates a new breathing generator, which calls the given function with a level between the min and max
+// level. One cycle takes the given period, it starts with the min level and reaches the max level after the half
+// period.
+func NewBreathe(setLevel func(level byte) error, period time.Duration, minLevel, maxLevel byte) *Breathe {
+	if setLevel == nil {
+		panic("the function to set the level is mandatory for breathe")
+	}
+	if period <= 0 {
+		panic(fmt.Sprintf("period (%s) of breathe must be greater than zero", period))
+	}
+	if minLevel > maxLevel {
+		panic(fmt.Sprintf("min level (%d) of breathe must not be greater than max level (%d)", minLevel, maxLevel))
+	}
+
+	return &Breathe{
+		setLevel: setLevel,
+		period:   period,
+		minLevel: minLevel,
+		maxLevel: maxLevel,
+		interval: breatheDefaultInterval,
+		now:      time.Now,
+	}
+}
+
+// SetInterval sets the time between two updates of the level (default 20 ms). A shorter interval gives a smoother
+// curve, but causes more writes to the adaptor. This has no effect on a running generator.
+func (b *Breathe) SetInterval(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("update interval (%s) of breathe must be greater than zero", interval)
+	}
+
+	b.interval = interval
+
+	return nil
+}
+
+// Interval returns the time between two updates of the level, see SetInterval().
+func (b *Breathe) Interval() time.Duration {
+	return b.interval
+}
+
+// Period returns the duration of one cycle.
+func (b *Breathe) Period() time.Duration {
+	return b.period
+}
+
+// Range returns the min and max level.
+func (b *Breathe) Range() (byte, byte) {
+	return b.minLevel, b.maxLevel
+}
+
+// Level returns the level at the given time since the start of the first cycle.
+func (b *Breathe) Level(elapsed time.Duration) byte {
+	phase := float64(elapsed%b.period) / float64(b.period)
+	if phase < 0 {
+		phase++
+	}
+
+	// raised cosine, which starts at 0, reaches 1 at the half period and returns to 0
+	factor := (1 - math.Cos(2*math.Pi*phase)) / 2
+
+	return b.minLevel + byte(math.Round(factor*float64(b.maxLevel-b.minLevel)))
+}
+
+// Run writes the level of the curve repeatedly, until the context is cancelled or the writing fails. This blocks, so
+// it is normally called in a go routine. The first level is written immediately. On cancellation nil is returned and
+// the last written level is kept, so it is up to the caller to switch off the output afterwards.
+func (b *Breathe) Run(ctx context.Context) error {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	start := b.now()
+	for {
+		if err := b.setLevel(b.Level(b.now().Sub(start))); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package gpio
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBreathe(t *testing.T) {
+	// arrange
+	setLevel := func(byte) error { return nil }
+	// act
+	b := NewBreathe(setLevel, 2*time.Second, 10, 200)
+	// assert
+	assert.Equal(t, 2*time.Second, b.Period())
+	assert.Equal(t, 20*time.Millisecond, b.Interval())
+	minLevel, maxLevel := b.Range()
+	assert.Equal(t, byte(10), minLevel)
+	assert.Equal(t, byte(200), maxLevel)
+	assert.NotNil(t, b.setLevel)
+	assert.NotNil(t, b.now)
+}
+
+func TestNewBreathe_panics(t *testing.T) {
+	setLevel := func(byte) error { return nil }
+	assert.PanicsWithValue(t, "the function to set the level is mandatory for breathe",
+		func() { _ = NewBreathe(nil, time.Second, 0, 255) })
+	assert.PanicsWithValue(t, "period (0s) of breathe must be greater than zero",
+		func() { _ = NewBreathe(setLevel, 0, 0, 255) })
+	assert.PanicsWithValue(t, "min level (100) of breathe must not be greater than max level (99)",
+		func() { _ = NewBreathe(setLevel, time.Second, 100, 99) })
+}
+
+func TestBreatheSetInterval(t *testing.T) {
+	// arrange
+	b := NewBreathe(func(byte) error { return nil }, time.Second, 0, 255)
+	// act & assert
+	require.NoError(t, b.SetInterval(5*time.Millisecond))
+	assert.Equal(t, 5*time.Millisecond, b.Interval())
+	require.EqualError(t, b.SetInterval(0), "update interval (0s) of breathe must be greater than zero")
+	assert.Equal(t, 5*time.Millisecond, b.Interval())
+}
+
+func TestBreatheLevel(t *testing.T) {
+	tests := map[string]struct {
+		minLevel byte
+		maxLevel byte
+		elapsed  []time.Duration
+		want     []byte
+	}{
+		"full_range_one_cycle": {
+			maxLevel: 255,
+			elapsed: []time.Duration{
+				0, 100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 400 * time.Millisecond,
+				500 * time.Millisecond, 600 * time.Millisecond, 700 * time.Millisecond, 800 * time.Millisecond,
+				900 * time.Millisecond, time.Second,
+			},
+			want: []byte{0, 24, 88, 167, 231, 255, 231, 167, 88, 24, 0},
+		},
+		"limited_range": {
+			minLevel: 50,
+			maxLevel: 200,
+			elapsed: []time.Duration{
+				0, 250 * time.Millisecond, 500 * time.Millisecond, 750 * time.Millisecond, time.Second,
+			},
+			want: []byte{50, 125, 200, 125, 50},
+		},
+		"next_cycles": {
+			maxLevel: 255,
+			elapsed:  []time.Duration{1500 * time.Millisecond, 2100 * time.Millisecond, 10 * time.Second},
+			want:     []byte{255, 24, 0},
+		},
+		"constant": {
+			minLevel: 80,
+			maxLevel: 80,
+			elapsed:  []time.Duration{0, 500 * time.Millisecond},
+			want:     []byte{80, 80},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			b := NewBreathe(func(byte) error { return nil }, time.Second, tc.minLevel, tc.maxLevel)
+			for i, elapsed := range tc.elapsed {
+				// act
+				got := b.Level(elapsed)
+				// assert
+				assert.Equal(t, tc.want[i], got, "elapsed %s", elapsed)
+			}
+		})
+	}
+}
+
+func TestBreatheLevel_curve(t *testing.T) {
+	// arrange
+	const period = 2 * time.Second
+	b := NewBreathe(func(byte) error { return nil }, period, 0, 255)
+	// act & assert
+	for elapsed := time.Duration(0); elapsed < period/2; elapsed += time.Millisecond {
+		// rising in the first half and symmetric to the second half, except rounding
+		assert.LessOrEqual(t, b.Level(elapsed), b.Level(elapsed+time.Millisecond), "elapsed %s", elapsed)
+		assert.InDelta(t, b.Level(elapsed), b.Level(period-elapsed), 1, "elapsed %s", elapsed)
+	}
+}
+
+func TestBreatheRun(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	var levels []byte
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a.pwmWriteFunc = func(pin string, val byte) error {
+		assert.Equal(t, "1", pin)
+		levels = append(levels, val)
+		if len(levels) == 11 {
+			cancel()
+		}
+		return nil
+	}
+	led := NewLedDriver(a, "1")
+	b := NewBreathe(led.Brightness, time.Second, 0, 255)
+	require.NoError(t, b.SetInterval(time.Millisecond))
+	// each update is done 100 ms later
+	now := time.Now()
+	b.now = func() time.Time {
+		now = now.Add(100 * time.Millisecond)
+		return now
+	}
+	// act
+	err := b.Run(ctx)
+	// assert
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(levels), 11)
+	assert.Equal(t, []byte{24, 88, 167, 231, 255, 231, 167, 88, 24, 0, 24}, levels[:11])
+}
+
+func TestBreatheRun_error(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	var calls int
+	a.pwmWriteFunc = func(string, byte) error {
+		calls++
+		if calls == 3 {
+			return errors.New("pwm error")
+		}
+		return nil
+	}
+	motor := NewMotorDriver(a, "1")
+	b := NewBreathe(motor.SetSpeed, time.Second, 50, 200)
+	require.NoError(t, b.SetInterval(time.Millisecond))
+	// act
+	err := b.Run(context.Background())
+	// assert
+	require.EqualError(t, err, "pwm error")
+	assert.Equal(t, 3, calls)
+}
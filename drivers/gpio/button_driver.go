@@ -7,6 +7,10 @@ import (
 	"gobot.io/x/gobot/v2"
 )
 
+// buttonTouchDebounceTime is the debounce time of the touch profile, capacitive touch sensors tend to flicker near
+// the detection threshold
+const buttonTouchDebounceTime = 30 * time.Millisecond
+
 // buttonOptionApplier needs to be implemented by each configurable option type
 type buttonOptionApplier interface {
 	apply(cfg *buttonConfiguration)
@@ -16,6 +20,8 @@ type buttonOptionApplier interface {
 type buttonConfiguration struct {
 	readInterval time.Duration
 	defaultState int
+	debounceTime time.Duration
+	heartbeat    time.Duration
 }
 
 // buttonReadIntervalOption is the type for applying another read interval to the configuration
@@ -24,13 +30,27 @@ type buttonReadIntervalOption time.Duration
 // buttonDefaultStateOption is the type for applying another default state to the configuration
 type buttonDefaultStateOption int
 
+// buttonActiveHighOption is the type for applying the active level to the configuration
+type buttonActiveHighOption bool
+
+// buttonDebounceOption is the type for applying a debounce time to the configuration
+type buttonDebounceOption time.Duration
+
+// buttonHeartbeatOption is the type for applying a heartbeat interval to the configuration
+type buttonHeartbeatOption time.Duration
+
+// buttonTouchProfileOption is the type for applying the settings for capacitive touch sensors to the configuration
+type buttonTouchProfileOption struct{}
+
 // ButtonDriver Represents a digital Button
 type ButtonDriver struct {
 	*driver
 	buttonCfg *buttonConfiguration
 	gobot.Eventer
-	active bool
-	halt   chan struct{}
+	active    bool
+	halt      chan struct{}
+	debouncer *debouncer
+	detector  *valueChangeDetector
 }
 
 // NewButtonDriver returns a driver for a button with a polling interval for changed state of 10 milliseconds,
@@ -40,6 +60,11 @@ type ButtonDriver struct {
 //
 //	"WithName"
 //	"WithButtonPollInterval"
+//	"WithButtonDefaultState"
+//	"WithButtonActiveHigh"
+//	"WithButtonDebounce"
+//	"WithButtonTouchProfile"
+//	"WithButtonHeartbeat"
 func NewButtonDriver(a DigitalReader, pin string, opts ...interface{}) *ButtonDriver {
 	//nolint:forcetypeassert // no error return value, so there is no better way
 	d := &ButtonDriver{
@@ -66,7 +91,8 @@ func NewButtonDriver(a DigitalReader, pin string, opts ...interface{}) *ButtonDr
 	return d
 }
 
-// WithButtonPollInterval change the asynchronous cyclic reading interval from default 10ms to the given value.
+// WithButtonPollInterval change the asynchronous cyclic reading interval from default 10ms to the given value. A zero
+// interval deactivates the cyclic reading, e.g. if the button is polled by a [gobot.PollGroup].
 func WithButtonPollInterval(interval time.Duration) buttonOptionApplier {
 	return buttonReadIntervalOption(interval)
 }
@@ -76,6 +102,34 @@ func WithButtonDefaultState(s int) buttonOptionApplier {
 	return buttonDefaultStateOption(s)
 }
 
+// WithButtonActiveHigh configures the level of a pushed button. With true (default), the pin is high while the button
+// is pushed, e.g. for a button wired to VCC with a pull-down resistor or a Grove touch module. With false, the pin is
+// low while the button is pushed, e.g. for a button wired to GND with a pull-up resistor. This is an alternative to
+// [gpio.WithButtonDefaultState].
+func WithButtonActiveHigh(activeHigh bool) buttonOptionApplier {
+	return buttonActiveHighOption(activeHigh)
+}
+
+// WithButtonDebounce change the debounce time from default 0 (no debouncing) to the given value. A changed state is
+// only taken over and published, if it is stable for at least this time.
+func WithButtonDebounce(debounceTime time.Duration) buttonOptionApplier {
+	return buttonDebounceOption(debounceTime)
+}
+
+// WithButtonTouchProfile configures the driver for capacitive touch sensors, e.g. Grove touch modules. The sensor is
+// active high and the state is debounced by 30ms, because the output can flicker near the detection threshold. The
+// push and release events are the same like for a button.
+func WithButtonTouchProfile() buttonOptionApplier {
+	return buttonTouchProfileOption{}
+}
+
+// WithButtonHeartbeat change the heartbeat interval from default 0 (deactivated) to the given value. Normally an
+// event is only published on a changed state. With a heartbeat, the event of the current state is published again,
+// if the state was not changed within this time.
+func WithButtonHeartbeat(interval time.Duration) buttonOptionApplier {
+	return buttonHeartbeatOption(interval)
+}
+
 // Active gets the current state
 func (d *ButtonDriver) Active() bool {
 	// ensure that read and write can not interfere
@@ -95,7 +149,36 @@ func (d *ButtonDriver) SetDefaultState(s int) {
 	WithButtonDefaultState(s).apply(d.buttonCfg)
 }
 
-// initialize the ButtonDriver and polls the state of the button at the given interval.
+// Poll reads the state of the button once and publishes the events like the cyclic reading. This allows the polling
+// of the button by a [gobot.PollGroup], usually with a deactivated cyclic reading, see [gpio.WithButtonPollInterval].
+// The driver needs to be started before.
+func (d *ButtonDriver) Poll() error {
+	d.mutex.Lock()
+	started := d.Eventer != nil
+	d.mutex.Unlock()
+
+	if !started {
+		return fmt.Errorf("the button '%s' needs to be started before polling", d.driverCfg.name)
+	}
+
+	newValue, err := d.digitalRead(d.driverCfg.pin)
+	if err != nil {
+		d.Publish(Error, err)
+		return err
+	}
+	if newValue == -1 {
+		return nil
+	}
+
+	if state, changed := d.detectChange(newValue, time.Now()); changed {
+		d.update(state)
+	}
+
+	return nil
+}
+
+// initialize the ButtonDriver and if the cyclic reading is active, polls the state of the button at the given
+// interval.
 //
 // Emits the Events:
 //
@@ -103,30 +186,27 @@ func (d *ButtonDriver) SetDefaultState(s int) {
 //	Release int - On button release
 //	Error error - On button error
 func (d *ButtonDriver) initialize() error {
-	if d.buttonCfg.readInterval == 0 {
-		return fmt.Errorf("the read interval for button needs to be greater than zero")
-	}
-
 	d.Eventer = gobot.NewEventer()
 	d.AddEvent(ButtonPush)
 	d.AddEvent(ButtonRelease)
 	d.AddEvent(Error)
 
-	d.halt = make(chan struct{})
-
 	state := d.buttonCfg.defaultState
+	d.debouncer = newDebouncer(state, d.buttonCfg.debounceTime)
+	d.detector = newValueChangeDetector(state, d.buttonCfg.heartbeat)
+
+	if d.buttonCfg.readInterval == 0 {
+		// cyclic reading deactivated
+		return nil
+	}
+
+	d.halt = make(chan struct{})
 
 	go func() {
 		for {
 			select {
 			case <-time.After(d.buttonCfg.readInterval):
-				newValue, err := d.digitalRead(d.driverCfg.pin)
-				if err != nil {
-					d.Publish(Error, err)
-				} else if newValue != state && newValue != -1 {
-					state = newValue
-					d.update(newValue)
-				}
+				_ = d.Poll() // the error is already published
 			case <-d.halt:
 				return
 			}
@@ -145,6 +225,17 @@ func (d *ButtonDriver) shutdown() error {
 	return nil
 }
 
+// detectChange debounces the given value, read at the given time, and returns the resulting state and whether it
+// needs to be published.
+func (d *ButtonDriver) detectChange(newValue int, now time.Time) (int, bool) {
+	// ensure that read and write can not interfere
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	state := d.debouncer.debounce(newValue, now)
+	return state, d.detector.changed(state)
+}
+
 func (d *ButtonDriver) update(newValue int) {
 	// ensure that read and write can not interfere
 	d.mutex.Lock()
@@ -167,6 +258,22 @@ func (o buttonDefaultStateOption) String() string {
 	return "default state option for buttons"
 }
 
+func (o buttonActiveHighOption) String() string {
+	return "active high option for buttons"
+}
+
+func (o buttonDebounceOption) String() string {
+	return "debounce option for buttons"
+}
+
+func (o buttonHeartbeatOption) String() string {
+	return "heartbeat option for buttons"
+}
+
+func (o buttonTouchProfileOption) String() string {
+	return "touch profile option for buttons"
+}
+
 func (o buttonReadIntervalOption) apply(cfg *buttonConfiguration) {
 	cfg.readInterval = time.Duration(o)
 }
@@ -174,3 +281,24 @@ func (o buttonReadIntervalOption) apply(cfg *buttonConfiguration) {
 func (o buttonDefaultStateOption) apply(cfg *buttonConfiguration) {
 	cfg.defaultState = int(o)
 }
+
+func (o buttonActiveHighOption) apply(cfg *buttonConfiguration) {
+	if o {
+		cfg.defaultState = 0
+	} else {
+		cfg.defaultState = 1
+	}
+}
+
+func (o buttonDebounceOption) apply(cfg *buttonConfiguration) {
+	cfg.debounceTime = time.Duration(o)
+}
+
+func (o buttonHeartbeatOption) apply(cfg *buttonConfiguration) {
+	cfg.heartbeat = time.Duration(o)
+}
+
+func (o buttonTouchProfileOption) apply(cfg *buttonConfiguration) {
+	buttonActiveHighOption(true).apply(cfg)
+	cfg.debounceTime = buttonTouchDebounceTime
+}
@@ -14,7 +14,10 @@ import (
 	"gobot.io/x/gobot/v2/drivers/aio"
 )
 
-var _ gobot.Driver = (*ButtonDriver)(nil)
+var (
+	_ gobot.Driver = (*ButtonDriver)(nil)
+	_ gobot.Poller = (*ButtonDriver)(nil)
+)
 
 const buttonTestDelay = 250
 
@@ -197,6 +200,54 @@ func TestButtonStart_WithDefaultState(t *testing.T) {
 	}
 }
 
+func TestButtonPoll_byPollGroup(t *testing.T) {
+	// arrange: the button is polled by the group instead of the cyclic reading
+	a := newGpioTestAdaptor()
+	reads := []int{1, 1, -2, -1, 0}
+	a.digitalReadFunc = func(string) (int, error) {
+		val := reads[0]
+		reads = reads[1:]
+		if val == -2 {
+			return 0, fmt.Errorf("digital read error")
+		}
+		return val, nil
+	}
+	d := NewButtonDriver(a, "1", WithButtonPollInterval(0))
+	g := gobot.NewPollGroup(time.Hour)
+	g.Add(d)
+	require.ErrorContains(t, d.Poll(), "needs to be started before polling")
+	require.NoError(t, d.Start())
+	assert.Nil(t, d.halt) // no cyclic reading
+	events := d.Subscribe()
+	var got []string
+	next := func() {
+		select {
+		case evt := <-events:
+			got = append(got, fmt.Sprintf("%s=%v", evt.Name, evt.Data))
+		case <-time.After(time.Second):
+			t.Errorf("Button event was not published")
+		}
+	}
+	// act & assert: the events are the same like for the cyclic reading
+	assert.Equal(t, 0, g.PollAll())
+	next()
+	assert.True(t, d.Active())
+	assert.Equal(t, 0, g.PollAll()) // unchanged state, no event
+	assert.Equal(t, 1, g.PollAll())
+	next()
+	assert.Equal(t, 0, g.PollAll()) // no value, no event
+	assert.Equal(t, 0, g.PollAll())
+	next()
+	assert.False(t, d.Active())
+	assert.Equal(t, []string{"push=1", "error=digital read error", "release=0"}, got)
+	select {
+	case evt := <-events:
+		t.Errorf("unexpected event %s", evt.Name)
+	case <-time.After(10 * time.Millisecond):
+	}
+	require.NoError(t, d.Halt())
+}
+
 func TestButtonHalt(t *testing.T) {
 	// arrange
 	d, _ := initTestButtonDriverWithStubbedAdaptor()
@@ -234,3 +285,180 @@ func TestButtonActive(t *testing.T) {
 		})
 	}
 }
+
+func TestButton_WithButtonActiveHigh_WithButtonDebounce_WithButtonTouchProfile(t *testing.T) {
+	// arrange
+	cfg := buttonConfiguration{defaultState: 5, readInterval: time.Millisecond}
+	// act & assert
+	WithButtonActiveHigh(false).apply(&cfg)
+	assert.Equal(t, 1, cfg.defaultState)
+	WithButtonActiveHigh(true).apply(&cfg)
+	assert.Equal(t, 0, cfg.defaultState)
+	WithButtonDebounce(5 * time.Millisecond).apply(&cfg)
+	assert.Equal(t, 5*time.Millisecond, cfg.debounceTime)
+	cfg.defaultState = 1
+	WithButtonTouchProfile().apply(&cfg)
+	assert.Equal(t, buttonConfiguration{readInterval: time.Millisecond, defaultState: 0, debounceTime: 30 * time.Millisecond},
+		cfg)
+}
+
+func TestButtonStart_activeLevelAndDebounce(t *testing.T) {
+	const (
+		pushed   = "push"
+		released = "release"
+	)
+	tests := map[string]struct {
+		opts       []interface{}
+		idle       int
+		values     []int // each value is returned for one read, the last one is repeated
+		wantEvents []string
+		wantData   []int
+	}{
+		"active_high": {
+			opts:       []interface{}{WithButtonActiveHigh(true)},
+			idle:       0,
+			values:     []int{0, 1, 1, 1, 0},
+			wantEvents: []string{pushed, released},
+			wantData:   []int{1, 0},
+		},
+		"active_low": {
+			opts:       []interface{}{WithButtonActiveHigh(false)},
+			idle:       1,
+			values:     []int{1, 0, 0, 0, 1},
+			wantEvents: []string{pushed, released},
+			wantData:   []int{0, 1},
+		},
+		"active_high_without_debounce_flicker": {
+			opts:       []interface{}{WithButtonActiveHigh(true)},
+			idle:       0,
+			values:     []int{1, 0, 1, 0},
+			wantEvents: []string{pushed, released, pushed, released},
+			wantData:   []int{1, 0, 1, 0},
+		},
+		"touch_debounce_suppress_flicker": {
+			opts:   []interface{}{WithButtonTouchProfile(), WithButtonDebounce(10 * time.Millisecond)},
+			idle:   0,
+			values: append(append([]int{1, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, repeatInt(1, 30)...), 0, 1, 0),
+			// the first flicker and the short release is ignored
+			wantEvents: []string{pushed, released},
+			wantData:   []int{1, 0},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			var mtx sync.Mutex
+			var begin bool
+			var idx int
+			idle, values := tc.idle, tc.values // the read can be called once more after halt
+			a := newGpioTestAdaptor()
+			a.digitalReadFunc = func(string) (int, error) {
+				mtx.Lock()
+				defer mtx.Unlock()
+				if !begin {
+					return idle, nil
+				}
+				val := values[idx]
+				if idx < len(values)-1 {
+					idx++
+				}
+				return val, nil
+			}
+			d := NewButtonDriver(a, "1", append(tc.opts, WithButtonPollInterval(time.Millisecond))...)
+			require.NoError(t, d.Start())
+			defer func() { _ = d.Halt() }()
+			events := d.Subscribe()
+			defer d.Unsubscribe(events)
+			// act
+			mtx.Lock()
+			begin = true
+			mtx.Unlock()
+			// assert
+			var gotEvents []string
+			var gotData []int
+			timeout := time.After(buttonTestDelay * time.Millisecond)
+			for len(gotEvents) < len(tc.wantEvents) {
+				select {
+				case evt := <-events:
+					gotEvents = append(gotEvents, evt.Name)
+					gotData = append(gotData, evt.Data.(int))
+				case <-timeout:
+					require.Fail(t, "missing events", "got %v, want %v", gotEvents, tc.wantEvents)
+				}
+			}
+			assert.Equal(t, tc.wantEvents, gotEvents)
+			assert.Equal(t, tc.wantData, gotData)
+			assert.False(t, d.Active())
+			select {
+			case evt := <-events:
+				assert.Fail(t, "unexpected event", "%s", evt.Name)
+			case <-time.After(20 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func repeatInt(val int, count int) []int {
+	vals := make([]int, count)
+	for i := range vals {
+		vals[i] = val
+	}
+	return vals
+}
+
+func TestButtonStart_changeDetection(t *testing.T) {
+	tests := map[string]struct {
+		opts       []interface{}
+		value      int
+		wantEvents []string
+	}{
+		"unchanged_no_event": {
+			value: 0,
+		},
+		"changed_one_event": {
+			value:      1,
+			wantEvents: []string{ButtonPush},
+		},
+		"unchanged_heartbeat": {
+			opts:       []interface{}{WithButtonHeartbeat(20 * time.Millisecond)},
+			value:      0,
+			wantEvents: []string{ButtonRelease, ButtonRelease},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			value := tc.value // the read can be called once more after halt
+			a := newGpioTestAdaptor()
+			a.digitalReadFunc = func(string) (int, error) {
+				return value, nil
+			}
+			d := NewButtonDriver(a, "1", append(tc.opts, WithButtonPollInterval(time.Millisecond))...)
+			// act
+			require.NoError(t, d.Start())
+			defer func() { _ = d.Halt() }()
+			events := d.Subscribe()
+			defer d.Unsubscribe(events)
+			// assert
+			var gotEvents []string
+			timeout := time.After(50 * time.Millisecond)
+			for done := false; !done; {
+				select {
+				case evt := <-events:
+					gotEvents = append(gotEvents, evt.Name)
+				case <-timeout:
+					done = true
+				}
+			}
+			if len(tc.wantEvents) < 2 {
+				assert.Equal(t, tc.wantEvents, gotEvents)
+			} else {
+				// the count of heartbeats depends on the scheduling, but each event is the same
+				assert.GreaterOrEqual(t, len(gotEvents), len(tc.wantEvents))
+				for _, got := range gotEvents {
+					assert.Equal(t, tc.wantEvents[0], got)
+				}
+			}
+		})
+	}
+}
@@ -140,6 +140,7 @@ type BuzzerDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithVerifiedWrite"
 func NewBuzzerDriver(a DigitalWriter, pin string, opts ...interface{}) *BuzzerDriver {
 	//nolint:forcetypeassert // no error return value, so there is no better way
 	d := &BuzzerDriver{
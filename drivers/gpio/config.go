@@ -0,0 +1,293 @@
+package gpio
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// Config describes the GPIO drivers of a robot, e.g. parsed from a JSON or YAML file by ParseConfigJSON() or
+// ParseConfigYAML(). The drivers are created by NewDriversFromConfig().
+type Config struct {
+	Drivers []DriverConfig `json:"drivers" yaml:"drivers"`
+}
+
+// DriverConfig describes a single GPIO driver. The type is one of "easydriver", "led", "button", "servo" or "relay"
+// (case insensitive). The pin is the main pin of the driver, e.g. the step pin of the EasyDriver. Additional pins and
+// parameters depend on the type:
+//
+//	easydriver: pins "dir", "enable", "sleep"; params "anglePerStep" (mandatory), "speed" (rpm)
+//	button: params "pollInterval", "debounce" (duration string, e.g. "10ms"), "defaultState", "activeHigh"
+//	relay: params "inverted"
+//
+// The name is optional, without name the default name of the driver is used.
+type DriverConfig struct {
+	Name   string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	Type   string                 `json:"type" yaml:"type"`
+	Pin    string                 `json:"pin" yaml:"pin"`
+	Pins   map[string]string      `json:"pins,omitempty" yaml:"pins,omitempty"`
+	Params map[string]interface{} `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// ParseConfigJSON parses the given JSON data to a configuration.
+func ParseConfigJSON(data []byte) (*Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("can not parse JSON configuration: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// ParseConfigYAML parses the given YAML data to a configuration.
+func ParseConfigYAML(data []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("can not parse YAML configuration: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// NewDriversFromConfig creates the drivers of the given configuration for the given adaptor. The drivers are returned
+// in the same order like in the configuration. An error is returned for an unknown type, pin or parameter, a missing
+// mandatory value or if the adaptor does not support the needed capabilities of a driver.
+func NewDriversFromConfig(a gobot.Connection, cfg *Config) ([]gobot.Driver, error) {
+	drivers := make([]gobot.Driver, 0, len(cfg.Drivers))
+	for i, dc := range cfg.Drivers {
+		d, err := newDriverFromConfig(a, dc)
+		if err != nil {
+			return nil, fmt.Errorf("driver %d ('%s'): %w", i, dc.Name, err)
+		}
+		drivers = append(drivers, d)
+	}
+
+	return drivers, nil
+}
+
+func newDriverFromConfig(a gobot.Connection, dc DriverConfig) (gobot.Driver, error) {
+	if dc.Pin == "" {
+		return nil, fmt.Errorf("pin is mandatory")
+	}
+
+	var opts []interface{}
+	if dc.Name != "" {
+		opts = append(opts, WithName(dc.Name))
+	}
+
+	switch strings.ToLower(dc.Type) {
+	case "easydriver":
+		return newEasyDriverFromConfig(a, dc, opts)
+	case "led":
+		if err := checkConfigKeys(dc, nil, nil); err != nil {
+			return nil, err
+		}
+		writer, ok := a.(DigitalWriter)
+		if !ok {
+			return nil, fmt.Errorf("DigitalWrite is not supported by the platform '%s'", a.Name())
+		}
+		return NewLedDriver(writer, dc.Pin, opts...), nil
+	case "button":
+		return newButtonDriverFromConfig(a, dc, opts)
+	case "servo":
+		if err := checkConfigKeys(dc, nil, nil); err != nil {
+			return nil, err
+		}
+		writer, ok := a.(ServoWriter)
+		if !ok {
+			return nil, fmt.Errorf("ServoWrite is not supported by the platform '%s'", a.Name())
+		}
+		return NewServoDriver(writer, dc.Pin, opts...), nil
+	case "relay":
+		return newRelayDriverFromConfig(a, dc, opts)
+	default:
+		return nil, fmt.Errorf("unknown type '%s', supported are: easydriver, led, button, servo, relay", dc.Type)
+	}
+}
+
+func newEasyDriverFromConfig(a gobot.Connection, dc DriverConfig, opts []interface{}) (gobot.Driver, error) {
+	if err := checkConfigKeys(dc, []string{"dir", "enable", "sleep"}, []string{"anglePerStep", "speed"}); err != nil {
+		return nil, err
+	}
+
+	writer, ok := a.(DigitalWriter)
+	if !ok {
+		return nil, fmt.Errorf("DigitalWrite is not supported by the platform '%s'", a.Name())
+	}
+
+	if _, ok := dc.Params["anglePerStep"]; !ok {
+		return nil, fmt.Errorf("param 'anglePerStep' is mandatory")
+	}
+	anglePerStep, err := configFloat(dc.Params, "anglePerStep")
+	if err != nil {
+		return nil, err
+	}
+	if anglePerStep <= 0 {
+		return nil, fmt.Errorf("param 'anglePerStep' (%v) must be greater than zero", anglePerStep)
+	}
+
+	if pin := dc.Pins["dir"]; pin != "" {
+		opts = append(opts, WithEasyDirectionPin(pin))
+	}
+	if pin := dc.Pins["enable"]; pin != "" {
+		opts = append(opts, WithEasyEnablePin(pin))
+	}
+	if pin := dc.Pins["sleep"]; pin != "" {
+		opts = append(opts, WithEasySleepPin(pin))
+	}
+
+	d := NewEasyDriver(writer, float32(anglePerStep), dc.Pin, opts...)
+
+	if _, ok := dc.Params["speed"]; ok {
+		speed, err := configFloat(dc.Params, "speed")
+		if err != nil {
+			return nil, err
+		}
+		if speed < 0 {
+			return nil, fmt.Errorf("param 'speed' (%v) must not be negative", speed)
+		}
+		if err := d.SetSpeed(uint(speed)); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+func newButtonDriverFromConfig(a gobot.Connection, dc DriverConfig, opts []interface{}) (gobot.Driver, error) {
+	if err := checkConfigKeys(dc, nil, []string{"pollInterval", "debounce", "defaultState", "activeHigh"}); err != nil {
+		return nil, err
+	}
+
+	reader, ok := a.(DigitalReader)
+	if !ok {
+		return nil, fmt.Errorf("DigitalRead is not supported by the platform '%s'", a.Name())
+	}
+
+	if _, ok := dc.Params["pollInterval"]; ok {
+		interval, err := configDuration(dc.Params, "pollInterval")
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithButtonPollInterval(interval))
+	}
+	if _, ok := dc.Params["debounce"]; ok {
+		debounce, err := configDuration(dc.Params, "debounce")
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithButtonDebounce(debounce))
+	}
+	if _, ok := dc.Params["defaultState"]; ok {
+		state, err := configFloat(dc.Params, "defaultState")
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithButtonDefaultState(int(state)))
+	}
+	if _, ok := dc.Params["activeHigh"]; ok {
+		activeHigh, err := configBool(dc.Params, "activeHigh")
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithButtonActiveHigh(activeHigh))
+	}
+
+	return NewButtonDriver(reader, dc.Pin, opts...), nil
+}
+
+func newRelayDriverFromConfig(a gobot.Connection, dc DriverConfig, opts []interface{}) (gobot.Driver, error) {
+	if err := checkConfigKeys(dc, nil, []string{"inverted"}); err != nil {
+		return nil, err
+	}
+
+	writer, ok := a.(DigitalWriter)
+	if !ok {
+		return nil, fmt.Errorf("DigitalWrite is not supported by the platform '%s'", a.Name())
+	}
+
+	if _, ok := dc.Params["inverted"]; ok {
+		inverted, err := configBool(dc.Params, "inverted")
+		if err != nil {
+			return nil, err
+		}
+		if inverted {
+			opts = append(opts, WithRelayInverted())
+		}
+	}
+
+	return NewRelayDriver(writer, dc.Pin, opts...), nil
+}
+
+// checkConfigKeys ensures that only the given pins and parameters are used, so typos are not silently ignored.
+func checkConfigKeys(dc DriverConfig, pins []string, params []string) error {
+	if unknown := unknownConfigKeys(dc.Pins, pins); len(unknown) > 0 {
+		return fmt.Errorf("unknown pins for type '%s': %s", dc.Type, strings.Join(unknown, ", "))
+	}
+	if unknown := unknownConfigKeys(dc.Params, params); len(unknown) > 0 {
+		return fmt.Errorf("unknown params for type '%s': %s", dc.Type, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+func unknownConfigKeys[T any](m map[string]T, known []string) []string {
+	var unknown []string
+	for key := range m {
+		found := false
+		for _, k := range known {
+			if key == k {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+
+	return unknown
+}
+
+// configFloat returns the parameter as float64, the JSON parser returns all numbers as float64 and the YAML parser
+// returns int or float64
+func configFloat(params map[string]interface{}, key string) (float64, error) {
+	switch v := params[key].(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	default:
+		return 0, fmt.Errorf("param '%s' needs to be a number, but is '%v'", key, params[key])
+	}
+}
+
+func configBool(params map[string]interface{}, key string) (bool, error) {
+	v, ok := params[key].(bool)
+	if !ok {
+		return false, fmt.Errorf("param '%s' needs to be a boolean, but is '%v'", key, params[key])
+	}
+
+	return v, nil
+}
+
+func configDuration(params map[string]interface{}, key string) (time.Duration, error) {
+	s, ok := params[key].(string)
+	if !ok {
+		return 0, fmt.Errorf("param '%s' needs to be a duration string (e.g. \"10ms\"), but is '%v'", key, params[key])
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("param '%s': %w", key, err)
+	}
+
+	return d, nil
+}
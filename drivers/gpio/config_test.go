@@ -0,0 +1,191 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const testConfigYAML = `
+drivers:
+  - name: stepper
+    type: EasyDriver
+    pin: "7"
+    pins:
+      dir: "8"
+      enable: "9"
+    params:
+      anglePerStep: 1.8
+      speed: 20
+  - name: status
+    type: led
+    pin: "13"
+  - name: start
+    type: button
+    pin: "2"
+    params:
+      pollInterval: 20ms
+      debounce: 5ms
+      activeHigh: false
+  - type: servo
+    pin: "3"
+  - name: pump
+    type: relay
+    pin: "4"
+    params:
+      inverted: true
+`
+
+const testConfigJSON = `{
+  "drivers": [
+    {"name": "stepper", "type": "easydriver", "pin": "7", "pins": {"dir": "8", "enable": "9"},
+      "params": {"anglePerStep": 1.8, "speed": 20}},
+    {"name": "status", "type": "led", "pin": "13"},
+    {"name": "start", "type": "button", "pin": "2",
+      "params": {"pollInterval": "20ms", "debounce": "5ms", "activeHigh": false}},
+    {"type": "servo", "pin": "3"},
+    {"name": "pump", "type": "relay", "pin": "4", "params": {"inverted": true}}
+  ]
+}`
+
+func TestNewDriversFromConfig(t *testing.T) {
+	tests := map[string]struct {
+		parse func([]byte) (*Config, error)
+		data  string
+	}{
+		"yaml": {parse: ParseConfigYAML, data: testConfigYAML},
+		"json": {parse: ParseConfigJSON, data: testConfigJSON},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			cfg, err := tc.parse([]byte(tc.data))
+			require.NoError(t, err)
+			// act
+			drivers, err := NewDriversFromConfig(a, cfg)
+			// assert
+			require.NoError(t, err)
+			require.Len(t, drivers, 5)
+
+			easy, ok := drivers[0].(*EasyDriver)
+			require.True(t, ok)
+			assert.Equal(t, "stepper", easy.Name())
+			assert.Equal(t, "7", easy.stepPin)
+			assert.Equal(t, "8", easy.easyCfg.dirPin)
+			assert.Equal(t, "9", easy.easyCfg.enPin)
+			assert.Equal(t, "", easy.easyCfg.sleepPin)
+			assert.InDelta(t, 200.0, easy.stepsPerRev, 0.0)
+			assert.Equal(t, uint(20), easy.speedRpm)
+
+			led, ok := drivers[1].(*LedDriver)
+			require.True(t, ok)
+			assert.Equal(t, "status", led.Name())
+			assert.Equal(t, "13", led.Pin())
+
+			button, ok := drivers[2].(*ButtonDriver)
+			require.True(t, ok)
+			assert.Equal(t, "start", button.Name())
+			assert.Equal(t, "2", button.Pin())
+			assert.Equal(t, 20*time.Millisecond, button.buttonCfg.readInterval)
+			assert.Equal(t, 5*time.Millisecond, button.buttonCfg.debounceTime)
+			assert.Equal(t, 1, button.buttonCfg.defaultState)
+
+			servo, ok := drivers[3].(*ServoDriver)
+			require.True(t, ok)
+			assert.Contains(t, servo.Name(), "Servo")
+			assert.Equal(t, "3", servo.Pin())
+
+			relay, ok := drivers[4].(*RelayDriver)
+			require.True(t, ok)
+			assert.Equal(t, "pump", relay.Name())
+			assert.Equal(t, "4", relay.Pin())
+			assert.True(t, relay.relayCfg.inverted)
+		})
+	}
+}
+
+func TestNewDriversFromConfig_error(t *testing.T) {
+	tests := map[string]struct {
+		bareAdaptor bool
+		dc          DriverConfig
+		wantErr     string
+	}{
+		"unknown_type": {
+			dc:      DriverConfig{Name: "x", Type: "motor", Pin: "1"},
+			wantErr: "driver 0 ('x'): unknown type 'motor', supported are: easydriver, led, button, servo, relay",
+		},
+		"missing_pin": {
+			dc:      DriverConfig{Type: "led"},
+			wantErr: "driver 0 (''): pin is mandatory",
+		},
+		"unknown_pin": {
+			dc:      DriverConfig{Type: "easydriver", Pin: "1", Pins: map[string]string{"direction": "2"}},
+			wantErr: "unknown pins for type 'easydriver': direction",
+		},
+		"unknown_params": {
+			dc:      DriverConfig{Type: "led", Pin: "1", Params: map[string]interface{}{"b": 1, "a": 2}},
+			wantErr: "unknown params for type 'led': a, b",
+		},
+		"missing_angle_per_step": {
+			dc:      DriverConfig{Type: "easydriver", Pin: "1"},
+			wantErr: "param 'anglePerStep' is mandatory",
+		},
+		"invalid_number": {
+			dc:      DriverConfig{Type: "easydriver", Pin: "1", Params: map[string]interface{}{"anglePerStep": "1.8"}},
+			wantErr: "param 'anglePerStep' needs to be a number, but is '1.8'",
+		},
+		"zero_angle_per_step": {
+			dc:      DriverConfig{Type: "easydriver", Pin: "1", Params: map[string]interface{}{"anglePerStep": 0}},
+			wantErr: "param 'anglePerStep' (0) must be greater than zero",
+		},
+		"negative_angle_per_step": {
+			dc:      DriverConfig{Type: "easydriver", Pin: "1", Params: map[string]interface{}{"anglePerStep": -1.8}},
+			wantErr: "param 'anglePerStep' (-1.8) must be greater than zero",
+		},
+		"invalid_speed": {
+			dc: DriverConfig{
+				Type: "easydriver", Pin: "1", Params: map[string]interface{}{"anglePerStep": 1.8, "speed": 1000},
+			},
+			wantErr: "cannot be greater then maximal value",
+		},
+		"invalid_bool": {
+			dc:      DriverConfig{Type: "relay", Pin: "1", Params: map[string]interface{}{"inverted": "yes"}},
+			wantErr: "param 'inverted' needs to be a boolean, but is 'yes'",
+		},
+		"invalid_duration": {
+			dc:      DriverConfig{Type: "button", Pin: "1", Params: map[string]interface{}{"debounce": "5 ms"}},
+			wantErr: "param 'debounce': time: unknown unit",
+		},
+		"unsupported_by_adaptor": {
+			bareAdaptor: true,
+			dc:          DriverConfig{Type: "button", Pin: "1"},
+			wantErr:     "DigitalRead is not supported by the platform ''",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			var a gobot.Connection = newGpioTestAdaptor()
+			if tc.bareAdaptor {
+				a = &gpioTestBareAdaptor{}
+			}
+			cfg := &Config{Drivers: []DriverConfig{tc.dc}}
+			// act
+			_, err := NewDriversFromConfig(a, cfg)
+			// assert
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestParseConfig_error(t *testing.T) {
+	_, err := ParseConfigJSON([]byte("{"))
+	require.ErrorContains(t, err, "can not parse JSON configuration")
+	_, err = ParseConfigYAML([]byte("drivers: ["))
+	require.ErrorContains(t, err, "can not parse YAML configuration")
+}
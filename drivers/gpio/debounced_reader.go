@@ -0,0 +1,95 @@
+package gpio
+
+import (
+	"sync"
+	"time"
+)
+
+// debouncer takes over a changed value only, if it was stable for the given time window. It is used by polling
+// drivers and the DebouncedReader.
+type debouncer struct {
+	window         time.Duration
+	stable         int
+	candidate      int
+	candidateSince time.Time
+}
+
+// newDebouncer creates a debouncer with the given initial stable value.
+func newDebouncer(initial int, window time.Duration) *debouncer {
+	return &debouncer{window: window, stable: initial, candidate: initial}
+}
+
+// debounce evaluates the given value, read at the given time, and returns the stable value. Without a time window,
+// each changed value is taken over immediately.
+func (b *debouncer) debounce(value int, now time.Time) int {
+	if value != b.candidate {
+		b.candidate = value
+		b.candidateSince = now
+	}
+
+	if b.candidate != b.stable && now.Sub(b.candidateSince) >= b.window {
+		b.stable = b.candidate
+	}
+
+	return b.stable
+}
+
+// DebouncedReader wraps a pin of a DigitalReader, e.g. for a mechanical switch, and suppresses the bouncing of the
+// contacts. A changed value is reported only, after it has been read consistently for the debounce window.
+type DebouncedReader struct {
+	reader    DigitalReader
+	pin       string
+	window    time.Duration
+	debouncer *debouncer // created by the first read
+	now       func() time.Time
+	mutex     *sync.Mutex
+}
+
+// NewDebouncedReader returns a new debounced reader for the given pin of the DigitalReader. The value of the first
+// read is taken over immediately as stable value. Read() needs to be called repeatedly, at an interval clearly
+// shorter than the debounce window, because the value is evaluated only on each read.
+func NewDebouncedReader(reader DigitalReader, pin string, window time.Duration) *DebouncedReader {
+	return &DebouncedReader{
+		reader: reader,
+		pin:    pin,
+		window: window,
+		now:    time.Now,
+		mutex:  &sync.Mutex{},
+	}
+}
+
+// Pin returns the pin of the reader.
+func (r *DebouncedReader) Pin() string {
+	return r.pin
+}
+
+// Window returns the debounce window.
+func (r *DebouncedReader) Window() time.Duration {
+	return r.window
+}
+
+// Read reads the pin and returns the debounced value. On error, the stable value is not changed.
+func (r *DebouncedReader) Read() (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	value, err := r.reader.DigitalRead(r.pin)
+	if err != nil {
+		return 0, err
+	}
+
+	if r.debouncer == nil {
+		r.debouncer = newDebouncer(value, r.window)
+		return value, nil
+	}
+
+	return r.debouncer.debounce(value, r.now()), nil
+}
+
+// Reset discards the stable value, so the next read is taken over immediately.
+func (r *DebouncedReader) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.debouncer = nil
+}
@@ -0,0 +1,147 @@
+package gpio
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// debouncedReaderTestStep is one read of the sequence: the raw value of the pin, the time since the previous read and
+// the expected debounced value
+type debouncedReaderTestStep struct {
+	raw     int
+	elapsed time.Duration
+	want    int
+}
+
+func TestNewDebouncedReader(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	r := NewDebouncedReader(a, "3", 10*time.Millisecond)
+	// assert
+	assert.Equal(t, "3", r.Pin())
+	assert.Equal(t, 10*time.Millisecond, r.Window())
+	assert.Equal(t, a, r.reader)
+	assert.Nil(t, r.debouncer)
+	assert.NotNil(t, r.now)
+	assert.NotNil(t, r.mutex)
+}
+
+func TestDebouncedReaderRead(t *testing.T) {
+	const ms = time.Millisecond
+	tests := map[string]struct {
+		window time.Duration
+		steps  []debouncedReaderTestStep
+	}{
+		"first_read_is_stable": {
+			window: 10 * ms,
+			steps:  []debouncedReaderTestStep{{raw: 1, want: 1}},
+		},
+		"bouncing_press": {
+			window: 10 * ms,
+			steps: []debouncedReaderTestStep{
+				{raw: 0, want: 0},
+				{raw: 1, elapsed: 2 * ms, want: 0},
+				{raw: 0, elapsed: 2 * ms, want: 0},
+				{raw: 1, elapsed: 2 * ms, want: 0}, // candidate since here
+				{raw: 0, elapsed: 2 * ms, want: 0},
+				{raw: 1, elapsed: 2 * ms, want: 0}, // candidate since here
+				{raw: 1, elapsed: 5 * ms, want: 0},
+				{raw: 1, elapsed: 4 * ms, want: 0},
+				{raw: 1, elapsed: 1 * ms, want: 1}, // stable for the window
+				{raw: 1, elapsed: 5 * ms, want: 1},
+			},
+		},
+		"bouncing_release": {
+			window: 10 * ms,
+			steps: []debouncedReaderTestStep{
+				{raw: 1, want: 1},
+				{raw: 0, elapsed: 3 * ms, want: 1},
+				{raw: 1, elapsed: 3 * ms, want: 1},
+				{raw: 0, elapsed: 3 * ms, want: 1},
+				{raw: 0, elapsed: 6 * ms, want: 1},
+				{raw: 0, elapsed: 4 * ms, want: 0},
+			},
+		},
+		"glitch_is_suppressed": {
+			window: 10 * ms,
+			steps: []debouncedReaderTestStep{
+				{raw: 0, want: 0},
+				{raw: 1, elapsed: 5 * ms, want: 0},
+				{raw: 0, elapsed: 8 * ms, want: 0},
+				{raw: 0, elapsed: 20 * ms, want: 0},
+			},
+		},
+		"without_window": {
+			steps: []debouncedReaderTestStep{
+				{raw: 0, want: 0},
+				{raw: 1, elapsed: ms, want: 1},
+				{raw: 0, elapsed: ms, want: 0},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			var raw int
+			a := newGpioTestAdaptor()
+			a.digitalReadFunc = func(string) (int, error) { return raw, nil }
+			r := NewDebouncedReader(a, "1", tc.window)
+			now := time.Now()
+			r.now = func() time.Time { return now }
+			for i, step := range tc.steps {
+				raw = step.raw
+				now = now.Add(step.elapsed)
+				// act
+				got, err := r.Read()
+				// assert
+				require.NoError(t, err)
+				assert.Equal(t, step.want, got, "step %d", i)
+			}
+		})
+	}
+}
+
+func TestDebouncedReaderRead_error(t *testing.T) {
+	// arrange
+	var readErr error
+	a := newGpioTestAdaptor()
+	a.digitalReadFunc = func(string) (int, error) { return 1, readErr }
+	r := NewDebouncedReader(a, "1", time.Hour)
+	got, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, 1, got)
+	readErr = errors.New("read error")
+	// act
+	_, err = r.Read()
+	// assert
+	require.EqualError(t, err, "read error")
+	readErr = nil
+	got, err = r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, 1, got)
+}
+
+func TestDebouncedReaderReset(t *testing.T) {
+	// arrange
+	raw := 0
+	a := newGpioTestAdaptor()
+	a.digitalReadFunc = func(string) (int, error) { return raw, nil }
+	r := NewDebouncedReader(a, "1", time.Hour)
+	_, err := r.Read()
+	require.NoError(t, err)
+	raw = 1
+	got, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, 0, got)
+	// act
+	r.Reset()
+	got, err = r.Read()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, got)
+}
@@ -0,0 +1,202 @@
+package gpio
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// differentialDriveOptionApplier needs to be implemented by each configurable option type
+type differentialDriveOptionApplier interface {
+	apply(cfg *differentialDriveConfiguration)
+}
+
+// differentialDriveConfiguration contains all changeable attributes of the driver.
+type differentialDriveConfiguration struct {
+	maxSpeed byte
+	trim     float64
+}
+
+// differentialDriveMaxSpeedOption is the type for applying the max. speed of both motors to the configuration
+type differentialDriveMaxSpeedOption byte
+
+// differentialDriveTrimOption is the type for applying a trim of the motors to the configuration
+type differentialDriveTrimOption float64
+
+// DifferentialDriveDriver represents the drive of a two-wheeled robot (rover), which is steered by different speeds
+// of the left and right motor.
+type DifferentialDriveDriver struct {
+	*driver
+	driveCfg   *differentialDriveConfiguration
+	left       *MotorDriver
+	right      *MotorDriver
+	leftSpeed  int
+	rightSpeed int
+	driveMutex *sync.Mutex // the driver mutex is locked during halt
+}
+
+// NewDifferentialDriveDriver returns a new driver for a two-wheeled robot, given the motor drivers of the left and the
+// right wheel. The motors needs to be connected, so that "forward" moves the robot forward.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithDifferentialDriveMaxSpeed"
+//	"WithDifferentialDriveTrim"
+//
+// Adds the following API Commands:
+//
+//	"Drive" - See DifferentialDriveDriver.Drive
+//	"Stop" - See DifferentialDriveDriver.Stop
+func NewDifferentialDriveDriver(left *MotorDriver, right *MotorDriver, opts ...interface{}) *DifferentialDriveDriver {
+	d := &DifferentialDriveDriver{
+		driver:     newDriver(left.connection, "DifferentialDrive"),
+		driveCfg:   &differentialDriveConfiguration{maxSpeed: 255},
+		left:       left,
+		right:      right,
+		driveMutex: &sync.Mutex{},
+	}
+	d.beforeHalt = d.Stop
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case differentialDriveOptionApplier:
+			o.apply(d.driveCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	if d.driveCfg.trim < -1 || d.driveCfg.trim > 1 {
+		panic(fmt.Sprintf("trim (%v) of '%s' must be within -1..1", d.driveCfg.trim, d.driverCfg.name))
+	}
+
+	d.AddCommand("Drive", func(params map[string]interface{}) interface{} {
+		linear := params["linear"].(float64)   //nolint:forcetypeassert // ok here
+		angular := params["angular"].(float64) //nolint:forcetypeassert // ok here
+		return d.Drive(linear, angular)
+	})
+	d.AddCommand("Stop", func(params map[string]interface{}) interface{} {
+		return d.Stop()
+	})
+
+	return d
+}
+
+// WithDifferentialDriveMaxSpeed change the max. speed of both motors from default 255 to the given value. The speeds
+// of Drive() are scaled to this value.
+func WithDifferentialDriveMaxSpeed(maxSpeed byte) differentialDriveOptionApplier {
+	return differentialDriveMaxSpeedOption(maxSpeed)
+}
+
+// WithDifferentialDriveTrim compensates a mismatch of the motors, which lets the robot drift, although it should go
+// straight ahead. The value needs to be within -1..1. A positive value reduces the speed of the left motor by the
+// given fraction, e.g. if the robot drifts to the right. A negative value reduces the speed of the right motor.
+func WithDifferentialDriveTrim(trim float64) differentialDriveOptionApplier {
+	return differentialDriveTrimOption(trim)
+}
+
+// Pin returns the pins of both motors.
+func (d *DifferentialDriveDriver) Pin() string {
+	return "left=" + d.left.Pin() + ", right=" + d.right.Pin()
+}
+
+// Left returns the motor driver of the left wheel.
+func (d *DifferentialDriveDriver) Left() *MotorDriver { return d.left }
+
+// Right returns the motor driver of the right wheel.
+func (d *DifferentialDriveDriver) Right() *MotorDriver { return d.right }
+
+// Speeds returns the current speeds of the left and the right motor, a negative value means backward.
+func (d *DifferentialDriveDriver) Speeds() (int, int) {
+	d.driveMutex.Lock()
+	defer d.driveMutex.Unlock()
+
+	return d.leftSpeed, d.rightSpeed
+}
+
+// Drive moves the robot with the given linear and angular speed, both within -1..1 and clamped to this range. A
+// positive linear speed moves the robot forward, a positive angular speed turns it counter-clockwise (to the left).
+// Both speeds are mixed to the speeds of the left and right motor. If the result exceeds the max. speed of a motor,
+// both speeds are reduced by the same factor, so the curve radius is kept. Afterwards the trim is applied.
+func (d *DifferentialDriveDriver) Drive(linear, angular float64) error {
+	left, right := differentialDriveMix(clampDifferentialDrive(linear), clampDifferentialDrive(angular))
+
+	if d.driveCfg.trim > 0 {
+		left *= 1 - d.driveCfg.trim
+	} else {
+		right *= 1 + d.driveCfg.trim
+	}
+
+	maxSpeed := float64(d.driveCfg.maxSpeed)
+
+	return d.setSpeeds(int(math.Round(left*maxSpeed)), int(math.Round(right*maxSpeed)))
+}
+
+// Stop stops both motors.
+func (d *DifferentialDriveDriver) Stop() error {
+	return d.setSpeeds(0, 0)
+}
+
+// setSpeeds runs each motor with the given speed, a negative value runs the motor backward
+func (d *DifferentialDriveDriver) setSpeeds(left, right int) error {
+	d.driveMutex.Lock()
+	defer d.driveMutex.Unlock()
+
+	if err := runDifferentialDriveMotor(d.left, left); err != nil {
+		return err
+	}
+	d.leftSpeed = left
+
+	if err := runDifferentialDriveMotor(d.right, right); err != nil {
+		return err
+	}
+	d.rightSpeed = right
+
+	return nil
+}
+
+func runDifferentialDriveMotor(motor *MotorDriver, speed int) error {
+	if speed < 0 {
+		return motor.Backward(byte(-speed))
+	}
+
+	return motor.Forward(byte(speed))
+}
+
+// differentialDriveMix mixes the linear and angular speed to the speeds of the left and right motor, both within -1..1
+//
+//nolint:nonamedreturns // is sufficient here
+func differentialDriveMix(linear, angular float64) (left float64, right float64) {
+	left = linear - angular
+	right = linear + angular
+
+	if maxAbs := math.Max(math.Abs(left), math.Abs(right)); maxAbs > 1 {
+		left /= maxAbs
+		right /= maxAbs
+	}
+
+	return left, right
+}
+
+func clampDifferentialDrive(value float64) float64 {
+	return math.Max(-1, math.Min(1, value))
+}
+
+func (o differentialDriveMaxSpeedOption) String() string {
+	return "max speed option for differential drive"
+}
+
+func (o differentialDriveTrimOption) String() string {
+	return "trim option for differential drive"
+}
+
+func (o differentialDriveMaxSpeedOption) apply(cfg *differentialDriveConfiguration) {
+	cfg.maxSpeed = byte(o)
+}
+
+func (o differentialDriveTrimOption) apply(cfg *differentialDriveConfiguration) {
+	cfg.trim = float64(o)
+}
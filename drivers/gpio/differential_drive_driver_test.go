@@ -0,0 +1,264 @@
+//nolint:forcetypeassert // ok here
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+)
+
+var _ gobot.Driver = (*DifferentialDriveDriver)(nil)
+
+// differentialDriveTestWrites records the last written value per pin
+type differentialDriveTestWrites struct {
+	mtx    sync.Mutex
+	values map[string]byte
+}
+
+func initTestDifferentialDriveDriver(opts ...interface{}) (*DifferentialDriveDriver, *gpioTestAdaptor,
+	*differentialDriveTestWrites,
+) {
+	w := &differentialDriveTestWrites{values: make(map[string]byte)}
+	a := newGpioTestAdaptor()
+	write := func(pin string, val byte) error {
+		w.mtx.Lock()
+		defer w.mtx.Unlock()
+		w.values[pin] = val
+		return nil
+	}
+	a.pwmWriteFunc = write
+	a.digitalWriteFunc = write
+	left := NewMotorDriver(a, "1", WithMotorDirectionPin("11"))
+	right := NewMotorDriver(a, "2", WithMotorDirectionPin("12"))
+	return NewDifferentialDriveDriver(left, right, opts...), a, w
+}
+
+// get returns the speed and the level of the direction pin of the given motor pin
+func (w *differentialDriveTestWrites) get(pin string) (byte, byte) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return w.values[pin], w.values["1"+pin]
+}
+
+func TestNewDifferentialDriveDriver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	left := NewMotorDriver(a, "1")
+	right := NewMotorDriver(a, "2")
+	// act
+	d := NewDifferentialDriveDriver(left, right)
+	// assert
+	assert.IsType(t, &DifferentialDriveDriver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "DifferentialDrive"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: driver specific attributes
+	assert.Equal(t, "left=1, right=2", d.Pin())
+	assert.Equal(t, left, d.Left())
+	assert.Equal(t, right, d.Right())
+	assert.Equal(t, &differentialDriveConfiguration{maxSpeed: 255}, d.driveCfg)
+}
+
+func TestNewDifferentialDriveDriver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const myName = "rover"
+	a := newGpioTestAdaptor()
+	panicFunc := func() {
+		NewDifferentialDriveDriver(NewMotorDriver(a, "1"), NewMotorDriver(a, "2"), WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewDifferentialDriveDriver(NewMotorDriver(a, "1"), NewMotorDriver(a, "2"), WithName(myName),
+		WithDifferentialDriveMaxSpeed(200), WithDifferentialDriveTrim(0.1))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, &differentialDriveConfiguration{maxSpeed: 200, trim: 0.1}, d.driveCfg)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+	assert.PanicsWithValue(t, "trim (-1.5) of 'rover' must be within -1..1", func() {
+		NewDifferentialDriveDriver(NewMotorDriver(a, "1"), NewMotorDriver(a, "2"), WithName(myName),
+			WithDifferentialDriveTrim(-1.5))
+	})
+}
+
+func TestDifferentialDriveDrive(t *testing.T) {
+	tests := map[string]struct {
+		opts      []interface{}
+		linear    float64
+		angular   float64
+		wantLeft  int
+		wantRight int
+	}{
+		"stand_still": {
+			wantLeft:  0,
+			wantRight: 0,
+		},
+		"translation_forward": {
+			linear:    1,
+			wantLeft:  255,
+			wantRight: 255,
+		},
+		"translation_backward_half": {
+			linear:    -0.5,
+			wantLeft:  -128,
+			wantRight: -128,
+		},
+		"rotation_counter_clockwise": {
+			angular:   1,
+			wantLeft:  -255,
+			wantRight: 255,
+		},
+		"rotation_clockwise_half": {
+			angular:   -0.5,
+			wantLeft:  128,
+			wantRight: -128,
+		},
+		"combined_curve_left": {
+			linear:    0.5,
+			angular:   0.25,
+			wantLeft:  64,
+			wantRight: 191,
+		},
+		"combined_saturated_keeps_ratio": {
+			linear:    1,
+			angular:   0.5,
+			wantLeft:  85,
+			wantRight: 255,
+		},
+		"combined_backward_curve": {
+			linear:    -0.6,
+			angular:   0.2,
+			wantLeft:  -204,
+			wantRight: -102,
+		},
+		"clamped_input": {
+			linear:    3,
+			angular:   0,
+			wantLeft:  255,
+			wantRight: 255,
+		},
+		"max_speed": {
+			opts:      []interface{}{WithDifferentialDriveMaxSpeed(100)},
+			linear:    1,
+			angular:   -0.5,
+			wantLeft:  100,
+			wantRight: 33,
+		},
+		"trim_left": {
+			opts:      []interface{}{WithDifferentialDriveTrim(0.1)},
+			linear:    1,
+			wantLeft:  230,
+			wantRight: 255,
+		},
+		"trim_right": {
+			opts:      []interface{}{WithDifferentialDriveTrim(-0.2)},
+			linear:    -1,
+			wantLeft:  -255,
+			wantRight: -204,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, w := initTestDifferentialDriveDriver(tc.opts...)
+			// act
+			err := d.Drive(tc.linear, tc.angular)
+			// assert
+			require.NoError(t, err)
+			gotLeft, gotRight := d.Speeds()
+			assert.Equal(t, tc.wantLeft, gotLeft)
+			assert.Equal(t, tc.wantRight, gotRight)
+			for pin, want := range map[string]int{"1": tc.wantLeft, "2": tc.wantRight} {
+				speed, direction := w.get(pin)
+				wantDirection := byte(1)
+				if want < 0 {
+					wantDirection = 0
+					want = -want
+				}
+				assert.Equal(t, byte(want), speed, "speed of pin %s", pin)
+				assert.Equal(t, wantDirection, direction, "direction of pin %s", pin)
+			}
+		})
+	}
+}
+
+func TestDifferentialDriveStop(t *testing.T) {
+	// arrange
+	d, _, w := initTestDifferentialDriveDriver()
+	require.NoError(t, d.Drive(0.5, 0.5))
+	// act
+	err := d.Stop()
+	// assert
+	require.NoError(t, err)
+	gotLeft, gotRight := d.Speeds()
+	assert.Equal(t, 0, gotLeft)
+	assert.Equal(t, 0, gotRight)
+	leftSpeed, _ := w.get("1")
+	rightSpeed, _ := w.get("2")
+	assert.Equal(t, byte(0), leftSpeed)
+	assert.Equal(t, byte(0), rightSpeed)
+}
+
+func TestDifferentialDriveHalt(t *testing.T) {
+	// arrange
+	d, _, w := initTestDifferentialDriveDriver()
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Drive(1, 0))
+	// act
+	err := d.Halt()
+	// assert
+	require.NoError(t, err)
+	leftSpeed, _ := w.get("1")
+	rightSpeed, _ := w.get("2")
+	assert.Equal(t, byte(0), leftSpeed)
+	assert.Equal(t, byte(0), rightSpeed)
+}
+
+func TestDifferentialDriveDrive_error(t *testing.T) {
+	// arrange
+	d, a, _ := initTestDifferentialDriveDriver()
+	a.pwmWriteFunc = func(pin string, val byte) error {
+		if pin == "2" {
+			return errors.New("pwm error")
+		}
+		return nil
+	}
+	// act
+	err := d.Drive(1, 0)
+	// assert
+	require.EqualError(t, err, "pwm error")
+	gotLeft, gotRight := d.Speeds()
+	assert.Equal(t, 255, gotLeft)
+	assert.Equal(t, 0, gotRight)
+}
+
+func TestDifferentialDriveCommands(t *testing.T) {
+	// arrange
+	d, _, _ := initTestDifferentialDriveDriver()
+	// act
+	err := d.Command("Drive")(map[string]interface{}{"linear": 1.0, "angular": 1.0})
+	// assert
+	assert.Nil(t, err)
+	gotLeft, gotRight := d.Speeds()
+	assert.Equal(t, 0, gotLeft)
+	assert.Equal(t, 255, gotRight)
+	// act & assert
+	assert.Nil(t, d.Command("Stop")(nil))
+	gotLeft, gotRight = d.Speeds()
+	assert.Equal(t, 0, gotLeft)
+	assert.Equal(t, 0, gotRight)
+}
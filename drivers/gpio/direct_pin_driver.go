@@ -16,6 +16,7 @@ type DirectPinDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithVerifiedWrite"
 //
 // Adds the following API Commands:
 //
@@ -77,3 +78,15 @@ func (d *DirectPinDriver) PwmWrite(level byte) error {
 func (d *DirectPinDriver) ServoWrite(level byte) error {
 	return d.servoWrite(d.driverCfg.pin, level)
 }
+
+// SetPWMPolarity sets the polarity of the PWM output, which is used by PwmWrite(). With "false" the output is
+// inverted. An error is returned, if not supported by the adaptor.
+func (d *DirectPinDriver) SetPWMPolarity(normal bool) error {
+	return d.setPWMPolarity(d.driverCfg.pin, normal)
+}
+
+// SetPWMCenterAligned switches the PWM output, which is used by PwmWrite(), to center-aligned mode or back to
+// edge-aligned mode. An error is returned, if not supported by the adaptor.
+func (d *DirectPinDriver) SetPWMCenterAligned(centerAligned bool) error {
+	return d.setPWMCenterAligned(d.driverCfg.pin, centerAligned)
+}
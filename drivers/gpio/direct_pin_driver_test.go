@@ -185,3 +185,45 @@ func TestDirectPinServoWriteError(t *testing.T) {
 	d := initTestDirectPinDriver()
 	require.Error(t, d.ServoWrite(1))
 }
+
+func TestDirectPinSetPWMPolarity(t *testing.T) {
+	a := newGpioTestAdaptor()
+	var gotPin string
+	gotNormal := true
+	a.pwmPolarityFunc = func(pin string, normal bool) error {
+		gotPin = pin
+		gotNormal = normal
+		return nil
+	}
+	d := NewDirectPinDriver(a, "1")
+	require.NoError(t, d.SetPWMPolarity(false))
+	assert.Equal(t, "1", gotPin)
+	assert.False(t, gotNormal)
+}
+
+func TestDirectPinSetPWMPolarityNotSupported(t *testing.T) {
+	a := &gpioTestBareAdaptor{}
+	d := NewDirectPinDriver(a, "1")
+	require.EqualError(t, d.SetPWMPolarity(false), "SetPWMPolarity is not supported by this platform")
+}
+
+func TestDirectPinSetPWMCenterAligned(t *testing.T) {
+	a := newGpioTestAdaptor()
+	var gotPin string
+	var gotCenterAligned bool
+	a.pwmCenterAlignFunc = func(pin string, centerAligned bool) error {
+		gotPin = pin
+		gotCenterAligned = centerAligned
+		return nil
+	}
+	d := NewDirectPinDriver(a, "1")
+	require.NoError(t, d.SetPWMCenterAligned(true))
+	assert.Equal(t, "1", gotPin)
+	assert.True(t, gotCenterAligned)
+}
+
+func TestDirectPinSetPWMCenterAlignedNotSupported(t *testing.T) {
+	a := &gpioTestBareAdaptor{}
+	d := NewDirectPinDriver(a, "1")
+	require.EqualError(t, d.SetPWMCenterAligned(true), "SetPWMCenterAligned is not supported by this platform")
+}
@@ -2,13 +2,41 @@ package gpio
 
 import (
 	"fmt"
+	"log"
+	"math"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"gobot.io/x/gobot/v2"
 )
 
-const easyDriverDebug = false
+const (
+	easyDriverDebug = false
+
+	// easyDefaultStepPulseWidth is a safe minimum of the high time of the step pin for common driver boards, e.g.
+	// A3967 (1 us) or DRV8825 (1.9 us)
+	easyDefaultStepPulseWidth = 2 * time.Microsecond
+	// easyDefaultDirectionSetupTime is a conservative time between a change of the direction pin and the next step for
+	// common driver boards, e.g. A4988 (200 ns), DRV8825 (650 ns) or opto-isolated boards (some us)
+	easyDefaultDirectionSetupTime = 5 * time.Microsecond
+
+	easyParamSpeed     = "speed"
+	easyParamDirection = "direction"
+	easyParamPosition  = "position"
+	easyParamEnabled   = "enabled"
+	easyParamSleeping  = "sleeping"
+)
+
+const (
+	// EasyIdleHold keeps the motor output enabled after a move, so the position is held by the coils (default).
+	EasyIdleHold = "hold"
+	// EasyIdleRelease disables the motor output after a move and the idle delay, needs the enable pin.
+	EasyIdleRelease = "release"
+	// EasyIdleSleep puts the driver to sleep after a move and the idle delay, needs the sleep pin.
+	EasyIdleSleep = "sleep"
+)
 
 // easyOptionApplier needs to be implemented by each configurable option type
 type easyOptionApplier interface {
@@ -17,9 +45,12 @@ type easyOptionApplier interface {
 
 // easyConfiguration contains all changeable attributes of the driver.
 type easyConfiguration struct {
-	dirPin   string
-	enPin    string
-	sleepPin string
+	dirPin       string
+	enPin        string
+	sleepPin     string
+	current      *easyCurrentConfiguration
+	writeTimeout time.Duration
+	invertedStep bool
 }
 
 // easyDirPinOption is the type for applying a pin for change direction
@@ -31,15 +62,87 @@ type easyEnPinOption string
 // easySleepPinOption is the type for applying a pin for setting device to sleep/wake
 type easySleepPinOption string
 
+// easyWriteTimeoutOption is the type for applying a timeout for the writes of enable, disable, sleep and wake
+type easyWriteTimeoutOption time.Duration
+
+// easyInvertedStepOption is the type for applying an inverted polarity of the step pulse
+type easyInvertedStepOption bool
+
+// EasyDriverState contains the current state and the capabilities of the driver, e.g. to decide which controls can
+// be used in an user interface.
+type EasyDriverState struct {
+	Direction    string `json:"direction"`
+	CurrentStep  int    `json:"currentStep"`
+	SpeedRpm     uint   `json:"speedRpm"`
+	Moving       bool   `json:"moving"`
+	Enabled      bool   `json:"enabled"`
+	Sleeping     bool   `json:"sleeping"`
+	HasDirPin    bool   `json:"hasDirPin"`
+	HasEnablePin bool   `json:"hasEnablePin"`
+	HasSleepPin  bool   `json:"hasSleepPin"`
+}
+
 // EasyDriver is an driver for stepper hardware board from SparkFun (https://www.sparkfun.com/products/12779)
 // This should also work for the BigEasyDriver (untested). It is basically a wrapper for the common StepperDriver{}
 // with the specific additions for the board, e.g. direction, enable and sleep outputs.
+//
+// All methods can be called concurrently. Movements (e.g. Move, MoveDeg, SetValue, FindLimits) are serialized, so a
+// second movement waits until the first one is finished. During a movement it is safe to call the getters (e.g.
+// State, CurrentStep, IsMoving, IsEnabled, IsSleeping), SetSpeed and SetDirection (both affect the next step), and
+// Stop, SoftStop, EmergencyStop, Disable and Sleep (which stop the movement).
 type EasyDriver struct {
 	*StepperDriver
-	easyCfg      *easyConfiguration
-	stepPin      string
-	anglePerStep float32
-	sleeping     bool
+	easyCfg            *easyConfiguration
+	stepPin            string
+	anglePerStep       float32
+	sleeping           bool
+	currentLimit       float64
+	stepPulseWidth     time.Duration
+	directionSetupTime time.Duration
+	directionChangedAt time.Time
+	settleDelay        time.Duration
+	settlePending      bool                // the settle delay is needed before the next step
+	waitFunc           func(time.Duration) // used for the delays of a step and the settle delay
+	trace              bool
+	traceLogger        *log.Logger
+
+	idleMutex      sync.Mutex
+	idleMode       string
+	idleDelay      time.Duration
+	idleTimer      *time.Timer
+	idleGeneration int
+	idleAction     string // the idle action done after the last move, which needs to be reverted on next move
+
+	watchdogTimeout   time.Duration
+	watchdogActivity  time.Time // time of the last move, step, enable or wake up
+	watchdogStop      chan struct{}
+	watchdogDone      chan struct{}
+	watchdogNow       func() time.Time
+	watchdogNewTicker func(interval time.Duration) (<-chan time.Time, func())
+
+	queueMutex   sync.Mutex
+	queue        []easyQueuedMove
+	queueRunning bool
+
+	progressMutex    sync.Mutex
+	progressInterval time.Duration
+	progressStop     chan struct{}
+	progressDone     chan struct{}
+
+	samplingMutex    sync.Mutex
+	samplingInterval time.Duration
+	samplingStop     chan struct{}
+	samplingDone     chan struct{}
+
+	pathMutex sync.Mutex
+	path      *easyPath
+
+	travelMutex sync.Mutex
+	travelRange *[2]int // discovered by FindLimits()
+
+	encoderMutex sync.Mutex
+	encoder      *easyEncoder
+	gobot.Eventer
 }
 
 // NewEasyDriver returns a new driver
@@ -51,9 +154,19 @@ type EasyDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithVerifiedWrite"
 //	"WithEasyDirectionPin"
 //	"WithEasyEnablePin"
 //	"WithEasySleepPin"
+//	"WithEasyCurrentControl"
+//	"WithEasyWriteTimeout"
+//	"WithEasyInvertedStep"
+//
+// Adds the following API Commands additionally to the commands of the StepperDriver:
+//
+//	"State"     - See EasyDriver.State
+//	"GetParams" - See EasyDriver.GetParams
+//	"SetParams" - See EasyDriver.SetParams, the parameters are given by the keys of the map
 func NewEasyDriver(a DigitalWriter, anglePerStep float32, stepPin string, opts ...interface{}) *EasyDriver {
 	if anglePerStep <= 0 {
 		panic("angle per step needs to be greater than zero")
@@ -69,17 +182,40 @@ func NewEasyDriver(a DigitalWriter, anglePerStep float32, stepPin string, opts .
 	stepper.haltIfRunning = false
 	stepper.stepsPerRev = 360.0 / anglePerStep
 	d := &EasyDriver{
-		StepperDriver: stepper,
-		easyCfg:       &easyConfiguration{},
-		stepPin:       stepPin,
-		anglePerStep:  anglePerStep,
+		StepperDriver:      stepper,
+		easyCfg:            &easyConfiguration{},
+		stepPin:            stepPin,
+		anglePerStep:       anglePerStep,
+		stepPulseWidth:     easyDefaultStepPulseWidth,
+		directionSetupTime: easyDefaultDirectionSetupTime,
+		waitFunc:           time.Sleep,
+		traceLogger:        log.Default(),
+		idleMode:           EasyIdleHold,
+		watchdogNow:        time.Now,
+		watchdogNewTicker: func(interval time.Duration) (<-chan time.Time, func()) {
+			ticker := time.NewTicker(interval)
+			return ticker.C, ticker.Stop
+		},
+		Eventer: gobot.NewEventer(),
 	}
+	d.AddEvent(EasyQueueDrained)
+	d.AddEvent(EasyLostSteps)
+	d.AddEvent(EasyWaypointReached)
+	d.AddEvent(EasyPathDone)
+	d.AddEvent(EasyMoveProgress)
+	d.AddEvent(EasyPosition)
+	d.AddEvent(EasyIdle)
+	d.AddEvent(Error)
 	d.stepFunc = d.onePinStepping
 	d.sleepFunc = d.sleepWithSleepPin
+	d.afterStart = d.initialize
+	d.beforeMoveFunc = d.beforeMove
+	d.afterMoveFunc = d.afterMove
+	d.directionFunc = d.changeDirectionPin
 	d.beforeHalt = d.shutdown
 
 	// 1/4 of max speed. Not too fast, not too slow
-	d.speedRpm = d.MaxSpeed() / 4
+	d.speedRpm = d.maxSpeed() / 4
 
 	for _, opt := range opts {
 		switch o := opt.(type) {
@@ -88,13 +224,27 @@ func NewEasyDriver(a DigitalWriter, anglePerStep float32, stepPin string, opts .
 		case easyOptionApplier:
 			o.apply(d.easyCfg)
 		default:
-			oNames := []string{"WithEasyDirectionPin", "WithEasyEnablePin", "WithEasySleepPin"}
+			oNames := []string{"WithEasyDirectionPin", "WithEasyEnablePin", "WithEasySleepPin", "WithEasyCurrentControl",
+				"WithEasyWriteTimeout", "WithEasyInvertedStep"}
 			msg := fmt.Sprintf("'%s' can not be applied on '%s', consider to use one of the options instead: %s",
 				opt, d.driverCfg.name, strings.Join(oNames, ", "))
 			panic(msg)
 		}
 	}
 
+	d.AddCommand("State", func(params map[string]interface{}) interface{} {
+		return d.State()
+	})
+	d.AddCommand("GetParams", func(params map[string]interface{}) interface{} {
+		return d.GetParams()
+	})
+	d.AddCommand("SetParams", func(params map[string]interface{}) interface{} {
+		return d.SetParams(params)
+	})
+	d.AddCommand("EmergencyStop", func(params map[string]interface{}) interface{} {
+		return d.EmergencyStop()
+	})
+
 	return d
 }
 
@@ -113,10 +263,23 @@ func WithEasySleepPin(pin string) easyOptionApplier {
 	return easySleepPinOption(pin)
 }
 
+// WithEasyWriteTimeout configure a timeout for the writes of Enable(), Disable(), Sleep() and Wake(), e.g. if the pins
+// are connected by an I/O expander, which can hang. A write, which is not finished in time, leads to an error instead
+// of blocking forever. The hanging write itself can not be canceled. Default is no timeout.
+func WithEasyWriteTimeout(timeout time.Duration) easyOptionApplier {
+	return easyWriteTimeoutOption(timeout)
+}
+
+// WithEasyInvertedStep inverts the polarity of the step pulse, e.g. for boards which step on the falling edge or
+// expect active low pulses. The step pin is high, when idle.
+func WithEasyInvertedStep() easyOptionApplier {
+	return easyInvertedStepOption(true)
+}
+
 // SetDirection sets the direction to be moving.
 func (d *EasyDriver) SetDirection(direction string) error {
 	if d.easyCfg.dirPin == "" {
-		return fmt.Errorf("dirPin is not set for '%s'", d.driverCfg.name)
+		return fmt.Errorf("%w for '%s'", ErrDirPinNotSet, d.driverCfg.name)
 	}
 
 	direction = strings.ToLower(direction)
@@ -125,12 +288,7 @@ func (d *EasyDriver) SetDirection(direction string) error {
 			direction, StepperDriverForward, StepperDriverBackward)
 	}
 
-	writeVal := byte(0) // low is forward
-	if direction == StepperDriverBackward {
-		writeVal = 1 // high is backward
-	}
-
-	if err := d.digitalWrite(d.easyCfg.dirPin, writeVal); err != nil {
+	if err := d.writeDirection(direction); err != nil {
 		return err
 	}
 
@@ -138,6 +296,8 @@ func (d *EasyDriver) SetDirection(direction string) error {
 	d.valueMutex.Lock()
 	defer d.valueMutex.Unlock()
 	d.direction = direction
+	d.directionChangedAt = time.Now()
+	d.settlePending = true
 
 	return nil
 }
@@ -145,53 +305,84 @@ func (d *EasyDriver) SetDirection(direction string) error {
 // Enable enables all motor output
 func (d *EasyDriver) Enable() error {
 	if d.easyCfg.enPin == "" {
-		d.disabled = false
-		return fmt.Errorf("enPin is not set - board '%s' is enabled by default", d.driverCfg.name)
+		d.setDisabled(false)
+		return fmt.Errorf("%w - board '%s' is enabled by default", ErrEnablePinNotSet, d.driverCfg.name)
 	}
 
 	// enPin is active low
-	if err := d.digitalWrite(d.easyCfg.enPin, 0); err != nil {
+	if err := d.controlWrite(d.easyCfg.enPin, 0); err != nil {
 		return err
 	}
 
-	d.disabled = false
+	d.setDisabled(false)
+	d.recordActivity()
 	return nil
 }
 
 // Disable disables all motor output
 func (d *EasyDriver) Disable() error {
 	if d.easyCfg.enPin == "" {
-		return fmt.Errorf("enPin is not set for '%s'", d.driverCfg.name)
+		return fmt.Errorf("%w for '%s'", ErrEnablePinNotSet, d.driverCfg.name)
 	}
 
 	_ = d.stopIfRunning() // drop step errors
 
 	// enPin is active low
-	if err := d.digitalWrite(d.easyCfg.enPin, 1); err != nil {
+	if err := d.controlWrite(d.easyCfg.enPin, 1); err != nil {
 		return err
 	}
-	d.disabled = true
+	d.setDisabled(true)
 
 	return nil
 }
 
 // IsEnabled returns a bool stating whether motor is enabled
 func (d *EasyDriver) IsEnabled() bool {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
 	return !d.disabled
 }
 
+// EmergencyStop stops the motor immediately like Stop(), cancels a running path and all queued movements and
+// de-energizes the coils with the enable pin or, if not available, with the sleep pin. Afterwards the motor needs to
+// be enabled or woken up for the next movement. An error is returned, if no pin is available to de-energize the coils.
+func (d *EasyDriver) EmergencyStop() error {
+	d.ClearQueue()
+	d.signalStopPath()
+	stopErr := d.stopIfRunning()
+
+	var err error
+	switch {
+	case d.easyCfg.enPin != "":
+		err = d.Disable()
+	case d.easyCfg.sleepPin != "":
+		err = d.Sleep()
+	default:
+		err = fmt.Errorf("neither enPin nor sleepPin is set for '%s', the coils can not be de-energized: %w",
+			d.driverCfg.name, ErrPinNotSet)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return stopErr
+}
+
 // Wake wakes up the driver
 func (d *EasyDriver) Wake() error {
 	if d.easyCfg.sleepPin == "" {
-		return fmt.Errorf("sleepPin is not set for '%s'", d.driverCfg.name)
+		return fmt.Errorf("%w for '%s'", ErrSleepPinNotSet, d.driverCfg.name)
 	}
 
 	// sleepPin is active low
-	if err := d.digitalWrite(d.easyCfg.sleepPin, 1); err != nil {
+	if err := d.controlWrite(d.easyCfg.sleepPin, 1); err != nil {
 		return err
 	}
 
-	d.sleeping = false
+	d.setSleeping(false)
+	d.recordActivity()
 
 	// we need to wait 1ms after sleeping before doing a step to charge the step pump (according to data sheet)
 	time.Sleep(1 * time.Millisecond)
@@ -199,52 +390,856 @@ func (d *EasyDriver) Wake() error {
 	return nil
 }
 
+// SetPositionSign defines the sign convention of the step counter, see CurrentStep(). With 1 (default), a step
+// forward increments the counter and a step backward decrements it. With -1 the counting is inverted, e.g. to match
+// the physical motion, if the direction pin is wired inverted. Movements to an absolute position, e.g. by SetValue(),
+// consider the sign convention.
+func (d *EasyDriver) SetPositionSign(sign int) error {
+	if sign != 1 && sign != -1 {
+		return fmt.Errorf("position sign (%d) must be 1 or -1", sign)
+	}
+
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.positionSign = sign
+
+	return nil
+}
+
+// SetAnglePerStep changes the step angle of the motor in degrees, e.g. after a change of the micro stepping. The steps
+// per revolution are recalculated together with the angle, so the speed calculations stay consistent. The speed is
+// limited to the new MaxSpeed() and the max. step frequency, the accumulated fraction of MoveDeg() is dropped. A
+// running finite movement is finished before, an endless movement by Run() needs to be stopped before.
+func (d *EasyDriver) SetAnglePerStep(anglePerStep float32) error {
+	if anglePerStep <= 0 {
+		return fmt.Errorf("angle per step (%v) of '%s' needs to be greater than zero", anglePerStep, d.driverCfg.name)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	if d.stopAsynchRunFunc != nil {
+		return fmt.Errorf("angle per step of '%s' can not be changed while moving", d.driverCfg.name)
+	}
+
+	d.anglePerStep = anglePerStep
+	d.stepsPerRev = 360.0 / anglePerStep
+	d.moveDegResidual = 0
+	maxRpm := d.maxSpeed()
+	if d.maxStepFrequency > 0 {
+		if maxFreqRpm := uint(float32(60*d.maxStepFrequency) / d.stepsPerRev); maxFreqRpm < maxRpm {
+			maxRpm = maxFreqRpm
+		}
+	}
+	if maxRpm < 1 {
+		maxRpm = 1
+	}
+	if d.speedRpm > maxRpm {
+		d.speedRpm = maxRpm
+	}
+
+	return nil
+}
+
+// AnglePerStep returns the step angle of the motor in degrees.
+func (d *EasyDriver) AnglePerStep() float32 {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.anglePerStep
+}
+
+// PositionSign returns the sign convention of the step counter, see SetPositionSign().
+func (d *EasyDriver) PositionSign() int {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.positionSign
+}
+
+// SetStepPulseWidth sets the minimum time, the step pin stays high for each step (default 2 us). Some driver boards,
+// e.g. with slow opto-isolated inputs, need a longer pulse to register a step reliably. The pulse is part of the delay
+// between two steps, so the speed is not changed, as long as the pulse width is shorter than this delay. Zero disables
+// the holding of the pulse.
+func (d *EasyDriver) SetStepPulseWidth(width time.Duration) error {
+	if width < 0 {
+		return fmt.Errorf("step pulse width (%s) cannot be a negative value", width)
+	}
+
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.stepPulseWidth = width
+
+	return nil
+}
+
+// SetDirectionSetupTime sets the minimum time between a change of the direction pin and the next step (default 5 us).
+// The driver boards need this setup time to step reliably in the new direction.
+func (d *EasyDriver) SetDirectionSetupTime(setupTime time.Duration) error {
+	if setupTime < 0 {
+		return fmt.Errorf("direction setup time (%s) cannot be a negative value", setupTime)
+	}
+
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.directionSetupTime = setupTime
+
+	return nil
+}
+
+// DirectionSetupTime returns the minimum time between a change of the direction pin and the next step, see
+// SetDirectionSetupTime().
+func (d *EasyDriver) DirectionSetupTime() time.Duration {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.directionSetupTime
+}
+
+// SetDirectionSettleDelay sets the delay, which is inserted before the next step after each write of the direction
+// pin, by SetDirection() or by a reversal within a movement (default 0, no delay). In contrast to the setup time, which
+// elapses during the low time of the step pulse for slow speeds, this delay is always added, e.g. for driver boards
+// with a slow propagation of the direction signal, which would otherwise lose the first step.
+func (d *EasyDriver) SetDirectionSettleDelay(delay time.Duration) error {
+	if delay < 0 {
+		return fmt.Errorf("direction settle delay (%s) cannot be a negative value", delay)
+	}
+
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.settleDelay = delay
+
+	return nil
+}
+
+// DirectionSettleDelay returns the delay before the next step after a write of the direction pin, see
+// SetDirectionSettleDelay().
+func (d *EasyDriver) DirectionSettleDelay() time.Duration {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.settleDelay
+}
+
+// StepPulseWidth returns the minimum time, the step pin stays high for each step, see SetStepPulseWidth().
+func (d *EasyDriver) StepPulseWidth() time.Duration {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.stepPulseWidth
+}
+
+// SetTrace switches the logging of each step on or off (default), e.g. for debugging of timing issues. Each step is
+// logged with its index in the movement, the position, the direction and the delay. The logging is costly, so it
+// should not be activated in production.
+func (d *EasyDriver) SetTrace(enabled bool) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.trace = enabled
+}
+
+// SetIdleBehavior defines what happens with the motor output after a finished move. With EasyIdleHold (default) the
+// coils stay energized to hold the position. With EasyIdleRelease the motor output is disabled and with EasyIdleSleep
+// the driver is put to sleep, both after the given delay to reduce heat and power consumption. A released or sleeping
+// driver is enabled or woken up automatically by the next move. The position can be lost while the coils are released.
+func (d *EasyDriver) SetIdleBehavior(mode string, delay time.Duration) error {
+	switch mode {
+	case EasyIdleHold:
+	case EasyIdleRelease:
+		if !d.HasEnablePin() {
+			return fmt.Errorf("%w for '%s', so idle behavior '%s' is not possible", ErrEnablePinNotSet,
+				d.driverCfg.name, mode)
+		}
+	case EasyIdleSleep:
+		if !d.HasSleepPin() {
+			return fmt.Errorf("%w for '%s', so idle behavior '%s' is not possible", ErrSleepPinNotSet,
+				d.driverCfg.name, mode)
+		}
+	default:
+		return fmt.Errorf("invalid idle behavior '%s', value should be '%s', '%s' or '%s'", mode, EasyIdleHold,
+			EasyIdleRelease, EasyIdleSleep)
+	}
+
+	if delay < 0 {
+		return fmt.Errorf("idle delay (%s) must not be negative", delay)
+	}
+
+	d.idleMutex.Lock()
+	defer d.idleMutex.Unlock()
+
+	d.idleMode = mode
+	d.idleDelay = delay
+
+	return nil
+}
+
+// IdleBehavior returns the current idle behavior and delay, see SetIdleBehavior().
+func (d *EasyDriver) IdleBehavior() (string, time.Duration) {
+	d.idleMutex.Lock()
+	defer d.idleMutex.Unlock()
+
+	return d.idleMode, d.idleDelay
+}
+
 // IsSleeping returns a bool stating whether motor is sleeping
 func (d *EasyDriver) IsSleeping() bool {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
 	return d.sleeping
 }
 
+// HasDirPin returns true, if a pin for changing the direction was configured, see [gpio.WithEasyDirectionPin].
+func (d *EasyDriver) HasDirPin() bool {
+	return d.easyCfg.dirPin != ""
+}
+
+// HasEnablePin returns true, if a pin for disabling/enabling was configured, see [gpio.WithEasyEnablePin].
+func (d *EasyDriver) HasEnablePin() bool {
+	return d.easyCfg.enPin != ""
+}
+
+// HasSleepPin returns true, if a pin for sleep/wake was configured, see [gpio.WithEasySleepPin].
+func (d *EasyDriver) HasSleepPin() bool {
+	return d.easyCfg.sleepPin != ""
+}
+
+// State returns the current state together with the capabilities of the driver.
+func (d *EasyDriver) State() EasyDriverState {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return EasyDriverState{
+		Direction:    d.direction,
+		CurrentStep:  d.stepNum,
+		SpeedRpm:     d.speedRpm,
+		Moving:       d.stopAsynchRunFunc != nil,
+		Enabled:      !d.disabled,
+		Sleeping:     d.sleeping,
+		HasDirPin:    d.HasDirPin(),
+		HasEnablePin: d.HasEnablePin(),
+		HasSleepPin:  d.HasSleepPin(),
+	}
+}
+
+// GetParams returns all parameters, which can be changed by SetParams(). The keys are "speed" (rpm), "direction",
+// "position" (current step), "enabled" and "sleeping".
+func (d *EasyDriver) GetParams() map[string]interface{} {
+	state := d.State()
+
+	return map[string]interface{}{
+		easyParamSpeed:     state.SpeedRpm,
+		easyParamDirection: state.Direction,
+		easyParamPosition:  state.CurrentStep,
+		easyParamEnabled:   state.Enabled,
+		easyParamSleeping:  state.Sleeping,
+	}
+}
+
+// SetParams changes the given parameters, see GetParams() for the keys. Not given parameters are not changed. All
+// parameters are validated before the first one is applied, so an invalid value prevents the whole update. A change,
+// which needs a pin which is not configured, is invalid. Numbers and booleans can also be given as string, e.g. for
+// usage by the API. A given position leads to a movement to this step. A given direction is applied after this
+// movement, so it is kept for the next movements.
+func (d *EasyDriver) SetParams(params map[string]interface{}) error {
+	var speed, position *int
+	var direction *string
+	var enabled, sleeping *bool
+
+	for key, val := range params {
+		var err error
+		switch key {
+		case easyParamSpeed:
+			speed, err = easyParamToInt(key, val)
+			if err == nil && *speed <= 0 {
+				err = fmt.Errorf("parameter '%s' (%d) must be greater than zero", key, *speed)
+			}
+			if err == nil {
+				_, err = d.limitSpeed(uint(*speed))
+			}
+		case easyParamPosition:
+			position, err = easyParamToInt(key, val)
+		case easyParamDirection:
+			str, ok := val.(string)
+			if !ok {
+				err = fmt.Errorf("parameter '%s' needs to be a string, but is '%v'", key, val)
+				break
+			}
+			str = strings.ToLower(str)
+			if str != StepperDriverForward && str != StepperDriverBackward {
+				err = fmt.Errorf("Invalid direction '%s'. Value should be '%s' or '%s'",
+					str, StepperDriverForward, StepperDriverBackward)
+			} else if str != d.State().Direction && !d.HasDirPin() {
+				err = fmt.Errorf("%w for '%s'", ErrDirPinNotSet, d.driverCfg.name)
+			}
+			direction = &str
+		case easyParamEnabled:
+			enabled, err = easyParamToBool(key, val)
+			if err == nil && *enabled != d.IsEnabled() && !d.HasEnablePin() {
+				err = fmt.Errorf("%w for '%s'", ErrEnablePinNotSet, d.driverCfg.name)
+			}
+		case easyParamSleeping:
+			sleeping, err = easyParamToBool(key, val)
+			if err == nil && *sleeping != d.IsSleeping() && !d.HasSleepPin() {
+				err = fmt.Errorf("%w for '%s'", ErrSleepPinNotSet, d.driverCfg.name)
+			}
+		default:
+			err = fmt.Errorf("unknown parameter '%s'", key)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if position != nil && d.stepsToPosition(*position) != 0 {
+		d.idleMutex.Lock()
+		releasedByIdle := d.idleAction == EasyIdleRelease
+		d.idleMutex.Unlock()
+		if (enabled != nil && !*enabled) || (enabled == nil && !d.IsEnabled() && !releasedByIdle) {
+			return fmt.Errorf("'%s' is disabled and can not be moved to position %d", d.driverCfg.name, *position)
+		}
+	}
+
+	if enabled != nil {
+		if err := d.setEnabled(*enabled); err != nil {
+			return err
+		}
+	}
+
+	if sleeping != nil && !*sleeping && d.IsSleeping() {
+		if err := d.Wake(); err != nil {
+			return err
+		}
+	}
+
+	if speed != nil {
+		if err := d.SetSpeed(uint(*speed)); err != nil {
+			return err
+		}
+	}
+
+	if position != nil {
+		if steps := d.stepsToPosition(*position); steps != 0 {
+			if err := d.Move(steps); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the direction is applied after the movement, because the movement changes the direction
+	if direction != nil && *direction != d.State().Direction && d.HasDirPin() {
+		if err := d.SetDirection(*direction); err != nil {
+			return err
+		}
+	}
+
+	if sleeping != nil && *sleeping && !d.IsSleeping() {
+		return d.Sleep()
+	}
+
+	return nil
+}
+
+// SetValue (interface gobot.Actuator) moves the motor to the given angle in degrees, related to the position at start
+// (step zero). Like for GetValue(), the angle is taken within one revolution, so the motor moves on the shortest path
+// to the angle, e.g. setting the value, which was just read, does not move the motor after several revolutions. Use
+// MoveToDeg() to move to an absolute angle over several revolutions.
+func (d *EasyDriver) SetValue(val float64) error {
+	if val < 0 || val > 360 {
+		return fmt.Errorf("angle (%v) must be between 0-360", val)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.valueMutex.Lock()
+	current := float64(d.stepNum) * float64(d.anglePerStep)
+	d.valueMutex.Unlock()
+
+	diff := math.Mod(val-current, 360)
+	if diff > 180 {
+		diff -= 360
+	} else if diff < -180 {
+		diff += 360
+	}
+
+	return d.moveToDeg(current + diff)
+}
+
+// StepOnce does exactly one step in the given direction and returns afterwards, e.g. for a fine alignment during setup.
+// The step is done with the delay of the current speed, but without the asynchronous stepping. A change of the
+// direction needs the direction pin and is kept for following steps. A motor, which was released or put to sleep by
+// the idle behavior, is woken up before.
+func (d *EasyDriver) StepOnce(direction string) error {
+	direction = strings.ToLower(direction)
+	if direction != StepperDriverForward && direction != StepperDriverBackward {
+		return fmt.Errorf("Invalid direction '%s'. Value should be '%s' or '%s'",
+			direction, StepperDriverForward, StepperDriverBackward)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.IsMoving() {
+		return fmt.Errorf("'%s' is moving, single step not possible", d.driverCfg.name)
+	}
+
+	if err := d.beforeMoveFunc(); err != nil {
+		return err
+	}
+	defer d.afterMoveFunc()
+
+	if !d.IsEnabled() {
+		return fmt.Errorf("'%s' is disabled and can not be running or moving", d.driverCfg.name)
+	}
+
+	d.valueMutex.Lock()
+	currentDirection := d.direction
+	d.valueMutex.Unlock()
+
+	if direction != currentDirection {
+		if err := d.SetDirection(direction); err != nil {
+			return err
+		}
+	}
+
+	return d.stepFunc()
+}
+
+// SelfTest moves the motor the given number of steps forward and back again, e.g. to confirm the wiring before a run.
+// An error is returned, if the driver is disabled or already moving, if a step fails or if the current step differs
+// from the start position afterwards. A motor, which was released by the idle behavior, is enabled again. The
+// direction, which was set before, is restored after the test.
+func (d *EasyDriver) SelfTest(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps (%d) for self test must be greater than zero", steps)
+	}
+	d.idleMutex.Lock()
+	releasedByIdle := d.idleAction == EasyIdleRelease
+	d.idleMutex.Unlock()
+	if !d.IsEnabled() && !releasedByIdle {
+		return fmt.Errorf("'%s' is disabled, self test not possible", d.driverCfg.name)
+	}
+	if d.IsMoving() {
+		return fmt.Errorf("'%s' is moving, self test not possible", d.driverCfg.name)
+	}
+
+	d.valueMutex.Lock()
+	priorDirection := d.direction
+	d.valueMutex.Unlock()
+
+	defer func() {
+		d.valueMutex.Lock()
+		defer d.valueMutex.Unlock()
+
+		if err := d.changeDirection(priorDirection); err != nil {
+			d.debug(fmt.Sprintf("restore of direction '%s' failed: %v", priorDirection, err))
+		}
+	}()
+
+	startStep := d.CurrentStep()
+	if err := d.Move(steps); err != nil {
+		return fmt.Errorf("self test of '%s' failed on forward move: %w", d.driverCfg.name, err)
+	}
+	if err := d.Move(-steps); err != nil {
+		return fmt.Errorf("self test of '%s' failed on backward move: %w", d.driverCfg.name, err)
+	}
+
+	if endStep := d.CurrentStep(); endStep != startStep {
+		return fmt.Errorf("self test of '%s' failed, position %d differs from start position %d", d.driverCfg.name,
+			endStep, startStep)
+	}
+
+	return nil
+}
+
+// GetValue (interface gobot.Actuator) returns the current angle in degrees, related to the position at start
+// (step zero). The angle is normalized to the range [0, 360) of ValueRange(), also after several revolutions or
+// movements below step zero.
+func (d *EasyDriver) GetValue() float64 {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.normalizedAngle(d.stepNum)
+}
+
+// normalizedAngle returns the angle of the given step within [0, 360). The value mutex needs to be locked by the
+// caller.
+func (d *EasyDriver) normalizedAngle(step int) float64 {
+	angle := math.Mod(float64(step)*float64(d.anglePerStep), 360)
+	if angle < 0 {
+		angle += 360
+	}
+
+	return angle
+}
+
+// ValueRange (interface gobot.Actuator) returns the range of the absolute angle in degrees
+func (d *EasyDriver) ValueRange() (float64, float64) {
+	return 0, 360
+}
+
 func (d *EasyDriver) onePinStepping() error {
 	// ensure that read and write of variables (direction, stepNum) can not interfere
 	d.valueMutex.Lock()
 	defer d.valueMutex.Unlock()
 
-	// a valid steps occurs for a low to high transition
-	if err := d.digitalWrite(d.stepPin, 0); err != nil {
+	if d.settlePending {
+		d.settlePending = false
+		if d.settleDelay > 0 {
+			d.waitFunc(d.settleDelay)
+		}
+	}
+
+	// a valid steps occurs for a low to high transition, or high to low for inverted polarity
+	idle, active := byte(0), byte(1)
+	if d.easyCfg.invertedStep {
+		idle, active = 1, 0
+	}
+
+	if err := d.digitalWrite(d.stepPin, idle); err != nil {
 		return err
 	}
 
-	time.Sleep(d.getDelayPerStep())
-	if err := d.digitalWrite(d.stepPin, 1); err != nil {
+	// the high time of the pulse is taken from the delay, so the speed is kept
+	delay := d.getSteppingDelay()
+	lowTime := delay - d.stepPulseWidth
+	if lowTime > 0 {
+		d.waitFunc(lowTime)
+	}
+	if wait := d.directionSetupTime - time.Since(d.directionChangedAt); wait > 0 {
+		d.waitFunc(wait)
+	}
+	if err := d.digitalWrite(d.stepPin, active); err != nil {
 		return err
 	}
+	if d.stepPulseWidth > 0 {
+		d.waitFunc(d.stepPulseWidth)
+	}
 
 	if d.direction == StepperDriverForward {
-		d.stepNum++
+		d.stepNum += d.positionSign
 	} else {
-		d.stepNum--
+		d.stepNum -= d.positionSign
+	}
+
+	if d.trace {
+		d.traceStep(delay)
+	}
+
+	return nil
+}
+
+// traceStep logs the done step. The value mutex needs to be locked by the caller.
+func (d *EasyDriver) traceStep(delay time.Duration) {
+	var index int
+	if d.moveProgress != nil && d.moveProgress.active {
+		index = d.moveProgress.doneSteps
+	}
+
+	d.traceLogger.Printf("'%s' step index=%d position=%d direction=%s delay=%s\n", d.driverCfg.name, index, d.stepNum,
+		d.direction, delay)
+}
+
+// stepsToPosition returns the steps to move (positive for forward) to reach the given position
+func (d *EasyDriver) stepsToPosition(position int) int {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return (position - d.stepNum) * d.positionSign
+}
+
+// writeDirection writes the given direction to the direction pin. Without a direction pin, nothing is written, because
+// the direction is given by the wiring.
+func (d *EasyDriver) writeDirection(direction string) error {
+	if d.easyCfg.dirPin == "" {
+		return nil
+	}
+
+	writeVal := byte(0) // low is forward
+	if direction == StepperDriverBackward {
+		writeVal = 1 // high is backward
+	}
+
+	return d.digitalWrite(d.easyCfg.dirPin, writeVal)
+}
+
+// changeDirectionPin writes the direction pin on a change of the direction by a movement and remembers the time of
+// the change for the setup time. The value mutex needs to be locked by the caller.
+func (d *EasyDriver) changeDirectionPin(direction string) error {
+	if d.easyCfg.dirPin == "" {
+		return nil
+	}
+
+	if err := d.writeDirection(direction); err != nil {
+		return err
 	}
+	d.directionChangedAt = time.Now()
+	d.settlePending = true
 
 	return nil
 }
 
+// initialize declares all used pins as outputs, if supported by the adaptor. The initial values matches the state of
+// the driver (forward, enabled, awake, step pin idle).
+func (d *EasyDriver) initialize() error {
+	if err := RequireCapabilities(d.connection, DigitalWriterCapability); err != nil {
+		return err
+	}
+
+	pins := []gobot.PinConfig{{Pin: d.stepPin, Output: true}}
+	if d.easyCfg.invertedStep {
+		pins[0].InitialValue = 1
+	}
+	if d.HasDirPin() {
+		pins = append(pins, gobot.PinConfig{Pin: d.easyCfg.dirPin, Output: true})
+	}
+	if d.HasEnablePin() {
+		// enPin is active low
+		pins = append(pins, gobot.PinConfig{Pin: d.easyCfg.enPin, Output: true})
+	}
+	if d.HasSleepPin() {
+		// sleepPin is active low
+		pins = append(pins, gobot.PinConfig{Pin: d.easyCfg.sleepPin, Output: true, InitialValue: 1})
+	}
+
+	if err := d.setupPins(pins...); err != nil {
+		return err
+	}
+
+	d.startIdleWatchdog()
+
+	return nil
+}
+
+// shutdown cancels a pending idle action and the idle watchdog, clears the motion queue, stops a path and the motor,
+// if running
+func (d *EasyDriver) shutdown() error {
+	d.ClearQueue()
+	d.signalStopPath()
+	d.takeProgressReport()
+	d.takePositionSampling()
+	d.takeIdleWatchdog()
+
+	d.idleMutex.Lock()
+	d.stopIdleTimer()
+	d.idleMutex.Unlock()
+
+	return d.StepperDriver.shutdown()
+}
+
+// leaveIdle cancels a pending idle action and reverts the last one, so the motor is able to move. The driver mutex
+// needs to be locked by the caller.
+func (d *EasyDriver) leaveIdle() error {
+	d.idleMutex.Lock()
+	d.stopIdleTimer()
+	action := d.idleAction
+	d.idleAction = ""
+	d.watchdogActivity = d.watchdogNow()
+	d.idleMutex.Unlock()
+
+	switch action {
+	case EasyIdleRelease:
+		if !d.IsEnabled() {
+			return d.Enable()
+		}
+	case EasyIdleSleep:
+		if d.IsSleeping() {
+			return d.Wake()
+		}
+	}
+
+	return nil
+}
+
+// beforeMove leaves the idle state and starts the progress report and the position sampling. The driver mutex needs
+// to be locked by the caller.
+func (d *EasyDriver) beforeMove() error {
+	if err := d.leaveIdle(); err != nil {
+		return err
+	}
+
+	d.startProgressReport()
+	d.startPositionSampling()
+
+	return nil
+}
+
+// afterMove stops the progress report and the position sampling, verifies the move with the attached encoder and
+// enters the idle state. The driver mutex needs to be locked by the caller.
+func (d *EasyDriver) afterMove() {
+	d.stopProgressReport()
+	d.stopPositionSampling()
+	d.verifyEncoder()
+	d.enterIdle()
+}
+
+// enterIdle starts the timer for the idle action after a move. The driver mutex needs to be locked by the caller.
+func (d *EasyDriver) enterIdle() {
+	d.idleMutex.Lock()
+	defer d.idleMutex.Unlock()
+
+	d.watchdogActivity = d.watchdogNow()
+
+	if d.idleMode == EasyIdleHold {
+		return
+	}
+
+	mode := d.idleMode
+	generation := d.idleGeneration
+	d.idleTimer = time.AfterFunc(d.idleDelay, func() {
+		// the driver mutex ensures, that the action can not interfere with a move
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+
+		d.idleMutex.Lock()
+		defer d.idleMutex.Unlock()
+
+		if generation != d.idleGeneration {
+			// a new move was started in the meantime
+			return
+		}
+
+		var err error
+		if mode == EasyIdleRelease {
+			err = d.Disable()
+		} else {
+			err = d.sleepWithSleepPin()
+		}
+		if err != nil {
+			d.debug(fmt.Sprintf("idle action '%s' failed: %v", mode, err))
+			return
+		}
+
+		d.idleAction = mode
+	})
+}
+
+// stopIdleTimer stops a pending idle action. The idle mutex needs to be locked by the caller.
+func (d *EasyDriver) stopIdleTimer() {
+	d.idleGeneration++
+	if d.idleTimer != nil {
+		d.idleTimer.Stop()
+		d.idleTimer = nil
+	}
+}
+
+func (d *EasyDriver) setEnabled(enabled bool) error {
+	if enabled == d.IsEnabled() {
+		return nil
+	}
+
+	if enabled {
+		return d.Enable()
+	}
+
+	return d.Disable()
+}
+
+// setDisabled writes the flag for disabled motor output
+func (d *EasyDriver) setDisabled(disabled bool) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.disabled = disabled
+}
+
+// setSleeping writes the flag for the sleeping driver
+func (d *EasyDriver) setSleeping(sleeping bool) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.sleeping = sleeping
+}
+
 // sleepWithSleepPin puts the driver to sleep and disables all motor output.  Low power mode.
 func (d *EasyDriver) sleepWithSleepPin() error {
 	if d.easyCfg.sleepPin == "" {
-		return fmt.Errorf("sleepPin is not set for '%s'", d.driverCfg.name)
+		return fmt.Errorf("%w for '%s'", ErrSleepPinNotSet, d.driverCfg.name)
 	}
 
 	_ = d.stopIfRunning() // drop step errors
 
 	// sleepPin is active low
-	if err := d.digitalWrite(d.easyCfg.sleepPin, 0); err != nil {
+	if err := d.controlWrite(d.easyCfg.sleepPin, 0); err != nil {
 		return err
 	}
-	d.sleeping = true
+	d.setSleeping(true)
 
 	return nil
 }
 
+// controlWrite writes the given value to the given control pin (enable, sleep) and returns an error, if the write
+// is not finished within the configured timeout.
+func (d *EasyDriver) controlWrite(pin string, val byte) error {
+	timeout := d.easyCfg.writeTimeout
+	if timeout <= 0 {
+		return d.digitalWrite(pin, val)
+	}
+
+	errChan := make(chan error, 1) // buffered, so a late write does not block forever
+	go func() {
+		errChan <- d.digitalWrite(pin, val)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("write of pin '%s' for '%s' timed out after %s", pin, d.driverCfg.name, timeout)
+	}
+}
+
+// easyParamToInt converts the given parameter value (int, float64 or string) to an integer
+func easyParamToInt(key string, val interface{}) (*int, error) {
+	var i int
+	switch v := val.(type) {
+	case int:
+		i = v
+	case float64:
+		if v != math.Trunc(v) {
+			return nil, fmt.Errorf("parameter '%s' needs to be an integer, but is '%v'", key, val)
+		}
+		i = int(v)
+	case string:
+		var err error
+		if i, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("parameter '%s' needs to be an integer, but is '%v'", key, val)
+		}
+	default:
+		return nil, fmt.Errorf("parameter '%s' needs to be an integer, but is '%v'", key, val)
+	}
+
+	return &i, nil
+}
+
+// easyParamToBool converts the given parameter value (bool or string) to a boolean
+func easyParamToBool(key string, val interface{}) (*bool, error) {
+	var b bool
+	switch v := val.(type) {
+	case bool:
+		b = v
+	case string:
+		var err error
+		if b, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("parameter '%s' needs to be a boolean, but is '%v'", key, val)
+		}
+	default:
+		return nil, fmt.Errorf("parameter '%s' needs to be a boolean, but is '%v'", key, val)
+	}
+
+	return &b, nil
+}
+
 func (o easyDirPinOption) String() string {
 	return "direction pin option easy driver"
 }
@@ -257,6 +1252,14 @@ func (o easySleepPinOption) String() string {
 	return "sleep pin option easy driver"
 }
 
+func (o easyWriteTimeoutOption) String() string {
+	return "write timeout option easy driver"
+}
+
+func (o easyInvertedStepOption) String() string {
+	return "inverted step option easy driver"
+}
+
 func (o easyDirPinOption) apply(cfg *easyConfiguration) {
 	cfg.dirPin = string(o)
 }
@@ -268,3 +1271,11 @@ func (o easyEnPinOption) apply(cfg *easyConfiguration) {
 func (o easySleepPinOption) apply(cfg *easyConfiguration) {
 	cfg.sleepPin = string(o)
 }
+
+func (o easyWriteTimeoutOption) apply(cfg *easyConfiguration) {
+	cfg.writeTimeout = time.Duration(o)
+}
+
+func (o easyInvertedStepOption) apply(cfg *easyConfiguration) {
+	cfg.invertedStep = bool(o)
+}
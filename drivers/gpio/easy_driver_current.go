@@ -0,0 +1,86 @@
+package gpio
+
+import (
+	"fmt"
+	"math"
+)
+
+// easyCurrentConfiguration contains the attributes for the control of the coil current by the reference voltage
+type easyCurrentConfiguration struct {
+	pin           string
+	senseResistor float64
+	gain          float64
+	outputVoltage float64
+}
+
+// easyCurrentControlOption is the type for applying the control of the coil current
+type easyCurrentControlOption easyCurrentConfiguration
+
+// WithEasyCurrentControl configures a PWM output (normally filtered by a RC low pass) or DAC, which drives the reference
+// voltage (Vref) for the current limit of the board. The relation is "Vref = current * gain * senseResistor", e.g.
+// the gain is 8 for A4988 and 5 for DRV8825 boards. The output voltage is the voltage at full duty cycle, e.g. 3.3 V.
+func WithEasyCurrentControl(pin string, senseResistor, gain, outputVoltage float64) easyOptionApplier {
+	return easyCurrentControlOption{
+		pin:           pin,
+		senseResistor: senseResistor,
+		gain:          gain,
+		outputVoltage: outputVoltage,
+	}
+}
+
+// HasCurrentControl returns true, if the current control was configured, see [gpio.WithEasyCurrentControl].
+func (d *EasyDriver) HasCurrentControl() bool {
+	return d.easyCfg.current != nil
+}
+
+// SetCurrentLimit sets the limit of the coil current in ampere by writing the related reference voltage.
+func (d *EasyDriver) SetCurrentLimit(amps float64) error {
+	cfg := d.easyCfg.current
+	if cfg == nil {
+		return fmt.Errorf("current control not configured for '%s'", d.driverCfg.name)
+	}
+
+	if cfg.senseResistor <= 0 || cfg.gain <= 0 || cfg.outputVoltage <= 0 {
+		return fmt.Errorf("current control of '%s' needs positive values for sense resistor, gain and output voltage",
+			d.driverCfg.name)
+	}
+
+	if amps < 0 {
+		return fmt.Errorf("current limit (%.3f A) cannot be a negative value", amps)
+	}
+
+	vref := amps * cfg.gain * cfg.senseResistor
+	if vref > cfg.outputVoltage {
+		return fmt.Errorf("current limit (%.3f A) needs a reference voltage of %.3f V, which exceeds the output of %.3f V",
+			amps, vref, cfg.outputVoltage)
+	}
+
+	level := byte(math.Round(vref / cfg.outputVoltage * math.MaxUint8))
+	if err := d.pwmWrite(cfg.pin, level); err != nil {
+		return err
+	}
+
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.currentLimit = amps
+
+	return nil
+}
+
+// CurrentLimit returns the last set limit of the coil current in ampere.
+func (d *EasyDriver) CurrentLimit() float64 {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.currentLimit
+}
+
+func (o easyCurrentControlOption) String() string {
+	return "current control option easy driver"
+}
+
+func (o easyCurrentControlOption) apply(cfg *easyConfiguration) {
+	current := easyCurrentConfiguration(o)
+	cfg.current = &current
+}
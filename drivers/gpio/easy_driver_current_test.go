@@ -0,0 +1,116 @@
+package gpio
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEasy_WithEasyCurrentControl(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.False(t, d.HasCurrentControl())
+	// act
+	WithEasyCurrentControl("5", 0.1, 8, 5.0).apply(d.easyCfg)
+	// assert
+	require.True(t, d.HasCurrentControl())
+	assert.Equal(t, easyCurrentConfiguration{pin: "5", senseResistor: 0.1, gain: 8, outputVoltage: 5.0},
+		*d.easyCfg.current)
+}
+
+func TestEasySetCurrentLimit(t *testing.T) {
+	tests := map[string]struct {
+		opts        []interface{}
+		amps        float64
+		simulateErr bool
+		wantPin     string
+		wantLevel   byte
+		wantWrites  int
+		wantLimit   float64
+		wantErr     string
+	}{
+		"a4988_1A": {
+			opts:       []interface{}{WithEasyCurrentControl("5", 0.1, 8, 5.0)},
+			amps:       1.0,
+			wantPin:    "5",
+			wantLevel:  41, // 0.8 V of 5 V
+			wantWrites: 1,
+			wantLimit:  1.0,
+		},
+		"drv8825_1.5A": {
+			opts:       []interface{}{WithEasyCurrentControl("6", 0.1, 5, 3.3)},
+			amps:       1.5,
+			wantPin:    "6",
+			wantLevel:  58, // 0.75 V of 3.3 V
+			wantWrites: 1,
+			wantLimit:  1.5,
+		},
+		"full_output": {
+			opts:       []interface{}{WithEasyCurrentControl("5", 0.25, 8, 5.0)},
+			amps:       2.5,
+			wantPin:    "5",
+			wantLevel:  255,
+			wantWrites: 1,
+			wantLimit:  2.5,
+		},
+		"error_not_configured": {
+			amps:    1.0,
+			wantErr: "current control not configured for 'EasyDriver-",
+		},
+		"error_invalid_configuration": {
+			opts:    []interface{}{WithEasyCurrentControl("5", 0, 8, 5.0)},
+			amps:    1.0,
+			wantErr: "needs positive values for sense resistor, gain and output voltage",
+		},
+		"error_negative": {
+			opts:    []interface{}{WithEasyCurrentControl("5", 0.1, 8, 5.0)},
+			amps:    -0.1,
+			wantErr: "current limit (-0.100 A) cannot be a negative value",
+		},
+		"error_exceeds_output": {
+			opts:    []interface{}{WithEasyCurrentControl("5", 0.1, 8, 3.3)},
+			amps:    4.2,
+			wantErr: "current limit (4.200 A) needs a reference voltage of 3.360 V, which exceeds the output of 3.300 V",
+		},
+		"error_write": {
+			opts:        []interface{}{WithEasyCurrentControl("5", 0.1, 8, 5.0)},
+			amps:        1.0,
+			simulateErr: true,
+			wantWrites:  1,
+			wantErr:     "pwm write error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 0.5, "1", tc.opts...)
+			var writes int
+			var gotPin string
+			var gotLevel byte
+			a.pwmWriteFunc = func(pin string, val byte) error {
+				writes++
+				gotPin = pin
+				gotLevel = val
+				if tc.simulateErr {
+					return fmt.Errorf("pwm write error")
+				}
+				return nil
+			}
+			// act
+			err := d.SetCurrentLimit(tc.amps)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantPin, gotPin)
+				assert.Equal(t, tc.wantLevel, gotLevel)
+			}
+			assert.Equal(t, tc.wantWrites, writes)
+			assert.InDelta(t, tc.wantLimit, d.CurrentLimit(), 0.0)
+		})
+	}
+}
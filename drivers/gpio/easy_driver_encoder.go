@@ -0,0 +1,152 @@
+package gpio
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// easyEncoderDefaultTolerance is the default count of steps, which can differ between the commanded steps and the
+// counted position of the encoder without a report of lost steps
+const easyEncoderDefaultTolerance = 1
+
+// PositionEncoder is the interface of an encoder, which can be attached to the EasyDriver, e.g. the
+// RotaryEncoderDriver or the i2c.AS5600Driver. The position is given in counts and needs to be continuous over more
+// than one revolution.
+type PositionEncoder interface {
+	Position() int
+}
+
+// easyEncoder contains the attached encoder and the reference positions of the last verification
+type easyEncoder struct {
+	driver        PositionEncoder
+	countsPerStep float64
+	tolerance     float64
+	refStep       int
+	refCount      int
+	discrepancy   float64
+}
+
+// AttachEncoder attaches a rotary encoder or an absolute angle encoder, which is coupled to the motor shaft, for the
+// verification of the moves. The counts per step is the change of the encoder position for one step forward, e.g. 0.2
+// for an encoder with 400 counts per revolution at a motor with 2000 steps per revolution. A negative value can be
+// used, if the encoder counts in the opposite direction. After each finished move the steps, which were commanded
+// since attaching, are compared with the counted position of the encoder, see EncoderDiscrepancy().
+//
+// Emits the Events:
+//
+//	EasyLostSteps float64 - On the discrepancy in steps exceeds the tolerance, see SetEncoderTolerance()
+func (d *EasyDriver) AttachEncoder(enc PositionEncoder, countsPerStep float64) error {
+	if v := reflect.ValueOf(enc); !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return fmt.Errorf("no encoder given to attach to '%s'", d.driverCfg.name)
+	}
+	if countsPerStep == 0 || math.IsNaN(countsPerStep) || math.IsInf(countsPerStep, 0) {
+		return fmt.Errorf("counts per step (%v) of the encoder must be a finite value other than zero", countsPerStep)
+	}
+
+	d.encoderMutex.Lock()
+	defer d.encoderMutex.Unlock()
+
+	tolerance := float64(easyEncoderDefaultTolerance)
+	if d.encoder != nil {
+		tolerance = d.encoder.tolerance
+	}
+
+	d.encoder = &easyEncoder{
+		driver:        enc,
+		countsPerStep: countsPerStep,
+		tolerance:     tolerance,
+		refStep:       d.CurrentStep(),
+		refCount:      enc.Position(),
+	}
+
+	return nil
+}
+
+// DetachEncoder removes the encoder, which was attached by AttachEncoder().
+func (d *EasyDriver) DetachEncoder() {
+	d.encoderMutex.Lock()
+	defer d.encoderMutex.Unlock()
+
+	d.encoder = nil
+}
+
+// SetEncoderTolerance sets the count of steps, which can differ between the commanded steps and the counted position
+// of the encoder without a report of lost steps (default 1).
+func (d *EasyDriver) SetEncoderTolerance(steps float64) error {
+	if steps < 0 || math.IsNaN(steps) {
+		return fmt.Errorf("encoder tolerance (%v) must not be negative", steps)
+	}
+
+	d.encoderMutex.Lock()
+	defer d.encoderMutex.Unlock()
+
+	if d.encoder == nil {
+		return fmt.Errorf("no encoder attached to '%s'", d.driverCfg.name)
+	}
+
+	d.encoder.tolerance = steps
+
+	return nil
+}
+
+// EncoderDiscrepancy returns the difference in steps between the commanded steps and the counted position of the
+// encoder, determined after the last move. A positive value means, that the motor moved less than commanded. The
+// flag is false, if no encoder is attached.
+func (d *EasyDriver) EncoderDiscrepancy() (float64, bool) {
+	d.encoderMutex.Lock()
+	defer d.encoderMutex.Unlock()
+
+	if d.encoder == nil {
+		return 0, false
+	}
+
+	return d.encoder.discrepancy, true
+}
+
+// verifyEncoder compares the commanded steps with the counted position of the attached encoder and publishes the
+// discrepancy, if it exceeds the tolerance. The driver mutex needs to be locked by the caller.
+func (d *EasyDriver) verifyEncoder() {
+	d.encoderMutex.Lock()
+	defer d.encoderMutex.Unlock()
+
+	enc := d.encoder
+	if enc == nil {
+		return
+	}
+
+	if rotary, ok := enc.driver.(*RotaryEncoderDriver); ok && rotary.halt != nil {
+		// give the polling routine the chance to read the last edge
+		time.Sleep(rotary.rotaryEncoderCfg.readInterval)
+	}
+
+	// the step counter is related to the direction, the encoder is related to the motion
+	commanded := float64(d.CurrentStep()-enc.refStep) * float64(d.PositionSign())
+	counted := float64(enc.driver.Position()-enc.refCount) / enc.countsPerStep
+
+	discrepancy := commanded - counted
+	if commanded < 0 {
+		discrepancy = -discrepancy
+	}
+	enc.discrepancy = discrepancy
+
+	if math.Abs(discrepancy) > enc.tolerance {
+		d.Publish(EasyLostSteps, discrepancy)
+	}
+}
+
+// resyncEncoder takes over the current positions as reference for the next verification, e.g. after the step counter
+// was reset.
+func (d *EasyDriver) resyncEncoder() {
+	d.encoderMutex.Lock()
+	defer d.encoderMutex.Unlock()
+
+	if d.encoder == nil {
+		return
+	}
+
+	d.encoder.refStep = d.CurrentStep()
+	d.encoder.refCount = d.encoder.driver.Position()
+	d.encoder.discrepancy = 0
+}
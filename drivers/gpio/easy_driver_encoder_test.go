@@ -0,0 +1,222 @@
+//nolint:forcetypeassert // ok here
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// easyTestEncoderStates is the sequence of quadrature states for moving forward
+var easyTestEncoderStates = [4]int{2, 3, 1, 0}
+
+// easyTestCoupledEncoder simulates an encoder coupled to the motor shaft, which counts once per step and misses the
+// given count of steps at the start of the first move
+func easyTestCoupledEncoder(t *testing.T, d *EasyDriver, a *gpioTestAdaptor, missedSteps int) *RotaryEncoderDriver {
+	t.Helper()
+	enc := NewRotaryEncoderDriver(a, "5", "6")
+	phase := 3 // state 0
+	missed := 0
+	a.digitalWriteFunc = func(pin string, val byte) error {
+		if pin != "1" || val != 1 {
+			return nil
+		}
+		if missed < missedSteps {
+			missed++
+			return nil
+		}
+		// the value mutex is locked by the stepping
+		if d.direction == StepperDriverBackward {
+			phase = (phase + 3) % 4
+		} else {
+			phase = (phase + 1) % 4
+		}
+		enc.evaluate(easyTestEncoderStates[phase])
+		return nil
+	}
+	return enc
+}
+
+func TestEasyAttachEncoder(t *testing.T) {
+	tests := map[string]struct {
+		steps           int
+		missedSteps     int
+		countsPerStep   float64
+		positionSign    int
+		wantDiscrepancy float64
+		wantLostSteps   bool
+	}{
+		"no_lost_steps": {
+			steps:         20,
+			countsPerStep: 1,
+			positionSign:  1,
+		},
+		"lost_steps_forward": {
+			steps:           20,
+			missedSteps:     5,
+			countsPerStep:   1,
+			positionSign:    1,
+			wantDiscrepancy: 5,
+			wantLostSteps:   true,
+		},
+		"lost_steps_backward": {
+			steps:           -20,
+			missedSteps:     5,
+			countsPerStep:   1,
+			positionSign:    1,
+			wantDiscrepancy: 5,
+			wantLostSteps:   true,
+		},
+		"lost_steps_within_tolerance": {
+			steps:           20,
+			missedSteps:     1,
+			countsPerStep:   1,
+			positionSign:    1,
+			wantDiscrepancy: 1,
+		},
+		"inverted_encoder": {
+			steps:           20,
+			missedSteps:     4,
+			countsPerStep:   -1,
+			positionSign:    1,
+			wantDiscrepancy: 4,
+			wantLostSteps:   true,
+		},
+		"inverted_position_sign": {
+			steps:           20,
+			missedSteps:     3,
+			countsPerStep:   1,
+			positionSign:    -1,
+			wantDiscrepancy: 3,
+			wantLostSteps:   true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 1.8, "1", WithEasyDirectionPin("2"))
+			require.NoError(t, d.Start())
+			maxSpeed, err := d.MaxSpeed()
+			require.NoError(t, err)
+			require.NoError(t, d.SetSpeed(maxSpeed))
+			require.NoError(t, d.SetPositionSign(tc.positionSign))
+			enc := easyTestCoupledEncoder(t, d, a, tc.missedSteps)
+			if tc.countsPerStep < 0 {
+				origEvaluate := a.digitalWriteFunc
+				a.digitalWriteFunc = func(pin string, val byte) error {
+					before := enc.Position()
+					err := origEvaluate(pin, val)
+					// invert the counting direction of the encoder
+					enc.mutex.Lock()
+					enc.position = before - (enc.position - before)
+					enc.mutex.Unlock()
+					return err
+				}
+			}
+			require.NoError(t, d.AttachEncoder(enc, tc.countsPerStep))
+			lostSteps := make(chan float64, 1)
+			_ = d.On(EasyLostSteps, func(data interface{}) { lostSteps <- data.(float64) })
+			// act
+			err = d.Move(tc.steps)
+			// assert
+			require.NoError(t, err)
+			discrepancy, ok := d.EncoderDiscrepancy()
+			assert.True(t, ok)
+			assert.InDelta(t, tc.wantDiscrepancy, discrepancy, 0.0)
+			if tc.wantLostSteps {
+				select {
+				case got := <-lostSteps:
+					assert.InDelta(t, tc.wantDiscrepancy, got, 0.0)
+				case <-time.After(time.Second):
+					require.Fail(t, "lost steps event was not published")
+				}
+			} else {
+				select {
+				case <-lostSteps:
+					require.Fail(t, "lost steps event was published")
+				case <-time.After(50 * time.Millisecond):
+				}
+			}
+		})
+	}
+}
+
+func TestEasyAttachEncoder_tolerance(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 1.8, "1", WithEasyDirectionPin("2"))
+	require.NoError(t, d.Start())
+	maxSpeed, err := d.MaxSpeed()
+	require.NoError(t, err)
+	require.NoError(t, d.SetSpeed(maxSpeed))
+	enc := easyTestCoupledEncoder(t, d, a, 5)
+	require.NoError(t, d.AttachEncoder(enc, 1))
+	lostSteps := make(chan float64, 1)
+	_ = d.On(EasyLostSteps, func(data interface{}) { lostSteps <- data.(float64) })
+	// act
+	require.NoError(t, d.SetEncoderTolerance(5))
+	require.NoError(t, d.Move(20))
+	// assert
+	select {
+	case <-lostSteps:
+		require.Fail(t, "lost steps event was published")
+	case <-time.After(50 * time.Millisecond):
+	}
+	discrepancy, _ := d.EncoderDiscrepancy()
+	assert.InDelta(t, 5.0, discrepancy, 0.0)
+}
+
+// easyTestPositionEncoder is a position encoder without polling, e.g. an absolute angle encoder
+type easyTestPositionEncoder struct {
+	position int
+}
+
+func (e *easyTestPositionEncoder) Position() int { return e.position }
+
+func TestEasyAttachEncoder_positionEncoder(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 1.8, "1")
+	require.NoError(t, d.Start())
+	maxSpeed, err := d.MaxSpeed()
+	require.NoError(t, err)
+	require.NoError(t, d.SetSpeed(maxSpeed))
+	enc := &easyTestPositionEncoder{position: 1000}
+	require.NoError(t, d.AttachEncoder(enc, 2))
+	a.digitalWriteFunc = func(pin string, val byte) error {
+		if pin == "1" && val == 1 && enc.position < 1030 {
+			enc.position += 2 // the last 5 steps are lost
+		}
+		return nil
+	}
+	// act
+	require.NoError(t, d.Move(20))
+	// assert
+	discrepancy, ok := d.EncoderDiscrepancy()
+	assert.True(t, ok)
+	assert.InDelta(t, 5.0, discrepancy, 0.0)
+}
+
+func TestEasyAttachEncoder_errors(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 1.8, "1", WithName("motor"))
+	enc := NewRotaryEncoderDriver(a, "5", "6")
+	// act & assert
+	require.EqualError(t, d.AttachEncoder(nil, 1), "no encoder given to attach to 'motor'")
+	var nilEnc *RotaryEncoderDriver
+	require.EqualError(t, d.AttachEncoder(nilEnc, 1), "no encoder given to attach to 'motor'")
+	require.EqualError(t, d.AttachEncoder(enc, 0),
+		"counts per step (0) of the encoder must be a finite value other than zero")
+	require.EqualError(t, d.SetEncoderTolerance(2), "no encoder attached to 'motor'")
+	_, ok := d.EncoderDiscrepancy()
+	assert.False(t, ok)
+	require.NoError(t, d.AttachEncoder(enc, 1))
+	require.EqualError(t, d.SetEncoderTolerance(-1), "encoder tolerance (-1) must not be negative")
+	d.DetachEncoder()
+	_, ok = d.EncoderDiscrepancy()
+	assert.False(t, ok)
+}
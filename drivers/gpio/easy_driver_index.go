@@ -0,0 +1,148 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// HomeToIndex rotates the motor forward with the given speed until the index pin pulses, which is normally done once
+// per revolution by an index output of the motor or an encoder. The position is zeroed at the step of the rising edge
+// of the pulse. The edge detection of the adaptor is used, if available for the pin. Otherwise the pin is read before
+// each step. The movement is stopped with an error, if no pulse is detected within the given timeout. The speed and
+// direction, which were set before, are restored afterwards. Without direction pin, the motor rotates in the direction
+// given by the wiring of the board.
+func (d *EasyDriver) HomeToIndex(indexPin string, speedRpm uint, timeout time.Duration) error {
+	if indexPin == "" {
+		return fmt.Errorf("the index pin is mandatory to home '%s'", d.driverCfg.name)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("the timeout (%s) to home '%s' needs to be greater than zero", timeout, d.driverCfg.name)
+	}
+	if d.IsMoving() {
+		return fmt.Errorf("'%s' is moving, homing not possible", d.driverCfg.name)
+	}
+
+	d.valueMutex.Lock()
+	priorSpeed := d.speedRpm
+	priorDirection := d.direction
+	d.valueMutex.Unlock()
+
+	if err := d.SetSpeed(speedRpm); err != nil {
+		d.valueMutex.Lock()
+		d.speedRpm = priorSpeed
+		d.valueMutex.Unlock()
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.beforeMoveFunc(); err != nil {
+		return err
+	}
+	defer d.afterMoveFunc()
+
+	defer func() {
+		if d.HasDirPin() {
+			// errors are ignored, because the direction was already written successfully with the same pin
+			_ = d.SetDirection(priorDirection)
+		}
+		d.valueMutex.Lock()
+		d.speedRpm = priorSpeed
+		d.valueMutex.Unlock()
+	}()
+
+	if d.HasDirPin() {
+		if err := d.SetDirection(StepperDriverForward); err != nil {
+			return err
+		}
+	}
+
+	edges, stopEdges := d.detectIndexEdges(indexPin)
+	defer stopEdges()
+
+	start := time.Now()
+	lastState := -1
+	for {
+		if edges != nil {
+			select {
+			case position := <-edges:
+				d.zeroPositionAt(position)
+				return nil
+			default:
+			}
+		} else {
+			state, err := d.digitalRead(indexPin)
+			if err != nil {
+				return err
+			}
+			if lastState == 0 && state == 1 {
+				d.zeroPositionAt(d.CurrentStep())
+				return nil
+			}
+			lastState = state
+		}
+
+		if time.Since(start) > timeout {
+			return fmt.Errorf("index pulse at pin '%s' not detected within %s for '%s'", indexPin, timeout,
+				d.driverCfg.name)
+		}
+
+		if err := d.stepFunc(); err != nil {
+			return err
+		}
+	}
+}
+
+// detectIndexEdges tries to register an edge handler for the index pin by the digital pin of the adaptor. The
+// position at each rising edge is sent to the returned channel. The channel is nil, if the edge detection is not
+// available, so polling is needed. The returned function deactivates the sending of further edges.
+func (d *EasyDriver) detectIndexEdges(indexPin string) (<-chan int, func()) {
+	provider, ok := d.connection.(gobot.DigitalPinnerProvider)
+	if !ok {
+		return nil, func() {}
+	}
+
+	pin, err := provider.DigitalPin(indexPin)
+	if err != nil {
+		return nil, func() {}
+	}
+
+	edges := make(chan int, 1)
+	done := make(chan struct{})
+	handler := func(_ int, _ time.Duration, _ string, _ uint32, _ uint32) {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		d.valueMutex.Lock()
+		position := d.stepNum
+		d.valueMutex.Unlock()
+
+		select {
+		case edges <- position:
+		default:
+			// the first edge is already pending
+		}
+	}
+
+	if err := pin.ApplyOptions(system.WithPinEventOnRisingEdge(handler)); err != nil {
+		return nil, func() {}
+	}
+
+	return edges, func() { close(done) }
+}
+
+// zeroPositionAt zeroes the position at the given step, the steps done after this step are kept
+func (d *EasyDriver) zeroPositionAt(position int) {
+	d.valueMutex.Lock()
+	d.stepNum -= position
+	d.valueMutex.Unlock()
+
+	d.resyncEncoder()
+}
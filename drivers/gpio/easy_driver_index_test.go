@@ -0,0 +1,169 @@
+package gpio
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// edgeHandlerOptioner captures the edge handler of the applied pin options, all other options are not supported
+type edgeHandlerOptioner struct {
+	gobot.DigitalPinOptioner
+	handler func(lineOffset int, timestamp time.Duration, detectedEdge string, seqno uint32, lseqno uint32)
+}
+
+func (o *edgeHandlerOptioner) SetEventHandlerForEdge(
+	handler func(int, time.Duration, string, uint32, uint32),
+	_ int,
+) bool {
+	o.handler = handler
+	return true
+}
+
+func TestEasyHomeToIndex_polling(t *testing.T) {
+	const indexPin = "5"
+
+	tests := map[string]struct {
+		startStep  int
+		readValues []int // the last value is repeated
+		timeout    time.Duration
+		wantSteps  int
+		wantErr    string
+	}{
+		"pulse_after_5_reads": {
+			startStep:  123,
+			readValues: []int{0, 0, 0, 0, 1},
+			timeout:    time.Second,
+			wantSteps:  4,
+		},
+		"index_high_at_start": {
+			startStep:  -7,
+			readValues: []int{1, 1, 0, 0, 0, 1},
+			timeout:    time.Second,
+			wantSteps:  5,
+		},
+		"error_timeout": {
+			startStep:  10,
+			readValues: []int{0},
+			timeout:    20 * time.Millisecond,
+			wantErr:    "index pulse at pin '5' not detected within 20ms",
+		},
+		"error_read": {
+			startStep:  10,
+			readValues: []int{-1},
+			timeout:    time.Second,
+			wantErr:    "read error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 0.5, "1", WithEasyDirectionPin("2"))
+			d.stepNum = tc.startStep
+			var reads int
+			a.digitalReadFunc = func(pin string) (int, error) {
+				if pin != indexPin {
+					return 0, nil
+				}
+				val := tc.readValues[len(tc.readValues)-1]
+				if reads < len(tc.readValues) {
+					val = tc.readValues[reads]
+				}
+				reads++
+				if val < 0 {
+					return 0, fmt.Errorf("read error")
+				}
+				return val, nil
+			}
+			var steps int
+			origStepFunc := d.stepFunc
+			d.stepFunc = func() error {
+				steps++
+				return origStepFunc()
+			}
+			require.NoError(t, d.SetSpeed(30))
+			require.NoError(t, d.SetDirection(StepperDriverBackward))
+			// act
+			err := d.HomeToIndex(indexPin, 50, tc.timeout)
+			// assert
+			assert.Equal(t, uint(30), d.speedRpm)
+			assert.Equal(t, StepperDriverBackward, d.direction)
+			assert.False(t, d.IsMoving())
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 0, d.CurrentStep())
+			assert.Equal(t, tc.wantSteps, steps)
+		})
+	}
+}
+
+func TestEasyHomeToIndex_edgeDetection(t *testing.T) {
+	// arrange
+	const (
+		indexPin = "5"
+		edgeStep = 7
+	)
+	a := newGpioTestAdaptor()
+	var mutex sync.Mutex
+	optioner := &edgeHandlerOptioner{}
+	pin := a.addDigitalPin(indexPin)
+	pin.applyOptionsFunc = func(options ...func(gobot.DigitalPinOptioner) bool) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		for _, o := range options {
+			o(optioner)
+		}
+		return nil
+	}
+	a.digitalReadFunc = func(string) (int, error) {
+		return 0, fmt.Errorf("the index pin must not be polled")
+	}
+	d := NewEasyDriver(a, 0.5, "1")
+	d.stepNum = 100
+	var steps int
+	origStepFunc := d.stepFunc
+	d.stepFunc = func() error {
+		err := origStepFunc()
+		steps++
+		if steps == edgeStep {
+			mutex.Lock()
+			handler := optioner.handler
+			mutex.Unlock()
+			handler(0, 0, "rising", 0, 0)
+		}
+		return err
+	}
+	// act
+	err := d.HomeToIndex(indexPin, 50, time.Second)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, edgeStep, steps)
+	assert.Equal(t, 0, d.CurrentStep())
+	// assert: further edges are ignored
+	optioner.handler(0, 0, "rising", 0, 0)
+	require.NoError(t, d.Move(2))
+	assert.Equal(t, 2, d.CurrentStep())
+}
+
+func TestEasyHomeToIndex_invalidParameters(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	// act & assert
+	require.EqualError(t, d.HomeToIndex("", 10, time.Second), fmt.Sprintf("the index pin is mandatory to home '%s'",
+		d.Name()))
+	require.ErrorContains(t, d.HomeToIndex("5", 10, 0), "the timeout (0s) to home")
+	require.EqualError(t, d.HomeToIndex("5", 0, time.Second), "RPM (0) cannot be a zero or negative value")
+	maxSpeed, err := d.MaxSpeed()
+	require.NoError(t, err)
+	assert.Equal(t, maxSpeed/4, d.speedRpm)
+}
@@ -0,0 +1,134 @@
+package gpio
+
+import (
+	"fmt"
+)
+
+// easyFindLimitsMaxRevolutions limits the search of a switch, e.g. if the switch is broken or not wired
+const easyFindLimitsMaxRevolutions = 100
+
+// FindLimits discovers the travel range of an axis with a limit switch at both ends. The motor is moved backward with
+// the given speed until the switch at the minimum pin is triggered, this position is used as step zero. Afterwards the
+// motor is moved forward until the switch at the maximum pin is triggered and the step count is recorded as the travel
+// range, see TravelRange(). The switches needs to be high when triggered. The movement is stopped with an error, if
+// the switch at the opposite end is triggered (e.g. caused by a wrong wiring of the direction) or if no switch is
+// triggered within 100 revolutions. The direction pin is mandatory. The speed and direction, which were set before,
+// are restored afterwards.
+func (d *EasyDriver) FindLimits(minPin, maxPin string, speedRpm uint) error {
+	if minPin == "" || maxPin == "" {
+		return fmt.Errorf("pins for both limit switches are mandatory to find the limits of '%s'", d.driverCfg.name)
+	}
+	if !d.HasDirPin() {
+		return fmt.Errorf("%w for '%s', but is needed to find the limits", ErrDirPinNotSet, d.driverCfg.name)
+	}
+	if d.IsMoving() {
+		return fmt.Errorf("'%s' is moving, finding the limits not possible", d.driverCfg.name)
+	}
+
+	d.valueMutex.Lock()
+	priorSpeed := d.speedRpm
+	priorDirection := d.direction
+	d.valueMutex.Unlock()
+
+	if err := d.SetSpeed(speedRpm); err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.beforeMoveFunc(); err != nil {
+		return err
+	}
+	defer d.afterMoveFunc()
+
+	defer func() {
+		// errors are ignored, because the direction was already written successfully with the same pin
+		_ = d.SetDirection(priorDirection)
+		d.valueMutex.Lock()
+		d.speedRpm = priorSpeed
+		d.valueMutex.Unlock()
+	}()
+
+	d.travelMutex.Lock()
+	d.travelRange = nil
+	d.travelMutex.Unlock()
+
+	if err := d.stepUntilSwitch(StepperDriverBackward, minPin, maxPin); err != nil {
+		return err
+	}
+
+	d.valueMutex.Lock()
+	d.stepNum = 0
+	d.valueMutex.Unlock()
+	d.resyncEncoder()
+
+	if err := d.stepUntilSwitch(StepperDriverForward, maxPin, minPin); err != nil {
+		return err
+	}
+
+	travel := d.CurrentStep()
+	d.travelMutex.Lock()
+	defer d.travelMutex.Unlock()
+
+	if travel < 0 {
+		d.travelRange = &[2]int{travel, 0}
+	} else {
+		d.travelRange = &[2]int{0, travel}
+	}
+
+	return nil
+}
+
+// TravelRange returns the minimum and maximum step of the axis, which was discovered by FindLimits(). The flag is
+// false, if the limits are not discovered yet.
+func (d *EasyDriver) TravelRange() (int, int, bool) {
+	d.travelMutex.Lock()
+	defer d.travelMutex.Unlock()
+
+	if d.travelRange == nil {
+		return 0, 0, false
+	}
+
+	return d.travelRange[0], d.travelRange[1], true
+}
+
+// stepUntilSwitch steps in the given direction until the target switch is triggered. The opposite switch is only
+// checked after it was released, because it is normally triggered at the start of the way back.
+func (d *EasyDriver) stepUntilSwitch(direction, targetPin, oppositePin string) error {
+	if err := d.SetDirection(direction); err != nil {
+		return err
+	}
+
+	maxSteps := int(easyFindLimitsMaxRevolutions * d.stepsPerRev)
+	oppositeReleased := false
+	for steps := 0; ; steps++ {
+		triggered, err := d.digitalRead(targetPin)
+		if err != nil {
+			return err
+		}
+		if triggered == 1 {
+			return nil
+		}
+
+		oppositeTriggered, err := d.digitalRead(oppositePin)
+		if err != nil {
+			return err
+		}
+		if oppositeTriggered == 0 {
+			oppositeReleased = true
+		} else if oppositeReleased {
+			return fmt.Errorf("switch at pin '%s' triggered while moving %s to switch at pin '%s' of '%s', "+
+				"check the wiring", oppositePin, direction, targetPin, d.driverCfg.name)
+		}
+
+		if steps >= maxSteps {
+			return fmt.Errorf("switch at pin '%s' not triggered within %d steps of '%s'", targetPin, maxSteps,
+				d.driverCfg.name)
+		}
+
+		if err := d.stepFunc(); err != nil {
+			return err
+		}
+	}
+}
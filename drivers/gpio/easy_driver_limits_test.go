@@ -0,0 +1,159 @@
+package gpio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// easyTestAxis simulates a linear axis with a limit switch at both ends, the position is counted by the writes to
+// the step pin "1" and the direction pin "2"
+type easyTestAxis struct {
+	position    int
+	backward    bool
+	minPosition int
+	maxPosition int
+}
+
+func (x *easyTestAxis) digitalWrite(pin string, val byte) error {
+	switch pin {
+	case "1":
+		if val == 1 {
+			if x.backward {
+				x.position--
+			} else {
+				x.position++
+			}
+		}
+	case "2":
+		x.backward = val == 1
+	}
+	return nil
+}
+
+func (x *easyTestAxis) digitalRead(pin string) (int, error) {
+	switch {
+	case pin == "5" && x.position <= x.minPosition:
+		return 1, nil
+	case pin == "6" && x.position >= x.maxPosition:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func TestEasyFindLimits(t *testing.T) {
+	tests := map[string]struct {
+		startPosition int
+		minPosition   int
+		maxPosition   int
+		positionSign  int
+		swapDirection bool
+		wantMin       int
+		wantMax       int
+		wantStep      int
+		wantPosition  int
+		wantErr       string
+	}{
+		"from_middle": {
+			startPosition: 0,
+			minPosition:   -30,
+			maxPosition:   170,
+			positionSign:  1,
+			wantMax:       200,
+			wantStep:      200,
+			wantPosition:  170,
+		},
+		"from_min": {
+			startPosition: -30,
+			minPosition:   -30,
+			maxPosition:   90,
+			positionSign:  1,
+			wantMax:       120,
+			wantStep:      120,
+			wantPosition:  90,
+		},
+		"from_max": {
+			startPosition: 90,
+			minPosition:   -30,
+			maxPosition:   90,
+			positionSign:  1,
+			wantMax:       120,
+			wantStep:      120,
+			wantPosition:  90,
+		},
+		"inverted_position_sign": {
+			startPosition: 0,
+			minPosition:   -30,
+			maxPosition:   170,
+			positionSign:  -1,
+			wantMin:       -200,
+			wantStep:      -200,
+			wantPosition:  170,
+		},
+		"error_wrong_direction": {
+			startPosition: 0,
+			minPosition:   -30,
+			maxPosition:   20,
+			positionSign:  1,
+			swapDirection: true,
+			wantPosition:  20,
+			wantStep:      -20,
+			wantErr:       "switch at pin '6' triggered while moving backward to switch at pin '5'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			axis := &easyTestAxis{position: tc.startPosition, minPosition: tc.minPosition, maxPosition: tc.maxPosition}
+			a.digitalWriteFunc = func(pin string, val byte) error {
+				if tc.swapDirection && pin == "2" {
+					val ^= 1
+				}
+				return axis.digitalWrite(pin, val)
+			}
+			a.digitalReadFunc = axis.digitalRead
+			d := NewEasyDriver(a, 0.5, "1", WithEasyDirectionPin("2"))
+			require.NoError(t, d.SetPositionSign(tc.positionSign))
+			require.NoError(t, d.SetSpeed(10))
+			maxSpeed, err := d.MaxSpeed()
+			require.NoError(t, err)
+			// act
+			err = d.FindLimits("5", "6", maxSpeed)
+			// assert
+			gotMin, gotMax, ok := d.TravelRange()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.False(t, ok)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, ok)
+				assert.Equal(t, tc.wantMin, gotMin)
+				assert.Equal(t, tc.wantMax, gotMax)
+			}
+			assert.Equal(t, tc.wantStep, d.CurrentStep())
+			assert.Equal(t, tc.wantPosition, axis.position)
+			assert.Equal(t, uint(10), d.speedRpm)
+			assert.Equal(t, StepperDriverForward, d.direction)
+		})
+	}
+}
+
+func TestEasyFindLimits_error(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	a.digitalReadFunc = func(string) (int, error) { return 0, nil }
+	d := NewEasyDriver(a, 90, "1", WithEasyDirectionPin("2"))
+	dNoDir := NewEasyDriver(a, 90, "1")
+	var steps int
+	d.stepFunc = func() error { steps++; return nil }
+	// act & assert
+	require.ErrorContains(t, d.FindLimits("", "6", 10), "pins for both limit switches are mandatory")
+	require.ErrorContains(t, dNoDir.FindLimits("5", "6", 10), "dirPin is not set")
+	require.ErrorContains(t, d.FindLimits("5", "6", 0), "RPM (0) cannot be a zero or negative value")
+	require.ErrorContains(t, d.FindLimits("5", "6", 10), "switch at pin '5' not triggered within 400 steps")
+	assert.Equal(t, 400, steps)
+	_, _, ok := d.TravelRange()
+	assert.False(t, ok)
+}
@@ -0,0 +1,198 @@
+package gpio
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Waypoint is a target of a path, see RunPath().
+type Waypoint struct {
+	TargetDeg float64       // absolute angle in degrees, related to the position at start (step zero)
+	Speed     uint          // in RPM, zero keeps the current speed
+	Dwell     time.Duration // wait time after the target is reached
+}
+
+// easyPath contains the state of a running path
+type easyPath struct {
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// MoveToDeg moves the motor to the given absolute angle in degrees, related to the position at start (step zero).
+// In contrast to SetValue(), the angle is not limited to one revolution.
+func (d *EasyDriver) MoveToDeg(deg float64) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.moveToDeg(deg)
+}
+
+// RunPath visits the targets of the given waypoints sequentially in the background. Each target is approached with
+// the speed of the waypoint by MoveToDeg() and the next waypoint is started after the dwell time. The speed of the
+// waypoints is only used for the path, so the speed set by SetSpeed() is not changed. A running path can be stopped by
+// StopPath().
+//
+// Emits the Events:
+//
+//	EasyWaypointReached int - On a target is reached, before the dwell time, with the index of the waypoint
+//	EasyPathDone - On all waypoints are visited, including the last dwell time
+//	Error error - On a failed movement, the path is stopped
+func (d *EasyDriver) RunPath(waypoints []Waypoint) error {
+	if len(waypoints) == 0 {
+		return fmt.Errorf("path of '%s' has no waypoints", d.driverCfg.name)
+	}
+
+	maxSpeed, err := d.MaxSpeed()
+	if err != nil {
+		return err
+	}
+	for i, wp := range waypoints {
+		if wp.Speed > maxSpeed {
+			return fmt.Errorf("speed (%d) of waypoint %d cannot be greater then maximal value %d", wp.Speed, i, maxSpeed)
+		}
+		if wp.Dwell < 0 {
+			return fmt.Errorf("dwell (%s) of waypoint %d cannot be a negative value", wp.Dwell, i)
+		}
+	}
+
+	d.pathMutex.Lock()
+	defer d.pathMutex.Unlock()
+
+	if d.path != nil {
+		return fmt.Errorf("path of '%s' is already running", d.driverCfg.name)
+	}
+
+	path := &easyPath{stop: make(chan struct{}), done: make(chan struct{})}
+	d.path = path
+	go d.runPath(path, append([]Waypoint{}, waypoints...))
+
+	return nil
+}
+
+// StopPath stops a running path. The current movement is stopped immediately and no further waypoint is visited.
+// The function returns after the path is finished.
+func (d *EasyDriver) StopPath() error {
+	d.pathMutex.Lock()
+	path := d.path
+	d.pathMutex.Unlock()
+
+	if path == nil {
+		return fmt.Errorf("path of '%s' is not running", d.driverCfg.name)
+	}
+
+	path.stopOnce.Do(func() { close(path.stop) })
+	if d.IsMoving() {
+		// an error is possible, if the movement was just finished
+		_ = d.Stop()
+	}
+	<-path.done
+
+	return nil
+}
+
+// IsPathRunning returns true, if a path was started by RunPath() and is not finished yet.
+func (d *EasyDriver) IsPathRunning() bool {
+	d.pathMutex.Lock()
+	defer d.pathMutex.Unlock()
+
+	return d.path != nil
+}
+
+// runPath visits the waypoints until the end, an error occurs or the path is stopped
+func (d *EasyDriver) runPath(path *easyPath, waypoints []Waypoint) {
+	defer func() {
+		d.pathMutex.Lock()
+		d.path = nil
+		d.pathMutex.Unlock()
+		close(path.done)
+	}()
+
+	var speedRpm uint // zero for the speed of SetSpeed(), until a waypoint sets another one
+	for i, wp := range waypoints {
+		if wp.Speed > 0 {
+			speedRpm = wp.Speed
+		}
+
+		stopped, err := d.moveToWaypoint(path, wp.TargetDeg, speedRpm)
+		if err != nil {
+			d.Publish(d.Event(Error), err)
+			return
+		}
+		if stopped {
+			return
+		}
+
+		d.Publish(d.Event(EasyWaypointReached), i)
+
+		select {
+		case <-path.stop:
+			return
+		case <-time.After(wp.Dwell):
+		}
+	}
+
+	d.Publish(d.Event(EasyPathDone), nil)
+}
+
+// moveToWaypoint moves to the given angle with the given speed, if the path is not stopped. The check is done after
+// the driver mutex is locked, so a stop during a halt of the driver can not be missed. The speed is only used for this
+// movement, zero means the speed of SetSpeed().
+func (d *EasyDriver) moveToWaypoint(path *easyPath, deg float64, speedRpm uint) (bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.setMoveSpeed(speedRpm)
+	defer d.setMoveSpeed(0)
+
+	select {
+	case <-path.stop:
+		return true, nil
+	default:
+	}
+
+	if err := d.moveToDeg(deg); err != nil {
+		return false, err
+	}
+
+	// a stop while moving leads to no error, but the target is not reached
+	select {
+	case <-path.stop:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// moveToDeg moves the motor to the given absolute angle in degrees. The driver mutex needs to be locked by the caller.
+func (d *EasyDriver) moveToDeg(deg float64) error {
+	targetStep := int(math.Round(deg / float64(d.anglePerStep)))
+	stepsToMove := d.stepsToPosition(targetStep)
+	if stepsToMove == 0 {
+		return nil
+	}
+
+	if err := d.beforeMoveFunc(); err != nil {
+		return err
+	}
+	defer d.afterMoveFunc()
+
+	if err := d.stepAsynch(float64(stepsToMove)); err != nil {
+		// something went wrong with preparation
+		return err
+	}
+
+	return d.waitAsynchMove()
+}
+
+// signalStopPath signals a running path to stop, without waiting for the end of the path
+func (d *EasyDriver) signalStopPath() {
+	d.pathMutex.Lock()
+	defer d.pathMutex.Unlock()
+
+	if d.path != nil {
+		d.path.stopOnce.Do(func() { close(d.path.stop) })
+	}
+}
@@ -0,0 +1,275 @@
+package gpio
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEasyMoveToDeg(t *testing.T) {
+	tests := map[string]struct {
+		startStep int
+		inputDeg  float64
+		wantSteps int
+		wantMoves int
+	}{
+		"forward": {
+			inputDeg:  10,
+			wantSteps: 20,
+			wantMoves: 20,
+		},
+		"backward": {
+			startStep: 30,
+			inputDeg:  10,
+			wantSteps: 20,
+			wantMoves: 10,
+		},
+		"negative_angle": {
+			inputDeg:  -5,
+			wantSteps: -10,
+			wantMoves: 10,
+		},
+		"more_than_one_revolution": {
+			startStep: 720,
+			inputDeg:  365,
+			wantSteps: 730,
+			wantMoves: 10,
+		},
+		"no_movement": {
+			startStep: 20,
+			inputDeg:  10.1,
+			wantSteps: 20,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestEasyDriverWithStubbedAdaptor()
+			d.stepNum = tc.startStep
+			a.written = nil
+			// act
+			err := d.MoveToDeg(tc.inputDeg)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantSteps, d.CurrentStep())
+			assert.Len(t, a.written, 2*tc.wantMoves)
+			assert.False(t, d.IsMoving())
+		})
+	}
+}
+
+func TestEasyRunPath(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 0.5, "1", WithEasyDirectionPin("2"))
+	require.NoError(t, d.Start())
+	require.NoError(t, d.SetSpeed(20))
+	var mutex sync.Mutex
+	var speeds []uint
+	origStepFunc := d.stepFunc
+	d.stepFunc = func() error {
+		// called with locked value mutex
+		mutex.Lock()
+		speeds = append(speeds, d.moveSpeedRpm)
+		mutex.Unlock()
+		return origStepFunc()
+	}
+	waypoints := []Waypoint{
+		{TargetDeg: 10, Speed: 40, Dwell: 50 * time.Millisecond},
+		{TargetDeg: 5, Dwell: 80 * time.Millisecond},
+		{TargetDeg: 15, Speed: 30},
+	}
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	type received struct {
+		name string
+		data interface{}
+		step int
+		at   time.Time
+	}
+	var got []received
+	// act
+	err := d.RunPath(waypoints)
+	// assert
+	require.NoError(t, err)
+	assert.True(t, d.IsPathRunning())
+	for len(got) == 0 || got[len(got)-1].name != EasyPathDone {
+		select {
+		case evt := <-events:
+			got = append(got, received{name: evt.Name, data: evt.Data, step: d.CurrentStep(), at: time.Now()})
+			if len(got) == 1 {
+				// the speed of the path is not changed by the call, but the speed after the path
+				require.NoError(t, d.SetSpeed(25))
+			}
+		case <-time.After(3 * time.Second):
+			require.Fail(t, "path was not done")
+		}
+	}
+	require.Len(t, got, 4)
+	for i, want := range []received{
+		{name: EasyWaypointReached, data: 0, step: 20},
+		{name: EasyWaypointReached, data: 1, step: 10},
+		{name: EasyWaypointReached, data: 2, step: 30},
+		{name: EasyPathDone, step: 30},
+	} {
+		assert.Equal(t, want.name, got[i].name, "event %d", i)
+		assert.Equal(t, want.data, got[i].data, "event %d", i)
+		assert.Equal(t, want.step, got[i].step, "event %d", i)
+	}
+	// the dwell is part of the time between the events
+	assert.GreaterOrEqual(t, got[1].at.Sub(got[0].at), waypoints[0].Dwell)
+	assert.GreaterOrEqual(t, got[2].at.Sub(got[1].at), waypoints[1].Dwell)
+	mutex.Lock()
+	require.Len(t, speeds, 50)
+	for i, speed := range speeds {
+		wantSpeed := uint(40)
+		if i >= 30 {
+			wantSpeed = 30
+		}
+		assert.Equal(t, wantSpeed, speed, "step %d", i)
+	}
+	mutex.Unlock()
+	assert.Eventually(t, func() bool { return !d.IsPathRunning() }, time.Second, time.Millisecond)
+	assert.Equal(t, uint(25), d.State().SpeedRpm)
+	assert.Equal(t, uint(0), d.moveSpeedRpm)
+}
+
+func TestEasyStopPath(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	waypoints := []Waypoint{
+		{TargetDeg: 1, Dwell: 5 * time.Second},
+		{TargetDeg: 2},
+	}
+	reachedChan := make(chan int, 2)
+	_ = d.On(EasyWaypointReached, func(data interface{}) { reachedChan <- data.(int) })
+	var doneCount int
+	var mutex sync.Mutex
+	_ = d.On(EasyPathDone, func(interface{}) {
+		mutex.Lock()
+		doneCount++
+		mutex.Unlock()
+	})
+	require.NoError(t, d.RunPath(waypoints))
+	select {
+	case idx := <-reachedChan:
+		require.Equal(t, 0, idx)
+	case <-time.After(time.Second):
+		require.Fail(t, "first waypoint was not reached")
+	}
+	// act
+	start := time.Now()
+	err := d.StopPath()
+	// assert
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.False(t, d.IsPathRunning())
+	assert.Equal(t, 2, d.CurrentStep())
+	time.Sleep(10 * time.Millisecond)
+	mutex.Lock()
+	assert.Equal(t, 0, doneCount)
+	mutex.Unlock()
+	assert.Empty(t, reachedChan)
+}
+
+func TestEasyStopPath_whileMoving(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.SetSpeed(1))
+	require.NoError(t, d.RunPath([]Waypoint{{TargetDeg: 180}}))
+	assert.Eventually(t, d.IsMoving, time.Second, time.Millisecond)
+	// act
+	err := d.StopPath()
+	// assert
+	require.NoError(t, err)
+	assert.False(t, d.IsPathRunning())
+	assert.False(t, d.IsMoving())
+	assert.Less(t, d.CurrentStep(), 360)
+}
+
+func TestEasyRunPath_error(t *testing.T) {
+	tests := map[string]struct {
+		waypoints      []Waypoint
+		simulateActive bool
+		wantErr        string
+	}{
+		"error_empty": {
+			wantErr: "path of 'EasyDriver' has no waypoints",
+		},
+		"error_speed": {
+			waypoints: []Waypoint{{TargetDeg: 10}, {TargetDeg: 20, Speed: 1000}},
+			wantErr:   "speed (1000) of waypoint 1 cannot be greater then maximal value 58",
+		},
+		"error_dwell": {
+			waypoints: []Waypoint{{TargetDeg: 10, Dwell: -time.Second}},
+			wantErr:   "dwell (-1s) of waypoint 0 cannot be a negative value",
+		},
+		"error_already_running": {
+			waypoints:      []Waypoint{{TargetDeg: 10}},
+			simulateActive: true,
+			wantErr:        "path of 'EasyDriver' is already running",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewEasyDriver(newGpioTestAdaptor(), 0.5, "1", WithName("EasyDriver"))
+			if tc.simulateActive {
+				d.path = &easyPath{}
+			}
+			// act
+			err := d.RunPath(tc.waypoints)
+			// assert
+			require.EqualError(t, err, tc.wantErr)
+			assert.Equal(t, 0, d.CurrentStep())
+		})
+	}
+}
+
+func TestEasyRunPath_moveError(t *testing.T) {
+	// arrange
+	d, a := initTestEasyDriverWithStubbedAdaptor()
+	a.simulateWriteError = true
+	errChan := make(chan interface{}, 1)
+	_ = d.Once(Error, func(data interface{}) { errChan <- data })
+	// act
+	require.NoError(t, d.RunPath([]Waypoint{{TargetDeg: 10}, {TargetDeg: 20}}))
+	// assert
+	select {
+	case data := <-errChan:
+		require.EqualError(t, data.(error), "write error")
+	case <-time.After(time.Second):
+		require.Fail(t, "error event was not published")
+	}
+	assert.Eventually(t, func() bool { return !d.IsPathRunning() }, time.Second, time.Millisecond)
+}
+
+func TestEasyStopPath_error(t *testing.T) {
+	// arrange
+	d := NewEasyDriver(newGpioTestAdaptor(), 0.5, "1", WithName("EasyDriver"))
+	// act & assert
+	require.EqualError(t, d.StopPath(), "path of 'EasyDriver' is not running")
+}
+
+func TestEasyHalt_stopsPath(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	reachedChan := make(chan int, 2)
+	_ = d.On(EasyWaypointReached, func(data interface{}) { reachedChan <- data.(int) })
+	require.NoError(t, d.RunPath([]Waypoint{{TargetDeg: 1, Dwell: 5 * time.Second}, {TargetDeg: 0}}))
+	select {
+	case <-reachedChan:
+	case <-time.After(time.Second):
+		require.Fail(t, "first waypoint was not reached")
+	}
+	// act
+	err := d.Halt()
+	// assert
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return !d.IsPathRunning() }, time.Second, time.Millisecond)
+	assert.Equal(t, 2, d.CurrentStep())
+	assert.Empty(t, reachedChan)
+}
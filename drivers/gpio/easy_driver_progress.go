@@ -0,0 +1,109 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+)
+
+// EasyMoveProgressData is the data of the EasyMoveProgress event.
+type EasyMoveProgressData struct {
+	DoneSteps  int           `json:"doneSteps"`
+	TotalSteps int           `json:"totalSteps"`
+	Remaining  time.Duration `json:"remaining"`
+}
+
+// SetProgressInterval activates the publishing of the EasyMoveProgress event with the given interval during each
+// finite movement, e.g. for the feedback in an user interface. An additional event is published after the movement
+// has finished. Zero (default) deactivates the events.
+func (d *EasyDriver) SetProgressInterval(interval time.Duration) error {
+	if interval < 0 {
+		return fmt.Errorf("progress interval (%s) cannot be a negative value", interval)
+	}
+
+	d.progressMutex.Lock()
+	defer d.progressMutex.Unlock()
+
+	d.progressInterval = interval
+
+	return nil
+}
+
+// startProgressReport starts the cyclic publishing of the progress, if activated. A still running report of an
+// endless movement is stopped before.
+func (d *EasyDriver) startProgressReport() {
+	d.takeProgressReport()
+
+	d.progressMutex.Lock()
+	defer d.progressMutex.Unlock()
+
+	if d.progressInterval <= 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	d.progressStop = stop
+	d.progressDone = done
+
+	go func(interval time.Duration) {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				done, total := d.Progress()
+				if !d.IsMoving() {
+					continue
+				}
+				if total == 0 {
+					// endless movement, e.g. by Run()
+					return
+				}
+				d.publishProgress(done, total)
+			}
+		}
+	}(d.progressInterval)
+}
+
+// stopProgressReport stops the cyclic publishing of the progress and publishes the final progress
+func (d *EasyDriver) stopProgressReport() {
+	if !d.takeProgressReport() {
+		return
+	}
+
+	if done, total := d.Progress(); total > 0 {
+		d.publishProgress(done, total)
+	}
+}
+
+// takeProgressReport stops the cyclic publishing of the progress and returns true, if the report was running
+func (d *EasyDriver) takeProgressReport() bool {
+	d.progressMutex.Lock()
+	stop := d.progressStop
+	done := d.progressDone
+	d.progressStop = nil
+	d.progressDone = nil
+	d.progressMutex.Unlock()
+
+	if stop == nil {
+		return false
+	}
+
+	close(stop)
+	<-done
+
+	return true
+}
+
+func (d *EasyDriver) publishProgress(done, total int) {
+	d.Publish(d.Event(EasyMoveProgress), EasyMoveProgressData{
+		DoneSteps:  done,
+		TotalSteps: total,
+		Remaining:  d.EstimatedTimeRemaining(),
+	})
+}
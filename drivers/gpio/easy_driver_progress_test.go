@@ -0,0 +1,84 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEasySetProgressInterval(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	// act & assert
+	require.NoError(t, d.SetProgressInterval(10*time.Millisecond))
+	assert.Equal(t, 10*time.Millisecond, d.progressInterval)
+	require.ErrorContains(t, d.SetProgressInterval(-time.Millisecond), "progress interval (-1ms) cannot be a negative")
+	assert.Equal(t, 10*time.Millisecond, d.progressInterval)
+}
+
+func TestEasyMoveProgressEvent(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.Start())
+	require.NoError(t, d.SetSpeed(20)) // ~4 ms per step
+	require.NoError(t, d.SetProgressInterval(20*time.Millisecond))
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	// act
+	require.NoError(t, d.Move(40))
+	// assert
+	var got []EasyMoveProgressData
+	timeout := time.After(time.Second)
+	for len(got) == 0 || got[len(got)-1].DoneSteps < 40 {
+		select {
+		case evt := <-events:
+			if evt.Name == EasyMoveProgress {
+				got = append(got, evt.Data.(EasyMoveProgressData))
+			}
+		case <-timeout:
+			require.Fail(t, "missing final progress event", "got: %v", got)
+		}
+	}
+	require.Greater(t, len(got), 2)
+	for i, p := range got {
+		assert.Equal(t, 40, p.TotalSteps)
+		if i > 0 {
+			assert.GreaterOrEqual(t, p.DoneSteps, got[i-1].DoneSteps)
+			assert.LessOrEqual(t, p.Remaining, got[i-1].Remaining)
+		}
+	}
+	assert.Equal(t, time.Duration(0), got[len(got)-1].Remaining)
+}
+
+func TestEasyMoveProgressEvent_deactivated(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.Start())
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	// act
+	require.NoError(t, d.Move(10))
+	// assert
+	select {
+	case evt := <-events:
+		assert.NotEqual(t, EasyMoveProgress, evt.Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Nil(t, d.progressStop)
+}
+
+func TestEasyMoveProgressEvent_run(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.Start())
+	require.NoError(t, d.SetProgressInterval(5*time.Millisecond))
+	// act
+	require.NoError(t, d.Run())
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, d.Stop())
+	// assert: the report ends by itself for an endless movement and is removed by halt
+	require.NoError(t, d.Halt())
+	assert.Nil(t, d.progressStop)
+}
@@ -0,0 +1,105 @@
+package gpio
+
+import (
+	"fmt"
+)
+
+// easyQueuedMove is a movement of the motion queue with the speed and direction at the time of enqueue
+type easyQueuedMove struct {
+	degs      int
+	speedRpm  uint
+	direction string
+}
+
+// QueueMove appends a movement of the given degrees to the motion queue. The speed and direction, which are set at
+// the time of the call, are used for this movement. Negative degrees reverse the direction. The queue is executed
+// by StartQueue().
+func (d *EasyDriver) QueueMove(degs int) error {
+	if degs == 0 {
+		return fmt.Errorf("no movement for 0 degrees can be queued for '%s'", d.driverCfg.name)
+	}
+
+	d.valueMutex.Lock()
+	move := easyQueuedMove{degs: degs, speedRpm: d.speedRpm, direction: d.direction}
+	d.valueMutex.Unlock()
+
+	d.queueMutex.Lock()
+	defer d.queueMutex.Unlock()
+
+	d.queue = append(d.queue, move)
+
+	return nil
+}
+
+// QueueLen returns the count of movements, which are waiting in the motion queue.
+func (d *EasyDriver) QueueLen() int {
+	d.queueMutex.Lock()
+	defer d.queueMutex.Unlock()
+
+	return len(d.queue)
+}
+
+// StartQueue executes the movements of the motion queue sequentially in the background.
+//
+// Emits the Events:
+//
+//	EasyQueueDrained - On all movements of the queue are done
+//	Error error - On a failed movement, the queue is stopped and the remaining movements are kept
+func (d *EasyDriver) StartQueue() error {
+	d.queueMutex.Lock()
+	defer d.queueMutex.Unlock()
+
+	if d.queueRunning {
+		return fmt.Errorf("motion queue of '%s' is already running", d.driverCfg.name)
+	}
+	if len(d.queue) == 0 {
+		return fmt.Errorf("motion queue of '%s' is empty", d.driverCfg.name)
+	}
+
+	d.queueRunning = true
+	go d.runQueue()
+
+	return nil
+}
+
+// ClearQueue removes all waiting movements from the motion queue. A running movement is finished.
+func (d *EasyDriver) ClearQueue() {
+	d.queueMutex.Lock()
+	defer d.queueMutex.Unlock()
+
+	d.queue = nil
+}
+
+// runQueue executes the movements until the queue is empty or an error occurs
+func (d *EasyDriver) runQueue() {
+	for {
+		d.queueMutex.Lock()
+		if len(d.queue) == 0 {
+			d.queueRunning = false
+			d.queueMutex.Unlock()
+			d.Publish(d.Event(EasyQueueDrained), nil)
+			return
+		}
+		move := d.queue[0]
+		d.queue = d.queue[1:]
+		d.queueMutex.Unlock()
+
+		if err := d.executeQueuedMove(move); err != nil {
+			d.queueMutex.Lock()
+			d.queueRunning = false
+			d.queueMutex.Unlock()
+			d.Publish(d.Event(Error), err)
+			return
+		}
+	}
+}
+
+// executeQueuedMove moves the motor with the speed of the queued movement, the speed of SetSpeed() is not changed
+func (d *EasyDriver) executeQueuedMove(move easyQueuedMove) error {
+	degs := move.degs
+	if move.direction == StepperDriverBackward {
+		degs = -degs
+	}
+
+	return d.moveDegAtSpeed(degs, move.speedRpm)
+}
@@ -0,0 +1,86 @@
+package gpio
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEasyQueueMove(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 0.5, "1", WithEasyDirectionPin("2"))
+	require.NoError(t, d.Start())
+	var mutex sync.Mutex
+	var steps []string
+	var speeds []uint
+	firstStep := make(chan struct{})
+	origStepFunc := d.stepFunc
+	d.stepFunc = func() error {
+		// called with locked value mutex
+		mutex.Lock()
+		steps = append(steps, d.direction)
+		speeds = append(speeds, d.moveSpeedRpm)
+		if len(steps) == 1 {
+			close(firstStep)
+		}
+		mutex.Unlock()
+		return origStepFunc()
+	}
+	drained := make(chan struct{})
+	_ = d.Once(EasyQueueDrained, func(interface{}) { close(drained) })
+	require.NoError(t, d.SetSpeed(40))
+	require.NoError(t, d.QueueMove(10))
+	require.NoError(t, d.SetDirection(StepperDriverBackward))
+	require.NoError(t, d.SetSpeed(20))
+	require.NoError(t, d.QueueMove(20))
+	require.NoError(t, d.SetDirection(StepperDriverForward))
+	require.NoError(t, d.QueueMove(5))
+	assert.Equal(t, 3, d.QueueLen())
+	// act
+	require.NoError(t, d.StartQueue())
+	<-firstStep
+	require.NoError(t, d.SetSpeed(30)) // used by the next call of QueueMove() only
+	// assert
+	select {
+	case <-drained:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "queue was not drained")
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Len(t, steps, 70)
+	for i, direction := range steps {
+		wantDirection := StepperDriverForward
+		wantSpeed := uint(20)
+		if i < 20 {
+			wantSpeed = 40
+		}
+		if i >= 20 && i < 60 {
+			wantDirection = StepperDriverBackward
+		}
+		assert.Equal(t, wantDirection, direction, "step %d", i)
+		assert.Equal(t, wantSpeed, speeds[i], "step %d", i)
+	}
+	assert.Equal(t, -10, d.stepNum)
+	assert.Equal(t, 0, d.QueueLen())
+	assert.Equal(t, uint(30), d.speedRpm)
+	assert.Equal(t, uint(0), d.moveSpeedRpm)
+	assert.False(t, d.queueRunning)
+}
+
+func TestEasyQueueMove_error(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	// act & assert
+	require.ErrorContains(t, d.QueueMove(0), "no movement for 0 degrees can be queued")
+	require.ErrorContains(t, d.StartQueue(), "is empty")
+	require.NoError(t, d.QueueMove(10))
+	d.queueRunning = true
+	require.ErrorContains(t, d.StartQueue(), "is already running")
+	d.ClearQueue()
+	assert.Equal(t, 0, d.QueueLen())
+}
@@ -0,0 +1,150 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+)
+
+// EasyPositionData is the data of the EasyPosition event.
+type EasyPositionData struct {
+	CurrentStep  int       `json:"currentStep"`
+	CurrentAngle float64   `json:"currentAngle"` // in degrees within [0, 360), see EasyDriver.GetValue()
+	Time         time.Time `json:"time"`
+}
+
+// SetPositionSampleInterval activates the publishing of the EasyPosition event with the given interval during each
+// movement, including endless movements by Run(), e.g. for a live plot while tuning the speed or the step timer. The
+// interval is independent of the step rate, so fast movements do not flood the subscribers. An additional event is
+// published at the start and after the end of the movement. Zero (default) deactivates the events.
+func (d *EasyDriver) SetPositionSampleInterval(interval time.Duration) error {
+	if interval < 0 {
+		return fmt.Errorf("position sample interval (%s) cannot be a negative value", interval)
+	}
+
+	d.samplingMutex.Lock()
+	defer d.samplingMutex.Unlock()
+
+	d.samplingInterval = interval
+
+	return nil
+}
+
+// PositionSampleInterval returns the interval of the EasyPosition event, see SetPositionSampleInterval().
+func (d *EasyDriver) PositionSampleInterval() time.Duration {
+	d.samplingMutex.Lock()
+	defer d.samplingMutex.Unlock()
+
+	return d.samplingInterval
+}
+
+// startPositionSampling starts the cyclic publishing of the position, if activated. A still running sampling of an
+// endless movement is stopped before.
+func (d *EasyDriver) startPositionSampling() {
+	d.takePositionSampling()
+
+	interval := d.PositionSampleInterval()
+	if interval <= 0 {
+		return
+	}
+
+	d.publishPosition()
+
+	// the progress of the movement is created after the start of the sampling, so a former one is not considered
+	d.valueMutex.Lock()
+	formerProgress := d.moveProgress
+	d.valueMutex.Unlock()
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	d.samplingMutex.Lock()
+	d.samplingStop = stop
+	d.samplingDone = done
+	d.samplingMutex.Unlock()
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				d.valueMutex.Lock()
+				progress := d.moveProgress
+				endlessStopped := progress != formerProgress && progress != nil && progress.totalSteps == 0 &&
+					!progress.active
+				d.valueMutex.Unlock()
+
+				if !endlessStopped {
+					d.publishPosition()
+					continue
+				}
+
+				// the endless movement, e.g. by Run(), was stopped, there is no call of afterMove(), maybe even before
+				// the first tick
+				if d.releasePositionSampling(stop) {
+					d.publishPosition()
+				}
+				return
+			}
+		}
+	}()
+}
+
+// stopPositionSampling stops the cyclic publishing of the position and publishes the final position
+func (d *EasyDriver) stopPositionSampling() {
+	if d.takePositionSampling() {
+		d.publishPosition()
+	}
+}
+
+// takePositionSampling stops the cyclic publishing of the position and returns true, if the sampling was running
+func (d *EasyDriver) takePositionSampling() bool {
+	d.samplingMutex.Lock()
+	stop := d.samplingStop
+	done := d.samplingDone
+	d.samplingStop = nil
+	d.samplingDone = nil
+	d.samplingMutex.Unlock()
+
+	if stop == nil {
+		return false
+	}
+
+	close(stop)
+	<-done
+
+	return true
+}
+
+// releasePositionSampling is called by the sampling itself at its end and returns false, if the sampling was already
+// taken, e.g. by afterMove(), so the final position is published only once
+func (d *EasyDriver) releasePositionSampling(stop chan struct{}) bool {
+	d.samplingMutex.Lock()
+	defer d.samplingMutex.Unlock()
+
+	if d.samplingStop != stop {
+		return false
+	}
+
+	d.samplingStop = nil
+	d.samplingDone = nil
+
+	return true
+}
+
+func (d *EasyDriver) publishPosition() {
+	d.valueMutex.Lock()
+	step := d.stepNum
+	angle := d.normalizedAngle(step)
+	d.valueMutex.Unlock()
+
+	d.Publish(d.Event(EasyPosition), EasyPositionData{
+		CurrentStep:  step,
+		CurrentAngle: angle,
+		Time:         time.Now(),
+	})
+}
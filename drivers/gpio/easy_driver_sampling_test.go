@@ -0,0 +1,173 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// collectEasyPositions receives the EasyPosition events, until the given function returns true for the last one
+func collectEasyPositions(t *testing.T, events chan *gobot.Event, last func(EasyPositionData) bool) []EasyPositionData {
+	t.Helper()
+
+	var got []EasyPositionData
+	timeout := time.After(time.Second)
+	for len(got) == 0 || !last(got[len(got)-1]) {
+		select {
+		case evt := <-events:
+			if evt.Name == EasyPosition {
+				got = append(got, evt.Data.(EasyPositionData))
+			}
+		case <-timeout:
+			require.Fail(t, "missing final position event", "got: %v", got)
+		}
+	}
+
+	return got
+}
+
+func TestEasySetPositionSampleInterval(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	// act & assert
+	assert.Equal(t, time.Duration(0), d.PositionSampleInterval())
+	require.NoError(t, d.SetPositionSampleInterval(10*time.Millisecond))
+	assert.Equal(t, 10*time.Millisecond, d.PositionSampleInterval())
+	require.ErrorContains(t, d.SetPositionSampleInterval(-time.Millisecond),
+		"position sample interval (-1ms) cannot be a negative")
+	assert.Equal(t, 10*time.Millisecond, d.PositionSampleInterval())
+}
+
+func TestEasyPositionEvent(t *testing.T) {
+	// arrange
+	const interval = 20 * time.Millisecond
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.Start())
+	require.NoError(t, d.SetSpeed(20)) // ~4 ms per step, so ~5 steps between two samples
+	require.NoError(t, d.SetPositionSampleInterval(interval))
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	// act
+	require.NoError(t, d.Move(40))
+	// assert
+	got := collectEasyPositions(t, events, func(p EasyPositionData) bool { return p.CurrentStep == 40 })
+	// ~160 ms move gives ~8 samples, the start and the final event, but not one event per step
+	require.Greater(t, len(got), 4)
+	assert.Less(t, len(got), 20)
+	assert.Equal(t, 0, got[0].CurrentStep)
+	for i, p := range got {
+		assert.InDelta(t, d.normalizedAngle(p.CurrentStep), p.CurrentAngle, 1e-9)
+		if i > 0 {
+			assert.GreaterOrEqual(t, p.CurrentStep, got[i-1].CurrentStep)
+			assert.GreaterOrEqual(t, p.CurrentAngle, got[i-1].CurrentAngle)
+			assert.True(t, p.Time.After(got[i-1].Time))
+		}
+	}
+	// the mean distance of the cyclic samples is roughly the interval
+	cyclic := got[1 : len(got)-1]
+	mean := cyclic[len(cyclic)-1].Time.Sub(cyclic[0].Time) / time.Duration(len(cyclic)-1)
+	assert.InDelta(t, float64(interval), float64(mean), float64(interval/2))
+}
+
+func TestEasyPositionEvent_deactivated(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.Start())
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	// act
+	require.NoError(t, d.Move(10))
+	// assert
+	select {
+	case evt := <-events:
+		assert.NotEqual(t, EasyPosition, evt.Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Nil(t, d.samplingStop)
+}
+
+func TestEasyPositionEvent_run(t *testing.T) {
+	tests := map[string]struct {
+		interval  time.Duration
+		runTime   time.Duration
+		minEvents int
+	}{
+		"stop_after_some_samples": {
+			interval:  5 * time.Millisecond,
+			runTime:   50 * time.Millisecond,
+			minEvents: 3,
+		},
+		"stop_before_first_sample": {
+			interval:  100 * time.Millisecond,
+			minEvents: 2,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestEasyDriverWithStubbedAdaptor()
+			require.NoError(t, d.Start())
+			require.NoError(t, d.SetSpeed(20))
+			require.NoError(t, d.SetPositionSampleInterval(tc.interval))
+			events := d.Subscribe()
+			defer d.Unsubscribe(events)
+			d.samplingMutex.Lock()
+			require.Nil(t, d.samplingDone)
+			d.samplingMutex.Unlock()
+			// act
+			require.NoError(t, d.Run())
+			d.samplingMutex.Lock()
+			done := d.samplingDone
+			d.samplingMutex.Unlock()
+			time.Sleep(tc.runTime)
+			require.NoError(t, d.Stop())
+			// assert: the sampling ends by itself for an endless movement with the final position
+			require.NotNil(t, done)
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				require.Fail(t, "position sampling was not finished after stop")
+			}
+			stoppedAt := d.CurrentStep()
+			got := collectEasyPositions(t, events, func(p EasyPositionData) bool { return p.CurrentStep == stoppedAt })
+			require.GreaterOrEqual(t, len(got), tc.minEvents)
+			for i := 1; i < len(got); i++ {
+				assert.GreaterOrEqual(t, got[i].CurrentStep, got[i-1].CurrentStep)
+			}
+			// no further position is published after the end of the sampling
+			timeout := time.After(2 * tc.interval)
+			for waiting := true; waiting; {
+				select {
+				case evt := <-events:
+					if evt.Name == EasyPosition {
+						assert.Equal(t, stoppedAt, evt.Data.(EasyPositionData).CurrentStep)
+					}
+				case <-timeout:
+					waiting = false
+				}
+			}
+			d.samplingMutex.Lock()
+			assert.Nil(t, d.samplingStop)
+			d.samplingMutex.Unlock()
+			require.NoError(t, d.Halt())
+		})
+	}
+}
+
+func TestEasyPositionEvent_normalizedAngle(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	d.stepNum = -900 // 0.5 degree per step, so -450 degree
+	// act
+	d.publishPosition()
+	// assert
+	got := collectEasyPositions(t, events, func(EasyPositionData) bool { return true })
+	assert.Equal(t, -900, got[0].CurrentStep)
+	assert.InDelta(t, 270.0, got[0].CurrentAngle, 1e-9)
+}
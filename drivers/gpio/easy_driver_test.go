@@ -1,14 +1,21 @@
 package gpio
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"log"
+	"math"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"gobot.io/x/gobot/v2"
 	"gobot.io/x/gobot/v2/drivers/aio"
 )
 
@@ -50,6 +57,8 @@ func TestNewEasyDriver(t *testing.T) {
 	assert.Empty(t, d.easyCfg.dirPin)
 	assert.Empty(t, d.easyCfg.enPin)
 	assert.Empty(t, d.easyCfg.sleepPin)
+	assert.Equal(t, 2*time.Microsecond, d.stepPulseWidth)
+	assert.Equal(t, 5*time.Microsecond, d.directionSetupTime)
 }
 
 func TestNewEasyDriver_options(t *testing.T) {
@@ -71,7 +80,8 @@ func TestNewEasyDriver_options(t *testing.T) {
 	assert.Equal(t, dirPin, d.easyCfg.dirPin)
 	assert.Equal(t, myName, d.Name())
 	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy', "+
-		"consider to use one of the options instead: WithEasyDirectionPin, WithEasyEnablePin, WithEasySleepPin", panicFunc)
+		"consider to use one of the options instead: WithEasyDirectionPin, WithEasyEnablePin, WithEasySleepPin, "+
+		"WithEasyCurrentControl, WithEasyWriteTimeout, WithEasyInvertedStep", panicFunc)
 }
 
 func TestEasy_WithEasyEnablePin(t *testing.T) {
@@ -94,6 +104,129 @@ func TestEasy_WithEasySleepPin(t *testing.T) {
 	assert.Equal(t, mySleepPin, cfg.sleepPin)
 }
 
+func TestEasy_WithEasyWriteTimeout(t *testing.T) {
+	// arrange
+	cfg := easyConfiguration{}
+	// act
+	WithEasyWriteTimeout(50 * time.Millisecond).apply(&cfg)
+	// assert
+	assert.Equal(t, 50*time.Millisecond, cfg.writeTimeout)
+}
+
+func TestEasy_WithEasyInvertedStep(t *testing.T) {
+	// arrange
+	cfg := easyConfiguration{}
+	// act
+	WithEasyInvertedStep().apply(&cfg)
+	// assert
+	assert.True(t, cfg.invertedStep)
+}
+
+func TestEasyStepPolarity(t *testing.T) {
+	tests := map[string]struct {
+		opts        []interface{}
+		direction   string
+		wantSteps   int
+		wantWritten []gpioTestWritten
+	}{
+		"normal": {
+			direction:   StepperDriverForward,
+			wantSteps:   1,
+			wantWritten: []gpioTestWritten{{pin: "1", val: 0x0}, {pin: "1", val: 0x1}},
+		},
+		"inverted": {
+			opts:        []interface{}{WithEasyInvertedStep()},
+			direction:   StepperDriverForward,
+			wantSteps:   1,
+			wantWritten: []gpioTestWritten{{pin: "1", val: 0x1}, {pin: "1", val: 0x0}},
+		},
+		"inverted_backward": {
+			opts:        []interface{}{WithEasyInvertedStep()},
+			direction:   StepperDriverBackward,
+			wantSteps:   -1,
+			wantWritten: []gpioTestWritten{{pin: "1", val: 0x1}, {pin: "1", val: 0x0}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 0.5, "1", tc.opts...)
+			d.direction = tc.direction
+			// act
+			err := d.onePinStepping()
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantWritten, a.written)
+			assert.Equal(t, tc.wantSteps, d.CurrentStep())
+		})
+	}
+}
+
+func TestEasyWriteTimeout(t *testing.T) {
+	tests := map[string]struct {
+		sleeping bool
+		disabled bool
+		control  func(d *EasyDriver) error
+		wantPin  string
+	}{
+		"enable": {
+			disabled: true,
+			control:  func(d *EasyDriver) error { return d.Enable() },
+			wantPin:  "3",
+		},
+		"disable": {
+			control: func(d *EasyDriver) error { return d.Disable() },
+			wantPin: "3",
+		},
+		"sleep": {
+			control: func(d *EasyDriver) error { return d.Sleep() },
+			wantPin: "4",
+		},
+		"wake": {
+			sleeping: true,
+			control:  func(d *EasyDriver) error { return d.Wake() },
+			wantPin:  "4",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 1.8, "1", WithName("motor"), WithEasyEnablePin("3"), WithEasySleepPin("4"),
+				WithEasyWriteTimeout(20*time.Millisecond))
+			d.disabled = tc.disabled
+			d.sleeping = tc.sleeping
+			release := make(chan struct{})
+			defer close(release)
+			a.digitalWriteFunc = func(string, byte) error {
+				<-release // simulates a hanging write
+				return nil
+			}
+			// act
+			start := time.Now()
+			err := tc.control(d)
+			// assert
+			require.EqualError(t, err, "write of pin '"+tc.wantPin+"' for 'motor' timed out after 20ms")
+			assert.Less(t, time.Since(start), time.Second)
+			assert.Equal(t, tc.disabled, d.disabled)
+			assert.Equal(t, tc.sleeping, d.sleeping)
+		})
+	}
+}
+
+func TestEasyWriteTimeout_notExceeded(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 1.8, "1", WithEasyEnablePin("3"), WithEasyWriteTimeout(time.Second))
+	a.digitalWriteFunc = func(string, byte) error { return fmt.Errorf("write error") }
+	// act & assert
+	require.EqualError(t, d.Disable(), "write error")
+	a.digitalWriteFunc = func(string, byte) error { return nil }
+	require.NoError(t, d.Disable())
+	assert.False(t, d.IsEnabled())
+}
+
 func TestEasyMoveDeg_IsMoving(t *testing.T) {
 	tests := map[string]struct {
 		inputDeg               int
@@ -117,6 +250,12 @@ func TestEasyMoveDeg_IsMoving(t *testing.T) {
 			wantSteps:  40,
 			wantMoving: false,
 		},
+		"move_backward": {
+			inputDeg:   -10,
+			wantWrites: 40,
+			wantSteps:  -20,
+			wantMoving: false,
+		},
 		"error_disabled": {
 			simulateDisabled: true,
 			wantMoving:       false,
@@ -165,10 +304,169 @@ func TestEasyMoveDeg_IsMoving(t *testing.T) {
 			assert.Equal(t, tc.wantSteps, d.stepNum)
 			assert.Len(t, a.written, tc.wantWrites)
 			assert.Equal(t, tc.wantMoving, d.IsMoving())
+			assert.Equal(t, StepperDriverForward, d.direction)
+		})
+	}
+}
+
+func TestEasyMoveDeg_withDirPin(t *testing.T) {
+	tests := map[string]struct {
+		priorDirection   string
+		inputDeg         int
+		simulateWriteErr bool
+		wantSteps        int
+		wantDirWrites    []gpioTestWritten
+		wantErr          string
+	}{
+		"backward": {
+			priorDirection: "forward",
+			inputDeg:       -20,
+			wantSteps:      -40,
+			wantDirWrites:  []gpioTestWritten{{pin: "2", val: 0x1}, {pin: "2", val: 0x0}},
+		},
+		"forward": {
+			priorDirection: "forward",
+			inputDeg:       20,
+			wantSteps:      40,
+		},
+		"backward_prior_backward": {
+			priorDirection: "backward",
+			inputDeg:       -20,
+			wantSteps:      -40,
+		},
+		"forward_prior_backward": {
+			priorDirection: "backward",
+			inputDeg:       20,
+			wantSteps:      40,
+			wantDirWrites:  []gpioTestWritten{{pin: "2", val: 0x0}, {pin: "2", val: 0x1}},
+		},
+		"error_write": {
+			priorDirection:   "forward",
+			inputDeg:         -20,
+			simulateWriteErr: true,
+			wantErr:          "write error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 0.5, "1", WithEasyDirectionPin("2"))
+			maxSpeed, err := d.MaxSpeed()
+			require.NoError(t, err)
+			require.NoError(t, d.SetSpeed(maxSpeed))
+			require.NoError(t, d.SetDirection(tc.priorDirection))
+			a.written = nil
+			a.simulateWriteError = tc.simulateWriteErr
+			// act
+			err = d.MoveDeg(tc.inputDeg)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantSteps, d.CurrentStep())
+			var dirWrites []gpioTestWritten
+			for _, w := range a.written {
+				if w.pin == "2" {
+					dirWrites = append(dirWrites, w)
+				}
+			}
+			assert.Equal(t, tc.wantDirWrites, dirWrites)
+			if len(tc.wantDirWrites) > 0 {
+				// the direction is written before the first step and restored after the last step
+				assert.Equal(t, tc.wantDirWrites[0], a.written[0])
+				assert.Equal(t, tc.wantDirWrites[1], a.written[len(a.written)-1])
+			}
+			assert.Len(t, a.written, 2*int(math.Abs(float64(tc.wantSteps)))+len(tc.wantDirWrites))
+			assert.Equal(t, tc.priorDirection, d.State().Direction)
+			assert.False(t, d.IsMoving())
+		})
+	}
+}
+
+func TestEasyMove_backwardWithDirPin(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 0.5, "1", WithEasyDirectionPin("2"))
+	a.written = nil
+	// act
+	err := d.Move(-5)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, -5, d.CurrentStep())
+	assert.Equal(t, gpioTestWritten{pin: "2", val: 0x1}, a.written[0])
+	assert.Len(t, a.written, 1+2*5)
+	assert.Equal(t, StepperDriverBackward, d.State().Direction)
+}
+
+func TestEasyMoveDeg_noDrift(t *testing.T) {
+	tests := map[string]struct {
+		anglePerStep float32
+		inputDeg     int
+		moves        int
+	}{
+		"divisible": {
+			anglePerStep: 0.5,
+			inputDeg:     1,
+			moves:        100,
+		},
+		"not_divisible": {
+			anglePerStep: 0.7,
+			inputDeg:     1,
+			moves:        100,
+		},
+		"not_divisible_backward": {
+			anglePerStep: 0.7,
+			inputDeg:     -3,
+			moves:        50,
+		},
+		"smaller_than_one_step": {
+			anglePerStep: 1.8,
+			inputDeg:     1,
+			moves:        100,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, tc.anglePerStep, "1")
+			maxSpeed, err := d.MaxSpeed()
+			require.NoError(t, err)
+			require.NoError(t, d.SetSpeed(maxSpeed))
+			wantSteps := float64(tc.moves*tc.inputDeg) / float64(tc.anglePerStep)
+			// act
+			for i := 0; i < tc.moves; i++ {
+				require.NoError(t, d.MoveDeg(tc.inputDeg))
+			}
+			// assert
+			assert.InDelta(t, wantSteps, float64(d.CurrentStep()), 1)
+			assert.InDelta(t, wantSteps, float64(d.CurrentStep())+d.MoveDegResidual(), 0.001)
+			assert.Less(t, math.Abs(d.MoveDegResidual()), 1.0)
 		})
 	}
 }
 
+func TestEasyMoveDeg_residual(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.SetAnglePerStep(1.8))
+	// act & assert: less than one step is only accumulated
+	require.NoError(t, d.MoveDeg(1))
+	assert.Equal(t, 0, d.CurrentStep())
+	assert.InDelta(t, 0.5556, d.MoveDegResidual(), 0.0001)
+	// act & assert: the accumulated fraction completes a step
+	require.NoError(t, d.MoveDeg(1))
+	assert.Equal(t, 1, d.CurrentStep())
+	assert.InDelta(t, 0.1111, d.MoveDegResidual(), 0.0001)
+	// act & assert: backward reduces the fraction
+	require.NoError(t, d.MoveDeg(-2))
+	assert.Equal(t, 0, d.CurrentStep())
+	assert.InDelta(t, 0.0, d.MoveDegResidual(), 0.0001)
+}
+
 func TestEasyRun_IsMoving(t *testing.T) {
 	tests := map[string]struct {
 		simulateDisabled       bool
@@ -237,6 +535,200 @@ func TestEasyStop_IsMoving(t *testing.T) {
 	assert.False(t, d.IsMoving())
 }
 
+func TestEasyRun_LastError(t *testing.T) {
+	// arrange
+	d, a := initTestEasyDriverWithStubbedAdaptor()
+	var fail atomic.Bool
+	fail.Store(true)
+	a.digitalWriteFunc = func(string, byte) error {
+		if fail.Load() {
+			return fmt.Errorf("write error")
+		}
+		return nil
+	}
+	// act: the asynchronous loop ends with the write error
+	require.NoError(t, d.Run())
+	// assert
+	assert.Eventually(t, func() bool { return d.LastError() != nil }, time.Second, time.Millisecond)
+	require.EqualError(t, d.LastError(), "write error")
+	require.EqualError(t, d.Stop(), "write error")
+	// act: the next successful run clears the error
+	fail.Store(false)
+	require.NoError(t, d.Run())
+	// assert
+	assert.Eventually(t, func() bool { return d.LastError() == nil }, time.Second, time.Millisecond)
+	require.NoError(t, d.Stop())
+}
+
+func TestEasySoftStop(t *testing.T) {
+	timer := TrapezoidalStepTimer{StartDelay: 4 * time.Millisecond, CruiseDelay: time.Millisecond, RampSteps: 4}
+
+	tests := map[string]struct {
+		move      bool
+		stopFunc  func(d *EasyDriver) error
+		wantDecel bool
+	}{
+		"soft_stop_run": {
+			stopFunc:  func(d *EasyDriver) error { return d.SoftStop() },
+			wantDecel: true,
+		},
+		"soft_stop_move": {
+			move:      true,
+			stopFunc:  func(d *EasyDriver) error { return d.SoftStop() },
+			wantDecel: true,
+		},
+		"hard_stop_run": {
+			stopFunc: func(d *EasyDriver) error { return d.Stop() },
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestEasyDriverWithStubbedAdaptor()
+			d.SetStepTimer(timer)
+			var mutex sync.Mutex
+			var stopped, stopReturned bool
+			var delaysAfterStop []time.Duration
+			var stepsAfterStopReturned int
+			origStepFunc := d.stepFunc
+			d.stepFunc = func() error {
+				d.valueMutex.Lock()
+				delay := d.getStepDelay()
+				d.valueMutex.Unlock()
+				mutex.Lock()
+				if stopped {
+					delaysAfterStop = append(delaysAfterStop, delay)
+				}
+				if stopReturned {
+					stepsAfterStopReturned++
+				}
+				mutex.Unlock()
+				return origStepFunc()
+			}
+			moveErr := make(chan error, 1)
+			if tc.move {
+				go func() { moveErr <- d.Move(1000) }()
+			} else {
+				require.NoError(t, d.Run())
+				moveErr <- nil
+			}
+			// wait until cruising
+			require.Eventually(t, func() bool {
+				done, _ := d.Progress()
+				return done > 2*timer.RampSteps
+			}, time.Second, time.Millisecond)
+			// act
+			stopStart := time.Now()
+			mutex.Lock()
+			stopped = true
+			mutex.Unlock()
+			err := tc.stopFunc(d)
+			stopDuration := time.Since(stopStart)
+			mutex.Lock()
+			stopReturned = true
+			mutex.Unlock()
+			// assert
+			require.NoError(t, err)
+			require.NoError(t, <-moveErr)
+			assert.False(t, d.IsMoving())
+			assert.Less(t, d.CurrentStep(), 1000)
+			stepAtStop := d.CurrentStep()
+			time.Sleep(5 * timer.CruiseDelay)
+			assert.Equal(t, stepAtStop, d.CurrentStep())
+			mutex.Lock()
+			defer mutex.Unlock()
+			assert.Equal(t, 0, stepsAfterStopReturned)
+			decelSteps := 0
+			if tc.wantDecel {
+				decelSteps = timer.RampSteps
+			}
+			require.GreaterOrEqual(t, len(delaysAfterStop), decelSteps)
+			// the count of cruising steps until the stop is recognized is limited by the cruise delay, one step can be
+			// in progress already
+			cruise := delaysAfterStop[:len(delaysAfterStop)-decelSteps]
+			assert.LessOrEqual(t, len(cruise), int(stopDuration/timer.CruiseDelay)+1)
+			for _, delay := range cruise {
+				assert.Equal(t, timer.CruiseDelay, delay)
+			}
+			if !tc.wantDecel {
+				return
+			}
+			decel := delaysAfterStop[len(delaysAfterStop)-timer.RampSteps:]
+			for i := 1; i < len(decel); i++ {
+				assert.Greater(t, decel[i], decel[i-1])
+			}
+			assert.Greater(t, decel[0], timer.CruiseDelay)
+			assert.Equal(t, timer.StartDelay, decel[len(decel)-1])
+		})
+	}
+}
+
+func TestEasySoftStop_withoutStepTimer(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.Run())
+	time.Sleep(5 * time.Millisecond)
+	// act
+	err := d.SoftStop()
+	// assert
+	require.NoError(t, err)
+	assert.False(t, d.IsMoving())
+}
+
+func TestEasySoftStop_notStarted(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	// act
+	err := d.SoftStop()
+	// assert
+	require.ErrorContains(t, err, "is not yet started")
+}
+
+func TestEasyEmergencyStop(t *testing.T) {
+	tests := map[string]struct {
+		opts         []interface{}
+		wantDisabled bool
+		wantSleeping bool
+		wantErr      string
+	}{
+		"with_enable_pin": {
+			opts:         []interface{}{WithEasyEnablePin("10")},
+			wantDisabled: true,
+		},
+		"with_sleep_pin": {
+			opts:         []interface{}{WithEasySleepPin("11")},
+			wantSleeping: true,
+		},
+		"with_enable_and_sleep_pin": {
+			opts:         []interface{}{WithEasyEnablePin("10"), WithEasySleepPin("11")},
+			wantDisabled: true,
+		},
+		"error_no_pin": {
+			wantErr: "neither enPin nor sleepPin is set",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 0.5, "1", tc.opts...)
+			require.NoError(t, d.Run())
+			require.True(t, d.IsMoving())
+			// act
+			err := d.EmergencyStop()
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.False(t, d.IsMoving())
+			assert.Equal(t, tc.wantDisabled, !d.IsEnabled())
+			assert.Equal(t, tc.wantSleeping, d.IsSleeping())
+		})
+	}
+}
+
 func TestEasyDriverHalt_IsMoving(t *testing.T) {
 	// arrange
 	d, _ := initTestEasyDriverWithStubbedAdaptor()
@@ -318,6 +810,79 @@ func TestEasySetDirection(t *testing.T) {
 	}
 }
 
+func TestEasyConcurrentAccess(t *testing.T) {
+	// this test needs to be run with "-race" to be meaningful
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 1.8, "1", WithEasyDirectionPin("2"), WithEasyEnablePin("3"))
+	require.NoError(t, d.Start())
+	maxSpeed, err := d.MaxSpeed()
+	require.NoError(t, err)
+	require.NoError(t, d.SetSpeed(maxSpeed))
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	// act: movements
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			_ = d.MoveDeg(18)
+			_ = d.Move(-5)
+		}
+	}()
+	// act: setters and getters during the movements
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			_ = d.SetSpeed(maxSpeed - 1)
+			_ = d.SetDirection(StepperDriverBackward)
+			_ = d.SetSpeed(maxSpeed)
+			_ = d.SetDirection(StepperDriverForward)
+			_ = d.IsMoving()
+			_ = d.CurrentStep()
+			_ = d.IsEnabled()
+			_ = d.IsSleeping()
+			_ = d.State()
+			_ = d.GetParams()
+			_ = d.GetValue()
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	// act: stop and disable during the movements
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 5; i++ {
+			time.Sleep(5 * time.Millisecond)
+			_ = d.Stop()
+			_ = d.Disable()
+			_ = d.Enable()
+		}
+	}()
+	// assert: no deadlock
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	close(done)
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "concurrent callers did not finish")
+	}
+	assert.True(t, d.IsEnabled())
+	assert.False(t, d.IsMoving())
+	require.NoError(t, d.Halt())
+}
+
 func TestEasyMaxSpeed(t *testing.T) {
 	const delayForMaxSpeed = 1428 * time.Microsecond // 1/700Hz
 
@@ -350,10 +915,10 @@ func TestEasyMaxSpeed(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			// arrange
 			d, _ := initTestEasyDriverWithStubbedAdaptor()
-			d.anglePerStep = tc.anglePerStep
-			d.stepsPerRev = 360.0 / tc.anglePerStep
+			require.NoError(t, d.SetAnglePerStep(tc.anglePerStep))
 			// act
-			got := d.MaxSpeed()
+			got, err := d.MaxSpeed()
+			require.NoError(t, err)
 			d.speedRpm = got
 			got2 := d.getDelayPerStep()
 			// assert
@@ -363,6 +928,80 @@ func TestEasyMaxSpeed(t *testing.T) {
 	}
 }
 
+func TestEasySetAnglePerStep(t *testing.T) {
+	tests := map[string]struct {
+		anglePerStep    float32
+		speedBefore     uint
+		wantStepsPerRev float32
+		wantSpeed       uint
+		wantErr         string
+	}{
+		"full_step": {
+			anglePerStep:    1.8,
+			speedBefore:     10,
+			wantStepsPerRev: 200,
+			wantSpeed:       10,
+		},
+		"micro_step_limits_speed": {
+			anglePerStep:    1.8 / 16,
+			speedBefore:     100,
+			wantStepsPerRev: 3200,
+			wantSpeed:       13,
+		},
+		"error_zero": {
+			anglePerStep:    0,
+			speedBefore:     10,
+			wantStepsPerRev: 720,
+			wantSpeed:       10,
+			wantErr:         "angle per step (0) of 'EasyDriver",
+		},
+		"error_negative": {
+			anglePerStep:    -1.8,
+			speedBefore:     10,
+			wantStepsPerRev: 720,
+			wantSpeed:       10,
+			wantErr:         "needs to be greater than zero",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestEasyDriverWithStubbedAdaptor()
+			d.speedRpm = tc.speedBefore
+			d.moveDegResidual = 0.5
+			// act
+			err := d.SetAnglePerStep(tc.anglePerStep)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.InDelta(t, float32(0.5), d.AnglePerStep(), 0.0)
+				assert.InDelta(t, 0.5, d.MoveDegResidual(), 0.0)
+			} else {
+				require.NoError(t, err)
+				assert.InDelta(t, tc.anglePerStep, d.AnglePerStep(), 0.0)
+				assert.InDelta(t, 0.0, d.MoveDegResidual(), 0.0)
+			}
+			// the steps per revolution are always consistent to the angle
+			assert.InDelta(t, tc.wantStepsPerRev, d.stepsPerRev, 0.001)
+			assert.InDelta(t, 360.0, d.stepsPerRev*d.AnglePerStep(), 0.001)
+			assert.Equal(t, tc.wantSpeed, d.speedRpm)
+		})
+	}
+}
+
+func TestEasySetAnglePerStep_whileRunning(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Run())
+	defer func() { _ = d.Stop() }()
+	// act
+	err := d.SetAnglePerStep(1.8)
+	// assert
+	require.ErrorContains(t, err, "can not be changed while moving")
+	assert.InDelta(t, float32(0.5), d.AnglePerStep(), 0.0)
+}
+
 func TestEasySetSpeed(t *testing.T) {
 	const (
 		anglePerStep = 10
@@ -398,8 +1037,7 @@ func TestEasySetSpeed(t *testing.T) {
 			// arrange
 			d, _ := initTestEasyDriverWithStubbedAdaptor()
 			d.speedRpm = 0
-			d.anglePerStep = anglePerStep
-			d.stepsPerRev = 360.0 / anglePerStep
+			require.NoError(t, d.SetAnglePerStep(anglePerStep))
 			// act
 			err := d.SetSpeed(tc.input)
 			// assert
@@ -517,25 +1155,367 @@ func TestEasy_onePinStepping(t *testing.T) {
 	}
 }
 
-func TestEasyEnable_IsEnabled(t *testing.T) {
-	const anglePerStep = 0.5 // use non int step angle to check int math
-
+func TestEasyStepOnce(t *testing.T) {
 	tests := map[string]struct {
-		enPin            string
+		directions       []string
+		withDirPin       bool
+		disabled         bool
+		moving           bool
 		simulateWriteErr bool
-		wantWrites       int
-		wantEnabled      bool
+		wantSteps        int
+		wantWritten      []gpioTestWritten
 		wantErr          string
 	}{
-		"basic": {
-			enPin:       "10",
-			wantWrites:  1,
-			wantEnabled: true,
-		},
-		"with_run": {
-			enPin:       "11",
-			wantWrites:  1,
-			wantEnabled: true,
+		"forward": {
+			directions: []string{"forward"},
+			wantSteps:  1,
+			wantWritten: []gpioTestWritten{
+				{pin: "1", val: 0x0},
+				{pin: "1", val: 0x1},
+			},
+		},
+		"backward": {
+			directions: []string{"Backward"},
+			withDirPin: true,
+			wantSteps:  -1,
+			wantWritten: []gpioTestWritten{
+				{pin: "2", val: 0x1},
+				{pin: "1", val: 0x0},
+				{pin: "1", val: 0x1},
+			},
+		},
+		"forth_and_back": {
+			directions: []string{"forward", "forward", "backward"},
+			withDirPin: true,
+			wantSteps:  1,
+			wantWritten: []gpioTestWritten{
+				{pin: "1", val: 0x0},
+				{pin: "1", val: 0x1},
+				{pin: "1", val: 0x0},
+				{pin: "1", val: 0x1},
+				{pin: "2", val: 0x1},
+				{pin: "1", val: 0x0},
+				{pin: "1", val: 0x1},
+			},
+		},
+		"error_no_dir_pin": {
+			directions: []string{"backward"},
+			wantErr:    "dirPin is not set for 'EasyDriver'",
+		},
+		"error_invalid_direction": {
+			directions: []string{"upward"},
+			wantErr:    "Invalid direction 'upward'. Value should be 'forward' or 'backward'",
+		},
+		"error_disabled": {
+			directions: []string{"forward"},
+			disabled:   true,
+			wantErr:    "'EasyDriver' is disabled and can not be running or moving",
+		},
+		"error_moving": {
+			directions: []string{"forward"},
+			moving:     true,
+			wantErr:    "'EasyDriver' is moving, single step not possible",
+		},
+		"error_write": {
+			directions:       []string{"forward"},
+			simulateWriteErr: true,
+			wantErr:          "write error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			opts := []interface{}{WithName("EasyDriver")}
+			if tc.withDirPin {
+				opts = append(opts, WithEasyDirectionPin("2"))
+			}
+			d := NewEasyDriver(a, 1.8, "1", opts...)
+			d.disabled = tc.disabled
+			if tc.moving {
+				d.stopAsynchRunFunc = func(bool) error { return nil }
+			}
+			a.simulateWriteError = tc.simulateWriteErr
+			// act
+			var err error
+			for _, direction := range tc.directions {
+				if err = d.StepOnce(direction); err != nil {
+					break
+				}
+			}
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantSteps, d.CurrentStep())
+			assert.Equal(t, tc.wantWritten, a.written)
+		})
+	}
+}
+
+func TestEasySetStepPulseWidth(t *testing.T) {
+	tests := map[string]struct {
+		width   time.Duration
+		want    time.Duration
+		wantErr string
+	}{
+		"zero": {
+			width: 0,
+			want:  0,
+		},
+		"positive": {
+			width: 10 * time.Microsecond,
+			want:  10 * time.Microsecond,
+		},
+		"error_negative": {
+			width:   -time.Microsecond,
+			want:    2 * time.Microsecond,
+			wantErr: "step pulse width (-1µs) cannot be a negative value",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestEasyDriverWithStubbedAdaptor()
+			// act
+			err := d.SetStepPulseWidth(tc.width)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, d.StepPulseWidth())
+		})
+	}
+}
+
+func TestEasySetDirectionSetupTime(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	// act & assert
+	require.NoError(t, d.SetDirectionSetupTime(time.Millisecond))
+	assert.Equal(t, time.Millisecond, d.DirectionSetupTime())
+	require.EqualError(t, d.SetDirectionSetupTime(-time.Millisecond),
+		"direction setup time (-1ms) cannot be a negative value")
+	assert.Equal(t, time.Millisecond, d.DirectionSetupTime())
+}
+
+func TestEasyDirectionSetupTime(t *testing.T) {
+	const setupTime = 20 * time.Millisecond
+
+	tests := map[string]struct {
+		prepare  func(d *EasyDriver) error
+		steps    int
+		wantDirs int
+	}{
+		"set_direction_before_move": {
+			prepare:  func(d *EasyDriver) error { return d.SetDirection(StepperDriverBackward) },
+			steps:    -3,
+			wantDirs: 1,
+		},
+		"direction_change_by_move": {
+			prepare:  func(d *EasyDriver) error { return nil },
+			steps:    -3,
+			wantDirs: 1,
+		},
+		"set_direction_before_step_once": {
+			prepare:  func(d *EasyDriver) error { return d.SetDirection(StepperDriverBackward) },
+			wantDirs: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 0.5, "1", WithEasyDirectionPin("2"))
+			maxSpeed, err := d.MaxSpeed()
+			require.NoError(t, err)
+			require.NoError(t, d.SetSpeed(maxSpeed))
+			require.NoError(t, d.SetDirectionSetupTime(setupTime))
+			var dirWrites, steps []time.Time
+			a.digitalWriteFunc = func(pin string, val byte) error {
+				switch {
+				case pin == "2":
+					dirWrites = append(dirWrites, time.Now())
+				case val == 1:
+					steps = append(steps, time.Now())
+				}
+				return nil
+			}
+			// act
+			require.NoError(t, tc.prepare(d))
+			if tc.steps != 0 {
+				err = d.Move(tc.steps)
+			} else {
+				err = d.StepOnce(StepperDriverBackward)
+			}
+			// assert
+			require.NoError(t, err)
+			require.Len(t, dirWrites, tc.wantDirs)
+			require.NotEmpty(t, steps)
+			assert.GreaterOrEqual(t, steps[0].Sub(dirWrites[0]), setupTime)
+			for i := 1; i < len(steps); i++ {
+				// no further delay, if the direction is not changed
+				assert.Less(t, steps[i].Sub(steps[i-1]), setupTime, "step %d", i)
+			}
+		})
+	}
+}
+
+func TestEasySetDirectionSettleDelay(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.Equal(t, time.Duration(0), d.DirectionSettleDelay())
+	// act & assert
+	require.NoError(t, d.SetDirectionSettleDelay(time.Millisecond))
+	assert.Equal(t, time.Millisecond, d.DirectionSettleDelay())
+	require.EqualError(t, d.SetDirectionSettleDelay(-time.Millisecond),
+		"direction settle delay (-1ms) cannot be a negative value")
+	assert.Equal(t, time.Millisecond, d.DirectionSettleDelay())
+}
+
+func TestEasyDirectionSettleDelay(t *testing.T) {
+	const settleDelay = 3 * time.Millisecond
+
+	tests := map[string]struct {
+		settleDelay time.Duration
+		prepare     func(d *EasyDriver) error
+		moves       []int
+		want        []string
+	}{
+		"default_without_delay": {
+			prepare: func(d *EasyDriver) error { return nil },
+			moves:   []int{-2},
+			want:    []string{"dir=1", "step", "step"},
+		},
+		"reversal_by_move": {
+			settleDelay: settleDelay,
+			prepare:     func(d *EasyDriver) error { return nil },
+			moves:       []int{-2},
+			want:        []string{"dir=1", "wait=3ms", "step", "step"},
+		},
+		"set_direction": {
+			settleDelay: settleDelay,
+			prepare:     func(d *EasyDriver) error { return d.SetDirection(StepperDriverBackward) },
+			moves:       []int{-2},
+			want:        []string{"dir=1", "wait=3ms", "step", "step"},
+		},
+		"no_reversal": {
+			settleDelay: settleDelay,
+			prepare:     func(d *EasyDriver) error { return nil },
+			moves:       []int{2, 1},
+			want:        []string{"step", "step", "step"},
+		},
+		"reversal_between_moves": {
+			settleDelay: settleDelay,
+			prepare:     func(d *EasyDriver) error { return d.SetDirection(StepperDriverBackward) },
+			moves:       []int{-1, 2},
+			want:        []string{"dir=1", "wait=3ms", "step", "dir=0", "wait=3ms", "step", "step"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 0.5, "1", WithEasyDirectionPin("2"))
+			maxSpeed, err := d.MaxSpeed()
+			require.NoError(t, err)
+			require.NoError(t, d.SetSpeed(maxSpeed))
+			require.NoError(t, d.SetDirectionSettleDelay(tc.settleDelay))
+			var got []string
+			a.digitalWriteFunc = func(pin string, val byte) error {
+				switch {
+				case pin == "2":
+					got = append(got, fmt.Sprintf("dir=%d", val))
+				case val == 1:
+					got = append(got, "step")
+				}
+				return nil
+			}
+			d.waitFunc = func(delay time.Duration) {
+				// the waits of the steps are not of interest here
+				if delay == tc.settleDelay {
+					got = append(got, fmt.Sprintf("wait=%s", delay))
+				}
+			}
+			require.NoError(t, tc.prepare(d))
+			// act
+			for _, steps := range tc.moves {
+				require.NoError(t, d.Move(steps))
+			}
+			// assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEasyStepPulseWidth_highDuration(t *testing.T) {
+	// 200 steps per revolution at 30 rpm gives a delay of 10 ms per step
+	tests := map[string]struct {
+		width    time.Duration
+		wantStep []string
+	}{
+		"default": {
+			width:    2 * time.Microsecond,
+			wantStep: []string{"low", "wait=9.998ms", "high", "wait=2µs"},
+		},
+		"shorter_than_delay": {
+			width:    4 * time.Millisecond,
+			wantStep: []string{"low", "wait=6ms", "high", "wait=4ms"},
+		},
+		"longer_than_delay": {
+			width:    15 * time.Millisecond,
+			wantStep: []string{"low", "high", "wait=15ms"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 1.8, "1")
+			require.NoError(t, d.SetSpeed(30))
+			require.NoError(t, d.SetStepPulseWidth(tc.width))
+			var got []string
+			a.digitalWriteFunc = func(pin string, val byte) error {
+				if val == 1 {
+					got = append(got, "high")
+				} else {
+					got = append(got, "low")
+				}
+				return nil
+			}
+			d.waitFunc = func(wait time.Duration) { got = append(got, fmt.Sprintf("wait=%s", wait)) }
+			// act
+			err := d.Move(2)
+			// assert: the pulse width is part of the delay, so the speed is kept
+			require.NoError(t, err)
+			assert.Equal(t, append(append([]string{}, tc.wantStep...), tc.wantStep...), got)
+		})
+	}
+}
+
+func TestEasyEnable_IsEnabled(t *testing.T) {
+	const anglePerStep = 0.5 // use non int step angle to check int math
+
+	tests := map[string]struct {
+		enPin            string
+		simulateWriteErr bool
+		wantWrites       int
+		wantEnabled      bool
+		wantErr          string
+	}{
+		"basic": {
+			enPin:       "10",
+			wantWrites:  1,
+			wantEnabled: true,
+		},
+		"with_run": {
+			enPin:       "11",
+			wantWrites:  1,
+			wantEnabled: true,
 		},
 		"error_no_pin": {
 			enPin:       "",
@@ -804,3 +1784,897 @@ func TestEasyWake_IsSleeping(t *testing.T) {
 		})
 	}
 }
+
+func TestEasySetValue_Actuator(t *testing.T) {
+	var _ gobot.Actuator = (*EasyDriver)(nil)
+
+	tests := map[string]struct {
+		startStep  int
+		input      float64
+		wantWrites int
+		wantSteps  int
+		wantErr    string
+	}{
+		"forward": {
+			input:      10,
+			wantWrites: 40,
+			wantSteps:  20,
+		},
+		"backward": {
+			startStep:  30,
+			input:      5,
+			wantWrites: 40,
+			wantSteps:  10,
+		},
+		"no_move": {
+			startStep: 20,
+			input:     10,
+			wantSteps: 20,
+		},
+		"error_out_of_range": {
+			input:   361,
+			wantErr: "angle (361) must be between 0-360",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestEasyDriverWithStubbedAdaptor()
+			d.stepNum = tc.startStep
+			a.written = nil
+			// act
+			err := d.SetValue(tc.input)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.InDelta(t, tc.input, d.GetValue(), 0.0)
+			}
+			assert.Equal(t, tc.wantSteps, d.CurrentStep())
+			assert.Len(t, a.written, tc.wantWrites)
+			minVal, maxVal := d.ValueRange()
+			assert.InDelta(t, 0.0, minVal, 0.0)
+			assert.InDelta(t, 360.0, maxVal, 0.0)
+		})
+	}
+}
+
+func TestEasySetValue_afterRevolutions(t *testing.T) {
+	tests := map[string]struct {
+		startStep int
+		readBack  bool // the value, which was read before, is set
+		input     float64
+		wantSteps int
+	}{
+		"read_value_after_revolutions": {startStep: 1440 + 90, readBack: true, wantSteps: 1440 + 90},
+		"read_value_below_step_zero":   {startStep: -180, readBack: true, wantSteps: -180},
+		"forward_after_revolutions":    {startStep: 1440 + 90, input: 50, wantSteps: 1440 + 100},
+		"backward_below_step_zero":     {startStep: -180, input: 260, wantSteps: -200},
+		"forward_over_zero":            {startStep: 700, input: 10, wantSteps: 740},
+		"backward_over_zero":           {startStep: -700, input: 350, wantSteps: -740},
+		"full_revolution_is_zero":      {startStep: 720 + 2, input: 360, wantSteps: 720},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestEasyDriverWithStubbedAdaptor()
+			d.stepNum = tc.startStep
+			input := tc.input
+			if tc.readBack {
+				input = d.GetValue()
+			}
+			// act
+			err := d.SetValue(input)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantSteps, d.CurrentStep())
+			assert.InDelta(t, math.Mod(input, 360), d.GetValue(), 1e-9)
+		})
+	}
+}
+
+func TestEasyCapabilities_State(t *testing.T) {
+	tests := map[string]struct {
+		opts             []interface{}
+		wantHasDirPin    bool
+		wantHasEnPin     bool
+		wantHasSleepPin  bool
+		wantJSONContains string
+	}{
+		"no_pins": {
+			wantJSONContains: `"hasDirPin":false,"hasEnablePin":false,"hasSleepPin":false`,
+		},
+		"dir_pin": {
+			opts:             []interface{}{WithEasyDirectionPin("2")},
+			wantHasDirPin:    true,
+			wantJSONContains: `"hasDirPin":true,"hasEnablePin":false,"hasSleepPin":false`,
+		},
+		"all_pins": {
+			opts: []interface{}{
+				WithEasyDirectionPin("2"),
+				WithEasyEnablePin("3"),
+				WithEasySleepPin("4"),
+			},
+			wantHasDirPin:    true,
+			wantHasEnPin:     true,
+			wantHasSleepPin:  true,
+			wantJSONContains: `"hasDirPin":true,"hasEnablePin":true,"hasSleepPin":true`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewEasyDriver(newGpioTestAdaptor(), 1.8, "1", tc.opts...)
+			// act
+			state := d.State()
+			stateJSON, err := json.Marshal(d.Command("State")(nil))
+			// assert
+			assert.Equal(t, tc.wantHasDirPin, d.HasDirPin())
+			assert.Equal(t, tc.wantHasEnPin, d.HasEnablePin())
+			assert.Equal(t, tc.wantHasSleepPin, d.HasSleepPin())
+			assert.Equal(t, tc.wantHasDirPin, state.HasDirPin)
+			assert.Equal(t, tc.wantHasEnPin, state.HasEnablePin)
+			assert.Equal(t, tc.wantHasSleepPin, state.HasSleepPin)
+			assert.Equal(t, StepperDriverForward, state.Direction)
+			assert.True(t, state.Enabled)
+			assert.False(t, state.Sleeping)
+			assert.False(t, state.Moving)
+			require.NoError(t, err)
+			assert.Contains(t, string(stateJSON), tc.wantJSONContains)
+		})
+	}
+}
+
+func TestEasySetBacklash(t *testing.T) {
+	// arrange
+	d, a := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.SetBacklash(4))
+	require.NoError(t, d.Move(6))
+	a.written = nil
+	// act
+	err := d.Move(-2)
+	// assert: each step writes 2 values to the step pin
+	require.NoError(t, err)
+	assert.Len(t, a.written, 2*6)
+	assert.Equal(t, 4, d.CurrentStep())
+	// act: same direction
+	a.written = nil
+	err = d.Move(-2)
+	// assert
+	require.NoError(t, err)
+	assert.Len(t, a.written, 2*2)
+	assert.Equal(t, 2, d.CurrentStep())
+}
+
+func TestEasySetBacklash_negativePositionSign(t *testing.T) {
+	// arrange
+	d, a := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.SetPositionSign(-1))
+	require.NoError(t, d.SetBacklash(3))
+	require.NoError(t, d.Move(5))
+	require.Equal(t, -5, d.CurrentStep())
+	a.written = nil
+	// act: the reversal needs 3 take-up steps, which are not counted
+	err := d.Move(-5)
+	// assert: each step writes 2 values to the step pin
+	require.NoError(t, err)
+	assert.Len(t, a.written, 2*8)
+	assert.Equal(t, 0, d.CurrentStep())
+}
+
+func TestEasyGetParams(t *testing.T) {
+	// arrange
+	d := NewEasyDriver(newGpioTestAdaptor(), 0.5, "1", WithEasySleepPin("4"))
+	require.NoError(t, d.Move(3))
+	require.NoError(t, d.Sleep())
+	// act
+	got := d.Command("GetParams")(nil)
+	// assert
+	want := map[string]interface{}{
+		"speed":     uint(14),
+		"direction": "forward",
+		"position":  3,
+		"enabled":   true,
+		"sleeping":  true,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestEasySetParams(t *testing.T) {
+	allPins := []interface{}{WithEasyDirectionPin("2"), WithEasyEnablePin("3"), WithEasySleepPin("4")}
+	tests := map[string]struct {
+		opts          []interface{}
+		params        map[string]interface{}
+		wantSpeed     uint
+		wantDirection string
+		wantPosition  int
+		wantEnabled   bool
+		wantSleeping  bool
+		wantWritten   []gpioTestWritten
+		wantErr       string
+	}{
+		"empty": {
+			params:        map[string]interface{}{},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+		},
+		"all_params_from_api": {
+			opts: allPins,
+			params: map[string]interface{}{
+				"speed": float64(20), "direction": "Backward", "position": "-2", "enabled": true, "sleeping": "false",
+			},
+			wantSpeed:     20,
+			wantDirection: "backward",
+			wantPosition:  -2,
+			wantEnabled:   true,
+			wantWritten: []gpioTestWritten{
+				// direction
+				{pin: "2", val: 1},
+				// 2 steps
+				{pin: "1", val: 0}, {pin: "1", val: 1}, {pin: "1", val: 0}, {pin: "1", val: 1},
+			},
+		},
+		"direction_kept_after_position": {
+			opts:          allPins,
+			params:        map[string]interface{}{"direction": "forward", "position": -2},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantPosition:  -2,
+			wantEnabled:   true,
+			wantWritten: []gpioTestWritten{
+				// direction of the movement
+				{pin: "2", val: 1},
+				// 2 steps
+				{pin: "1", val: 0}, {pin: "1", val: 1}, {pin: "1", val: 0}, {pin: "1", val: 1},
+				// given direction
+				{pin: "2", val: 0},
+			},
+		},
+		"disable_and_sleep": {
+			opts:          allPins,
+			params:        map[string]interface{}{"enabled": false, "sleeping": true},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   false,
+			wantSleeping:  true,
+			wantWritten:   []gpioTestWritten{{pin: "3", val: 1}, {pin: "4", val: 0}},
+		},
+		"unchanged_values_without_pins": {
+			params:        map[string]interface{}{"direction": "forward", "enabled": true, "sleeping": false},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+		},
+		"error_unknown_param": {
+			params:        map[string]interface{}{"speed": 20, "acceleration": 5},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "unknown parameter 'acceleration'",
+		},
+		"error_speed_zero": {
+			params:        map[string]interface{}{"speed": 0},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "parameter 'speed' (0) must be greater than zero",
+		},
+		"error_speed_no_integer": {
+			params:        map[string]interface{}{"speed": 1.5},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "parameter 'speed' needs to be an integer, but is '1.5'",
+		},
+		"error_position_no_integer": {
+			params:        map[string]interface{}{"position": "abc"},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "parameter 'position' needs to be an integer, but is 'abc'",
+		},
+		"error_invalid_direction": {
+			opts:          allPins,
+			params:        map[string]interface{}{"direction": "up"},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "Invalid direction 'up'",
+		},
+		"error_direction_no_string": {
+			opts:          allPins,
+			params:        map[string]interface{}{"direction": 1},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "parameter 'direction' needs to be a string, but is '1'",
+		},
+		"error_enabled_no_bool": {
+			opts:          allPins,
+			params:        map[string]interface{}{"enabled": 1},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "parameter 'enabled' needs to be a boolean, but is '1'",
+		},
+		"error_no_dir_pin": {
+			params:        map[string]interface{}{"speed": 20, "direction": "backward"},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "dirPin is not set",
+		},
+		"error_no_enable_pin": {
+			params:        map[string]interface{}{"enabled": false},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "enPin is not set",
+		},
+		"error_no_sleep_pin": {
+			params:        map[string]interface{}{"sleeping": true},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "sleepPin is not set",
+		},
+		"error_speed_above_max_before_disable": {
+			opts:          allPins,
+			params:        map[string]interface{}{"speed": 100000, "enabled": false},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "cannot be greater then maximal value",
+		},
+		"error_position_while_disable": {
+			opts:          allPins,
+			params:        map[string]interface{}{"position": 2, "enabled": false, "speed": 20},
+			wantSpeed:     14,
+			wantDirection: "forward",
+			wantEnabled:   true,
+			wantErr:       "is disabled and can not be moved to position 2",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 0.5, "1", tc.opts...)
+			// act
+			got := d.Command("SetParams")(tc.params)
+			// assert
+			if tc.wantErr != "" {
+				err, ok := got.(error)
+				require.True(t, ok)
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				assert.Nil(t, got)
+			}
+			state := d.State()
+			assert.Equal(t, tc.wantSpeed, state.SpeedRpm)
+			assert.Equal(t, tc.wantDirection, state.Direction)
+			assert.Equal(t, tc.wantPosition, state.CurrentStep)
+			assert.Equal(t, tc.wantEnabled, state.Enabled)
+			assert.Equal(t, tc.wantSleeping, state.Sleeping)
+			assert.Equal(t, tc.wantWritten, a.written)
+		})
+	}
+}
+
+func TestEasySetIdleBehavior(t *testing.T) {
+	tests := map[string]struct {
+		opts      []interface{}
+		mode      string
+		delay     time.Duration
+		wantWrite *gpioTestWritten
+		wantState func(t *testing.T, d *EasyDriver)
+		wantErr   string
+	}{
+		"hold": {
+			opts:  []interface{}{WithEasyEnablePin("3"), WithEasySleepPin("4")},
+			mode:  EasyIdleHold,
+			delay: 5 * time.Millisecond,
+			wantState: func(t *testing.T, d *EasyDriver) {
+				assert.True(t, d.IsEnabled())
+				assert.False(t, d.IsSleeping())
+			},
+		},
+		"release": {
+			opts:      []interface{}{WithEasyEnablePin("3")},
+			mode:      EasyIdleRelease,
+			delay:     5 * time.Millisecond,
+			wantWrite: &gpioTestWritten{pin: "3", val: 1},
+			wantState: func(t *testing.T, d *EasyDriver) {
+				assert.False(t, d.IsEnabled())
+			},
+		},
+		"sleep": {
+			opts:      []interface{}{WithEasySleepPin("4")},
+			mode:      EasyIdleSleep,
+			delay:     5 * time.Millisecond,
+			wantWrite: &gpioTestWritten{pin: "4", val: 0},
+			wantState: func(t *testing.T, d *EasyDriver) {
+				assert.True(t, d.IsSleeping())
+			},
+		},
+		"error_release_without_enable_pin": {
+			mode:    EasyIdleRelease,
+			wantErr: "enPin is not set for 'EasyDriver', so idle behavior 'release' is not possible",
+		},
+		"error_sleep_without_sleep_pin": {
+			mode:    EasyIdleSleep,
+			wantErr: "sleepPin is not set for 'EasyDriver', so idle behavior 'sleep' is not possible",
+		},
+		"error_invalid_mode": {
+			mode:    "coast",
+			wantErr: "invalid idle behavior 'coast', value should be 'hold', 'release' or 'sleep'",
+		},
+		"error_negative_delay": {
+			opts:    []interface{}{WithEasyEnablePin("3")},
+			mode:    EasyIdleRelease,
+			delay:   -time.Millisecond,
+			wantErr: "idle delay (-1ms) must not be negative",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewEasyDriver(a, 0.5, "1", append(tc.opts, WithName("EasyDriver"))...)
+			// act
+			err := d.SetIdleBehavior(tc.mode, tc.delay)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				mode, delay := d.IdleBehavior()
+				assert.Equal(t, EasyIdleHold, mode)
+				assert.Equal(t, time.Duration(0), delay)
+				return
+			}
+			require.NoError(t, err)
+			// act: move and wait for the idle action
+			require.NoError(t, d.MoveDeg(2))
+			a.mtx.Lock()
+			a.written = nil
+			a.mtx.Unlock()
+			time.Sleep(10 * tc.delay)
+			// assert
+			a.mtx.Lock()
+			written := a.written
+			a.mtx.Unlock()
+			if tc.wantWrite != nil {
+				assert.Equal(t, []gpioTestWritten{*tc.wantWrite}, written)
+			} else {
+				assert.Empty(t, written)
+			}
+			d.mutex.Lock()
+			tc.wantState(t, d)
+			d.mutex.Unlock()
+		})
+	}
+}
+
+func TestEasySetIdleBehavior_nextMove(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 0.5, "1", WithEasyEnablePin("3"))
+	require.NoError(t, d.SetIdleBehavior(EasyIdleRelease, 5*time.Millisecond))
+	require.NoError(t, d.Move(1))
+	time.Sleep(50 * time.Millisecond)
+	d.mutex.Lock()
+	require.False(t, d.IsEnabled())
+	d.mutex.Unlock()
+	a.mtx.Lock()
+	a.written = nil
+	a.mtx.Unlock()
+	// act: the next move enables the released motor output
+	require.NoError(t, d.Move(1))
+	// assert
+	a.mtx.Lock()
+	assert.Equal(t, gpioTestWritten{pin: "3", val: 0}, a.written[0])
+	a.mtx.Unlock()
+	assert.Equal(t, 2, d.CurrentStep())
+	// act: a halt cancels the pending idle action
+	require.NoError(t, d.Halt())
+	time.Sleep(50 * time.Millisecond)
+	// assert
+	d.mutex.Lock()
+	assert.True(t, d.IsEnabled())
+	d.mutex.Unlock()
+}
+
+func TestEasySetIdleBehavior_userDisable(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 0.5, "1", WithEasyEnablePin("3"))
+	require.NoError(t, d.SetIdleBehavior(EasyIdleHold, 0))
+	require.NoError(t, d.Disable())
+	// act: a disabled driver is not enabled by the idle behavior
+	err := d.Move(1)
+	// assert
+	require.EqualError(t, err, "'"+d.Name()+"' is disabled and can not be running or moving")
+}
+
+func TestEasyGetValue(t *testing.T) {
+	tests := map[string]struct {
+		step      int
+		wantAngle float64
+	}{
+		"zero":             {step: 0, wantAngle: 0},
+		"within_range":     {step: 181, wantAngle: 90.5},
+		"one_revolution":   {step: 720, wantAngle: 0},
+		"two_revolutions":  {step: 1440 + 90, wantAngle: 45},
+		"below_step_zero":  {step: -180, wantAngle: 270},
+		"below_revolution": {step: -720 - 2, wantAngle: 359},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestEasyDriverWithStubbedAdaptor()
+			d.stepNum = tc.step
+			minVal, maxVal := d.ValueRange()
+			// act
+			got := d.GetValue()
+			// assert
+			assert.InDelta(t, tc.wantAngle, got, 1e-9)
+			assert.GreaterOrEqual(t, got, minVal)
+			assert.Less(t, got, maxVal)
+		})
+	}
+}
+
+func TestEasySetPositionSign(t *testing.T) {
+	tests := map[string]struct {
+		sign          int
+		wantAfterMove int
+		wantAngle     float64
+		wantErr       string
+	}{
+		"default": {
+			sign:          1,
+			wantAfterMove: 4,
+			wantAngle:     2,
+		},
+		"inverted": {
+			sign:          -1,
+			wantAfterMove: -4,
+			wantAngle:     358,
+		},
+		"error_invalid": {
+			sign:    2,
+			wantErr: "position sign (2) must be 1 or -1",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestEasyDriverWithStubbedAdaptor()
+			require.Equal(t, 1, d.PositionSign())
+			// act
+			err := d.SetPositionSign(tc.sign)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Equal(t, 1, d.PositionSign())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.sign, d.PositionSign())
+			// act & assert: the same motion leads to a flipped sign
+			require.NoError(t, d.MoveDeg(2))
+			assert.Equal(t, tc.wantAfterMove, d.CurrentStep())
+			assert.Equal(t, "forward", d.direction)
+			assert.InDelta(t, tc.wantAngle, d.GetValue(), 0.0)
+			// act & assert: absolute positions consider the sign convention
+			require.NoError(t, d.SetValue(1))
+			assert.Equal(t, 2, d.CurrentStep())
+			require.NoError(t, d.SetParams(map[string]interface{}{"position": -3}))
+			assert.Equal(t, -3, d.CurrentStep())
+		})
+	}
+}
+
+// gpioTestPinSetupAdaptor is a test adaptor, which supports the setup of all pins at once
+type gpioTestPinSetupAdaptor struct {
+	*gpioTestAdaptor
+	setups [][]gobot.PinConfig
+}
+
+func (a *gpioTestPinSetupAdaptor) SetupPins(pins []gobot.PinConfig) error {
+	a.setups = append(a.setups, pins)
+	return nil
+}
+
+func TestEasy_optionalPinNotSet(t *testing.T) {
+	tests := map[string]struct {
+		call    func(d *EasyDriver) error
+		wantErr error
+	}{
+		"set_direction": {
+			call:    func(d *EasyDriver) error { return d.SetDirection(StepperDriverBackward) },
+			wantErr: ErrDirPinNotSet,
+		},
+		"find_limits": {
+			call:    func(d *EasyDriver) error { return d.FindLimits("5", "6", 10) },
+			wantErr: ErrDirPinNotSet,
+		},
+		"enable": {
+			call:    func(d *EasyDriver) error { return d.Enable() },
+			wantErr: ErrEnablePinNotSet,
+		},
+		"disable": {
+			call:    func(d *EasyDriver) error { return d.Disable() },
+			wantErr: ErrEnablePinNotSet,
+		},
+		"idle_release": {
+			call:    func(d *EasyDriver) error { return d.SetIdleBehavior(EasyIdleRelease, 0) },
+			wantErr: ErrEnablePinNotSet,
+		},
+		"sleep": {
+			call:    func(d *EasyDriver) error { return d.Sleep() },
+			wantErr: ErrSleepPinNotSet,
+		},
+		"wake": {
+			call:    func(d *EasyDriver) error { return d.Wake() },
+			wantErr: ErrSleepPinNotSet,
+		},
+		"idle_sleep": {
+			call:    func(d *EasyDriver) error { return d.SetIdleBehavior(EasyIdleSleep, 0) },
+			wantErr: ErrSleepPinNotSet,
+		},
+		"set_params_sleeping": {
+			call:    func(d *EasyDriver) error { return d.SetParams(map[string]interface{}{"sleeping": true}) },
+			wantErr: ErrSleepPinNotSet,
+		},
+		"emergency_stop": {
+			call:    func(d *EasyDriver) error { return d.EmergencyStop() },
+			wantErr: ErrPinNotSet,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestEasyDriverWithStubbedAdaptor()
+			// act
+			err := tc.call(d)
+			// assert
+			require.ErrorIs(t, err, tc.wantErr)
+			require.ErrorIs(t, err, ErrPinNotSet)
+		})
+	}
+}
+
+func TestEasyStart_SetupPins(t *testing.T) {
+	tests := map[string]struct {
+		opts []interface{}
+		want []gobot.PinConfig
+	}{
+		"step_pin_only": {
+			want: []gobot.PinConfig{{Pin: "1", Output: true}},
+		},
+		"all_pins": {
+			opts: []interface{}{WithEasyDirectionPin("2"), WithEasyEnablePin("3"), WithEasySleepPin("4")},
+			want: []gobot.PinConfig{
+				{Pin: "1", Output: true},
+				{Pin: "2", Output: true},
+				{Pin: "3", Output: true},
+				{Pin: "4", Output: true, InitialValue: 1},
+			},
+		},
+		"inverted_step": {
+			opts: []interface{}{WithEasyInvertedStep()},
+			want: []gobot.PinConfig{{Pin: "1", Output: true, InitialValue: 1}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := &gpioTestPinSetupAdaptor{gpioTestAdaptor: newGpioTestAdaptor()}
+			d := NewEasyDriver(a, 0.5, "1", tc.opts...)
+			// act
+			err := d.Start()
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, [][]gobot.PinConfig{tc.want}, a.setups)
+			assert.Empty(t, a.written)
+		})
+	}
+}
+
+func TestEasyStart_withoutSetupPins(t *testing.T) {
+	// arrange
+	d, a := initTestEasyDriverWithStubbedAdaptor()
+	// act & assert: pins are configured on first usage
+	require.NoError(t, d.Start())
+	assert.Empty(t, a.written)
+}
+
+func TestEasyStart_twice(t *testing.T) {
+	// arrange
+	a := &gpioTestPinSetupAdaptor{gpioTestAdaptor: newGpioTestAdaptor()}
+	d := NewEasyDriver(a, 0.5, "1")
+	require.NoError(t, d.Start())
+	// act
+	err := d.Start()
+	// assert: the pins are configured only once
+	require.NoError(t, err)
+	assert.Len(t, a.setups, 1)
+}
+
+func TestEasyHalt_withoutStart(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	require.NoError(t, d.QueueMove(10))
+	// act
+	err := d.Halt()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 0, d.QueueLen())
+	require.NoError(t, d.Halt())
+	// act & assert: a restart is possible after halt
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Halt())
+}
+
+func TestEasyStart_missingDigitalWriter(t *testing.T) {
+	// arrange
+	d, _ := initTestEasyDriverWithStubbedAdaptor()
+	d.connection = &gpioTestBareAdaptor{}
+	// act
+	err := d.Start()
+	// assert
+	require.EqualError(t, err, "adaptor '' is missing the capabilities: DigitalWriter")
+}
+
+func TestEasySelfTest(t *testing.T) {
+	tests := map[string]struct {
+		steps       int
+		disable     bool
+		moving      bool
+		failOnWrite int
+		wantWrites  int
+		wantStep    int
+		wantErr     string
+	}{
+		"success": {
+			steps:      3,
+			wantWrites: 12,
+			wantStep:   7,
+		},
+		"error_write_forward": {
+			steps:       3,
+			failOnWrite: 2,
+			wantWrites:  2,
+			wantStep:    7,
+			wantErr:     "self test of 'EasyDriver' failed on forward move: write error",
+		},
+		"error_write_backward": {
+			steps:       3,
+			failOnWrite: 8,
+			wantWrites:  8,
+			wantStep:    10,
+			wantErr:     "self test of 'EasyDriver' failed on backward move: write error",
+		},
+		"error_disabled": {
+			steps:    3,
+			disable:  true,
+			wantStep: 7,
+			wantErr:  "'EasyDriver' is disabled, self test not possible",
+		},
+		"error_moving": {
+			steps:    3,
+			moving:   true,
+			wantStep: 7,
+			wantErr:  "'EasyDriver' is moving, self test not possible",
+		},
+		"error_no_steps": {
+			wantStep: 7,
+			wantErr:  "steps (0) for self test must be greater than zero",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestEasyDriverWithStubbedAdaptor()
+			d.driverCfg.name = "EasyDriver"
+			d.stepNum = 7
+			d.disabled = tc.disable
+			if tc.moving {
+				d.stopAsynchRunFunc = func(bool) error { return nil }
+			}
+			var writes int
+			failOnWrite := tc.failOnWrite
+			a.digitalWriteFunc = func(string, byte) error {
+				writes++
+				if writes == failOnWrite {
+					return fmt.Errorf("write error")
+				}
+				return nil
+			}
+			// act
+			err := d.SelfTest(tc.steps)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantWrites, writes)
+			assert.Equal(t, tc.wantStep, d.CurrentStep())
+			assert.Equal(t, StepperDriverForward, d.direction)
+		})
+	}
+}
+
+func TestEasySelfTest_restoresDirectionPin(t *testing.T) {
+	// arrange
+	const dirPin = "2"
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 0.5, "1", WithEasyDirectionPin(dirPin))
+	dirWrites := func() []byte {
+		a.mtx.Lock()
+		defer a.mtx.Unlock()
+		var vals []byte
+		for _, w := range a.written {
+			if w.pin == dirPin {
+				vals = append(vals, w.val)
+			}
+		}
+		a.written = nil
+		return vals
+	}
+	// act
+	require.NoError(t, d.SelfTest(2))
+	// assert: the direction pin is switched to backward and restored to forward
+	assert.Equal(t, []byte{1, 0}, dirWrites())
+	assert.Equal(t, StepperDriverForward, d.direction)
+	// act & assert: the next forward move needs no change of the direction pin
+	require.NoError(t, d.Move(1))
+	assert.Empty(t, dirWrites())
+	assert.Equal(t, 1, d.CurrentStep())
+}
+
+func TestEasySetTrace(t *testing.T) {
+	tests := map[string]struct {
+		trace     bool
+		steps     int
+		wantLines []string
+	}{
+		"trace_on": {
+			trace: true,
+			steps: -3,
+			wantLines: []string{
+				"step index=0 position=-1 direction=backward delay=",
+				"step index=1 position=-2 direction=backward delay=",
+				"step index=2 position=-3 direction=backward delay=",
+			},
+		},
+		"trace_off": {
+			steps: 3,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestEasyDriverWithStubbedAdaptor()
+			var buf bytes.Buffer
+			d.traceLogger = log.New(&buf, "", 0)
+			d.SetTrace(tc.trace)
+			// act
+			err := d.Move(tc.steps)
+			// assert
+			require.NoError(t, err)
+			var lines []string
+			if buf.Len() > 0 {
+				lines = strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			}
+			require.Len(t, lines, len(tc.wantLines))
+			for i, want := range tc.wantLines {
+				assert.Contains(t, lines[i], want)
+				assert.True(t, strings.HasPrefix(lines[i], "'"+d.Name()+"'"))
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+)
+
+// easyWatchdogChecksPerTimeout defines the check interval of the idle watchdog relative to the timeout
+const easyWatchdogChecksPerTimeout = 10
+
+// SetIdleWatchdog activates a watchdog, which de-energizes the motor, if no movement, step, enable or wake up was done
+// within the given timeout, e.g. because the program has stopped to issue commands. The motor output is disabled with
+// the enable pin or, if not available, the driver is put to sleep. Afterwards the EasyIdle event is published with
+// EasyIdleRelease or EasyIdleSleep as data. Like for SetIdleBehavior() the motor is enabled or woken up automatically
+// by the next move. The watchdog is active while the driver is started, an endless movement by Run() counts as
+// activity. Zero (default) deactivates the watchdog.
+func (d *EasyDriver) SetIdleWatchdog(timeout time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("idle watchdog timeout (%s) must not be negative", timeout)
+	}
+
+	if timeout > 0 && !d.HasEnablePin() && !d.HasSleepPin() {
+		return fmt.Errorf("neither enPin nor sleepPin is set for '%s', so the idle watchdog is not possible: %w",
+			d.driverCfg.name, ErrPinNotSet)
+	}
+
+	d.idleMutex.Lock()
+	d.watchdogTimeout = timeout
+	d.idleMutex.Unlock()
+
+	d.startIdleWatchdog()
+
+	return nil
+}
+
+// IdleWatchdog returns the timeout of the idle watchdog, see SetIdleWatchdog().
+func (d *EasyDriver) IdleWatchdog() time.Duration {
+	d.idleMutex.Lock()
+	defer d.idleMutex.Unlock()
+
+	return d.watchdogTimeout
+}
+
+// recordActivity restarts the timeout of the idle watchdog
+func (d *EasyDriver) recordActivity() {
+	d.idleMutex.Lock()
+	defer d.idleMutex.Unlock()
+
+	d.watchdogActivity = d.watchdogNow()
+}
+
+// startIdleWatchdog starts the cyclic check of the idle watchdog, if activated. A running check is stopped before and
+// the timeout starts again.
+func (d *EasyDriver) startIdleWatchdog() {
+	d.takeIdleWatchdog()
+
+	d.idleMutex.Lock()
+	defer d.idleMutex.Unlock()
+
+	d.watchdogActivity = d.watchdogNow()
+	if d.watchdogTimeout <= 0 {
+		return
+	}
+
+	interval := d.watchdogTimeout / easyWatchdogChecksPerTimeout
+	if interval <= 0 {
+		interval = d.watchdogTimeout
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	d.watchdogStop = stop
+	d.watchdogDone = done
+	ticks, stopTicker := d.watchdogNewTicker(interval)
+
+	go func() {
+		defer close(done)
+		defer stopTicker()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticks:
+				d.checkIdleWatchdog()
+			}
+		}
+	}()
+}
+
+// takeIdleWatchdog stops the cyclic check of the idle watchdog and waits until a running check is finished
+func (d *EasyDriver) takeIdleWatchdog() {
+	d.idleMutex.Lock()
+	stop := d.watchdogStop
+	done := d.watchdogDone
+	d.watchdogStop = nil
+	d.watchdogDone = nil
+	d.idleMutex.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+// checkIdleWatchdog de-energizes the motor and publishes the EasyIdle event, if the timeout has elapsed
+func (d *EasyDriver) checkIdleWatchdog() {
+	// a locked driver mutex means a running movement or a start or halt of the driver, so this check is skipped to
+	// prevent a dead lock while waiting for the end of the watchdog
+	if !d.mutex.TryLock() {
+		return
+	}
+	action := d.idleWatchdogAction()
+	d.mutex.Unlock()
+
+	if action != "" {
+		d.Publish(d.Event(EasyIdle), action)
+	}
+}
+
+// idleWatchdogAction de-energizes the motor, if the timeout has elapsed, and returns the done action. The driver mutex
+// needs to be locked by the caller.
+func (d *EasyDriver) idleWatchdogAction() string {
+	if d.state != driverStateStarted {
+		return ""
+	}
+
+	d.idleMutex.Lock()
+	defer d.idleMutex.Unlock()
+
+	if d.watchdogTimeout <= 0 {
+		return ""
+	}
+
+	if d.IsMoving() {
+		// endless movement
+		d.watchdogActivity = d.watchdogNow()
+		return ""
+	}
+
+	if !d.IsEnabled() || d.IsSleeping() {
+		// already de-energized
+		return ""
+	}
+
+	if d.watchdogNow().Sub(d.watchdogActivity) < d.watchdogTimeout {
+		return ""
+	}
+
+	action := EasyIdleSleep
+	var err error
+	if d.HasEnablePin() {
+		action = EasyIdleRelease
+		err = d.Disable()
+	} else {
+		err = d.sleepWithSleepPin()
+	}
+	if err != nil {
+		d.debug(fmt.Sprintf("idle watchdog action '%s' failed: %v", action, err))
+		return ""
+	}
+
+	d.idleAction = action
+
+	return action
+}
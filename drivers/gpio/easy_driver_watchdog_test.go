@@ -0,0 +1,258 @@
+package gpio
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// easyTestClock is a manual clock for the idle watchdog
+type easyTestClock struct {
+	now time.Time
+	mtx sync.Mutex
+}
+
+func (c *easyTestClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+func (c *easyTestClock) Add(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// initTestEasyDriverWithWatchdog creates a driver with a manual clock, whose watchdog checks are only done
+// synchronously on call of the returned tick function
+func initTestEasyDriverWithWatchdog(opts ...interface{}) (*EasyDriver, *gpioTestAdaptor, *easyTestClock, func()) {
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 0.5, "1", opts...)
+	clock := &easyTestClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	d.watchdogNow = clock.Now
+	d.watchdogNewTicker = func(time.Duration) (<-chan time.Time, func()) { return nil, func() {} }
+	return d, a, clock, d.checkIdleWatchdog
+}
+
+// waitForEasyIdle returns the data of the next EasyIdle event
+func waitForEasyIdle(t *testing.T, events chan *gobot.Event) interface{} {
+	t.Helper()
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case evt := <-events:
+			if evt.Name == EasyIdle {
+				return evt.Data
+			}
+		case <-timeout:
+			require.Fail(t, "missing idle event")
+			return nil
+		}
+	}
+}
+
+func TestEasySetIdleWatchdog(t *testing.T) {
+	tests := map[string]struct {
+		opts    []interface{}
+		timeout time.Duration
+		wantErr string
+	}{
+		"enable_pin": {
+			opts:    []interface{}{WithEasyEnablePin("3")},
+			timeout: time.Second,
+		},
+		"sleep_pin": {
+			opts:    []interface{}{WithEasySleepPin("4")},
+			timeout: time.Second,
+		},
+		"deactivate_without_pins": {
+			timeout: 0,
+		},
+		"error_negative": {
+			opts:    []interface{}{WithEasyEnablePin("3")},
+			timeout: -time.Second,
+			wantErr: "idle watchdog timeout (-1s) must not be negative",
+		},
+		"error_no_pins": {
+			timeout: time.Second,
+			wantErr: "neither enPin nor sleepPin is set for 'EasyDriver', so the idle watchdog is not possible: " +
+				"pin is not set",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewEasyDriver(newGpioTestAdaptor(), 0.5, "1", append(tc.opts, WithName("EasyDriver"))...)
+			defer func() { _ = d.Halt() }()
+			// act
+			err := d.SetIdleWatchdog(tc.timeout)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Equal(t, time.Duration(0), d.IdleWatchdog())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.timeout, d.IdleWatchdog())
+			assert.Equal(t, tc.timeout > 0, d.watchdogStop != nil)
+		})
+	}
+}
+
+func TestEasyIdleWatchdog_release(t *testing.T) {
+	// arrange
+	d, a, clock, tick := initTestEasyDriverWithWatchdog(WithEasyEnablePin("3"))
+	require.NoError(t, d.SetIdleWatchdog(100*time.Millisecond))
+	require.NoError(t, d.Start())
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	// act & assert: not fired before the timeout
+	clock.Add(99 * time.Millisecond)
+	tick()
+	assert.True(t, d.IsEnabled())
+	// act & assert: fired after the timeout
+	clock.Add(time.Millisecond)
+	tick()
+	assert.Equal(t, EasyIdleRelease, waitForEasyIdle(t, events))
+	assert.False(t, d.IsEnabled())
+	a.mtx.Lock()
+	assert.Equal(t, gpioTestWritten{pin: "3", val: 1}, a.written[len(a.written)-1])
+	a.mtx.Unlock()
+	// act & assert: not fired again while released
+	clock.Add(time.Second)
+	tick()
+	// act & assert: the next move enables the motor output again and restarts the timeout
+	require.NoError(t, d.Move(1))
+	assert.True(t, d.IsEnabled())
+	assert.Equal(t, 1, d.CurrentStep())
+	clock.Add(99 * time.Millisecond)
+	tick()
+	assert.True(t, d.IsEnabled())
+	clock.Add(time.Millisecond)
+	tick()
+	assert.Equal(t, EasyIdleRelease, waitForEasyIdle(t, events))
+	assert.False(t, d.IsEnabled())
+	// act & assert: the halt stops the watchdog
+	require.NoError(t, d.Halt())
+	assert.Nil(t, d.watchdogStop)
+}
+
+func TestEasyIdleWatchdog_sleep(t *testing.T) {
+	// arrange
+	d, _, clock, tick := initTestEasyDriverWithWatchdog(WithEasySleepPin("4"))
+	require.NoError(t, d.SetIdleWatchdog(100*time.Millisecond))
+	require.NoError(t, d.Start())
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	// act
+	clock.Add(100 * time.Millisecond)
+	tick()
+	// assert
+	assert.Equal(t, EasyIdleSleep, waitForEasyIdle(t, events))
+	assert.True(t, d.IsSleeping())
+	// act & assert: the next single step wakes up the driver
+	require.NoError(t, d.StepOnce(StepperDriverForward))
+	assert.False(t, d.IsSleeping())
+	require.NoError(t, d.Halt())
+}
+
+func TestEasyIdleWatchdog_activityResets(t *testing.T) {
+	tests := map[string]struct {
+		activity func(d *EasyDriver) error
+	}{
+		"move": {
+			activity: func(d *EasyDriver) error { return d.Move(2) },
+		},
+		"step_once": {
+			activity: func(d *EasyDriver) error { return d.StepOnce(StepperDriverForward) },
+		},
+		"enable": {
+			activity: func(d *EasyDriver) error { return d.Enable() },
+		},
+		"wake": {
+			activity: func(d *EasyDriver) error { return d.Wake() },
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, clock, tick := initTestEasyDriverWithWatchdog(WithEasyEnablePin("3"), WithEasySleepPin("4"))
+			require.NoError(t, d.SetIdleWatchdog(100*time.Millisecond))
+			require.NoError(t, d.Start())
+			defer func() { _ = d.Halt() }()
+			clock.Add(60 * time.Millisecond)
+			// act
+			require.NoError(t, tc.activity(d))
+			// assert
+			clock.Add(60 * time.Millisecond)
+			tick()
+			assert.True(t, d.IsEnabled())
+			clock.Add(40 * time.Millisecond)
+			tick()
+			assert.False(t, d.IsEnabled())
+			assert.False(t, d.IsSleeping())
+		})
+	}
+}
+
+func TestEasyIdleWatchdog_run(t *testing.T) {
+	// arrange
+	d, _, clock, tick := initTestEasyDriverWithWatchdog(WithEasyEnablePin("3"))
+	require.NoError(t, d.SetIdleWatchdog(100*time.Millisecond))
+	require.NoError(t, d.Start())
+	defer func() { _ = d.Halt() }()
+	require.NoError(t, d.Run())
+	// act & assert: an endless movement is activity
+	clock.Add(time.Second)
+	tick()
+	assert.True(t, d.IsEnabled())
+	assert.True(t, d.IsMoving())
+	// act & assert: the timeout starts with the last check while running
+	require.NoError(t, d.Stop())
+	clock.Add(99 * time.Millisecond)
+	tick()
+	assert.True(t, d.IsEnabled())
+	clock.Add(time.Millisecond)
+	tick()
+	assert.False(t, d.IsEnabled())
+}
+
+func TestEasyIdleWatchdog_notStarted(t *testing.T) {
+	// arrange
+	d, _, clock, tick := initTestEasyDriverWithWatchdog(WithEasyEnablePin("3"))
+	require.NoError(t, d.SetIdleWatchdog(100*time.Millisecond))
+	defer func() { _ = d.Halt() }()
+	// act
+	clock.Add(time.Second)
+	tick()
+	// assert
+	assert.True(t, d.IsEnabled())
+	// act & assert: the timeout starts with the start of the driver
+	require.NoError(t, d.Start())
+	clock.Add(99 * time.Millisecond)
+	tick()
+	assert.True(t, d.IsEnabled())
+}
+
+func TestEasyIdleWatchdog_ticker(t *testing.T) {
+	// arrange
+	d := NewEasyDriver(newGpioTestAdaptor(), 0.5, "1", WithEasyEnablePin("3"))
+	require.NoError(t, d.SetIdleWatchdog(20*time.Millisecond))
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	start := time.Now()
+	// act
+	require.NoError(t, d.Start())
+	// assert
+	assert.Equal(t, EasyIdleRelease, waitForEasyIdle(t, events))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	assert.False(t, d.IsEnabled())
+	require.NoError(t, d.Halt())
+}
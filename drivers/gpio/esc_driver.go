@@ -0,0 +1,234 @@
+package gpio
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const escDefaultArmDuration = 2 * time.Second
+
+// escOptionApplier needs to be implemented by each configurable option type
+type escOptionApplier interface {
+	apply(cfg *escConfiguration)
+}
+
+// escConfiguration contains all changeable attributes of the driver.
+type escConfiguration struct {
+	armDuration time.Duration
+	minAngle    byte
+	maxAngle    byte
+	curve       func(throttle float64) float64
+}
+
+// escArmDurationOption is the type for applying another duration of the arming sequence to the configuration
+type escArmDurationOption time.Duration
+
+// escRangeOption is the type for applying another servo range to the configuration
+type escRangeOption struct {
+	minAngle byte
+	maxAngle byte
+}
+
+// escThrottleCurveOption is the type for applying another throttle curve to the configuration
+type escThrottleCurveOption func(throttle float64) float64
+
+// ESCDriver represents an electronic speed controller (ESC) for brushless motors, e.g. of drones or rovers. The ESC
+// is controlled like a RC servo, where the minimum angle is the minimum throttle.
+//
+// The ESC needs to be armed before use by holding the minimum throttle for a duration. This prevents accidental
+// spin-up of the motor. The throttle is mapped by a configurable curve, e.g. to make the control more linear.
+type ESCDriver struct {
+	*driver
+	escCfg   *escConfiguration
+	armed    bool
+	throttle float64
+}
+
+// NewESCDriver returns a new driver for an ESC given a ServoWriter and pin.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithESCArmDuration"
+//	"WithESCRange"
+//	"WithESCThrottleCurve"
+//
+// Adds the following API Commands:
+//
+//	"Arm" - See ESCDriver.Arm
+//	"Disarm" - See ESCDriver.Disarm
+//	"Throttle" - See ESCDriver.Throttle, needs the parameter "throttle"
+func NewESCDriver(a ServoWriter, pin string, opts ...interface{}) *ESCDriver {
+	//nolint:forcetypeassert // no error return value, so there is no better way
+	d := &ESCDriver{
+		driver: newDriver(a.(gobot.Connection), "ESC", withPin(pin)),
+		escCfg: &escConfiguration{
+			armDuration: escDefaultArmDuration,
+			minAngle:    0,
+			maxAngle:    180,
+			curve:       func(throttle float64) float64 { return throttle },
+		},
+	}
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case escOptionApplier:
+			o.apply(d.escCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddCommand("Arm", func(params map[string]interface{}) interface{} {
+		return d.Arm()
+	})
+	d.AddCommand("Disarm", func(params map[string]interface{}) interface{} {
+		return d.Disarm()
+	})
+	d.AddCommand("Throttle", func(params map[string]interface{}) interface{} {
+		throttle, _ := params["throttle"].(float64)
+		return d.Throttle(throttle)
+	})
+
+	return d
+}
+
+// WithESCArmDuration change the duration of holding the minimum throttle for arming from default 2s to the given
+// value.
+func WithESCArmDuration(duration time.Duration) escOptionApplier {
+	return escArmDurationOption(duration)
+}
+
+// WithESCRange change the servo angles for minimum and maximum throttle from default 0..180 to the given values.
+func WithESCRange(minAngle, maxAngle byte) escOptionApplier {
+	return escRangeOption{minAngle: minAngle, maxAngle: maxAngle}
+}
+
+// WithESCThrottleCurve substitute the default linear throttle curve by the given function. The function maps the
+// throttle in range 0..1 to an output in range 0..1, see also [gpio.ESCExpoCurve].
+func WithESCThrottleCurve(curve func(throttle float64) float64) escOptionApplier {
+	return escThrottleCurveOption(curve)
+}
+
+// ESCExpoCurve creates a throttle curve with the given exponential part in range 0..1. Zero results in a linear curve,
+// higher values gives a finer control for small throttle values. The formula is "(1-expo)*x + expo*x^3".
+func ESCExpoCurve(expo float64) func(throttle float64) float64 {
+	expo = math.Max(0, math.Min(1, expo))
+	return func(throttle float64) float64 {
+		return (1-expo)*throttle + expo*throttle*throttle*throttle
+	}
+}
+
+// Arm runs the arming sequence by writing the minimum throttle and holding it for the configured duration. The call
+// blocks until the sequence is finished.
+func (d *ESCDriver) Arm() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.servoWrite(d.driverCfg.pin, d.escCfg.minAngle); err != nil {
+		return err
+	}
+	time.Sleep(d.escCfg.armDuration)
+
+	d.armed = true
+	d.throttle = 0
+	return nil
+}
+
+// Disarm writes the minimum throttle and prevents further throttle changes until the next arming.
+func (d *ESCDriver) Disarm() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.disarm()
+}
+
+// IsArmed returns true, if the arming sequence was finished and the ESC was not disarmed afterwards.
+func (d *ESCDriver) IsArmed() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.armed
+}
+
+// Throttle sets the throttle in range 0..1, mapped by the throttle curve. The ESC needs to be armed before.
+func (d *ESCDriver) Throttle(throttle float64) error {
+	if throttle < 0 || throttle > 1 {
+		return fmt.Errorf("throttle (%v) must be between 0 and 1", throttle)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !d.armed {
+		return fmt.Errorf("'%s' is not armed", d.driverCfg.name)
+	}
+
+	if err := d.servoWrite(d.driverCfg.pin, d.throttleToAngle(throttle)); err != nil {
+		return err
+	}
+
+	d.throttle = throttle
+	return nil
+}
+
+// CurrentThrottle returns the last throttle, before mapping by the curve.
+func (d *ESCDriver) CurrentThrottle() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.throttle
+}
+
+// shutdown disarms the ESC, if armed
+func (d *ESCDriver) shutdown() error {
+	if !d.armed {
+		return nil
+	}
+
+	return d.disarm()
+}
+
+func (d *ESCDriver) disarm() error {
+	d.armed = false
+	d.throttle = 0
+	return d.servoWrite(d.driverCfg.pin, d.escCfg.minAngle)
+}
+
+// throttleToAngle maps the throttle by the curve to the servo range
+func (d *ESCDriver) throttleToAngle(throttle float64) byte {
+	out := math.Max(0, math.Min(1, d.escCfg.curve(throttle)))
+	span := float64(d.escCfg.maxAngle) - float64(d.escCfg.minAngle)
+	return byte(math.Round(float64(d.escCfg.minAngle) + out*span))
+}
+
+func (o escArmDurationOption) String() string {
+	return "arm duration option for ESC"
+}
+
+func (o escRangeOption) String() string {
+	return "range option for ESC"
+}
+
+func (o escThrottleCurveOption) String() string {
+	return "throttle curve option for ESC"
+}
+
+func (o escArmDurationOption) apply(cfg *escConfiguration) {
+	cfg.armDuration = time.Duration(o)
+}
+
+func (o escRangeOption) apply(cfg *escConfiguration) {
+	cfg.minAngle = o.minAngle
+	cfg.maxAngle = o.maxAngle
+}
+
+func (o escThrottleCurveOption) apply(cfg *escConfiguration) {
+	cfg.curve = o
+}
@@ -0,0 +1,169 @@
+package gpio
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+)
+
+var _ gobot.Driver = (*ESCDriver)(nil)
+
+func TestNewESCDriver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewESCDriver(a, "9")
+	// assert
+	assert.IsType(t, &ESCDriver{}, d)
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.Name(), "ESC"))
+	assert.Equal(t, "9", d.Pin())
+	require.NotNil(t, d.escCfg)
+	assert.Equal(t, escDefaultArmDuration, d.escCfg.armDuration)
+	assert.Equal(t, byte(0), d.escCfg.minAngle)
+	assert.Equal(t, byte(180), d.escCfg.maxAngle)
+	assert.InDelta(t, 0.3, d.escCfg.curve(0.3), 0.0)
+	assert.False(t, d.IsArmed())
+	assert.NotNil(t, d.Command("Arm"))
+	assert.NotNil(t, d.Command("Disarm"))
+	assert.NotNil(t, d.Command("Throttle"))
+}
+
+func TestNewESCDriver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const myName = "front left"
+	panicFunc := func() {
+		NewESCDriver(newGpioTestAdaptor(), "1", WithName("crazy"), aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewESCDriver(newGpioTestAdaptor(), "1", WithName(myName), WithESCArmDuration(time.Second),
+		WithESCRange(10, 170), WithESCThrottleCurve(ESCExpoCurve(1)))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, time.Second, d.escCfg.armDuration)
+	assert.Equal(t, byte(10), d.escCfg.minAngle)
+	assert.Equal(t, byte(170), d.escCfg.maxAngle)
+	assert.InDelta(t, 0.125, d.escCfg.curve(0.5), 0.0)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestESCArm(t *testing.T) {
+	// arrange
+	const armDuration = 20 * time.Millisecond
+	a := newGpioTestAdaptor()
+	var written []byte
+	var writeTimes []time.Time
+	a.servoWriteFunc = func(pin string, val byte) error {
+		written = append(written, val)
+		writeTimes = append(writeTimes, time.Now())
+		return nil
+	}
+	d := NewESCDriver(a, "1", WithESCArmDuration(armDuration), WithESCRange(20, 160))
+	// act & assert: throttle before arming is not possible
+	require.EqualError(t, d.Throttle(0.5), "'"+d.Name()+"' is not armed")
+	assert.Empty(t, written)
+	// act: arm
+	start := time.Now()
+	err := d.Arm()
+	// assert: the minimum throttle is hold for the arming duration
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), armDuration)
+	assert.True(t, d.IsArmed())
+	assert.Equal(t, []byte{20}, written)
+	// act: throttle after arming
+	require.NoError(t, d.Throttle(1))
+	assert.Equal(t, []byte{20, 160}, written)
+	assert.GreaterOrEqual(t, writeTimes[1].Sub(writeTimes[0]), armDuration)
+	assert.InDelta(t, 1.0, d.CurrentThrottle(), 0.0)
+	// act: disarm by halt
+	require.NoError(t, d.Halt())
+	assert.False(t, d.IsArmed())
+	assert.Equal(t, []byte{20, 160, 20}, written)
+	assert.InDelta(t, 0.0, d.CurrentThrottle(), 0.0)
+	require.EqualError(t, d.Throttle(0.5), "'"+d.Name()+"' is not armed")
+}
+
+func TestESCThrottle(t *testing.T) {
+	tests := map[string]struct {
+		curve     func(float64) float64
+		throttle  float64
+		wantAngle byte
+		wantErr   string
+	}{
+		"linear_zero": {
+			throttle:  0,
+			wantAngle: 0,
+		},
+		"linear_quarter": {
+			throttle:  0.25,
+			wantAngle: 45,
+		},
+		"linear_full": {
+			throttle:  1,
+			wantAngle: 180,
+		},
+		"expo_quarter": {
+			curve:     ESCExpoCurve(0.5),
+			throttle:  0.25, // 0.5*0.25 + 0.5*0.015625 = 0.1328125
+			wantAngle: 24,
+		},
+		"expo_half": {
+			curve:     ESCExpoCurve(0.5),
+			throttle:  0.5, // 0.5*0.5 + 0.5*0.125 = 0.3125
+			wantAngle: 56,
+		},
+		"expo_full": {
+			curve:     ESCExpoCurve(0.5),
+			throttle:  1,
+			wantAngle: 180,
+		},
+		"curve_clamped": {
+			curve:     func(float64) float64 { return 2 },
+			throttle:  0.5,
+			wantAngle: 180,
+		},
+		"error_below_range": {
+			throttle: -0.1,
+			wantErr:  "throttle (-0.1) must be between 0 and 1",
+		},
+		"error_above_range": {
+			throttle: 1.1,
+			wantErr:  "throttle (1.1) must be between 0 and 1",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			opts := []interface{}{WithESCArmDuration(0)}
+			if tc.curve != nil {
+				opts = append(opts, WithESCThrottleCurve(tc.curve))
+			}
+			d := NewESCDriver(a, "1", opts...)
+			require.NoError(t, d.Arm())
+			var gotAngle byte
+			a.servoWriteFunc = func(pin string, val byte) error {
+				gotAngle = val
+				return nil
+			}
+			// act
+			err := d.Throttle(tc.throttle)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantAngle, gotAngle)
+		})
+	}
+}
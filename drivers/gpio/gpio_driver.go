@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 
 	"gobot.io/x/gobot/v2"
@@ -22,8 +23,47 @@ var (
 	// ErrDigitalReadUnsupported is the error resulting when a driver attempts to use
 	// hardware capabilities which a connection does not support
 	ErrDigitalReadUnsupported = errors.New("DigitalRead is not supported by this platform")
+	// ErrDigitalReadOutputUnsupported is the error resulting when a driver attempts to use
+	// hardware capabilities which a connection does not support
+	ErrDigitalReadOutputUnsupported = errors.New("DigitalReadOutput is not supported by this platform")
+	// ErrPwmPolarityUnsupported is the error resulting when a driver attempts to use
+	// hardware capabilities which a connection does not support
+	ErrPwmPolarityUnsupported = errors.New("SetPWMPolarity is not supported by this platform")
+	// ErrPwmCenterAlignedUnsupported is the error resulting when a driver attempts to use
+	// hardware capabilities which a connection does not support
+	ErrPwmCenterAlignedUnsupported = errors.New("SetPWMCenterAligned is not supported by this platform")
+
+	// ErrPinNotSet is the error resulting when an operation needs an optional pin, which is not configured for the
+	// driver. It matches all errors of the more specific "...NotSet" errors by errors.Is().
+	ErrPinNotSet = errors.New("pin is not set")
+	// ErrDirPinNotSet is the error resulting when an operation needs the direction pin of a driver
+	ErrDirPinNotSet error = pinNotSetError("dirPin")
+	// ErrEnablePinNotSet is the error resulting when an operation needs the enable pin of a driver
+	ErrEnablePinNotSet error = pinNotSetError("enPin")
+	// ErrSleepPinNotSet is the error resulting when an operation needs the sleep pin of a driver
+	ErrSleepPinNotSet error = pinNotSetError("sleepPin")
+
+	// ErrWriteVerificationFailed is the error resulting when a pin, which is read back after a digital write, does not
+	// report the written level, see [gpio.WithVerifiedWrite]
+	ErrWriteVerificationFailed = errors.New("write verification failed")
+
+	// ErrStepsPerRevNotSet is the error resulting when the speed of a stepper should be calculated, but the steps per
+	// revolution are zero
+	ErrStepsPerRevNotSet = errors.New("steps per revolution is not set")
 )
 
+// pinNotSetError is the type of the errors for a specific optional pin, the value is the name of the pin
+type pinNotSetError string
+
+func (e pinNotSetError) Error() string {
+	return string(e) + " is not set"
+}
+
+// Is reports whether the target is ErrPinNotSet, so each specific error matches the generic one.
+func (e pinNotSetError) Is(target error) bool {
+	return target == ErrPinNotSet //nolint:errorlint // comparison of the sentinel is intended
+}
+
 const (
 	// Error event
 	Error = "error"
@@ -35,6 +75,24 @@ const (
 	MotionDetected = "motion-detected"
 	// MotionStopped event
 	MotionStopped = "motion-stopped"
+	// RotarySelectorPosition event
+	RotarySelectorPosition = "position"
+	// EasyQueueDrained event
+	EasyQueueDrained = "queue-drained"
+	// EasyLostSteps event
+	EasyLostSteps = "lost-steps"
+	// EasyWaypointReached event
+	EasyWaypointReached = "waypoint-reached"
+	// EasyPathDone event
+	EasyPathDone = "path-done"
+	// EasyMoveProgress event
+	EasyMoveProgress = "progress"
+	// EasyPosition event
+	EasyPosition = "position"
+	// EasyIdle event
+	EasyIdle = "idle"
+	// PanTiltPosition event
+	PanTiltPosition = "position"
 )
 
 // PwmWriter interface represents an Adaptor which has Pwm capabilities
@@ -47,6 +105,18 @@ type ServoWriter interface {
 	ServoWrite(pin string, val byte) error
 }
 
+// PwmPolaritySetter interface represents an Adaptor which can invert the polarity of a PWM output. This is optional
+// for adaptors and needed e.g. for LEDs connected to the supply voltage or for some motor H-bridges.
+type PwmPolaritySetter interface {
+	SetPWMPolarity(pin string, normal bool) error
+}
+
+// PwmCenterAligner interface represents an Adaptor which can switch a PWM output between edge-aligned (default) and
+// center-aligned mode. This is optional for adaptors and improves the symmetry of motor currents.
+type PwmCenterAligner interface {
+	SetPWMCenterAligned(pin string, centerAligned bool) error
+}
+
 // DigitalWriter interface represents an Adaptor which has DigitalWrite capabilities
 type DigitalWriter interface {
 	DigitalWrite(pin string, val byte) error
@@ -57,6 +127,33 @@ type DigitalReader interface {
 	DigitalRead(pin string) (val int, err error)
 }
 
+// DigitalOutputReader interface represents an Adaptor which can read back the level of an output pin, without
+// changing its direction. This is optional for adaptors and needed for [gpio.WithVerifiedWrite].
+type DigitalOutputReader interface {
+	DigitalReadOutput(pin string) (val int, err error)
+}
+
+// Capability is the name of an interface, which can be required from the adaptor by a driver, see
+// [gpio.RequireCapabilities].
+type Capability string
+
+// Capabilities of an adaptor, which are needed by gpio drivers
+const (
+	DigitalWriterCapability Capability = "DigitalWriter"
+	DigitalReaderCapability Capability = "DigitalReader"
+	PwmWriterCapability     Capability = "PwmWriter"
+	ServoWriterCapability   Capability = "ServoWriter"
+
+	PwmPolaritySetterCapability Capability = "PwmPolaritySetter"
+	PwmCenterAlignerCapability  Capability = "PwmCenterAligner"
+)
+
+// PinSetupper interface represents an Adaptor which can configure several pins at once. This is optional for adaptors
+// and prevents glitches, which can be caused by the lazy configuration of each pin at first usage.
+type PinSetupper interface {
+	SetupPins(pins []gobot.PinConfig) error
+}
+
 // optionApplier needs to be implemented by each configurable option type
 type optionApplier interface {
 	apply(cfg *configuration)
@@ -66,6 +163,9 @@ type optionApplier interface {
 type configuration struct {
 	name string
 	pin  string
+
+	verifyWrite     bool     // read back each digital write
+	verifyWritePins []string // restricts the verification to the given pins, if not empty
 }
 
 // nameOption is the type for applying another name to the configuration
@@ -74,6 +174,18 @@ type nameOption string
 // pinOption is the type for applying a pin to the configuration
 type pinOption string
 
+// verifyWriteOption is the type for activating the verification of digital writes, the value is the list of pins
+type verifyWriteOption []string
+
+// driverState is used to ensure well-defined results for repeated calls of Start() and Halt()
+type driverState int
+
+const (
+	driverStateInitial driverState = iota // not started yet or the start has failed
+	driverStateStarted
+	driverStateHalted
+)
+
 // Driver implements the interface gobot.Driver.
 type driver struct {
 	driverCfg  *configuration
@@ -81,7 +193,12 @@ type driver struct {
 	afterStart func() error
 	beforeHalt func() error
 	gobot.Commander
+	gobot.Labeler
 	mutex *sync.Mutex // mutex often needed to ensure that write-read sequences are not interrupted
+	state driverState
+
+	lastErrMutex *sync.Mutex // separate mutex, so the last error can be read while the driver mutex is locked
+	lastErr      error
 }
 
 // newDriver creates a new generic and basic gpio gobot driver.
@@ -90,6 +207,7 @@ type driver struct {
 //
 //	"WithName"
 //	"withPin"
+//	"WithVerifiedWrite"
 func newDriver(a gobot.Adaptor, name string, opts ...interface{}) *driver {
 	d := &driver{
 		driverCfg:  &configuration{name: gobot.DefaultName(name)},
@@ -97,7 +215,10 @@ func newDriver(a gobot.Adaptor, name string, opts ...interface{}) *driver {
 		afterStart: func() error { return nil },
 		beforeHalt: func() error { return nil },
 		Commander:  gobot.NewCommander(),
+		Labeler:    gobot.NewLabeler(),
 		mutex:      &sync.Mutex{},
+
+		lastErrMutex: &sync.Mutex{},
 	}
 
 	for _, opt := range opts {
@@ -123,6 +244,14 @@ func withPin(pin string) optionApplier {
 	return pinOption(pin)
 }
 
+// WithVerifiedWrite is used to read back the pin after each digital write of the driver. If the read level differs
+// from the written one, ErrWriteVerificationFailed is returned, e.g. on a broken trace or a stuck output. Without
+// arguments all pins of the driver are verified, otherwise only the given ones. The adaptor needs to implement the
+// DigitalOutputReader interface, otherwise the start of the driver fails.
+func WithVerifiedWrite(pins ...string) optionApplier {
+	return verifyWriteOption(pins)
+}
+
 // Name returns the name of the gpio device.
 func (d *driver) Name() string {
 	return d.driverCfg.name
@@ -149,60 +278,199 @@ func (d *driver) Connection() gobot.Connection {
 	return nil
 }
 
-// Start initializes the gpio device.
+// Start initializes the gpio device. Calling Start on an already started device does nothing. If the initialization
+// fails, the device is not started, so the call can be repeated.
 func (d *driver) Start() error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	// currently there is nothing to do here for the driver
+	if d.state == driverStateStarted {
+		return nil
+	}
+
+	if d.driverCfg.verifyWrite {
+		if _, ok := d.connection.(DigitalOutputReader); !ok {
+			return fmt.Errorf("%w, so the writes of '%s' can not be verified", ErrDigitalReadOutputUnsupported,
+				d.driverCfg.name)
+		}
+	}
+
+	if err := d.afterStart(); err != nil {
+		return err
+	}
+
+	d.state = driverStateStarted
 
-	return d.afterStart()
+	return nil
 }
 
-// Halt halts the gpio device.
+// Halt halts the gpio device. This is also done for a device, which was never started or whose start has failed,
+// e.g. to stop a motor. Calling Halt on an already halted device does nothing, until it is started again. The device
+// is treated as halted, also if an error is returned.
 func (d *driver) Halt() error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	// currently there is nothing to do after halt for the driver
+	if d.state == driverStateHalted {
+		return nil
+	}
+
+	d.state = driverStateHalted
 
 	return d.beforeHalt()
 }
 
+// LastError returns the error of the most recent read or write access to the connection, also if done by a background
+// routine of the driver. It is nil, if the most recent access was successful.
+func (d *driver) LastError() error {
+	d.lastErrMutex.Lock()
+	defer d.lastErrMutex.Unlock()
+
+	return d.lastErr
+}
+
+// RequireCapabilities checks whether the adaptor implements the interfaces of all given capabilities. This is
+// normally called on start of a driver, so a missing capability leads to an early and clear error instead of an
+// error on first usage. All missing capabilities are listed in the returned error.
+func RequireCapabilities(a gobot.Adaptor, caps ...Capability) error {
+	if a == nil {
+		return fmt.Errorf("no adaptor given to check the capabilities")
+	}
+
+	var missing []string
+	for _, c := range caps {
+		if !hasCapability(a, c) {
+			missing = append(missing, string(c))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("adaptor '%s' is missing the capabilities: %s", a.Name(), strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+func hasCapability(a gobot.Adaptor, c Capability) bool {
+	var ok bool
+	switch c {
+	case DigitalWriterCapability:
+		_, ok = a.(DigitalWriter)
+	case DigitalReaderCapability:
+		_, ok = a.(DigitalReader)
+	case PwmWriterCapability:
+		_, ok = a.(PwmWriter)
+	case ServoWriterCapability:
+		_, ok = a.(ServoWriter)
+	case PwmPolaritySetterCapability:
+		_, ok = a.(PwmPolaritySetter)
+	case PwmCenterAlignerCapability:
+		_, ok = a.(PwmCenterAligner)
+	}
+
+	return ok
+}
+
 // digitalRead is a helper function with check that the connection implements DigitalReader
 func (d *driver) digitalRead(pin string) (int, error) {
 	if reader, ok := d.connection.(DigitalReader); ok {
-		return reader.DigitalRead(pin)
+		val, err := reader.DigitalRead(pin)
+		return val, d.recordError(err)
 	}
 
-	return 0, ErrDigitalReadUnsupported
+	return 0, d.recordError(ErrDigitalReadUnsupported)
 }
 
 // digitalWrite is a helper function with check that the connection implements DigitalWriter
 func (d *driver) digitalWrite(pin string, val byte) error {
-	if writer, ok := d.connection.(DigitalWriter); ok {
-		return writer.DigitalWrite(pin, val)
+	writer, ok := d.connection.(DigitalWriter)
+	if !ok {
+		return d.recordError(ErrDigitalWriteUnsupported)
+	}
+
+	if err := writer.DigitalWrite(pin, val); err != nil || !d.driverCfg.isWriteVerified(pin) {
+		return d.recordError(err)
 	}
 
-	return ErrDigitalWriteUnsupported
+	return d.recordError(d.verifyDigitalWrite(pin, val))
+}
+
+// verifyDigitalWrite reads back the pin and compares the level with the written value
+func (d *driver) verifyDigitalWrite(pin string, val byte) error {
+	reader, ok := d.connection.(DigitalOutputReader)
+	if !ok {
+		return fmt.Errorf("%w, so pin '%s' of '%s' can not be verified", ErrDigitalReadOutputUnsupported, pin,
+			d.driverCfg.name)
+	}
+
+	got, err := reader.DigitalReadOutput(pin)
+	if err != nil {
+		return err
+	}
+
+	if (got != 0) != (val != 0) {
+		return fmt.Errorf("%w: pin '%s' of '%s' reads %d after writing %d", ErrWriteVerificationFailed, pin,
+			d.driverCfg.name, got, val)
+	}
+
+	return nil
+}
+
+// setupPins is a helper function, which configures all given pins at once, if the connection implements PinSetupper.
+// Otherwise the pins are configured on first usage, so nothing to do.
+func (d *driver) setupPins(pins ...gobot.PinConfig) error {
+	if setupper, ok := d.connection.(PinSetupper); ok {
+		return setupper.SetupPins(pins)
+	}
+
+	return nil
 }
 
 // pwmWrite is a helper function with check that the connection implements PwmWriter
 func (d *driver) pwmWrite(pin string, level byte) error {
 	if writer, ok := d.connection.(PwmWriter); ok {
-		return writer.PwmWrite(pin, level)
+		return d.recordError(writer.PwmWrite(pin, level))
 	}
 
-	return ErrPwmWriteUnsupported
+	return d.recordError(ErrPwmWriteUnsupported)
+}
+
+// setPWMPolarity is a helper function with check that the connection implements PwmPolaritySetter
+func (d *driver) setPWMPolarity(pin string, normal bool) error {
+	if setter, ok := d.connection.(PwmPolaritySetter); ok {
+		return setter.SetPWMPolarity(pin, normal)
+	}
+
+	return ErrPwmPolarityUnsupported
+}
+
+// setPWMCenterAligned is a helper function with check that the connection implements PwmCenterAligner
+func (d *driver) setPWMCenterAligned(pin string, centerAligned bool) error {
+	if aligner, ok := d.connection.(PwmCenterAligner); ok {
+		return aligner.SetPWMCenterAligned(pin, centerAligned)
+	}
+
+	return ErrPwmCenterAlignedUnsupported
 }
 
 // servoWrite is a helper function with check that the connection implements ServoWriter
 func (d *driver) servoWrite(pin string, level byte) error {
 	if writer, ok := d.connection.(ServoWriter); ok {
-		return writer.ServoWrite(pin, level)
+		return d.recordError(writer.ServoWrite(pin, level))
 	}
 
-	return ErrServoWriteUnsupported
+	return d.recordError(ErrServoWriteUnsupported)
+}
+
+// recordError stores the given result of an access to the connection for LastError() and returns it unchanged. A nil
+// value clears the last error.
+func (d *driver) recordError(err error) error {
+	d.lastErrMutex.Lock()
+	defer d.lastErrMutex.Unlock()
+
+	d.lastErr = err
+
+	return err
 }
 
 func (o nameOption) String() string {
@@ -213,6 +481,10 @@ func (o pinOption) String() string {
 	return "pin option for digital drivers"
 }
 
+func (o verifyWriteOption) String() string {
+	return "verify write option for digital drivers"
+}
+
 // apply change the name in the configuration.
 func (o nameOption) apply(c *configuration) {
 	c.name = string(o)
@@ -222,3 +494,28 @@ func (o nameOption) apply(c *configuration) {
 func (o pinOption) apply(c *configuration) {
 	c.pin = string(o)
 }
+
+// apply activates the verification of digital writes in the configuration, the pins are added to the list.
+func (o verifyWriteOption) apply(c *configuration) {
+	c.verifyWrite = true
+	c.verifyWritePins = append(c.verifyWritePins, o...)
+}
+
+// isWriteVerified returns whether a digital write to the given pin needs to be read back.
+func (c *configuration) isWriteVerified(pin string) bool {
+	if !c.verifyWrite {
+		return false
+	}
+
+	if len(c.verifyWritePins) == 0 {
+		return true
+	}
+
+	for _, p := range c.verifyWritePins {
+		if p == pin {
+			return true
+		}
+	}
+
+	return false
+}
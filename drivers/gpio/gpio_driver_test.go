@@ -1,6 +1,7 @@
 package gpio
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -36,6 +37,8 @@ func TestNewDriver(t *testing.T) {
 	require.NoError(t, d.beforeHalt())
 	assert.NotNil(t, d.Commander)
 	assert.NotNil(t, d.mutex)
+	assert.NotNil(t, d.lastErrMutex)
+	require.NoError(t, d.LastError())
 }
 
 func Test_applyWithName(t *testing.T) {
@@ -58,6 +61,21 @@ func Test_applywithPin(t *testing.T) {
 	assert.Equal(t, pin, cfg.pin)
 }
 
+func Test_applyWithVerifiedWrite(t *testing.T) {
+	// arrange
+	cfg := configuration{}
+	// act
+	WithVerifiedWrite("1").apply(&cfg)
+	WithVerifiedWrite("2", "3").apply(&cfg)
+	// assert
+	assert.True(t, cfg.verifyWrite)
+	assert.Equal(t, []string{"1", "2", "3"}, cfg.verifyWritePins)
+	assert.True(t, cfg.isWriteVerified("3"))
+	assert.False(t, cfg.isWriteVerified("4"))
+	assert.False(t, (&configuration{}).isWriteVerified("1"))
+	assert.True(t, (&configuration{verifyWrite: true}).isWriteVerified("4"))
+}
+
 func TestConnection(t *testing.T) {
 	// arrange
 	d, a := initTestDriverWithStubbedAdaptor()
@@ -68,12 +86,16 @@ func TestConnection(t *testing.T) {
 func TestStart(t *testing.T) {
 	// arrange
 	d := initTestDriver()
-	// act, assert
-	require.NoError(t, d.Start())
 	// arrange after start function
 	d.afterStart = func() error { return fmt.Errorf("after start error") }
 	// act, assert
 	require.EqualError(t, d.Start(), "after start error")
+	assert.Equal(t, driverStateInitial, d.state)
+	// arrange after start function
+	d.afterStart = func() error { return nil }
+	// act, assert
+	require.NoError(t, d.Start())
+	assert.Equal(t, driverStateStarted, d.state)
 }
 
 func TestHalt(t *testing.T) {
@@ -82,7 +104,399 @@ func TestHalt(t *testing.T) {
 	// act, assert
 	require.NoError(t, d.Halt())
 	// arrange after start function
+	d = initTestDriver()
 	d.beforeHalt = func() error { return fmt.Errorf("before halt error") }
 	// act, assert
 	require.EqualError(t, d.Halt(), "before halt error")
+	assert.Equal(t, driverStateHalted, d.state)
+}
+
+func TestStartHalt_repeated(t *testing.T) {
+	tests := map[string]struct {
+		calls         []string
+		wantStarts    int
+		wantHalts     int
+		wantState     driverState
+		failFirstCall bool
+	}{
+		"double_start": {
+			calls:      []string{"start", "start"},
+			wantStarts: 1,
+			wantState:  driverStateStarted,
+		},
+		"halt_without_start": {
+			calls:     []string{"halt"},
+			wantHalts: 1,
+			wantState: driverStateHalted,
+		},
+		"double_halt": {
+			calls:      []string{"start", "halt", "halt"},
+			wantStarts: 1,
+			wantHalts:  1,
+			wantState:  driverStateHalted,
+		},
+		"restart_after_halt": {
+			calls:      []string{"start", "halt", "start", "halt"},
+			wantStarts: 2,
+			wantHalts:  2,
+			wantState:  driverStateHalted,
+		},
+		"start_again_after_failed_start": {
+			calls:         []string{"start", "start"},
+			failFirstCall: true,
+			wantStarts:    2,
+			wantState:     driverStateStarted,
+		},
+		"halt_after_failed_start": {
+			calls:         []string{"start", "halt"},
+			failFirstCall: true,
+			wantStarts:    1,
+			wantHalts:     1,
+			wantState:     driverStateHalted,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := initTestDriver()
+			var starts, halts int
+			fail := tc.failFirstCall
+			d.afterStart = func() error {
+				starts++
+				if fail {
+					fail = false
+					return fmt.Errorf("after start error")
+				}
+				return nil
+			}
+			d.beforeHalt = func() error {
+				halts++
+				return nil
+			}
+			// act
+			for i, call := range tc.calls {
+				var err error
+				if call == "start" {
+					err = d.Start()
+				} else {
+					err = d.Halt()
+				}
+				if i == 0 && tc.failFirstCall {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+			}
+			// assert
+			assert.Equal(t, tc.wantStarts, starts)
+			assert.Equal(t, tc.wantHalts, halts)
+			assert.Equal(t, tc.wantState, d.state)
+		})
+	}
+}
+
+func TestLastError(t *testing.T) {
+	tests := map[string]struct {
+		access  func(d *driver) error
+		wantErr string
+	}{
+		"digital_read": {
+			access:  func(d *driver) error { _, err := d.digitalRead("1"); return err },
+			wantErr: "read error",
+		},
+		"digital_write": {
+			access:  func(d *driver) error { return d.digitalWrite("1", 1) },
+			wantErr: "write error",
+		},
+		"pwm_write": {
+			access:  func(d *driver) error { return d.pwmWrite("1", 1) },
+			wantErr: "pwm error",
+		},
+		"servo_write": {
+			access:  func(d *driver) error { return d.servoWrite("1", 1) },
+			wantErr: "servo error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestDriverWithStubbedAdaptor()
+			var fail bool
+			simulateErr := func(msg string) error {
+				if fail {
+					return errors.New(msg)
+				}
+				return nil
+			}
+			a.digitalReadFunc = func(string) (int, error) { return 0, simulateErr("read error") }
+			a.digitalWriteFunc = func(string, byte) error { return simulateErr("write error") }
+			a.pwmWriteFunc = func(string, byte) error { return simulateErr("pwm error") }
+			a.servoWriteFunc = func(string, byte) error { return simulateErr("servo error") }
+			// act & assert: error is recorded
+			fail = true
+			require.EqualError(t, tc.access(d), tc.wantErr)
+			require.EqualError(t, d.LastError(), tc.wantErr)
+			// act & assert: successful access clears the error
+			fail = false
+			require.NoError(t, tc.access(d))
+			require.NoError(t, d.LastError())
+		})
+	}
+}
+
+func TestLastError_unsupported(t *testing.T) {
+	// arrange
+	d := newDriver(&gpioTestBareAdaptor{}, "GPIO_BARE")
+	// act
+	err := d.pwmWrite("1", 1)
+	// assert
+	require.ErrorIs(t, err, ErrPwmWriteUnsupported)
+	require.ErrorIs(t, d.LastError(), ErrPwmWriteUnsupported)
+}
+
+func TestPinNotSetErrors(t *testing.T) {
+	tests := map[string]struct {
+		err     error
+		wantMsg string
+	}{
+		"direction": {err: ErrDirPinNotSet, wantMsg: "dirPin is not set"},
+		"enable":    {err: ErrEnablePinNotSet, wantMsg: "enPin is not set"},
+		"sleep":     {err: ErrSleepPinNotSet, wantMsg: "sleepPin is not set"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			wrapped := fmt.Errorf("%w for 'driver'", tc.err)
+			// assert
+			require.EqualError(t, tc.err, tc.wantMsg)
+			assert.ErrorIs(t, wrapped, tc.err)
+			assert.ErrorIs(t, wrapped, ErrPinNotSet)
+			for otherName, other := range tests {
+				if otherName != name {
+					assert.NotErrorIs(t, wrapped, other.err)
+				}
+			}
+			assert.NotErrorIs(t, ErrPinNotSet, tc.err)
+		})
+	}
+}
+
+func TestRequireCapabilities(t *testing.T) {
+	tests := map[string]struct {
+		adaptor gobot.Adaptor
+		caps    []Capability
+		wantErr string
+	}{
+		"all_available": {
+			adaptor: newGpioTestAdaptor(),
+			caps: []Capability{
+				DigitalWriterCapability, DigitalReaderCapability, PwmWriterCapability, ServoWriterCapability,
+				PwmPolaritySetterCapability, PwmCenterAlignerCapability,
+			},
+		},
+		"nothing_required": {
+			adaptor: &gpioTestBareAdaptor{},
+		},
+		"error_missing_one": {
+			adaptor: &gpioTestBareAdaptor{},
+			caps:    []Capability{DigitalWriterCapability},
+			wantErr: "adaptor '' is missing the capabilities: DigitalWriter",
+		},
+		"error_missing_all": {
+			adaptor: &gpioTestBareAdaptor{},
+			caps: []Capability{
+				DigitalWriterCapability, DigitalReaderCapability, PwmWriterCapability, ServoWriterCapability,
+			},
+			wantErr: "adaptor '' is missing the capabilities: DigitalWriter, DigitalReader, PwmWriter, ServoWriter",
+		},
+		"error_no_adaptor": {
+			caps:    []Capability{DigitalWriterCapability},
+			wantErr: "no adaptor given to check the capabilities",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			err := RequireCapabilities(tc.adaptor, tc.caps...)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// gpioTestWriterAdaptor is an adaptor, which can write but not read digital pins
+type gpioTestWriterAdaptor struct {
+	gpioTestBareAdaptor
+}
+
+func (t *gpioTestWriterAdaptor) DigitalWrite(string, byte) error { return nil }
+
+func TestDigitalWrite_verified(t *testing.T) {
+	tests := map[string]struct {
+		opts        []interface{}
+		pin         string
+		val         byte
+		readVal     int
+		readErr     error
+		wantReads   int
+		wantErr     error
+		wantErrText string
+	}{
+		"not_verified_by_default": {
+			pin:     "1",
+			val:     1,
+			readVal: 0,
+		},
+		"all_pins_ok": {
+			opts:      []interface{}{WithVerifiedWrite()},
+			pin:       "1",
+			val:       1,
+			readVal:   1,
+			wantReads: 1,
+		},
+		"all_pins_low_ok": {
+			opts:      []interface{}{WithVerifiedWrite()},
+			pin:       "1",
+			val:       0,
+			readVal:   0,
+			wantReads: 1,
+		},
+		"all_pins_stuck_low": {
+			opts:        []interface{}{WithVerifiedWrite()},
+			pin:         "1",
+			val:         1,
+			readVal:     0,
+			wantReads:   1,
+			wantErr:     ErrWriteVerificationFailed,
+			wantErrText: "write verification failed: pin '1' of 'GPIO_BASIC' reads 0 after writing 1",
+		},
+		"all_pins_stuck_high": {
+			opts:        []interface{}{WithVerifiedWrite()},
+			pin:         "1",
+			val:         0,
+			readVal:     1,
+			wantReads:   1,
+			wantErr:     ErrWriteVerificationFailed,
+			wantErrText: "write verification failed: pin '1' of 'GPIO_BASIC' reads 1 after writing 0",
+		},
+		"selected_pin_stuck": {
+			opts:        []interface{}{WithVerifiedWrite("2", "3")},
+			pin:         "3",
+			val:         1,
+			readVal:     0,
+			wantReads:   1,
+			wantErr:     ErrWriteVerificationFailed,
+			wantErrText: "write verification failed: pin '3' of 'GPIO_BASIC' reads 0 after writing 1",
+		},
+		"other_pin_not_verified": {
+			opts:    []interface{}{WithVerifiedWrite("2", "3")},
+			pin:     "1",
+			val:     1,
+			readVal: 0,
+		},
+		"read_error": {
+			opts:        []interface{}{WithVerifiedWrite()},
+			pin:         "1",
+			val:         1,
+			readErr:     errors.New("read error"),
+			wantReads:   1,
+			wantErrText: "read error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			var reads int
+			a.digitalReadOutFunc = func(pin string) (int, error) {
+				reads++
+				assert.Equal(t, tc.pin, pin)
+				return tc.readVal, tc.readErr
+			}
+			d := newDriver(a, "GPIO_BASIC", append(tc.opts, WithName("GPIO_BASIC"))...)
+			// act
+			err := d.digitalWrite(tc.pin, tc.val)
+			// assert
+			assert.Equal(t, []gpioTestWritten{{pin: tc.pin, val: tc.val}}, a.written)
+			assert.Equal(t, tc.wantReads, reads)
+			if tc.wantErrText == "" {
+				require.NoError(t, err)
+				require.NoError(t, d.LastError())
+				return
+			}
+			require.EqualError(t, err, tc.wantErrText)
+			require.EqualError(t, d.LastError(), tc.wantErrText)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestDigitalWrite_verifiedWriteError(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	a.simulateWriteError = true
+	a.digitalReadOutFunc = func(string) (int, error) {
+		require.Fail(t, "pin should not be read back after a failed write")
+		return 0, nil
+	}
+	d := newDriver(a, "GPIO_BASIC", WithVerifiedWrite())
+	// act
+	err := d.digitalWrite("1", 1)
+	// assert
+	require.EqualError(t, err, "write error")
+}
+
+func TestDigitalWrite_verifiedReadUnsupported(t *testing.T) {
+	// arrange
+	d := newDriver(&gpioTestWriterAdaptor{}, "GPIO_WRITER", WithVerifiedWrite(), WithName("GPIO_WRITER"))
+	// act
+	err := d.digitalWrite("1", 1)
+	// assert
+	require.ErrorIs(t, err, ErrDigitalReadOutputUnsupported)
+	require.ErrorContains(t, err, "pin '1' of 'GPIO_WRITER' can not be verified")
+}
+
+func TestStart_verifiedWriteUnsupported(t *testing.T) {
+	tests := map[string]struct {
+		adaptor gobot.Adaptor
+		wantErr string
+	}{
+		"supported": {
+			adaptor: newGpioTestAdaptor(),
+		},
+		"error_unsupported": {
+			adaptor: &gpioTestWriterAdaptor{},
+			wantErr: "DigitalReadOutput is not supported by this platform, so the writes of 'GPIO_VERIFIED' can " +
+				"not be verified",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			var started bool
+			d := newDriver(tc.adaptor, "GPIO_VERIFIED", WithVerifiedWrite(), WithName("GPIO_VERIFIED"))
+			d.afterStart = func() error {
+				started = true
+				return nil
+			}
+			// act
+			err := d.Start()
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				require.ErrorIs(t, err, ErrDigitalReadOutputUnsupported)
+				assert.False(t, started)
+				assert.Equal(t, driverStateInitial, d.state)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, started)
+		})
+	}
 }
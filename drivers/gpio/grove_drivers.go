@@ -101,7 +101,8 @@ type GroveTouchDriver struct {
 //	"WithName"
 //	"WithButtonPollInterval"
 //
-// Deprecated: Please use [gpio.NewButtonDriver] instead. Development will be discontinued.
+// Deprecated: Please use [gpio.NewButtonDriver] with option [gpio.WithButtonTouchProfile] instead. Development will
+// be discontinued.
 func NewGroveTouchDriver(a DigitalReader, pin string, opts ...interface{}) *GroveTouchDriver {
 	return &GroveTouchDriver{
 		ButtonDriver: NewButtonDriver(a, pin, opts...),
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 	"gobot.io/x/gobot/v2/system"
 )
 
@@ -153,6 +154,12 @@ func (d *HCSR04Driver) Distance() float64 {
 	return float64(distMm) / 1000.0
 }
 
+// DistanceMeasurement returns the last distance measured like Distance(), but together with its unit. It does not
+// trigger a distance measurement.
+func (d *HCSR04Driver) DistanceMeasurement() measurement.Measurement {
+	return measurement.New(d.Distance(), measurement.Meter)
+}
+
 // StartDistanceMonitor starts continuous measurement. The current value can be read by Distance()
 func (d *HCSR04Driver) StartDistanceMonitor() error {
 	// ensure that start and stop can not interfere
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 	"gobot.io/x/gobot/v2/system"
 )
 
@@ -182,6 +183,18 @@ func TestHCSR04Distance(t *testing.T) {
 	}
 }
 
+func TestHCSR04DistanceMeasurement(t *testing.T) {
+	// arrange
+	d := HCSR04Driver{lastMeasureMicroSec: 23324} // 23324us ~ 24ms => ~4m
+	// act
+	got := d.DistanceMeasurement()
+	// assert
+	assert.Equal(t, measurement.New(4.0, measurement.Meter), got)
+	centimeters, err := got.Centimeters()
+	require.NoError(t, err)
+	assert.InDelta(t, 400.0, centimeters, 1e-9)
+}
+
 func TestHCSR04StartDistanceMonitor(t *testing.T) {
 	tests := map[string]struct {
 		simulateIsStarted bool
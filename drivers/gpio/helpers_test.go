@@ -15,7 +15,8 @@ func (t *gpioTestBareAdaptor) Name() string     { return "" }
 func (t *gpioTestBareAdaptor) SetName(n string) {}
 
 type digitalPinMock struct {
-	writeFunc func(val int) error
+	writeFunc        func(val int) error
+	applyOptionsFunc func(options ...func(gobot.DigitalPinOptioner) bool) error
 }
 
 type gpioTestWritten struct {
@@ -31,9 +32,12 @@ type gpioTestAdaptor struct {
 	simulateWriteError bool
 	mtx                sync.Mutex
 	digitalReadFunc    func(ping string) (val int, err error)
+	digitalReadOutFunc func(pin string) (val int, err error)
 	digitalWriteFunc   func(pin string, val byte) error
 	pwmWriteFunc       func(pin string, val byte) error
 	servoWriteFunc     func(pin string, val byte) error
+	pwmPolarityFunc    func(pin string, normal bool) error
+	pwmCenterAlignFunc func(pin string, centerAligned bool) error
 }
 
 func newGpioTestAdaptor() *gpioTestAdaptor {
@@ -53,6 +57,15 @@ func newGpioTestAdaptor() *gpioTestAdaptor {
 		digitalReadFunc: func(pin string) (int, error) {
 			return 1, nil
 		},
+		digitalReadOutFunc: func(pin string) (int, error) {
+			return 1, nil
+		},
+		pwmPolarityFunc: func(pin string, normal bool) error {
+			return nil
+		},
+		pwmCenterAlignFunc: func(pin string, centerAligned bool) error {
+			return nil
+		},
 	}
 
 	return &t
@@ -65,6 +78,13 @@ func (t *gpioTestAdaptor) DigitalRead(pin string) (int, error) {
 	return t.digitalReadFunc(pin)
 }
 
+// DigitalReadOutput capabilities (interface DigitalOutputReader)
+func (t *gpioTestAdaptor) DigitalReadOutput(pin string) (int, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.digitalReadOutFunc(pin)
+}
+
 // DigitalWrite capabilities (interface DigitalWriter)
 func (t *gpioTestAdaptor) DigitalWrite(pin string, val byte) error {
 	t.mtx.Lock()
@@ -91,6 +111,20 @@ func (t *gpioTestAdaptor) ServoWrite(pin string, val byte) error {
 	return t.servoWriteFunc(pin, val)
 }
 
+// SetPWMPolarity capabilities (interface PwmPolaritySetter)
+func (t *gpioTestAdaptor) SetPWMPolarity(pin string, normal bool) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.pwmPolarityFunc(pin, normal)
+}
+
+// SetPWMCenterAligned capabilities (interface PwmCenterAligner)
+func (t *gpioTestAdaptor) SetPWMCenterAligned(pin string, centerAligned bool) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.pwmCenterAlignFunc(pin, centerAligned)
+}
+
 func (t *gpioTestAdaptor) Connect() error   { return nil }
 func (t *gpioTestAdaptor) Finalize() error  { return nil }
 func (t *gpioTestAdaptor) Name() string     { return t.name }
@@ -107,6 +141,9 @@ func (t *gpioTestAdaptor) DigitalPin(id string) (gobot.DigitalPinner, error) {
 
 // ApplyOptions (interface DigitalPinOptionApplier by DigitalPinner) apply all given options to the pin immediately
 func (d *digitalPinMock) ApplyOptions(options ...func(gobot.DigitalPinOptioner) bool) error {
+	if d.applyOptionsFunc != nil {
+		return d.applyOptionsFunc(options...)
+	}
 	return nil
 }
 
@@ -0,0 +1,364 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	hx711DefaultReadyTimeout = 500 * time.Millisecond // output data rate is 10 or 80 Hz
+	hx711ReadyPollInterval   = time.Millisecond
+	hx711DataBits            = 24
+)
+
+// HX711Gain is the type for the gain and input channel selection of the next conversion.
+type HX711Gain uint8
+
+const (
+	// HX711GainChannelA128 selects channel A with a gain of 128 (default)
+	HX711GainChannelA128 HX711Gain = 128
+	// HX711GainChannelA64 selects channel A with a gain of 64
+	HX711GainChannelA64 HX711Gain = 64
+	// HX711GainChannelB32 selects channel B with a gain of 32
+	HX711GainChannelB32 HX711Gain = 32
+)
+
+// hx711OptionApplier needs to be implemented by each configurable option type
+type hx711OptionApplier interface {
+	apply(cfg *hx711Configuration)
+}
+
+// hx711Configuration contains all changeable attributes of the driver.
+type hx711Configuration struct {
+	gain         HX711Gain
+	averageCount int
+	readyTimeout time.Duration
+	scale        float64
+}
+
+// hx711GainOption is the type for applying another gain to the configuration
+type hx711GainOption HX711Gain
+
+// hx711AverageOption is the type for applying another count of reads for averaging to the configuration
+type hx711AverageOption int
+
+// hx711ReadyTimeoutOption is the type for applying another timeout for data ready to the configuration
+type hx711ReadyTimeoutOption time.Duration
+
+// hx711ScaleOption is the type for applying another scale factor to the configuration
+type hx711ScaleOption float64
+
+// HX711Driver is a driver for the HX711 24-bit ADC, which is typically used for load cells (weighing scales).
+// The serial interface is driven by bit banging of the clock pin and reading of the data pin.
+//
+// Datasheet: https://cdn.sparkfun.com/datasheets/Sensors/ForceFlex/hx711_english.pdf
+//
+// Please note: the clock must not be high longer than 60 us, otherwise the chip enters power down mode. This
+// needs a platform with fast digital writes.
+type HX711Driver struct {
+	*driver
+	hx711Cfg    *hx711Configuration
+	clockPin    string
+	dataPin     string
+	offset      float64
+	gainApplied bool
+}
+
+// NewHX711Driver return a new driver for the HX711 given a gobot.Connection and the clock (PD_SCK) and data (DOUT)
+// pins.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithHX711Gain"
+//	"WithHX711Average"
+//	"WithHX711ReadyTimeout"
+//	"WithHX711Scale"
+//
+// Adds the following API Commands:
+//
+//	"ReadRaw" - See HX711Driver.ReadRaw
+//	"Weight"  - See HX711Driver.Weight
+//	"Tare"    - See HX711Driver.Tare
+func NewHX711Driver(a gobot.Connection, clockPin, dataPin string, opts ...interface{}) *HX711Driver {
+	d := &HX711Driver{
+		driver: newDriver(a, "HX711"),
+		hx711Cfg: &hx711Configuration{
+			gain:         HX711GainChannelA128,
+			averageCount: 1,
+			readyTimeout: hx711DefaultReadyTimeout,
+			scale:        1,
+		},
+		clockPin: clockPin,
+		dataPin:  dataPin,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case hx711OptionApplier:
+			o.apply(d.hx711Cfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddCommand("ReadRaw", func(params map[string]interface{}) interface{} {
+		val, err := d.ReadRaw()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("Weight", func(params map[string]interface{}) interface{} {
+		val, err := d.Weight()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("Tare", func(params map[string]interface{}) interface{} {
+		return d.Tare()
+	})
+
+	return d
+}
+
+// WithHX711Gain substitute the default gain of 128 at channel A.
+func WithHX711Gain(gain HX711Gain) hx711OptionApplier {
+	return hx711GainOption(gain)
+}
+
+// WithHX711Average substitute the default count of 1 read for averaging the value.
+func WithHX711Average(count int) hx711OptionApplier {
+	return hx711AverageOption(count)
+}
+
+// WithHX711ReadyTimeout substitute the default timeout of 500 ms for waiting on data ready.
+func WithHX711ReadyTimeout(timeout time.Duration) hx711OptionApplier {
+	return hx711ReadyTimeoutOption(timeout)
+}
+
+// WithHX711Scale substitute the default scale factor of 1, which is used to calculate the weight from the raw value.
+// The factor is the raw value per unit, e.g. per gram.
+func WithHX711Scale(factor float64) hx711OptionApplier {
+	return hx711ScaleOption(factor)
+}
+
+// SetGain changes the gain and channel. The new gain is applied before the next reading.
+func (d *HX711Driver) SetGain(gain HX711Gain) error {
+	if _, err := gain.extraPulses(); err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.hx711Cfg.gain = gain
+	d.gainApplied = false
+	return nil
+}
+
+// SetScale changes the scale factor, see [gpio.WithHX711Scale].
+func (d *HX711Driver) SetScale(factor float64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.hx711Cfg.scale = factor
+}
+
+// Offset returns the raw value of the last tare.
+func (d *HX711Driver) Offset() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.offset
+}
+
+// ReadRaw reads the signed 24-bit value and returns the average of the configured count of reads.
+func (d *HX711Driver) ReadRaw() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readAverage()
+}
+
+// Tare reads the current value and stores it as offset, so the following weight is related to this value.
+func (d *HX711Driver) Tare() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.readAverage()
+	if err != nil {
+		return err
+	}
+
+	d.offset = val
+	return nil
+}
+
+// Weight reads the value and returns the weight, calculated by the offset and the scale factor.
+func (d *HX711Driver) Weight() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.readAverage()
+	if err != nil {
+		return 0, err
+	}
+
+	if d.hx711Cfg.scale == 0 {
+		return 0, fmt.Errorf("scale factor must not be zero for '%s'", d.driverCfg.name)
+	}
+
+	return (val - d.offset) / d.hx711Cfg.scale, nil
+}
+
+// initialize sets the clock to low, which wakes up the chip
+func (d *HX711Driver) initialize() error {
+	if _, err := d.hx711Cfg.gain.extraPulses(); err != nil {
+		return err
+	}
+
+	d.gainApplied = false
+	return d.digitalWrite(d.clockPin, 0)
+}
+
+// shutdown sets the clock to high, which let the chip enter power down mode after 60 us
+func (d *HX711Driver) shutdown() error {
+	return d.digitalWrite(d.clockPin, 1)
+}
+
+func (d *HX711Driver) readAverage() (float64, error) {
+	if !d.gainApplied {
+		// the gain is applied by the extra pulses after a reading, so the first reading can be done with another gain
+		if _, err := d.readValue(); err != nil {
+			return 0, err
+		}
+		d.gainApplied = true
+	}
+
+	count := d.hx711Cfg.averageCount
+	if count < 1 {
+		count = 1
+	}
+
+	var sum int64
+	for i := 0; i < count; i++ {
+		val, err := d.readValue()
+		if err != nil {
+			return 0, err
+		}
+		sum += int64(val)
+	}
+
+	return float64(sum) / float64(count), nil
+}
+
+// readValue waits for data ready, reads 24 bits (MSB first) and sends the extra pulses to select the gain for the
+// next conversion.
+func (d *HX711Driver) readValue() (int32, error) {
+	extraPulses, err := d.hx711Cfg.gain.extraPulses()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := d.waitReady(); err != nil {
+		return 0, err
+	}
+
+	var val uint32
+	for i := 0; i < hx711DataBits; i++ {
+		if err := d.digitalWrite(d.clockPin, 1); err != nil {
+			return 0, err
+		}
+		bit, err := d.digitalRead(d.dataPin)
+		if err != nil {
+			return 0, err
+		}
+		if err := d.digitalWrite(d.clockPin, 0); err != nil {
+			return 0, err
+		}
+		val = val<<1 | uint32(bit&0x01)
+	}
+
+	for i := 0; i < extraPulses; i++ {
+		if err := d.digitalWrite(d.clockPin, 1); err != nil {
+			return 0, err
+		}
+		if err := d.digitalWrite(d.clockPin, 0); err != nil {
+			return 0, err
+		}
+	}
+
+	// sign extension of the two's complement 24-bit value
+	if val&0x800000 != 0 {
+		val |= 0xFF000000
+	}
+
+	return int32(val), nil
+}
+
+// waitReady waits until the data pin is low, which signals that a conversion is ready to read
+func (d *HX711Driver) waitReady() error {
+	timeout := time.Now().Add(d.hx711Cfg.readyTimeout)
+	for {
+		val, err := d.digitalRead(d.dataPin)
+		if err != nil {
+			return err
+		}
+		if val == 0 {
+			return nil
+		}
+		if time.Now().After(timeout) {
+			return fmt.Errorf("timeout of %s exceeded while waiting for data ready of '%s'", d.hx711Cfg.readyTimeout,
+				d.driverCfg.name)
+		}
+		time.Sleep(hx711ReadyPollInterval)
+	}
+}
+
+// extraPulses returns the count of clock pulses after the data bits to select the gain for the next conversion
+func (g HX711Gain) extraPulses() (int, error) {
+	switch g {
+	case HX711GainChannelA128:
+		return 1, nil
+	case HX711GainChannelB32:
+		return 2, nil
+	case HX711GainChannelA64:
+		return 3, nil
+	default:
+		return 0, fmt.Errorf("gain %d is not supported, use 128, 64 or 32", g)
+	}
+}
+
+func (o hx711GainOption) String() string {
+	return "gain option for HX711"
+}
+
+func (o hx711AverageOption) String() string {
+	return "average option for HX711"
+}
+
+func (o hx711ReadyTimeoutOption) String() string {
+	return "ready timeout option for HX711"
+}
+
+func (o hx711ScaleOption) String() string {
+	return "scale option for HX711"
+}
+
+func (o hx711GainOption) apply(cfg *hx711Configuration) {
+	cfg.gain = HX711Gain(o)
+}
+
+func (o hx711AverageOption) apply(cfg *hx711Configuration) {
+	cfg.averageCount = int(o)
+}
+
+func (o hx711ReadyTimeoutOption) apply(cfg *hx711Configuration) {
+	cfg.readyTimeout = time.Duration(o)
+}
+
+func (o hx711ScaleOption) apply(cfg *hx711Configuration) {
+	cfg.scale = float64(o)
+}
@@ -0,0 +1,207 @@
+package gpio
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*HX711Driver)(nil)
+
+// hx711Simulator simulates the serial output of the chip, driven by the clock pulses
+type hx711Simulator struct {
+	values         []int32 // the value of each conversion
+	pulsesPerFrame int     // 24 data bits + extra pulses for the gain
+	pulses         int
+	clockHighCount int
+}
+
+func (s *hx711Simulator) attach(a *gpioTestAdaptor, clockPin, dataPin string) {
+	a.digitalWriteFunc = func(pin string, val byte) error {
+		if pin == clockPin && val == 1 {
+			s.pulses++
+			s.clockHighCount++
+		}
+		return nil
+	}
+	a.digitalReadFunc = func(pin string) (int, error) {
+		if pin != dataPin {
+			return 0, nil
+		}
+		pos := s.pulses % s.pulsesPerFrame
+		if pos == 0 || pos > hx711DataBits {
+			return 0, nil // ready
+		}
+		frame := s.pulses / s.pulsesPerFrame
+		val := uint32(s.values[frame%len(s.values)])
+		return int(val>>(hx711DataBits-pos)) & 0x01, nil
+	}
+}
+
+func initTestHX711DriverWithSimulator(values []int32, opts ...interface{}) (*HX711Driver, *hx711Simulator) {
+	a := newGpioTestAdaptor()
+	d := NewHX711Driver(a, "1", "2", opts...)
+	extraPulses, _ := d.hx711Cfg.gain.extraPulses()
+	s := &hx711Simulator{values: values, pulsesPerFrame: hx711DataBits + extraPulses}
+	s.attach(a, "1", "2")
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, s
+}
+
+func TestNewHX711Driver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewHX711Driver(a, "3", "4")
+	// assert
+	assert.IsType(t, &HX711Driver{}, d)
+	assert.True(t, strings.HasPrefix(d.Name(), "HX711"))
+	assert.Equal(t, a, d.Connection())
+	assert.Equal(t, "3", d.clockPin)
+	assert.Equal(t, "4", d.dataPin)
+	require.NotNil(t, d.hx711Cfg)
+	assert.Equal(t, HX711GainChannelA128, d.hx711Cfg.gain)
+	assert.Equal(t, 1, d.hx711Cfg.averageCount)
+	assert.Equal(t, hx711DefaultReadyTimeout, d.hx711Cfg.readyTimeout)
+	assert.InDelta(t, 1.0, d.hx711Cfg.scale, 0.0)
+	assert.NotNil(t, d.Command("ReadRaw"))
+	assert.NotNil(t, d.Command("Weight"))
+	assert.NotNil(t, d.Command("Tare"))
+}
+
+func TestNewHX711Driver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const myName = "kitchen scale"
+	panicFunc := func() {
+		NewHX711Driver(newGpioTestAdaptor(), "1", "2", WithName("crazy"), WithMotorDirectionPin("3"))
+	}
+	// act
+	d := NewHX711Driver(newGpioTestAdaptor(), "1", "2", WithName(myName), WithHX711Gain(HX711GainChannelB32),
+		WithHX711Average(5), WithHX711ReadyTimeout(time.Second), WithHX711Scale(420.5))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, HX711GainChannelB32, d.hx711Cfg.gain)
+	assert.Equal(t, 5, d.hx711Cfg.averageCount)
+	assert.Equal(t, time.Second, d.hx711Cfg.readyTimeout)
+	assert.InDelta(t, 420.5, d.hx711Cfg.scale, 0.0)
+	assert.PanicsWithValue(t, "'direction pin option for motors' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestHX711ReadRaw(t *testing.T) {
+	tests := map[string]struct {
+		gain           HX711Gain
+		values         []int32
+		average        int
+		want           float64
+		wantClockCount int
+	}{
+		"positive": {
+			gain:           HX711GainChannelA128,
+			values:         []int32{0x123456},
+			want:           0x123456,
+			wantClockCount: 2 * 25,
+		},
+		"negative": {
+			gain:           HX711GainChannelA128,
+			values:         []int32{-1000},
+			want:           -1000,
+			wantClockCount: 2 * 25,
+		},
+		"max": {
+			gain:           HX711GainChannelB32,
+			values:         []int32{0x7FFFFF},
+			want:           0x7FFFFF,
+			wantClockCount: 2 * 26,
+		},
+		"min": {
+			gain:           HX711GainChannelA64,
+			values:         []int32{-0x800000},
+			want:           -0x800000,
+			wantClockCount: 2 * 27,
+		},
+		"average": {
+			gain:           HX711GainChannelA128,
+			values:         []int32{0, 100, 200, 600}, // first one is skipped for applying the gain
+			average:        3,
+			want:           300,
+			wantClockCount: 4 * 25,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, s := initTestHX711DriverWithSimulator(tc.values, WithHX711Gain(tc.gain), WithHX711Average(tc.average))
+			if len(tc.values) == 1 {
+				s.values = []int32{0, tc.values[0]}
+			}
+			// act
+			got, err := d.ReadRaw()
+			// assert
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, got, 0.0)
+			assert.Equal(t, tc.wantClockCount, s.clockHighCount)
+		})
+	}
+}
+
+func TestHX711TareAndWeight(t *testing.T) {
+	// arrange
+	d, s := initTestHX711DriverWithSimulator([]int32{0, 8000, 8000, 50000}, WithHX711Scale(420))
+	// act
+	err := d.Tare()
+	require.NoError(t, err)
+	s.pulses = 3 * s.pulsesPerFrame // next read will be the last value
+	got, err := d.Weight()
+	// assert
+	require.NoError(t, err)
+	assert.InDelta(t, 8000.0, d.Offset(), 0.0)
+	assert.InDelta(t, 100.0, got, 0.0)
+	// act: change scale
+	d.SetScale(0)
+	_, err = d.Weight()
+	// assert
+	require.ErrorContains(t, err, "scale factor must not be zero")
+}
+
+func TestHX711SetGain(t *testing.T) {
+	// arrange
+	d, _ := initTestHX711DriverWithSimulator([]int32{0})
+	d.gainApplied = true
+	// act & assert
+	require.NoError(t, d.SetGain(HX711GainChannelA64))
+	assert.Equal(t, HX711GainChannelA64, d.hx711Cfg.gain)
+	assert.False(t, d.gainApplied)
+	require.EqualError(t, d.SetGain(100), "gain 100 is not supported, use 128, 64 or 32")
+}
+
+func TestHX711ReadRaw_timeout(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	a.digitalReadFunc = func(string) (int, error) { return 1, nil } // never ready
+	d := NewHX711Driver(a, "1", "2", WithName("hx"), WithHX711ReadyTimeout(5*time.Millisecond))
+	require.NoError(t, d.Start())
+	// act
+	_, err := d.ReadRaw()
+	// assert
+	require.EqualError(t, err, "timeout of 5ms exceeded while waiting for data ready of 'hx'")
+}
+
+func TestHX711StartHalt(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewHX711Driver(a, "1", "2")
+	// act & assert
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Halt())
+	assert.Equal(t, []gpioTestWritten{{pin: "1", val: 0}, {pin: "1", val: 1}}, a.written)
+}
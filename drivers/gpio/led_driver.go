@@ -15,6 +15,7 @@ type LedDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithVerifiedWrite"
 //
 // Adds the following API Commands:
 //
@@ -83,3 +84,16 @@ func (d *LedDriver) Toggle() error {
 func (d *LedDriver) Brightness(level byte) error {
 	return d.pwmWrite(d.driverCfg.pin, level)
 }
+
+// SetPWMPolarity sets the polarity of the PWM output, which is used by Brightness(). With "false" the output is
+// inverted, e.g. for a LED which is connected to the supply voltage. An error is returned, if not supported by the
+// adaptor.
+func (d *LedDriver) SetPWMPolarity(normal bool) error {
+	return d.setPWMPolarity(d.driverCfg.pin, normal)
+}
+
+// SetPWMCenterAligned switches the PWM output, which is used by Brightness(), to center-aligned mode or back to
+// edge-aligned mode. An error is returned, if not supported by the adaptor.
+func (d *LedDriver) SetPWMCenterAligned(centerAligned bool) error {
+	return d.setPWMCenterAligned(d.driverCfg.pin, centerAligned)
+}
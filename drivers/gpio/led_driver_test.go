@@ -106,3 +106,52 @@ func TestLedBrightness(t *testing.T) {
 	}
 	require.EqualError(t, d.Brightness(150), "pwm error")
 }
+
+func TestLedSetPWMPolarity(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewLedDriver(a, "1")
+	var gotPin string
+	gotNormal := true
+	a.pwmPolarityFunc = func(pin string, normal bool) error {
+		gotPin = pin
+		gotNormal = normal
+		return nil
+	}
+	require.NoError(t, d.SetPWMPolarity(false))
+	assert.Equal(t, "1", gotPin)
+	assert.False(t, gotNormal)
+	a.pwmPolarityFunc = func(string, bool) error {
+		return errors.New("polarity error")
+	}
+	require.EqualError(t, d.SetPWMPolarity(true), "polarity error")
+}
+
+func TestLedSetPWMCenterAligned(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewLedDriver(a, "1")
+	var gotPin string
+	var gotCenterAligned bool
+	a.pwmCenterAlignFunc = func(pin string, centerAligned bool) error {
+		gotPin = pin
+		gotCenterAligned = centerAligned
+		return nil
+	}
+	require.NoError(t, d.SetPWMCenterAligned(true))
+	assert.Equal(t, "1", gotPin)
+	assert.True(t, gotCenterAligned)
+}
+
+func TestLedVerifiedWrite(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	a.digitalWriteFunc = func(string, byte) error { return nil }
+	// the output is stuck at low level
+	a.digitalReadOutFunc = func(string) (int, error) { return 0, nil }
+	d := NewLedDriver(a, "1", WithVerifiedWrite())
+	// act
+	errOn := d.On()
+	errOff := d.Off()
+	// assert
+	require.ErrorIs(t, errOn, ErrWriteVerificationFailed)
+	require.NoError(t, errOff)
+}
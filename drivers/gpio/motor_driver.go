@@ -2,6 +2,7 @@ package gpio
 
 import (
 	"fmt"
+	"math"
 
 	"gobot.io/x/gobot/v2"
 )
@@ -50,6 +51,7 @@ type MotorDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithVerifiedWrite"
 //	"WithMotorAnalog"
 //	"WithMotorDirectionPin"
 //	"WithMotorForwardPin"
@@ -142,9 +144,22 @@ func (d *MotorDriver) SetSpeed(value byte) error {
 	if writer, ok := d.connection.(PwmWriter); ok {
 		WithMotorAnalog().apply(d.motorCfg)
 		d.currentSpeed = value
-		return writer.PwmWrite(d.driverCfg.pin, value)
+		return d.recordError(writer.PwmWrite(d.driverCfg.pin, value))
 	}
-	return ErrPwmWriteUnsupported
+	return d.recordError(ErrPwmWriteUnsupported)
+}
+
+// SetPWMPolarity sets the polarity of the PWM output of the speed pin, which is used in analog mode. With "false" the
+// output is inverted, e.g. for H-bridges with active low inputs. An error is returned, if not supported by the adaptor.
+func (d *MotorDriver) SetPWMPolarity(normal bool) error {
+	return d.setPWMPolarity(d.driverCfg.pin, normal)
+}
+
+// SetPWMCenterAligned switches the PWM output of the speed pin to center-aligned mode or back to edge-aligned mode.
+// The center-aligned mode improves the symmetry of the motor current. An error is returned, if not supported by the
+// adaptor.
+func (d *MotorDriver) SetPWMCenterAligned(centerAligned bool) error {
+	return d.setPWMCenterAligned(d.driverCfg.pin, centerAligned)
 }
 
 // Forward runs the motor forward with the specified speed.
@@ -243,6 +258,31 @@ func (d *MotorDriver) Speed() byte {
 	return d.currentSpeed
 }
 
+// SetValue (interface gobot.Actuator) runs the motor with the given speed. Negative values run the motor backward,
+// see MotorDriver.Forward and MotorDriver.Backward.
+func (d *MotorDriver) SetValue(val float64) error {
+	if val < -255 || val > 255 {
+		return fmt.Errorf("motor speed (%v) must be between -255 and 255", val)
+	}
+	if val < 0 {
+		return d.Backward(byte(math.Round(-val)))
+	}
+	return d.Forward(byte(math.Round(val)))
+}
+
+// GetValue (interface gobot.Actuator) returns the current speed, negative for backward direction
+func (d *MotorDriver) GetValue() float64 {
+	if d.currentDirection == "backward" {
+		return -float64(d.currentSpeed)
+	}
+	return float64(d.currentSpeed)
+}
+
+// ValueRange (interface gobot.Actuator) returns the range of the speed, negative values are used for backward
+func (d *MotorDriver) ValueRange() (float64, float64) {
+	return -255, 255
+}
+
 func (d *MotorDriver) changeState(state byte) error {
 	d.currentState = state
 	if state == 1 {
@@ -165,6 +165,36 @@ func TestMotorSetSpeed(t *testing.T) {
 	require.NoError(t, d.SetSpeed(100))
 }
 
+func TestMotorSetPWMPolarity(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewMotorDriver(a, "1")
+	var gotPin string
+	gotNormal := true
+	a.pwmPolarityFunc = func(pin string, normal bool) error {
+		gotPin = pin
+		gotNormal = normal
+		return nil
+	}
+	require.NoError(t, d.SetPWMPolarity(false))
+	assert.Equal(t, "1", gotPin)
+	assert.False(t, gotNormal)
+}
+
+func TestMotorSetPWMCenterAligned(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewMotorDriver(a, "1")
+	var gotPin string
+	var gotCenterAligned bool
+	a.pwmCenterAlignFunc = func(pin string, centerAligned bool) error {
+		gotPin = pin
+		gotCenterAligned = centerAligned
+		return nil
+	}
+	require.NoError(t, d.SetPWMCenterAligned(true))
+	assert.Equal(t, "1", gotPin)
+	assert.True(t, gotCenterAligned)
+}
+
 func TestMotorForward(t *testing.T) {
 	d := initTestMotorDriver()
 	require.NoError(t, d.Forward(100))
@@ -199,3 +229,66 @@ func TestMotorDigital(t *testing.T) {
 	require.NoError(t, d.Off())
 	assert.Equal(t, uint8(0), d.currentState)
 }
+
+func TestMotorSetValue_Actuator(t *testing.T) {
+	var _ gobot.Actuator = (*MotorDriver)(nil)
+
+	tests := map[string]struct {
+		input         float64
+		wantSpeed     byte
+		wantDirection string
+		wantDirLevel  byte
+		wantErr       string
+	}{
+		"forward": {
+			input:         100,
+			wantSpeed:     100,
+			wantDirection: "forward",
+			wantDirLevel:  1,
+		},
+		"backward": {
+			input:         -200,
+			wantSpeed:     200,
+			wantDirection: "backward",
+			wantDirLevel:  0,
+		},
+		"error_out_of_range": {
+			input:         256,
+			wantDirection: "forward",
+			wantDirLevel:  0xFF,
+			wantErr:       "motor speed (256) must be between -255 and 255",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewMotorDriver(a, "1", WithMotorDirectionPin("2"))
+			var writtenSpeed byte
+			a.pwmWriteFunc = func(pin string, val byte) error {
+				writtenSpeed = val
+				return nil
+			}
+			dirLevel := byte(0xFF)
+			a.digitalWriteFunc = func(pin string, val byte) error {
+				dirLevel = val
+				return nil
+			}
+			// act
+			err := d.SetValue(tc.input)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.InDelta(t, tc.input, d.GetValue(), 0.0)
+			}
+			assert.Equal(t, tc.wantSpeed, writtenSpeed)
+			assert.Equal(t, tc.wantDirection, d.Direction())
+			assert.Equal(t, tc.wantDirLevel, dirLevel)
+			minVal, maxVal := d.ValueRange()
+			assert.InDelta(t, -255.0, minVal, 0.0)
+			assert.InDelta(t, 255.0, maxVal, 0.0)
+		})
+	}
+}
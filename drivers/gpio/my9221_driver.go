@@ -0,0 +1,151 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	// MY9221LedCount is the count of LEDs of the Grove LED bar
+	MY9221LedCount = 10
+
+	my9221CmdMode     = 0x0000 // 8 bit grayscale, internal oscillator, no output waveform selection
+	my9221Channels    = 12     // the LED bar uses 10 of the 12 channels, the remaining ones needs to be padded
+	my9221LatchDelay  = 220 * time.Microsecond
+	my9221LatchPulses = 4
+)
+
+// MY9221Driver is the gobot driver for the MY9221 LED driver used in the Grove LED bar. The chip is driven by a clock
+// and data line, whereby a bit is taken over on each edge of the clock.
+//
+// Datasheet: https://raw.githubusercontent.com/SeeedDocument/Grove-LED_Bar/master/res/MY9221_DS_1.0.pdf
+//
+// Library ported from: https://github.com/Seeed-Studio/Grove_LED_Bar
+type MY9221Driver struct {
+	*driver
+	pinClock   *DirectPinDriver
+	pinData    *DirectPinDriver
+	clockLevel byte
+	bits       uint16
+	brightness byte
+}
+
+// NewMY9221Driver return a new driver for MY9221 LED driver given a gobot.Connection and the clock and data pins.
+//
+// Supported options:
+//
+//	"WithName"
+func NewMY9221Driver(a gobot.Connection, clockPin string, dataPin string, opts ...interface{}) *MY9221Driver {
+	d := &MY9221Driver{
+		driver:     newDriver(a, "MY9221", opts...),
+		pinClock:   NewDirectPinDriver(a, clockPin),
+		pinData:    NewDirectPinDriver(a, dataPin),
+		brightness: 0xFF,
+	}
+	d.afterStart = d.initialize
+
+	/* TODO : Add commands */
+
+	return d
+}
+
+// SetLevel turns on the first LEDs up to the given level (0..10) and turns off the remaining LEDs.
+func (d *MY9221Driver) SetLevel(level uint8) error {
+	if level > MY9221LedCount {
+		return fmt.Errorf("level %d is out of range 0..%d", level, MY9221LedCount)
+	}
+
+	return d.SetBits(uint16(1)<<level - 1)
+}
+
+// SetBits turns on the LEDs according to the given mask, the lowest bit is related to the first LED.
+func (d *MY9221Driver) SetBits(mask uint16) error {
+	if mask >= 1<<MY9221LedCount {
+		return fmt.Errorf("mask 0x%04X has bits for more than %d LEDs", mask, MY9221LedCount)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.bits = mask
+	return d.display()
+}
+
+// SetBrightness changes the brightness (0..255) of all LEDs, which are turned on.
+func (d *MY9221Driver) SetBrightness(brightness byte) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.brightness = brightness
+	return d.display()
+}
+
+// initialize initializes the MY9221 and turns off all LEDs
+func (d *MY9221Driver) initialize() error {
+	if err := d.pinData.Off(); err != nil {
+		return err
+	}
+	if err := d.pinClock.Off(); err != nil {
+		return err
+	}
+	d.clockLevel = 0
+
+	return d.display()
+}
+
+// display sends the command word, the grayscale of all channels and latches the data afterwards
+func (d *MY9221Driver) display() error {
+	if err := d.send(my9221CmdMode); err != nil {
+		return err
+	}
+	for i := 0; i < my9221Channels; i++ {
+		var grayscale uint16
+		if i < MY9221LedCount && d.bits&(1<<i) > 0 {
+			grayscale = uint16(d.brightness)
+		}
+		if err := d.send(grayscale); err != nil {
+			return err
+		}
+	}
+
+	return d.latch()
+}
+
+// send writes the 16 bit word with MSB first, each bit is taken over by toggling the clock
+func (d *MY9221Driver) send(data uint16) error {
+	for i := 0; i < 16; i++ {
+		if err := d.pinData.DigitalWrite(byte(data >> 15)); err != nil {
+			return err
+		}
+		data <<= 1
+
+		d.clockLevel ^= 1
+		if err := d.pinClock.DigitalWrite(d.clockLevel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// latch takes over the data into the output registers, this is done by holding the data line low for the start time
+// followed by 4 pulses on the data line while the clock is kept
+func (d *MY9221Driver) latch() error {
+	if err := d.pinData.Off(); err != nil {
+		return err
+	}
+	time.Sleep(my9221LatchDelay)
+	for i := 0; i < my9221LatchPulses; i++ {
+		if err := d.pinData.On(); err != nil {
+			return err
+		}
+		if err := d.pinData.Off(); err != nil {
+			return err
+		}
+	}
+	time.Sleep(my9221LatchDelay)
+
+	return nil
+}
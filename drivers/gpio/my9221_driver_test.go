@@ -0,0 +1,190 @@
+package gpio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+)
+
+var _ gobot.Driver = (*MY9221Driver)(nil)
+
+func initTestMY9221DriverWithStubbedAdaptor() (*MY9221Driver, *gpioTestAdaptor) {
+	a := newGpioTestAdaptor()
+	return NewMY9221Driver(a, "1", "2"), a
+}
+
+// decodeMY9221Words decodes the words clocked out on pin "1" (clock) and pin "2" (data) and returns the remaining
+// writes after the last clock edge, which contains the latch sequence
+func decodeMY9221Words(t *testing.T, written []gpioTestWritten) ([]uint16, []gpioTestWritten) {
+	var words []uint16
+	var word uint16
+	var bitCount int
+	var dataLevel byte
+	var clockLevel byte
+	lastClockIdx := -1
+	for i, w := range written {
+		switch w.pin {
+		case "2":
+			dataLevel = w.val
+		case "1":
+			require.NotEqual(t, clockLevel, w.val, "clock needs to toggle on each bit (write %d)", i)
+			clockLevel = w.val
+			word = word<<1 | uint16(dataLevel)
+			bitCount++
+			if bitCount == 16 {
+				words = append(words, word)
+				word = 0
+				bitCount = 0
+			}
+			lastClockIdx = i
+		}
+	}
+	require.Zero(t, bitCount, "incomplete word")
+
+	return words, written[lastClockIdx+1:]
+}
+
+func TestNewMY9221Driver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewMY9221Driver(a, "1", "2")
+	// assert
+	assert.IsType(t, &MY9221Driver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "MY9221"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: driver specific attributes
+	assert.NotNil(t, d.pinClock)
+	assert.NotNil(t, d.pinData)
+	assert.Equal(t, uint8(0xFF), d.brightness)
+	assert.Equal(t, uint16(0), d.bits)
+}
+
+func TestNewMY9221Driver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const myName = "level meter"
+	panicFunc := func() {
+		NewMY9221Driver(newGpioTestAdaptor(), "1", "2", WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewMY9221Driver(newGpioTestAdaptor(), "1", "2", WithName(myName))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestMY9221Start(t *testing.T) {
+	// arrange
+	d, a := initTestMY9221DriverWithStubbedAdaptor()
+	// act
+	err := d.Start()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []gpioTestWritten{{pin: "2", val: 0}, {pin: "1", val: 0}}, a.written[:2])
+	words, _ := decodeMY9221Words(t, a.written[2:])
+	assert.Equal(t, make([]uint16, 13), words)
+}
+
+func TestMY9221SetLevel(t *testing.T) {
+	tests := map[string]struct {
+		level     uint8
+		wantWords []uint16
+		wantErr   string
+	}{
+		"level_3": {
+			level:     3,
+			wantWords: []uint16{0x0000, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		"full_on": {
+			level:     10,
+			wantWords: []uint16{0x0000, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0, 0},
+		},
+		"off": {
+			level:     0,
+			wantWords: make([]uint16, 13),
+		},
+		"error_out_of_range": {
+			level:   11,
+			wantErr: "level 11 is out of range 0..10",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestMY9221DriverWithStubbedAdaptor()
+			a.written = nil
+			// act
+			err := d.SetLevel(tc.level)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Empty(t, a.written)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, a.written, 13*16*2+1+2*my9221LatchPulses)
+			words, latch := decodeMY9221Words(t, a.written)
+			assert.Equal(t, tc.wantWords, words)
+			wantLatch := []gpioTestWritten{
+				{pin: "2", val: 0},
+				{pin: "2", val: 1}, {pin: "2", val: 0},
+				{pin: "2", val: 1}, {pin: "2", val: 0},
+				{pin: "2", val: 1}, {pin: "2", val: 0},
+				{pin: "2", val: 1}, {pin: "2", val: 0},
+			}
+			assert.Equal(t, wantLatch, latch)
+		})
+	}
+}
+
+func TestMY9221SetBits(t *testing.T) {
+	// arrange
+	d, a := initTestMY9221DriverWithStubbedAdaptor()
+	a.written = nil
+	// act
+	err := d.SetBits(0x0201)
+	// assert
+	require.NoError(t, err)
+	words, _ := decodeMY9221Words(t, a.written)
+	assert.Equal(t, []uint16{0x0000, 0xFF, 0, 0, 0, 0, 0, 0, 0, 0, 0xFF, 0, 0}, words)
+	require.EqualError(t, d.SetBits(0x0400), "mask 0x0400 has bits for more than 10 LEDs")
+}
+
+func TestMY9221SetBrightness(t *testing.T) {
+	// arrange
+	d, a := initTestMY9221DriverWithStubbedAdaptor()
+	require.NoError(t, d.SetLevel(2))
+	a.written = nil
+	// act
+	err := d.SetBrightness(0x20)
+	// assert
+	require.NoError(t, err)
+	words, _ := decodeMY9221Words(t, a.written)
+	assert.Equal(t, []uint16{0x0000, 0x20, 0x20, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, words)
+	assert.Equal(t, uint8(0x20), d.brightness)
+}
+
+func TestMY9221SetLevel_writeError(t *testing.T) {
+	// arrange
+	d, a := initTestMY9221DriverWithStubbedAdaptor()
+	a.simulateWriteError = true
+	// act
+	err := d.SetLevel(5)
+	// assert
+	require.EqualError(t, err, "write error")
+}
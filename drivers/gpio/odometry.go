@@ -0,0 +1,183 @@
+package gpio
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// odometryOptionApplier needs to be implemented by each configurable option type
+type odometryOptionApplier interface {
+	apply(cfg *odometryConfiguration)
+}
+
+// odometryConfiguration contains all changeable attributes of the odometry.
+type odometryConfiguration struct {
+	counterRange int
+}
+
+// odometryCounterRangeOption is the type for applying the range of the encoder counters to the configuration
+type odometryCounterRangeOption int
+
+// OdometryPose contains the estimated position and heading of a robot. The heading is given in radians within
+// -Pi..Pi, zero is the direction of the x-axis at the start and a positive value is counter-clockwise.
+type OdometryPose struct {
+	X       float64
+	Y       float64
+	Heading float64
+}
+
+// Odometry estimates the pose of a robot with differential drive by dead-reckoning, given the counts of the encoders
+// at the left and right wheel, e.g. the position of two RotaryEncoderDriver. The unit of the position is the unit of
+// the wheel radius and the track width. The error of the estimation grows with the driven distance, mainly caused by
+// slip of the wheels and inaccurate geometry.
+type Odometry struct {
+	odometryCfg        *odometryConfiguration
+	wheelRadius        float64
+	trackWidth         float64
+	ticksPerRevolution int
+	pose               OdometryPose
+	lastLeft           int
+	lastRight          int
+	initialized        bool
+	mutex              *sync.Mutex
+}
+
+// NewOdometry returns a new odometry for a robot with the given wheel radius and track width (distance between the
+// wheels) in the same unit, e.g. meter. The count of encoder ticks per revolution of the wheel is used to convert
+// the ticks to the driven distance. The start pose is zero.
+//
+// Supported options:
+//
+//	"WithOdometryCounterRange"
+func NewOdometry(wheelRadius, trackWidth float64, ticksPerRevolution int, opts ...interface{}) *Odometry {
+	o := &Odometry{
+		odometryCfg:        &odometryConfiguration{},
+		wheelRadius:        wheelRadius,
+		trackWidth:         trackWidth,
+		ticksPerRevolution: ticksPerRevolution,
+		mutex:              &sync.Mutex{},
+	}
+
+	for _, opt := range opts {
+		switch oo := opt.(type) {
+		case odometryOptionApplier:
+			oo.apply(o.odometryCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on odometry", opt))
+		}
+	}
+
+	if wheelRadius <= 0 || trackWidth <= 0 || ticksPerRevolution <= 0 {
+		panic(fmt.Sprintf("wheel radius (%v), track width (%v) and ticks per revolution (%d) of odometry must be "+
+			"greater than zero", wheelRadius, trackWidth, ticksPerRevolution))
+	}
+	if o.odometryCfg.counterRange < 0 {
+		panic(fmt.Sprintf("counter range (%d) of odometry must not be negative", o.odometryCfg.counterRange))
+	}
+
+	return o
+}
+
+// WithOdometryCounterRange is used for encoders with a limited counter, e.g. 65536 for a 16 bit hardware counter,
+// which wraps around from the max. value to zero and vice versa. The change of a counter between two calls of
+// Update() needs to be less than the half of the range. By default, the counters are not wrapped.
+func WithOdometryCounterRange(counterRange int) odometryOptionApplier {
+	return odometryCounterRangeOption(counterRange)
+}
+
+// Update integrates the change of the given encoder counts since the last call into the pose. The first call only
+// takes over the counts as reference. The counts needs to increase, when the wheel moves the robot forward.
+func (o *Odometry) Update(leftCount, rightCount int) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if !o.initialized {
+		o.lastLeft, o.lastRight = leftCount, rightCount
+		o.initialized = true
+
+		return
+	}
+
+	leftDelta := o.countDelta(o.lastLeft, leftCount)
+	rightDelta := o.countDelta(o.lastRight, rightCount)
+	o.lastLeft, o.lastRight = leftCount, rightCount
+
+	o.integrate(o.ticksToDistance(leftDelta), o.ticksToDistance(rightDelta))
+}
+
+// Pose returns the current estimation of the pose.
+func (o *Odometry) Pose() OdometryPose {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return o.pose
+}
+
+// Reset sets the pose to zero. The next call of Update() takes over the counts as new reference.
+func (o *Odometry) Reset() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.pose = OdometryPose{}
+	o.initialized = false
+}
+
+// countDelta returns the change between both counts, respecting the wrap-around of the counter
+func (o *Odometry) countDelta(last, current int) int {
+	delta := current - last
+	if o.odometryCfg.counterRange == 0 {
+		return delta
+	}
+
+	counterRange := o.odometryCfg.counterRange
+	delta %= counterRange
+	if delta >= counterRange/2 {
+		delta -= counterRange
+	} else if delta < -counterRange/2 {
+		delta += counterRange
+	}
+
+	return delta
+}
+
+func (o *Odometry) ticksToDistance(ticks int) float64 {
+	return 2 * math.Pi * o.wheelRadius * float64(ticks) / float64(o.ticksPerRevolution)
+}
+
+// integrate moves the pose by the given distances of both wheels. Both wheels are assumed to move with constant
+// speed, so the robot moves on a circular arc.
+func (o *Odometry) integrate(leftDistance, rightDistance float64) {
+	distance := (leftDistance + rightDistance) / 2
+	rotation := (rightDistance - leftDistance) / o.trackWidth
+	heading := o.pose.Heading
+
+	if math.Abs(rotation) < 1e-9 {
+		o.pose.X += distance * math.Cos(heading)
+		o.pose.Y += distance * math.Sin(heading)
+	} else {
+		radius := distance / rotation
+		o.pose.X += radius * (math.Sin(heading+rotation) - math.Sin(heading))
+		o.pose.Y -= radius * (math.Cos(heading+rotation) - math.Cos(heading))
+	}
+
+	o.pose.Heading = normalizeOdometryHeading(heading + rotation)
+}
+
+// normalizeOdometryHeading maps the given angle in radians to -Pi..Pi
+func normalizeOdometryHeading(angle float64) float64 {
+	angle = math.Mod(angle+math.Pi, 2*math.Pi)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+
+	return angle - math.Pi
+}
+
+func (o odometryCounterRangeOption) String() string {
+	return "counter range option for odometry"
+}
+
+func (o odometryCounterRangeOption) apply(cfg *odometryConfiguration) {
+	cfg.counterRange = int(o)
+}
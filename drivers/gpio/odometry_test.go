@@ -0,0 +1,139 @@
+package gpio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// geometry for the tests: each tick drives Pi/2000, so 1000 ticks drives Pi/2 and a turn on the spot by 90 degree
+// needs 250 ticks per wheel
+const (
+	odometryTestWheelRadius = 0.125
+	odometryTestTrackWidth  = 0.5
+	odometryTestTicks       = 500
+)
+
+func TestNewOdometry(t *testing.T) {
+	// act
+	o := NewOdometry(0.03, 0.15, 360)
+	// assert
+	assert.InDelta(t, 0.03, o.wheelRadius, 0.0)
+	assert.InDelta(t, 0.15, o.trackWidth, 0.0)
+	assert.Equal(t, 360, o.ticksPerRevolution)
+	assert.Equal(t, &odometryConfiguration{}, o.odometryCfg)
+	assert.Equal(t, OdometryPose{}, o.Pose())
+	assert.NotNil(t, o.mutex)
+}
+
+func TestNewOdometry_options(t *testing.T) {
+	// act
+	o := NewOdometry(0.03, 0.15, 360, WithOdometryCounterRange(65536))
+	// assert
+	assert.Equal(t, 65536, o.odometryCfg.counterRange)
+	assert.PanicsWithValue(t, "'name option for digital drivers' can not be applied on odometry", func() {
+		NewOdometry(0.03, 0.15, 360, WithName("crazy"))
+	})
+	assert.PanicsWithValue(t, "wheel radius (0.03), track width (0) and ticks per revolution (360) of odometry must "+
+		"be greater than zero", func() { NewOdometry(0.03, 0, 360) })
+	assert.PanicsWithValue(t, "counter range (-1) of odometry must not be negative", func() {
+		NewOdometry(0.03, 0.15, 360, WithOdometryCounterRange(-1))
+	})
+}
+
+func TestOdometryUpdate(t *testing.T) {
+	tests := map[string]struct {
+		opts   []interface{}
+		start  [2]int
+		deltas [][2]int
+		want   OdometryPose
+	}{
+		"no_motion": {
+			deltas: [][2]int{{0, 0}, {0, 0}},
+			want:   OdometryPose{},
+		},
+		"straight_forward": {
+			deltas: [][2]int{{200, 200}, {500, 500}, {300, 300}},
+			want:   OdometryPose{X: math.Pi / 2},
+		},
+		"straight_backward": {
+			start:  [2]int{1000, -1000},
+			deltas: [][2]int{{-500, -500}, {-500, -500}},
+			want:   OdometryPose{X: -math.Pi / 2},
+		},
+		"turn_on_the_spot_counter_clockwise": {
+			deltas: [][2]int{{-100, 100}, {-150, 150}},
+			want:   OdometryPose{Heading: math.Pi / 2},
+		},
+		"turn_on_the_spot_clockwise": {
+			deltas: [][2]int{{250, -250}},
+			want:   OdometryPose{Heading: -math.Pi / 2},
+		},
+		"heading_wraps_at_pi": {
+			deltas: [][2]int{{-250, 250}, {-250, 250}, {-250, 250}},
+			want:   OdometryPose{Heading: -math.Pi / 2},
+		},
+		"quarter_circle_left": {
+			// radius 1, the left wheel drives on radius 0.75 and the right one on radius 1.25
+			deltas: [][2]int{{300, 500}, {450, 750}},
+			want:   OdometryPose{X: 1, Y: 1, Heading: math.Pi / 2},
+		},
+		"straight_after_turn": {
+			deltas: [][2]int{{-250, 250}, {1000, 1000}},
+			want:   OdometryPose{Y: math.Pi / 2, Heading: math.Pi / 2},
+		},
+		"counter_wrap_forward": {
+			opts:   []interface{}{WithOdometryCounterRange(1024)},
+			start:  [2]int{900, 1000},
+			deltas: [][2]int{{500, 500}, {500, 500}},
+			want:   OdometryPose{X: math.Pi / 2},
+		},
+		"counter_wrap_backward": {
+			opts:   []interface{}{WithOdometryCounterRange(1024)},
+			start:  [2]int{100, 0},
+			deltas: [][2]int{{-500, -500}, {-500, -500}},
+			want:   OdometryPose{X: -math.Pi / 2},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			o := NewOdometry(odometryTestWheelRadius, odometryTestTrackWidth, odometryTestTicks, tc.opts...)
+			left, right := tc.start[0], tc.start[1]
+			o.Update(left, right)
+			// act
+			for _, delta := range tc.deltas {
+				left += delta[0]
+				right += delta[1]
+				if cr := o.odometryCfg.counterRange; cr > 0 {
+					// simulate the counter of the hardware
+					left = (left%cr + cr) % cr
+					right = (right%cr + cr) % cr
+				}
+				o.Update(left, right)
+			}
+			// assert
+			got := o.Pose()
+			assert.InDelta(t, tc.want.X, got.X, 0.0001)
+			assert.InDelta(t, tc.want.Y, got.Y, 0.0001)
+			assert.InDelta(t, tc.want.Heading, got.Heading, 0.0001)
+		})
+	}
+}
+
+func TestOdometryReset(t *testing.T) {
+	// arrange
+	o := NewOdometry(odometryTestWheelRadius, odometryTestTrackWidth, odometryTestTicks)
+	o.Update(0, 0)
+	o.Update(1000, 1000)
+	// act
+	o.Reset()
+	o.Update(2000, 3000) // new reference
+	o.Update(3000, 4000)
+	// assert
+	got := o.Pose()
+	assert.InDelta(t, math.Pi/2, got.X, 0.0001)
+	assert.InDelta(t, 0.0, got.Y, 0.0001)
+	assert.InDelta(t, 0.0, got.Heading, 0.0001)
+}
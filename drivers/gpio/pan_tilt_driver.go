@@ -0,0 +1,372 @@
+package gpio
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// panTiltStepInterval is the interval between two intermediate positions of a smooth movement, which is the frame
+// period of most hobby servos
+const panTiltStepInterval = 20 * time.Millisecond
+
+// panTiltOptionApplier needs to be implemented by each configurable option type
+type panTiltOptionApplier interface {
+	apply(cfg *panTiltConfiguration)
+}
+
+// panTiltConfiguration contains all changeable attributes of the driver.
+type panTiltConfiguration struct {
+	panMin       int
+	panMax       int
+	tiltMin      int
+	tiltMax      int
+	easeDuration time.Duration
+}
+
+// panTiltPanLimitsOption is the type for applying the soft limits of the pan axis to the configuration
+type panTiltPanLimitsOption [2]int
+
+// panTiltTiltLimitsOption is the type for applying the soft limits of the tilt axis to the configuration
+type panTiltTiltLimitsOption [2]int
+
+// panTiltEasingOption is the type for applying the duration of an eased movement to the configuration
+type panTiltEasingOption time.Duration
+
+// PanTiltAngles contains the angles of both axis, e.g. as data of the position event.
+type PanTiltAngles struct {
+	Pan  int
+	Tilt int
+}
+
+// PanTiltDriver represents a two-axis gimbal (pan-tilt), which is moved by two servos, e.g. for a camera.
+type PanTiltDriver struct {
+	*driver
+	panTiltCfg *panTiltConfiguration
+	gobot.Eventer
+	pan         *ServoDriver
+	tilt        *ServoDriver
+	moveMutex   *sync.Mutex // serializes the movements
+	cancelMutex *sync.Mutex // protects the cancel channel, the driver mutex is locked during halt
+	cancel      chan struct{}
+}
+
+// NewPanTiltDriver returns a new driver for a two-axis gimbal, given a ServoWriter and the pins of the pan (horizontal)
+// and tilt (vertical) servo. Each movement is clamped to the soft limits of the axis, which are 0-180 by default.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithPanTiltPanLimits"
+//	"WithPanTiltTiltLimits"
+//	"WithPanTiltEasing"
+//
+// Adds the following API Commands:
+//
+//	"MoveTo" - See PanTiltDriver.MoveTo
+//	"Stop" - See PanTiltDriver.Stop
+func NewPanTiltDriver(a ServoWriter, panPin string, tiltPin string, opts ...interface{}) *PanTiltDriver {
+	//nolint:forcetypeassert // no error return value, so there is no better way
+	d := &PanTiltDriver{
+		driver:      newDriver(a.(gobot.Connection), "PanTilt"),
+		panTiltCfg:  &panTiltConfiguration{panMin: 0, panMax: 180, tiltMin: 0, tiltMax: 180},
+		Eventer:     gobot.NewEventer(),
+		pan:         NewServoDriver(a, panPin),
+		tilt:        NewServoDriver(a, tiltPin),
+		moveMutex:   &sync.Mutex{},
+		cancelMutex: &sync.Mutex{},
+	}
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case panTiltOptionApplier:
+			o.apply(d.panTiltCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	if !validPanTiltLimits(d.panTiltCfg.panMin, d.panTiltCfg.panMax) {
+		panic(fmt.Sprintf("pan limits (%d-%d) of '%s' must be ordered and within 0-180", d.panTiltCfg.panMin,
+			d.panTiltCfg.panMax, d.driverCfg.name))
+	}
+	if !validPanTiltLimits(d.panTiltCfg.tiltMin, d.panTiltCfg.tiltMax) {
+		panic(fmt.Sprintf("tilt limits (%d-%d) of '%s' must be ordered and within 0-180", d.panTiltCfg.tiltMin,
+			d.panTiltCfg.tiltMax, d.driverCfg.name))
+	}
+
+	d.AddEvent(PanTiltPosition)
+
+	d.AddCommand("MoveTo", func(params map[string]interface{}) interface{} {
+		pan := int(params["pan"].(float64))   //nolint:forcetypeassert // ok here
+		tilt := int(params["tilt"].(float64)) //nolint:forcetypeassert // ok here
+		return d.MoveTo(pan, tilt)
+	})
+	d.AddCommand("Stop", func(params map[string]interface{}) interface{} {
+		d.Stop()
+		return nil
+	})
+
+	return d
+}
+
+// WithPanTiltPanLimits change the soft limits of the pan axis from default 0-180 to the given values.
+func WithPanTiltPanLimits(minAngle, maxAngle int) panTiltOptionApplier {
+	return panTiltPanLimitsOption{minAngle, maxAngle}
+}
+
+// WithPanTiltTiltLimits change the soft limits of the tilt axis from default 0-180 to the given values.
+func WithPanTiltTiltLimits(minAngle, maxAngle int) panTiltOptionApplier {
+	return panTiltTiltLimitsOption{minAngle, maxAngle}
+}
+
+// WithPanTiltEasing change the duration of a movement by MoveTo() from default 0 (immediately) to the given value.
+// Both axis are moved together with an ease-in-out profile, so they start and arrive at the same time. This also
+// applies the ease-in-out profile to each leg of Sweep().
+func WithPanTiltEasing(duration time.Duration) panTiltOptionApplier {
+	return panTiltEasingOption(duration)
+}
+
+// Pin returns the pins of both servos.
+func (d *PanTiltDriver) Pin() string {
+	return "pan=" + d.pan.Pin() + ", tilt=" + d.tilt.Pin()
+}
+
+// Pan returns the servo driver of the pan axis.
+func (d *PanTiltDriver) Pan() *ServoDriver { return d.pan }
+
+// Tilt returns the servo driver of the tilt axis.
+func (d *PanTiltDriver) Tilt() *ServoDriver { return d.tilt }
+
+// Position returns the current angles of both axis.
+func (d *PanTiltDriver) Position() (int, int) {
+	d.moveMutex.Lock()
+	defer d.moveMutex.Unlock()
+
+	return int(d.pan.Angle()), int(d.tilt.Angle())
+}
+
+// MoveTo moves both servos to the given angles, which are clamped to the soft limits of each axis. The movement is
+// eased, if configured by [gpio.WithPanTiltEasing]. A running movement is interrupted.
+//
+// Emits the Events:
+//
+//	"position" PanTiltAngles - the reached position
+func (d *PanTiltDriver) MoveTo(pan, tilt int) error {
+	cancel := d.beginMove()
+	defer d.endMove(cancel)
+
+	var ease func(float64) float64
+	if d.panTiltCfg.easeDuration > 0 {
+		ease = panTiltEaseInOut
+	}
+
+	return d.moveSmooth(cancel, pan, tilt, d.panTiltCfg.easeDuration, ease)
+}
+
+// Sweep moves both axis coordinated back and forth between the first and the second position for the given count
+// of cycles, each leg takes the given duration. The gimbal is moved to the first position before, and also ends
+// there. The angles are clamped to the soft limits of each axis. The call blocks until the sweep is finished or
+// interrupted by Stop(), Halt() or another movement.
+//
+// Emits the Events:
+//
+//	"position" PanTiltAngles - the reached position of each leg
+func (d *PanTiltDriver) Sweep(pan1, tilt1, pan2, tilt2 int, legDuration time.Duration, cycles int) error {
+	if legDuration <= 0 {
+		return fmt.Errorf("sweep duration (%s) of '%s' must be greater than zero", legDuration, d.driverCfg.name)
+	}
+	if cycles <= 0 {
+		return fmt.Errorf("sweep cycles (%d) of '%s' must be greater than zero", cycles, d.driverCfg.name)
+	}
+
+	cancel := d.beginMove()
+	defer d.endMove(cancel)
+
+	ease := panTiltLinear
+	if d.panTiltCfg.easeDuration > 0 {
+		ease = panTiltEaseInOut
+	}
+
+	if err := d.moveSmooth(cancel, pan1, tilt1, d.panTiltCfg.easeDuration, ease); err != nil {
+		return err
+	}
+
+	for i := 0; i < cycles && !panTiltCanceled(cancel); i++ {
+		if err := d.moveSmooth(cancel, pan2, tilt2, legDuration, ease); err != nil {
+			return err
+		}
+		if panTiltCanceled(cancel) {
+			break
+		}
+		if err := d.moveSmooth(cancel, pan1, tilt1, legDuration, ease); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop interrupts a running movement, e.g. a sweep. The servos stay at the last written position.
+func (d *PanTiltDriver) Stop() {
+	d.cancelMutex.Lock()
+	defer d.cancelMutex.Unlock()
+
+	if d.cancel != nil {
+		close(d.cancel)
+		d.cancel = nil
+	}
+}
+
+func (d *PanTiltDriver) shutdown() error {
+	d.Stop()
+	return nil
+}
+
+// beginMove interrupts a running movement and waits until it is finished, afterwards the movement mutex is locked
+func (d *PanTiltDriver) beginMove() chan struct{} {
+	d.Stop()
+
+	cancel := make(chan struct{})
+	d.moveMutex.Lock()
+
+	d.cancelMutex.Lock()
+	defer d.cancelMutex.Unlock()
+	d.cancel = cancel
+
+	return cancel
+}
+
+// endMove releases the movement mutex
+func (d *PanTiltDriver) endMove(cancel chan struct{}) {
+	d.cancelMutex.Lock()
+	if d.cancel == cancel {
+		d.cancel = nil
+	}
+	d.cancelMutex.Unlock()
+
+	d.moveMutex.Unlock()
+}
+
+// moveSmooth moves from the current position to the given one in the given duration. The intermediate positions are
+// written every step interval and are given by the ease function, which maps the elapsed part of the duration to the
+// part of the way, both within 0..1. Without an ease function, the target is written immediately. The position event
+// is published for the reached position, also if the movement is interrupted.
+func (d *PanTiltDriver) moveSmooth(cancel chan struct{}, pan, tilt int, duration time.Duration,
+	ease func(float64) float64,
+) error {
+	pan = clampPanTilt(pan, d.panTiltCfg.panMin, d.panTiltCfg.panMax)
+	tilt = clampPanTilt(tilt, d.panTiltCfg.tiltMin, d.panTiltCfg.tiltMax)
+	startPan, startTilt := int(d.pan.Angle()), int(d.tilt.Angle())
+
+	steps := int(duration / panTiltStepInterval)
+	if ease == nil || steps < 1 {
+		steps = 1
+	}
+
+	for i := 1; i <= steps; i++ {
+		if steps > 1 {
+			select {
+			case <-cancel:
+				d.publishPosition()
+				return nil
+			case <-time.After(panTiltStepInterval):
+			}
+		}
+
+		part := 1.0
+		if ease != nil {
+			part = ease(float64(i) / float64(steps))
+		}
+
+		nextPan := interpolatePanTilt(startPan, pan, part)
+		nextTilt := interpolatePanTilt(startTilt, tilt, part)
+		if err := d.writeAngles(nextPan, nextTilt); err != nil {
+			return err
+		}
+	}
+
+	d.publishPosition()
+
+	return nil
+}
+
+func (d *PanTiltDriver) writeAngles(pan, tilt int) error {
+	if err := d.pan.Move(uint8(pan)); err != nil {
+		return err
+	}
+
+	return d.tilt.Move(uint8(tilt))
+}
+
+func (d *PanTiltDriver) publishPosition() {
+	d.Publish(PanTiltPosition, PanTiltAngles{Pan: int(d.pan.Angle()), Tilt: int(d.tilt.Angle())})
+}
+
+func panTiltCanceled(cancel chan struct{}) bool {
+	select {
+	case <-cancel:
+		return true
+	default:
+		return false
+	}
+}
+
+func validPanTiltLimits(minAngle, maxAngle int) bool {
+	return minAngle >= 0 && maxAngle <= 180 && minAngle <= maxAngle
+}
+
+func clampPanTilt(angle, minAngle, maxAngle int) int {
+	if angle < minAngle {
+		return minAngle
+	}
+	if angle > maxAngle {
+		return maxAngle
+	}
+
+	return angle
+}
+
+func interpolatePanTilt(start, target int, part float64) int {
+	return start + int(math.Round(float64(target-start)*part))
+}
+
+func panTiltLinear(x float64) float64 {
+	return x
+}
+
+func panTiltEaseInOut(x float64) float64 {
+	return (1 - math.Cos(math.Pi*x)) / 2
+}
+
+func (o panTiltPanLimitsOption) String() string {
+	return "pan limits option for pan-tilt"
+}
+
+func (o panTiltTiltLimitsOption) String() string {
+	return "tilt limits option for pan-tilt"
+}
+
+func (o panTiltEasingOption) String() string {
+	return "easing option for pan-tilt"
+}
+
+func (o panTiltPanLimitsOption) apply(cfg *panTiltConfiguration) {
+	cfg.panMin = o[0]
+	cfg.panMax = o[1]
+}
+
+func (o panTiltTiltLimitsOption) apply(cfg *panTiltConfiguration) {
+	cfg.tiltMin = o[0]
+	cfg.tiltMax = o[1]
+}
+
+func (o panTiltEasingOption) apply(cfg *panTiltConfiguration) {
+	cfg.easeDuration = time.Duration(o)
+}
@@ -0,0 +1,262 @@
+//nolint:forcetypeassert // ok here
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+)
+
+var _ gobot.Driver = (*PanTiltDriver)(nil)
+
+// panTiltTestWrites records the written angles per pin
+type panTiltTestWrites struct {
+	mtx    sync.Mutex
+	angles map[string][]int
+}
+
+func initTestPanTiltDriver(opts ...interface{}) (*PanTiltDriver, *panTiltTestWrites) {
+	w := &panTiltTestWrites{angles: make(map[string][]int)}
+	a := newGpioTestAdaptor()
+	a.servoWriteFunc = func(pin string, val byte) error {
+		w.mtx.Lock()
+		defer w.mtx.Unlock()
+		w.angles[pin] = append(w.angles[pin], int(val))
+		return nil
+	}
+	return NewPanTiltDriver(a, "1", "2", opts...), w
+}
+
+func (w *panTiltTestWrites) get(pin string) []int {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return append([]int(nil), w.angles[pin]...)
+}
+
+func TestNewPanTiltDriver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewPanTiltDriver(a, "1", "2")
+	// assert
+	assert.IsType(t, &PanTiltDriver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "PanTilt"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: driver specific attributes
+	assert.Equal(t, "pan=1, tilt=2", d.Pin())
+	assert.Equal(t, "1", d.Pan().Pin())
+	assert.Equal(t, "2", d.Tilt().Pin())
+	assert.Equal(t, &panTiltConfiguration{panMin: 0, panMax: 180, tiltMin: 0, tiltMax: 180}, d.panTiltCfg)
+	assert.NotNil(t, d.Eventer)
+}
+
+func TestNewPanTiltDriver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const myName = "camera"
+	panicFunc := func() {
+		NewPanTiltDriver(newGpioTestAdaptor(), "1", "2", WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewPanTiltDriver(newGpioTestAdaptor(), "1", "2", WithName(myName), WithPanTiltPanLimits(10, 170),
+		WithPanTiltTiltLimits(30, 120), WithPanTiltEasing(time.Second))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, &panTiltConfiguration{panMin: 10, panMax: 170, tiltMin: 30, tiltMax: 120, easeDuration: time.Second},
+		d.panTiltCfg)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestNewPanTiltDriver_invalidLimits(t *testing.T) {
+	tests := map[string]struct {
+		opt       panTiltOptionApplier
+		wantPanic string
+	}{
+		"pan_not_ordered": {
+			opt:       WithPanTiltPanLimits(100, 80),
+			wantPanic: "pan limits (100-80) of 'gimbal' must be ordered and within 0-180",
+		},
+		"tilt_too_big": {
+			opt:       WithPanTiltTiltLimits(0, 181),
+			wantPanic: "tilt limits (0-181) of 'gimbal' must be ordered and within 0-180",
+		},
+		"tilt_negative": {
+			opt:       WithPanTiltTiltLimits(-1, 90),
+			wantPanic: "tilt limits (-1-90) of 'gimbal' must be ordered and within 0-180",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			opt := tc.opt
+			// act & assert
+			assert.PanicsWithValue(t, tc.wantPanic, func() {
+				NewPanTiltDriver(newGpioTestAdaptor(), "1", "2", WithName("gimbal"), opt)
+			})
+		})
+	}
+}
+
+func TestPanTiltMoveTo(t *testing.T) {
+	tests := map[string]struct {
+		pan      int
+		tilt     int
+		wantPan  int
+		wantTilt int
+	}{
+		"within_limits": {pan: 90, tilt: 45, wantPan: 90, wantTilt: 45},
+		"min_limits":    {pan: 10, tilt: 30, wantPan: 10, wantTilt: 30},
+		"clamp_min":     {pan: -20, tilt: 0, wantPan: 10, wantTilt: 30},
+		"clamp_max":     {pan: 200, tilt: 150, wantPan: 170, wantTilt: 120},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, w := initTestPanTiltDriver(WithPanTiltPanLimits(10, 170), WithPanTiltTiltLimits(30, 120))
+			events := d.Subscribe()
+			defer d.Unsubscribe(events)
+			// act
+			err := d.MoveTo(tc.pan, tc.tilt)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, []int{tc.wantPan}, w.get("1"))
+			assert.Equal(t, []int{tc.wantTilt}, w.get("2"))
+			gotPan, gotTilt := d.Position()
+			assert.Equal(t, tc.wantPan, gotPan)
+			assert.Equal(t, tc.wantTilt, gotTilt)
+			select {
+			case evt := <-events:
+				assert.Equal(t, PanTiltPosition, evt.Name)
+				assert.Equal(t, PanTiltAngles{Pan: tc.wantPan, Tilt: tc.wantTilt}, evt.Data)
+			case <-time.After(100 * time.Millisecond):
+				assert.Fail(t, "position event was not published")
+			}
+		})
+	}
+}
+
+func TestPanTiltMoveTo_easing(t *testing.T) {
+	// arrange
+	d, w := initTestPanTiltDriver(WithPanTiltEasing(5 * panTiltStepInterval))
+	// act
+	err := d.MoveTo(100, 50)
+	// assert
+	require.NoError(t, err)
+	pans := w.get("1")
+	tilts := w.get("2")
+	require.Len(t, pans, 5)
+	require.Len(t, tilts, 5)
+	assert.Equal(t, 100, pans[4])
+	assert.Equal(t, 50, tilts[4])
+	for i := 1; i < len(pans); i++ {
+		assert.GreaterOrEqual(t, pans[i], pans[i-1])
+		assert.GreaterOrEqual(t, tilts[i], tilts[i-1])
+	}
+	// ease-in-out: the middle steps are larger than the first one
+	assert.Greater(t, pans[2]-pans[1], pans[0])
+}
+
+func TestPanTiltMoveTo_error(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	a.servoWriteFunc = func(string, byte) error {
+		return errors.New("servo error")
+	}
+	d := NewPanTiltDriver(a, "1", "2")
+	// act
+	err := d.MoveTo(90, 90)
+	// assert
+	require.EqualError(t, err, "servo error")
+}
+
+func TestPanTiltSweep(t *testing.T) {
+	// arrange
+	d, w := initTestPanTiltDriver(WithPanTiltTiltLimits(20, 160))
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	// act
+	err := d.Sweep(0, 10, 40, 200, 2*panTiltStepInterval, 2)
+	// assert
+	require.NoError(t, err)
+	// first position, then two cycles with two steps for each leg
+	assert.Equal(t, []int{0, 20, 40, 20, 0, 20, 40, 20, 0}, w.get("1"))
+	assert.Equal(t, []int{20, 90, 160, 90, 20, 90, 160, 90, 20}, w.get("2"))
+	var got []PanTiltAngles
+	for len(got) < 5 {
+		select {
+		case evt := <-events:
+			got = append(got, evt.Data.(PanTiltAngles))
+		case <-time.After(100 * time.Millisecond):
+			require.Fail(t, "missing position events", "got %v", got)
+		}
+	}
+	assert.Equal(t, []PanTiltAngles{{0, 20}, {40, 160}, {0, 20}, {40, 160}, {0, 20}}, got)
+}
+
+func TestPanTiltSweep_invalid(t *testing.T) {
+	tests := map[string]struct {
+		duration time.Duration
+		cycles   int
+		wantErr  string
+	}{
+		"zero_duration": {
+			duration: 0,
+			cycles:   1,
+			wantErr:  "sweep duration (0s) of 'gimbal' must be greater than zero",
+		},
+		"zero_cycles": {
+			duration: time.Second,
+			cycles:   0,
+			wantErr:  "sweep cycles (0) of 'gimbal' must be greater than zero",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, w := initTestPanTiltDriver(WithName("gimbal"))
+			// act
+			err := d.Sweep(0, 0, 180, 180, tc.duration, tc.cycles)
+			// assert
+			require.EqualError(t, err, tc.wantErr)
+			assert.Empty(t, w.get("1"))
+		})
+	}
+}
+
+func TestPanTiltStop(t *testing.T) {
+	// arrange
+	d, w := initTestPanTiltDriver()
+	done := make(chan error)
+	go func() {
+		done <- d.Sweep(0, 0, 180, 180, 10*panTiltStepInterval, 100)
+	}()
+	time.Sleep(5 * panTiltStepInterval)
+	// act
+	require.NoError(t, d.Halt())
+	// assert
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "sweep was not stopped")
+	}
+	assert.Less(t, len(w.get("1")), 20)
+	assert.Nil(t, d.cancel)
+}
@@ -15,11 +15,15 @@ type pirMotionOptionApplier interface {
 // pirMotionConfiguration contains all changeable attributes of the driver.
 type pirMotionConfiguration struct {
 	readInterval time.Duration
+	heartbeat    time.Duration
 }
 
 // pirMotionReadIntervalOption is the type for applying another read interval to the configuration
 type pirMotionReadIntervalOption time.Duration
 
+// pirMotionHeartbeatOption is the type for applying a heartbeat interval to the configuration
+type pirMotionHeartbeatOption time.Duration
+
 // PIRMotionDriver represents a digital Proximity Infra Red (PIR) motion detecter
 //
 // Supported options:
@@ -39,7 +43,8 @@ type PIRMotionDriver struct {
 // Supported options:
 //
 //	"WithName"
-//	"WithButtonPollInterval"
+//	"WithPIRMotionPollInterval"
+//	"WithPIRMotionHeartbeat"
 func NewPIRMotionDriver(a DigitalReader, pin string, opts ...interface{}) *PIRMotionDriver {
 	//nolint:forcetypeassert // no error return value, so there is no better way
 	d := &PIRMotionDriver{
@@ -71,6 +76,13 @@ func WithPIRMotionPollInterval(interval time.Duration) pirMotionOptionApplier {
 	return pirMotionReadIntervalOption(interval)
 }
 
+// WithPIRMotionHeartbeat change the heartbeat interval from default 0 (deactivated) to the given value. Normally an
+// event is only published on a changed state. With a heartbeat, the event of the current state is published again,
+// if the state was not changed within this time.
+func WithPIRMotionHeartbeat(interval time.Duration) pirMotionOptionApplier {
+	return pirMotionHeartbeatOption(interval)
+}
+
 // Active gets the current state
 func (d *PIRMotionDriver) Active() bool {
 	// ensure that read and write can not interfere
@@ -88,10 +100,7 @@ func (d *PIRMotionDriver) Active() bool {
 //	MotionStopped int - On motion stopped
 //	Error error - On pirMotion error
 //
-// The PIRMotionDriver will send the MotionDetected event over and over,
-// just as long as motion is still being detected.
-// It will only send the MotionStopped event once, however, until
-// motion starts being detected again
+// Each event is only sent once on a changed state, except a heartbeat is configured by [gpio.WithPIRMotionHeartbeat].
 func (d *PIRMotionDriver) initialize() error {
 	if d.pirMotionCfg.readInterval == 0 {
 		return fmt.Errorf("the read interval for pirMotion needs to be greater than zero")
@@ -104,6 +113,8 @@ func (d *PIRMotionDriver) initialize() error {
 
 	d.halt = make(chan struct{})
 
+	detector := newValueChangeDetector(0, d.pirMotionCfg.heartbeat)
+
 	go func() {
 		for {
 			select {
@@ -112,7 +123,9 @@ func (d *PIRMotionDriver) initialize() error {
 				if err != nil {
 					d.Publish(Error, err)
 				}
-				d.update(newValue)
+				if (newValue == 0 || newValue == 1) && detector.changed(newValue) {
+					d.update(newValue)
+				}
 			case <-d.halt:
 				return
 			}
@@ -139,15 +152,11 @@ func (d *PIRMotionDriver) update(newValue int) {
 
 	switch newValue {
 	case 1:
-		if !d.active {
-			d.active = true
-			d.Publish(MotionDetected, newValue)
-		}
+		d.active = true
+		d.Publish(MotionDetected, newValue)
 	case 0:
-		if d.active {
-			d.active = false
-			d.Publish(MotionStopped, newValue)
-		}
+		d.active = false
+		d.Publish(MotionStopped, newValue)
 	}
 }
 
@@ -155,6 +164,14 @@ func (o pirMotionReadIntervalOption) String() string {
 	return "read interval option for PIR motion sensor"
 }
 
+func (o pirMotionHeartbeatOption) String() string {
+	return "heartbeat option for PIR motion sensor"
+}
+
 func (o pirMotionReadIntervalOption) apply(cfg *pirMotionConfiguration) {
 	cfg.readInterval = time.Duration(o)
 }
+
+func (o pirMotionHeartbeatOption) apply(cfg *pirMotionConfiguration) {
+	cfg.heartbeat = time.Duration(o)
+}
@@ -180,3 +180,60 @@ func TestPIRMotionActive(t *testing.T) {
 		})
 	}
 }
+
+func TestPIRMotionStart_changeDetection(t *testing.T) {
+	tests := map[string]struct {
+		opts       []interface{}
+		value      int
+		wantEvents []string
+	}{
+		"unchanged_no_event": {
+			value: 0,
+		},
+		"changed_one_event": {
+			value:      1,
+			wantEvents: []string{MotionDetected},
+		},
+		"unchanged_heartbeat": {
+			opts:       []interface{}{WithPIRMotionHeartbeat(20 * time.Millisecond)},
+			value:      1,
+			wantEvents: []string{MotionDetected, MotionDetected},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			value := tc.value // the read can be called once more after halt
+			a := newGpioTestAdaptor()
+			a.digitalReadFunc = func(string) (int, error) {
+				return value, nil
+			}
+			d := NewPIRMotionDriver(a, "1", append(tc.opts, WithPIRMotionPollInterval(time.Millisecond))...)
+			// act
+			require.NoError(t, d.Start())
+			defer func() { _ = d.Halt() }()
+			events := d.Subscribe()
+			defer d.Unsubscribe(events)
+			// assert
+			var gotEvents []string
+			timeout := time.After(50 * time.Millisecond)
+			for done := false; !done; {
+				select {
+				case evt := <-events:
+					gotEvents = append(gotEvents, evt.Name)
+				case <-timeout:
+					done = true
+				}
+			}
+			if len(tc.wantEvents) < 2 {
+				assert.Equal(t, tc.wantEvents, gotEvents)
+			} else {
+				// the count of heartbeats depends on the scheduling, but each event is the same
+				assert.GreaterOrEqual(t, len(gotEvents), len(tc.wantEvents))
+				for _, got := range gotEvents {
+					assert.Equal(t, tc.wantEvents[0], got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+const pwmInputDefaultPollInterval = 100 * time.Microsecond
+
+// pwmInputOptionApplier needs to be implemented by each configurable option type
+type pwmInputOptionApplier interface {
+	apply(cfg *pwmInputConfiguration)
+}
+
+// pwmInputConfiguration contains all changeable attributes of the driver.
+type pwmInputConfiguration struct {
+	pollInterval time.Duration
+	forcePolling bool
+}
+
+// pwmInputPollIntervalOption is the type for applying another poll interval to the configuration
+type pwmInputPollIntervalOption time.Duration
+
+// pwmInputForcePollingOption is the type for applying to use polling instead of edge detection of the adaptor
+type pwmInputForcePollingOption bool
+
+// PWMInputDriver measures the pulse width and the period of a PWM signal at an input pin. This is useful for sensors,
+// which encode the value by the duty cycle or the pulse width, e.g. RC receivers or some anemometers.
+//
+// If the adaptor supports edge detection for the pin (e.g. by "cdev"), the timestamps of the edges are used. Otherwise
+// the input is polled and the edges are timed by the driver, which is CPU consuming and less accurate.
+type PWMInputDriver struct {
+	*driver
+	pwmInputCfg *pwmInputConfiguration
+	usePolling  bool
+	halt        chan struct{}
+	startTime   time.Time
+	// values of the measurement, protected by the mutex
+	lastRising  time.Duration
+	risingValid bool
+	pulseWidth  time.Duration
+	period      time.Duration
+	// used only by the polling routine
+	lastState int
+}
+
+// NewPWMInputDriver returns a new driver for measuring a PWM signal, given a gobot.Connection and the input pin.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithPWMInputPollInterval"
+//	"WithPWMInputForcePolling"
+//
+// Adds the following API Commands:
+//
+//	"PulseWidth" - See PWMInputDriver.PulseWidth, in microseconds
+//	"Period"     - See PWMInputDriver.Period, in microseconds
+//	"DutyCycle"  - See PWMInputDriver.DutyCycle
+func NewPWMInputDriver(a gobot.Connection, pin string, opts ...interface{}) *PWMInputDriver {
+	d := &PWMInputDriver{
+		driver:      newDriver(a, "PWMInput", withPin(pin)),
+		pwmInputCfg: &pwmInputConfiguration{pollInterval: pwmInputDefaultPollInterval},
+		lastState:   -1,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case pwmInputOptionApplier:
+			o.apply(d.pwmInputCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddCommand("PulseWidth", func(params map[string]interface{}) interface{} {
+		return d.PulseWidth().Microseconds()
+	})
+	d.AddCommand("Period", func(params map[string]interface{}) interface{} {
+		return d.Period().Microseconds()
+	})
+	d.AddCommand("DutyCycle", func(params map[string]interface{}) interface{} {
+		return d.DutyCycle()
+	})
+
+	return d
+}
+
+// WithPWMInputPollInterval change the interval for polling the input from default 100us to the given value. The
+// value is only used, if the edge detection of the adaptor is not available or polling is forced.
+func WithPWMInputPollInterval(interval time.Duration) pwmInputOptionApplier {
+	return pwmInputPollIntervalOption(interval)
+}
+
+// WithPWMInputForcePolling use the polling of the input by the driver, also if the adaptor supports edge detection.
+func WithPWMInputForcePolling() pwmInputOptionApplier {
+	return pwmInputForcePollingOption(true)
+}
+
+// PulseWidth returns the last measured duration of the high level.
+func (d *PWMInputDriver) PulseWidth() time.Duration {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.pulseWidth
+}
+
+// Period returns the last measured duration between two rising edges.
+func (d *PWMInputDriver) Period() time.Duration {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.period
+}
+
+// DutyCycle returns the ratio of the last measured pulse width and period in the range 0..1. If no complete period
+// was measured yet, zero is returned.
+func (d *PWMInputDriver) DutyCycle() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.period <= 0 {
+		return 0
+	}
+
+	duty := float64(d.pulseWidth) / float64(d.period)
+	if duty > 1 {
+		duty = 1
+	}
+	return duty
+}
+
+// IsPolling returns true, if the input is polled by the driver instead of using the edge detection of the adaptor.
+func (d *PWMInputDriver) IsPolling() bool {
+	return d.usePolling
+}
+
+// initialize activates the edge detection of the adaptor or starts polling, if not supported
+func (d *PWMInputDriver) initialize() error {
+	d.startTime = time.Now()
+	d.risingValid = false
+	d.pulseWidth = 0
+	d.period = 0
+
+	if !d.pwmInputCfg.forcePolling {
+		if err := d.applyEdgeDetection(); err == nil {
+			d.usePolling = false
+			return nil
+		}
+	}
+
+	if d.pwmInputCfg.pollInterval <= 0 {
+		return fmt.Errorf("the poll interval for PWM input needs to be greater than zero")
+	}
+
+	if _, ok := d.connection.(DigitalReader); !ok {
+		return ErrDigitalReadUnsupported
+	}
+
+	d.usePolling = true
+	d.lastState = -1
+	halt := make(chan struct{})
+	d.halt = halt
+
+	go func() {
+		for {
+			select {
+			case <-halt:
+				return
+			default:
+				d.poll()
+				time.Sleep(d.pwmInputCfg.pollInterval)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (d *PWMInputDriver) shutdown() error {
+	if d.halt == nil {
+		return nil
+	}
+
+	close(d.halt)
+	d.halt = nil
+	return nil
+}
+
+// applyEdgeDetection tries to register the edge handler by the digital pin of the adaptor
+func (d *PWMInputDriver) applyEdgeDetection() error {
+	provider, ok := d.connection.(gobot.DigitalPinnerProvider)
+	if !ok {
+		return fmt.Errorf("digital pins are not supported by the platform")
+	}
+
+	pin, err := provider.DigitalPin(d.driverCfg.pin)
+	if err != nil {
+		return err
+	}
+
+	return pin.ApplyOptions(system.WithPinEventOnBothEdges(
+		func(_ int, t time.Duration, edge string, _ uint32, _ uint32) {
+			d.handleEdge(edge, t)
+		}))
+}
+
+// poll reads the input and calls the edge handler on changes
+func (d *PWMInputDriver) poll() {
+	state, err := d.digitalRead(d.driverCfg.pin)
+	if err != nil {
+		return
+	}
+
+	if d.lastState != -1 && state != d.lastState {
+		edge := system.DigitalPinEventFallingEdge
+		if state > d.lastState {
+			edge = system.DigitalPinEventRisingEdge
+		}
+		d.handleEdge(edge, time.Since(d.startTime))
+	}
+	d.lastState = state
+}
+
+// handleEdge updates the measurement with the given edge at the given timestamp
+func (d *PWMInputDriver) handleEdge(edge string, t time.Duration) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	switch edge {
+	case system.DigitalPinEventRisingEdge:
+		if d.risingValid {
+			d.period = t - d.lastRising
+		}
+		d.lastRising = t
+		d.risingValid = true
+	case system.DigitalPinEventFallingEdge:
+		if d.risingValid {
+			d.pulseWidth = t - d.lastRising
+		}
+	}
+}
+
+func (o pwmInputPollIntervalOption) String() string {
+	return "poll interval option for PWM input"
+}
+
+func (o pwmInputForcePollingOption) String() string {
+	return "force polling option for PWM input"
+}
+
+func (o pwmInputPollIntervalOption) apply(cfg *pwmInputConfiguration) {
+	cfg.pollInterval = time.Duration(o)
+}
+
+func (o pwmInputForcePollingOption) apply(cfg *pwmInputConfiguration) {
+	cfg.forcePolling = bool(o)
+}
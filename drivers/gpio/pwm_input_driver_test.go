@@ -0,0 +1,169 @@
+package gpio
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/system"
+)
+
+var _ gobot.Driver = (*PWMInputDriver)(nil)
+
+func TestNewPWMInputDriver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewPWMInputDriver(a, "7")
+	// assert
+	assert.IsType(t, &PWMInputDriver{}, d)
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.Name(), "PWMInput"))
+	assert.Equal(t, "7", d.Pin())
+	assert.Equal(t, a, d.connection)
+	require.NotNil(t, d.pwmInputCfg)
+	assert.Equal(t, pwmInputDefaultPollInterval, d.pwmInputCfg.pollInterval)
+	assert.False(t, d.pwmInputCfg.forcePolling)
+	assert.NotNil(t, d.Command("PulseWidth"))
+	assert.NotNil(t, d.Command("Period"))
+	assert.NotNil(t, d.Command("DutyCycle"))
+}
+
+func TestNewPWMInputDriver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const myName = "wind speed"
+	panicFunc := func() {
+		NewPWMInputDriver(newGpioTestAdaptor(), "1", WithName("crazy"), aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewPWMInputDriver(newGpioTestAdaptor(), "1", WithName(myName), WithPWMInputPollInterval(time.Millisecond),
+		WithPWMInputForcePolling())
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, time.Millisecond, d.pwmInputCfg.pollInterval)
+	assert.True(t, d.pwmInputCfg.forcePolling)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestPWMInput_handleEdge(t *testing.T) {
+	const (
+		rising  = system.DigitalPinEventRisingEdge
+		falling = system.DigitalPinEventFallingEdge
+	)
+	type edge struct {
+		name string
+		t    time.Duration
+	}
+	tests := map[string]struct {
+		edges      []edge
+		wantWidth  time.Duration
+		wantPeriod time.Duration
+		wantDuty   float64
+	}{
+		"no_edges": {},
+		"start_with_falling": {
+			edges:     []edge{{falling, 100}, {rising, 1000}, {falling, 1250}},
+			wantWidth: 250,
+		},
+		"one_period": {
+			edges:      []edge{{rising, 1000}, {falling, 1250}, {rising, 2000}},
+			wantWidth:  250,
+			wantPeriod: 1000,
+			wantDuty:   0.25,
+		},
+		"rc_servo_signal": {
+			edges: []edge{
+				{rising, 0}, {falling, 1500 * time.Microsecond},
+				{rising, 20 * time.Millisecond}, {falling, 21500 * time.Microsecond},
+				{rising, 40 * time.Millisecond},
+			},
+			wantWidth:  1500 * time.Microsecond,
+			wantPeriod: 20 * time.Millisecond,
+			wantDuty:   0.075,
+		},
+		"duty_change": {
+			edges: []edge{
+				{rising, 0}, {falling, 200}, {rising, 1000}, {falling, 1800}, {rising, 2000},
+			},
+			wantWidth:  800,
+			wantPeriod: 1000,
+			wantDuty:   0.8,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewPWMInputDriver(newGpioTestAdaptor(), "1")
+			// act
+			for _, e := range tc.edges {
+				d.handleEdge(e.name, e.t)
+			}
+			// assert
+			assert.Equal(t, tc.wantWidth, d.PulseWidth())
+			assert.Equal(t, tc.wantPeriod, d.Period())
+			assert.InDelta(t, tc.wantDuty, d.DutyCycle(), 1e-9)
+		})
+	}
+}
+
+func TestPWMInputStart_edgeDetection(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	a.pinMap["1"] = &digitalPinMock{}
+	d := NewPWMInputDriver(a, "1")
+	// act
+	err := d.Start()
+	// assert
+	require.NoError(t, err)
+	assert.False(t, d.IsPolling())
+	assert.Nil(t, d.halt)
+	require.NoError(t, d.Halt())
+}
+
+func TestPWMInputStart_polling(t *testing.T) {
+	// arrange: the pin is not known by the test adaptor, so polling is the fallback
+	a := newGpioTestAdaptor()
+	var mtx sync.Mutex
+	var reads int
+	a.digitalReadFunc = func(string) (int, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		// simulate a signal of 4 reads with a duty of 25%
+		reads++
+		if reads%4 == 0 {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	d := NewPWMInputDriver(a, "1", WithPWMInputPollInterval(time.Millisecond))
+	// act
+	err := d.Start()
+	// assert
+	require.NoError(t, err)
+	assert.True(t, d.IsPolling())
+	assert.Eventually(t, func() bool { return d.Period() > 0 && d.PulseWidth() > 0 }, time.Second, time.Millisecond)
+	// the timing of polling in tests is not exact, so only a rough value can be checked
+	duty := d.DutyCycle()
+	assert.Greater(t, duty, 0.0)
+	assert.Less(t, duty, 0.75)
+	require.NoError(t, d.Halt())
+	assert.Nil(t, d.halt)
+}
+
+func TestPWMInputStart_pollingError(t *testing.T) {
+	// arrange
+	d := NewPWMInputDriver(newGpioTestAdaptor(), "1", WithPWMInputForcePolling(), WithPWMInputPollInterval(0))
+	// act
+	err := d.Start()
+	// assert
+	require.EqualError(t, err, "the poll interval for PWM input needs to be greater than zero")
+}
@@ -31,6 +31,7 @@ type RelayDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithVerifiedWrite"
 //	"WithRelayInverted"
 //
 // Adds the following API Commands:
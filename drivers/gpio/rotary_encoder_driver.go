@@ -0,0 +1,212 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// rotaryEncoderTransitions contains the change of the position for each transition of the quadrature signals, the
+// index is "previous state << 2 | new state" with "state = a << 1 | b"
+var rotaryEncoderTransitions = [16]int{0, -1, 1, 0, 1, 0, 0, -1, -1, 0, 0, 1, 0, 1, -1, 0}
+
+// rotaryEncoderOptionApplier needs to be implemented by each configurable option type
+type rotaryEncoderOptionApplier interface {
+	apply(cfg *rotaryEncoderConfiguration)
+}
+
+// rotaryEncoderConfiguration contains all changeable attributes of the driver.
+type rotaryEncoderConfiguration struct {
+	readInterval time.Duration
+}
+
+// rotaryEncoderReadIntervalOption is the type for applying another read interval to the configuration
+type rotaryEncoderReadIntervalOption time.Duration
+
+// RotaryEncoderDriver represents an incremental rotary encoder with quadrature outputs (channel A and B), which are
+// wired to two digital inputs.
+type RotaryEncoderDriver struct {
+	*driver
+	rotaryEncoderCfg *rotaryEncoderConfiguration
+	gobot.Eventer
+	pinA     string
+	pinB     string
+	position int
+	halt     chan struct{}
+	// value for decoding, used only by the polling routine
+	state int
+}
+
+// NewRotaryEncoderDriver returns a driver for an incremental rotary encoder with a polling interval of 1 millisecond,
+// given a DigitalReader and the pins of channel A and B. Each edge of both channels is counted, so the position
+// changes by 4 per cycle of the quadrature signal. Rotating from A to B leading increments the position.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithRotaryEncoderPollInterval"
+func NewRotaryEncoderDriver(a DigitalReader, pinA, pinB string, opts ...interface{}) *RotaryEncoderDriver {
+	//nolint:forcetypeassert // no error return value, so there is no better way
+	d := &RotaryEncoderDriver{
+		driver: newDriver(a.(gobot.Connection), "RotaryEncoder"),
+		rotaryEncoderCfg: &rotaryEncoderConfiguration{
+			readInterval: 1 * time.Millisecond,
+		},
+		Eventer: gobot.NewEventer(),
+		pinA:    pinA,
+		pinB:    pinB,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case rotaryEncoderOptionApplier:
+			o.apply(d.rotaryEncoderCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddEvent(Error)
+
+	d.AddCommand("Position", func(params map[string]interface{}) interface{} {
+		return d.Position()
+	})
+	d.AddCommand("ResetPosition", func(params map[string]interface{}) interface{} {
+		d.ResetPosition()
+		return nil
+	})
+
+	return d
+}
+
+// WithRotaryEncoderPollInterval change the asynchronous cyclic reading interval from default 1ms to the given value.
+// The interval needs to be shorter than the time between two edges of the quadrature signals, otherwise counts are
+// lost.
+func WithRotaryEncoderPollInterval(interval time.Duration) rotaryEncoderOptionApplier {
+	return rotaryEncoderReadIntervalOption(interval)
+}
+
+// Position returns the counted position since start or the last call of ResetPosition().
+func (d *RotaryEncoderDriver) Position() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.position
+}
+
+// ResetPosition sets the counted position to zero.
+func (d *RotaryEncoderDriver) ResetPosition() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.position = 0
+}
+
+// Pins returns the pins of channel A and B.
+func (d *RotaryEncoderDriver) Pins() (string, string) {
+	return d.pinA, d.pinB
+}
+
+// initialize the RotaryEncoderDriver, reads the initial state and polls the state of the inputs at the given
+// interval.
+//
+// Emits the Events:
+//
+//	Error error - On read error
+func (d *RotaryEncoderDriver) initialize() error {
+	if d.rotaryEncoderCfg.readInterval == 0 {
+		return fmt.Errorf("the read interval for rotary encoder needs to be greater than zero")
+	}
+
+	if d.pinA == "" || d.pinB == "" {
+		return fmt.Errorf("pins for channel A and B are mandatory for rotary encoder '%s'", d.driverCfg.name)
+	}
+
+	state, err := d.read()
+	if err != nil {
+		return err
+	}
+	d.state = state
+
+	halt := make(chan struct{})
+	d.halt = halt
+
+	go func() {
+		for {
+			select {
+			case <-time.After(d.rotaryEncoderCfg.readInterval):
+				if err := d.poll(); err != nil {
+					d.Publish(Error, err)
+				}
+			case <-halt:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (d *RotaryEncoderDriver) shutdown() error {
+	if d.halt == nil {
+		// cyclic reading deactivated
+		return nil
+	}
+
+	close(d.halt) // broadcast halt, also to the test
+	d.halt = nil
+	return nil
+}
+
+// poll reads both inputs and evaluates the change of the position
+func (d *RotaryEncoderDriver) poll() error {
+	state, err := d.read()
+	if err != nil {
+		return err
+	}
+
+	d.evaluate(state)
+	return nil
+}
+
+// read returns the combined state of both channels
+func (d *RotaryEncoderDriver) read() (int, error) {
+	a, err := d.digitalRead(d.pinA)
+	if err != nil {
+		return 0, err
+	}
+	b, err := d.digitalRead(d.pinB)
+	if err != nil {
+		return 0, err
+	}
+
+	return (a&1)<<1 | b&1, nil
+}
+
+// evaluate decodes the transition from the last to the given state, an invalid transition (both channels changed)
+// is ignored
+func (d *RotaryEncoderDriver) evaluate(state int) {
+	change := rotaryEncoderTransitions[d.state<<2|state]
+	d.state = state
+
+	if change == 0 {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.position += change
+}
+
+func (o rotaryEncoderReadIntervalOption) String() string {
+	return "read interval option for rotary encoders"
+}
+
+func (o rotaryEncoderReadIntervalOption) apply(cfg *rotaryEncoderConfiguration) {
+	cfg.readInterval = time.Duration(o)
+}
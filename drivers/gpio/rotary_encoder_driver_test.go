@@ -0,0 +1,150 @@
+//nolint:forcetypeassert // ok here
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*RotaryEncoderDriver)(nil)
+
+func TestNewRotaryEncoderDriver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewRotaryEncoderDriver(a, "1", "2")
+	// assert
+	assert.IsType(t, &RotaryEncoderDriver{}, d)
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "RotaryEncoder"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.Eventer)
+	assert.Nil(t, d.halt) // will be created on initialize
+	pinA, pinB := d.Pins()
+	assert.Equal(t, "1", pinA)
+	assert.Equal(t, "2", pinB)
+	assert.Equal(t, 0, d.Position())
+	require.NotNil(t, d.rotaryEncoderCfg)
+	assert.Equal(t, time.Millisecond, d.rotaryEncoderCfg.readInterval)
+	assert.NotNil(t, d.Command("Position"))
+	assert.NotNil(t, d.Command("ResetPosition"))
+}
+
+func TestNewRotaryEncoderDriver_options(t *testing.T) {
+	// arrange
+	const myName = "knob"
+	// act
+	d := NewRotaryEncoderDriver(newGpioTestAdaptor(), "1", "2", WithName(myName),
+		WithRotaryEncoderPollInterval(5*time.Millisecond))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, 5*time.Millisecond, d.rotaryEncoderCfg.readInterval)
+}
+
+func TestRotaryEncoder_evaluate(t *testing.T) {
+	tests := map[string]struct {
+		states       []int
+		wantPosition int
+	}{
+		"forward_cycle": {
+			states:       []int{2, 3, 1, 0},
+			wantPosition: 4,
+		},
+		"backward_cycle": {
+			states:       []int{1, 3, 2, 0},
+			wantPosition: -4,
+		},
+		"forward_and_back": {
+			states:       []int{2, 3, 2, 0},
+			wantPosition: 0,
+		},
+		"no_change": {
+			states:       []int{0, 0, 0},
+			wantPosition: 0,
+		},
+		"invalid_transition_ignored": {
+			states:       []int{3, 1, 0},
+			wantPosition: 2,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewRotaryEncoderDriver(newGpioTestAdaptor(), "1", "2")
+			// act
+			for _, state := range tc.states {
+				d.evaluate(state)
+			}
+			// assert
+			assert.Equal(t, tc.wantPosition, d.Position())
+			d.ResetPosition()
+			assert.Equal(t, 0, d.Position())
+		})
+	}
+}
+
+func TestRotaryEncoderStart(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	var mtx sync.Mutex
+	levels := map[string]int{"1": 0, "2": 0}
+	a.digitalReadFunc = func(pin string) (int, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return levels[pin], nil
+	}
+	d := NewRotaryEncoderDriver(a, "1", "2", WithRotaryEncoderPollInterval(time.Millisecond))
+	require.NoError(t, d.Start())
+	// act
+	mtx.Lock()
+	levels["1"] = 1
+	mtx.Unlock()
+	// assert
+	assert.Eventually(t, func() bool { return d.Position() == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, d.Halt())
+}
+
+func TestRotaryEncoderStart_error(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	var mtx sync.Mutex
+	var failing bool
+	a.digitalReadFunc = func(string) (int, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		if failing {
+			return 0, errors.New("read error")
+		}
+		return 0, nil
+	}
+	d := NewRotaryEncoderDriver(a, "1", "2", WithRotaryEncoderPollInterval(time.Millisecond))
+	sem := make(chan error, 10)
+	_ = d.On(Error, func(data interface{}) {
+		sem <- data.(error)
+	})
+	// act & assert
+	require.NoError(t, d.Start())
+	mtx.Lock()
+	failing = true
+	mtx.Unlock()
+	select {
+	case err := <-sem:
+		require.EqualError(t, err, "read error")
+	case <-time.After(time.Second):
+		require.Fail(t, "error event was not published")
+	}
+	require.NoError(t, d.Halt())
+	// initial read fails
+	require.EqualError(t, NewRotaryEncoderDriver(a, "1", "2").Start(), "read error")
+	// no pins
+	require.EqualError(t, NewRotaryEncoderDriver(a, "1", "", WithName("empty")).Start(),
+		"pins for channel A and B are mandatory for rotary encoder 'empty'")
+}
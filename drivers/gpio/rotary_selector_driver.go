@@ -0,0 +1,250 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// rotarySelectorOptionApplier needs to be implemented by each configurable option type
+type rotarySelectorOptionApplier interface {
+	apply(cfg *rotarySelectorConfiguration)
+}
+
+// rotarySelectorConfiguration contains all changeable attributes of the driver.
+type rotarySelectorConfiguration struct {
+	readInterval  time.Duration
+	debounceCount int
+	activeState   int
+}
+
+// rotarySelectorReadIntervalOption is the type for applying another read interval to the configuration
+type rotarySelectorReadIntervalOption time.Duration
+
+// rotarySelectorDebounceCountOption is the type for applying another count of stable reads to the configuration
+type rotarySelectorDebounceCountOption int
+
+// rotarySelectorActiveStateOption is the type for applying another active state to the configuration
+type rotarySelectorActiveStateOption int
+
+// RotarySelectorDriver represents a single-pole multi-throw rotary switch (multi-position switch), which is wired to
+// one digital input per position.
+type RotarySelectorDriver struct {
+	*driver
+	rotarySelectorCfg *rotarySelectorConfiguration
+	gobot.Eventer
+	pins     []string
+	position int
+	halt     chan struct{}
+	// values for debouncing, used only by the polling routine
+	candidate      int
+	candidateCount int
+}
+
+// NewRotarySelectorDriver returns a driver for a rotary selector switch with a polling interval of 10 milliseconds,
+// given a DigitalReader and the pins in the order of the positions. The position is -1 until a stable position was
+// read.
+//
+// During rotation the switch can be transiently all-open or multiple-closed. In this case the last stable position
+// is held. A new position is taken over, when it was read consecutively for the debounce count (default 3).
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithRotarySelectorPollInterval"
+//	"WithRotarySelectorDebounceCount"
+//	"WithRotarySelectorActiveState"
+func NewRotarySelectorDriver(a DigitalReader, pins []string, opts ...interface{}) *RotarySelectorDriver {
+	//nolint:forcetypeassert // no error return value, so there is no better way
+	d := &RotarySelectorDriver{
+		driver: newDriver(a.(gobot.Connection), "RotarySelector"),
+		rotarySelectorCfg: &rotarySelectorConfiguration{
+			readInterval:  10 * time.Millisecond,
+			debounceCount: 3,
+			activeState:   1,
+		},
+		Eventer:   gobot.NewEventer(),
+		pins:      pins,
+		position:  -1,
+		candidate: -1,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case rotarySelectorOptionApplier:
+			o.apply(d.rotarySelectorCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddEvent(RotarySelectorPosition)
+	d.AddEvent(Error)
+
+	d.AddCommand("Position", func(params map[string]interface{}) interface{} {
+		return d.Position()
+	})
+
+	return d
+}
+
+// WithRotarySelectorPollInterval change the asynchronous cyclic reading interval from default 10ms to the given
+// value.
+func WithRotarySelectorPollInterval(interval time.Duration) rotarySelectorOptionApplier {
+	return rotarySelectorReadIntervalOption(interval)
+}
+
+// WithRotarySelectorDebounceCount change the count of consecutive equal reads, which are needed to take over a new
+// position, from default 3 to the given value.
+func WithRotarySelectorDebounceCount(count int) rotarySelectorOptionApplier {
+	return rotarySelectorDebounceCountOption(count)
+}
+
+// WithRotarySelectorActiveState change the state of the input for the selected position from default 1 to the given
+// value. Use 0, if the common pole is connected to ground and the inputs have pull-up resistors.
+func WithRotarySelectorActiveState(s int) rotarySelectorOptionApplier {
+	return rotarySelectorActiveStateOption(s)
+}
+
+// Position returns the index of the last stable selected position, or -1 if not known yet.
+func (d *RotarySelectorDriver) Position() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.position
+}
+
+// Pins returns the pins of all positions.
+func (d *RotarySelectorDriver) Pins() []string {
+	return d.pins
+}
+
+// initialize the RotarySelectorDriver and polls the state of the inputs at the given interval.
+//
+// Emits the Events:
+//
+//	Position int - On change of the stable position
+//	Error error - On read error
+func (d *RotarySelectorDriver) initialize() error {
+	if d.rotarySelectorCfg.readInterval == 0 {
+		return fmt.Errorf("the read interval for rotary selector needs to be greater than zero")
+	}
+
+	if len(d.pins) == 0 {
+		return fmt.Errorf("no pins given for rotary selector '%s'", d.driverCfg.name)
+	}
+
+	halt := make(chan struct{})
+	d.halt = halt
+
+	go func() {
+		for {
+			select {
+			case <-time.After(d.rotarySelectorCfg.readInterval):
+				if err := d.poll(); err != nil {
+					d.Publish(Error, err)
+				}
+			case <-halt:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (d *RotarySelectorDriver) shutdown() error {
+	if d.halt == nil {
+		// cyclic reading deactivated
+		return nil
+	}
+
+	close(d.halt) // broadcast halt, also to the test
+	d.halt = nil
+	return nil
+}
+
+// poll reads all inputs and evaluates the selected position
+func (d *RotarySelectorDriver) poll() error {
+	states := make([]int, len(d.pins))
+	for i, pin := range d.pins {
+		val, err := d.digitalRead(pin)
+		if err != nil {
+			return err
+		}
+		states[i] = val
+	}
+
+	d.evaluate(states)
+	return nil
+}
+
+// evaluate debounces the read states and publishes a changed stable position
+func (d *RotarySelectorDriver) evaluate(states []int) {
+	selected := -1
+	for i, state := range states {
+		if state != d.rotarySelectorCfg.activeState {
+			continue
+		}
+		if selected != -1 {
+			// multiple closed, transient state
+			selected = -1
+			break
+		}
+		selected = i
+	}
+
+	if selected == -1 {
+		// all open or multiple closed, hold the last stable position
+		d.candidate = -1
+		d.candidateCount = 0
+		return
+	}
+
+	if selected != d.candidate {
+		d.candidate = selected
+		d.candidateCount = 0
+	}
+	d.candidateCount++
+
+	if d.candidateCount < d.rotarySelectorCfg.debounceCount {
+		return
+	}
+
+	d.mutex.Lock()
+	changed := d.position != selected
+	d.position = selected
+	d.mutex.Unlock()
+
+	if changed {
+		d.Publish(RotarySelectorPosition, selected)
+	}
+}
+
+func (o rotarySelectorReadIntervalOption) String() string {
+	return "read interval option for rotary selectors"
+}
+
+func (o rotarySelectorDebounceCountOption) String() string {
+	return "debounce count option for rotary selectors"
+}
+
+func (o rotarySelectorActiveStateOption) String() string {
+	return "active state option for rotary selectors"
+}
+
+func (o rotarySelectorReadIntervalOption) apply(cfg *rotarySelectorConfiguration) {
+	cfg.readInterval = time.Duration(o)
+}
+
+func (o rotarySelectorDebounceCountOption) apply(cfg *rotarySelectorConfiguration) {
+	cfg.debounceCount = int(o)
+}
+
+func (o rotarySelectorActiveStateOption) apply(cfg *rotarySelectorConfiguration) {
+	cfg.activeState = int(o)
+}
@@ -0,0 +1,218 @@
+//nolint:forcetypeassert // ok here
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+)
+
+var _ gobot.Driver = (*RotarySelectorDriver)(nil)
+
+func TestNewRotarySelectorDriver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	pins := []string{"1", "2", "3"}
+	// act
+	d := NewRotarySelectorDriver(a, pins)
+	// assert
+	assert.IsType(t, &RotarySelectorDriver{}, d)
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "RotarySelector"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.Eventer)
+	assert.Nil(t, d.halt) // will be created on initialize
+	assert.Equal(t, pins, d.Pins())
+	assert.Equal(t, -1, d.Position())
+	require.NotNil(t, d.rotarySelectorCfg)
+	assert.Equal(t, 10*time.Millisecond, d.rotarySelectorCfg.readInterval)
+	assert.Equal(t, 3, d.rotarySelectorCfg.debounceCount)
+	assert.Equal(t, 1, d.rotarySelectorCfg.activeState)
+	assert.NotNil(t, d.Command("Position"))
+}
+
+func TestNewRotarySelectorDriver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const (
+		myName     = "mode"
+		cycReadDur = 30 * time.Millisecond
+	)
+	panicFunc := func() {
+		NewRotarySelectorDriver(newGpioTestAdaptor(), []string{"1"}, WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewRotarySelectorDriver(newGpioTestAdaptor(), []string{"1"}, WithName(myName),
+		WithRotarySelectorPollInterval(cycReadDur), WithRotarySelectorDebounceCount(5),
+		WithRotarySelectorActiveState(0))
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.Equal(t, cycReadDur, d.rotarySelectorCfg.readInterval)
+	assert.Equal(t, 5, d.rotarySelectorCfg.debounceCount)
+	assert.Equal(t, 0, d.rotarySelectorCfg.activeState)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestRotarySelector_evaluate(t *testing.T) {
+	tests := map[string]struct {
+		activeState   int
+		reads         [][]int
+		wantPosition  int
+		wantPublished []int
+	}{
+		"stable_position": {
+			activeState:   1,
+			reads:         [][]int{{0, 1, 0}, {0, 1, 0}, {0, 1, 0}, {0, 1, 0}},
+			wantPosition:  1,
+			wantPublished: []int{1},
+		},
+		"not_debounced": {
+			activeState:  1,
+			reads:        [][]int{{0, 1, 0}, {0, 1, 0}},
+			wantPosition: -1,
+		},
+		"position_change": {
+			activeState:   1,
+			reads:         [][]int{{1, 0, 0}, {1, 0, 0}, {1, 0, 0}, {0, 0, 1}, {0, 0, 1}, {0, 0, 1}},
+			wantPosition:  2,
+			wantPublished: []int{0, 2},
+		},
+		"transient_all_open": {
+			activeState: 1,
+			reads: [][]int{
+				{1, 0, 0}, {1, 0, 0}, {1, 0, 0},
+				{0, 0, 0}, {0, 0, 0}, {0, 0, 0}, {0, 0, 0},
+			},
+			wantPosition:  0,
+			wantPublished: []int{0},
+		},
+		"transient_multiple_closed": {
+			activeState: 1,
+			reads: [][]int{
+				{1, 0, 0}, {1, 0, 0}, {1, 0, 0},
+				{1, 1, 0}, {1, 1, 0}, {1, 1, 0},
+				{0, 1, 0}, {0, 1, 0}, {0, 1, 0},
+			},
+			wantPosition:  1,
+			wantPublished: []int{0, 1},
+		},
+		"glitch_interrupts_debouncing": {
+			activeState:   1,
+			reads:         [][]int{{1, 0, 0}, {1, 0, 0}, {0, 1, 0}, {1, 0, 0}, {1, 0, 0}},
+			wantPosition:  -1,
+			wantPublished: nil,
+		},
+		"active_low": {
+			activeState:   0,
+			reads:         [][]int{{1, 1, 0}, {1, 1, 0}, {1, 1, 0}},
+			wantPosition:  2,
+			wantPublished: []int{2},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewRotarySelectorDriver(newGpioTestAdaptor(), []string{"1", "2", "3"},
+				WithRotarySelectorActiveState(tc.activeState))
+			var published []int
+			var mtx sync.Mutex
+			sem := make(chan struct{}, 10)
+			_ = d.On(RotarySelectorPosition, func(data interface{}) {
+				mtx.Lock()
+				defer mtx.Unlock()
+				published = append(published, data.(int))
+				sem <- struct{}{}
+			})
+			// act
+			for _, states := range tc.reads {
+				d.evaluate(states)
+			}
+			// assert
+			for range tc.wantPublished {
+				select {
+				case <-sem:
+				case <-time.After(time.Second):
+					require.Fail(t, "position event was not published")
+				}
+			}
+			assert.Equal(t, tc.wantPosition, d.Position())
+			mtx.Lock()
+			defer mtx.Unlock()
+			assert.Equal(t, tc.wantPublished, published)
+		})
+	}
+}
+
+func TestRotarySelectorStart(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	var mtx sync.Mutex
+	active := "2"
+	a.digitalReadFunc = func(pin string) (int, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		if pin == active {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	d := NewRotarySelectorDriver(a, []string{"1", "2", "3"}, WithRotarySelectorPollInterval(time.Millisecond))
+	sem := make(chan int, 10)
+	_ = d.On(RotarySelectorPosition, func(data interface{}) {
+		sem <- data.(int)
+	})
+	// act & assert
+	require.NoError(t, d.Start())
+	select {
+	case pos := <-sem:
+		assert.Equal(t, 1, pos)
+	case <-time.After(time.Second):
+		require.Fail(t, "position event was not published")
+	}
+	mtx.Lock()
+	active = "3"
+	mtx.Unlock()
+	select {
+	case pos := <-sem:
+		assert.Equal(t, 2, pos)
+	case <-time.After(time.Second):
+		require.Fail(t, "position event was not published")
+	}
+	require.NoError(t, d.Halt())
+}
+
+func TestRotarySelectorStart_error(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	a.digitalReadFunc = func(string) (int, error) {
+		return 0, errors.New("read error")
+	}
+	d := NewRotarySelectorDriver(a, []string{"1", "2"}, WithRotarySelectorPollInterval(time.Millisecond))
+	sem := make(chan error, 10)
+	_ = d.On(Error, func(data interface{}) {
+		sem <- data.(error)
+	})
+	// act & assert
+	require.NoError(t, d.Start())
+	select {
+	case err := <-sem:
+		require.EqualError(t, err, "read error")
+	case <-time.After(time.Second):
+		require.Fail(t, "error event was not published")
+	}
+	require.NoError(t, d.Halt())
+	// no pins
+	require.EqualError(t, NewRotarySelectorDriver(a, nil, WithName("empty")).Start(),
+		"no pins given for rotary selector 'empty'")
+}
@@ -2,6 +2,7 @@ package gpio
 
 import (
 	"fmt"
+	"math"
 
 	"gobot.io/x/gobot/v2"
 )
@@ -75,3 +76,21 @@ func (d *ServoDriver) ToMax() error {
 func (d *ServoDriver) Angle() uint8 {
 	return d.currentAngle
 }
+
+// SetValue (interface gobot.Actuator) moves the servo to the given angle, see ServoDriver.Move
+func (d *ServoDriver) SetValue(val float64) error {
+	if val < 0 || val > 180 {
+		return fmt.Errorf("servo angle (%v) must be between 0-180", val)
+	}
+	return d.Move(uint8(math.Round(val)))
+}
+
+// GetValue (interface gobot.Actuator) returns the current angle
+func (d *ServoDriver) GetValue() float64 {
+	return float64(d.Angle())
+}
+
+// ValueRange (interface gobot.Actuator) returns the range of the angle
+func (d *ServoDriver) ValueRange() (float64, float64) {
+	return 0, 180
+}
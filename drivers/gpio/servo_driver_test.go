@@ -107,3 +107,50 @@ func TestServoCenter(t *testing.T) {
 	_ = d.ToCenter()
 	assert.Equal(t, uint8(90), d.currentAngle)
 }
+
+func TestServoSetValue_Actuator(t *testing.T) {
+	var _ gobot.Actuator = (*ServoDriver)(nil)
+
+	tests := map[string]struct {
+		input     float64
+		wantAngle byte
+		wantErr   string
+	}{
+		"min":     {input: 0, wantAngle: 0},
+		"rounded": {input: 44.6, wantAngle: 45},
+		"max":     {input: 180, wantAngle: 180},
+		"error_below_range": {
+			input:   -1,
+			wantErr: "servo angle (-1) must be between 0-180",
+		},
+		"error_above_range": {
+			input:   180.5,
+			wantErr: "servo angle (180.5) must be between 0-180",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			d := NewServoDriver(a, "3")
+			var writtenAngle byte
+			a.servoWriteFunc = func(pin string, val byte) error {
+				writtenAngle = val
+				return nil
+			}
+			// act
+			err := d.SetValue(tc.input)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantAngle, writtenAngle)
+			assert.InDelta(t, float64(tc.wantAngle), d.GetValue(), 0.0)
+			minVal, maxVal := d.ValueRange()
+			assert.InDelta(t, 0.0, minVal, 0.0)
+			assert.InDelta(t, 180.0, maxVal, 0.0)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package gpio
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// SetDither sets the max. random variation of the delay between two steps, which can reduce the noise and resonance
+// of the motor at some speeds. Each delay is varied equally distributed around the nominal value, so the average speed
+// is preserved. The variation is limited to the nominal delay. Zero (default) keeps the timing deterministic.
+func (d *StepperDriver) SetDither(maxJitter time.Duration) error {
+	if maxJitter < 0 {
+		return fmt.Errorf("dither (%s) cannot be a negative value", maxJitter)
+	}
+
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.maxJitter = maxJitter
+
+	return nil
+}
+
+// Dither returns the max. random variation of the delay between two steps.
+func (d *StepperDriver) Dither() time.Duration {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.maxJitter
+}
+
+// getSteppingDelay gives the delay of the next step, varied by the dither. The value mutex needs to be locked by the
+// caller.
+func (d *StepperDriver) getSteppingDelay() time.Duration {
+	delay := d.getStepDelay()
+	if d.maxJitter == 0 {
+		return delay
+	}
+
+	maxJitter := d.maxJitter
+	if maxJitter > delay {
+		maxJitter = delay
+	}
+
+	// equally distributed in the range [-maxJitter, maxJitter), so the average is the nominal delay
+	jitter := time.Duration((2*d.ditherRandFunc() - 1) * float64(maxJitter))
+
+	return delay + jitter
+}
+
+// ditherRand is the default random function for the dither, a cryptographic quality is not needed
+func ditherRand() float64 {
+	return rand.Float64() //nolint:gosec // no cryptographic usage
+}
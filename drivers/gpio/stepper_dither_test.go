@@ -0,0 +1,118 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepperSetDither(t *testing.T) {
+	tests := map[string]struct {
+		maxJitter time.Duration
+		want      time.Duration
+		wantErr   string
+	}{
+		"zero": {
+			maxJitter: 0,
+			want:      0,
+		},
+		"positive": {
+			maxJitter: 2 * time.Millisecond,
+			want:      2 * time.Millisecond,
+		},
+		"error_negative": {
+			maxJitter: -time.Millisecond,
+			want:      0,
+			wantErr:   "dither (-1ms) cannot be a negative value",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestStepperDriverWithStubbedAdaptor()
+			// act
+			err := d.SetDither(tc.maxJitter)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, d.Dither())
+		})
+	}
+}
+
+func TestStepperGetSteppingDelay(t *testing.T) {
+	const nominal = 18750 * time.Microsecond // 32 steps per revolution at 100 rpm
+
+	tests := map[string]struct {
+		maxJitter time.Duration
+		wantBound time.Duration
+	}{
+		"no_dither": {
+			maxJitter: 0,
+			wantBound: 0,
+		},
+		"dither": {
+			maxJitter: 2 * time.Millisecond,
+			wantBound: 2 * time.Millisecond,
+		},
+		"dither_limited_to_nominal": {
+			maxJitter: time.Second,
+			wantBound: nominal,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestStepperDriverWithStubbedAdaptor()
+			d.speedRpm = 100
+			require.NoError(t, d.SetDither(tc.maxJitter))
+			const count = 1000
+			var calls int
+			d.ditherRandFunc = func() float64 {
+				// equally distributed values in the range [0, 1)
+				v := float64(calls) / count
+				calls++
+				return v
+			}
+			var sum time.Duration
+			// act
+			for i := 0; i < count; i++ {
+				delay := d.getSteppingDelay()
+				// assert
+				require.GreaterOrEqual(t, delay, nominal-tc.wantBound)
+				require.LessOrEqual(t, delay, nominal+tc.wantBound)
+				sum += delay
+			}
+			// assert
+			assert.InDelta(t, float64(nominal), float64(sum/count), float64(tc.wantBound)/count+1)
+			if tc.maxJitter == 0 {
+				assert.Equal(t, 0, calls)
+			}
+		})
+	}
+}
+
+func TestStepperMove_withDither(t *testing.T) {
+	// arrange
+	d, a := initTestStepperDriverWithStubbedAdaptor()
+	d.speedRpm = 100
+	require.NoError(t, d.SetDither(5*time.Millisecond))
+	var calls int
+	d.ditherRandFunc = func() float64 {
+		calls++
+		return 0.5
+	}
+	a.written = nil
+	// act
+	err := d.Move(4)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 4, d.CurrentStep())
+	assert.Len(t, a.written, 4*4)
+	assert.Equal(t, 4, calls)
+}
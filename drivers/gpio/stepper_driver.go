@@ -58,6 +58,9 @@ var StepperModes = struct {
 	},
 }
 
+// moveDegResidualTolerance is used to compensate the floating point error of accumulated step fractions
+const moveDegResidualTolerance = 1e-9
+
 // StepperDriver is a common driver for stepper motors. It supports 3 different stepping modes.
 type StepperDriver struct {
 	*driver
@@ -66,18 +69,37 @@ type StepperDriver struct {
 	phase       phase
 	stepsPerRev float32
 
-	stepperDebug   bool
-	speedRpm       uint
-	direction      string
-	skipStepErrors bool
-	haltIfRunning  bool // stop automatically if run is called
-	disabled       bool
-	valueMutex     *sync.Mutex // to ensure that read and write of values do not interfere
+	stepperDebug     bool
+	speedRpm         uint
+	moveSpeedRpm     uint // speed of the current movement instead of speedRpm, if not zero
+	maxStepFrequency uint // in Hz, zero means no limit
+	direction        string
+	skipStepErrors   bool
+	haltIfRunning    bool // stop automatically if run is called
+	disabled         bool
+	valueMutex       *sync.Mutex // to ensure that read and write of values do not interfere
 
 	stepFunc          func() error
 	sleepFunc         func() error
+	beforeMoveFunc    func() error                 // called before each movement, e.g. to wake up the hardware
+	afterMoveFunc     func()                       // called after each finite movement
+	directionFunc     func(direction string) error // called on a change of the direction by a movement
+	profileNow        func() time.Time             // clock of the profile following, see FollowProfile()
 	stepNum           int
+	positionSign      int // sign of the counting of a step, see EasyDriver.SetPositionSign()
 	stopAsynchRunFunc func(bool) error
+
+	backlashSteps     int
+	lastMoveDirection string  // direction of the last movement, used to detect reversals for backlash compensation
+	phaseOffset       int     // shift of the phase, caused by steps which are not counted
+	profileLag        int     // max. lag in steps of the last profile following
+	moveDegResidual   float64 // fraction of a step, which was not moved by MoveDeg() yet
+
+	maxJitter      time.Duration        // max. random variation of the delay per step
+	ditherRandFunc func() float64       // random values in range [0, 1) for the dither
+	stepTimer      StepTimer            // optional timing of the steps of a movement, e.g. for a ramp
+	moveProgress   *stepperMoveProgress // progress of the last asynchronous movement
+	group          *StepperGroup        // limits the count of simultaneous ramps, if set
 }
 
 // NewStepperDriver returns a new StepperDriver given a DigitalWriter
@@ -88,6 +110,7 @@ type StepperDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithVerifiedWrite"
 func NewStepperDriver(
 	a DigitalWriter,
 	pins [4]string,
@@ -108,13 +131,18 @@ func NewStepperDriver(
 		skipStepErrors: false,
 		haltIfRunning:  true,
 		direction:      StepperDriverForward,
+		ditherRandFunc: ditherRand,
+		profileNow:     time.Now,
 		stepNum:        0,
+		positionSign:   1,
 		speedRpm:       1,
 		valueMutex:     &sync.Mutex{},
 	}
-	d.speedRpm = d.MaxSpeed()
+	d.speedRpm = d.maxSpeed()
 	d.stepFunc = d.phasedStepping
 	d.sleepFunc = d.sleepOuputs
+	d.beforeMoveFunc = func() error { return nil }
+	d.afterMoveFunc = func() {}
 	d.beforeHalt = d.shutdown
 
 	d.AddCommand("MoveDeg", func(params map[string]interface{}) interface{} {
@@ -137,6 +165,9 @@ func NewStepperDriver(
 	d.AddCommand("Stop", func(params map[string]interface{}) interface{} {
 		return d.Stop()
 	})
+	d.AddCommand("SoftStop", func(params map[string]interface{}) interface{} {
+		return d.SoftStop()
+	})
 	d.AddCommand("Halt", func(params map[string]interface{}) interface{} {
 		return d.Halt()
 	})
@@ -149,33 +180,94 @@ func (d *StepperDriver) Move(stepsToMove int) error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	if err := d.beforeMoveFunc(); err != nil {
+		return err
+	}
+	defer d.afterMoveFunc()
+
 	if err := d.stepAsynch(float64(stepsToMove)); err != nil {
 		// something went wrong with preparation
 		return err
 	}
 
-	err := d.stopAsynchRunFunc(false) // wait to finish with err or nil
-	d.stopAsynchRunFunc = nil
-
-	return err
+	return d.waitAsynchMove()
 }
 
-// MoveDeg moves the motor given number of degrees at current speed. Negative values cause to move backward.
+// MoveDeg moves the motor given number of degrees at current speed. Negative values cause to move backward. The
+// direction, which was set before (e.g. by SetDirection), is restored after the movement.
+// If the degrees do not match a whole number of steps, the remaining fraction of a step is accumulated and added to
+// the next call, so repeated small movements do not drift from the total angle, see MoveDegResidual(). A movement,
+// which is smaller than one step, only accumulates the fraction.
 func (d *StepperDriver) MoveDeg(degs int) error {
+	return d.moveDegAtSpeed(degs, 0)
+}
+
+// moveDegAtSpeed moves like MoveDeg(), but with the given speed instead of the speed of SetSpeed(), if not zero. The
+// speed is only used for this movement, so other movements and the value of AngularSpeed() are not affected.
+func (d *StepperDriver) moveDegAtSpeed(degs int, speedRpm uint) error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	stepsToMove := float64(degs) * float64(d.stepsPerRev) / 360
+	d.setMoveSpeed(speedRpm)
+	defer d.setMoveSpeed(0)
+
+	if err := d.beforeMoveFunc(); err != nil {
+		return err
+	}
+	defer d.afterMoveFunc()
+
+	d.valueMutex.Lock()
+	priorDirection := d.direction
+	d.valueMutex.Unlock()
+
+	defer func() {
+		d.valueMutex.Lock()
+		defer d.valueMutex.Unlock()
+
+		if err := d.changeDirection(priorDirection); err != nil {
+			d.debug(fmt.Sprintf("restore of direction '%s' failed: %v", priorDirection, err))
+		}
+	}()
+
+	d.valueMutex.Lock()
+	exactSteps := float64(degs)*float64(d.stepsPerRev)/360 + d.moveDegResidual
+	d.valueMutex.Unlock()
+
+	// a small offset prevents, that a sum of fractions like 0.9999999 is truncated to zero
+	stepsToMove := math.Trunc(exactSteps + math.Copysign(moveDegResidualTolerance, exactSteps))
+	residual := exactSteps - stepsToMove
+
+	if stepsToMove == 0 && degs != 0 {
+		d.valueMutex.Lock()
+		d.moveDegResidual = residual
+		d.valueMutex.Unlock()
+
+		return nil
+	}
 
 	if err := d.stepAsynch(stepsToMove); err != nil {
 		// something went wrong with preparation
 		return err
 	}
 
-	err := d.stopAsynchRunFunc(false) // wait to finish with err or nil
-	d.stopAsynchRunFunc = nil
+	if err := d.waitAsynchMove(); err != nil {
+		return err
+	}
 
-	return err
+	d.valueMutex.Lock()
+	d.moveDegResidual = residual
+	d.valueMutex.Unlock()
+
+	return nil
+}
+
+// MoveDegResidual returns the accumulated fraction of a step, which was not moved by MoveDeg() yet, because it is
+// smaller than one step. The value is in steps and negative for the backward direction.
+func (d *StepperDriver) MoveDegResidual() float64 {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.moveDegResidual
 }
 
 // Run runs the stepper continuously. Stop needs to be done with call Stop().
@@ -183,24 +275,64 @@ func (d *StepperDriver) Run() error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	if err := d.beforeMoveFunc(); err != nil {
+		return err
+	}
+
 	return d.stepAsynch(float64(math.MaxInt) + 1)
 }
 
 // IsMoving returns a bool stating whether motor is currently in motion
 func (d *StepperDriver) IsMoving() bool {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
 	return d.stopAsynchRunFunc != nil
 }
 
 // Stop running the stepper
 func (d *StepperDriver) Stop() error {
-	if d.stopAsynchRunFunc == nil {
+	stop := d.takeStopAsynchRunFunc()
+	if stop == nil {
 		return fmt.Errorf("'%s' is not yet started", d.driverCfg.name)
 	}
 
-	err := d.stopAsynchRunFunc(true)
-	d.stopAsynchRunFunc = nil
+	return stop(true)
+}
 
-	return err
+// SoftStop decelerates the running movement with the ramp of the step timer (see SetStepTimer()) and waits until the
+// motor stands still. In contrast to Stop(), which stops immediately, no steps are lost and the mechanism is not
+// jerked. Without step timer, there is no ramp, so the motor is stopped after the current step. A finite movement is
+// only shortened, if the deceleration fits into the remaining steps.
+func (d *StepperDriver) SoftStop() error {
+	d.valueMutex.Lock()
+	progress := d.moveProgress
+	moving := d.stopAsynchRunFunc != nil
+	var endless bool
+	if moving {
+		endless = progress.totalSteps == 0
+		progress.decelerate = true
+	}
+	d.valueMutex.Unlock()
+
+	if !moving {
+		return fmt.Errorf("'%s' is not yet started", d.driverCfg.name)
+	}
+
+	<-progress.finished
+
+	if !endless {
+		// the caller of the finite movement cleans up by itself
+		return nil
+	}
+
+	// cleanup of the endless movement
+	stop := d.takeStopAsynchRunFunc()
+	if stop == nil {
+		return nil
+	}
+
+	return stop(false)
 }
 
 // Sleep release all pins to the same output level, so no current is consumed anymore.
@@ -234,21 +366,48 @@ func (d *StepperDriver) SetDirection(direction string) error {
 // * duration of GPIO write (PI1 can reach up to 70kHz, typically 20kHz, so this is most likely not the limiting factor)
 // * the hardware driver, to force the high current transitions for the max. speed
 // * there are CNC steppers with 1000..20.000 steps per revolution, which works with faster step rates (e.g. 200kHz)
-func (d *StepperDriver) MaxSpeed() uint {
-	const maxStepsPerSecond = 700 // a typical value for a normal, lightly loaded motor
-	return uint(float32(60*maxStepsPerSecond) / d.stepsPerRev)
+//
+// An error is returned, if the steps per revolution are not set, see [gpio.ErrStepsPerRevNotSet].
+func (d *StepperDriver) MaxSpeed() (uint, error) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	if err := d.stepsPerRevError(); err != nil {
+		return 0, err
+	}
+
+	return d.maxSpeed(), nil
 }
 
 // SetSpeed sets the rpm for the next move or run. A valid value is between 1 and MaxSpeed().
 // The run needs to be stopped and called again after set this value.
 func (d *StepperDriver) SetSpeed(rpm uint) error {
+	if err := d.checkStepsPerRev(); err != nil {
+		return err
+	}
+
+	rpm, err := d.limitSpeed(rpm)
+
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.speedRpm = rpm
+
+	return err
+}
+
+// limitSpeed returns the given rpm, limited to the range of SetSpeed(), and an error, if the rpm was out of range
+func (d *StepperDriver) limitSpeed(rpm uint) (uint, error) {
 	var err error
 	if rpm <= 0 {
 		rpm = 0
 		err = fmt.Errorf("RPM (%d) cannot be a zero or negative value", rpm)
 	}
 
-	maxRpm := d.MaxSpeed()
+	maxRpm, maxErr := d.MaxSpeed()
+	if maxErr != nil {
+		return 0, maxErr
+	}
 	if rpm > maxRpm {
 		rpm = maxRpm
 		err = fmt.Errorf("RPM (%d) cannot be greater then maximal value %d", rpm, maxRpm)
@@ -256,9 +415,98 @@ func (d *StepperDriver) SetSpeed(rpm uint) error {
 
 	d.valueMutex.Lock()
 	defer d.valueMutex.Unlock()
-	d.speedRpm = rpm
 
-	return err
+	if d.maxStepFrequency > 0 {
+		maxFreqRpm := uint(float32(60*d.maxStepFrequency) / d.stepsPerRev)
+		if maxFreqRpm < 1 {
+			maxFreqRpm = 1
+		}
+		if rpm > maxFreqRpm {
+			err = fmt.Errorf("RPM (%d) exceeds the max. step frequency of %d Hz, clamped to %d", rpm,
+				d.maxStepFrequency, maxFreqRpm)
+			rpm = maxFreqRpm
+		}
+	}
+
+	return rpm, err
+}
+
+// SetAngularSpeed sets the speed in degrees per second for the next move or run. This is a convenience for
+// SetSpeed(), so the value is rounded to the next full RPM (multiple of 6 deg/s). A valid value is between 6 deg/s and
+// 6 times MaxSpeed().
+func (d *StepperDriver) SetAngularSpeed(degPerSec float64) error {
+	if degPerSec <= 0 {
+		return fmt.Errorf("angular speed (%.2f deg/s) cannot be a zero or negative value", degPerSec)
+	}
+
+	maxRpm, err := d.MaxSpeed()
+	if err != nil {
+		return err
+	}
+
+	rpm := math.Round(degPerSec * 60 / 360)
+	if rpm < 1 {
+		return fmt.Errorf("angular speed (%.2f deg/s) cannot be lower than minimal value %d deg/s", degPerSec,
+			360/60)
+	}
+
+	maxDegPerSec := maxRpm * 360 / 60
+	if rpm > float64(maxRpm) {
+		return fmt.Errorf("angular speed (%.2f deg/s) cannot be greater then maximal value %d deg/s", degPerSec,
+			maxDegPerSec)
+	}
+
+	return d.SetSpeed(uint(rpm))
+}
+
+// AngularSpeed returns the speed in degrees per second, which is used for the next move or run.
+func (d *StepperDriver) AngularSpeed() float64 {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return float64(d.speedRpm) * 360 / 60
+}
+
+// SetBacklash sets the count of extra steps, which are inserted on a reversal of the direction between two movements to
+// take up the mechanical backlash (e.g. of a lead screw). The extra steps do not count for the current step. A value of
+// zero deactivates the compensation.
+func (d *StepperDriver) SetBacklash(steps int) error {
+	if steps < 0 {
+		return fmt.Errorf("backlash steps (%d) cannot be a negative value", steps)
+	}
+
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.backlashSteps = steps
+
+	return nil
+}
+
+// Backlash returns the count of extra steps, which are inserted on a reversal of the direction.
+func (d *StepperDriver) Backlash() int {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.backlashSteps
+}
+
+// SetMaxStepFrequency sets a hard limit for the step frequency in Hz, which protects the hardware independent of the
+// RPM based MaxSpeed(). The delay per step will never be shorter than the period of this frequency and a later call of
+// SetSpeed() with a higher speed is clamped. A value of zero removes the limit.
+func (d *StepperDriver) SetMaxStepFrequency(hz uint) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.maxStepFrequency = hz
+}
+
+// MaxStepFrequency returns the hard limit for the step frequency in Hz, zero means no limit.
+func (d *StepperDriver) MaxStepFrequency() uint {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.maxStepFrequency
 }
 
 // CurrentStep gives the current step of motor
@@ -273,29 +521,58 @@ func (d *StepperDriver) CurrentStep() int {
 // SetHaltIfRunning with the given value. Normally a call of Run() returns an error if already running. If set this
 // to true, the next call of Run() cause a automatic stop before.
 func (d *StepperDriver) SetHaltIfRunning(val bool) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
 	d.haltIfRunning = val
 }
 
+// changeDirection sets the direction for the next steps and calls the direction function, if the direction changes.
+// The value mutex needs to be locked by the caller.
+func (d *StepperDriver) changeDirection(direction string) error {
+	if direction == d.direction {
+		return nil
+	}
+
+	if d.directionFunc != nil {
+		if err := d.directionFunc(direction); err != nil {
+			return err
+		}
+	}
+	d.direction = direction
+
+	return nil
+}
+
 // shutdown the driver
 func (d *StepperDriver) shutdown() error {
 	// stops the continuous motion of the stepper, if running
 	return d.stopIfRunning()
 }
 
+// stepAsynch starts the asynchronous stepping. The driver mutex needs to be locked by the caller.
 func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
-	if d.disabled {
+	d.valueMutex.Lock()
+	disabled := d.disabled
+	running := d.stopAsynchRunFunc != nil
+	haltIfRunning := d.haltIfRunning
+	group := d.group
+	d.valueMutex.Unlock()
+
+	if disabled {
 		return fmt.Errorf("'%s' is disabled and can not be running or moving", d.driverCfg.name)
 	}
 
 	// if running, return error or stop automatically
-	if d.stopAsynchRunFunc != nil {
-		if !d.haltIfRunning {
+	if running {
+		if !haltIfRunning {
 			return fmt.Errorf("'%s' already running or moving", d.driverCfg.name)
 		}
 		d.debug("stop former run forcefully")
-		if err := d.stopAsynchRunFunc(true); err != nil {
-			d.stopAsynchRunFunc = nil
-			return err
+		if stop := d.takeStopAsynchRunFunc(); stop != nil {
+			if err := stop(true); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -305,52 +582,76 @@ func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
 		return fmt.Errorf("no steps to do for '%s'", d.driverCfg.name)
 	}
 
+	// wait for the budget of the group, the ramp is released after the acceleration by the go routine
+	releaseRamp := func() {}
+	if group != nil {
+		releaseRamp = group.acquireRamp()
+	}
+	started := false
+	defer func() {
+		if !started {
+			releaseRamp()
+		}
+	}()
+
+	// ensure that the read and write of values can not interfere with other callers and the stepping
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
 	// t [min] = steps [st] / (steps_per_revolution [st/u] * speed [u/min]) or
 	// t [min] = steps [st] * delay_per_step [min/st], use safety factor 2 and a small offset of 100 ms
 	// prepare this timeout outside of stop function to prevent data race with stepsLeft
 	stopTimeout := time.Duration(2*stepsLeft)*d.getDelayPerStep() + 100*time.Millisecond
 	endlessMovement := false
 
+	var takeUpSteps uint64
 	if stepsLeft > math.MaxInt {
 		stopTimeout = 100 * time.Millisecond
 		endlessMovement = true
 	} else {
-		d.direction = "forward"
+		direction := StepperDriverForward
 		if stepsToMove < 0 {
-			d.direction = "backward"
+			direction = StepperDriverBackward
+		}
+		if err := d.changeDirection(direction); err != nil {
+			return err
+		}
+
+		// insert the take-up steps only on a genuine reversal
+		if d.backlashSteps > 0 && d.lastMoveDirection != "" && d.lastMoveDirection != d.direction {
+			takeUpSteps = uint64(d.backlashSteps)
+			stepsLeft += takeUpSteps
+			stopTimeout += time.Duration(2*takeUpSteps) * d.getDelayPerStep()
+		}
+	}
+	d.lastMoveDirection = d.direction
+
+	progress := &stepperMoveProgress{active: true, finished: make(chan struct{})}
+	if !endlessMovement {
+		progress.totalSteps = int(stepsLeft)
+		if d.stepTimer != nil {
+			stopTimeout = 2*d.sumStepTimerDelays(progress.totalSteps) + 100*time.Millisecond
 		}
 	}
+	d.moveProgress = progress
 
 	// prepare new asynchronous stepping
 	onceDoneChan := make(chan struct{})
 	runStopChan := make(chan struct{})
-	runErrChan := make(chan error)
+	runErrChan := make(chan error, 1) // buffered, so the go routine can finish also without a waiting caller
+	var runStopOnce sync.Once         // the stop function can be called concurrently, e.g. Stop() while Move()
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt)
 
 	d.stopAsynchRunFunc = func(forceStop bool) error {
-		defer func() {
-			d.debug("RUN: cleanup stop channel")
-			if runStopChan != nil {
-				close(runStopChan)
-			}
-			runStopChan = nil
-			d.debug("STOP: cleanup err channel")
-			if runErrChan != nil {
-				close(runErrChan)
-			}
-			runErrChan = nil
-			d.debug("STOP: cleanup done")
-		}()
-
 		d.debug("STOP: wait for once done")
 		<-onceDoneChan // wait for the first step was called
 
 		// send stop for endless movement or a forceful stop happen
 		if endlessMovement || forceStop {
-			d.debug("STOP: send stop channel")
-			runStopChan <- struct{}{}
+			d.debug("STOP: close stop channel")
+			runStopOnce.Do(func() { close(runStopChan) })
 		}
 
 		if !endlessMovement && forceStop {
@@ -370,10 +671,17 @@ func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
 	}
 
 	d.debug(fmt.Sprintf("going to start go routine - endless=%t, steps=%d", endlessMovement, stepsLeft))
+	started = true
 	go func(name string) {
 		var err error
 		var onceDone bool
+		endless := endlessMovement // a soft stop turns an endless movement into a finite deceleration
 		defer func() {
+			signal.Stop(sigChan)
+			releaseRamp()
+			if !onceDone {
+				close(onceDoneChan) // no step was done, but stop must not wait forever
+			}
 			// some cases here:
 			// * stop by stop channel: error should be send as nil
 			// * count of steps reached: error should be send as nil
@@ -381,6 +689,10 @@ func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
 			//    * for Run(): caller needs to send stop channel and read the error
 			//    * for Move(): caller waits for the error, but don't send stop channel
 			//
+			d.valueMutex.Lock()
+			progress.active = false
+			d.valueMutex.Unlock()
+			close(progress.finished)
 			d.debug(fmt.Sprintf("RUN: write '%v' to err channel", err))
 			runErrChan <- err
 		}()
@@ -388,7 +700,7 @@ func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
 			select {
 			case <-sigChan:
 				d.debug("RUN: OS signal received")
-				err = fmt.Errorf("OS signal received")
+				err = d.recordError(fmt.Errorf("OS signal received"))
 				return
 			case <-runStopChan:
 				d.debug("RUN: stop channel received")
@@ -404,15 +716,33 @@ func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
 							d.debug("RUN: write error occurred")
 						}
 					}
+					if err == nil {
+						d.valueMutex.Lock()
+						progress.doneSteps++
+						ramping := d.isRamping()
+						decelSteps, decelerate := d.takeDeceleration(progress, endless, stepsLeft-1)
+						d.valueMutex.Unlock()
+						if !ramping {
+							releaseRamp()
+						}
+						if decelerate {
+							endless = false
+							stepsLeft = decelSteps + 1 // the done step is subtracted below
+						}
+					}
 					if !onceDone {
 						close(onceDoneChan) // to inform that we are ready for stop now
 						onceDone = true
 						d.debug("RUN: once done")
 					}
-					if !endlessMovement {
+					if !endless {
 						if err != nil {
 							return
 						}
+						if takeUpSteps > 0 {
+							d.uncountStep()
+							takeUpSteps--
+						}
 						stepsLeft--
 					}
 				}
@@ -423,12 +753,38 @@ func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
 	return nil
 }
 
-// getDelayPerStep gives the delay per step
+// setMoveSpeed sets the speed of the current movement, zero resets it to the speed of SetSpeed(). The driver mutex
+// needs to be locked by the caller, so no other movement can use the speed.
+func (d *StepperDriver) setMoveSpeed(speedRpm uint) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.moveSpeedRpm = speedRpm
+}
+
+// getDelayPerStep gives the delay per step, which is not below the period of the max. step frequency
 // formula: delay_per_step [min] = 1/(steps_per_revolution * speed [rpm])
 func (d *StepperDriver) getDelayPerStep() time.Duration {
 	// considering a max. speed of 1000 rpm and max. 1000 steps per revolution, a microsecond resolution is needed
 	// if the motor or application needs bigger values, switch to nanosecond is needed
-	return time.Duration(60*1000*1000/(d.stepsPerRev*float32(d.speedRpm))) * time.Microsecond
+	speedRpm := d.speedRpm
+	if d.moveSpeedRpm > 0 {
+		speedRpm = d.moveSpeedRpm
+	}
+	delay := time.Duration(60*1000*1000/(d.stepsPerRev*float32(speedRpm))) * time.Microsecond
+
+	return d.limitDelay(delay)
+}
+
+// limitDelay gives the given delay, but not below the period of the max. step frequency
+func (d *StepperDriver) limitDelay(delay time.Duration) time.Duration {
+	if d.maxStepFrequency > 0 {
+		if minDelay := time.Duration(1000*1000/d.maxStepFrequency) * time.Microsecond; delay < minDelay {
+			delay = minDelay
+		}
+	}
+
+	return delay
 }
 
 // phasedStepping moves the motor one step with the configured speed and direction. The speed can be adjusted
@@ -452,7 +808,7 @@ func (d *StepperDriver) phasedStepping() error {
 		d.stepNum = int(d.stepsPerRev) - 1
 	}
 
-	r := int(math.Abs(float64(d.stepNum))) % len(d.phase)
+	r := ((d.stepNum+d.phaseOffset)%len(d.phase) + len(d.phase)) % len(d.phase)
 
 	for i, v := range d.phase[r] {
 		if err := d.digitalWrite(d.pins[i], v); err != nil {
@@ -461,12 +817,39 @@ func (d *StepperDriver) phasedStepping() error {
 		}
 	}
 
-	delay := d.getDelayPerStep()
+	delay := d.getSteppingDelay()
 	time.Sleep(delay)
 
 	return nil
 }
 
+// uncountStep reverts the counting of the last step, e.g. for a take-up step of the backlash compensation. The phase is
+// shifted accordingly, so the next step continues with the correct phase.
+func (d *StepperDriver) uncountStep() {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	if d.direction == StepperDriverForward {
+		d.stepNum -= d.positionSign
+		d.phaseOffset++
+	} else {
+		d.stepNum += d.positionSign
+		d.phaseOffset--
+	}
+
+	if d.phase == nil {
+		// no phased stepping (e.g. EasyDriver), so the step number is not wrapped
+		return
+	}
+
+	d.phaseOffset %= len(d.phase)
+	if d.stepNum >= int(d.stepsPerRev) {
+		d.stepNum = 0
+	} else if d.stepNum < 0 {
+		d.stepNum = int(d.stepsPerRev) - 1
+	}
+}
+
 func (d *StepperDriver) sleepOuputs() error {
 	for _, pin := range d.pins {
 		if err := d.digitalWrite(pin, 0); err != nil {
@@ -479,16 +862,75 @@ func (d *StepperDriver) sleepOuputs() error {
 // stopIfRunning stop the stepper if moving or running
 func (d *StepperDriver) stopIfRunning() error {
 	// stops the continuous motion of the stepper, if running
-	if d.stopAsynchRunFunc == nil {
+	stop := d.takeStopAsynchRunFunc()
+	if stop == nil {
 		return nil
 	}
 
-	err := d.stopAsynchRunFunc(true)
-	d.stopAsynchRunFunc = nil
+	return stop(true)
+}
+
+// waitAsynchMove waits until the finite movement, started by stepAsynch(), is finished. The driver mutex needs to be
+// locked by the caller.
+func (d *StepperDriver) waitAsynchMove() error {
+	d.valueMutex.Lock()
+	stop := d.stopAsynchRunFunc
+	d.valueMutex.Unlock()
+
+	if stop == nil {
+		// already stopped, e.g. by Stop()
+		return nil
+	}
+
+	err := stop(false) // wait to finish with err or nil
+	d.takeStopAsynchRunFunc()
 
 	return err
 }
 
+// takeStopAsynchRunFunc returns the stop function of the asynchronous stepping and resets it, so the motor is not
+// moving anymore from the callers point of view. Returns nil, if not moving.
+func (d *StepperDriver) takeStopAsynchRunFunc() func(bool) error {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	stop := d.stopAsynchRunFunc
+	d.stopAsynchRunFunc = nil
+
+	return stop
+}
+
+// maxSpeed calculates the max RPM from the steps per revolution, see MaxSpeed(). Zero is returned, if the steps per
+// revolution are not set. The value mutex needs to be locked by the caller.
+func (d *StepperDriver) maxSpeed() uint {
+	const maxStepsPerSecond = 700 // a typical value for a normal, lightly loaded motor
+
+	if d.stepsPerRev <= 0 {
+		return 0
+	}
+
+	return uint(float32(60*maxStepsPerSecond) / d.stepsPerRev)
+}
+
+// checkStepsPerRev returns an error, if the steps per revolution are not set, so no speed can be calculated
+func (d *StepperDriver) checkStepsPerRev() error {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.stepsPerRevError()
+}
+
+// stepsPerRevError returns an error, if the steps per revolution are not set. The value mutex needs to be locked by
+// the caller.
+func (d *StepperDriver) stepsPerRevError() error {
+	if d.stepsPerRev <= 0 {
+		return fmt.Errorf("%w for '%s' (see SetAnglePerStep()), so the speed can not be calculated",
+			ErrStepsPerRevNotSet, d.driverCfg.name)
+	}
+
+	return nil
+}
+
 func (d *StepperDriver) debug(text string) {
 	if d.stepperDebug {
 		fmt.Println(text)
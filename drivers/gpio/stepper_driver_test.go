@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -364,6 +365,60 @@ func TestStepperSetDirection(t *testing.T) {
 	}
 }
 
+func TestStepperMoveDeg_restoresDirection(t *testing.T) {
+	tests := map[string]struct {
+		priorDirection string
+		inputDeg       int
+		wantSteps      int
+		wantFirstPhase [4]byte
+	}{
+		"forward_positive": {
+			priorDirection: "forward",
+			inputDeg:       90,
+			wantSteps:      8,
+			wantFirstPhase: StepperModes.DualPhaseStepping[1],
+		},
+		"forward_negative": {
+			priorDirection: "forward",
+			inputDeg:       -90,
+			wantSteps:      32 - 8,
+			wantFirstPhase: StepperModes.DualPhaseStepping[3],
+		},
+		"backward_positive": {
+			priorDirection: "backward",
+			inputDeg:       90,
+			wantSteps:      8,
+			wantFirstPhase: StepperModes.DualPhaseStepping[1],
+		},
+		"backward_negative": {
+			priorDirection: "backward",
+			inputDeg:       -90,
+			wantSteps:      32 - 8,
+			wantFirstPhase: StepperModes.DualPhaseStepping[3],
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestStepperDriverWithStubbedAdaptor()
+			require.NoError(t, d.SetDirection(tc.priorDirection))
+			a.written = nil
+			// act
+			err := d.MoveDeg(tc.inputDeg)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantSteps, d.CurrentStep())
+			require.Len(t, a.written, 8*4)
+			var firstPhase [4]byte
+			for i := range firstPhase {
+				firstPhase[i] = a.written[i].val
+			}
+			assert.Equal(t, tc.wantFirstPhase, firstPhase)
+			assert.Equal(t, tc.priorDirection, d.direction)
+		})
+	}
+}
+
 func TestStepperMaxSpeed(t *testing.T) {
 	const delayForMaxSpeed = 1428 * time.Microsecond // 1/700Hz
 
@@ -395,9 +450,10 @@ func TestStepperMaxSpeed(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			// arrange
-			d := StepperDriver{stepsPerRev: tc.stepsPerRev}
+			d := StepperDriver{stepsPerRev: tc.stepsPerRev, valueMutex: &sync.Mutex{}}
 			// act
-			got := d.MaxSpeed()
+			got, err := d.MaxSpeed()
+			require.NoError(t, err)
 			d.speedRpm = got
 			got2 := d.getDelayPerStep()
 			// assert
@@ -407,6 +463,23 @@ func TestStepperMaxSpeed(t *testing.T) {
 	}
 }
 
+func TestStepperMaxSpeed_stepsPerRevNotSet(t *testing.T) {
+	// arrange
+	d, _ := initTestStepperDriverWithStubbedAdaptor()
+	d.stepsPerRev = 0
+	speedBefore := d.speedRpm
+	// act & assert
+	maxSpeed, err := d.MaxSpeed()
+	assert.Equal(t, uint(0), maxSpeed)
+	require.ErrorIs(t, err, ErrStepsPerRevNotSet)
+	require.EqualError(t, err, fmt.Sprintf("steps per revolution is not set for '%s' (see SetAnglePerStep()), "+
+		"so the speed can not be calculated", d.Name()))
+	err = d.SetSpeed(10)
+	require.ErrorIs(t, err, ErrStepsPerRevNotSet)
+	require.ErrorIs(t, d.SetAngularSpeed(60), ErrStepsPerRevNotSet)
+	assert.Equal(t, speedBefore, d.speedRpm)
+}
+
 func TestStepperSetSpeed(t *testing.T) {
 	const maxRpm = 1166
 
@@ -451,3 +524,233 @@ func TestStepperSetSpeed(t *testing.T) {
 		})
 	}
 }
+
+func TestStepperSetAngularSpeed(t *testing.T) {
+	const maxRpm = 1166
+
+	tests := map[string]struct {
+		input     float64
+		wantRpm   uint
+		wantSpeed float64
+		wantErr   string
+	}{
+		"one_rpm": {
+			input:     6,
+			wantRpm:   1,
+			wantSpeed: 6,
+		},
+		"rounded_down": {
+			input:     62,
+			wantRpm:   10,
+			wantSpeed: 60,
+		},
+		"rounded_up": {
+			input:     63,
+			wantRpm:   11,
+			wantSpeed: 66,
+		},
+		"maximum": {
+			input:     maxRpm * 6,
+			wantRpm:   maxRpm,
+			wantSpeed: maxRpm * 6,
+		},
+		"error_zero": {
+			input:     0,
+			wantRpm:   20,
+			wantSpeed: 120,
+			wantErr:   "angular speed (0.00 deg/s) cannot be a zero or negative value",
+		},
+		"error_below_minimum": {
+			input:     2.9,
+			wantRpm:   20,
+			wantSpeed: 120,
+			wantErr:   "angular speed (2.90 deg/s) cannot be lower than minimal value 6 deg/s",
+		},
+		"error_above_maximum": {
+			input:     maxRpm*6 + 3,
+			wantRpm:   20,
+			wantSpeed: 120,
+			wantErr:   "angular speed (6999.00 deg/s) cannot be greater then maximal value 6996 deg/s",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestStepperDriverWithStubbedAdaptor()
+			d.stepsPerRev = 36
+			d.speedRpm = 20
+			// act
+			err := d.SetAngularSpeed(tc.input)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantRpm, d.speedRpm)
+			assert.InDelta(t, tc.wantSpeed, d.AngularSpeed(), 0.0)
+		})
+	}
+}
+
+func TestStepperSetMaxStepFrequency(t *testing.T) {
+	tests := map[string]struct {
+		maxFreq   uint
+		speed     uint
+		wantSpeed uint
+		wantDelay time.Duration
+		wantErr   string
+	}{
+		"no_limit": {
+			speed:     420,
+			wantSpeed: 420,
+			wantDelay: 1428 * time.Microsecond, // 700 Hz
+		},
+		"below_limit": {
+			maxFreq:   500,
+			speed:     60,
+			wantSpeed: 60,
+			wantDelay: 10 * time.Millisecond, // 100 Hz
+		},
+		"at_limit": {
+			maxFreq:   500,
+			speed:     300,
+			wantSpeed: 300,
+			wantDelay: 2 * time.Millisecond,
+		},
+		"clamped": {
+			maxFreq:   500,
+			speed:     420,
+			wantSpeed: 300,
+			wantDelay: 2 * time.Millisecond,
+			wantErr:   "RPM (420) exceeds the max. step frequency of 500 Hz, clamped to 300",
+		},
+		"clamped_to_minimum": {
+			maxFreq:   1,
+			speed:     2,
+			wantSpeed: 1,
+			wantDelay: time.Second,
+			wantErr:   "RPM (2) exceeds the max. step frequency of 1 Hz, clamped to 1",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestStepperDriverWithStubbedAdaptor()
+			d.stepsPerRev = 100
+			d.SetMaxStepFrequency(tc.maxFreq)
+			// act
+			err := d.SetSpeed(tc.speed)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.maxFreq, d.MaxStepFrequency())
+			assert.Equal(t, tc.wantSpeed, d.speedRpm)
+			assert.Equal(t, tc.wantDelay, d.getDelayPerStep())
+		})
+	}
+}
+
+func TestStepperGetDelayPerStep_maxStepFrequency(t *testing.T) {
+	// arrange: the speed was set before the limit
+	d, _ := initTestStepperDriverWithStubbedAdaptor()
+	d.stepsPerRev = 100
+	require.NoError(t, d.SetSpeed(420)) // 700 Hz
+	// act
+	d.SetMaxStepFrequency(200)
+	// assert
+	assert.Equal(t, 5*time.Millisecond, d.getDelayPerStep())
+}
+
+func TestStepperSetBacklash(t *testing.T) {
+	tests := map[string]struct {
+		backlash      int
+		moves         []int
+		wantLastSteps int // count of steps of the last movement
+		wantStepNum   int
+		wantErr       string
+	}{
+		"reversal_forward_to_backward": {
+			backlash:      3,
+			moves:         []int{10, -4},
+			wantLastSteps: 7,
+			wantStepNum:   6,
+		},
+		"reversal_backward_to_forward": {
+			backlash:      2,
+			moves:         []int{-5, 3},
+			wantLastSteps: 5,
+			wantStepNum:   30,
+		},
+		"same_direction": {
+			backlash:      3,
+			moves:         []int{10, 4},
+			wantLastSteps: 4,
+			wantStepNum:   14,
+		},
+		"first_move": {
+			backlash:      3,
+			moves:         []int{-4},
+			wantLastSteps: 4,
+			wantStepNum:   28,
+		},
+		"no_backlash": {
+			moves:         []int{10, -4},
+			wantLastSteps: 4,
+			wantStepNum:   6,
+		},
+		"error_negative": {
+			backlash:      -1,
+			moves:         []int{10, -4},
+			wantLastSteps: 4,
+			wantStepNum:   6,
+			wantErr:       "backlash steps (-1) cannot be a negative value",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestStepperDriverWithStubbedAdaptor()
+			maxSpeed, err := d.MaxSpeed()
+			require.NoError(t, err)
+			require.NoError(t, d.SetSpeed(maxSpeed))
+			// act
+			err = d.SetBacklash(tc.backlash)
+			for _, steps := range tc.moves {
+				a.written = nil
+				require.NoError(t, d.Move(steps))
+			}
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.backlash, d.Backlash())
+			}
+			assert.Len(t, a.written, tc.wantLastSteps*4)
+			assert.Equal(t, tc.wantStepNum, d.CurrentStep())
+		})
+	}
+}
+
+func TestStepperSetBacklash_phaseContinuity(t *testing.T) {
+	// arrange
+	d, a := initTestStepperDriverWithStubbedAdaptor()
+	require.NoError(t, d.SetBacklash(2))
+	require.NoError(t, d.Move(3)) // phases 1, 2, 3
+	a.written = nil
+	// act
+	require.NoError(t, d.Move(-1))
+	// assert: the backward steps follow the phases 2, 1, 0 without repetition, but only one step is counted
+	require.Len(t, a.written, 12)
+	wantPhases := []int{2, 1, 0}
+	for i, phase := range wantPhases {
+		for pin := 0; pin < 4; pin++ {
+			assert.Equal(t, StepperModes.DualPhaseStepping[phase][pin], a.written[i*4+pin].val)
+		}
+	}
+	assert.Equal(t, 2, d.CurrentStep())
+}
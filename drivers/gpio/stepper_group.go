@@ -0,0 +1,139 @@
+package gpio
+
+import (
+	"sync"
+)
+
+// StepperGroup limits the count of member drivers, which accelerate at the same time, e.g. because all motors share
+// one power supply, which is not able to deliver the current for all accelerations at once. The start of a movement
+// (e.g. by Move(), MoveDeg() or Run()) waits until the budget allows the next ramp. The acceleration phase is given by
+// the step timer of the driver (see SetStepTimer()) and ends, when the delay of the next step is not shorter than the
+// current one. A driver without step timer only needs the budget for its first step.
+type StepperGroup struct {
+	budget  chan struct{}
+	mutex   sync.Mutex
+	members []*StepperDriver
+}
+
+// NewStepperGroup creates a new group, which allows the given count of member drivers to accelerate at the same time.
+func NewStepperGroup(maxRamping int) *StepperGroup {
+	if maxRamping <= 0 {
+		panic("the max. count of ramping drivers of a stepper group needs to be greater than zero")
+	}
+
+	return &StepperGroup{budget: make(chan struct{}, maxRamping)}
+}
+
+// Add adds the given drivers to the group. A driver can be member of only one group, so it is removed from its former
+// group. For an EasyDriver, add the embedded StepperDriver.
+func (g *StepperGroup) Add(drivers ...*StepperDriver) {
+	for _, d := range drivers {
+		d.valueMutex.Lock()
+		former := d.group
+		d.group = g
+		d.valueMutex.Unlock()
+
+		if former != nil && former != g {
+			former.removeMember(d)
+		}
+
+		g.mutex.Lock()
+		if !g.isMember(d) {
+			g.members = append(g.members, d)
+		}
+		g.mutex.Unlock()
+	}
+}
+
+// Remove removes the given driver from the group and returns false, if the driver was not a member.
+func (g *StepperGroup) Remove(d *StepperDriver) bool {
+	if !g.removeMember(d) {
+		return false
+	}
+
+	d.valueMutex.Lock()
+	if d.group == g {
+		d.group = nil
+	}
+	d.valueMutex.Unlock()
+
+	return true
+}
+
+// Len returns the count of member drivers.
+func (g *StepperGroup) Len() int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return len(g.members)
+}
+
+// Moving returns the count of member drivers, which are currently moving or running.
+func (g *StepperGroup) Moving() int {
+	g.mutex.Lock()
+	members := append([]*StepperDriver{}, g.members...)
+	g.mutex.Unlock()
+
+	var count int
+	for _, d := range members {
+		if d.IsMoving() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Ramping returns the count of member drivers, which are currently accelerating or waiting for the first step.
+func (g *StepperGroup) Ramping() int {
+	return len(g.budget)
+}
+
+// acquireRamp waits until the budget allows the next ramp and returns the function to release it again. The release
+// function can be called more than once.
+func (g *StepperGroup) acquireRamp() func() {
+	g.budget <- struct{}{}
+
+	var once sync.Once
+	return func() { once.Do(func() { <-g.budget }) }
+}
+
+func (g *StepperGroup) removeMember(d *StepperDriver) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for i, m := range g.members {
+		if m == d {
+			g.members = append(g.members[:i], g.members[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMember needs the group mutex to be locked by the caller
+func (g *StepperGroup) isMember(d *StepperDriver) bool {
+	for _, m := range g.members {
+		if m == d {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRamping returns true, while the delay of the next step of the running movement is shorter than the delay of the
+// last step. The value mutex needs to be locked by the caller.
+func (d *StepperDriver) isRamping() bool {
+	p := d.moveProgress
+	if d.stepTimer == nil || p == nil || p.doneSteps == 0 {
+		return false
+	}
+
+	if p.totalSteps > 0 && p.doneSteps >= p.totalSteps {
+		return false
+	}
+
+	return d.stepTimer.NextDelay(p.doneSteps, p.totalSteps) < d.stepTimer.NextDelay(p.doneSteps-1, p.totalSteps)
+}
@@ -0,0 +1,153 @@
+package gpio
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStepperGroup(t *testing.T) {
+	// act
+	g := NewStepperGroup(2)
+	// assert
+	assert.Equal(t, 2, cap(g.budget))
+	assert.Equal(t, 0, g.Len())
+	assert.Equal(t, 0, g.Ramping())
+	assert.PanicsWithValue(t, "the max. count of ramping drivers of a stepper group needs to be greater than zero",
+		func() { NewStepperGroup(0) })
+}
+
+func TestStepperGroupAddRemove(t *testing.T) {
+	// arrange
+	g1 := NewStepperGroup(1)
+	g2 := NewStepperGroup(1)
+	d1, _ := initTestStepperDriverWithStubbedAdaptor()
+	e2, _ := initTestEasyDriverWithStubbedAdaptor()
+	d2 := e2.StepperDriver
+	// act & assert
+	g1.Add(d1, d2, d1)
+	assert.Equal(t, 2, g1.Len())
+	assert.Equal(t, g1, d1.group)
+	g2.Add(d2)
+	assert.Equal(t, 1, g1.Len())
+	assert.Equal(t, 1, g2.Len())
+	assert.Equal(t, g2, d2.group)
+	assert.True(t, g1.Remove(d1))
+	assert.Nil(t, d1.group)
+	assert.False(t, g1.Remove(d1))
+	assert.False(t, g1.Remove(d2))
+	assert.Equal(t, g2, d2.group)
+}
+
+func TestStepperGroup_budgetStaggersRamps(t *testing.T) {
+	const (
+		drivers = 3
+		steps   = 12
+	)
+	timer := TrapezoidalStepTimer{StartDelay: 4 * time.Millisecond, CruiseDelay: time.Millisecond, RampSteps: 4}
+
+	// arrange
+	g := NewStepperGroup(1)
+	var mutex sync.Mutex
+	firstStep := make([]time.Time, drivers)
+	rampDone := make([]time.Time, drivers)
+	var maxRamping int
+	var members []*StepperDriver
+	for i := 0; i < drivers; i++ {
+		i := i
+		d, _ := initTestStepperDriverWithStubbedAdaptor()
+		d.SetStepTimer(timer)
+		origStepFunc := d.stepFunc
+		d.stepFunc = func() error {
+			d.valueMutex.Lock()
+			stepIndex := d.moveProgress.doneSteps
+			d.valueMutex.Unlock()
+			mutex.Lock()
+			if stepIndex == 0 {
+				firstStep[i] = time.Now()
+			}
+			if r := g.Ramping(); r > maxRamping {
+				maxRamping = r
+			}
+			mutex.Unlock()
+			err := origStepFunc()
+			if stepIndex == timer.RampSteps-1 {
+				mutex.Lock()
+				rampDone[i] = time.Now()
+				mutex.Unlock()
+			}
+			return err
+		}
+		members = append(members, d)
+	}
+	g.Add(members...)
+	// act
+	var wg sync.WaitGroup
+	errs := make([]error, drivers)
+	for i, d := range members {
+		wg.Add(1)
+		go func(i int, d *StepperDriver) {
+			defer wg.Done()
+			errs[i] = d.Move(steps)
+		}(i, d)
+	}
+	wg.Wait()
+	// assert
+	for i, d := range members {
+		require.NoError(t, errs[i])
+		assert.Equal(t, steps, d.CurrentStep())
+	}
+	assert.Equal(t, 1, maxRamping)
+	assert.Equal(t, 0, g.Ramping())
+	order := []int{0, 1, 2}
+	sort.Slice(order, func(a, b int) bool { return firstStep[order[a]].Before(firstStep[order[b]]) })
+	for k := 1; k < drivers; k++ {
+		assert.False(t, firstStep[order[k]].Before(rampDone[order[k-1]]),
+			"driver %d started before driver %d finished the acceleration", order[k], order[k-1])
+	}
+}
+
+func TestStepperGroup_run(t *testing.T) {
+	// arrange
+	g := NewStepperGroup(1)
+	d1, _ := initTestStepperDriverWithStubbedAdaptor()
+	d2, _ := initTestStepperDriverWithStubbedAdaptor()
+	timer := TrapezoidalStepTimer{StartDelay: 4 * time.Millisecond, CruiseDelay: time.Millisecond, RampSteps: 3}
+	d1.SetStepTimer(timer)
+	d2.SetStepTimer(timer)
+	g.Add(d1, d2)
+	// act
+	require.NoError(t, d1.Run())
+	start := time.Now()
+	require.NoError(t, d2.Run()) // waits for the acceleration of d1
+	waited := time.Since(start)
+	moving := g.Moving()
+	require.NoError(t, d1.Stop())
+	require.NoError(t, d2.Stop())
+	// assert
+	assert.GreaterOrEqual(t, waited, 5*time.Millisecond)
+	assert.Equal(t, 2, moving)
+	assert.Equal(t, 0, g.Moving())
+	assert.Equal(t, 0, g.Ramping())
+}
+
+func TestStepperGroup_noStepTimer(t *testing.T) {
+	// arrange
+	g := NewStepperGroup(1)
+	d1, _ := initTestStepperDriverWithStubbedAdaptor()
+	d2, _ := initTestStepperDriverWithStubbedAdaptor()
+	g.Add(d1, d2)
+	// act
+	require.NoError(t, d1.Run())
+	require.NoError(t, d2.Run())
+	moving := g.Moving()
+	require.NoError(t, d1.Stop())
+	require.NoError(t, d2.Stop())
+	// assert
+	assert.Equal(t, 2, moving)
+	assert.Equal(t, 0, g.Ramping())
+}
@@ -0,0 +1,235 @@
+package gpio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// stepperProfileIdleInterval is the wait time, if the target position of the profile is reached
+const stepperProfileIdleInterval = time.Millisecond
+
+// ProfilePoint is a point of a motion profile for a stepper motor.
+type ProfilePoint struct {
+	Time     time.Duration // since start of the profile
+	Position int           // in steps, relative to the position at start of the profile
+}
+
+// ReadProfileCSV reads a motion profile from the given CSV data with the columns "time,position". The time is given
+// in seconds (fractions are allowed) and the position in steps. A header line and empty lines are ignored.
+func ReadProfileCSV(r io.Reader) ([]ProfilePoint, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var points []ProfilePoint
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		seconds, errTime := strconv.ParseFloat(strings.TrimSpace(record[0]), 64)
+		position, errPos := strconv.Atoi(strings.TrimSpace(record[1]))
+		if errTime != nil || errPos != nil {
+			if line == 1 {
+				// header line
+				continue
+			}
+			return nil, fmt.Errorf("invalid profile point '%s' in line %d", strings.Join(record, ","), line)
+		}
+
+		points = append(points, ProfilePoint{
+			Time:     time.Duration(seconds * float64(time.Second)),
+			Position: position,
+		})
+	}
+
+	return points, nil
+}
+
+// FollowProfile moves the motor along the given profile and blocks until the last point is reached. The target
+// position is linear interpolated between the points. Before the first point, the interpolation starts at the current
+// position. The step rate is limited by the current speed, so the motor can lag behind the profile, if the speed is
+// too low or the hardware can not keep up. The maximum lag can be read afterwards by ProfileLag().
+// While following the profile, the motor is moving (see IsMoving()) and can be stopped by Stop(), SoftStop() or
+// EmergencyStop(). A soft stop is done after the current step, because the profile has no ramp.
+func (d *StepperDriver) FollowProfile(points []ProfilePoint) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := validateProfile(points); err != nil {
+		return err
+	}
+
+	if err := d.beforeMoveFunc(); err != nil {
+		return err
+	}
+	defer d.afterMoveFunc()
+
+	d.valueMutex.Lock()
+	disabled := d.disabled
+	running := d.stopAsynchRunFunc != nil
+	d.valueMutex.Unlock()
+
+	if disabled {
+		return fmt.Errorf("'%s' is disabled and can not be running or moving", d.driverCfg.name)
+	}
+
+	if running {
+		return fmt.Errorf("'%s' already running or moving", d.driverCfg.name)
+	}
+
+	// the profile is registered as movement, so it can be stopped like any other movement
+	stopChan := make(chan struct{})
+	doneChan := make(chan struct{})
+	var stopOnce sync.Once
+	progress := &stepperMoveProgress{active: true, finished: make(chan struct{})}
+
+	d.valueMutex.Lock()
+	d.profileLag = 0
+	d.moveProgress = progress
+	d.stopAsynchRunFunc = func(bool) error {
+		stopOnce.Do(func() { close(stopChan) })
+		<-doneChan // no step is done anymore after return, e.g. for EmergencyStop()
+		return nil
+	}
+	d.valueMutex.Unlock()
+
+	defer func() {
+		d.takeStopAsynchRunFunc()
+		d.valueMutex.Lock()
+		progress.active = false
+		d.valueMutex.Unlock()
+		close(progress.finished)
+		close(doneChan)
+	}()
+
+	var position int
+	start := d.profileNow()
+	for {
+		select {
+		case <-stopChan:
+			return nil
+		default:
+		}
+
+		target, finished := profileTarget(points, d.profileNow().Sub(start))
+		diff := target - position
+		if diff == 0 {
+			if finished {
+				break
+			}
+			select {
+			case <-stopChan:
+				return nil
+			case <-time.After(stepperProfileIdleInterval):
+			}
+			continue
+		}
+
+		direction := StepperDriverForward
+		if diff < 0 {
+			direction = StepperDriverBackward
+		}
+
+		d.valueMutex.Lock()
+		if progress.decelerate {
+			// soft stop
+			d.valueMutex.Unlock()
+			return nil
+		}
+		if d.disabled {
+			d.valueMutex.Unlock()
+			return fmt.Errorf("'%s' is disabled and can not be running or moving", d.driverCfg.name)
+		}
+		if err := d.changeDirection(direction); err != nil {
+			d.valueMutex.Unlock()
+			return err
+		}
+		d.lastMoveDirection = direction
+		// the step, which is done now, is not counted as lag
+		if lag := int(math.Abs(float64(diff))) - 1; lag > d.profileLag {
+			d.profileLag = lag
+		}
+		d.valueMutex.Unlock()
+
+		if err := d.stepFunc(); err != nil {
+			return err
+		}
+
+		if diff > 0 {
+			position++
+		} else {
+			position--
+		}
+
+		d.valueMutex.Lock()
+		progress.doneSteps++
+		d.valueMutex.Unlock()
+	}
+
+	if lag := d.ProfileLag(); lag > 0 {
+		log.Printf("'%s' lagged behind the profile by up to %d steps\n", d.driverCfg.name, lag)
+	}
+
+	return nil
+}
+
+// ProfileLag returns the maximum count of steps, the motor lagged behind the target position during the last call of
+// FollowProfile().
+func (d *StepperDriver) ProfileLag() int {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	return d.profileLag
+}
+
+// validateProfile checks for at least one point and strictly increasing, non negative times
+func validateProfile(points []ProfilePoint) error {
+	if len(points) == 0 {
+		return fmt.Errorf("the profile needs at least one point")
+	}
+
+	last := time.Duration(-1)
+	for i, p := range points {
+		if p.Time <= last {
+			return fmt.Errorf("the time (%s) of profile point %d must be greater than the time before", p.Time, i)
+		}
+		last = p.Time
+	}
+
+	return nil
+}
+
+// profileTarget returns the interpolated position of the profile at the given time and whether the end of the
+// profile is reached. Before the first point, the interpolation starts at position zero.
+func profileTarget(points []ProfilePoint, elapsed time.Duration) (int, bool) {
+	last := points[len(points)-1]
+	if elapsed >= last.Time {
+		return last.Position, true
+	}
+
+	before := ProfilePoint{}
+	for _, p := range points {
+		if elapsed < p.Time {
+			ratio := float64(elapsed-before.Time) / float64(p.Time-before.Time)
+			return before.Position + int(math.Round(ratio*float64(p.Position-before.Position))), false
+		}
+		before = p
+	}
+
+	// not reachable
+	return last.Position, true
+}
@@ -0,0 +1,288 @@
+package gpio
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadProfileCSV(t *testing.T) {
+	tests := map[string]struct {
+		csv     string
+		want    []ProfilePoint
+		wantErr string
+	}{
+		"empty": {
+			csv: "",
+		},
+		"with_header": {
+			csv: "time,position\n0,0\n0.5,100\n1.25,-20\n",
+			want: []ProfilePoint{
+				{Time: 0, Position: 0},
+				{Time: 500 * time.Millisecond, Position: 100},
+				{Time: 1250 * time.Millisecond, Position: -20},
+			},
+		},
+		"without_header_comments_and_spaces": {
+			csv: "# my profile\n0.1, 10\n\n 0.2 , 20\n",
+			want: []ProfilePoint{
+				{Time: 100 * time.Millisecond, Position: 10},
+				{Time: 200 * time.Millisecond, Position: 20},
+			},
+		},
+		"error_invalid_value": {
+			csv:     "time,position\n0,0\n0.5,abc\n",
+			wantErr: "invalid profile point '0.5,abc' in line 3",
+		},
+		"error_field_count": {
+			csv:     "0,0\n0.5,1,2\n",
+			wantErr: "wrong number of fields",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := ReadProfileCSV(strings.NewReader(tc.csv))
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func Test_profileTarget(t *testing.T) {
+	points := []ProfilePoint{
+		{Time: 100 * time.Millisecond, Position: 10},
+		{Time: 200 * time.Millisecond, Position: 30},
+		{Time: 400 * time.Millisecond, Position: -10},
+	}
+	tests := map[string]struct {
+		elapsed      time.Duration
+		want         int
+		wantFinished bool
+	}{
+		"start":             {elapsed: 0, want: 0},
+		"before_first":      {elapsed: 50 * time.Millisecond, want: 5},
+		"first":             {elapsed: 100 * time.Millisecond, want: 10},
+		"between_rising":    {elapsed: 125 * time.Millisecond, want: 15},
+		"second":            {elapsed: 200 * time.Millisecond, want: 30},
+		"between_falling":   {elapsed: 300 * time.Millisecond, want: 10},
+		"last":              {elapsed: 400 * time.Millisecond, want: -10, wantFinished: true},
+		"after_last":        {elapsed: time.Second, want: -10, wantFinished: true},
+		"rounding_in_range": {elapsed: 104 * time.Millisecond, want: 11},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, finished := profileTarget(points, tc.elapsed)
+			// assert
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantFinished, finished)
+		})
+	}
+}
+
+func TestEasyFollowProfile(t *testing.T) {
+	// arrange
+	points := []ProfilePoint{
+		{Time: 200 * time.Millisecond, Position: 60},
+		{Time: 300 * time.Millisecond, Position: 30},
+	}
+	d, a := initTestEasyDriverWithStubbedAdaptor()
+	maxSpeed, err := d.MaxSpeed()
+	require.NoError(t, err)
+	require.NoError(t, d.SetSpeed(maxSpeed))
+	// each reading of the clock advances the time by 1 ms, which needs at most one step to follow the profile
+	var elapsed time.Duration
+	start := time.Now()
+	d.profileNow = func() time.Time {
+		now := start.Add(elapsed)
+		elapsed += time.Millisecond
+		return now
+	}
+	type sample struct {
+		t   time.Duration
+		pos int
+	}
+	var samples []sample
+	var pos int
+	a.digitalWriteFunc = func(pin string, val byte) error {
+		// called by the step function with locked value mutex, so direct access is possible
+		if pin == d.stepPin && val == 1 {
+			if d.direction == StepperDriverForward {
+				pos++
+			} else {
+				pos--
+			}
+			// the last reading of the clock was done for the target of this step
+			samples = append(samples, sample{t: elapsed - time.Millisecond, pos: pos})
+		}
+		return nil
+	}
+	// act
+	err = d.FollowProfile(points)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 30, d.CurrentStep())
+	assert.Equal(t, StepperDriverBackward, d.direction)
+	assert.Len(t, samples, 90)
+	assert.Equal(t, 0, d.ProfileLag())
+	for _, s := range samples {
+		want, _ := profileTarget(points, s.t)
+		assert.Equal(t, want, s.pos, "at %s", s.t)
+	}
+}
+
+func TestEasyFollowProfile_directionPin(t *testing.T) {
+	// arrange
+	points := []ProfilePoint{
+		{Time: 50 * time.Millisecond, Position: 3},
+		{Time: 100 * time.Millisecond, Position: 0},
+		{Time: 150 * time.Millisecond, Position: 2},
+	}
+	a := newGpioTestAdaptor()
+	d := NewEasyDriver(a, 0.5, "1", WithEasyDirectionPin("2"))
+	maxSpeed, err := d.MaxSpeed()
+	require.NoError(t, err)
+	require.NoError(t, d.SetSpeed(maxSpeed))
+	// act
+	err = d.FollowProfile(points)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.CurrentStep())
+	var dirWrites []byte
+	for _, w := range a.written {
+		if w.pin == "2" {
+			dirWrites = append(dirWrites, w.val)
+		}
+	}
+	assert.Equal(t, []byte{1, 0}, dirWrites)
+}
+
+func TestEasyFollowProfile_stop(t *testing.T) {
+	tests := map[string]struct {
+		stopFunc     func(d *EasyDriver) error
+		wantDisabled bool
+	}{
+		"stop": {
+			stopFunc: func(d *EasyDriver) error { return d.Stop() },
+		},
+		"soft_stop": {
+			stopFunc: func(d *EasyDriver) error { return d.SoftStop() },
+		},
+		"emergency_stop": {
+			stopFunc:     func(d *EasyDriver) error { return d.EmergencyStop() },
+			wantDisabled: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewEasyDriver(newGpioTestAdaptor(), 0.5, "1", WithEasyEnablePin("3"))
+			var mutex sync.Mutex
+			var stopReturned bool
+			var stepsAfterStop int
+			origStepFunc := d.stepFunc
+			d.stepFunc = func() error {
+				mutex.Lock()
+				if stopReturned {
+					stepsAfterStop++
+				}
+				mutex.Unlock()
+				return origStepFunc()
+			}
+			profileErr := make(chan error, 1)
+			go func() { profileErr <- d.FollowProfile([]ProfilePoint{{Time: 10 * time.Second, Position: 10000}}) }()
+			require.Eventually(t, func() bool { return d.CurrentStep() > 2 }, time.Second, time.Millisecond)
+			require.True(t, d.IsMoving())
+			// act
+			err := tc.stopFunc(d)
+			mutex.Lock()
+			stopReturned = true
+			mutex.Unlock()
+			// assert
+			require.NoError(t, err)
+			select {
+			case err := <-profileErr:
+				require.NoError(t, err)
+			case <-time.After(time.Second):
+				require.Fail(t, "profile not stopped")
+			}
+			assert.False(t, d.IsMoving())
+			assert.Less(t, d.CurrentStep(), 10000)
+			assert.Equal(t, tc.wantDisabled, !d.IsEnabled())
+			mutex.Lock()
+			defer mutex.Unlock()
+			assert.Equal(t, 0, stepsAfterStop)
+		})
+	}
+}
+
+func TestStepperFollowProfile_lag(t *testing.T) {
+	// arrange: the profile needs 1 step per ms, but each step takes 5 ms
+	d, _ := initTestStepperDriverWithStubbedAdaptor()
+	var steps int
+	d.stepFunc = func() error {
+		steps++
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}
+	// act
+	err := d.FollowProfile([]ProfilePoint{{Time: 10 * time.Millisecond, Position: 20}})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 20, steps)
+	assert.GreaterOrEqual(t, d.ProfileLag(), 10)
+}
+
+func TestStepperFollowProfile_error(t *testing.T) {
+	tests := map[string]struct {
+		points           []ProfilePoint
+		disabled         bool
+		simulateWriteErr bool
+		wantErr          string
+	}{
+		"error_empty": {
+			wantErr: "the profile needs at least one point",
+		},
+		"error_time_not_increasing": {
+			points:  []ProfilePoint{{Time: 10, Position: 1}, {Time: 10, Position: 2}},
+			wantErr: "the time (10ns) of profile point 1 must be greater than the time before",
+		},
+		"error_negative_time": {
+			points:  []ProfilePoint{{Time: -2, Position: 1}},
+			wantErr: "the time (-2ns) of profile point 0 must be greater than the time before",
+		},
+		"error_disabled": {
+			points:   []ProfilePoint{{Time: 10, Position: 1}},
+			disabled: true,
+			wantErr:  "is disabled and can not be running or moving",
+		},
+		"error_write": {
+			points:           []ProfilePoint{{Time: 10, Position: 1}},
+			simulateWriteErr: true,
+			wantErr:          "write error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestStepperDriverWithStubbedAdaptor()
+			d.disabled = tc.disabled
+			a.simulateWriteError = tc.simulateWriteErr
+			// act
+			err := d.FollowProfile(tc.points)
+			// assert
+			require.ErrorContains(t, err, tc.wantErr)
+			assert.Equal(t, 0, d.CurrentStep())
+		})
+	}
+}
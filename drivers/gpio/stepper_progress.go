@@ -0,0 +1,51 @@
+package gpio
+
+import (
+	"time"
+)
+
+// stepperMoveProgress contains the progress of an asynchronous movement
+type stepperMoveProgress struct {
+	doneSteps  int
+	totalSteps int  // zero for an endless movement
+	active     bool // false after the movement has finished or was stopped
+	decelerate bool // soft stop requested, see SoftStop()
+	finished   chan struct{}
+}
+
+// Progress returns the count of done steps and the count of all steps of the current or last movement. The total
+// steps are zero for an endless movement, e.g. started by Run(). Both values are zero, if there was no movement yet.
+func (d *StepperDriver) Progress() (int, int) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	if d.moveProgress == nil {
+		return 0, 0
+	}
+
+	return d.moveProgress.doneSteps, d.moveProgress.totalSteps
+}
+
+// EstimatedTimeRemaining returns the estimated time until the current movement is finished, calculated from the
+// remaining steps and the current delay per step or the step timer. Zero is returned, if no movement is running or
+// the movement is endless.
+func (d *StepperDriver) EstimatedTimeRemaining() time.Duration {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	p := d.moveProgress
+	if p == nil || !p.active || p.totalSteps == 0 {
+		return 0
+	}
+
+	if d.stepTimer == nil {
+		return time.Duration(p.totalSteps-p.doneSteps) * d.getDelayPerStep()
+	}
+
+	var remaining time.Duration
+	for i := p.doneSteps; i < p.totalSteps; i++ {
+		remaining += d.limitDelay(d.stepTimer.NextDelay(i, p.totalSteps))
+	}
+
+	return remaining
+}
@@ -0,0 +1,101 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepperProgress(t *testing.T) {
+	const delay = 3125 * time.Microsecond
+
+	tests := map[string]struct {
+		stepTimer     StepTimer
+		steps         int
+		wantDone      []int
+		wantRemaining []time.Duration
+	}{
+		"forward": {
+			steps:         3,
+			wantDone:      []int{0, 1, 2},
+			wantRemaining: []time.Duration{3 * delay, 2 * delay, delay},
+		},
+		"backward": {
+			steps:         -2,
+			wantDone:      []int{0, 1},
+			wantRemaining: []time.Duration{2 * delay, delay},
+		},
+		"with_step_timer": {
+			stepTimer: TrapezoidalStepTimer{StartDelay: 4 * time.Millisecond, CruiseDelay: time.Millisecond,
+				RampSteps: 1},
+			steps:         3,
+			wantDone:      []int{0, 1, 2},
+			wantRemaining: []time.Duration{9 * time.Millisecond, 5 * time.Millisecond, 4 * time.Millisecond},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestStepperDriverWithStubbedAdaptor()
+			d.speedRpm = 600 // ~3 ms
+			d.SetStepTimer(tc.stepTimer)
+			var gotDone []int
+			var gotRemaining []time.Duration
+			origStepFunc := d.stepFunc
+			d.stepFunc = func() error {
+				done, total := d.Progress()
+				assert.Equal(t, absInt(tc.steps), total)
+				gotDone = append(gotDone, done)
+				gotRemaining = append(gotRemaining, d.EstimatedTimeRemaining())
+				return origStepFunc()
+			}
+			// act
+			err := d.Move(tc.steps)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantDone, gotDone)
+			assert.Equal(t, tc.wantRemaining, gotRemaining)
+			done, total := d.Progress()
+			assert.Equal(t, absInt(tc.steps), done)
+			assert.Equal(t, absInt(tc.steps), total)
+			assert.Equal(t, time.Duration(0), d.EstimatedTimeRemaining())
+		})
+	}
+}
+
+func TestStepperProgress_noMovement(t *testing.T) {
+	// arrange
+	d, _ := initTestStepperDriverWithStubbedAdaptor()
+	// act
+	done, total := d.Progress()
+	// assert
+	assert.Equal(t, 0, done)
+	assert.Equal(t, 0, total)
+	assert.Equal(t, time.Duration(0), d.EstimatedTimeRemaining())
+}
+
+func TestStepperProgress_run(t *testing.T) {
+	// arrange
+	d, _ := initTestStepperDriverWithStubbedAdaptor()
+	d.speedRpm = 600 // ~3 ms
+	// act
+	require.NoError(t, d.Run())
+	time.Sleep(20 * time.Millisecond)
+	done, total := d.Progress()
+	remaining := d.EstimatedTimeRemaining()
+	require.NoError(t, d.Stop())
+	// assert
+	assert.Positive(t, done)
+	assert.Equal(t, 0, total)
+	assert.Equal(t, time.Duration(0), remaining)
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
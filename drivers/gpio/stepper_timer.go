@@ -0,0 +1,106 @@
+package gpio
+
+import (
+	"time"
+)
+
+// StepTimer defines the delay before each step of a movement, e.g. to accelerate and decelerate the motor with a ramp.
+// Own implementations can be used for other profiles, e.g. a S-curve.
+type StepTimer interface {
+	// NextDelay returns the delay before the step with the given index (starts with 0) of a movement with the given
+	// count of steps. The count is zero for an endless movement, e.g. started by Run().
+	NextDelay(stepIndex, totalSteps int) time.Duration
+}
+
+// ConstantStepTimer moves all steps with the same delay.
+type ConstantStepTimer struct {
+	Delay time.Duration
+}
+
+// TrapezoidalStepTimer accelerates from the start delay to the cruise delay within the given count of ramp steps and
+// decelerates in the same way at the end of the movement. The speed changes linearly from step to step. If the
+// movement is too short for both ramps, the speed is reversed in the middle of the movement. An endless movement
+// only accelerates.
+type TrapezoidalStepTimer struct {
+	StartDelay  time.Duration // delay of the first and last step, the slowest speed
+	CruiseDelay time.Duration // delay after the acceleration, the fastest speed
+	RampSteps   int           // count of steps for the acceleration and deceleration
+}
+
+// SetStepTimer sets the timing of the steps for the next movements. With nil (default), the delay is given by the
+// speed, see SetSpeed(). The max. step frequency and the dither are also applied to the delays of the timer.
+func (d *StepperDriver) SetStepTimer(timer StepTimer) {
+	d.valueMutex.Lock()
+	defer d.valueMutex.Unlock()
+
+	d.stepTimer = timer
+}
+
+// NextDelay returns the constant delay (interface StepTimer).
+func (t ConstantStepTimer) NextDelay(int, int) time.Duration {
+	return t.Delay
+}
+
+// NextDelay returns the delay according to the position within the ramps (interface StepTimer).
+func (t TrapezoidalStepTimer) NextDelay(stepIndex, totalSteps int) time.Duration {
+	rampPos := stepIndex
+	if totalSteps > 0 && totalSteps-1-stepIndex < rampPos {
+		rampPos = totalSteps - 1 - stepIndex
+	}
+
+	if t.RampSteps <= 0 || rampPos >= t.RampSteps || t.StartDelay <= 0 || t.CruiseDelay <= 0 {
+		return t.CruiseDelay
+	}
+
+	startFreq := 1 / t.StartDelay.Seconds()
+	cruiseFreq := 1 / t.CruiseDelay.Seconds()
+	freq := startFreq + (cruiseFreq-startFreq)*float64(rampPos)/float64(t.RampSteps)
+
+	return time.Duration(float64(time.Second) / freq)
+}
+
+// getStepDelay gives the delay of the next step, given by the step timer of the running movement or by the speed.
+// The value mutex needs to be locked by the caller.
+func (d *StepperDriver) getStepDelay() time.Duration {
+	if d.stepTimer == nil || d.moveProgress == nil || !d.moveProgress.active {
+		return d.getDelayPerStep()
+	}
+
+	return d.limitDelay(d.stepTimer.NextDelay(d.moveProgress.doneSteps, d.moveProgress.totalSteps))
+}
+
+// sumStepTimerDelays gives the duration of a movement with the given steps, timed by the step timer
+func (d *StepperDriver) sumStepTimerDelays(totalSteps int) time.Duration {
+	var sum time.Duration
+	for i := 0; i < totalSteps; i++ {
+		sum += d.limitDelay(d.stepTimer.NextDelay(i, totalSteps))
+	}
+
+	return sum
+}
+
+// takeDeceleration returns the count of steps to decelerate after a soft stop was requested, and true for the first
+// call after the request. A finite movement is not extended, so false is returned, if the remaining steps are not
+// more than the deceleration. The value mutex needs to be locked by the caller.
+func (d *StepperDriver) takeDeceleration(p *stepperMoveProgress, endless bool, stepsLeft uint64) (uint64, bool) {
+	if !p.decelerate {
+		return 0, false
+	}
+	p.decelerate = false
+
+	// the deceleration takes as many steps as the acceleration, which was done until now
+	var decelSteps int
+	if d.stepTimer != nil {
+		for decelSteps < p.doneSteps && d.stepTimer.NextDelay(decelSteps+1, 0) < d.stepTimer.NextDelay(decelSteps, 0) {
+			decelSteps++
+		}
+	}
+
+	if !endless && uint64(decelSteps) >= stepsLeft {
+		return 0, false
+	}
+
+	p.totalSteps = p.doneSteps + decelSteps
+
+	return uint64(decelSteps), true
+}
@@ -0,0 +1,150 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrapezoidalStepTimer(t *testing.T) {
+	const (
+		s = 10 * time.Millisecond // 100 Hz
+		c = 5 * time.Millisecond  // 200 Hz
+	)
+	period := func(hz float64) time.Duration { return time.Duration(float64(time.Second) / hz) }
+	// speed increases by 25 Hz per step
+	r1, r2, r3 := period(125), period(150), period(175)
+
+	tests := map[string]struct {
+		timer      TrapezoidalStepTimer
+		totalSteps int
+		want       []time.Duration
+	}{
+		"ramp_up_cruise_ramp_down": {
+			timer:      TrapezoidalStepTimer{StartDelay: s, CruiseDelay: c, RampSteps: 4},
+			totalSteps: 12,
+			want:       []time.Duration{s, r1, r2, r3, c, c, c, c, r3, r2, r1, s},
+		},
+		"too_short_for_cruise": {
+			timer:      TrapezoidalStepTimer{StartDelay: s, CruiseDelay: c, RampSteps: 4},
+			totalSteps: 5,
+			want:       []time.Duration{s, r1, r2, r1, s},
+		},
+		"endless": {
+			timer: TrapezoidalStepTimer{StartDelay: s, CruiseDelay: c, RampSteps: 4},
+			want:  []time.Duration{s, r1, r2, r3, c, c, c},
+		},
+		"no_ramp": {
+			timer:      TrapezoidalStepTimer{StartDelay: s, CruiseDelay: c},
+			totalSteps: 3,
+			want:       []time.Duration{c, c, c},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			var got []time.Duration
+			for i := range tc.want {
+				got = append(got, tc.timer.NextDelay(i, tc.totalSteps))
+			}
+			// assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestConstantStepTimer(t *testing.T) {
+	// arrange
+	timer := ConstantStepTimer{Delay: 3 * time.Millisecond}
+	// act & assert
+	assert.Equal(t, 3*time.Millisecond, timer.NextDelay(0, 10))
+	assert.Equal(t, 3*time.Millisecond, timer.NextDelay(9, 10))
+	assert.Equal(t, 3*time.Millisecond, timer.NextDelay(100, 0))
+}
+
+// recordStepDelays moves the given steps and records the delay, which is used for each step
+func recordStepDelays(t *testing.T, d *StepperDriver, steps int) []time.Duration {
+	t.Helper()
+
+	var delays []time.Duration
+	origStepFunc := d.stepFunc
+	d.stepFunc = func() error {
+		d.valueMutex.Lock()
+		delays = append(delays, d.getStepDelay())
+		d.valueMutex.Unlock()
+		return origStepFunc()
+	}
+	require.NoError(t, d.Move(steps))
+
+	return delays
+}
+
+func TestStepperSetStepTimer(t *testing.T) {
+	t.Run("constant_reproduces_speed", func(t *testing.T) {
+		// arrange
+		d, _ := initTestStepperDriverWithStubbedAdaptor()
+		d.speedRpm = 600 // ~3 ms
+		wantDelays := recordStepDelays(t, d, 4)
+		d.SetStepTimer(ConstantStepTimer{Delay: d.getDelayPerStep()})
+		// act
+		delays := recordStepDelays(t, d, 4)
+		// assert
+		assert.Equal(t, wantDelays, delays)
+		assert.Equal(t, []time.Duration{3125 * time.Microsecond, 3125 * time.Microsecond, 3125 * time.Microsecond,
+			3125 * time.Microsecond}, delays)
+	})
+	t.Run("trapezoidal", func(t *testing.T) {
+		// arrange
+		d, _ := initTestStepperDriverWithStubbedAdaptor()
+		timer := TrapezoidalStepTimer{StartDelay: 4 * time.Millisecond, CruiseDelay: time.Millisecond, RampSteps: 2}
+		d.SetStepTimer(timer)
+		// act
+		delays := recordStepDelays(t, d, 6)
+		// assert
+		var want []time.Duration
+		for i := 0; i < 6; i++ {
+			want = append(want, timer.NextDelay(i, 6))
+		}
+		assert.Equal(t, want, delays)
+		assert.Equal(t, 6, d.CurrentStep())
+		// assert: the timer is only used for movements
+		assert.False(t, d.moveProgress.active)
+		assert.Equal(t, d.getDelayPerStep(), d.getStepDelay())
+	})
+	t.Run("max_step_frequency", func(t *testing.T) {
+		// arrange
+		d, _ := initTestStepperDriverWithStubbedAdaptor()
+		d.SetMaxStepFrequency(500)
+		d.SetStepTimer(ConstantStepTimer{Delay: time.Millisecond})
+		// act
+		delays := recordStepDelays(t, d, 2)
+		// assert
+		assert.Equal(t, []time.Duration{2 * time.Millisecond, 2 * time.Millisecond}, delays)
+	})
+	t.Run("reset", func(t *testing.T) {
+		// arrange
+		d, _ := initTestStepperDriverWithStubbedAdaptor()
+		d.speedRpm = 600
+		d.SetStepTimer(ConstantStepTimer{Delay: time.Millisecond})
+		// act
+		d.SetStepTimer(nil)
+		delays := recordStepDelays(t, d, 1)
+		// assert
+		assert.Equal(t, []time.Duration{3125 * time.Microsecond}, delays)
+	})
+}
+
+func TestStepperMove_slowStepTimer(t *testing.T) {
+	// the timeout of the movement must consider the delays of the step timer instead of the speed
+	// arrange
+	d, _ := initTestStepperDriverWithStubbedAdaptor()
+	d.speedRpm = 600 // ~3 ms
+	d.SetStepTimer(ConstantStepTimer{Delay: 60 * time.Millisecond})
+	// act
+	err := d.Move(3)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, d.CurrentStep())
+}
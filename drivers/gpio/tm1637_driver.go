@@ -0,0 +1,356 @@
+package gpio
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// Commands of the driver
+const (
+	TM1637DataCmd  = 0x40 // write data to display register with automatic address increment
+	TM1637DispCtrl = 0x80 // display off, with 0x08 display on and bits 0..2 for the brightness
+	TM1637AddrCmd  = 0xC0 // address of the first digit
+
+	TM1637DispOn = 0x08
+)
+
+const (
+	// TM1637Digits is the count of digits of the common 4-digit modules
+	TM1637Digits = 4
+	// TM1637MaxBrightness is the highest level of the brightness
+	TM1637MaxBrightness = 7
+
+	tm1637ColonDigit = 1    // the colon of clock displays is connected to the dot segment of the second digit
+	tm1637ColonBit   = 0x80 // dot segment
+)
+
+// tm1637OptionApplier needs to be implemented by each configurable option type
+type tm1637OptionApplier interface {
+	apply(cfg *tm1637Configuration)
+}
+
+// tm1637Configuration contains all changeable attributes of the driver.
+type tm1637Configuration struct {
+	ackCheck bool
+}
+
+// tm1637AckCheckOption is the type for applying the check of the acknowledge to the configuration
+type tm1637AckCheckOption bool
+
+// TM1637Driver is the driver for 4-digit 7-segment displays based on the TM1637, which are often used for clocks. The
+// chip is driven by a 2-wire protocol, which is similar to I2C, but without an address and with LSB first.
+//
+// Datasheet EN: https://www.mcielectronics.cl/website_MCI/static/documents/Datasheet_TM1637.pdf
+type TM1637Driver struct {
+	*driver
+	tm1637Cfg  *tm1637Configuration
+	pinClock   *DirectPinDriver
+	pinData    *DirectPinDriver
+	fonts      map[string]byte
+	brightness byte
+	colon      bool
+	segments   [TM1637Digits]byte
+}
+
+// NewTM1637Driver return a new TM1637Driver given a gobot.Connection and the clock and data pins.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithTM1637AckCheck"
+//
+// Adds the following API Commands:
+//
+//	"DisplayInt" - See TM1637Driver.DisplayInt
+//	"DisplayText" - See TM1637Driver.DisplayText
+//	"SetBrightness" - See TM1637Driver.SetBrightness
+//	"SetColon" - See TM1637Driver.SetColon
+func NewTM1637Driver(a gobot.Connection, clockPin, dataPin string, opts ...interface{}) *TM1637Driver {
+	d := &TM1637Driver{
+		driver:     newDriver(a, "TM1637"),
+		tm1637Cfg:  &tm1637Configuration{},
+		pinClock:   NewDirectPinDriver(a, clockPin),
+		pinData:    NewDirectPinDriver(a, dataPin),
+		fonts:      NewTM1638Fonts(),
+		brightness: TM1637MaxBrightness,
+	}
+	d.afterStart = d.initialize
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case tm1637OptionApplier:
+			o.apply(d.tm1637Cfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	//nolint:forcetypeassert // ok here
+	d.AddCommand("DisplayInt", func(params map[string]interface{}) interface{} {
+		return d.DisplayInt(int(params["value"].(float64)))
+	})
+	//nolint:forcetypeassert // ok here
+	d.AddCommand("DisplayText", func(params map[string]interface{}) interface{} {
+		return d.DisplayText(params["text"].(string))
+	})
+	//nolint:forcetypeassert // ok here
+	d.AddCommand("SetBrightness", func(params map[string]interface{}) interface{} {
+		return d.SetBrightness(byte(params["level"].(float64)))
+	})
+	//nolint:forcetypeassert // ok here
+	d.AddCommand("SetColon", func(params map[string]interface{}) interface{} {
+		return d.SetColon(params["on"].(bool))
+	})
+
+	return d
+}
+
+// WithTM1637AckCheck activates the check of the acknowledge, which is given by the chip after each byte. This needs
+// an adaptor, which can read back the level of the data pin while it is released (high level), e.g. by an external
+// pull-up resistor.
+func WithTM1637AckCheck() tm1637OptionApplier {
+	return tm1637AckCheckOption(true)
+}
+
+// SetBrightness changes the brightness of the display (0-7). The display is switched on.
+func (d *TM1637Driver) SetBrightness(level byte) error {
+	if level > TM1637MaxBrightness {
+		return fmt.Errorf("brightness (%d) of '%s' must be between 0 and %d", level, d.driverCfg.name,
+			TM1637MaxBrightness)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.brightness = level
+
+	return d.writeCommand(TM1637DispCtrl | TM1637DispOn | d.brightness)
+}
+
+// Brightness returns the current brightness of the display.
+func (d *TM1637Driver) Brightness() byte {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.brightness
+}
+
+// DisplayOff switches off the display, the content is kept. Use SetBrightness() to switch on the display again.
+func (d *TM1637Driver) DisplayOff() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeCommand(TM1637DispCtrl)
+}
+
+// DisplayInt shows the number right aligned, negative numbers with a leading minus. The number needs to fit into the
+// four digits, so the range is -999..9999.
+func (d *TM1637Driver) DisplayInt(n int) error {
+	if n < -999 || n > 9999 {
+		return fmt.Errorf("number (%d) does not fit into the %d digits of '%s'", n, TM1637Digits, d.driverCfg.name)
+	}
+
+	return d.DisplayText(fmt.Sprintf("%4s", strconv.Itoa(n)))
+}
+
+// DisplayText shows the text left aligned, unused digits are blank. The text can have up to four displayable
+// characters, e.g. digits, most letters, "-" and "_". An error is returned for characters without a representation
+// on a 7-segment digit.
+func (d *TM1637Driver) DisplayText(text string) error {
+	chars := strings.Split(text, "")
+	if len(chars) > TM1637Digits {
+		return fmt.Errorf("text '%s' is too long for the %d digits of '%s'", text, TM1637Digits, d.driverCfg.name)
+	}
+
+	var segments [TM1637Digits]byte
+	for i, char := range chars {
+		val, ok := d.fonts[char]
+		if !ok || (val == 0 && char != " ") {
+			return fmt.Errorf("character '%s' can not be displayed by '%s'", char, d.driverCfg.name)
+		}
+		segments[i] = val
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.segments = segments
+
+	return d.writeSegments()
+}
+
+// SetColon switches the colon of clock displays on or off, the digits are kept.
+func (d *TM1637Driver) SetColon(on bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.colon = on
+
+	return d.writeSegments()
+}
+
+// ToggleColon switches the colon of clock displays, e.g. each second.
+func (d *TM1637Driver) ToggleColon() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.colon = !d.colon
+
+	return d.writeSegments()
+}
+
+// Colon returns whether the colon is switched on.
+func (d *TM1637Driver) Colon() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.colon
+}
+
+// Clear switches off all segments and the colon.
+func (d *TM1637Driver) Clear() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.segments = [TM1637Digits]byte{}
+	d.colon = false
+
+	return d.writeSegments()
+}
+
+// initialize releases the bus, clears the display and switches it on with the current brightness
+func (d *TM1637Driver) initialize() error {
+	if err := d.pinClock.On(); err != nil {
+		return err
+	}
+	if err := d.pinData.On(); err != nil {
+		return err
+	}
+	if err := d.writeSegments(); err != nil {
+		return err
+	}
+
+	return d.writeCommand(TM1637DispCtrl | TM1637DispOn | d.brightness)
+}
+
+// writeSegments writes all digits, starting at the first address. The mutex needs to be locked by the caller.
+func (d *TM1637Driver) writeSegments() error {
+	if err := d.writeCommand(TM1637DataCmd); err != nil {
+		return err
+	}
+
+	data := make([]byte, 0, TM1637Digits+1)
+	data = append(data, TM1637AddrCmd)
+	for i, segment := range d.segments {
+		if i == tm1637ColonDigit && d.colon {
+			segment |= tm1637ColonBit
+		}
+		data = append(data, segment)
+	}
+
+	return d.writeFrame(data...)
+}
+
+// writeCommand writes a single command byte in its own frame
+func (d *TM1637Driver) writeCommand(cmd byte) error {
+	return d.writeFrame(cmd)
+}
+
+// writeFrame writes the given bytes between a start and a stop condition
+func (d *TM1637Driver) writeFrame(data ...byte) error {
+	if err := d.start(); err != nil {
+		return err
+	}
+
+	for _, b := range data {
+		if err := d.writeByte(b); err != nil {
+			return err
+		}
+	}
+
+	return d.stop()
+}
+
+// start signals the start condition, the data pin falls while the clock is high
+func (d *TM1637Driver) start() error {
+	if err := d.pinClock.On(); err != nil {
+		return err
+	}
+	if err := d.pinData.On(); err != nil {
+		return err
+	}
+
+	return d.pinData.Off()
+}
+
+// stop signals the stop condition, the data pin rises while the clock is high
+func (d *TM1637Driver) stop() error {
+	if err := d.pinClock.Off(); err != nil {
+		return err
+	}
+	if err := d.pinData.Off(); err != nil {
+		return err
+	}
+	if err := d.pinClock.On(); err != nil {
+		return err
+	}
+
+	return d.pinData.On()
+}
+
+// writeByte writes the byte with LSB first, the data is taken over by the chip on the rising edge of the clock, and
+// clocks the acknowledge afterwards
+func (d *TM1637Driver) writeByte(data byte) error {
+	for i := 0; i < 8; i++ {
+		if err := d.pinClock.Off(); err != nil {
+			return err
+		}
+		if err := d.pinData.DigitalWrite(data & 1); err != nil {
+			return err
+		}
+		if err := d.pinClock.On(); err != nil {
+			return err
+		}
+		data >>= 1
+	}
+
+	return d.ack()
+}
+
+// ack clocks the acknowledge, the chip pulls down the released data pin until the falling edge of the ninth clock
+func (d *TM1637Driver) ack() error {
+	if err := d.pinClock.Off(); err != nil {
+		return err
+	}
+	if err := d.pinData.On(); err != nil {
+		return err
+	}
+	if err := d.pinClock.On(); err != nil {
+		return err
+	}
+
+	if d.tm1637Cfg.ackCheck {
+		val, err := d.pinData.DigitalRead()
+		if err != nil {
+			return err
+		}
+		if val != 0 {
+			return fmt.Errorf("no acknowledge from '%s'", d.driverCfg.name)
+		}
+	}
+
+	return d.pinClock.Off()
+}
+
+func (o tm1637AckCheckOption) String() string {
+	return "acknowledge check option for TM1637 drivers"
+}
+
+func (o tm1637AckCheckOption) apply(cfg *tm1637Configuration) {
+	cfg.ackCheck = bool(o)
+}
@@ -0,0 +1,357 @@
+package gpio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+)
+
+var _ gobot.Driver = (*TM1637Driver)(nil)
+
+const (
+	tm1637TestClockPin = "1"
+	tm1637TestDataPin  = "2"
+)
+
+func initTestTM1637DriverWithStubbedAdaptor() (*TM1637Driver, *gpioTestAdaptor) {
+	a := newGpioTestAdaptor()
+	d := NewTM1637Driver(a, tm1637TestClockPin, tm1637TestDataPin)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	a.written = nil
+	return d, a
+}
+
+// tm1637TestFrames decodes the written levels of clock and data pin into frames of bytes. A frame starts with a falling
+// data pin and ends with a rising data pin, both while the clock is high. The bits are sampled on the rising edge of
+// the clock with LSB first, each byte is followed by the acknowledge bit, which needs to be released (high).
+func tm1637TestFrames(t *testing.T, written []gpioTestWritten) [][]byte {
+	t.Helper()
+
+	var frames [][]byte
+	var bits []byte
+	var inFrame bool
+	clock, data := byte(1), byte(1)
+	for _, w := range written {
+		switch w.pin {
+		case tm1637TestClockPin:
+			if inFrame && clock == 0 && w.val == 1 {
+				bits = append(bits, data)
+			}
+			clock = w.val
+		case tm1637TestDataPin:
+			if clock == 1 && data == 1 && w.val == 0 {
+				inFrame = true
+				bits = nil
+			}
+			if clock == 1 && data == 0 && w.val == 1 && inFrame {
+				inFrame = false
+				// the clock pulse of the stop condition is not part of a byte
+				var frame []byte
+				for i := 0; i+9 <= len(bits); i += 9 {
+					var b byte
+					for j := 0; j < 8; j++ {
+						b |= bits[i+j] << j
+					}
+					assert.Equal(t, byte(1), bits[i+8], "released data pin on acknowledge")
+					frame = append(frame, b)
+				}
+				frames = append(frames, frame)
+			}
+			data = w.val
+		default:
+			t.Errorf("unexpected pin '%s'", w.pin)
+		}
+	}
+
+	assert.False(t, inFrame, "frame without stop condition")
+
+	return frames
+}
+
+func TestNewTM1637Driver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	d := NewTM1637Driver(a, "1", "2")
+	// assert
+	assert.IsType(t, &TM1637Driver{}, d)
+	// assert: gpio.driver attributes
+	require.NotNil(t, d.driver)
+	assert.True(t, strings.HasPrefix(d.driverCfg.name, "TM1637"))
+	assert.Equal(t, a, d.connection)
+	assert.NotNil(t, d.afterStart)
+	assert.NotNil(t, d.beforeHalt)
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+	// assert: driver specific attributes
+	assert.False(t, d.tm1637Cfg.ackCheck)
+	assert.NotNil(t, d.pinClock)
+	assert.NotNil(t, d.pinData)
+	assert.NotNil(t, d.fonts)
+	assert.Equal(t, byte(7), d.brightness)
+	assert.False(t, d.colon)
+}
+
+func TestNewTM1637Driver_options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithName() option, least one
+	// option of this driver and one of another driver (which should lead to panic). Further tests for options can also
+	// be done by call of "WithOption(val).apply(cfg)".
+	// arrange
+	const myName = "clock"
+	panicFunc := func() {
+		NewTM1637Driver(newGpioTestAdaptor(), "1", "2", WithName("crazy"),
+			aio.WithActuatorScaler(func(float64) int { return 0 }))
+	}
+	// act
+	d := NewTM1637Driver(newGpioTestAdaptor(), "1", "2", WithName(myName), WithTM1637AckCheck())
+	// assert
+	assert.Equal(t, myName, d.Name())
+	assert.True(t, d.tm1637Cfg.ackCheck)
+	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
+}
+
+func TestTM1637Start(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewTM1637Driver(a, tm1637TestClockPin, tm1637TestDataPin)
+	// act
+	err := d.Start()
+	// assert
+	require.NoError(t, err)
+	want := [][]byte{{0x40}, {0xC0, 0x00, 0x00, 0x00, 0x00}, {0x8F}}
+	assert.Equal(t, want, tm1637TestFrames(t, a.written))
+}
+
+func TestTM1637DisplayInt_withColon(t *testing.T) {
+	// arrange
+	d, a := initTestTM1637DriverWithStubbedAdaptor()
+	require.NoError(t, d.SetColon(true))
+	a.written = nil
+	// act
+	err := d.DisplayInt(1234)
+	// assert
+	require.NoError(t, err)
+	want := [][]byte{
+		{0x40},                                // data command, auto increment
+		{0xC0, 0x06, 0x5B | 0x80, 0x4F, 0x66}, // address command, "1", "2" with colon, "3", "4"
+	}
+	assert.Equal(t, want, tm1637TestFrames(t, a.written))
+}
+
+func TestTM1637DisplayInt(t *testing.T) {
+	tests := map[string]struct {
+		value   int
+		want    []byte
+		wantErr string
+	}{
+		"one_digit": {
+			value: 7,
+			want:  []byte{0x00, 0x00, 0x00, 0x27},
+		},
+		"negative": {
+			value: -42,
+			want:  []byte{0x00, 0x40, 0x66, 0x5B},
+		},
+		"min": {
+			value: -999,
+			want:  []byte{0x40, 0x6F, 0x6F, 0x6F},
+		},
+		"max": {
+			value: 9999,
+			want:  []byte{0x6F, 0x6F, 0x6F, 0x6F},
+		},
+		"error_too_big": {
+			value:   10000,
+			wantErr: "number (10000) does not fit into the 4 digits of 'TM1637",
+		},
+		"error_too_small": {
+			value:   -1000,
+			wantErr: "number (-1000) does not fit into the 4 digits of 'TM1637",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestTM1637DriverWithStubbedAdaptor()
+			// act
+			err := d.DisplayInt(tc.value)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Empty(t, a.written)
+				return
+			}
+			require.NoError(t, err)
+			want := [][]byte{{0x40}, append([]byte{0xC0}, tc.want...)}
+			assert.Equal(t, want, tm1637TestFrames(t, a.written))
+		})
+	}
+}
+
+func TestTM1637DisplayText(t *testing.T) {
+	tests := map[string]struct {
+		text    string
+		want    []byte
+		wantErr string
+	}{
+		"full": {
+			text: "AbC-",
+			want: []byte{0x77, 0x7C, 0x39, 0x40},
+		},
+		"short_with_blank": {
+			text: "H i",
+			want: []byte{0x76, 0x00, 0x04, 0x00},
+		},
+		"empty": {
+			text: "",
+			want: []byte{0x00, 0x00, 0x00, 0x00},
+		},
+		"error_too_long": {
+			text:    "hello",
+			wantErr: "text 'hello' is too long for the 4 digits of 'TM1637",
+		},
+		"error_not_displayable": {
+			text:    "1+2",
+			wantErr: "character '+' can not be displayed by 'TM1637",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestTM1637DriverWithStubbedAdaptor()
+			// act
+			err := d.DisplayText(tc.text)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Empty(t, a.written)
+				return
+			}
+			require.NoError(t, err)
+			want := [][]byte{{0x40}, append([]byte{0xC0}, tc.want...)}
+			assert.Equal(t, want, tm1637TestFrames(t, a.written))
+		})
+	}
+}
+
+func TestTM1637ToggleColon(t *testing.T) {
+	// arrange
+	d, a := initTestTM1637DriverWithStubbedAdaptor()
+	require.NoError(t, d.DisplayText("1200"))
+	// act & assert
+	a.written = nil
+	require.NoError(t, d.ToggleColon())
+	assert.True(t, d.Colon())
+	assert.Equal(t, [][]byte{{0x40}, {0xC0, 0x06, 0xDB, 0x3F, 0x3F}}, tm1637TestFrames(t, a.written))
+	a.written = nil
+	require.NoError(t, d.ToggleColon())
+	assert.False(t, d.Colon())
+	assert.Equal(t, [][]byte{{0x40}, {0xC0, 0x06, 0x5B, 0x3F, 0x3F}}, tm1637TestFrames(t, a.written))
+}
+
+func TestTM1637Clear(t *testing.T) {
+	// arrange
+	d, a := initTestTM1637DriverWithStubbedAdaptor()
+	require.NoError(t, d.DisplayInt(1234))
+	require.NoError(t, d.SetColon(true))
+	a.written = nil
+	// act
+	err := d.Clear()
+	// assert
+	require.NoError(t, err)
+	assert.False(t, d.Colon())
+	assert.Equal(t, [][]byte{{0x40}, {0xC0, 0x00, 0x00, 0x00, 0x00}}, tm1637TestFrames(t, a.written))
+}
+
+func TestTM1637SetBrightness(t *testing.T) {
+	tests := map[string]struct {
+		level   byte
+		want    [][]byte
+		wantErr string
+	}{
+		"min": {
+			level: 0,
+			want:  [][]byte{{0x88}},
+		},
+		"max": {
+			level: 7,
+			want:  [][]byte{{0x8F}},
+		},
+		"error_too_bright": {
+			level:   8,
+			wantErr: "brightness (8) of 'TM1637",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestTM1637DriverWithStubbedAdaptor()
+			// act
+			err := d.SetBrightness(tc.level)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Empty(t, a.written)
+				assert.Equal(t, byte(7), d.Brightness())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.level, d.Brightness())
+			assert.Equal(t, tc.want, tm1637TestFrames(t, a.written))
+		})
+	}
+}
+
+func TestTM1637DisplayOff(t *testing.T) {
+	// arrange
+	d, a := initTestTM1637DriverWithStubbedAdaptor()
+	// act
+	err := d.DisplayOff()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x80}}, tm1637TestFrames(t, a.written))
+}
+
+func TestTM1637AckCheck(t *testing.T) {
+	tests := map[string]struct {
+		readVal int
+		wantErr string
+	}{
+		"acknowledged": {
+			readVal: 0,
+		},
+		"not_acknowledged": {
+			readVal: 1,
+			wantErr: "no acknowledge from 'TM1637",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newGpioTestAdaptor()
+			var reads int
+			a.digitalReadFunc = func(pin string) (int, error) {
+				assert.Equal(t, tm1637TestDataPin, pin)
+				reads++
+				return tc.readVal, nil
+			}
+			d := NewTM1637Driver(a, tm1637TestClockPin, tm1637TestDataPin, WithTM1637AckCheck())
+			// act
+			err := d.SetBrightness(3)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, 1, reads)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package gpio
+
+import (
+	"time"
+)
+
+// valueChangeDetector is used by polling drivers to publish an event only, if the read value differs from the
+// previous one. With a heartbeat interval greater than zero, the unchanged value is reported again after this time,
+// e.g. to signal that the sensor is still alive.
+type valueChangeDetector struct {
+	last       int
+	heartbeat  time.Duration
+	lastReport time.Time
+	now        func() time.Time
+}
+
+// newValueChangeDetector creates a detector with the given initial value, which is not reported as change.
+func newValueChangeDetector(initial int, heartbeat time.Duration) *valueChangeDetector {
+	c := &valueChangeDetector{last: initial, heartbeat: heartbeat, now: time.Now}
+	c.lastReport = c.now()
+
+	return c
+}
+
+// changed returns true, if the value differs from the previous one or the heartbeat is due. In this case the value
+// is taken over as the last reported value.
+func (c *valueChangeDetector) changed(value int) bool {
+	now := c.now()
+	if value == c.last && (c.heartbeat <= 0 || now.Sub(c.lastReport) < c.heartbeat) {
+		return false
+	}
+
+	c.last = value
+	c.lastReport = now
+
+	return true
+}
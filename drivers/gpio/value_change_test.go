@@ -0,0 +1,55 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueChangeDetector(t *testing.T) {
+	tests := map[string]struct {
+		heartbeat time.Duration
+		elapsed   time.Duration // simulated time between reads
+		values    []int
+		want      []bool
+	}{
+		"unchanged_no_report": {
+			values: []int{0, 0, 0},
+			want:   []bool{false, false, false},
+		},
+		"changed_report_once": {
+			values: []int{1, 1, 0, 0, 1},
+			want:   []bool{true, false, true, false, true},
+		},
+		"heartbeat_not_due": {
+			heartbeat: 10 * time.Millisecond,
+			elapsed:   4 * time.Millisecond,
+			values:    []int{0, 0, 1, 1},
+			want:      []bool{false, false, true, false},
+		},
+		"heartbeat_due": {
+			heartbeat: 10 * time.Millisecond,
+			elapsed:   5 * time.Millisecond,
+			values:    []int{0, 0, 0, 0, 0},
+			want:      []bool{false, true, false, true, false},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			now := time.Now()
+			c := newValueChangeDetector(0, tc.heartbeat)
+			c.lastReport = now
+			c.now = func() time.Time { return now }
+			// act
+			var got []bool
+			for _, v := range tc.values {
+				now = now.Add(tc.elapsed)
+				got = append(got, c.changed(v))
+			}
+			// assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
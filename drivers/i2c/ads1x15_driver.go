@@ -443,7 +443,8 @@ func (d *ADS1x15Driver) waitForConversionFinished(delay time.Duration) error {
 	for i := 0; i < ads1x15WaitMaxCount; i++ {
 		if i == ads1x15WaitMaxCount-1 {
 			// most likely the last try will also not finish, so we stop with an error
-			return fmt.Errorf("The conversion is not finished within %s", time.Since(start))
+			return fmt.Errorf("%w: the conversion of '%s' is not finished within %s", ErrMeasurementTimeout, d.name,
+				time.Since(start))
 		}
 
 		data, err := d.readWordBigEndian(ads1x15PointerConfig)
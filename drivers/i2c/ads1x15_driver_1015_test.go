@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -283,3 +284,18 @@ func TestADS1015_rawRead(t *testing.T) {
 		})
 	}
 }
+
+func TestADS1x15_waitForConversionFinishedTimeout(t *testing.T) {
+	// arrange
+	d, a := initTestADS1015DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		// bit 15 is never set, so the conversion is in progress forever
+		copy(b, []byte{0x00, 0x00})
+		return len(b), nil
+	}
+	// act
+	err := d.waitForConversionFinished(time.Microsecond)
+	// assert
+	require.ErrorIs(t, err, ErrMeasurementTimeout)
+	assert.Contains(t, err.Error(), "is not finished within")
+}
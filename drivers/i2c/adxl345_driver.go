@@ -4,6 +4,10 @@ import (
 	"encoding/binary"
 	"fmt"
 	"log"
+	"math"
+	"time"
+
+	"gobot.io/x/gobot/v2"
 )
 
 const adxl345Debug = false
@@ -15,9 +19,19 @@ const (
 	adxl345DefaultAddress = 0x53
 )
 
+const (
+	// ADXL345Tap event, published on a detected single tap
+	ADXL345Tap = "tap"
+	// ADXL345DoubleTap event, published on a detected double tap
+	ADXL345DoubleTap = "doubletap"
+	// ADXL345FreeFall event, published on a detected free-fall
+	ADXL345FreeFall = "freefall"
+)
+
 type (
 	ADXL345RateConfig    uint8
 	ADXL345FsRangeConfig uint8
+	ADXL345TapAxes       uint8
 )
 
 const (
@@ -76,8 +90,34 @@ const (
 	ADXL345FsRange_4G  ADXL345FsRangeConfig = 0x01 // +-4 g
 	ADXL345FsRange_8G  ADXL345FsRangeConfig = 0x02 // +-8 g
 	ADXL345FsRange_16G ADXL345FsRangeConfig = 0x03 // +-16 g)
+
+	ADXL345TapAxis_Z   ADXL345TapAxes = 0x01
+	ADXL345TapAxis_Y   ADXL345TapAxes = 0x02
+	ADXL345TapAxis_X   ADXL345TapAxes = 0x04
+	ADXL345TapAxis_All ADXL345TapAxes = 0x07
+
+	// bits of interrupt enable and interrupt source register
+	adxl345Int_SingleTapBit = 0x40
+	adxl345Int_DoubleTapBit = 0x20
+	adxl345Int_FreeFallBit  = 0x04
+
+	// scale factors of the tap and free-fall registers
+	adxl345Scale_ThreshG    = 0.0625                  // THRESH_TAP, THRESH_FF: 62.5 mg/LSB
+	adxl345Scale_TapDur     = 625 * time.Microsecond  // DUR: 625 us/LSB
+	adxl345Scale_TapLatency = 1250 * time.Microsecond // LATENT, WINDOW: 1.25 ms/LSB
+	adxl345Scale_TimeFF     = 5 * time.Millisecond    // TIME_FF: 5 ms/LSB
 )
 
+// ADXL345TapConfig contains the settings of the tap detection. The double tap detection is only active, if latency
+// and window are given. See the datasheet for recommended values.
+type ADXL345TapConfig struct {
+	ThresholdG float64        // min. acceleration of a tap, 62.5 mg/LSB, max. 15.9 g
+	Duration   time.Duration  // max. time above the threshold to be a tap, 625 us/LSB, max. 159 ms
+	Latency    time.Duration  // wait time after the first tap until the window starts, 1.25 ms/LSB, max. 318 ms
+	Window     time.Duration  // time after the latency to detect the second tap, 1.25 ms/LSB, max. 318 ms
+	Axes       ADXL345TapAxes // axes used for the detection, zero means all axes
+}
+
 // ADXL345Driver is the gobot driver for the digital accelerometer ADXL345
 //
 // Datasheet EN: http://www.analog.com/media/en/technical-documentation/data-sheets/ADXL345.pdf
@@ -86,9 +126,21 @@ const (
 // Ported from the Arduino driver https://github.com/jakalada/Arduino-ADXL345
 type ADXL345Driver struct {
 	*Driver
-	powerCtl   adxl345PowerCtl
-	dataFormat adxl345DataFormat
-	bwRate     adxl345BwRate
+	gobot.Eventer
+	powerCtl     adxl345PowerCtl
+	dataFormat   adxl345DataFormat
+	bwRate       adxl345BwRate
+	tap          *ADXL345TapConfig
+	freeFall     *adxl345FreeFall
+	intEnable    uint8
+	pollInterval time.Duration
+	halt         chan struct{}
+}
+
+// Internal structure for the free-fall configuration
+type adxl345FreeFall struct {
+	thresholdG float64
+	duration   time.Duration
 }
 
 // Internal structure for the power configuration
@@ -125,9 +177,13 @@ type adxl345BwRate struct {
 //
 //	i2c.WithBus(int):	bus to use with this driver
 //	i2c.WithAddress(int):	address to use with this driver
+//	i2c.WithADXL345TapDetection(ADXL345TapConfig):	activates the tap detection
+//	i2c.WithADXL345FreeFallDetection(float64, time.Duration):	activates the free-fall detection
+//	i2c.WithADXL345InterruptPolling(time.Duration):	interval for reading the interrupt source and publish the events
 func NewADXL345Driver(c Connector, options ...func(Config)) *ADXL345Driver {
 	d := &ADXL345Driver{
-		Driver: NewDriver(c, "ADXL345", adxl345DefaultAddress),
+		Driver:  NewDriver(c, "ADXL345", adxl345DefaultAddress),
+		Eventer: gobot.NewEventer(),
 		powerCtl: adxl345PowerCtl{
 			measure: 1,
 		},
@@ -146,6 +202,11 @@ func NewADXL345Driver(c Connector, options ...func(Config)) *ADXL345Driver {
 		option(d)
 	}
 
+	d.AddEvent(ADXL345Tap)
+	d.AddEvent(ADXL345DoubleTap)
+	d.AddEvent(ADXL345FreeFall)
+	d.AddEvent(Error)
+
 	// TODO: add commands for API
 	return d
 }
@@ -185,6 +246,43 @@ func WithADXL345FullScaleRange(val ADXL345FsRangeConfig) func(Config) {
 	}
 }
 
+// WithADXL345TapDetection option activates the detection of single taps and, if latency and window are given, also
+// double taps. The tap events are published by PollInterrupts().
+func WithADXL345TapDetection(cfg ADXL345TapConfig) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*ADXL345Driver); ok {
+			d.tap = &cfg
+		} else if adxl345Debug {
+			log.Printf("Trying to set tap detection for non-ADXL345Driver %v", c)
+		}
+	}
+}
+
+// WithADXL345FreeFallDetection option activates the detection of a free-fall. All axes needs to be below the given
+// threshold (62.5 mg/LSB, recommended 0.3-0.6 g) for at least the given duration (5 ms/LSB, recommended 100-350 ms).
+// The free-fall event is published by PollInterrupts().
+func WithADXL345FreeFallDetection(thresholdG float64, duration time.Duration) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*ADXL345Driver); ok {
+			d.freeFall = &adxl345FreeFall{thresholdG: thresholdG, duration: duration}
+		} else if adxl345Debug {
+			log.Printf("Trying to set free-fall detection for non-ADXL345Driver %v", c)
+		}
+	}
+}
+
+// WithADXL345InterruptPolling option activates the cyclic call of PollInterrupts() with the given interval. If the
+// interrupt pin of the device is wired, calling PollInterrupts() on a change of the pin can be used instead.
+func WithADXL345InterruptPolling(interval time.Duration) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*ADXL345Driver); ok {
+			d.pollInterval = interval
+		} else if adxl345Debug {
+			log.Printf("Trying to set interrupt polling for non-ADXL345Driver %v", c)
+		}
+	}
+}
+
 // UseLowPower change the current rate of the sensor
 func (d *ADXL345Driver) UseLowPower(lowPower bool) error {
 	d.mutex.Lock()
@@ -212,6 +310,60 @@ func (d *ADXL345Driver) SetRange(fullScaleRange ADXL345FsRangeConfig) error {
 	return d.connection.WriteByteData(adxl345Reg_DATA_FORMAT, d.dataFormat.toByte())
 }
 
+// SetTapDetection writes the tap configuration and activates the detection immediately, see
+// WithADXL345TapDetection().
+func (d *ADXL345Driver) SetTapDetection(cfg ADXL345TapConfig) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.writeTapDetection(cfg); err != nil {
+		return err
+	}
+	d.tap = &cfg
+
+	return d.connection.WriteByteData(adxl345Reg_INT_ENABLE, d.intEnable)
+}
+
+// SetFreeFallDetection writes the free-fall configuration and activates the detection immediately, see
+// WithADXL345FreeFallDetection().
+func (d *ADXL345Driver) SetFreeFallDetection(thresholdG float64, duration time.Duration) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	ff := adxl345FreeFall{thresholdG: thresholdG, duration: duration}
+	if err := d.writeFreeFallDetection(ff); err != nil {
+		return err
+	}
+	d.freeFall = &ff
+
+	return d.connection.WriteByteData(adxl345Reg_INT_ENABLE, d.intEnable)
+}
+
+// PollInterrupts reads the interrupt source register and publishes the events of the activated detections. The
+// register is cleared by the read. A double tap is published together with the tap event of its first tap.
+//
+// Emits the Events:
+//
+//	ADXL345Tap - On a single tap was detected
+//	ADXL345DoubleTap - On a double tap was detected
+//	ADXL345FreeFall - On a free-fall was detected
+func (d *ADXL345Driver) PollInterrupts() error {
+	d.mutex.Lock()
+	source, err := d.connection.ReadByteData(adxl345Reg_INT_SOUCE)
+	intEnable := d.intEnable
+	d.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	for _, evt := range adxl345InterruptEvents(source & intEnable) {
+		d.Publish(d.Event(evt), nil)
+	}
+
+	return nil
+}
+
 // XYZ returns the adjusted x, y and z axis, unit [g]
 func (d *ADXL345Driver) XYZ() (float64, float64, float64, error) {
 	d.mutex.Lock()
@@ -256,10 +408,36 @@ func (d *ADXL345Driver) initialize() error {
 		return err
 	}
 
+	if d.tap != nil {
+		if err := d.writeTapDetection(*d.tap); err != nil {
+			return err
+		}
+	}
+	if d.freeFall != nil {
+		if err := d.writeFreeFallDetection(*d.freeFall); err != nil {
+			return err
+		}
+	}
+	if d.intEnable != 0 {
+		if err := d.connection.WriteByteData(adxl345Reg_INT_ENABLE, d.intEnable); err != nil {
+			return err
+		}
+	}
+
+	if d.pollInterval > 0 {
+		d.halt = make(chan struct{})
+		go d.pollInterruptsCyclic(d.halt)
+	}
+
 	return nil
 }
 
 func (d *ADXL345Driver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+
 	d.powerCtl.measure = 0
 	if d.connection == nil {
 		return fmt.Errorf("connection not available")
@@ -267,6 +445,117 @@ func (d *ADXL345Driver) shutdown() error {
 	return d.connection.WriteByteData(adxl345Reg_POWER_CTL, d.powerCtl.toByte())
 }
 
+func (d *ADXL345Driver) pollInterruptsCyclic(halt chan struct{}) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-halt:
+			return
+		case <-ticker.C:
+			if err := d.PollInterrupts(); err != nil {
+				d.Publish(d.Event(Error), err)
+			}
+		}
+	}
+}
+
+// writeTapDetection writes the registers of the tap detection and updates the interrupt enable bits, but does not
+// write the interrupt enable register
+func (d *ADXL345Driver) writeTapDetection(cfg ADXL345TapConfig) error {
+	thresh, err := adxl345ToRegister("tap threshold", cfg.ThresholdG/adxl345Scale_ThreshG)
+	if err != nil {
+		return err
+	}
+	dur, err := adxl345ToRegister("tap duration", float64(cfg.Duration)/float64(adxl345Scale_TapDur))
+	if err != nil {
+		return err
+	}
+	latent, err := adxl345ToRegister("tap latency", float64(cfg.Latency)/float64(adxl345Scale_TapLatency))
+	if err != nil {
+		return err
+	}
+	window, err := adxl345ToRegister("tap window", float64(cfg.Window)/float64(adxl345Scale_TapLatency))
+	if err != nil {
+		return err
+	}
+	axes := cfg.Axes & ADXL345TapAxis_All
+	if axes == 0 {
+		axes = ADXL345TapAxis_All
+	}
+
+	for _, rv := range [][2]uint8{
+		{adxl345Reg_THRESH_TAP, thresh},
+		{adxl345Reg_DUR, dur},
+		{adxl345Reg_LATENT, latent},
+		{adxl345Reg_WINDOW, window},
+		{adxl345Reg_TAP_AXES, uint8(axes)},
+	} {
+		if err := d.connection.WriteByteData(rv[0], rv[1]); err != nil {
+			return err
+		}
+	}
+
+	d.intEnable |= adxl345Int_SingleTapBit
+	d.intEnable &^= adxl345Int_DoubleTapBit
+	if latent > 0 && window > 0 {
+		d.intEnable |= adxl345Int_DoubleTapBit
+	}
+
+	return nil
+}
+
+// writeFreeFallDetection writes the registers of the free-fall detection and updates the interrupt enable bits, but
+// does not write the interrupt enable register
+func (d *ADXL345Driver) writeFreeFallDetection(ff adxl345FreeFall) error {
+	thresh, err := adxl345ToRegister("free-fall threshold", ff.thresholdG/adxl345Scale_ThreshG)
+	if err != nil {
+		return err
+	}
+	dur, err := adxl345ToRegister("free-fall duration", float64(ff.duration)/float64(adxl345Scale_TimeFF))
+	if err != nil {
+		return err
+	}
+
+	if err := d.connection.WriteByteData(adxl345Reg_THRESH_FF, thresh); err != nil {
+		return err
+	}
+	if err := d.connection.WriteByteData(adxl345Reg_TIME_FF, dur); err != nil {
+		return err
+	}
+
+	d.intEnable |= adxl345Int_FreeFallBit
+
+	return nil
+}
+
+// adxl345ToRegister rounds the given scaled value to a register value and checks the range
+func adxl345ToRegister(name string, scaled float64) (uint8, error) {
+	val := math.Round(scaled)
+	if val < 0 || val > math.MaxUint8 {
+		return 0, fmt.Errorf("%s is out of range, the register value %v must be between 0 and 255", name, val)
+	}
+
+	return uint8(val), nil
+}
+
+// adxl345InterruptEvents decodes the given value of the interrupt source register to the event names
+func adxl345InterruptEvents(source uint8) []string {
+	var events []string
+	if source&adxl345Int_SingleTapBit != 0 {
+		events = append(events, ADXL345Tap)
+	}
+	if source&adxl345Int_DoubleTapBit != 0 {
+		events = append(events, ADXL345DoubleTap)
+	}
+	if source&adxl345Int_FreeFallBit != 0 {
+		events = append(events, ADXL345FreeFall)
+	}
+
+	return events
+}
+
 // convertToG converts the given raw value by range configuration to the unit [g]
 func (d *adxl345DataFormat) convertToG(rawValue int16) float64 {
 	switch d.fullScaleRange {
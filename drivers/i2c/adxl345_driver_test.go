@@ -3,7 +3,9 @@ package i2c
 import (
 	"errors"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -329,3 +331,235 @@ func TestADXL345_shutdown(t *testing.T) {
 	assert.Equal(t, wantReg, a.written[0])
 	assert.Equal(t, wantVal, a.written[1])
 }
+
+func TestADXL345SetTapDetection(t *testing.T) {
+	// sequence to set the tap detection:
+	// * convert the values to the register scale
+	// * write the threshold (0x1D), duration (0x21), latency (0x22), window (0x23) and axes (0x2A) registers
+	// * write the interrupt enable register (0x2E)
+	tests := map[string]struct {
+		cfg         ADXL345TapConfig
+		wantWritten []byte
+		wantErr     string
+		wantEnabled uint8
+	}{
+		"single_tap": {
+			cfg: ADXL345TapConfig{ThresholdG: 3, Duration: 10 * time.Millisecond, Axes: ADXL345TapAxis_Z},
+			wantWritten: []byte{
+				0x1D, 0x30, // 3 g / 62.5 mg
+				0x21, 0x10, // 10 ms / 625 us
+				0x22, 0x00,
+				0x23, 0x00,
+				0x2A, 0x01,
+				0x2E, 0x40,
+			},
+			wantEnabled: 0x40,
+		},
+		"double_tap_all_axes": {
+			cfg: ADXL345TapConfig{
+				ThresholdG: 2.5,
+				Duration:   20 * time.Millisecond,
+				Latency:    100 * time.Millisecond,
+				Window:     250 * time.Millisecond,
+			},
+			wantWritten: []byte{
+				0x1D, 0x28, // 2.5 g / 62.5 mg
+				0x21, 0x20, // 20 ms / 625 us
+				0x22, 0x50, // 100 ms / 1.25 ms
+				0x23, 0xC8, // 250 ms / 1.25 ms
+				0x2A, 0x07,
+				0x2E, 0x60,
+			},
+			wantEnabled: 0x60,
+		},
+		"error_threshold": {
+			cfg:         ADXL345TapConfig{ThresholdG: 16},
+			wantWritten: []byte{},
+			wantErr:     "tap threshold is out of range, the register value 256 must be between 0 and 255",
+		},
+		"error_window": {
+			cfg:         ADXL345TapConfig{ThresholdG: 3, Window: time.Second},
+			wantWritten: []byte{},
+			wantErr:     "tap window is out of range, the register value 800 must be between 0 and 255",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestADXL345WithStubbedAdaptor()
+			require.NoError(t, d.Start())
+			a.written = []byte{} // reset writes of start
+			// act
+			err := d.SetTapDetection(tc.cfg)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Nil(t, d.tap)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.cfg, *d.tap)
+			}
+			assert.Equal(t, tc.wantWritten, a.written)
+			assert.Equal(t, tc.wantEnabled, d.intEnable)
+		})
+	}
+}
+
+func TestADXL345SetFreeFallDetection(t *testing.T) {
+	// sequence to set the free-fall detection:
+	// * convert the values to the register scale
+	// * write the threshold (0x28) and time (0x29) registers
+	// * write the interrupt enable register (0x2E), the tap detection bits are kept
+	// arrange
+	d, a := initTestADXL345WithStubbedAdaptor()
+	require.NoError(t, d.Start())
+	d.intEnable = 0x40   // tap detection already activated
+	a.written = []byte{} // reset writes of start
+	// act
+	err := d.SetFreeFallDetection(0.4, 200*time.Millisecond)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x28, 0x06, 0x29, 0x28, 0x2E, 0x44}, a.written)
+	// act & assert: out of range
+	a.written = []byte{}
+	err = d.SetFreeFallDetection(0.4, 2*time.Second)
+	require.EqualError(t, err, "free-fall duration is out of range, the register value 400 must be between 0 and 255")
+	assert.Empty(t, a.written)
+}
+
+func TestADXL345_initializeWithDetections(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewADXL345Driver(a,
+		WithADXL345TapDetection(ADXL345TapConfig{ThresholdG: 3, Duration: 10 * time.Millisecond}),
+		WithADXL345FreeFallDetection(0.5, 100*time.Millisecond))
+	// act
+	err := d.Start()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x2C, 0x1A, 0x2D, 0x08, 0x31, 0x00, // see test of initialize()
+		0x1D, 0x30, 0x21, 0x10, 0x22, 0x00, 0x23, 0x00, 0x2A, 0x07, // tap detection
+		0x28, 0x08, 0x29, 0x14, // free-fall detection
+		0x2E, 0x44, // interrupt enable
+	}, a.written)
+}
+
+func TestADXL345PollInterrupts(t *testing.T) {
+	// sequence to poll:
+	// * read the interrupt source register (0x30)
+	// * publish the events of activated detections
+	tests := map[string]struct {
+		intEnable  uint8
+		source     uint8
+		wantEvents []string
+	}{
+		"no_detection": {
+			intEnable:  0x64,
+			source:     0x83, // with data ready, watermark and overrun
+			wantEvents: nil,
+		},
+		"single_tap": {
+			intEnable:  0x64,
+			source:     0xC3,
+			wantEvents: []string{ADXL345Tap},
+		},
+		"double_tap": {
+			intEnable:  0x64,
+			source:     0xE0,
+			wantEvents: []string{ADXL345Tap, ADXL345DoubleTap},
+		},
+		"free_fall": {
+			intEnable:  0x64,
+			source:     0x04,
+			wantEvents: []string{ADXL345FreeFall},
+		},
+		"not_activated": {
+			intEnable:  0x04,
+			source:     0x60,
+			wantEvents: nil,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestADXL345WithStubbedAdaptor()
+			require.NoError(t, d.Start())
+			d.intEnable = tc.intEnable
+			a.written = []byte{}
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				b[0] = tc.source
+				return len(b), nil
+			}
+			events := d.Subscribe()
+			defer d.Unsubscribe(events)
+			// act
+			err := d.PollInterrupts()
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, []byte{0x30}, a.written)
+			var got []string
+			for done := false; !done; {
+				select {
+				case evt := <-events:
+					got = append(got, evt.Name)
+				case <-time.After(50 * time.Millisecond):
+					done = true
+				}
+			}
+			assert.Equal(t, tc.wantEvents, got)
+		})
+	}
+}
+
+func TestADXL345InterruptPolling(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewADXL345Driver(a, WithADXL345FreeFallDetection(0.5, 100*time.Millisecond),
+		WithADXL345InterruptPolling(time.Millisecond))
+	var mutex sync.Mutex
+	source := uint8(0)
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		b[0] = source
+		source = 0 // cleared by read
+		return len(b), nil
+	}
+	freeFall := make(chan struct{})
+	_ = d.Once(ADXL345FreeFall, func(interface{}) { close(freeFall) })
+	require.NoError(t, d.Start())
+	// act
+	mutex.Lock()
+	source = 0x04
+	mutex.Unlock()
+	// assert
+	select {
+	case <-freeFall:
+	case <-time.After(time.Second):
+		require.Fail(t, "free-fall event was not published")
+	}
+	require.NoError(t, d.Halt())
+	assert.Nil(t, d.halt)
+}
+
+func TestADXL345InterruptPollingError(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewADXL345Driver(a, WithADXL345InterruptPolling(time.Millisecond))
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	errChan := make(chan interface{}, 1)
+	_ = d.Once(Error, func(data interface{}) { errChan <- data })
+	// act
+	require.NoError(t, d.Start())
+	// assert
+	select {
+	case data := <-errChan:
+		require.EqualError(t, data.(error), "read error")
+	case <-time.After(time.Second):
+		require.Fail(t, "error event was not published")
+	}
+	require.NoError(t, d.Halt())
+}
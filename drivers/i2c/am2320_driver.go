@@ -0,0 +1,222 @@
+package i2c
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
+)
+
+// AM2320Driver is a driver for the Aosong AM2320 humidity and temperature sensor. The device sleeps between the
+// measurements, so it is woken up before each read. The response is validated by the contained CRC-16 (Modbus).
+//
+// datasheet:
+// https://cdn-shop.adafruit.com/product-files/3721/AM2320.pdf
+
+const (
+	am2320Debug          = false
+	am2320DefaultAddress = 0x5C
+
+	am2320FuncReadRegisters = 0x03
+	am2320RegHumidityHigh   = 0x00
+	am2320RegCount          = 4 // humidity high/low and temperature high/low
+
+	am2320WakeupDelay  = 1 * time.Millisecond // min. 800us
+	am2320MeasureDelay = 2 * time.Millisecond // min. 1.5ms
+)
+
+// AM2320Measurement event contains the latest readings of temperature in Celsius and relative humidity in percent
+const AM2320Measurement = "measurement"
+
+// AM2320Driver is a driver for the AM2320 humidity and temperature sensor.
+type AM2320Driver struct {
+	*Driver
+	gobot.Eventer
+	readInterval time.Duration
+	halt         chan struct{}
+}
+
+// NewAM2320Driver creates a new driver for the AM2320 device with the specified i2c interface.
+// Params:
+//
+//	c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//
+//	i2c.WithBus(int):		bus to use with this driver
+//	i2c.WithAddress(int):		address to use with this driver
+//	i2c.WithAM2320CyclicRead(time.Duration):	interval for reading and publish the measurement event
+func NewAM2320Driver(c Connector, options ...func(Config)) *AM2320Driver {
+	d := &AM2320Driver{
+		Driver:  NewDriver(c, "AM2320", am2320DefaultAddress),
+		Eventer: gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddEvent(AM2320Measurement)
+	d.AddEvent(Error)
+
+	d.AddCommand("Temperature", func(params map[string]interface{}) interface{} {
+		val, err := d.Temperature()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("Humidity", func(params map[string]interface{}) interface{} {
+		val, err := d.Humidity()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return d
+}
+
+// WithAM2320CyclicRead option activates the cyclic reading with the given interval. The measurement event is
+// published after each successful read. The datasheet recommends an interval of at least 2 seconds.
+func WithAM2320CyclicRead(interval time.Duration) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*AM2320Driver); ok {
+			d.readInterval = interval
+		} else if am2320Debug {
+			log.Printf("Trying to set read interval for non-AM2320Driver %v", c)
+		}
+	}
+}
+
+// Sample reads the temperature in Celsius and the relative humidity in percent with one measurement.
+//
+//nolint:nonamedreturns // is sufficient here
+func (d *AM2320Driver) Sample() (temp float32, rh float32, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readSample()
+}
+
+// Temperature reads the temperature in Celsius.
+func (d *AM2320Driver) Temperature() (float32, error) {
+	temp, _, err := d.Sample()
+	return temp, err
+}
+
+// TemperatureMeasurement returns the current temperature like Temperature(), but together with its unit.
+func (d *AM2320Driver) TemperatureMeasurement() (measurement.Measurement, error) {
+	val, err := d.Temperature()
+	if err != nil {
+		return measurement.Measurement{}, err
+	}
+
+	return measurement.New(float64(val), measurement.Celsius), nil
+}
+
+// Humidity reads the relative humidity in percent.
+func (d *AM2320Driver) Humidity() (float32, error) {
+	_, rh, err := d.Sample()
+	return rh, err
+}
+
+// initialize starts the cyclic reading, if configured.
+func (d *AM2320Driver) initialize() error {
+	if d.readInterval > 0 {
+		d.halt = make(chan struct{})
+		go d.cyclicRead(d.halt)
+	}
+
+	return nil
+}
+
+// shutdown stops the cyclic reading, if running.
+func (d *AM2320Driver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+	return nil
+}
+
+func (d *AM2320Driver) cyclicRead(halt chan struct{}) {
+	ticker := time.NewTicker(d.readInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-halt:
+			return
+		case <-ticker.C:
+			temp, rh, err := d.Sample()
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+				continue
+			}
+			d.Publish(d.Event(AM2320Measurement), map[string]float32{
+				"temperature": temp,
+				"humidity":    rh,
+			})
+		}
+	}
+}
+
+//nolint:nonamedreturns // is sufficient here
+func (d *AM2320Driver) readSample() (temp float32, rh float32, err error) {
+	// the device does not acknowledge the wake up, so the error is expected and ignored
+	_, _ = d.connection.Write([]byte{0x00})
+	time.Sleep(am2320WakeupDelay)
+
+	if _, err = d.connection.Write([]byte{am2320FuncReadRegisters, am2320RegHumidityHigh, am2320RegCount}); err != nil {
+		return
+	}
+	time.Sleep(am2320MeasureDelay)
+
+	// function code, count of bytes, data, CRC (LSB first)
+	buf := make([]byte, 2+am2320RegCount+2)
+	got, err := d.connection.Read(buf)
+	if err != nil {
+		return
+	}
+	if got != len(buf) {
+		err = ErrNotEnoughBytes
+		return
+	}
+
+	crc := uint16(buf[len(buf)-1])<<8 | uint16(buf[len(buf)-2])
+	if crc != am2320Crc16(buf[:len(buf)-2]) {
+		err = ErrInvalidCrc
+		return
+	}
+	if buf[0] != am2320FuncReadRegisters || buf[1] != am2320RegCount {
+		err = fmt.Errorf("unexpected response header (0x%02X 0x%02X) from '%s'", buf[0], buf[1], d.name)
+		return
+	}
+
+	rh = float32(uint16(buf[2])<<8|uint16(buf[3])) / 10
+
+	// the temperature is given by sign and magnitude
+	rawTemp := uint16(buf[4])<<8 | uint16(buf[5])
+	temp = float32(rawTemp&0x7FFF) / 10
+	if rawTemp&0x8000 != 0 {
+		temp = -temp
+	}
+
+	return
+}
+
+// am2320Crc16 calculates the CRC-16 (Modbus) of the given data
+func am2320Crc16(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&0x01 != 0 {
+				crc = (crc >> 1) ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+
+	return crc
+}
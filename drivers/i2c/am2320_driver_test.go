@@ -0,0 +1,217 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*AM2320Driver)(nil)
+
+func initTestAM2320DriverWithStubbedAdaptor() (*AM2320Driver, *i2cTestAdaptor) {
+	a := newI2cTestAdaptor()
+	d := NewAM2320Driver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a
+}
+
+func TestNewAM2320Driver(t *testing.T) {
+	var di interface{} = NewAM2320Driver(newI2cTestAdaptor())
+	d, ok := di.(*AM2320Driver)
+	if !ok {
+		t.Error("NewAM2320Driver() should return a *AM2320Driver")
+	}
+	assert.NotNil(t, d.Driver)
+	assert.NotNil(t, d.Eventer)
+	assert.True(t, strings.HasPrefix(d.Name(), "AM2320"))
+	assert.Equal(t, 0x5C, d.defaultAddress)
+}
+
+func TestAM2320Options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewAM2320Driver(newI2cTestAdaptor(), WithBus(2), WithAM2320CyclicRead(3*time.Second))
+	assert.Equal(t, 2, d.GetBusOrDefault(1))
+	assert.Equal(t, 3*time.Second, d.readInterval)
+}
+
+func TestAM2320Sample(t *testing.T) {
+	tests := map[string]struct {
+		readData []byte
+		readErr  error
+		wantTemp float32
+		wantRh   float32
+		wantErr  string
+	}{
+		"datasheet_example": {
+			readData: []byte{0x03, 0x04, 0x01, 0xF4, 0x00, 0xFA, 0x31, 0xA5},
+			wantTemp: 25.0,
+			wantRh:   50.0,
+		},
+		"negative_temperature": {
+			readData: []byte{0x03, 0x04, 0x02, 0x6C, 0x80, 0x65, 0x91, 0xA6},
+			wantTemp: -10.1,
+			wantRh:   62.0,
+		},
+		"error_invalid_crc": {
+			readData: []byte{0x03, 0x04, 0x01, 0xF4, 0x00, 0xFB, 0x31, 0xA5},
+			wantErr:  "Invalid crc",
+		},
+		"error_crc_byte_order": {
+			readData: []byte{0x03, 0x04, 0x01, 0xF4, 0x00, 0xFA, 0xA5, 0x31},
+			wantErr:  "Invalid crc",
+		},
+		"error_not_enough_bytes": {
+			readData: []byte{0x03, 0x04, 0x01, 0xF4},
+			wantErr:  "Not enough bytes read",
+		},
+		"error_read": {
+			readErr: errors.New("read error"),
+			wantErr: "read error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestAM2320DriverWithStubbedAdaptor()
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				return copy(b, tc.readData), tc.readErr
+			}
+			// act
+			temp, rh, err := d.Sample()
+			// assert
+			assert.Equal(t, []byte{0x00, 0x03, 0x00, 0x04}, a.written)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.wantTemp, temp, 0.001)
+			assert.InDelta(t, tc.wantRh, rh, 0.001)
+		})
+	}
+}
+
+func TestAM2320Sample_unexpectedHeader(t *testing.T) {
+	// arrange
+	d, a := initTestAM2320DriverWithStubbedAdaptor()
+	data := []byte{0x83, 0x04, 0x01, 0xF4, 0x00, 0xFA}
+	crc := am2320Crc16(data)
+	data = append(data, byte(crc&0xFF), byte(crc>>8))
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return copy(b, data), nil
+	}
+	// act
+	_, _, err := d.Sample()
+	// assert
+	require.ErrorContains(t, err, "unexpected response header (0x83 0x04)")
+}
+
+func TestAM2320TemperatureHumidity(t *testing.T) {
+	// arrange
+	d, a := initTestAM2320DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return copy(b, []byte{0x03, 0x04, 0x01, 0xF4, 0x00, 0xFA, 0x31, 0xA5}), nil
+	}
+	// act
+	temp, errTemp := d.Temperature()
+	rh, errRh := d.Humidity()
+	// assert
+	require.NoError(t, errTemp)
+	require.NoError(t, errRh)
+	assert.InDelta(t, 25.0, temp, 0.001)
+	assert.InDelta(t, 50.0, rh, 0.001)
+}
+
+func TestAM2320TemperatureMeasurement(t *testing.T) {
+	// arrange
+	d, a := initTestAM2320DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return copy(b, []byte{0x03, 0x04, 0x01, 0xF4, 0x00, 0xFA, 0x31, 0xA5}), nil
+	}
+	// act
+	got, err := d.TemperatureMeasurement()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, measurement.New(25.0, measurement.Celsius), got)
+}
+
+func TestAM2320WakeupErrorIgnored(t *testing.T) {
+	// arrange
+	d, a := initTestAM2320DriverWithStubbedAdaptor()
+	var writes int
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		writes++
+		if writes == 1 {
+			return 0, errors.New("nack")
+		}
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return copy(b, []byte{0x03, 0x04, 0x01, 0xF4, 0x00, 0xFA, 0x31, 0xA5}), nil
+	}
+	// act
+	temp, err := d.Temperature()
+	// assert
+	require.NoError(t, err)
+	assert.InDelta(t, 25.0, temp, 0.001)
+}
+
+func TestAM2320CyclicRead(t *testing.T) {
+	tests := map[string]struct {
+		readData  []byte
+		wantEvent string
+	}{
+		"measurement": {
+			readData:  []byte{0x03, 0x04, 0x01, 0xF4, 0x00, 0xFA, 0x31, 0xA5},
+			wantEvent: AM2320Measurement,
+		},
+		"error_invalid_crc": {
+			readData:  []byte{0x03, 0x04, 0x01, 0xF4, 0x00, 0xFA, 0x00, 0x00},
+			wantEvent: Error,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newI2cTestAdaptor()
+			readData := tc.readData
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				return copy(b, readData), nil
+			}
+			d := NewAM2320Driver(a, WithAM2320CyclicRead(10*time.Millisecond))
+			events := d.Subscribe()
+			defer d.Unsubscribe(events)
+			// act
+			require.NoError(t, d.Start())
+			defer func() { _ = d.Halt() }()
+			// assert
+			select {
+			case evt := <-events:
+				assert.Equal(t, tc.wantEvent, evt.Name)
+				if tc.wantEvent == AM2320Measurement {
+					assert.Equal(t, map[string]float32{"temperature": 25.0, "humidity": 50.0}, evt.Data)
+				} else {
+					assert.Equal(t, ErrInvalidCrc, evt.Data)
+				}
+			case <-time.After(time.Second):
+				require.Fail(t, "event was not published")
+			}
+		})
+	}
+}
+
+func TestAM2320Crc16(t *testing.T) {
+	assert.Equal(t, uint16(0xA531), am2320Crc16([]byte{0x03, 0x04, 0x01, 0xF4, 0x00, 0xFA}))
+}
@@ -0,0 +1,330 @@
+package i2c
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// AS5600Driver is a driver for the ams AS5600 12 bit contactless magnetic rotary position sensor. The angle of a
+// diametric magnet above the chip is measured absolute within one revolution.
+//
+// datasheet:
+// https://ams.com/documents/20143/36005/AS5600_DS000365_5-00.pdf
+
+const (
+	as5600Debug          = false
+	as5600DefaultAddress = 0x36
+
+	as5600RegRawAngle  = 0x0C // high byte, low byte at 0x0D
+	as5600RegStatus    = 0x0B
+	as5600RegAGC       = 0x1A
+	as5600RegMagnitude = 0x1B // high byte, low byte at 0x1C
+
+	as5600StatusMagnetHigh     = 0x08 // MH: magnet too strong
+	as5600StatusMagnetLow      = 0x10 // ML: magnet too weak
+	as5600StatusMagnetDetected = 0x20 // MD: magnet detected
+
+	// AS5600CountsPerRevolution is the resolution of the sensor
+	AS5600CountsPerRevolution = 4096
+)
+
+// AS5600Angle event contains the latest angle in degree
+const AS5600Angle = "angle"
+
+// AS5600MagnetStatus contains the flags of the status register about the magnet.
+type AS5600MagnetStatus struct {
+	Detected  bool // a magnet is detected
+	TooWeak   bool // the magnet is too weak or too far away, the AGC gain is at maximum
+	TooStrong bool // the magnet is too strong or too close, the AGC gain is at minimum
+}
+
+// AS5600Driver is a driver for the AS5600 magnetic angle encoder.
+type AS5600Driver struct {
+	*Driver
+	gobot.Eventer
+	zeroPosition int
+	reverse      bool
+	readInterval time.Duration
+	halt         chan struct{}
+	// values to track the position over more than one revolution
+	position  int
+	lastCount int
+	tracking  bool
+}
+
+// NewAS5600Driver creates a new driver for the AS5600 device with the specified i2c interface. Without options, the
+// angle increases, if the magnet rotates counter-clockwise when viewed from the top (DIR pin connected to GND).
+// Params:
+//
+//	c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//
+//	i2c.WithBus(int):		bus to use with this driver
+//	i2c.WithAddress(int):		address to use with this driver
+//	i2c.WithAS5600ZeroPosition(int):	raw angle (0-4095) which is reported as 0 degree
+//	i2c.WithAS5600Reverse():	reverse the direction of the angle
+//	i2c.WithAS5600CyclicRead(time.Duration):	interval for reading and publish the angle event
+func NewAS5600Driver(c Connector, options ...func(Config)) *AS5600Driver {
+	d := &AS5600Driver{
+		Driver:  NewDriver(c, "AS5600", as5600DefaultAddress),
+		Eventer: gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, option := range options {
+		option(d)
+	}
+
+	if d.zeroPosition < 0 || d.zeroPosition >= AS5600CountsPerRevolution {
+		panic(fmt.Sprintf("zero position (%d) of '%s' must be within 0-4095", d.zeroPosition, d.name))
+	}
+
+	d.AddEvent(AS5600Angle)
+	d.AddEvent(Error)
+
+	d.AddCommand("Angle", func(params map[string]interface{}) interface{} {
+		val, err := d.Angle()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("RawAngle", func(params map[string]interface{}) interface{} {
+		val, err := d.RawAngle()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return d
+}
+
+// WithAS5600ZeroPosition option sets the raw angle (0-4095), which is reported as 0 degree. The value is not
+// programmed to the non-volatile memory of the device.
+func WithAS5600ZeroPosition(raw int) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*AS5600Driver); ok {
+			d.zeroPosition = raw
+		} else if as5600Debug {
+			log.Printf("Trying to set zero position for non-AS5600Driver %v", c)
+		}
+	}
+}
+
+// WithAS5600Reverse option reverses the direction of the angle, the same as connecting the DIR pin to VDD.
+func WithAS5600Reverse() func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*AS5600Driver); ok {
+			d.reverse = true
+		} else if as5600Debug {
+			log.Printf("Trying to set reverse direction for non-AS5600Driver %v", c)
+		}
+	}
+}
+
+// WithAS5600CyclicRead option activates the cyclic reading with the given interval. The angle event is published
+// after each successful read. This also keeps the position of Position() up to date, so the interval needs to be short
+// enough that the magnet rotates less than half a revolution between two reads.
+func WithAS5600CyclicRead(interval time.Duration) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*AS5600Driver); ok {
+			d.readInterval = interval
+		} else if as5600Debug {
+			log.Printf("Trying to set read interval for non-AS5600Driver %v", c)
+		}
+	}
+}
+
+// RawAngle reads the unmodified angle of the device (0-4095). The zero position and the direction of the driver are
+// not applied.
+func (d *AS5600Driver) RawAngle() (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readRawAngle()
+}
+
+// Angle reads the angle in degree (0-360), the zero position and the direction are applied.
+func (d *AS5600Driver) Angle() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	count, err := d.readCount()
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(count) * 360 / AS5600CountsPerRevolution, nil
+}
+
+// Position reads the angle and returns the position in counts (4096 per revolution) since the first read, the last
+// change of the zero position or the last call of ResetPosition(). The position is continuous over more than one
+// revolution, if the magnet rotates less than half a revolution between two reads, see [i2c.WithAS5600CyclicRead].
+// This implements gpio.PositionEncoder, so the driver can be attached to an EasyDriver for the verification of the
+// moves. On read error, the last known position is returned and the error event is published.
+func (d *AS5600Driver) Position() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, err := d.readCount(); err != nil {
+		d.Publish(d.Event(Error), err)
+	}
+
+	return d.position
+}
+
+// ResetPosition sets the position to zero, the current angle is the reference for the next reads.
+func (d *AS5600Driver) ResetPosition() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.tracking = false
+	d.position = 0
+}
+
+// ZeroPosition returns the raw angle, which is reported as 0 degree.
+func (d *AS5600Driver) ZeroPosition() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.zeroPosition
+}
+
+// SetZeroPosition sets the raw angle (0-4095), which is reported as 0 degree. The position is reset.
+func (d *AS5600Driver) SetZeroPosition(raw int) error {
+	if raw < 0 || raw >= AS5600CountsPerRevolution {
+		return fmt.Errorf("zero position (%d) of '%s' must be within 0-4095", raw, d.name)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.zeroPosition = raw
+	d.tracking = false
+	d.position = 0
+
+	return nil
+}
+
+// ZeroHere reads the current raw angle and sets it as zero position.
+func (d *AS5600Driver) ZeroHere() error {
+	raw, err := d.RawAngle()
+	if err != nil {
+		return err
+	}
+
+	return d.SetZeroPosition(raw)
+}
+
+// MagnetStatus reads the flags of the device about the detection and the strength of the magnet.
+func (d *AS5600Driver) MagnetStatus() (AS5600MagnetStatus, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	status, err := d.connection.ReadByteData(as5600RegStatus)
+	if err != nil {
+		return AS5600MagnetStatus{}, err
+	}
+
+	return AS5600MagnetStatus{
+		Detected:  status&as5600StatusMagnetDetected != 0,
+		TooWeak:   status&as5600StatusMagnetLow != 0,
+		TooStrong: status&as5600StatusMagnetHigh != 0,
+	}, nil
+}
+
+// AGC reads the value of the automatic gain control, which can be used to optimize the air gap between magnet and
+// device. The value is in the middle of the range (0-255 at 5V, 0-128 at 3.3V), if the air gap is optimal.
+func (d *AS5600Driver) AGC() (uint8, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.connection.ReadByteData(as5600RegAGC)
+}
+
+// Magnitude reads the magnitude of the internal CORDIC (12 bit).
+func (d *AS5600Driver) Magnitude() (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readRegister12(as5600RegMagnitude)
+}
+
+// initialize starts the cyclic reading, if configured.
+func (d *AS5600Driver) initialize() error {
+	if d.readInterval > 0 {
+		d.halt = make(chan struct{})
+		go d.cyclicRead(d.halt)
+	}
+
+	return nil
+}
+
+// shutdown stops the cyclic reading, if running.
+func (d *AS5600Driver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+	return nil
+}
+
+func (d *AS5600Driver) cyclicRead(halt chan struct{}) {
+	ticker := time.NewTicker(d.readInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-halt:
+			return
+		case <-ticker.C:
+			angle, err := d.Angle()
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+				continue
+			}
+			d.Publish(d.Event(AS5600Angle), angle)
+		}
+	}
+}
+
+// readCount reads the raw angle, applies the zero position and direction and updates the tracked position. The mutex
+// needs to be locked by the caller.
+func (d *AS5600Driver) readCount() (int, error) {
+	raw, err := d.readRawAngle()
+	if err != nil {
+		return 0, err
+	}
+
+	count := (raw - d.zeroPosition + AS5600CountsPerRevolution) % AS5600CountsPerRevolution
+	if d.reverse {
+		count = (AS5600CountsPerRevolution - count) % AS5600CountsPerRevolution
+	}
+
+	if d.tracking {
+		// the shortest way between both counts is assumed
+		delta := (count - d.lastCount + AS5600CountsPerRevolution) % AS5600CountsPerRevolution
+		if delta >= AS5600CountsPerRevolution/2 {
+			delta -= AS5600CountsPerRevolution
+		}
+		d.position += delta
+	}
+	d.lastCount = count
+	d.tracking = true
+
+	return count, nil
+}
+
+func (d *AS5600Driver) readRawAngle() (int, error) {
+	return d.readRegister12(as5600RegRawAngle)
+}
+
+// readRegister12 reads a 12 bit value, which is stored in two registers with the high byte first
+func (d *AS5600Driver) readRegister12(reg uint8) (int, error) {
+	buf := make([]byte, 2)
+	if err := d.connection.ReadBlockData(reg, buf); err != nil {
+		return 0, err
+	}
+
+	return int(buf[0]&0x0F)<<8 | int(buf[1]), nil
+}
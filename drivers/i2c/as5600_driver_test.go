@@ -0,0 +1,426 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*AS5600Driver)(nil)
+
+// the driver can be attached to an EasyDriver as position reference
+var _ gpio.PositionEncoder = (*AS5600Driver)(nil)
+
+func initTestAS5600DriverWithStubbedAdaptor(options ...func(Config)) (*AS5600Driver, *i2cTestAdaptor) {
+	a := newI2cTestAdaptor()
+	d := NewAS5600Driver(a, options...)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a
+}
+
+// as5600RawAngleData returns the register content for the given raw angle, the unused upper bits are set to ensure
+// they are masked
+func as5600RawAngleData(raw int) []byte {
+	return []byte{byte(raw>>8) | 0xF0, byte(raw)}
+}
+
+func TestNewAS5600Driver(t *testing.T) {
+	var di interface{} = NewAS5600Driver(newI2cTestAdaptor())
+	d, ok := di.(*AS5600Driver)
+	if !ok {
+		t.Error("NewAS5600Driver() should return a *AS5600Driver")
+	}
+	assert.NotNil(t, d.Driver)
+	assert.NotNil(t, d.Eventer)
+	assert.True(t, strings.HasPrefix(d.Name(), "AS5600"))
+	assert.Equal(t, 0x36, d.defaultAddress)
+	assert.Equal(t, 0, d.zeroPosition)
+	assert.False(t, d.reverse)
+}
+
+func TestAS5600Options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewAS5600Driver(newI2cTestAdaptor(), WithBus(2), WithAS5600ZeroPosition(1000), WithAS5600Reverse(),
+		WithAS5600CyclicRead(3*time.Second))
+	assert.Equal(t, 2, d.GetBusOrDefault(1))
+	assert.Equal(t, 1000, d.zeroPosition)
+	assert.True(t, d.reverse)
+	assert.Equal(t, 3*time.Second, d.readInterval)
+}
+
+func TestNewAS5600Driver_invalidZeroPosition(t *testing.T) {
+	assert.Panics(t, func() { _ = NewAS5600Driver(newI2cTestAdaptor(), WithAS5600ZeroPosition(4096)) })
+	assert.Panics(t, func() { _ = NewAS5600Driver(newI2cTestAdaptor(), WithAS5600ZeroPosition(-1)) })
+}
+
+func TestAS5600RawAngle(t *testing.T) {
+	// arrange
+	d, a := initTestAS5600DriverWithStubbedAdaptor(WithAS5600ZeroPosition(100), WithAS5600Reverse())
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return copy(b, as5600RawAngleData(0xABC)), nil
+	}
+	// act
+	got, err := d.RawAngle()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 0xABC, got)
+	assert.Equal(t, []byte{0x0C}, a.written)
+}
+
+func TestAS5600Angle(t *testing.T) {
+	tests := map[string]struct {
+		raw       int
+		zero      int
+		reverse   bool
+		readErr   error
+		wantAngle float64
+		wantErr   string
+	}{
+		"zero": {
+			raw:       0,
+			wantAngle: 0,
+		},
+		"quarter": {
+			raw:       1024,
+			wantAngle: 90,
+		},
+		"half": {
+			raw:       2048,
+			wantAngle: 180,
+		},
+		"max": {
+			raw:       4095,
+			wantAngle: 359.912109375,
+		},
+		"one_count": {
+			raw:       1,
+			wantAngle: 0.087890625,
+		},
+		"zero_offset": {
+			raw:       1524,
+			zero:      500,
+			wantAngle: 90,
+		},
+		"zero_offset_at_zero": {
+			raw:       500,
+			zero:      500,
+			wantAngle: 0,
+		},
+		"zero_offset_wraps": {
+			raw:       0,
+			zero:      1024,
+			wantAngle: 270,
+		},
+		"reverse": {
+			raw:       1024,
+			reverse:   true,
+			wantAngle: 270,
+		},
+		"reverse_zero": {
+			raw:       0,
+			reverse:   true,
+			wantAngle: 0,
+		},
+		"reverse_with_zero_offset": {
+			raw:       1524,
+			zero:      500,
+			reverse:   true,
+			wantAngle: 270,
+		},
+		"error_read": {
+			readErr: errors.New("read error"),
+			wantErr: "read error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			options := []func(Config){WithAS5600ZeroPosition(tc.zero)}
+			if tc.reverse {
+				options = append(options, WithAS5600Reverse())
+			}
+			d, a := initTestAS5600DriverWithStubbedAdaptor(options...)
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				return copy(b, as5600RawAngleData(tc.raw)), tc.readErr
+			}
+			// act
+			got, err := d.Angle()
+			// assert
+			assert.Equal(t, []byte{0x0C}, a.written)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.wantAngle, got, 0.0000001)
+		})
+	}
+}
+
+func TestAS5600SetZeroPosition(t *testing.T) {
+	// arrange
+	raw := 3000
+	d, a := initTestAS5600DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return copy(b, as5600RawAngleData(raw)), nil
+	}
+	got, err := d.Angle()
+	require.NoError(t, err)
+	require.InDelta(t, 263.671875, got, 0.0000001)
+	// act
+	err = d.SetZeroPosition(2000)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 2000, d.ZeroPosition())
+	got, err = d.Angle()
+	require.NoError(t, err)
+	assert.InDelta(t, 87.890625, got, 0.0000001)
+	// the raw angle is not affected
+	gotRaw, err := d.RawAngle()
+	require.NoError(t, err)
+	assert.Equal(t, 3000, gotRaw)
+}
+
+func TestAS5600SetZeroPosition_invalid(t *testing.T) {
+	// arrange
+	d, _ := initTestAS5600DriverWithStubbedAdaptor(WithAS5600ZeroPosition(10))
+	for _, raw := range []int{-1, 4096} {
+		// act
+		err := d.SetZeroPosition(raw)
+		// assert
+		require.ErrorContains(t, err, "must be within 0-4095")
+		assert.Equal(t, 10, d.ZeroPosition())
+	}
+}
+
+func TestAS5600ZeroHere(t *testing.T) {
+	// arrange
+	raw := 1234
+	d, a := initTestAS5600DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return copy(b, as5600RawAngleData(raw)), nil
+	}
+	// act
+	err := d.ZeroHere()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 1234, d.ZeroPosition())
+	got, err := d.Angle()
+	require.NoError(t, err)
+	assert.InDelta(t, 0.0, got, 0.0000001)
+	raw = 1234 + 512
+	got, err = d.Angle()
+	require.NoError(t, err)
+	assert.InDelta(t, 45.0, got, 0.0000001)
+}
+
+func TestAS5600MagnetStatus(t *testing.T) {
+	tests := map[string]struct {
+		status  byte
+		readErr error
+		want    AS5600MagnetStatus
+		wantErr string
+	}{
+		"no_magnet": {
+			status: 0x00,
+			want:   AS5600MagnetStatus{},
+		},
+		"detected": {
+			status: 0x20,
+			want:   AS5600MagnetStatus{Detected: true},
+		},
+		"detected_too_weak": {
+			status: 0x30,
+			want:   AS5600MagnetStatus{Detected: true, TooWeak: true},
+		},
+		"detected_too_strong": {
+			status: 0x28,
+			want:   AS5600MagnetStatus{Detected: true, TooStrong: true},
+		},
+		"unused_bits_ignored": {
+			status: 0xC7,
+			want:   AS5600MagnetStatus{},
+		},
+		"error_read": {
+			readErr: errors.New("read error"),
+			wantErr: "read error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestAS5600DriverWithStubbedAdaptor()
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				b[0] = tc.status
+				return 1, tc.readErr
+			}
+			// act
+			got, err := d.MagnetStatus()
+			// assert
+			assert.Equal(t, []byte{0x0B}, a.written)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAS5600AGCAndMagnitude(t *testing.T) {
+	// arrange
+	d, a := initTestAS5600DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		if a.written[len(a.written)-1] == 0x1A {
+			return copy(b, []byte{0x80}), nil
+		}
+		return copy(b, []byte{0x07, 0xD0}), nil
+	}
+	// act
+	agc, errAGC := d.AGC()
+	magnitude, errMagnitude := d.Magnitude()
+	// assert
+	require.NoError(t, errAGC)
+	require.NoError(t, errMagnitude)
+	assert.Equal(t, uint8(0x80), agc)
+	assert.Equal(t, 2000, magnitude)
+	assert.Equal(t, []byte{0x1A, 0x1B}, a.written)
+}
+
+func TestAS5600Position(t *testing.T) {
+	tests := map[string]struct {
+		reverse bool
+		raws    []int
+		want    []int
+	}{
+		"forward_over_one_revolution": {
+			raws: []int{100, 1100, 2100, 3100, 4000, 900, 1900},
+			want: []int{0, 1000, 2000, 3000, 3900, 4896, 5896},
+		},
+		"backward_over_zero": {
+			raws: []int{100, 4000, 3000, 2048, 1500},
+			want: []int{0, -196, -1196, -2148, -2696},
+		},
+		"reverse": {
+			raws:    []int{100, 4000, 3000},
+			reverse: true,
+			want:    []int{0, 196, 1196},
+		},
+		"half_revolution_is_backward": {
+			raws: []int{0, 2048},
+			want: []int{0, -2048},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			var options []func(Config)
+			if tc.reverse {
+				options = append(options, WithAS5600Reverse())
+			}
+			d, a := initTestAS5600DriverWithStubbedAdaptor(options...)
+			var raw int
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				return copy(b, as5600RawAngleData(raw)), nil
+			}
+			for i := range tc.raws {
+				raw = tc.raws[i]
+				// act
+				got := d.Position()
+				// assert
+				assert.Equal(t, tc.want[i], got, "read %d", i)
+			}
+		})
+	}
+}
+
+func TestAS5600Position_readError(t *testing.T) {
+	// arrange
+	d, a := initTestAS5600DriverWithStubbedAdaptor()
+	var readErr error
+	raw := 0
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return copy(b, as5600RawAngleData(raw)), readErr
+	}
+	require.Equal(t, 0, d.Position())
+	raw = 500
+	require.Equal(t, 500, d.Position())
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	readErr = errors.New("read error")
+	// act
+	got := d.Position()
+	// assert
+	assert.Equal(t, 500, got)
+	select {
+	case evt := <-events:
+		assert.Equal(t, Error, evt.Name)
+		assert.Equal(t, readErr, evt.Data)
+	case <-time.After(time.Second):
+		require.Fail(t, "error event was not published")
+	}
+}
+
+func TestAS5600ResetPosition(t *testing.T) {
+	// arrange
+	d, a := initTestAS5600DriverWithStubbedAdaptor()
+	raw := 0
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return copy(b, as5600RawAngleData(raw)), nil
+	}
+	require.Equal(t, 0, d.Position())
+	raw = 1000
+	require.Equal(t, 1000, d.Position())
+	// act
+	d.ResetPosition()
+	// assert
+	assert.Equal(t, 0, d.Position())
+	raw = 1500
+	assert.Equal(t, 500, d.Position())
+}
+
+func TestAS5600CyclicRead(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return copy(b, as5600RawAngleData(1024)), nil
+	}
+	d := NewAS5600Driver(a, WithAS5600CyclicRead(10*time.Millisecond))
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	// act
+	require.NoError(t, d.Start())
+	defer func() { _ = d.Halt() }()
+	// assert
+	select {
+	case evt := <-events:
+		assert.Equal(t, AS5600Angle, evt.Name)
+		assert.InDelta(t, 90.0, evt.Data, 0.0000001)
+	case <-time.After(time.Second):
+		require.Fail(t, "event was not published")
+	}
+}
+
+func TestAS5600Halt(t *testing.T) {
+	// arrange
+	d, _ := initTestAS5600DriverWithStubbedAdaptor(WithAS5600CyclicRead(time.Hour))
+	require.NotNil(t, d.halt)
+	// act
+	err := d.Halt()
+	// assert
+	require.NoError(t, err)
+	assert.Nil(t, d.halt)
+}
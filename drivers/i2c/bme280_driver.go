@@ -3,7 +3,7 @@ package i2c
 import (
 	"bytes"
 	"encoding/binary"
-	"errors"
+	"fmt"
 	"log"
 )
 
@@ -12,6 +12,8 @@ const bme280Debug = true
 type BME280HumidityOversampling uint8
 
 const (
+	bme280ChipID = 0x60
+
 	bme280RegCalibDigH1      = 0xA1
 	bme280RegCalibDigH2LSB   = 0xE1
 	bme280RegControlHumidity = 0xF2
@@ -136,6 +138,15 @@ func (d *BME280Driver) Humidity() (float32, error) {
 }
 
 func (d *BME280Driver) initializationBME280() error {
+	chipID, err := d.connection.ReadByteData(bmp280RegChipID)
+	if err != nil {
+		return fmt.Errorf("%w: '%s' does not respond (%v)", ErrDeviceNotFound, d.name, err)
+	}
+
+	if chipID != bme280ChipID {
+		return &ChipIDError{Name: d.name, Got: chipID, Want: bme280ChipID}
+	}
+
 	// call the initialization routine of base class BMP280Driver, which do:
 	// * initializes temperature and pressure calibration coefficients
 	// * set the control register
@@ -221,7 +232,7 @@ func (d *BME280Driver) rawHumidity() (uint32, error) {
 		return 0, err
 	}
 	if ret[0] == 0x80 && ret[1] == 0x00 {
-		return 0, errors.New("Humidity disabled")
+		return 0, fmt.Errorf("%w: humidity of '%s'", ErrMeasurementDisabled, d.name)
 	}
 	buf := bytes.NewBuffer(ret)
 	var rawH uint16
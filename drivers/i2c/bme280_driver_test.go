@@ -59,6 +59,8 @@ func TestBME280Measurements(t *testing.T) {
 		buf := new(bytes.Buffer)
 		// Values produced by dumping data from actual sensor
 		switch {
+		case adaptor.written[len(adaptor.written)-1] == bmp280RegChipID:
+			buf.Write([]byte{bme280ChipID})
 		case adaptor.written[len(adaptor.written)-1] == bmp280RegCalib00:
 			buf.Write([]byte{
 				126, 109, 214, 102, 50, 0, 54, 149, 220, 213, 208, 11, 64, 30, 166, 255, 249, 255, 172, 38, 10, 216, 189, 16,
@@ -87,6 +89,8 @@ func TestBME280InitH1Error(t *testing.T) {
 		buf := new(bytes.Buffer)
 		// Values produced by dumping data from actual sensor
 		switch {
+		case adaptor.written[len(adaptor.written)-1] == bmp280RegChipID:
+			buf.Write([]byte{bme280ChipID})
 		case adaptor.written[len(adaptor.written)-1] == bmp280RegCalib00:
 			buf.Write([]byte{
 				126, 109, 214, 102, 50, 0, 54, 149, 220, 213, 208, 11, 64, 30, 166, 255, 249, 255, 172, 38, 10, 216, 189, 16,
@@ -109,6 +113,8 @@ func TestBME280InitH2Error(t *testing.T) {
 		buf := new(bytes.Buffer)
 		// Values produced by dumping data from actual sensor
 		switch {
+		case adaptor.written[len(adaptor.written)-1] == bmp280RegChipID:
+			buf.Write([]byte{bme280ChipID})
 		case adaptor.written[len(adaptor.written)-1] == bmp280RegCalib00:
 			buf.Write([]byte{
 				126, 109, 214, 102, 50, 0, 54, 149, 220, 213, 208, 11, 64, 30, 166, 255, 249, 255, 172, 38, 10, 216, 189, 16,
@@ -155,6 +161,8 @@ func TestBME280HumidityNotEnabled(t *testing.T) {
 		buf := new(bytes.Buffer)
 		// Values produced by dumping data from actual sensor
 		switch {
+		case adaptor.written[len(adaptor.written)-1] == bmp280RegChipID:
+			buf.Write([]byte{bme280ChipID})
 		case adaptor.written[len(adaptor.written)-1] == bmp280RegCalib00:
 			buf.Write([]byte{
 				126, 109, 214, 102, 50, 0, 54, 149, 220, 213, 208, 11, 64, 30, 166, 255, 249, 255, 172, 38, 10, 216, 189, 16,
@@ -173,7 +181,8 @@ func TestBME280HumidityNotEnabled(t *testing.T) {
 	}
 	_ = bme280.Start()
 	hum, err := bme280.Humidity()
-	require.ErrorContains(t, err, "Humidity disabled")
+	require.ErrorIs(t, err, ErrMeasurementDisabled)
+	require.ErrorContains(t, err, "humidity")
 	assert.InDelta(t, float32(0.0), hum, 0.0)
 }
 
@@ -183,18 +192,23 @@ func TestBME280_initializationBME280(t *testing.T) {
 	adaptor.i2cReadImpl = func(b []byte) (int, error) {
 		readCallCounter++
 		if readCallCounter == 1 {
+			// Simulate returning the chip ID (register bmp280RegChipID)
+			b[0] = bme280ChipID
+			return 1, nil
+		}
+		if readCallCounter == 2 {
 			// Simulate returning 24 bytes for the coefficients (register bmp280RegCalib00)
 			return 24, nil
 		}
-		if readCallCounter == 2 {
+		if readCallCounter == 3 {
 			// Simulate returning a single byte for the hc.h1 value (register bme280RegCalibDigH1)
 			return 1, nil
 		}
-		if readCallCounter == 3 {
+		if readCallCounter == 4 {
 			// Simulate returning 7 bytes for the coefficients (register bme280RegCalibDigH2LSB)
 			return 7, nil
 		}
-		if readCallCounter == 4 {
+		if readCallCounter == 5 {
 			// Simulate returning 1 byte for the cmr (register bmp280RegControl)
 			return 1, nil
 		}
@@ -202,3 +216,46 @@ func TestBME280_initializationBME280(t *testing.T) {
 	}
 	require.NoError(t, bme280.Start())
 }
+
+func TestBME280_initializationBME280Errors(t *testing.T) {
+	tests := map[string]struct {
+		chipID     byte
+		readErr    error
+		wantIs     error
+		wantChipID *ChipIDError
+	}{
+		"device_not_found": {
+			readErr: errors.New("nack"),
+			wantIs:  ErrDeviceNotFound,
+		},
+		"bmp280_connected": {
+			chipID:     0x58,
+			wantIs:     ErrUnexpectedChipID,
+			wantChipID: &ChipIDError{Got: 0x58, Want: 0x60},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestBME280WithStubbedAdaptor()
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				b[0] = tc.chipID
+				return len(b), tc.readErr
+			}
+			// act
+			err := d.Start()
+			// assert
+			require.ErrorIs(t, err, tc.wantIs)
+			assert.Equal(t, []byte{bmp280RegChipID}, a.written)
+			var chipIDErr *ChipIDError
+			if tc.wantChipID == nil {
+				assert.False(t, errors.As(err, &chipIDErr))
+				return
+			}
+			require.ErrorAs(t, err, &chipIDErr)
+			assert.Equal(t, d.Name(), chipIDErr.Name)
+			assert.Equal(t, tc.wantChipID.Got, chipIDErr.Got)
+			assert.Equal(t, tc.wantChipID.Want, chipIDErr.Want)
+		})
+	}
+}
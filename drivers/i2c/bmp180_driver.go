@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"log"
 	"time"
+
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 const bmp180Debug = false
@@ -106,6 +108,16 @@ func (d *BMP180Driver) Temperature() (float32, error) {
 	return d.calculateTemp(rawTemp), nil
 }
 
+// TemperatureMeasurement returns the current temperature like Temperature(), but together with its unit.
+func (d *BMP180Driver) TemperatureMeasurement() (measurement.Measurement, error) {
+	val, err := d.Temperature()
+	if err != nil {
+		return measurement.Measurement{}, err
+	}
+
+	return measurement.New(float64(val), measurement.Celsius), nil
+}
+
 // Pressure returns the current pressure, in pascals.
 func (d *BMP180Driver) Pressure() (float32, error) {
 	d.mutex.Lock()
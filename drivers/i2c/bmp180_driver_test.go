@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 // this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
@@ -79,6 +80,9 @@ func TestBMP180Measurements(t *testing.T) {
 	temp, err := bmp180.Temperature()
 	require.NoError(t, err)
 	assert.InDelta(t, float32(15.0), temp, 0.0)
+	tempM, err := bmp180.TemperatureMeasurement()
+	require.NoError(t, err)
+	assert.Equal(t, measurement.New(15.0, measurement.Celsius), tempM)
 	pressure, err := bmp180.Pressure()
 	require.NoError(t, err)
 	assert.InDelta(t, float32(69964), pressure, 0.0)
@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"log"
 	"math"
+
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 const bmp280Debug = true
@@ -21,6 +23,7 @@ type (
 
 const (
 	bmp280RegCalib00      = 0x88 // 12 x 16 bit calibration data (T1..T3, P1..P9)
+	bmp280RegChipID       = 0xD0
 	bmp280RegCtrl         = 0xF4 // data acquisition options (oversampling of temperature and pressure, power mode)
 	bmp280RegConf         = 0xF5 // rate, IIR-filter and interface options (SPI)
 	bmp280RegPressureData = 0xF7
@@ -173,6 +176,16 @@ func (d *BMP280Driver) Temperature() (float32, error) {
 	return temp, nil
 }
 
+// TemperatureMeasurement returns the current temperature like Temperature(), but together with its unit.
+func (d *BMP280Driver) TemperatureMeasurement() (measurement.Measurement, error) {
+	val, err := d.Temperature()
+	if err != nil {
+		return measurement.Measurement{}, err
+	}
+
+	return measurement.New(float64(val), measurement.Celsius), nil
+}
+
 // Pressure returns the current barometric pressure, in Pa
 func (d *BMP280Driver) Pressure() (float32, error) {
 	d.mutex.Lock()
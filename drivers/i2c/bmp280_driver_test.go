@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 // this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
@@ -95,6 +96,10 @@ func TestBMP280Measurements(t *testing.T) {
 	temp, err := d.Temperature()
 	require.NoError(t, err)
 	assert.InDelta(t, float32(25.014637), temp, 0.0)
+	tempM, err := d.TemperatureMeasurement()
+	require.NoError(t, err)
+	assert.Equal(t, measurement.Celsius, tempM.Unit)
+	assert.InDelta(t, 25.014637, tempM.Value, 0.00001)
 	pressure, err := d.Pressure()
 	require.NoError(t, err)
 	assert.InDelta(t, float32(99545.414), pressure, 0.0)
@@ -125,6 +130,9 @@ func TestBMP280TemperatureReadError(t *testing.T) {
 	temp, err := d.Temperature()
 	require.ErrorContains(t, err, "read error")
 	assert.InDelta(t, float32(0.0), temp, 0.0)
+	tempM, err := d.TemperatureMeasurement()
+	require.ErrorContains(t, err, "read error")
+	assert.Equal(t, measurement.Measurement{}, tempM)
 }
 
 func TestBMP280PressureWriteError(t *testing.T) {
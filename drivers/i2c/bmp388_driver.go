@@ -3,7 +3,6 @@ package i2c
 import (
 	"bytes"
 	"encoding/binary"
-	"fmt"
 	"log"
 	"math"
 )
@@ -207,7 +206,7 @@ func (d *BMP388Driver) initialization() error {
 	}
 
 	if bmp388ChipID != chipID {
-		return fmt.Errorf("Incorrect BMP388 chip ID '0%x' Expected 0x%x", chipID, bmp388ChipID)
+		return &ChipIDError{Name: d.name, Got: chipID, Want: bmp388ChipID}
 	}
 
 	var (
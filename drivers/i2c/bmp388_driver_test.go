@@ -204,3 +204,20 @@ func TestBMP388_initialization(t *testing.T) {
 	assert.InDelta(t, float32(-0.00030517578), d.calCoeffs.p8, 0.0)
 	assert.InDelta(t, float32(5.8957283e-11), d.calCoeffs.p9, 0.0)
 }
+
+func TestBMP388_initializationChipIDError(t *testing.T) {
+	// arrange
+	d, a := initTestBMP388WithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0x60 // BMP390
+		return len(b), nil
+	}
+	// act
+	err := d.Start()
+	// assert
+	require.ErrorIs(t, err, ErrUnexpectedChipID)
+	var chipIDErr *ChipIDError
+	require.ErrorAs(t, err, &chipIDErr)
+	assert.Equal(t, uint8(0x60), chipIDErr.Got)
+	assert.Equal(t, uint8(0x50), chipIDErr.Want)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"time"
+
+	"gobot.io/x/gobot/v2"
 )
 
 // CCS811DriveMode type
@@ -36,9 +38,12 @@ const (
 	// Two byte read only register which contains the latest readings from the sensor.
 	// ccs811RegRawData = 0x03
 	// A multi-byte register that can be written with the current Humidity and Temperature values if known.
-	// ccs811RegEnvData = 0x05
+	ccs811RegEnvData = 0x05
 	// Register that holds the NTC value used for temperature calculations
 	ccs811RegNtc = 0x06
+	// Two byte read/write register which contains an encoded version of the current baseline used in algorithm
+	// calculations. The value can be saved and restored after a power cycle to skip the recalibration.
+	ccs811RegBaseline = 0x11
 	// Asserting the SW_RESET will restart the CCS811 in Boot mode to enable new application firmware to be downloaded.
 	ccs811RegSwReset = 0xFF
 	// Single byte read only register which holds the HW ID which is 0x81 for this family of CCS81x devices.
@@ -58,8 +63,17 @@ const (
 	// Constants
 	// The hardware ID code
 	ccs811HwIDCode = 0x81
+	// The temperature offset for the environment data register, which starts at -25°C
+	ccs811EnvTemperatureOffset = 25
+	// The maximum temperature, which can be written to the environment data register, about 103°C
+	ccs811EnvTemperatureMax = float32(math.MaxUint16)/512 - ccs811EnvTemperatureOffset
+	// The data sheet recommends to wait 20 minutes after power on, before the readings are accurate
+	ccs811DefaultWarmUpTime = 20 * time.Minute
 )
 
+// CCS811AirQuality event contains the latest eCO2 (ppm) and TVOC (ppb) values, emitted on cyclic reading
+const CCS811AirQuality = "air_quality"
+
 // The sequence of bytes needed to do a software reset
 var ccs811SwResetSequence = []byte{0x11, 0xE5, 0x72, 0x8A}
 
@@ -119,8 +133,14 @@ func (mm *CCS811MeasMode) GetMeasMode() byte {
 // CCS811Driver is the Gobot driver for the CCS811 (air quality sensor) Adafruit breakout board
 type CCS811Driver struct {
 	*Driver
+	gobot.Eventer
 	measMode           *CCS811MeasMode
 	ntcResistanceValue uint32
+	warmUpTime         time.Duration
+	readInterval       time.Duration
+	baseline           *uint16
+	startTime          time.Time
+	halt               chan struct{}
 }
 
 // NewCCS811Driver creates a new driver for the CCS811 (air quality sensor)
@@ -130,13 +150,19 @@ func NewCCS811Driver(c Connector, options ...func(Config)) *CCS811Driver {
 		measMode: NewCCS811MeasMode(),
 		// Recommended resistance value is 100,000
 		ntcResistanceValue: 100000,
+		warmUpTime:         ccs811DefaultWarmUpTime,
+		Eventer:            gobot.NewEventer(),
 	}
 	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
 
 	for _, option := range options {
 		option(d)
 	}
 
+	d.AddEvent(CCS811AirQuality)
+	d.AddEvent(Error)
+
 	return d
 }
 
@@ -157,6 +183,32 @@ func WithCCS811NTCResistance(val uint32) func(Config) {
 	}
 }
 
+// WithCCS811WarmUpTime sets the duration after start, before the readings are treated as valid. The default is 20
+// minutes, according to the data sheet. Please note, that a new sensor needs a burn-in of 48 hours additionally.
+func WithCCS811WarmUpTime(val time.Duration) func(Config) {
+	return func(c Config) {
+		d, _ := c.(*CCS811Driver)
+		d.warmUpTime = val
+	}
+}
+
+// WithCCS811CyclicRead activates the cyclic reading with the given interval. After the warm-up time has passed, the
+// "air_quality" event is published for each new data sample.
+func WithCCS811CyclicRead(interval time.Duration) func(Config) {
+	return func(c Config) {
+		d, _ := c.(*CCS811Driver)
+		d.readInterval = interval
+	}
+}
+
+// WithCCS811Baseline sets a baseline, formerly read by GetBaseline(), which is restored on start.
+func WithCCS811Baseline(val uint16) func(Config) {
+	return func(c Config) {
+		d, _ := c.(*CCS811Driver)
+		d.baseline = &val
+	}
+}
+
 // GetHardwareVersion returns the hardware version of the device in the form of 0x1X
 func (d *CCS811Driver) GetHardwareVersion() (uint8, error) {
 	d.mutex.Lock()
@@ -269,6 +321,63 @@ func (d *CCS811Driver) HasData() (bool, error) {
 	return true, nil
 }
 
+// IsWarmedUp returns true if the warm-up time after start has been passed, so the readings are valid.
+func (d *CCS811Driver) IsWarmedUp() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.isWarmedUp()
+}
+
+// SetEnvironmentalData writes the given relative humidity (%) and temperature (°C), e.g. read by another sensor, to
+// the device. This is used to compensate the gas readings.
+func (d *CCS811Driver) SetEnvironmentalData(humidity float32, temperature float32) error {
+	if math.IsNaN(float64(humidity)) || humidity < 0 || humidity > 100 {
+		return fmt.Errorf("humidity %.1f%% is out of range 0..100", humidity)
+	}
+	if math.IsNaN(float64(temperature)) {
+		return fmt.Errorf("temperature %.1f°C is invalid", temperature)
+	}
+	if temperature < -ccs811EnvTemperatureOffset {
+		return fmt.Errorf("temperature %.1f°C is below the minimum of -%d°C", temperature, ccs811EnvTemperatureOffset)
+	}
+	if temperature > ccs811EnvTemperatureMax {
+		return fmt.Errorf("temperature %.1f°C is above the maximum of %.3f°C", temperature, ccs811EnvTemperatureMax)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// both values are given in units of 1/512, starting with the MSB
+	hum := uint16(math.Round(float64(humidity) * 512))
+	temp := uint16(math.Round(float64(temperature+ccs811EnvTemperatureOffset) * 512))
+	data := []byte{byte(hum >> 8), byte(hum & 0xFF), byte(temp >> 8), byte(temp & 0xFF)}
+
+	return d.connection.WriteBlockData(ccs811RegEnvData, data)
+}
+
+// GetBaseline returns the current baseline of the device. The value can be stored and restored later by
+// SetBaseline() or the option WithCCS811Baseline(), to skip the recalibration after power on.
+func (d *CCS811Driver) GetBaseline() (uint16, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	data := make([]byte, 2)
+	if err := d.connection.ReadBlockData(ccs811RegBaseline, data); err != nil {
+		return 0, err
+	}
+
+	return (uint16(data[0]) << 8) | uint16(data[1]), nil
+}
+
+// SetBaseline restores a baseline, formerly read by GetBaseline().
+func (d *CCS811Driver) SetBaseline(val uint16) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeBaseline(val)
+}
+
 // EnableExternalInterrupt enables the external output hardware interrupt pin 3.
 func (d *CCS811Driver) EnableExternalInterrupt() error {
 	d.mutex.Lock()
@@ -313,9 +422,70 @@ func (d *CCS811Driver) initialize() error {
 		return fmt.Errorf("Failed to update the measMode register with error: %s", err.Error())
 	}
 
+	if d.baseline != nil {
+		if err := d.writeBaseline(*d.baseline); err != nil {
+			return fmt.Errorf("Failed to restore the baseline with error: %s", err.Error())
+		}
+	}
+
+	d.startTime = time.Now()
+
+	if d.readInterval > 0 {
+		d.halt = make(chan struct{})
+		go d.cyclicRead(d.halt)
+	}
+
+	return nil
+}
+
+// shutdown stops the cyclic reading, if running.
+func (d *CCS811Driver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
 	return nil
 }
 
+// cyclicRead publishes the air quality event for each new sample after the warm-up time is passed.
+func (d *CCS811Driver) cyclicRead(halt chan struct{}) {
+	ticker := time.NewTicker(d.readInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-halt:
+			return
+		case <-ticker.C:
+			if !d.IsWarmedUp() {
+				continue
+			}
+			hasData, err := d.HasData()
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+				continue
+			}
+			if !hasData {
+				continue
+			}
+			eco2, tvoc, err := d.GetGasData()
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+				continue
+			}
+			d.Publish(d.Event(CCS811AirQuality), map[string]uint16{"eco2": eco2, "tvoc": tvoc})
+		}
+	}
+}
+
+func (d *CCS811Driver) isWarmedUp() bool {
+	return !d.startTime.IsZero() && time.Since(d.startTime) >= d.warmUpTime
+}
+
+func (d *CCS811Driver) writeBaseline(val uint16) error {
+	return d.connection.WriteBlockData(ccs811RegBaseline, []byte{byte(val >> 8), byte(val & 0xFF)})
+}
+
 // ResetDevice does a software reset of the device. After this operation is done,
 // the user must start the app code before the sensor can take any measurements
 func (d *CCS811Driver) resetDevice() error {
@@ -2,8 +2,10 @@ package i2c
 
 import (
 	"errors"
+	"math"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -267,3 +269,168 @@ func TestCCS811_initialize(t *testing.T) {
 	assert.Equal(t, wantMeasReg, a.written[7])
 	assert.Equal(t, wantMeasRegVal, a.written[8])
 }
+
+func TestCCS811SetEnvironmentalData(t *testing.T) {
+	tests := map[string]struct {
+		humidity    float32
+		temperature float32
+		wantWritten []byte
+		wantErr     string
+	}{
+		"values": {
+			humidity:    65.5,
+			temperature: 20,
+			wantWritten: []byte{0x05, 0x83, 0x00, 0x5A, 0x00},
+		},
+		"minimum": {
+			humidity:    0,
+			temperature: -25,
+			wantWritten: []byte{0x05, 0x00, 0x00, 0x00, 0x00},
+		},
+		"maximum": {
+			humidity:    100,
+			temperature: ccs811EnvTemperatureMax,
+			wantWritten: []byte{0x05, 0xC8, 0x00, 0xFF, 0xFF},
+		},
+		"fraction": {
+			humidity:    48.5,
+			temperature: 23.5,
+			wantWritten: []byte{0x05, 0x61, 0x00, 0x61, 0x00},
+		},
+		"error_humidity": {
+			humidity: 100.1,
+			wantErr:  "humidity 100.1% is out of range",
+		},
+		"error_humidity_negative": {
+			humidity: -0.5,
+			wantErr:  "humidity -0.5% is out of range",
+		},
+		"error_humidity_nan": {
+			humidity: float32(math.NaN()),
+			wantErr:  "humidity NaN% is out of range",
+		},
+		"error_temperature": {
+			humidity:    50,
+			temperature: -25.5,
+			wantErr:     "below the minimum of -25°C",
+		},
+		"error_temperature_maximum": {
+			humidity:    50,
+			temperature: 103,
+			wantErr:     "temperature 103.0°C is above the maximum of 102.998°C",
+		},
+		"error_temperature_nan": {
+			humidity:    50,
+			temperature: float32(math.NaN()),
+			wantErr:     "temperature NaN°C is invalid",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestCCS811WithStubbedAdaptor()
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				b[0] = 0x81 // chip ID
+				return len(b), nil
+			}
+			require.NoError(t, d.Start())
+			a.written = []byte{} // reset writes of Start()
+			// act
+			err := d.SetEnvironmentalData(tc.humidity, tc.temperature)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Empty(t, a.written)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantWritten, a.written)
+		})
+	}
+}
+
+func TestCCS811Baseline_RoundTrip(t *testing.T) {
+	// arrange: a device with a baseline register
+	d, a := initTestCCS811WithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0x81 // chip ID
+		return len(b), nil
+	}
+	require.NoError(t, d.Start())
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0xA4, 0x7B})
+		return len(b), nil
+	}
+	a.written = []byte{} // reset writes of Start()
+	// act: save the baseline
+	baseline, err := d.GetBaseline()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0xA47B), baseline)
+	assert.Equal(t, []byte{0x11}, a.written)
+	// arrange: a new device with the saved baseline
+	d2, a2 := initTestCCS811WithStubbedAdaptor()
+	WithCCS811Baseline(baseline)(d2)
+	a2.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0x81 // chip ID
+		return len(b), nil
+	}
+	// act: restore on start
+	err = d2.Start()
+	// assert: the baseline register was written after the measurement mode
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x11, 0xA4, 0x7B}, a2.written[len(a2.written)-3:])
+	// act: restore manually
+	a2.written = []byte{}
+	require.NoError(t, d2.SetBaseline(baseline))
+	assert.Equal(t, []byte{0x11, 0xA4, 0x7B}, a2.written)
+}
+
+func TestCCS811IsWarmedUp(t *testing.T) {
+	// arrange
+	d, a := initTestCCS811WithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0x81 // chip ID
+		return len(b), nil
+	}
+	require.False(t, d.IsWarmedUp())
+	require.NoError(t, d.Start())
+	// act, assert
+	assert.False(t, d.IsWarmedUp())
+	d.warmUpTime = 0
+	assert.True(t, d.IsWarmedUp())
+}
+
+func TestCCS811CyclicRead(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewCCS811Driver(a, WithCCS811CyclicRead(time.Millisecond), WithCCS811WarmUpTime(0))
+	chipIDRead := false
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		switch len(b) {
+		case 1:
+			b[0] = 0x08 // status with data ready
+			if !chipIDRead {
+				b[0] = 0x81 // chip ID on start
+				chipIDRead = true
+			}
+		case 4:
+			copy(b, []byte{1, 156, 0, 86})
+		}
+		return len(b), nil
+	}
+	gotData := make(chan map[string]uint16, 1)
+	_ = d.Once(CCS811AirQuality, func(data interface{}) {
+		gotData <- data.(map[string]uint16)
+	})
+	// act
+	require.NoError(t, d.Start())
+	defer func() { _ = d.Halt() }()
+	// assert
+	select {
+	case data := <-gotData:
+		assert.Equal(t, map[string]uint16{"eco2": 412, "tvoc": 86}, data)
+	case <-time.After(time.Second):
+		t.Error("air quality event was not published")
+	}
+}
@@ -24,8 +24,35 @@ var (
 	ErrNotEnoughBytes = fmt.Errorf("Not enough bytes read")
 	// ErrNotReady is used when the device is not ready
 	ErrNotReady = fmt.Errorf("Device is not ready")
+	// ErrDeviceNotFound is used when the device does not respond on the first access
+	ErrDeviceNotFound = fmt.Errorf("Device not found")
+	// ErrUnexpectedChipID is used when the identification of the device does not match, see ChipIDError
+	ErrUnexpectedChipID = fmt.Errorf("Unexpected chip ID")
+	// ErrMeasurementTimeout is used when a measurement or conversion is not finished in time
+	ErrMeasurementTimeout = fmt.Errorf("Measurement timeout")
+	// ErrResetTimeout is used when the reset of the device is not finished in time
+	ErrResetTimeout = fmt.Errorf("Reset timeout")
+	// ErrMeasurementDisabled is used when a value is requested, which is not measured by the current configuration
+	ErrMeasurementDisabled = fmt.Errorf("Measurement disabled")
 )
 
+// ChipIDError is used when the chip ID read from the device differs from the expected one, e.g. because another
+// device type is connected. It matches ErrUnexpectedChipID, so errors.Is() and errors.As() can be used.
+type ChipIDError struct {
+	Name string // name of the driver
+	Got  uint8  // chip ID read from the device
+	Want uint8  // expected chip ID
+}
+
+func (e *ChipIDError) Error() string {
+	return fmt.Sprintf("%s 0x%02X of '%s', expected 0x%02X", ErrUnexpectedChipID, e.Got, e.Name, e.Want)
+}
+
+// Is reports whether the target is ErrUnexpectedChipID, so the specific error matches the generic one.
+func (e *ChipIDError) Is(target error) bool {
+	return target == ErrUnexpectedChipID //nolint:errorlint // comparison of the sentinel is intended
+}
+
 type bitState uint8
 
 const (
@@ -50,9 +77,11 @@ func NewConnection(bus gobot.I2cSystemDevicer, address int) *i2cConnection {
 	return &i2cConnection{bus: bus, address: address}
 }
 
-// Read data from an i2c device.
+// Read data from an i2c device. Some bus implementations return less bytes than requested without an error, so the
+// read is repeated for the remaining bytes until the buffer is full. If no further bytes are returned, an error
+// wrapping ErrNotEnoughBytes with the count of read bytes is returned.
 func (c *i2cConnection) Read(data []byte) (int, error) {
-	return c.bus.Read(c.address, data)
+	return readFull(func(b []byte) (int, error) { return c.bus.Read(c.address, b) }, data)
 }
 
 // Write data to an i2c device.
@@ -85,6 +114,16 @@ func (c *i2cConnection) ReadBlockData(reg uint8, b []byte) error {
 	return c.bus.ReadBlockData(c.address, reg, b)
 }
 
+// ReadSMBusBlockData reads a SMBus block from a register on the i2c device, the length is given by the device.
+func (c *i2cConnection) ReadSMBusBlockData(reg uint8, pec bool) ([]byte, error) {
+	r, ok := c.bus.(gobot.I2cSMBusBlockReader)
+	if !ok {
+		return nil, ErrSMBusBlockReadUnsupported
+	}
+
+	return r.ReadSMBusBlockData(c.address, reg, pec)
+}
+
 // WriteByte writes a single byte to the i2c device.
 func (c *i2cConnection) WriteByte(val byte) error {
 	return c.bus.WriteByte(c.address, val)
@@ -110,6 +149,34 @@ func (c *i2cConnection) WriteBytes(b []byte) error {
 	return c.bus.WriteBytes(c.address, b)
 }
 
+// ReadFull reads exactly len(data) bytes from the given connection. A short read is repeated for the remaining bytes,
+// as long as the connection returns further bytes. Otherwise an error wrapping ErrNotEnoughBytes is returned, which
+// contains the count of read bytes.
+func ReadFull(c Connection, data []byte) error {
+	_, err := readFull(c.Read, data)
+	return err
+}
+
+// readFull calls the given read function until the buffer is full or no further bytes are returned
+func readFull(read func([]byte) (int, error), data []byte) (int, error) {
+	var count int
+	for {
+		n, err := read(data[count:])
+		if n > 0 {
+			count += n
+		}
+		if err != nil {
+			return count, err
+		}
+		if count >= len(data) {
+			return count, nil
+		}
+		if n <= 0 {
+			return count, fmt.Errorf("%w: short read of %d from %d bytes", ErrNotEnoughBytes, count, len(data))
+		}
+	}
+}
+
 // setBit is used to set a bit at a given position to 1.
 func setBit(n uint8, pos uint8) uint8 {
 	n |= (1 << pos)
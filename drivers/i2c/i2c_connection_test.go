@@ -4,6 +4,8 @@
 package i2c
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"unsafe"
 
@@ -89,6 +91,125 @@ func TestI2CRead(t *testing.T) {
 	assert.Equal(t, 0, i)
 }
 
+// i2cShortReadDevice is a bus stub, which returns at most the given count of bytes on each read
+type i2cShortReadDevice struct {
+	gobot.I2cSystemDevicer
+	maxCounts []int // a count for each call, the last one is repeated
+	calls     int
+	next      byte
+}
+
+func (d *i2cShortReadDevice) Read(_ int, b []byte) (int, error) {
+	idx := d.calls
+	if idx >= len(d.maxCounts) {
+		idx = len(d.maxCounts) - 1
+	}
+	d.calls++
+	n := d.maxCounts[idx]
+	if n > len(b) {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		b[i] = d.next
+		d.next++
+	}
+	return n, nil
+}
+
+func TestI2CRead_shortReads(t *testing.T) {
+	tests := map[string]struct {
+		maxCounts []int
+		wantCount int
+		wantCalls int
+		wantErr   string
+	}{
+		"full_read": {
+			maxCounts: []int{6},
+			wantCount: 6,
+			wantCalls: 1,
+		},
+		"accumulated_short_reads": {
+			maxCounts: []int{2, 1, 3},
+			wantCount: 6,
+			wantCalls: 3,
+		},
+		"error_short_read": {
+			maxCounts: []int{4, 0},
+			wantCount: 4,
+			wantCalls: 2,
+			wantErr:   "Not enough bytes read: short read of 4 from 6 bytes",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			bus := &i2cShortReadDevice{maxCounts: tc.maxCounts}
+			c := NewConnection(bus, 0x06)
+			data := make([]byte, 6)
+			// act
+			n, err := c.Read(data)
+			// assert
+			assert.Equal(t, tc.wantCount, n)
+			assert.Equal(t, tc.wantCalls, bus.calls)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				require.ErrorIs(t, err, ErrNotEnoughBytes)
+				assert.Equal(t, []byte{0, 1, 2, 3, 0, 0}, data)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []byte{0, 1, 2, 3, 4, 5}, data)
+		})
+	}
+}
+
+func TestReadFull(t *testing.T) {
+	tests := map[string]struct {
+		counts  []int
+		readErr error
+		wantErr string
+	}{
+		"accumulated_short_reads": {
+			counts: []int{1, 2},
+		},
+		"error_short_read": {
+			counts:  []int{1, 0},
+			wantErr: "Not enough bytes read: short read of 1 from 3 bytes",
+		},
+		"error_read": {
+			counts:  []int{1},
+			readErr: errors.New("read error"),
+			wantErr: "read error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newI2cTestAdaptor()
+			var calls int
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				n := tc.counts[calls]
+				calls++
+				for i := 0; i < n; i++ {
+					b[i] = 0xAA
+				}
+				return n, tc.readErr
+			}
+			data := make([]byte, 3)
+			// act
+			err := ReadFull(a, data)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []byte{0xAA, 0xAA, 0xAA}, data)
+			assert.Equal(t, len(tc.counts), calls)
+		})
+	}
+}
+
 func TestI2CReadAddressError(t *testing.T) {
 	c := NewConnection(initI2CDeviceAddressError(), 0x06)
 	_, err := c.Read([]byte{})
@@ -143,6 +264,29 @@ func TestI2CReadWordDataAddressError(t *testing.T) {
 	require.ErrorContains(t, err, "Setting address failed with syscall.Errno operation not permitted")
 }
 
+func TestI2CReadSMBusBlockData(t *testing.T) {
+	// arrange
+	a := system.NewAccesser()
+	a.UseMockFilesystem([]string{dev})
+	msc := a.UseMockSyscall()
+	msc.Impl = func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (uintptr, uintptr, system.SyscallErrno) {
+		if (trap == system.Syscall_SYS_IOCTL) && (a2 == system.I2C_FUNCS) {
+			*(*uint64)(a3) = system.I2C_FUNC_SMBUS_READ_BLOCK_DATA
+		}
+		return 0, 0, 0
+	}
+	bus, _ := a.NewI2cDevice(dev)
+	c := NewConnection(bus, 0x0B)
+	// act
+	_, err := c.ReadSMBusBlockData(0x20, false)
+	// assert: the read is done by the bus, the mock returns an empty block
+	require.EqualError(t, err, "invalid SMBus block length 0, must be between 1 and 32")
+	// act & assert: a bus without the single transfer is not used
+	c = NewConnection(struct{ gobot.I2cSystemDevicer }{bus}, 0x0B)
+	_, err = c.ReadSMBusBlockData(0x20, false)
+	require.ErrorIs(t, err, ErrSMBusBlockReadUnsupported)
+}
+
 func TestI2CWriteByte(t *testing.T) {
 	c := NewConnection(initI2CDevice(), 0x06)
 	err := c.WriteByte(0x01)
@@ -202,3 +346,16 @@ func Test_clearBit(t *testing.T) {
 	gotVal := clearBit(128, 7)
 	assert.Equal(t, wantVal, gotVal)
 }
+
+func TestChipIDError(t *testing.T) {
+	// arrange
+	var err error = &ChipIDError{Name: "BME280", Got: 0x58, Want: 0x60}
+	wrapped := fmt.Errorf("start failed: %w", err)
+	// act, assert
+	assert.Equal(t, "Unexpected chip ID 0x58 of 'BME280', expected 0x60", err.Error())
+	require.ErrorIs(t, wrapped, ErrUnexpectedChipID)
+	assert.NotErrorIs(t, wrapped, ErrDeviceNotFound)
+	var chipIDErr *ChipIDError
+	require.ErrorAs(t, wrapped, &chipIDErr)
+	assert.Equal(t, uint8(0x58), chipIDErr.Got)
+}
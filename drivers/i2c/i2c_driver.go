@@ -45,8 +45,10 @@ type Driver struct {
 	connection     Connection
 	afterStart     func() error
 	beforeHalt     func() error
+	retryPolicy    RetryPolicy
 	Config
 	gobot.Commander
+	gobot.Labeler
 	mutex *sync.Mutex // mutex often needed to ensure that write-read sequences are not interrupted
 }
 
@@ -60,6 +62,7 @@ func NewDriver(c Connector, name string, address int, options ...func(Config)) *
 		beforeHalt:     func() error { return nil },
 		Config:         NewConfig(),
 		Commander:      gobot.NewCommander(),
+		Labeler:        gobot.NewLabeler(),
 		mutex:          &sync.Mutex{},
 	}
 
@@ -102,10 +105,16 @@ func (d *Driver) Start() error {
 	if d.connection, err = d.connector.GetI2cConnection(address, bus); err != nil {
 		return err
 	}
+	d.connection = NewRetryConnection(d.connection, d.retryPolicy)
 
 	return d.afterStart()
 }
 
+// setRetryPolicy sets the retry policy for the connection, see WithRetry().
+func (d *Driver) setRetryPolicy(policy RetryPolicy) {
+	d.retryPolicy = policy
+}
+
 // Halt halts the i2c device.
 func (d *Driver) Halt() error {
 	d.mutex.Lock()
@@ -0,0 +1,355 @@
+package i2c
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// INA2xxDriver is a driver for the Texas Instruments INA219 and INA226 devices. Both are current, bus voltage and
+// power monitors with an I2C and SMBUS compatible interface. The calibration register is computed from the shunt
+// resistance and the maximum expected current, so current and power are available in SI units.
+//
+// data sheets:
+// INA219: https://www.ti.com/lit/ds/symlink/ina219.pdf
+// INA226: https://www.ti.com/lit/ds/symlink/ina226.pdf
+
+const (
+	ina2xxDebug          = false
+	ina2xxDefaultAddress = 0x40 // 1000000 (A0+A1=GND)
+
+	ina2xxRegConfig       = 0x00
+	ina2xxRegShuntVoltage = 0x01
+	ina2xxRegBusVoltage   = 0x02
+	ina2xxRegPower        = 0x03
+	ina2xxRegCurrent      = 0x04
+	ina2xxRegCalibration  = 0x05
+
+	ina219ConfigDefault = 0x399F // 32V bus range, gain /8 (320mV), 12-bit ADC, continuous shunt and bus
+	ina226ConfigDefault = 0x4127 // 1 average, 1.1ms conversion time, continuous shunt and bus
+
+	ina2xxDefaultShuntOhm    = 0.1
+	ina2xxDefaultMaxCurrentA = 3.2
+)
+
+const (
+	// INA2xxMeasurement event contains the latest readings of bus voltage, current and power
+	INA2xxMeasurement = "measurement"
+	// INA2xxOverCurrent event is emitted when the absolute current exceeds the configured threshold
+	INA2xxOverCurrent = "overcurrent"
+)
+
+// ina2xxChip contains the chip specific constants
+type ina2xxChip struct {
+	calibrationFactor float64 // to calculate the calibration register
+	powerLSBFactor    float64 // power LSB = factor * current LSB
+	shuntVoltageLSB   float64 // in V
+	busVoltageLSB     float64 // in V
+	busVoltageShift   uint8   // count of unused lower bits in bus voltage register
+	config            uint16
+}
+
+var (
+	ina219Chip = ina2xxChip{
+		calibrationFactor: 0.04096,
+		powerLSBFactor:    20,
+		shuntVoltageLSB:   0.00001,
+		busVoltageLSB:     0.004,
+		busVoltageShift:   3,
+		config:            ina219ConfigDefault,
+	}
+	ina226Chip = ina2xxChip{
+		calibrationFactor: 0.00512,
+		powerLSBFactor:    25,
+		shuntVoltageLSB:   0.0000025,
+		busVoltageLSB:     0.00125,
+		busVoltageShift:   0,
+		config:            ina226ConfigDefault,
+	}
+)
+
+// INA2xxDriver is a driver for the INA219 and INA226 current, voltage and power monitoring devices.
+type INA2xxDriver struct {
+	*Driver
+	gobot.Eventer
+	chip                 ina2xxChip
+	shuntOhm             float64
+	maxCurrentA          float64
+	currentLSB           float64
+	calibration          uint16
+	readInterval         time.Duration
+	overCurrentThreshold float64
+	halt                 chan struct{}
+}
+
+// NewINA219Driver creates a new driver for the INA219 device with the specified i2c interface.
+// Params:
+//
+//	c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//
+//	i2c.WithBus(int):		bus to use with this driver
+//	i2c.WithAddress(int):		address to use with this driver
+//	i2c.WithINA2xxCalibration(float64, float64):	shunt resistance in Ohm and maximum expected current in A
+//	i2c.WithINA2xxCyclicRead(time.Duration):	interval for reading and publish the measurement event
+//	i2c.WithINA2xxOverCurrentThreshold(float64):	current in A to publish the overcurrent event
+func NewINA219Driver(c Connector, options ...func(Config)) *INA2xxDriver {
+	return newINA2xxDriver(c, "INA219", ina219Chip, options...)
+}
+
+// NewINA226Driver creates a new driver for the INA226 device with the specified i2c interface.
+// For params and optional params see [i2c.NewINA219Driver].
+func NewINA226Driver(c Connector, options ...func(Config)) *INA2xxDriver {
+	return newINA2xxDriver(c, "INA226", ina226Chip, options...)
+}
+
+func newINA2xxDriver(c Connector, name string, chip ina2xxChip, options ...func(Config)) *INA2xxDriver {
+	d := &INA2xxDriver{
+		Driver:      NewDriver(c, name, ina2xxDefaultAddress),
+		Eventer:     gobot.NewEventer(),
+		chip:        chip,
+		shuntOhm:    ina2xxDefaultShuntOhm,
+		maxCurrentA: ina2xxDefaultMaxCurrentA,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddEvent(INA2xxMeasurement)
+	d.AddEvent(INA2xxOverCurrent)
+	d.AddEvent(Error)
+
+	d.AddCommand("BusVoltage", func(params map[string]interface{}) interface{} {
+		val, err := d.BusVoltage()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("Current", func(params map[string]interface{}) interface{} {
+		val, err := d.Current()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("Power", func(params map[string]interface{}) interface{} {
+		val, err := d.Power()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return d
+}
+
+// WithINA2xxCalibration option sets the shunt resistance in Ohm and the maximum expected current in Ampere,
+// which are used to calculate the calibration register.
+func WithINA2xxCalibration(shuntOhm float64, maxCurrentA float64) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*INA2xxDriver); ok {
+			d.shuntOhm = shuntOhm
+			d.maxCurrentA = maxCurrentA
+		} else if ina2xxDebug {
+			log.Printf("Trying to set calibration for non-INA2xxDriver %v", c)
+		}
+	}
+}
+
+// WithINA2xxCyclicRead option activates the cyclic reading with the given interval. The measurement event is
+// published after each successful read.
+func WithINA2xxCyclicRead(interval time.Duration) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*INA2xxDriver); ok {
+			d.readInterval = interval
+		} else if ina2xxDebug {
+			log.Printf("Trying to set read interval for non-INA2xxDriver %v", c)
+		}
+	}
+}
+
+// WithINA2xxOverCurrentThreshold option sets the current in Ampere, above which the overcurrent event is published
+// on cyclic reading. A value of zero deactivates the event.
+func WithINA2xxOverCurrentThreshold(currentA float64) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*INA2xxDriver); ok {
+			d.overCurrentThreshold = currentA
+		} else if ina2xxDebug {
+			log.Printf("Trying to set over current threshold for non-INA2xxDriver %v", c)
+		}
+	}
+}
+
+// Calibration returns the value which was calculated for the calibration register.
+func (d *INA2xxDriver) Calibration() uint16 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.calibration
+}
+
+// BusVoltage reads the bus voltage in Volt.
+func (d *INA2xxDriver) BusVoltage() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readBusVoltage()
+}
+
+// ShuntVoltage reads the shunt voltage in Volt.
+func (d *INA2xxDriver) ShuntVoltage() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.readRegister(ina2xxRegShuntVoltage)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(int16(val)) * d.chip.shuntVoltageLSB, nil
+}
+
+// Current reads the current in Ampere, calculated by the device with the calibration register.
+func (d *INA2xxDriver) Current() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readCurrent()
+}
+
+// Power reads the power in Watt, calculated by the device with the calibration register.
+func (d *INA2xxDriver) Power() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readPower()
+}
+
+// initialize calculates and writes the calibration register and starts the cyclic reading, if configured.
+func (d *INA2xxDriver) initialize() error {
+	calibration, currentLSB, err := ina2xxCalibration(d.chip, d.shuntOhm, d.maxCurrentA)
+	if err != nil {
+		return err
+	}
+	d.calibration = calibration
+	d.currentLSB = currentLSB
+
+	if err := d.writeRegister(ina2xxRegConfig, d.chip.config); err != nil {
+		return err
+	}
+
+	if err := d.writeRegister(ina2xxRegCalibration, d.calibration); err != nil {
+		return err
+	}
+
+	if d.readInterval > 0 {
+		d.halt = make(chan struct{})
+		go d.cyclicRead(d.halt)
+	}
+
+	return nil
+}
+
+// shutdown stops the cyclic reading, if running.
+func (d *INA2xxDriver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+	return nil
+}
+
+func (d *INA2xxDriver) cyclicRead(halt chan struct{}) {
+	ticker := time.NewTicker(d.readInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-halt:
+			return
+		case <-ticker.C:
+			busVoltage, err := d.BusVoltage()
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+				continue
+			}
+			current, err := d.Current()
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+				continue
+			}
+			power, err := d.Power()
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+				continue
+			}
+			d.Publish(d.Event(INA2xxMeasurement), map[string]float64{
+				"busVoltage": busVoltage,
+				"current":    current,
+				"power":      power,
+			})
+			if d.overCurrentThreshold > 0 && math.Abs(current) > d.overCurrentThreshold {
+				d.Publish(d.Event(INA2xxOverCurrent), current)
+			}
+		}
+	}
+}
+
+func (d *INA2xxDriver) readBusVoltage() (float64, error) {
+	val, err := d.readRegister(ina2xxRegBusVoltage)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(val>>d.chip.busVoltageShift) * d.chip.busVoltageLSB, nil
+}
+
+func (d *INA2xxDriver) readCurrent() (float64, error) {
+	val, err := d.readRegister(ina2xxRegCurrent)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(int16(val)) * d.currentLSB, nil
+}
+
+func (d *INA2xxDriver) readPower() (float64, error) {
+	val, err := d.readRegister(ina2xxRegPower)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(val) * d.chip.powerLSBFactor * d.currentLSB, nil
+}
+
+// readRegister reads a word from the given register, the device sends the MSB first
+func (d *INA2xxDriver) readRegister(reg uint8) (uint16, error) {
+	val, err := d.connection.ReadWordData(reg)
+	if err != nil {
+		return 0, err
+	}
+
+	return swapBytes(val), nil
+}
+
+// writeRegister writes a word to the given register, the device expects the MSB first
+func (d *INA2xxDriver) writeRegister(reg uint8, val uint16) error {
+	return d.connection.WriteBlockData(reg, []byte{byte(val >> 8), byte(val & 0xFF)})
+}
+
+// ina2xxCalibration calculates the calibration register value and the current LSB (A/bit) for the given chip,
+// shunt resistance and maximum expected current.
+func ina2xxCalibration(chip ina2xxChip, shuntOhm float64, maxCurrentA float64) (uint16, float64, error) {
+	if shuntOhm <= 0 || maxCurrentA <= 0 {
+		return 0, 0, fmt.Errorf("shunt resistance (%f) and max. current (%f) must be greater than zero",
+			shuntOhm, maxCurrentA)
+	}
+
+	currentLSB := maxCurrentA / 32768
+	calibration := math.Trunc(chip.calibrationFactor / (currentLSB * shuntOhm))
+	if calibration < 1 || calibration > 0xFFFE {
+		return 0, 0, fmt.Errorf("calibration value %.0f out of range for shunt %f Ohm and max. current %f A",
+			calibration, shuntOhm, maxCurrentA)
+	}
+
+	return uint16(calibration), currentLSB, nil
+}
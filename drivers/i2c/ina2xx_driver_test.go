@@ -0,0 +1,245 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*INA2xxDriver)(nil)
+
+func initTestINA219DriverWithStubbedAdaptor() (*INA2xxDriver, *i2cTestAdaptor) {
+	a := newI2cTestAdaptor()
+	d := NewINA219Driver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a
+}
+
+func TestNewINA2xxDriver(t *testing.T) {
+	var di interface{} = NewINA219Driver(newI2cTestAdaptor())
+	d, ok := di.(*INA2xxDriver)
+	if !ok {
+		t.Error("NewINA219Driver() should return a *INA2xxDriver")
+	}
+	assert.NotNil(t, d.Driver)
+	assert.NotNil(t, d.Eventer)
+	assert.True(t, strings.HasPrefix(d.Name(), "INA219"))
+	assert.Equal(t, 0x40, d.defaultAddress)
+	assert.InDelta(t, 0.1, d.shuntOhm, 0.0)
+	assert.InDelta(t, 3.2, d.maxCurrentA, 0.0)
+	d = NewINA226Driver(newI2cTestAdaptor())
+	assert.True(t, strings.HasPrefix(d.Name(), "INA226"))
+}
+
+func TestINA2xxOptions(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewINA219Driver(newI2cTestAdaptor(), WithBus(2), WithINA2xxCalibration(0.01, 8),
+		WithINA2xxCyclicRead(time.Second), WithINA2xxOverCurrentThreshold(5))
+	assert.Equal(t, 2, d.GetBusOrDefault(1))
+	assert.InDelta(t, 0.01, d.shuntOhm, 0.0)
+	assert.InDelta(t, 8.0, d.maxCurrentA, 0.0)
+	assert.Equal(t, time.Second, d.readInterval)
+	assert.InDelta(t, 5.0, d.overCurrentThreshold, 0.0)
+}
+
+func TestINA2xxStart_Calibration(t *testing.T) {
+	tests := map[string]struct {
+		newFunc     func(c Connector, options ...func(Config)) *INA2xxDriver
+		shuntOhm    float64
+		maxCurrentA float64
+		wantCal     uint16
+		wantWritten []byte
+		wantErr     string
+	}{
+		"ina219_0.1ohm_3.2A": {
+			newFunc:     NewINA219Driver,
+			shuntOhm:    0.1,
+			maxCurrentA: 3.2,
+			wantCal:     4194,
+			wantWritten: []byte{0x00, 0x39, 0x9F, 0x05, 0x10, 0x62},
+		},
+		"ina219_0.1ohm_0.4A": {
+			newFunc:     NewINA219Driver,
+			shuntOhm:    0.1,
+			maxCurrentA: 0.4,
+			wantCal:     33554,
+			wantWritten: []byte{0x00, 0x39, 0x9F, 0x05, 0x83, 0x12},
+		},
+		"ina226_0.002ohm_10A": {
+			newFunc:     NewINA226Driver,
+			shuntOhm:    0.002,
+			maxCurrentA: 10,
+			wantCal:     8388,
+			wantWritten: []byte{0x00, 0x41, 0x27, 0x05, 0x20, 0xC4},
+		},
+		"error_zero_shunt": {
+			newFunc:     NewINA219Driver,
+			maxCurrentA: 3.2,
+			wantErr:     "must be greater than zero",
+		},
+		"error_out_of_range": {
+			newFunc:     NewINA219Driver,
+			shuntOhm:    0.001,
+			maxCurrentA: 0.01,
+			wantErr:     "out of range",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newI2cTestAdaptor()
+			d := tc.newFunc(a, WithINA2xxCalibration(tc.shuntOhm, tc.maxCurrentA))
+			// act
+			err := d.Start()
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCal, d.Calibration())
+			assert.Equal(t, tc.wantWritten, a.written)
+		})
+	}
+}
+
+func TestINA2xxReadings(t *testing.T) {
+	tests := map[string]struct {
+		newFunc      func(c Connector, options ...func(Config)) *INA2xxDriver
+		readFunc     func(d *INA2xxDriver) (float64, error)
+		readData     []byte
+		wantRegister uint8
+		want         float64
+	}{
+		"ina219_bus_voltage": {
+			newFunc:      NewINA219Driver,
+			readFunc:     (*INA2xxDriver).BusVoltage,
+			readData:     []byte{0x5D, 0xA0}, // 2996 after shift by 3
+			wantRegister: 0x02,
+			want:         11.984,
+		},
+		"ina219_shunt_voltage": {
+			newFunc:      NewINA219Driver,
+			readFunc:     (*INA2xxDriver).ShuntVoltage,
+			readData:     []byte{0x03, 0xE8}, // 1000 * 10uV
+			wantRegister: 0x01,
+			want:         0.01,
+		},
+		"ina219_shunt_voltage_negative": {
+			newFunc:      NewINA219Driver,
+			readFunc:     (*INA2xxDriver).ShuntVoltage,
+			readData:     []byte{0xFC, 0x18}, // -1000 * 10uV
+			wantRegister: 0x01,
+			want:         -0.01,
+		},
+		"ina219_current": {
+			newFunc:      NewINA219Driver,
+			readFunc:     (*INA2xxDriver).Current,
+			readData:     []byte{0x04, 0x00}, // 1024 * 3.2A/32768
+			wantRegister: 0x04,
+			want:         0.1,
+		},
+		"ina219_power": {
+			newFunc:      NewINA219Driver,
+			readFunc:     (*INA2xxDriver).Power,
+			readData:     []byte{0x01, 0x00}, // 256 * 20 * 3.2A/32768
+			wantRegister: 0x03,
+			want:         0.5,
+		},
+		"ina226_bus_voltage": {
+			newFunc:      NewINA226Driver,
+			readFunc:     (*INA2xxDriver).BusVoltage,
+			readData:     []byte{0x25, 0x80}, // 9600 * 1.25mV
+			wantRegister: 0x02,
+			want:         12,
+		},
+		"ina226_shunt_voltage": {
+			newFunc:      NewINA226Driver,
+			readFunc:     (*INA2xxDriver).ShuntVoltage,
+			readData:     []byte{0x03, 0xE8}, // 1000 * 2.5uV
+			wantRegister: 0x01,
+			want:         0.0025,
+		},
+		"ina226_power": {
+			newFunc:      NewINA226Driver,
+			readFunc:     (*INA2xxDriver).Power,
+			readData:     []byte{0x01, 0x00}, // 256 * 25 * 3.2A/32768
+			wantRegister: 0x03,
+			want:         0.625,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newI2cTestAdaptor()
+			d := tc.newFunc(a)
+			require.NoError(t, d.Start())
+			a.written = []byte{} // reset writes of Start()
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				copy(b, tc.readData)
+				return len(b), nil
+			}
+			// act
+			got, err := tc.readFunc(d)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, []byte{tc.wantRegister}, a.written)
+			assert.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+}
+
+func TestINA2xxReadError(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+
+	_, err := d.Current()
+	require.ErrorContains(t, err, "read error")
+}
+
+func TestINA2xxCyclicRead_OverCurrent(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewINA219Driver(a, WithINA2xxCyclicRead(time.Millisecond), WithINA2xxOverCurrentThreshold(1.5))
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x50, 0x00}) // 20480 * 3.2A/32768 = 2A
+		return len(b), nil
+	}
+	measured := make(chan map[string]float64, 1)
+	overCurrent := make(chan float64, 1)
+	_ = d.Once(INA2xxMeasurement, func(data interface{}) {
+		measured <- data.(map[string]float64)
+	})
+	_ = d.Once(INA2xxOverCurrent, func(data interface{}) {
+		overCurrent <- data.(float64)
+	})
+	// act
+	require.NoError(t, d.Start())
+	defer func() { _ = d.Halt() }()
+	// assert
+	select {
+	case m := <-measured:
+		assert.InDelta(t, 2.0, m["current"], 1e-9)
+	case <-time.After(time.Second):
+		t.Error("measurement event was not published")
+	}
+	select {
+	case c := <-overCurrent:
+		assert.InDelta(t, 2.0, c, 1e-9)
+	case <-time.After(time.Second):
+		t.Error("overcurrent event was not published")
+	}
+}
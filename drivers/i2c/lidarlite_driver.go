@@ -2,6 +2,8 @@ package i2c
 
 import (
 	"time"
+
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 const lidarliteDefaultAddress = 0x62
@@ -73,3 +75,13 @@ func (h *LIDARLiteDriver) Distance() (int, error) {
 
 	return distance, nil
 }
+
+// DistanceMeasurement returns the current distance like Distance(), but together with its unit.
+func (h *LIDARLiteDriver) DistanceMeasurement() (measurement.Measurement, error) {
+	val, err := h.Distance()
+	if err != nil {
+		return measurement.Measurement{}, err
+	}
+
+	return measurement.New(float64(val), measurement.Centimeter), nil
+}
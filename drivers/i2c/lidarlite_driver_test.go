@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 // this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
@@ -98,6 +99,36 @@ func TestLIDARLiteDriverDistance(t *testing.T) {
 	require.ErrorContains(t, err, "read error")
 }
 
+func TestLIDARLiteDriverDistanceMeasurement(t *testing.T) {
+	// arrange
+	d, a := initTestLIDARLiteDriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{1})
+		return 1, nil
+	}
+	// act
+	got, err := d.DistanceMeasurement()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, measurement.New(257, measurement.Centimeter), got)
+	meters, err := got.Meters()
+	require.NoError(t, err)
+	assert.InDelta(t, 2.57, meters, 1e-9)
+}
+
+func TestLIDARLiteDriverDistanceMeasurementError(t *testing.T) {
+	// arrange
+	d, a := initTestLIDARLiteDriverWithStubbedAdaptor()
+	a.i2cReadImpl = func([]byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	// act
+	got, err := d.DistanceMeasurement()
+	// assert
+	require.ErrorContains(t, err, "read error")
+	assert.Equal(t, measurement.Measurement{}, got)
+}
+
 func TestLIDARLiteDriverDistanceError1(t *testing.T) {
 	d, a := initTestLIDARLiteDriverWithStubbedAdaptor()
 	a.i2cWriteImpl = func([]byte) (int, error) {
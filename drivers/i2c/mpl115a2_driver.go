@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 const mpl115a2DefaultAddress = 0x60
@@ -89,6 +90,16 @@ func (d *MPL115A2Driver) Temperature() (float32, error) {
 	return t, err
 }
 
+// TemperatureMeasurement returns the current temperature like Temperature(), but together with its unit.
+func (d *MPL115A2Driver) TemperatureMeasurement() (measurement.Measurement, error) {
+	val, err := d.Temperature()
+	if err != nil {
+		return measurement.Measurement{}, err
+	}
+
+	return measurement.New(float64(val), measurement.Celsius), nil
+}
+
 func (d *MPL115A2Driver) initialization() error {
 	data := make([]byte, 8)
 	if err := d.connection.ReadBlockData(mpl115A2Reg_A0_MSB, data); err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 // this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
@@ -106,6 +107,23 @@ func TestMPL115A2ReadDataError(t *testing.T) {
 	require.ErrorContains(t, err, "write error")
 }
 
+func TestMPL115A2TemperatureMeasurement(t *testing.T) {
+	// arrange
+	d, a := initTestMPL115A2DriverWithStubbedAdaptor()
+	_ = d.Start()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		// raw temperature of 498 (0x1F2 << 6) is 25 °C
+		copy(b, []byte{0x00, 0x00, 0x7C, 0x80})
+		return len(b), nil
+	}
+	// act
+	got, err := d.TemperatureMeasurement()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, measurement.Celsius, got.Unit)
+	assert.InDelta(t, 25.0, got.Value, 0.001)
+}
+
 func TestMPL115A2_initialization(t *testing.T) {
 	// sequence for initialization the device on Start(), which calculates
 	// the coefficients for temperature compensation of pressure
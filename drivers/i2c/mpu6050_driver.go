@@ -274,7 +274,7 @@ func (m *MPU6050Driver) waitForReset() error {
 	start := time.Now()
 	for {
 		if time.Since(start) > wait {
-			return fmt.Errorf("timeout on wait for reset is done")
+			return fmt.Errorf("%w: '%s' is not reset within %s", ErrResetTimeout, m.name, wait)
 		}
 		if val, err := m.connection.ReadByteData(mpu6050Reg_PwrMgmt1); (val&mpu6050Pwr1_DeviceResetBit == 0) && (err == nil) {
 			return nil
@@ -286,7 +286,7 @@ func (m *MPU6050Driver) waitForReset() error {
 func (m *MPU6050Driver) initialize() error {
 	// reset device and wait for reset is finished
 	if err := m.connection.WriteByteData(mpu6050Reg_PwrMgmt1, mpu6050Pwr1_DeviceResetBit); err != nil {
-		return err
+		return fmt.Errorf("%w: '%s' does not respond (%v)", ErrDeviceNotFound, m.name, err)
 	}
 	if err := m.waitForReset(); err != nil {
 		return err
@@ -191,3 +191,39 @@ func TestMPU6050_initialize(t *testing.T) {
 	assert.Equal(t, uint8(0x6B), a.written[11])
 	assert.Equal(t, uint8(0x01), a.written[12])
 }
+
+func TestMPU6050_initializeErrors(t *testing.T) {
+	tests := map[string]struct {
+		writeErr error
+		resetBit byte
+		wantIs   error
+	}{
+		"device_not_found": {
+			writeErr: errors.New("nack"),
+			wantIs:   ErrDeviceNotFound,
+		},
+		"reset_timeout": {
+			resetBit: 0x80,
+			wantIs:   ErrResetTimeout,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newI2cTestAdaptor()
+			d := NewMPU6050Driver(a)
+			a.i2cWriteImpl = func(b []byte) (int, error) {
+				return len(b), tc.writeErr
+			}
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				b[0] = tc.resetBit
+				return len(b), nil
+			}
+			// act
+			err := d.Start()
+			// assert
+			require.ErrorIs(t, err, tc.wantIs)
+			assert.Contains(t, err.Error(), d.Name())
+		})
+	}
+}
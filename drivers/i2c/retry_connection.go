@@ -0,0 +1,167 @@
+package i2c
+
+import (
+	"log"
+	"time"
+)
+
+// RetryPolicy defines how often a failed i2c operation is repeated, e.g. caused by a sporadic NACK in a noisy
+// environment. The wait time before the first retry is given by the backoff and doubles for each further retry.
+type RetryPolicy struct {
+	Retries int
+	Backoff time.Duration
+}
+
+// retryPolicySetter is implemented by the Driver and all drivers based on it
+type retryPolicySetter interface {
+	setRetryPolicy(policy RetryPolicy)
+}
+
+// retryConnection wraps a connection and repeats failed operations according to the retry policy
+type retryConnection struct {
+	connection Connection
+	policy     RetryPolicy
+	sleepFunc  func(time.Duration)
+}
+
+// WithRetry sets a retry policy for all operations of the driver as a optional param. A failed operation is repeated up
+// to the given count of retries. The wait time before the first retry is the given backoff, which doubles for each
+// further retry. If all attempts fail, the error of the last one is returned.
+func WithRetry(retries int, backoff time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(retryPolicySetter)
+		if !ok {
+			panic("unable to set retry policy for i2c driver")
+		}
+		d.setRetryPolicy(RetryPolicy{Retries: retries, Backoff: backoff})
+	}
+}
+
+// NewRetryConnection wraps the given connection, so that failed operations are retried transparently according to the
+// given policy. The connection is returned unchanged for a policy without retries.
+func NewRetryConnection(c Connection, policy RetryPolicy) Connection {
+	if policy.Retries <= 0 {
+		return c
+	}
+
+	return &retryConnection{connection: c, policy: policy, sleepFunc: time.Sleep}
+}
+
+// do calls the given function until it succeeds or all retries are done
+func (c *retryConnection) do(operation string, f func() error) error {
+	err := f()
+	backoff := c.policy.Backoff
+	for retry := 1; err != nil && retry <= c.policy.Retries; retry++ {
+		log.Printf("i2c %s failed (%v), retry %d of %d in %s\n", operation, err, retry, c.policy.Retries, backoff)
+		c.sleepFunc(backoff)
+		backoff *= 2
+		err = f()
+	}
+
+	return err
+}
+
+// Read implements the Connection interface.
+func (c *retryConnection) Read(data []byte) (int, error) {
+	var n int
+	err := c.do("Read", func() error {
+		var err error
+		n, err = c.connection.Read(data)
+		return err
+	})
+	return n, err
+}
+
+// Write implements the Connection interface.
+func (c *retryConnection) Write(data []byte) (int, error) {
+	var n int
+	err := c.do("Write", func() error {
+		var err error
+		n, err = c.connection.Write(data)
+		return err
+	})
+	return n, err
+}
+
+// Close implements the Connection interface.
+func (c *retryConnection) Close() error {
+	return c.connection.Close()
+}
+
+// ReadByte implements the Connection interface.
+func (c *retryConnection) ReadByte() (byte, error) {
+	var val byte
+	err := c.do("ReadByte", func() error {
+		var err error
+		val, err = c.connection.ReadByte()
+		return err
+	})
+	return val, err
+}
+
+// ReadByteData implements the Connection interface.
+func (c *retryConnection) ReadByteData(reg uint8) (uint8, error) {
+	var val uint8
+	err := c.do("ReadByteData", func() error {
+		var err error
+		val, err = c.connection.ReadByteData(reg)
+		return err
+	})
+	return val, err
+}
+
+// ReadWordData implements the Connection interface.
+func (c *retryConnection) ReadWordData(reg uint8) (uint16, error) {
+	var val uint16
+	err := c.do("ReadWordData", func() error {
+		var err error
+		val, err = c.connection.ReadWordData(reg)
+		return err
+	})
+	return val, err
+}
+
+// ReadBlockData implements the Connection interface.
+func (c *retryConnection) ReadBlockData(reg uint8, b []byte) error {
+	return c.do("ReadBlockData", func() error { return c.connection.ReadBlockData(reg, b) })
+}
+
+// ReadSMBusBlockData implements the SMBusBlockReader interface.
+func (c *retryConnection) ReadSMBusBlockData(reg uint8, pec bool) ([]byte, error) {
+	if _, ok := c.connection.(SMBusBlockReader); !ok {
+		return nil, ErrSMBusBlockReadUnsupported
+	}
+
+	var data []byte
+	err := c.do("ReadSMBusBlockData", func() error {
+		var err error
+		data, err = ReadSMBusBlockData(c.connection, reg, pec)
+		return err
+	})
+	return data, err
+}
+
+// WriteByte implements the Connection interface.
+func (c *retryConnection) WriteByte(val byte) error {
+	return c.do("WriteByte", func() error { return c.connection.WriteByte(val) })
+}
+
+// WriteByteData implements the Connection interface.
+func (c *retryConnection) WriteByteData(reg uint8, val uint8) error {
+	return c.do("WriteByteData", func() error { return c.connection.WriteByteData(reg, val) })
+}
+
+// WriteWordData implements the Connection interface.
+func (c *retryConnection) WriteWordData(reg uint8, val uint16) error {
+	return c.do("WriteWordData", func() error { return c.connection.WriteWordData(reg, val) })
+}
+
+// WriteBlockData implements the Connection interface.
+func (c *retryConnection) WriteBlockData(reg uint8, b []byte) error {
+	return c.do("WriteBlockData", func() error { return c.connection.WriteBlockData(reg, b) })
+}
+
+// WriteBytes implements the Connection interface.
+func (c *retryConnection) WriteBytes(b []byte) error {
+	return c.do("WriteBytes", func() error { return c.connection.WriteBytes(b) })
+}
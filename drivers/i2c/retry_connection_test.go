@@ -0,0 +1,122 @@
+package i2c
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRetryConnection(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	// act & assert
+	assert.Equal(t, a, NewRetryConnection(a, RetryPolicy{}))
+	assert.IsType(t, &retryConnection{}, NewRetryConnection(a, RetryPolicy{Retries: 1}))
+}
+
+func TestRetryConnection(t *testing.T) {
+	tests := map[string]struct {
+		failures    int
+		wantCalls   int
+		wantBackoff []time.Duration
+		wantErr     string
+	}{
+		"no_failure": {
+			wantCalls: 1,
+		},
+		"fail_twice_then_succeed": {
+			failures:    2,
+			wantCalls:   3,
+			wantBackoff: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+		},
+		"error_all_attempts_failed": {
+			failures:    5,
+			wantCalls:   4,
+			wantBackoff: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond},
+			wantErr:     "nack 4",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newI2cTestAdaptor()
+			var calls int
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				calls++
+				if calls <= tc.failures {
+					return 0, fmt.Errorf("nack %d", calls)
+				}
+				b[0] = 0x42
+				return 1, nil
+			}
+			//nolint:forcetypeassert // ok here
+			c := NewRetryConnection(a, RetryPolicy{Retries: 3, Backoff: 10 * time.Millisecond}).(*retryConnection)
+			var backoff []time.Duration
+			c.sleepFunc = func(d time.Duration) { backoff = append(backoff, d) }
+			// act
+			val, err := c.ReadByte()
+			// assert
+			assert.Equal(t, tc.wantCalls, calls)
+			assert.Equal(t, tc.wantBackoff, backoff)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, byte(0x42), val)
+		})
+	}
+}
+
+func TestRetryConnectionReadSMBusBlockData(t *testing.T) {
+	// arrange
+	var calls int
+	sc := &smbusBlockTestConnection{
+		i2cTestAdaptor: newI2cTestAdaptor(),
+		readSMBusBlockImpl: func(reg uint8, pec bool) ([]byte, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("nack")
+			}
+			return []byte{reg}, nil
+		},
+	}
+	c := NewRetryConnection(sc, RetryPolicy{Retries: 2})
+	// act
+	got, err := ReadSMBusBlockData(c, 0x20, true)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x20}, got)
+	assert.Equal(t, 2, calls)
+	// act & assert: an unsupported read is not retried
+	c = NewRetryConnection(newI2cTestAdaptor(), RetryPolicy{Retries: 2})
+	_, err = ReadSMBusBlockData(c, 0x20, true)
+	require.ErrorIs(t, err, ErrSMBusBlockReadUnsupported)
+}
+
+func TestWithRetry(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	var calls int
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errors.New("nack")
+		}
+		return len(b), nil
+	}
+	d := NewDriver(a, "I2C_RETRY", 0x15, WithRetry(2, time.Millisecond))
+	// act
+	require.NoError(t, d.Start())
+	err := d.Write("1", 0x02)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, RetryPolicy{Retries: 2, Backoff: time.Millisecond}, d.retryPolicy)
+	assert.Equal(t, 2, calls)
+	// act & assert: other drivers based on the Driver also supports the option
+	assert.NotPanics(t, func() { _ = NewTCA9548ADriver(a, WithRetry(1, 0)) })
+}
@@ -25,6 +25,8 @@ import (
 	"time"
 
 	"github.com/sigurn/crc8"
+
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 const sht2xDefaultAddress = 0x40
@@ -145,6 +147,16 @@ func (d *SHT2xDriver) Temperature() (float32, error) {
 	return temp, nil
 }
 
+// TemperatureMeasurement returns the current temperature like Temperature(), but together with its unit.
+func (d *SHT2xDriver) TemperatureMeasurement() (measurement.Measurement, error) {
+	val, err := d.Temperature()
+	if err != nil {
+		return measurement.Measurement{}, err
+	}
+
+	return measurement.New(float64(val), measurement.Celsius), nil
+}
+
 // Humidity returns the current humidity in percentage of relative humidity
 func (d *SHT2xDriver) Humidity() (float32, error) {
 	rawH, err := d.readSensor(SHT2xTriggerHumdMeasureNohold)
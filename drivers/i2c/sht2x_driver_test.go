@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/common/measurement"
 )
 
 // this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
@@ -79,6 +80,10 @@ func TestSHT2xMeasurements(t *testing.T) {
 	temp, err := d.Temperature()
 	require.NoError(t, err)
 	assert.InDelta(t, float32(18.809052), temp, 0.0)
+	tempM, err := d.TemperatureMeasurement()
+	require.NoError(t, err)
+	assert.Equal(t, measurement.Celsius, tempM.Unit)
+	assert.InDelta(t, 18.809052, tempM.Value, 0.00001)
 	hum, err := d.Humidity()
 	require.NoError(t, err)
 	assert.InDelta(t, float32(40.279907), hum, 0.0)
@@ -0,0 +1,67 @@
+package i2c
+
+import (
+	"fmt"
+)
+
+const (
+	// SMBusBlockMaxLength is the maximum count of data bytes of a SMBus block transfer
+	SMBusBlockMaxLength = 32
+
+	smbusPECPolynomial = 0x07 // x^8 + x^2 + x + 1
+)
+
+// ErrSMBusBlockReadUnsupported is used when the connection is not able to read a SMBus block in a single transfer
+var ErrSMBusBlockReadUnsupported = fmt.Errorf("SMBus block read is not supported by this connection")
+
+// SMBusBlockReader is the optional interface of a connection for reading a SMBus block, see ReadSMBusBlockData()
+type SMBusBlockReader interface {
+	ReadSMBusBlockData(reg uint8, pec bool) ([]byte, error)
+}
+
+// SMBusPEC calculates the packet error code (CRC-8 with polynomial 0x07 and initial value 0) of the given bytes.
+// For a transfer, all bytes including the address bytes (address<<1 | r/w bit) are covered.
+func SMBusPEC(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = (crc << 1) ^ smbusPECPolynomial
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// ReadSMBusBlockData reads a SMBus block from the given register of the device. In contrast to ReadBlockData() of the
+// connection, the device determines the length of the block by the first byte of the response, which is not part of
+// the returned data. The block needs to be read in a single transfer with a repeated start, therefore the connection
+// must implement SMBusBlockReader. With pec, the packet error code sent by the device after the data is validated.
+func ReadSMBusBlockData(c Connection, reg uint8, pec bool) ([]byte, error) {
+	r, ok := c.(SMBusBlockReader)
+	if !ok {
+		return nil, ErrSMBusBlockReadUnsupported
+	}
+
+	return r.ReadSMBusBlockData(reg, pec)
+}
+
+// WriteSMBusBlockData writes the given data as SMBus block to the given register of the device with the given
+// address. The length of the block is written before the data. With pec, the packet error code is appended. The
+// address is needed for the calculation of the packet error code only.
+func WriteSMBusBlockData(c Connection, address int, reg uint8, data []byte, pec bool) error {
+	if len(data) == 0 || len(data) > SMBusBlockMaxLength {
+		return fmt.Errorf("invalid SMBus block length %d, must be between 1 and %d", len(data), SMBusBlockMaxLength)
+	}
+
+	buf := append([]byte{reg, byte(len(data))}, data...)
+	if pec {
+		buf = append(buf, SMBusPEC(append([]byte{byte(address << 1)}, buf...)))
+	}
+
+	return c.WriteBytes(buf)
+}
@@ -0,0 +1,132 @@
+package i2c
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMBusPEC(t *testing.T) {
+	// check value of CRC-8/SMBUS
+	assert.Equal(t, byte(0xF4), SMBusPEC([]byte("123456789")))
+	assert.Equal(t, byte(0x00), SMBusPEC(nil))
+}
+
+// smbusBlockTestConnection adds the single transfer SMBus block read to the test adaptor
+type smbusBlockTestConnection struct {
+	*i2cTestAdaptor
+	readSMBusBlockImpl func(reg uint8, pec bool) ([]byte, error)
+}
+
+func (c *smbusBlockTestConnection) ReadSMBusBlockData(reg uint8, pec bool) ([]byte, error) {
+	return c.readSMBusBlockImpl(reg, pec)
+}
+
+func TestReadSMBusBlockData(t *testing.T) {
+	tests := map[string]struct {
+		pec     bool
+		readErr error
+		want    []byte
+		wantErr string
+	}{
+		"without_pec": {
+			want: []byte("bat"),
+		},
+		"with_pec": {
+			pec:  true,
+			want: []byte("bat"),
+		},
+		"error_read": {
+			pec:     true,
+			readErr: fmt.Errorf("SMBus PEC mismatch"),
+			wantErr: "SMBus PEC mismatch",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			var gotReg uint8
+			var gotPEC bool
+			c := &smbusBlockTestConnection{
+				i2cTestAdaptor: newI2cTestAdaptor(),
+				readSMBusBlockImpl: func(reg uint8, pec bool) ([]byte, error) {
+					gotReg = reg
+					gotPEC = pec
+					if tc.readErr != nil {
+						return nil, tc.readErr
+					}
+					return []byte("bat"), nil
+				},
+			}
+			// act
+			got, err := ReadSMBusBlockData(c, 0x20, tc.pec)
+			// assert
+			assert.Equal(t, uint8(0x20), gotReg)
+			assert.Equal(t, tc.pec, gotPEC)
+			assert.Empty(t, c.written)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestReadSMBusBlockData_unsupported(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	// act
+	got, err := ReadSMBusBlockData(a, 0x20, true)
+	// assert
+	require.ErrorIs(t, err, ErrSMBusBlockReadUnsupported)
+	assert.Nil(t, got)
+	assert.Empty(t, a.written)
+}
+
+func TestWriteSMBusBlockData(t *testing.T) {
+	const address = 0x0B
+	tests := map[string]struct {
+		data        []byte
+		pec         bool
+		wantWritten []byte
+		wantErr     string
+	}{
+		"without_pec": {
+			data:        []byte{0x01, 0x02},
+			wantWritten: []byte{0x30, 0x02, 0x01, 0x02},
+		},
+		"with_pec": {
+			data:        []byte{0x01, 0x02},
+			pec:         true,
+			wantWritten: []byte{0x30, 0x02, 0x01, 0x02, SMBusPEC([]byte{address << 1, 0x30, 0x02, 0x01, 0x02})},
+		},
+		"error_empty": {
+			wantErr: "invalid SMBus block length 0, must be between 1 and 32",
+		},
+		"error_too_long": {
+			data:    make([]byte, 33),
+			wantErr: "invalid SMBus block length 33, must be between 1 and 32",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newI2cTestAdaptor()
+			// act
+			err := WriteSMBusBlockData(a, address, 0x30, tc.data, tc.pec)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Empty(t, a.written)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantWritten, a.written)
+		})
+	}
+}
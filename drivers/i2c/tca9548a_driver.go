@@ -0,0 +1,266 @@
+package i2c
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	tca9548aDefaultAddress = 0x70 // 0x70..0x77, selected by the pins A0..A2
+
+	// TCA9548AChannelCount is the count of downstream channels of the multiplexer
+	TCA9548AChannelCount = 8
+
+	tca9548aNoChannel = -1
+)
+
+// TCA9548ADriver is a driver for the TCA9548A 8-channel I2C multiplexer. This makes it possible to use identical
+// devices with the same address at one bus, e.g. eight sensors of the same type. Each device is connected to one of
+// the downstream channels of the multiplexer.
+//
+// Datasheet: https://www.ti.com/lit/ds/symlink/tca9548a.pdf
+//
+// A Connector for each channel is provided by Channel(). Drivers which uses this connector, selects the channel
+// transparently before each i2c operation. The multiplexer needs to be started before the connected drivers.
+type TCA9548ADriver struct {
+	*Driver
+	currentChannel int
+}
+
+// tca9548aChannel is the Connector for a downstream channel of the multiplexer
+type tca9548aChannel struct {
+	mux     *TCA9548ADriver
+	channel int
+}
+
+// tca9548aConnection is the connection to a device at a downstream channel of the multiplexer
+type tca9548aConnection struct {
+	channel    *tca9548aChannel
+	connection Connection
+}
+
+// NewTCA9548ADriver creates a new driver with specified i2c interface
+// Params:
+//
+//	c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//
+//	i2c.WithBus(int):	bus to use with this driver
+//	i2c.WithAddress(int):	address to use with this driver
+func NewTCA9548ADriver(c Connector, options ...func(Config)) *TCA9548ADriver {
+	d := &TCA9548ADriver{
+		Driver:         NewDriver(c, "TCA9548A", tca9548aDefaultAddress, options...),
+		currentChannel: tca9548aNoChannel,
+	}
+	d.afterStart = d.initialize
+
+	d.AddCommand("SelectChannel", func(params map[string]interface{}) interface{} {
+		channel, _ := strconv.Atoi(fmt.Sprintf("%v", params["channel"]))
+		err := d.SelectChannel(channel)
+		return map[string]interface{}{"err": err}
+	})
+
+	return d
+}
+
+// Channel returns the Connector for the given downstream channel (0..7). Drivers, which are created with this
+// connector, are attached to the channel transparently. The bus of the multiplexer is always used, independent of
+// the bus given to the driver.
+func (d *TCA9548ADriver) Channel(channel int) Connector {
+	if channel < 0 || channel >= TCA9548AChannelCount {
+		panic(fmt.Sprintf("channel (%d) of '%s' must be between 0 and %d", channel, d.name, TCA9548AChannelCount-1))
+	}
+
+	return &tca9548aChannel{mux: d, channel: channel}
+}
+
+// SelectChannel activates the given downstream channel (0..7). All other channels are deactivated. Normally there is
+// no need to call this function, because it is called by the connections of Channel() automatically.
+func (d *TCA9548ADriver) SelectChannel(channel int) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.selectChannel(channel)
+}
+
+// Disable deactivates all downstream channels.
+func (d *TCA9548ADriver) Disable() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeControl(0x00, tca9548aNoChannel)
+}
+
+func (d *TCA9548ADriver) initialize() error {
+	// the state of the multiplexer is unknown, so deactivate all channels
+	return d.writeControl(0x00, tca9548aNoChannel)
+}
+
+// selectChannel writes the control register, if not already selected. The mutex needs to be locked by the caller.
+func (d *TCA9548ADriver) selectChannel(channel int) error {
+	if channel < 0 || channel >= TCA9548AChannelCount {
+		return fmt.Errorf("channel (%d) of '%s' must be between 0 and %d", channel, d.name, TCA9548AChannelCount-1)
+	}
+
+	if channel == d.currentChannel {
+		return nil
+	}
+
+	return d.writeControl(1<<uint(channel), channel)
+}
+
+func (d *TCA9548ADriver) writeControl(val byte, channel int) error {
+	if d.connection == nil {
+		return fmt.Errorf("'%s' is not started", d.name)
+	}
+
+	if err := d.connection.WriteByte(val); err != nil {
+		d.currentChannel = tca9548aNoChannel
+		return err
+	}
+
+	d.currentChannel = channel
+	return nil
+}
+
+// GetI2cConnection implements the Connector interface. The connection is created at the bus of the multiplexer.
+func (c *tca9548aChannel) GetI2cConnection(address int, _ int) (Connection, error) {
+	mux := c.mux
+	mux.mutex.Lock()
+	defer mux.mutex.Unlock()
+
+	if mux.connection == nil {
+		return nil, fmt.Errorf("'%s' needs to be started before the connection to channel %d", mux.name, c.channel)
+	}
+
+	bus := mux.GetBusOrDefault(mux.connector.DefaultI2cBus())
+	connection, err := mux.connector.GetI2cConnection(address, bus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tca9548aConnection{channel: c, connection: connection}, nil
+}
+
+// DefaultI2cBus implements the Connector interface and returns the bus of the multiplexer.
+func (c *tca9548aChannel) DefaultI2cBus() int {
+	return c.mux.GetBusOrDefault(c.mux.connector.DefaultI2cBus())
+}
+
+// Name implements the gobot.Connection interface.
+func (c *tca9548aChannel) Name() string {
+	return fmt.Sprintf("%s-channel%d", c.mux.name, c.channel)
+}
+
+// SetName implements the gobot.Connection interface, the name is derived from the multiplexer, so nothing to do.
+func (c *tca9548aChannel) SetName(string) {}
+
+// Connect implements the gobot.Connection interface, the connection is done by the multiplexer.
+func (c *tca9548aChannel) Connect() error { return nil }
+
+// Finalize implements the gobot.Connection interface, the finalization is done by the multiplexer.
+func (c *tca9548aChannel) Finalize() error { return nil }
+
+// do selects the channel and calls the given function, both protected by the mutex of the multiplexer
+func (c *tca9548aConnection) do(f func() error) error {
+	mux := c.channel.mux
+	mux.mutex.Lock()
+	defer mux.mutex.Unlock()
+
+	if err := mux.selectChannel(c.channel.channel); err != nil {
+		return err
+	}
+
+	return f()
+}
+
+// Read implements the Connection interface.
+func (c *tca9548aConnection) Read(data []byte) (int, error) {
+	var n int
+	err := c.do(func() error {
+		var err error
+		n, err = c.connection.Read(data)
+		return err
+	})
+	return n, err
+}
+
+// Write implements the Connection interface.
+func (c *tca9548aConnection) Write(data []byte) (int, error) {
+	var n int
+	err := c.do(func() error {
+		var err error
+		n, err = c.connection.Write(data)
+		return err
+	})
+	return n, err
+}
+
+// Close implements the Connection interface.
+func (c *tca9548aConnection) Close() error {
+	return c.connection.Close()
+}
+
+// ReadByte implements the Connection interface.
+func (c *tca9548aConnection) ReadByte() (byte, error) {
+	var val byte
+	err := c.do(func() error {
+		var err error
+		val, err = c.connection.ReadByte()
+		return err
+	})
+	return val, err
+}
+
+// ReadByteData implements the Connection interface.
+func (c *tca9548aConnection) ReadByteData(reg uint8) (uint8, error) {
+	var val uint8
+	err := c.do(func() error {
+		var err error
+		val, err = c.connection.ReadByteData(reg)
+		return err
+	})
+	return val, err
+}
+
+// ReadWordData implements the Connection interface.
+func (c *tca9548aConnection) ReadWordData(reg uint8) (uint16, error) {
+	var val uint16
+	err := c.do(func() error {
+		var err error
+		val, err = c.connection.ReadWordData(reg)
+		return err
+	})
+	return val, err
+}
+
+// ReadBlockData implements the Connection interface.
+func (c *tca9548aConnection) ReadBlockData(reg uint8, b []byte) error {
+	return c.do(func() error { return c.connection.ReadBlockData(reg, b) })
+}
+
+// WriteByte implements the Connection interface.
+func (c *tca9548aConnection) WriteByte(val byte) error {
+	return c.do(func() error { return c.connection.WriteByte(val) })
+}
+
+// WriteByteData implements the Connection interface.
+func (c *tca9548aConnection) WriteByteData(reg uint8, val uint8) error {
+	return c.do(func() error { return c.connection.WriteByteData(reg, val) })
+}
+
+// WriteWordData implements the Connection interface.
+func (c *tca9548aConnection) WriteWordData(reg uint8, val uint16) error {
+	return c.do(func() error { return c.connection.WriteWordData(reg, val) })
+}
+
+// WriteBlockData implements the Connection interface.
+func (c *tca9548aConnection) WriteBlockData(reg uint8, b []byte) error {
+	return c.do(func() error { return c.connection.WriteBlockData(reg, b) })
+}
+
+// WriteBytes implements the Connection interface.
+func (c *tca9548aConnection) WriteBytes(b []byte) error {
+	return c.do(func() error { return c.connection.WriteBytes(b) })
+}
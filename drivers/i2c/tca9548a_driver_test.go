@@ -0,0 +1,206 @@
+package i2c
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*TCA9548ADriver)(nil)
+
+// the channel connector needs to be a gobot.Connection for the usage by drivers
+var _ gobot.Connection = (*tca9548aChannel)(nil)
+
+func initTestTCA9548AWithStubbedAdaptor() (*TCA9548ADriver, *i2cTestAdaptor) {
+	a := newI2cTestAdaptor()
+	d := NewTCA9548ADriver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a
+}
+
+func TestNewTCA9548ADriver(t *testing.T) {
+	// arrange, act
+	var di interface{} = NewTCA9548ADriver(newI2cTestAdaptor())
+	// assert
+	d, ok := di.(*TCA9548ADriver)
+	if !ok {
+		t.Errorf("NewTCA9548ADriver() should have returned a *TCA9548ADriver")
+	}
+	assert.NotNil(t, d.Driver)
+	assert.True(t, strings.HasPrefix(d.Name(), "TCA9548A"))
+	assert.Equal(t, 0x70, d.defaultAddress)
+	assert.Equal(t, -1, d.currentChannel)
+	assert.NotNil(t, d.Command("SelectChannel"))
+}
+
+func TestTCA9548AOptions(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewTCA9548ADriver(newI2cTestAdaptor(), WithBus(2), WithAddress(0x77))
+	assert.Equal(t, 2, d.GetBusOrDefault(1))
+	assert.Equal(t, 0x77, d.GetAddressOrDefault(0x70))
+	assert.Equal(t, 2, d.Channel(0).DefaultI2cBus())
+}
+
+func TestTCA9548AStart(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewTCA9548ADriver(a)
+	// act
+	err := d.Start()
+	// assert: all channels are deactivated
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00}, a.written)
+	assert.Equal(t, -1, d.currentChannel)
+}
+
+func TestTCA9548AChannel_selectPrecedesOperations(t *testing.T) {
+	// arrange
+	d, a := initTestTCA9548AWithStubbedAdaptor()
+	sensor3 := NewDriver(d.Channel(3), "sensor3", 0x23)
+	sensor5 := NewDriver(d.Channel(5), "sensor5", 0x23)
+	require.NoError(t, sensor3.Start())
+	require.NoError(t, sensor5.Start())
+	a.written = nil
+	// act & assert: the select byte precedes the first operation
+	require.NoError(t, sensor3.Write("1", 0xAA))
+	assert.Equal(t, []byte{0x08, 0x01, 0xAA}, a.written)
+	assert.Equal(t, 0x23, a.address)
+	// act & assert: no select needed for same channel
+	a.written = nil
+	require.NoError(t, sensor3.Write("2", 0xBB))
+	assert.Equal(t, []byte{0x02, 0xBB}, a.written)
+	// act & assert: select of other channel
+	a.written = nil
+	val, err := sensor5.Read("4")
+	require.NoError(t, err)
+	assert.Equal(t, 0, val)
+	assert.Equal(t, []byte{0x20, 0x04}, a.written)
+	// act & assert: select again after manual deactivation
+	require.NoError(t, d.Disable())
+	a.written = nil
+	require.NoError(t, sensor5.Write("3", 0xCC))
+	assert.Equal(t, []byte{0x20, 0x03, 0xCC}, a.written)
+}
+
+func TestTCA9548AChannel_concurrentAccess(t *testing.T) {
+	// arrange
+	d, a := initTestTCA9548AWithStubbedAdaptor()
+	type writeCall []byte
+	var calls []writeCall
+	a.Testi2cWriteImpl(func(b []byte) (int, error) {
+		calls = append(calls, append(writeCall{}, b...))
+		return len(b), nil
+	})
+	var sensors []*Driver
+	for ch := 0; ch < TCA9548AChannelCount; ch++ {
+		sensor := NewDriver(d.Channel(ch), "sensor", 0x40)
+		require.NoError(t, sensor.Start())
+		sensors = append(sensors, sensor)
+	}
+	// act: each sensor writes its channel number concurrently
+	var wg sync.WaitGroup
+	for ch, sensor := range sensors {
+		wg.Add(1)
+		go func(ch int, sensor *Driver) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				assert.NoError(t, sensor.Write("1", ch))
+			}
+		}(ch, sensor)
+	}
+	wg.Wait()
+	// assert: each data write was done with the correct channel selected
+	selected := -1
+	var dataWrites int
+	for _, call := range calls {
+		if len(call) == 1 {
+			// select channel
+			selected = -1
+			for ch := 0; ch < TCA9548AChannelCount; ch++ {
+				if call[0] == 1<<uint(ch) {
+					selected = ch
+				}
+			}
+			continue
+		}
+		require.Len(t, call, 2)
+		assert.Equal(t, byte(selected), call[1])
+		dataWrites++
+	}
+	assert.Equal(t, TCA9548AChannelCount*20, dataWrites)
+}
+
+func TestTCA9548ASelectChannel(t *testing.T) {
+	tests := map[string]struct {
+		channel     int
+		wantWritten []byte
+		wantErr     string
+	}{
+		"channel_0": {
+			channel:     0,
+			wantWritten: []byte{0x01},
+		},
+		"channel_7": {
+			channel:     7,
+			wantWritten: []byte{0x80},
+		},
+		"error_negative": {
+			channel: -1,
+			wantErr: "channel (-1) of 'TCA9548A",
+		},
+		"error_too_big": {
+			channel: 8,
+			wantErr: "must be between 0 and 7",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestTCA9548AWithStubbedAdaptor()
+			a.written = nil
+			// act
+			err := d.SelectChannel(tc.channel)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.channel, d.currentChannel)
+			}
+			assert.Equal(t, tc.wantWritten, a.written)
+		})
+	}
+}
+
+func TestTCA9548AChannel_errors(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewTCA9548ADriver(a)
+	sensor := NewDriver(d.Channel(1), "sensor", 0x23)
+	// act & assert: multiplexer not started
+	require.ErrorContains(t, sensor.Start(), "needs to be started before the connection to channel 1")
+	require.ErrorContains(t, d.SelectChannel(1), "is not started")
+	// act & assert: invalid channel
+	assert.PanicsWithValue(t, "channel (8) of '"+d.Name()+"' must be between 0 and 7", func() { d.Channel(8) })
+	// act & assert: select error, the channel is selected again on next operation
+	require.NoError(t, d.Start())
+	require.NoError(t, sensor.Start())
+	a.Testi2cWriteImpl(func(b []byte) (int, error) {
+		if len(b) == 1 {
+			return 0, assert.AnError
+		}
+		return len(b), nil
+	})
+	require.ErrorIs(t, sensor.Write("1", 2), assert.AnError)
+	assert.Equal(t, -1, d.currentChannel)
+}
@@ -458,7 +458,7 @@ func (d *TSL2561Driver) initialize() error {
 	if initialized, err := d.connection.ReadByteData(tsl2561RegisterID); err != nil {
 		return err
 	} else if (initialized & 0x0A) == 0 {
-		return fmt.Errorf("TSL2561 device not found (0x%X)", initialized)
+		return fmt.Errorf("%w: '%s' has an invalid ID register (0x%X)", ErrDeviceNotFound, d.name, initialized)
 	}
 
 	if err := d.SetIntegrationTime(d.integrationTime); err != nil {
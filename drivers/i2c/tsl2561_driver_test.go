@@ -74,7 +74,9 @@ func TestTSL2561DriverStartNotFound(t *testing.T) {
 		copy(b, buf.Bytes())
 		return buf.Len(), nil
 	}
-	require.ErrorContains(t, d.Start(), "TSL2561 device not found (0x1)")
+	err := d.Start()
+	require.ErrorIs(t, err, ErrDeviceNotFound)
+	require.ErrorContains(t, err, "invalid ID register (0x1)")
 }
 
 func TestTSL2561DriverHalt(t *testing.T) {
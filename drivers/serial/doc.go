@@ -0,0 +1,11 @@
+/*
+Package serial provides Gobot drivers for devices connected by a serial port (UART).
+
+Installing:
+
+	Please refer to the main [README.md](https://github.com/hybridgroup/gobot/blob/release/README.md)
+
+For further information refer to serial README:
+https://github.com/hybridgroup/gobot/blob/master/drivers/serial/README.md
+*/
+package serial // import "gobot.io/x/gobot/v2/drivers/serial"
@@ -0,0 +1,65 @@
+package serial
+
+import (
+	"sync"
+	"time"
+)
+
+type serialTestBareAdaptor struct{}
+
+func (t *serialTestBareAdaptor) Connect() error   { return nil }
+func (t *serialTestBareAdaptor) Finalize() error  { return nil }
+func (t *serialTestBareAdaptor) Name() string     { return "bare" }
+func (t *serialTestBareAdaptor) SetName(n string) {}
+
+type serialTestAdaptor struct {
+	name           string
+	mtx            sync.Mutex
+	chunks         chan []byte
+	pending        []byte
+	serialReadFunc func(b []byte) (int, error)
+	written        []byte
+}
+
+func newSerialTestAdaptor() *serialTestAdaptor {
+	t := serialTestAdaptor{
+		name:   "serial_test_adaptor",
+		chunks: make(chan []byte, 10),
+	}
+	t.serialReadFunc = func(b []byte) (int, error) {
+		if len(t.pending) == 0 {
+			select {
+			case t.pending = <-t.chunks:
+			case <-time.After(time.Millisecond):
+				return 0, nil
+			}
+		}
+		n := copy(b, t.pending)
+		t.pending = t.pending[n:]
+		return n, nil
+	}
+
+	return &t
+}
+
+// SerialRead capabilities (interface SerialReader)
+func (t *serialTestAdaptor) SerialRead(b []byte) (int, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	return t.serialReadFunc(b)
+}
+
+// SerialWrite capabilities (interface SerialWriter)
+func (t *serialTestAdaptor) SerialWrite(b []byte) (int, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.written = append(t.written, b...)
+	return len(b), nil
+}
+
+func (t *serialTestAdaptor) Connect() error   { return nil }
+func (t *serialTestAdaptor) Finalize() error  { return nil }
+func (t *serialTestAdaptor) Name() string     { return t.name }
+func (t *serialTestAdaptor) SetName(n string) { t.name = n }
@@ -0,0 +1,210 @@
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	lineReaderDefaultDelimiter = '\n'
+	lineReaderDefaultMaxLength = 1024
+	lineReaderReadBufferSize   = 256
+	lineReaderIdleDelay        = 10 * time.Millisecond
+)
+
+// ErrLineTooLong is published with the "error" event, if the maximum length is exceeded before the delimiter is
+// received. The data is discarded until the next delimiter.
+var ErrLineTooLong = errors.New("line exceeds the maximum length")
+
+// lineReaderOptionApplier needs to be implemented by each configurable option type
+type lineReaderOptionApplier interface {
+	apply(cfg *lineReaderConfiguration)
+}
+
+// lineReaderConfiguration contains all changeable attributes of the driver.
+type lineReaderConfiguration struct {
+	delimiter byte
+	maxLength int
+}
+
+// lineReaderDelimiterOption is the type for applying another delimiter to the configuration
+type lineReaderDelimiterOption byte
+
+// lineReaderMaxLengthOption is the type for applying another maximum line length to the configuration
+type lineReaderMaxLengthOption int
+
+// LineReaderDriver represents a generic driver for serial devices, which emit frames terminated by a delimiter, e.g.
+// NMEA sentences of a GPS receiver or the output of a microcontroller.
+type LineReaderDriver struct {
+	*driver
+	lineCfg *lineReaderConfiguration
+	halt    chan struct{}
+	gobot.Eventer
+	buffer     []byte
+	discarding bool
+}
+
+// NewLineReaderDriver creates a new driver, which reads the data from the given SerialReader and emits a "line" event
+// for each received delimiter. The default delimiter is a newline, in this case a trailing carriage return is removed
+// from the line, so "\r\n" terminated lines are supported too. The default maximum length of a line is 1024 bytes.
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithLineDelimiter"
+//	"WithLineMaxLength"
+func NewLineReaderDriver(a SerialReader, opts ...interface{}) *LineReaderDriver {
+	d := &LineReaderDriver{
+		driver: newDriver(a, "LineReader"),
+		lineCfg: &lineReaderConfiguration{
+			delimiter: lineReaderDefaultDelimiter,
+			maxLength: lineReaderDefaultMaxLength,
+		},
+		Eventer: gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case lineReaderOptionApplier:
+			o.apply(d.lineCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	d.AddEvent(Line)
+	d.AddEvent(Error)
+
+	return d
+}
+
+// WithLineDelimiter substitute the default delimiter (newline) by the given byte, e.g. 0x00 for zero terminated
+// frames. A carriage return is only removed for the newline delimiter.
+func WithLineDelimiter(delimiter byte) lineReaderOptionApplier {
+	return lineReaderDelimiterOption(delimiter)
+}
+
+// WithLineMaxLength change the maximum length of a line (without delimiter) from default 1024 bytes to the given
+// value. A longer line is discarded and an error event with ErrLineTooLong is published.
+func WithLineMaxLength(maxLength int) lineReaderOptionApplier {
+	return lineReaderMaxLengthOption(maxLength)
+}
+
+// initialize starts the reading of the serial data.
+// Emits the Events:
+//
+//	Line string - Event is emitted for each received delimiter and contains the line without delimiter.
+//	Error error - Event is emitted on error reading from the device or if the maximum length of a line is exceeded.
+func (d *LineReaderDriver) initialize() error {
+	if d.lineCfg.maxLength < 1 {
+		return fmt.Errorf("maximum line length (%d) must be greater than zero for '%s'", d.lineCfg.maxLength,
+			d.driverCfg.name)
+	}
+
+	reader, ok := d.connection.(SerialReader)
+	if !ok {
+		return fmt.Errorf("SerialRead is not supported by the platform '%s'", d.Connection().Name())
+	}
+
+	d.buffer = make([]byte, 0, d.lineCfg.maxLength)
+	d.discarding = false
+	halt := make(chan struct{})
+	d.halt = halt
+
+	go func() {
+		data := make([]byte, lineReaderReadBufferSize)
+		for {
+			select {
+			case <-halt:
+				return
+			default:
+			}
+
+			n, err := reader.SerialRead(data)
+			if n > 0 {
+				d.process(data[:n])
+			}
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+			}
+
+			if n == 0 || err != nil {
+				// prevent a busy loop for non-blocking readers or a permanent error
+				select {
+				case <-time.After(lineReaderIdleDelay):
+				case <-halt:
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// shutdown stops the reading of the serial data.
+func (d *LineReaderDriver) shutdown() error {
+	if d.halt == nil {
+		return nil
+	}
+
+	close(d.halt)
+	d.halt = nil
+	return nil
+}
+
+// process splits the given chunk of data into lines. An incomplete line is buffered until the next chunk arrives.
+func (d *LineReaderDriver) process(data []byte) {
+	for _, b := range data {
+		if b == d.lineCfg.delimiter {
+			if d.discarding {
+				d.discarding = false
+			} else {
+				d.Publish(d.Event(Line), d.line())
+			}
+			d.buffer = d.buffer[:0]
+			continue
+		}
+
+		if d.discarding {
+			continue
+		}
+
+		if len(d.buffer) >= d.lineCfg.maxLength {
+			d.Publish(d.Event(Error), fmt.Errorf("%w (%d) for '%s'", ErrLineTooLong, d.lineCfg.maxLength,
+				d.driverCfg.name))
+			d.discarding = true
+			d.buffer = d.buffer[:0]
+			continue
+		}
+
+		d.buffer = append(d.buffer, b)
+	}
+}
+
+// line returns the buffered line as string, for the newline delimiter a trailing carriage return is removed.
+func (d *LineReaderDriver) line() string {
+	line := d.buffer
+	if d.lineCfg.delimiter == '\n' && len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+
+	return string(line)
+}
+
+// apply change the delimiter in the configuration.
+func (o lineReaderDelimiterOption) apply(c *lineReaderConfiguration) {
+	c.delimiter = byte(o)
+}
+
+// apply change the maximum line length in the configuration.
+func (o lineReaderMaxLengthOption) apply(c *lineReaderConfiguration) {
+	c.maxLength = int(o)
+}
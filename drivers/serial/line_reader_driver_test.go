@@ -0,0 +1,180 @@
+package serial
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*LineReaderDriver)(nil)
+
+func TestNewLineReaderDriver(t *testing.T) {
+	// arrange
+	a := newSerialTestAdaptor()
+	// act
+	d := NewLineReaderDriver(a)
+	// assert
+	assert.IsType(t, &LineReaderDriver{}, d)
+	assert.True(t, strings.HasPrefix(d.Name(), "LineReader"))
+	assert.Equal(t, a, d.Connection())
+	assert.Equal(t, byte('\n'), d.lineCfg.delimiter)
+	assert.Equal(t, 1024, d.lineCfg.maxLength)
+	assert.Equal(t, Line, d.Event(Line))
+	assert.Equal(t, Error, d.Event(Error))
+}
+
+func TestNewLineReaderDriver_options(t *testing.T) {
+	// arrange
+	a := newSerialTestAdaptor()
+	// act
+	d := NewLineReaderDriver(a, WithName("gps"), WithLineDelimiter(0x00), WithLineMaxLength(82))
+	// assert
+	assert.Equal(t, "gps", d.Name())
+	assert.Equal(t, byte(0x00), d.lineCfg.delimiter)
+	assert.Equal(t, 82, d.lineCfg.maxLength)
+	assert.PanicsWithValue(t, "'unknown' can not be applied on 'LineReader'", func() {
+		_ = NewLineReaderDriver(a, WithName("LineReader"), "unknown")
+	})
+}
+
+func TestLineReaderProcess(t *testing.T) {
+	tests := map[string]struct {
+		opts       []interface{}
+		chunks     []string
+		wantLines  []string
+		wantErrors int
+	}{
+		"single_chunk": {
+			chunks:    []string{"first\nsecond\n"},
+			wantLines: []string{"first", "second"},
+		},
+		"chunks_across_frame_boundaries": {
+			chunks:    []string{"$GPGGA,12", "3519,48", "07.038,N\n$GPR", "MC,1\n", "\n"},
+			wantLines: []string{"$GPGGA,123519,4807.038,N", "$GPRMC,1", ""},
+		},
+		"carriage_return_removed": {
+			chunks:    []string{"first\r", "\nsecond\r\n"},
+			wantLines: []string{"first", "second"},
+		},
+		"incomplete_line_buffered": {
+			chunks:    []string{"complete\nincomp", "lete"},
+			wantLines: []string{"complete"},
+		},
+		"custom_delimiter": {
+			opts:      []interface{}{WithLineDelimiter(';')},
+			chunks:    []string{"a\r;b", "c;d\n;"},
+			wantLines: []string{"a\r", "bc", "d\n"},
+		},
+		"max_length_exceeded": {
+			opts:       []interface{}{WithLineMaxLength(4)},
+			chunks:     []string{"1234\n123", "45678", "9\n56\n"},
+			wantLines:  []string{"1234", "56"},
+			wantErrors: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewLineReaderDriver(newSerialTestAdaptor(), tc.opts...)
+			events := d.Subscribe()
+			defer d.Unsubscribe(events)
+			// act
+			for _, chunk := range tc.chunks {
+				d.process([]byte(chunk))
+			}
+			// assert
+			var gotLines []string
+			var gotErrors int
+			for len(gotLines)+gotErrors < len(tc.wantLines)+tc.wantErrors {
+				select {
+				case evt := <-events:
+					switch evt.Name {
+					case Line:
+						gotLines = append(gotLines, evt.Data.(string))
+					case Error:
+						require.ErrorIs(t, evt.Data.(error), ErrLineTooLong)
+						gotErrors++
+					}
+				case <-time.After(time.Second):
+					require.Fail(t, "events not published")
+				}
+			}
+			assert.Equal(t, tc.wantLines, gotLines)
+			assert.Equal(t, tc.wantErrors, gotErrors)
+		})
+	}
+}
+
+func TestLineReaderStart(t *testing.T) {
+	// arrange
+	a := newSerialTestAdaptor()
+	d := NewLineReaderDriver(a)
+	lines := make(chan string, 5)
+	_ = d.On(d.Event(Line), func(data interface{}) {
+		lines <- data.(string)
+	})
+	// act
+	require.NoError(t, d.Start())
+	a.chunks <- []byte("hel")
+	a.chunks <- []byte("lo\nwor")
+	a.chunks <- []byte("ld\n")
+	// assert
+	for _, want := range []string{"hello", "world"} {
+		select {
+		case got := <-lines:
+			assert.Equal(t, want, got)
+		case <-time.After(time.Second):
+			require.Fail(t, "line event was not published")
+		}
+	}
+	require.NoError(t, d.Halt())
+}
+
+func TestLineReaderStart_readError(t *testing.T) {
+	// arrange
+	a := newSerialTestAdaptor()
+	a.serialReadFunc = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	d := NewLineReaderDriver(a)
+	errs := make(chan error, 1)
+	_ = d.Once(d.Event(Error), func(data interface{}) {
+		errs <- data.(error)
+	})
+	// act
+	require.NoError(t, d.Start())
+	// assert
+	select {
+	case err := <-errs:
+		require.EqualError(t, err, "read error")
+	case <-time.After(time.Second):
+		require.Fail(t, "error event was not published")
+	}
+	require.NoError(t, d.Halt())
+}
+
+func TestLineReaderStart_error(t *testing.T) {
+	// arrange
+	dMaxLength := NewLineReaderDriver(newSerialTestAdaptor(), WithName("LineReader"), WithLineMaxLength(0))
+	dNoReader := NewLineReaderDriver(nil)
+	dNoReader.connection = &serialTestBareAdaptor{}
+	// act & assert
+	require.EqualError(t, dMaxLength.Start(), "maximum line length (0) must be greater than zero for 'LineReader'")
+	require.EqualError(t, dNoReader.Start(), "SerialRead is not supported by the platform 'bare'")
+}
+
+func TestLineReaderHalt(t *testing.T) {
+	// arrange
+	d := NewLineReaderDriver(newSerialTestAdaptor())
+	// act & assert
+	require.NoError(t, d.Halt())
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Halt())
+	require.NoError(t, d.Halt())
+}
@@ -0,0 +1,425 @@
+package serial
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+const (
+	modbusDefaultSlaveID = 1
+	modbusDefaultTimeout = time.Second
+	modbusReadDelay      = time.Millisecond
+
+	modbusMaxReadBits       = 2000
+	modbusMaxReadRegisters  = 125
+	modbusMaxWriteBits      = 1968
+	modbusMaxWriteRegisters = 123
+)
+
+// Modbus function codes
+const (
+	ModbusReadCoils              = 0x01
+	ModbusReadDiscreteInputs     = 0x02
+	ModbusReadHoldingRegisters   = 0x03
+	ModbusReadInputRegisters     = 0x04
+	ModbusWriteSingleCoil        = 0x05
+	ModbusWriteSingleRegister    = 0x06
+	ModbusWriteMultipleCoils     = 0x0F
+	ModbusWriteMultipleRegisters = 0x10
+)
+
+var modbusExceptionNames = map[byte]string{
+	0x01: "illegal function",
+	0x02: "illegal data address",
+	0x03: "illegal data value",
+	0x04: "slave device failure",
+	0x05: "acknowledge",
+	0x06: "slave device busy",
+	0x08: "memory parity error",
+	0x0A: "gateway path unavailable",
+	0x0B: "gateway target device failed to respond",
+}
+
+// SerialReadWriter interface represents an Adaptor which has SerialRead and SerialWrite capabilities
+type SerialReadWriter interface {
+	SerialReader
+	SerialWriter
+}
+
+// ModbusError is returned, if the slave responds with an exception.
+type ModbusError struct {
+	Function byte
+	Code     byte
+}
+
+// Error implements the error interface.
+func (e *ModbusError) Error() string {
+	name, ok := modbusExceptionNames[e.Code]
+	if !ok {
+		name = "unknown exception"
+	}
+
+	return fmt.Sprintf("modbus exception 0x%02X (%s) for function 0x%02X", e.Code, name, e.Function)
+}
+
+// modbusOptionApplier needs to be implemented by each configurable option type
+type modbusOptionApplier interface {
+	apply(cfg *modbusConfiguration)
+}
+
+// modbusConfiguration contains all changeable attributes of the driver.
+type modbusConfiguration struct {
+	slaveID byte
+	timeout time.Duration
+}
+
+// modbusSlaveIDOption is the type for applying another slave ID to the configuration
+type modbusSlaveIDOption byte
+
+// modbusTimeoutOption is the type for applying another response timeout to the configuration
+type modbusTimeoutOption time.Duration
+
+// ModbusRTUDriver is a Modbus RTU master for devices connected by a serial line, e.g. with a RS-485 transceiver.
+// The data and coil addresses are the zero based addresses of the protocol.
+type ModbusRTUDriver struct {
+	*driver
+	modbusCfg *modbusConfiguration
+}
+
+// NewModbusRTUDriver creates a new Modbus RTU master for the given SerialReadWriter. The default slave ID is 1 and the
+// default response timeout is 1 second. The serial port needs to be configured by the adaptor (e.g. 9600 baud, 8N1).
+//
+// Supported options:
+//
+//	"WithName"
+//	"WithModbusSlaveID"
+//	"WithModbusTimeout"
+func NewModbusRTUDriver(a SerialReadWriter, opts ...interface{}) *ModbusRTUDriver {
+	d := &ModbusRTUDriver{
+		driver: newDriver(a, "ModbusRTU"),
+		modbusCfg: &modbusConfiguration{
+			slaveID: modbusDefaultSlaveID,
+			timeout: modbusDefaultTimeout,
+		},
+	}
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case optionApplier:
+			o.apply(d.driverCfg)
+		case modbusOptionApplier:
+			o.apply(d.modbusCfg)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on '%s'", opt, d.driverCfg.name))
+		}
+	}
+
+	return d
+}
+
+// WithModbusSlaveID change the slave ID from default 1 to the given value. With 0 the request is a broadcast, so only
+// the write functions can be used and no response is expected.
+func WithModbusSlaveID(id byte) modbusOptionApplier {
+	return modbusSlaveIDOption(id)
+}
+
+// WithModbusTimeout change the timeout for the response of the slave from default 1 second to the given value.
+func WithModbusTimeout(timeout time.Duration) modbusOptionApplier {
+	return modbusTimeoutOption(timeout)
+}
+
+// SetSlaveID changes the slave ID for the next requests, e.g. to use the same bus for many devices.
+func (d *ModbusRTUDriver) SetSlaveID(id byte) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	WithModbusSlaveID(id).apply(d.modbusCfg)
+}
+
+// SlaveID returns the slave ID, which is used for the requests.
+func (d *ModbusRTUDriver) SlaveID() byte {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.modbusCfg.slaveID
+}
+
+// ReadCoils reads the given count of coils (function 0x01), starting at the given address.
+func (d *ModbusRTUDriver) ReadCoils(addr, count uint16) ([]bool, error) {
+	return d.readBits(ModbusReadCoils, addr, count)
+}
+
+// ReadDiscreteInputs reads the given count of discrete inputs (function 0x02), starting at the given address.
+func (d *ModbusRTUDriver) ReadDiscreteInputs(addr, count uint16) ([]bool, error) {
+	return d.readBits(ModbusReadDiscreteInputs, addr, count)
+}
+
+// ReadHoldingRegisters reads the given count of holding registers (function 0x03), starting at the given address.
+func (d *ModbusRTUDriver) ReadHoldingRegisters(addr, count uint16) ([]uint16, error) {
+	return d.readRegisters(ModbusReadHoldingRegisters, addr, count)
+}
+
+// ReadInputRegisters reads the given count of input registers (function 0x04), starting at the given address.
+func (d *ModbusRTUDriver) ReadInputRegisters(addr, count uint16) ([]uint16, error) {
+	return d.readRegisters(ModbusReadInputRegisters, addr, count)
+}
+
+// WriteSingleCoil writes the coil at the given address (function 0x05).
+func (d *ModbusRTUDriver) WriteSingleCoil(addr uint16, value bool) error {
+	var val uint16
+	if value {
+		val = 0xFF00
+	}
+
+	return d.writeSingle(ModbusWriteSingleCoil, addr, val)
+}
+
+// WriteSingleRegister writes the holding register at the given address (function 0x06).
+func (d *ModbusRTUDriver) WriteSingleRegister(addr, value uint16) error {
+	return d.writeSingle(ModbusWriteSingleRegister, addr, value)
+}
+
+// WriteMultipleCoils writes the given values to the coils (function 0x0F), starting at the given address.
+func (d *ModbusRTUDriver) WriteMultipleCoils(addr uint16, values []bool) error {
+	if len(values) < 1 || len(values) > modbusMaxWriteBits {
+		return fmt.Errorf("count of coils (%d) must be between 1 and %d", len(values), modbusMaxWriteBits)
+	}
+
+	data := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v {
+			data[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	return d.writeMultiple(ModbusWriteMultipleCoils, addr, uint16(len(values)), data)
+}
+
+// WriteMultipleRegisters writes the given values to the holding registers (function 0x10), starting at the given
+// address.
+func (d *ModbusRTUDriver) WriteMultipleRegisters(addr uint16, values []uint16) error {
+	if len(values) < 1 || len(values) > modbusMaxWriteRegisters {
+		return fmt.Errorf("count of registers (%d) must be between 1 and %d", len(values), modbusMaxWriteRegisters)
+	}
+
+	data := make([]byte, 2*len(values))
+	for i, v := range values {
+		binary.BigEndian.PutUint16(data[2*i:], v)
+	}
+
+	return d.writeMultiple(ModbusWriteMultipleRegisters, addr, uint16(len(values)), data)
+}
+
+func (d *ModbusRTUDriver) readBits(function byte, addr, count uint16) ([]bool, error) {
+	if count < 1 || count > modbusMaxReadBits {
+		return nil, fmt.Errorf("count of bits (%d) must be between 1 and %d", count, modbusMaxReadBits)
+	}
+
+	byteCount := int(count+7) / 8
+	data, err := d.readData(function, addr, count, byteCount)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]bool, count)
+	for i := range values {
+		values[i] = data[i/8]&(1<<(i%8)) != 0
+	}
+
+	return values, nil
+}
+
+func (d *ModbusRTUDriver) readRegisters(function byte, addr, count uint16) ([]uint16, error) {
+	if count < 1 || count > modbusMaxReadRegisters {
+		return nil, fmt.Errorf("count of registers (%d) must be between 1 and %d", count, modbusMaxReadRegisters)
+	}
+
+	data, err := d.readData(function, addr, count, 2*int(count))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]uint16, count)
+	for i := range values {
+		values[i] = binary.BigEndian.Uint16(data[2*i:])
+	}
+
+	return values, nil
+}
+
+// readData sends a read request and returns the data bytes of the response
+func (d *ModbusRTUDriver) readData(function byte, addr, count uint16, byteCount int) ([]byte, error) {
+	pdu := make([]byte, 5)
+	pdu[0] = function
+	binary.BigEndian.PutUint16(pdu[1:], addr)
+	binary.BigEndian.PutUint16(pdu[3:], count)
+
+	// response: slave ID, function, byte count, data, CRC
+	response, err := d.transaction(pdu, 3+byteCount+2, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(response[2]) != byteCount {
+		return nil, fmt.Errorf("modbus response with byte count %d, expected %d", response[2], byteCount)
+	}
+
+	return response[3 : 3+byteCount], nil
+}
+
+func (d *ModbusRTUDriver) writeSingle(function byte, addr, value uint16) error {
+	pdu := make([]byte, 5)
+	pdu[0] = function
+	binary.BigEndian.PutUint16(pdu[1:], addr)
+	binary.BigEndian.PutUint16(pdu[3:], value)
+
+	// the response is an echo of the request
+	_, err := d.transaction(pdu, 8, true)
+	return err
+}
+
+func (d *ModbusRTUDriver) writeMultiple(function byte, addr, count uint16, data []byte) error {
+	pdu := make([]byte, 6, 6+len(data))
+	pdu[0] = function
+	binary.BigEndian.PutUint16(pdu[1:], addr)
+	binary.BigEndian.PutUint16(pdu[3:], count)
+	pdu[5] = byte(len(data))
+	pdu = append(pdu, data...)
+
+	// response: slave ID, function, address, count, CRC
+	_, err := d.transaction(pdu, 8, true)
+	return err
+}
+
+// transaction sends the request with the given protocol data unit and reads the response with the expected length.
+// The response is validated by slave ID, function code and CRC. For an echo, the address and value (or count) of the
+// request and response needs to be the same.
+func (d *ModbusRTUDriver) transaction(pdu []byte, responseLen int, echo bool) ([]byte, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	rw, ok := d.connection.(SerialReadWriter)
+	if !ok {
+		return nil, fmt.Errorf("SerialRead and SerialWrite are not supported by the platform '%s'",
+			d.Connection().Name())
+	}
+
+	slaveID := d.modbusCfg.slaveID
+	if slaveID == 0 && !echo {
+		return nil, fmt.Errorf("read function 0x%02X can not be used with broadcast (slave ID 0)", pdu[0])
+	}
+
+	request := modbusFrame(slaveID, pdu)
+	if _, err := rw.SerialWrite(request); err != nil {
+		return nil, err
+	}
+
+	if slaveID == 0 {
+		// there is no response for a broadcast
+		return nil, nil
+	}
+
+	response, err := d.readResponse(rw, pdu[0], responseLen)
+	if err != nil {
+		return nil, err
+	}
+
+	if response[0] != slaveID {
+		return nil, fmt.Errorf("modbus response from slave %d, expected %d", response[0], slaveID)
+	}
+
+	if response[1] == pdu[0]|0x80 {
+		return nil, &ModbusError{Function: pdu[0], Code: response[2]}
+	}
+
+	if response[1] != pdu[0] {
+		return nil, fmt.Errorf("modbus response with function 0x%02X, expected 0x%02X", response[1], pdu[0])
+	}
+
+	if echo && string(response[2:6]) != string(pdu[1:5]) {
+		return nil, fmt.Errorf("modbus response % X differs from request % X", response[2:6], pdu[1:5])
+	}
+
+	return response, nil
+}
+
+// readResponse reads the response frame until the expected length or the shorter exception frame is received and
+// checks the CRC.
+func (d *ModbusRTUDriver) readResponse(r SerialReader, function byte, responseLen int) ([]byte, error) {
+	response := make([]byte, 0, responseLen)
+	buf := make([]byte, responseLen)
+	deadline := time.Now().Add(d.modbusCfg.timeout)
+	for {
+		wantLen := responseLen
+		if len(response) >= 2 && response[1] == function|0x80 {
+			// exception: slave ID, function, exception code, CRC
+			wantLen = 5
+		}
+
+		if len(response) >= wantLen {
+			response = response[:wantLen]
+			break
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("modbus response timeout after %s, received %d of %d bytes", d.modbusCfg.timeout,
+				len(response), wantLen)
+		}
+
+		n, err := r.SerialRead(buf[:wantLen-len(response)])
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			time.Sleep(modbusReadDelay)
+			continue
+		}
+		response = append(response, buf[:n]...)
+	}
+
+	crc := ModbusCRC(response[:len(response)-2])
+	if got := binary.LittleEndian.Uint16(response[len(response)-2:]); got != crc {
+		return nil, fmt.Errorf("modbus response with invalid CRC 0x%04X, expected 0x%04X", got, crc)
+	}
+
+	return response, nil
+}
+
+// modbusFrame creates the RTU frame for the given slave ID and protocol data unit
+func modbusFrame(slaveID byte, pdu []byte) []byte {
+	frame := make([]byte, 0, len(pdu)+3)
+	frame = append(frame, slaveID)
+	frame = append(frame, pdu...)
+	crc := ModbusCRC(frame)
+
+	return append(frame, byte(crc), byte(crc>>8))
+}
+
+// ModbusCRC calculates the CRC-16 of the given data, which is used by Modbus RTU. The CRC is appended with the low
+// byte first.
+func ModbusCRC(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&0x0001 != 0 {
+				crc = (crc >> 1) ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// apply change the slave ID in the configuration.
+func (o modbusSlaveIDOption) apply(c *modbusConfiguration) {
+	c.slaveID = byte(o)
+}
+
+// apply change the response timeout in the configuration.
+func (o modbusTimeoutOption) apply(c *modbusConfiguration) {
+	c.timeout = time.Duration(o)
+}
@@ -0,0 +1,272 @@
+package serial
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*ModbusRTUDriver)(nil)
+
+func initTestModbusRTUDriverWithStubbedAdaptor(opts ...interface{}) (*ModbusRTUDriver, *serialTestAdaptor) {
+	a := newSerialTestAdaptor()
+	d := NewModbusRTUDriver(a, opts...)
+	return d, a
+}
+
+func TestNewModbusRTUDriver(t *testing.T) {
+	// arrange
+	a := newSerialTestAdaptor()
+	// act
+	d := NewModbusRTUDriver(a)
+	// assert
+	assert.IsType(t, &ModbusRTUDriver{}, d)
+	assert.True(t, strings.HasPrefix(d.Name(), "ModbusRTU"))
+	assert.Equal(t, a, d.Connection())
+	assert.Equal(t, byte(1), d.SlaveID())
+	assert.Equal(t, time.Second, d.modbusCfg.timeout)
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Halt())
+}
+
+func TestNewModbusRTUDriver_options(t *testing.T) {
+	// arrange
+	a := newSerialTestAdaptor()
+	// act
+	d := NewModbusRTUDriver(a, WithName("meter"), WithModbusSlaveID(17), WithModbusTimeout(50*time.Millisecond))
+	// assert
+	assert.Equal(t, "meter", d.Name())
+	assert.Equal(t, byte(17), d.SlaveID())
+	assert.Equal(t, 50*time.Millisecond, d.modbusCfg.timeout)
+	assert.PanicsWithValue(t, "'unknown' can not be applied on 'meter'", func() {
+		_ = NewModbusRTUDriver(a, WithName("meter"), "unknown")
+	})
+}
+
+func TestModbusCRC(t *testing.T) {
+	tests := map[string]struct {
+		data []byte
+		want uint16
+	}{
+		"read_holding_registers": {data: []byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0A}, want: 0xCDC5},
+		"write_single_coil":      {data: []byte{0x11, 0x05, 0x00, 0xAC, 0xFF, 0x00}, want: 0x8B4E},
+		"empty":                  {data: []byte{}, want: 0xFFFF},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got := ModbusCRC(tc.data)
+			// assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestModbusFrame(t *testing.T) {
+	// act
+	got := modbusFrame(0x01, []byte{0x03, 0x00, 0x00, 0x00, 0x0A})
+	// assert
+	assert.Equal(t, []byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0A, 0xC5, 0xCD}, got)
+	assert.Equal(t, uint16(0), ModbusCRC(got), "CRC over the whole frame must be zero")
+}
+
+func TestModbusRTURead(t *testing.T) {
+	tests := map[string]struct {
+		read        func(d *ModbusRTUDriver) (interface{}, error)
+		response    []byte
+		wantRequest []byte
+		want        interface{}
+	}{
+		"read_coils": {
+			read:        func(d *ModbusRTUDriver) (interface{}, error) { return d.ReadCoils(0x13, 10) },
+			response:    []byte{0x01, 0x01, 0x02, 0xCD, 0x01},
+			wantRequest: []byte{0x01, 0x01, 0x00, 0x13, 0x00, 0x0A},
+			want:        []bool{true, false, true, true, false, false, true, true, true, false},
+		},
+		"read_discrete_inputs": {
+			read:        func(d *ModbusRTUDriver) (interface{}, error) { return d.ReadDiscreteInputs(0xC4, 3) },
+			response:    []byte{0x01, 0x02, 0x01, 0x05},
+			wantRequest: []byte{0x01, 0x02, 0x00, 0xC4, 0x00, 0x03},
+			want:        []bool{true, false, true},
+		},
+		"read_holding_registers": {
+			read:        func(d *ModbusRTUDriver) (interface{}, error) { return d.ReadHoldingRegisters(0x6B, 3) },
+			response:    []byte{0x01, 0x03, 0x06, 0x02, 0x2B, 0x00, 0x00, 0x00, 0x64},
+			wantRequest: []byte{0x01, 0x03, 0x00, 0x6B, 0x00, 0x03},
+			want:        []uint16{0x022B, 0x0000, 0x0064},
+		},
+		"read_input_registers": {
+			read:        func(d *ModbusRTUDriver) (interface{}, error) { return d.ReadInputRegisters(0x08, 1) },
+			response:    []byte{0x01, 0x04, 0x02, 0x00, 0x0A},
+			wantRequest: []byte{0x01, 0x04, 0x00, 0x08, 0x00, 0x01},
+			want:        []uint16{0x000A},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestModbusRTUDriverWithStubbedAdaptor()
+			response := modbusFrame(tc.response[0], tc.response[1:])
+			// split the response to simulate partial reads
+			a.chunks <- response[:2]
+			a.chunks <- response[2:]
+			// act
+			got, err := tc.read(d)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, modbusFrame(tc.wantRequest[0], tc.wantRequest[1:]), a.written)
+		})
+	}
+}
+
+func TestModbusRTUWrite(t *testing.T) {
+	tests := map[string]struct {
+		write       func(d *ModbusRTUDriver) error
+		wantRequest []byte
+		response    []byte
+	}{
+		"write_single_coil": {
+			write:       func(d *ModbusRTUDriver) error { return d.WriteSingleCoil(0xAC, true) },
+			wantRequest: []byte{0x01, 0x05, 0x00, 0xAC, 0xFF, 0x00},
+			response:    []byte{0x01, 0x05, 0x00, 0xAC, 0xFF, 0x00},
+		},
+		"write_single_register": {
+			write:       func(d *ModbusRTUDriver) error { return d.WriteSingleRegister(0x01, 0x0003) },
+			wantRequest: []byte{0x01, 0x06, 0x00, 0x01, 0x00, 0x03},
+			response:    []byte{0x01, 0x06, 0x00, 0x01, 0x00, 0x03},
+		},
+		"write_multiple_coils": {
+			write: func(d *ModbusRTUDriver) error {
+				return d.WriteMultipleCoils(0x13, []bool{true, false, true, true, false, false, true, true, true, false})
+			},
+			wantRequest: []byte{0x01, 0x0F, 0x00, 0x13, 0x00, 0x0A, 0x02, 0xCD, 0x01},
+			response:    []byte{0x01, 0x0F, 0x00, 0x13, 0x00, 0x0A},
+		},
+		"write_multiple_registers": {
+			write:       func(d *ModbusRTUDriver) error { return d.WriteMultipleRegisters(0x01, []uint16{0x000A, 0x0102}) },
+			wantRequest: []byte{0x01, 0x10, 0x00, 0x01, 0x00, 0x02, 0x04, 0x00, 0x0A, 0x01, 0x02},
+			response:    []byte{0x01, 0x10, 0x00, 0x01, 0x00, 0x02},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestModbusRTUDriverWithStubbedAdaptor()
+			a.chunks <- modbusFrame(tc.response[0], tc.response[1:])
+			// act
+			err := tc.write(d)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, modbusFrame(tc.wantRequest[0], tc.wantRequest[1:]), a.written)
+		})
+	}
+}
+
+func TestModbusRTUBroadcast(t *testing.T) {
+	// arrange
+	d, a := initTestModbusRTUDriverWithStubbedAdaptor(WithModbusSlaveID(0))
+	// act & assert
+	require.NoError(t, d.WriteSingleRegister(0x01, 0x0003))
+	assert.Equal(t, modbusFrame(0x00, []byte{0x06, 0x00, 0x01, 0x00, 0x03}), a.written)
+	_, err := d.ReadHoldingRegisters(0x01, 1)
+	require.EqualError(t, err, "read function 0x03 can not be used with broadcast (slave ID 0)")
+}
+
+func TestModbusRTU_error(t *testing.T) {
+	tests := map[string]struct {
+		response []byte
+		wantErr  string
+	}{
+		"exception": {
+			response: modbusFrame(0x01, []byte{0x83, 0x02}),
+			wantErr:  "modbus exception 0x02 (illegal data address) for function 0x03",
+		},
+		"invalid_crc": {
+			response: []byte{0x01, 0x03, 0x02, 0x00, 0x0A, 0x00, 0x00},
+			wantErr:  "modbus response with invalid CRC 0x0000, expected 0x4338",
+		},
+		"wrong_slave": {
+			response: modbusFrame(0x02, []byte{0x03, 0x02, 0x00, 0x0A}),
+			wantErr:  "modbus response from slave 2, expected 1",
+		},
+		"wrong_function": {
+			response: modbusFrame(0x01, []byte{0x04, 0x02, 0x00, 0x0A}),
+			wantErr:  "modbus response with function 0x04, expected 0x03",
+		},
+		"wrong_byte_count": {
+			response: modbusFrame(0x01, []byte{0x03, 0x03, 0x00, 0x0A}),
+			wantErr:  "modbus response with byte count 3, expected 2",
+		},
+		"timeout": {
+			response: []byte{0x01, 0x03},
+			wantErr:  "modbus response timeout after 20ms, received 2 of 7 bytes",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestModbusRTUDriverWithStubbedAdaptor(WithModbusTimeout(20 * time.Millisecond))
+			a.chunks <- tc.response
+			// act
+			_, err := d.ReadHoldingRegisters(0x01, 1)
+			// assert
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestModbusRTUException(t *testing.T) {
+	// arrange
+	d, a := initTestModbusRTUDriverWithStubbedAdaptor()
+	a.chunks <- modbusFrame(0x01, []byte{0x86, 0x06})
+	// act
+	err := d.WriteSingleRegister(0x01, 0x0003)
+	// assert
+	var modbusErr *ModbusError
+	require.True(t, errors.As(err, &modbusErr))
+	assert.Equal(t, byte(ModbusWriteSingleRegister), modbusErr.Function)
+	assert.Equal(t, byte(0x06), modbusErr.Code)
+}
+
+func TestModbusRTUWrite_echoMismatch(t *testing.T) {
+	// arrange
+	d, a := initTestModbusRTUDriverWithStubbedAdaptor()
+	a.chunks <- modbusFrame(0x01, []byte{0x06, 0x00, 0x01, 0x00, 0x04})
+	// act
+	err := d.WriteSingleRegister(0x01, 0x0003)
+	// assert
+	require.EqualError(t, err, "modbus response 00 01 00 04 differs from request 00 01 00 03")
+}
+
+func TestModbusRTU_invalidCount(t *testing.T) {
+	// arrange
+	d, a := initTestModbusRTUDriverWithStubbedAdaptor()
+	// act & assert
+	_, err := d.ReadCoils(0, 0)
+	require.EqualError(t, err, "count of bits (0) must be between 1 and 2000")
+	_, err = d.ReadInputRegisters(0, 126)
+	require.EqualError(t, err, "count of registers (126) must be between 1 and 125")
+	require.EqualError(t, d.WriteMultipleCoils(0, nil), "count of coils (0) must be between 1 and 1968")
+	require.EqualError(t, d.WriteMultipleRegisters(0, make([]uint16, 124)),
+		"count of registers (124) must be between 1 and 123")
+	assert.Empty(t, a.written)
+}
+
+func TestModbusRTU_readError(t *testing.T) {
+	// arrange
+	d, a := initTestModbusRTUDriverWithStubbedAdaptor()
+	a.serialReadFunc = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	// act
+	_, err := d.ReadHoldingRegisters(0x01, 1)
+	// assert
+	require.EqualError(t, err, "read error")
+}
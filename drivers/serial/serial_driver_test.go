@@ -0,0 +1,48 @@
+package serial
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*driver)(nil)
+
+func TestNewDriver(t *testing.T) {
+	// arrange
+	const name = "mybot"
+	a := newSerialTestAdaptor()
+	// act
+	d := newDriver(a, name)
+	// assert
+	assert.IsType(t, &driver{}, d)
+	assert.NotNil(t, d.driverCfg)
+	assert.True(t, strings.HasPrefix(d.Name(), name))
+	assert.Equal(t, a, d.Connection())
+	require.NoError(t, d.afterStart())
+	require.NoError(t, d.beforeHalt())
+	assert.NotNil(t, d.Commander)
+	assert.NotNil(t, d.mutex)
+}
+
+func TestSetName(t *testing.T) {
+	// arrange
+	d := newDriver(newSerialTestAdaptor(), "before")
+	// act
+	d.SetName("after")
+	// assert
+	assert.Equal(t, "after", d.Name())
+}
+
+func TestConnection(t *testing.T) {
+	// arrange
+	d := newDriver(newSerialTestAdaptor(), "serial")
+	dNoConn := newDriver(nil, "serial")
+	// act & assert
+	assert.NotNil(t, d.Connection())
+	assert.Nil(t, dNoConn.Connection())
+}
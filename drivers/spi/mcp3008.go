@@ -42,9 +42,26 @@ func (d *MCP3008Driver) Read(channel int) (int, error) {
 		return 0, fmt.Errorf("Invalid channel '%d' for read", channel)
 	}
 
+	return d.read(byte(8+channel) << 4)
+}
+
+// ReadDifferential reads the current analog data of the desired differential pair. The even pair numbers measure the
+// first channel of a pair against the second one (0: CH0 = IN+, CH1 = IN-, 2: CH2 = IN+, CH3 = IN-, ...), the odd
+// pair numbers measure inverted (1: CH0 = IN-, CH1 = IN+, ...). A negative difference is read as zero.
+func (d *MCP3008Driver) ReadDifferential(pair int) (int, error) {
+	if pair < 0 || pair > MCP3008DriverMaxChannel-1 {
+		return 0, fmt.Errorf("Invalid differential pair '%d' for read", pair)
+	}
+
+	return d.read(byte(pair) << 4)
+}
+
+// read sends the start bit and the given configuration bits (single/differential and channel selection) and returns
+// the 10 bit value of the conversion.
+func (d *MCP3008Driver) read(config byte) (int, error) {
 	tx := make([]byte, 3)
 	tx[0] = 0x01
-	tx[1] = byte(8+channel) << 4
+	tx[1] = config
 	tx[2] = 0x00
 
 	rx := make([]byte, 3)
@@ -88,6 +88,62 @@ func TestMCP3008Read(t *testing.T) {
 	}
 }
 
+func TestMCP3008ReadDifferential(t *testing.T) {
+	tests := map[string]struct {
+		pair        int
+		simRead     []byte
+		want        int
+		wantWritten []byte
+		wantErr     error
+	}{
+		"pair_negative_error": {
+			pair:    -1,
+			wantErr: fmt.Errorf("Invalid differential pair '-1' for read"),
+		},
+		"pair_0_ok": {
+			pair:        0,
+			simRead:     []byte{0xFF, 0xFD, 0x34},
+			wantWritten: []byte{0x01, 0x00, 0x00},
+			want:        0x0134,
+		},
+		"pair_1_ok": {
+			pair:        1,
+			simRead:     []byte{0xFF, 0xF8, 0x05},
+			wantWritten: []byte{0x01, 0x10, 0x00},
+			want:        0x0005,
+		},
+		"pair_6_ok": {
+			pair:        6,
+			simRead:     []byte{0xFF, 0xFE, 0xFF},
+			wantWritten: []byte{0x01, 0x60, 0x00},
+			want:        0x02FF,
+		},
+		"pair_7_ok": {
+			pair:        7,
+			simRead:     []byte{0xFF, 0xFB, 0xFF},
+			wantWritten: []byte{0x01, 0x70, 0x00},
+			want:        0x03FF,
+		},
+		"pair_8_error": {
+			pair:    8,
+			wantErr: fmt.Errorf("Invalid differential pair '8' for read"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestMCP3008DriverWithStubbedAdaptor()
+			a.spi.SetSimRead(tc.simRead)
+			// act
+			got, err := d.ReadDifferential(tc.pair)
+			// assert
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantWritten, a.spi.Written())
+		})
+	}
+}
+
 func TestMCP3008ReadWithError(t *testing.T) {
 	// arrange
 	d, a := initTestMCP3008DriverWithStubbedAdaptor()
@@ -83,6 +83,7 @@ type Driver struct {
 	beforeHalt func() error
 	Config
 	gobot.Commander
+	gobot.Labeler
 	mutex sync.Mutex
 }
 
@@ -95,6 +96,7 @@ func NewDriver(a Connector, name string, options ...func(Config)) *Driver {
 		beforeHalt: func() error { return nil },
 		Config:     NewConfig(),
 		Commander:  gobot.NewCommander(),
+		Labeler:    gobot.NewLabeler(),
 	}
 	for _, option := range options {
 		option(d)
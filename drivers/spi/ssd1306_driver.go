@@ -186,25 +186,29 @@ func NewSSD1306Driver(a gobot.Adaptor, options ...func(Config)) *SSD1306Driver {
 	return s
 }
 
-// WithDisplayWidth option sets the SSD1306Driver DisplayWidth option.
+// WithDisplayWidth option sets the SSD1306Driver DisplayWidth option or the width of the TFTDriver.
 func WithDisplayWidth(val int) func(Config) {
 	return func(c Config) {
-		d, ok := c.(*SSD1306Driver)
-		if ok {
+		switch d := c.(type) {
+		case *SSD1306Driver:
 			d.DisplayWidth = val
-		} else {
+		case *TFTDriver:
+			d.width = val
+		default:
 			panic("unable to set display width for ssd1306")
 		}
 	}
 }
 
-// WithDisplayHeight option sets the SSD1306Driver DisplayHeight option.
+// WithDisplayHeight option sets the SSD1306Driver DisplayHeight option or the height of the TFTDriver.
 func WithDisplayHeight(val int) func(Config) {
 	return func(c Config) {
-		d, ok := c.(*SSD1306Driver)
-		if ok {
+		switch d := c.(type) {
+		case *SSD1306Driver:
 			d.DisplayHeight = val
-		} else {
+		case *TFTDriver:
+			d.height = val
+		default:
 			panic("unable to set display height for ssd1306")
 		}
 	}
@@ -55,6 +55,12 @@ type gpioTestAdaptor struct {
 	pwmWriteFunc     func() error
 	analogReadFunc   func() (val int, err error)
 	digitalReadFunc  func() (val int, err error)
+	pinWrites        []gpioTestPinWrite
+}
+
+type gpioTestPinWrite struct {
+	pin string
+	val byte
 }
 
 func (t *gpioTestAdaptor) ServoWrite(string, byte) error {
@@ -81,9 +87,10 @@ func (t *gpioTestAdaptor) DigitalRead(string) (int, error) {
 	return t.digitalReadFunc()
 }
 
-func (t *gpioTestAdaptor) DigitalWrite(string, byte) error {
+func (t *gpioTestAdaptor) DigitalWrite(pin string, val byte) error {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
+	t.pinWrites = append(t.pinWrites, gpioTestPinWrite{pin: pin, val: val})
 	return t.digitalWriteFunc()
 }
 func (t *gpioTestAdaptor) Connect() error   { return nil }
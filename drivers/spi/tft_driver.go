@@ -0,0 +1,442 @@
+package spi
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+)
+
+const (
+	// commands of the MIPI display command set, which are used by ST7735 and ILI9341
+	tftSoftwareReset   = 0x01
+	tftSleepIn         = 0x10
+	tftSleepOut        = 0x11
+	tftDisplayOff      = 0x28
+	tftDisplayOn       = 0x29
+	tftColumnAddrSet   = 0x2A
+	tftRowAddrSet      = 0x2B
+	tftMemoryWrite     = 0x2C
+	tftMemoryAccessCtl = 0x36
+	tftPixelFormatSet  = 0x3A
+
+	tftPixelFormat16Bit = 0x55 // 16 bit/pixel for RGB and MCU interface
+	tftST7735Format     = 0x05 // 16 bit/pixel for ST7735, RGB part is ignored
+
+	tftST7735Width      = 128
+	tftST7735Height     = 160
+	tftST7735AccessCtl  = 0xC8 // row and column address order reversed, BGR
+	tftILI9341Width     = 240
+	tftILI9341Height    = 320
+	tftILI9341AccessCtl = 0x48 // column address order reversed, BGR
+
+	tftResetDelay    = 150 * time.Millisecond
+	tftSleepOutDelay = 120 * time.Millisecond
+
+	// tftMaxChunkSize is the maximum count of bytes for one SPI transfer, e.g. the default buffer size of spidev
+	tftMaxChunkSize = 4096
+)
+
+// tftCommand is one command of the initialization sequence with its parameters and the wait time afterwards
+type tftCommand struct {
+	cmd   byte
+	data  []byte
+	delay time.Duration
+}
+
+// TFTDriver is a minimal driver for small color TFT displays with a ST7735 or ILI9341 controller, connected by SPI.
+// The driver writes directly to the display memory (RGB565 format), there is no intermediate buffer.
+//
+// Datasheets:
+// https://www.displayfuture.com/Display/datasheet/controller/ST7735.pdf
+// https://cdn-shop.adafruit.com/datasheets/ILI9341.pdf
+type TFTDriver struct {
+	*Driver
+	dcDriver     *gpio.DirectPinDriver
+	rstDriver    *gpio.DirectPinDriver
+	csDriver     *gpio.DirectPinDriver
+	width        int
+	height       int
+	colOffset    int
+	rowOffset    int
+	initSequence []tftCommand
+	sleepFunc    func(time.Duration)
+}
+
+// NewST7735Driver creates a new driver for a ST7735 display controller (128x160 by default).
+//
+// Params:
+//
+//	a gobot.Adaptor - the adaptor to use with this driver, needs to implement the spi.Connector and the
+//	                  gpio.DigitalWriter
+//	dcPin string    - gpio pin connected to the data/command pin of the display
+//	rstPin string   - gpio pin connected to the reset pin of the display, "" if not connected
+//	csPin string    - gpio pin connected to the chip select pin of the display, "" if the chip select of the SPI
+//	                  bus is used
+//
+// Optional params:
+//
+//	spi.WithBusNumber(int):  bus to use with this driver
+//	spi.WithChipNumber(int): chip to use with this driver
+//	spi.WithMode(int):       mode to use with this driver
+//	spi.WithBitCount(int):   number of bits to use with this driver
+//	spi.WithSpeed(int64):    speed in Hz to use with this driver
+//	spi.WithDisplayWidth(int):  width of display (defaults to 128)
+//	spi.WithDisplayHeight(int): height of display (defaults to 160)
+//	spi.WithTFTOffset(int, int): offset of column and row in display memory (defaults to 0, 0)
+func NewST7735Driver(a gobot.Adaptor, dcPin, rstPin, csPin string, options ...func(Config)) *TFTDriver {
+	initSequence := []tftCommand{
+		{cmd: tftSoftwareReset, delay: tftResetDelay},
+		{cmd: tftSleepOut, delay: tftSleepOutDelay},
+		{cmd: tftPixelFormatSet, data: []byte{tftST7735Format}},
+		{cmd: tftMemoryAccessCtl, data: []byte{tftST7735AccessCtl}},
+		{cmd: tftDisplayOn},
+	}
+
+	return newTFTDriver(a, "ST7735", tftST7735Width, tftST7735Height, initSequence, dcPin, rstPin, csPin, options...)
+}
+
+// NewILI9341Driver creates a new driver for a ILI9341 display controller (240x320 by default). For the params and
+// options see NewST7735Driver().
+func NewILI9341Driver(a gobot.Adaptor, dcPin, rstPin, csPin string, options ...func(Config)) *TFTDriver {
+	initSequence := []tftCommand{
+		{cmd: tftSoftwareReset, delay: tftResetDelay},
+		{cmd: tftSleepOut, delay: tftSleepOutDelay},
+		{cmd: tftPixelFormatSet, data: []byte{tftPixelFormat16Bit}},
+		{cmd: tftMemoryAccessCtl, data: []byte{tftILI9341AccessCtl}},
+		{cmd: tftDisplayOn},
+	}
+
+	return newTFTDriver(a, "ILI9341", tftILI9341Width, tftILI9341Height, initSequence, dcPin, rstPin, csPin, options...)
+}
+
+func newTFTDriver(
+	a gobot.Adaptor,
+	controller string,
+	width, height int,
+	initSequence []tftCommand,
+	dcPin, rstPin, csPin string,
+	options ...func(Config),
+) *TFTDriver {
+	// cast adaptor to spi connector since we also need the adaptor for gpio
+	b, ok := a.(Connector)
+	if !ok {
+		panic(fmt.Sprintf("unable to get gobot connector for %s", controller))
+	}
+
+	if dcPin == "" {
+		panic(fmt.Sprintf("data/command pin is mandatory for %s", controller))
+	}
+
+	d := &TFTDriver{
+		Driver:       NewDriver(b, controller),
+		dcDriver:     gpio.NewDirectPinDriver(a, dcPin),
+		width:        width,
+		height:       height,
+		initSequence: initSequence,
+		sleepFunc:    time.Sleep,
+	}
+	if rstPin != "" {
+		d.rstDriver = gpio.NewDirectPinDriver(a, rstPin)
+	}
+	if csPin != "" {
+		d.csDriver = gpio.NewDirectPinDriver(a, csPin)
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddCommand("FillRect", func(params map[string]interface{}) interface{} {
+		x, _ := params["x"].(int)
+		y, _ := params["y"].(int)
+		w, _ := params["w"].(int)
+		h, _ := params["h"].(int)
+		color, _ := params["color"].(int)
+		err := d.FillRect(x, y, w, h, uint16(color))
+		return map[string]interface{}{"err": err}
+	})
+	d.AddCommand("DrawText", func(params map[string]interface{}) interface{} {
+		x, _ := params["x"].(int)
+		y, _ := params["y"].(int)
+		text, _ := params["text"].(string)
+		fg, _ := params["fg"].(int)
+		bg, _ := params["bg"].(int)
+		err := d.DrawText(x, y, text, uint16(fg), uint16(bg))
+		return map[string]interface{}{"err": err}
+	})
+
+	return d
+}
+
+// WithTFTOffset option sets the offset of the first visible column and row in the display memory. This is needed for
+// some displays with a ST7735 controller, which do not use the full memory size.
+func WithTFTOffset(col, row int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*TFTDriver)
+		if ok {
+			d.colOffset = col
+			d.rowOffset = row
+		} else {
+			panic("unable to set offset for tft display")
+		}
+	}
+}
+
+// RGB565 converts the given 8 bit color components to the 16 bit color format of the display.
+func RGB565(r, g, b uint8) uint16 {
+	return uint16(r&0xF8)<<8 | uint16(g&0xFC)<<3 | uint16(b)>>3
+}
+
+// Size returns the width and height of the display in pixels.
+func (d *TFTDriver) Size() (int, int) {
+	return d.width, d.height
+}
+
+// Reset does a hardware reset by the reset pin. The display needs to be initialized again afterwards.
+func (d *TFTDriver) Reset() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.reset()
+}
+
+// SetWindow defines the rectangle of the display memory (inclusive the end column and row), which will be written by
+// the next pixel data. The memory write is started, so the pixel data can be written afterwards, see Blit().
+func (d *TFTDriver) SetWindow(x0, y0, x1, y1 int) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.setWindow(x0, y0, x1, y1)
+}
+
+// Blit writes the given RGB565 pixels to the rectangle with the given position and size. The pixels are ordered
+// row by row.
+func (d *TFTDriver) Blit(x, y, w, h int, pixels []uint16) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.blit(x, y, w, h, pixels)
+}
+
+// FillRect fills the rectangle with the given position and size with the given RGB565 color.
+func (d *TFTDriver) FillRect(x, y, w, h int, color uint16) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// the size needs to be valid before the allocation of the pixels
+	if err := checkTFTSize(w, h); err != nil {
+		return err
+	}
+	if err := d.checkWindow(x, y, x+w-1, y+h-1); err != nil {
+		return err
+	}
+
+	pixels := make([]uint16, w*h)
+	for i := range pixels {
+		pixels[i] = color
+	}
+
+	return d.blit(x, y, w, h, pixels)
+}
+
+// Clear fills the whole display with the given RGB565 color.
+func (d *TFTDriver) Clear(color uint16) error {
+	return d.FillRect(0, 0, d.width, d.height, color)
+}
+
+// DrawText writes the given text in a single line with the bundled 5x7 font, starting at the given position (top
+// left corner). Each character needs a cell of 6x8 pixels, including the spacing, which is filled with the background
+// color. Characters outside the display are dropped, unsupported characters are replaced by '?'.
+func (d *TFTDriver) DrawText(x, y int, text string, fg, bg uint16) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	const cellWidth, cellHeight = tftFontWidth + 1, tftFontHeight + 1
+
+	for _, r := range text {
+		if x+cellWidth > d.width {
+			break
+		}
+
+		glyph := tftGlyph(r)
+		pixels := make([]uint16, cellWidth*cellHeight)
+		for row := 0; row < cellHeight; row++ {
+			for col := 0; col < cellWidth; col++ {
+				color := bg
+				if col < tftFontWidth && glyph[col]&(1<<uint(row)) != 0 {
+					color = fg
+				}
+				pixels[row*cellWidth+col] = color
+			}
+		}
+
+		if err := d.blit(x, y, cellWidth, cellHeight, pixels); err != nil {
+			return err
+		}
+		x += cellWidth
+	}
+
+	return nil
+}
+
+// initialize resets the display and writes the initialization sequence of the controller
+func (d *TFTDriver) initialize() error {
+	if err := d.reset(); err != nil {
+		return err
+	}
+
+	for _, c := range d.initSequence {
+		if err := d.command(c.cmd, c.data...); err != nil {
+			return err
+		}
+		if c.delay > 0 {
+			d.sleepFunc(c.delay)
+		}
+	}
+
+	return nil
+}
+
+func (d *TFTDriver) shutdown() error {
+	if err := d.command(tftDisplayOff); err != nil {
+		return err
+	}
+
+	return d.command(tftSleepIn)
+}
+
+// reset does a hardware reset, if the reset pin is available
+func (d *TFTDriver) reset() error {
+	if d.rstDriver == nil {
+		return nil
+	}
+
+	for _, level := range []byte{1, 0, 1} {
+		if err := d.rstDriver.DigitalWrite(level); err != nil {
+			return err
+		}
+		d.sleepFunc(10 * time.Millisecond)
+	}
+
+	return nil
+}
+
+func (d *TFTDriver) setWindow(x0, y0, x1, y1 int) error {
+	if err := d.checkWindow(x0, y0, x1, y1); err != nil {
+		return err
+	}
+
+	x0, x1 = x0+d.colOffset, x1+d.colOffset
+	y0, y1 = y0+d.rowOffset, y1+d.rowOffset
+	if err := d.command(tftColumnAddrSet, byte(x0>>8), byte(x0), byte(x1>>8), byte(x1)); err != nil {
+		return err
+	}
+	if err := d.command(tftRowAddrSet, byte(y0>>8), byte(y0), byte(y1>>8), byte(y1)); err != nil {
+		return err
+	}
+
+	return d.command(tftMemoryWrite)
+}
+
+// checkWindow returns an error, if the given window is invalid or not completely inside the display
+func (d *TFTDriver) checkWindow(x0, y0, x1, y1 int) error {
+	if x0 < 0 || y0 < 0 || x1 >= d.width || y1 >= d.height || x0 > x1 || y0 > y1 {
+		return fmt.Errorf("window (%d, %d)-(%d, %d) is invalid or outside the display size %dx%d of '%s'",
+			x0, y0, x1, y1, d.width, d.height, d.name)
+	}
+
+	return nil
+}
+
+func (d *TFTDriver) blit(x, y, w, h int, pixels []uint16) error {
+	if err := checkTFTSize(w, h); err != nil {
+		return err
+	}
+
+	if len(pixels) != w*h {
+		return fmt.Errorf("count of pixels (%d) does not match the size %dx%d", len(pixels), w, h)
+	}
+
+	if err := d.setWindow(x, y, x+w-1, y+h-1); err != nil {
+		return err
+	}
+
+	data := make([]byte, 2*len(pixels))
+	for i, p := range pixels {
+		data[2*i] = byte(p >> 8)
+		data[2*i+1] = byte(p)
+	}
+
+	return d.writeData(data)
+}
+
+// checkTFTSize returns an error, if the width or the height of a rectangle is not greater than zero
+func checkTFTSize(w, h int) error {
+	if w <= 0 || h <= 0 {
+		return fmt.Errorf("width (%d) and height (%d) must be greater than zero", w, h)
+	}
+
+	return nil
+}
+
+// command writes the given command byte with the data/command pin low, followed by the parameters, if any
+func (d *TFTDriver) command(cmd byte, params ...byte) error {
+	if err := d.selectChip(true); err != nil {
+		return err
+	}
+	if err := d.dcDriver.DigitalWrite(0); err != nil {
+		return err
+	}
+	if err := d.connection.WriteByte(cmd); err != nil {
+		return err
+	}
+	if err := d.selectChip(false); err != nil {
+		return err
+	}
+
+	if len(params) == 0 {
+		return nil
+	}
+
+	return d.writeData(params)
+}
+
+// writeData writes the given bytes with the data/command pin high, split into chunks if needed
+func (d *TFTDriver) writeData(data []byte) error {
+	if err := d.selectChip(true); err != nil {
+		return err
+	}
+	if err := d.dcDriver.DigitalWrite(1); err != nil {
+		return err
+	}
+
+	for len(data) > 0 {
+		n := len(data)
+		if n > tftMaxChunkSize {
+			n = tftMaxChunkSize
+		}
+		if err := d.connection.WriteBytes(data[:n]); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+
+	return d.selectChip(false)
+}
+
+// selectChip writes the chip select pin (active low), if available
+func (d *TFTDriver) selectChip(selected bool) error {
+	if d.csDriver == nil {
+		return nil
+	}
+
+	if selected {
+		return d.csDriver.DigitalWrite(0)
+	}
+
+	return d.csDriver.DigitalWrite(1)
+}
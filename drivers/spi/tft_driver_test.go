@@ -0,0 +1,290 @@
+package spi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// this ensures that the implementation is based on spi.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*TFTDriver)(nil)
+
+func initTestTFTDriverWithStubbedAdaptor(
+	newFunc func(gobot.Adaptor, string, string, string, ...func(Config)) *TFTDriver,
+	csPin string,
+	options ...func(Config),
+) (*TFTDriver, *gpioTestAdaptor, *spiTestAdaptor) {
+	a := newGpioTestAdaptor()
+	d := newFunc(a, "1", "2", csPin, options...)
+	d.sleepFunc = func(time.Duration) {}
+	//nolint:forcetypeassert // ok here
+	return d, a, a.Connector.(*spiTestAdaptor)
+}
+
+func TestNewTFTDriver(t *testing.T) {
+	tests := map[string]struct {
+		newFunc    func(gobot.Adaptor, string, string, string, ...func(Config)) *TFTDriver
+		wantName   string
+		wantWidth  int
+		wantHeight int
+	}{
+		"st7735": {
+			newFunc:    NewST7735Driver,
+			wantName:   "ST7735",
+			wantWidth:  128,
+			wantHeight: 160,
+		},
+		"ili9341": {
+			newFunc:    NewILI9341Driver,
+			wantName:   "ILI9341",
+			wantWidth:  240,
+			wantHeight: 320,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			d := tc.newFunc(newGpioTestAdaptor(), "1", "", "")
+			// assert
+			assert.Contains(t, d.Name(), tc.wantName)
+			w, h := d.Size()
+			assert.Equal(t, tc.wantWidth, w)
+			assert.Equal(t, tc.wantHeight, h)
+			assert.NotNil(t, d.dcDriver)
+			assert.Nil(t, d.rstDriver)
+			assert.Nil(t, d.csDriver)
+			assert.NotNil(t, d.Command("FillRect"))
+			assert.NotNil(t, d.Command("DrawText"))
+		})
+	}
+}
+
+func TestNewTFTDriver_options(t *testing.T) {
+	// act
+	d := NewST7735Driver(newGpioTestAdaptor(), "1", "2", "3", WithDisplayWidth(80), WithDisplayHeight(160),
+		WithTFTOffset(26, 1))
+	// assert
+	w, h := d.Size()
+	assert.Equal(t, 80, w)
+	assert.Equal(t, 160, h)
+	assert.Equal(t, 26, d.colOffset)
+	assert.Equal(t, 1, d.rowOffset)
+	assert.NotNil(t, d.rstDriver)
+	assert.NotNil(t, d.csDriver)
+	assert.PanicsWithValue(t, "data/command pin is mandatory for ILI9341", func() {
+		_ = NewILI9341Driver(newGpioTestAdaptor(), "", "", "")
+	})
+	assert.PanicsWithValue(t, "unable to set offset for tft display", func() {
+		_ = NewSSD1306Driver(newGpioTestAdaptor(), WithTFTOffset(1, 1))
+	})
+}
+
+func TestTFTStart_initSequence(t *testing.T) {
+	tests := map[string]struct {
+		newFunc     func(gobot.Adaptor, string, string, string, ...func(Config)) *TFTDriver
+		wantWritten []byte
+	}{
+		"st7735": {
+			newFunc:     NewST7735Driver,
+			wantWritten: []byte{0x01, 0x11, 0x3A, 0x05, 0x36, 0xC8, 0x29},
+		},
+		"ili9341": {
+			newFunc:     NewILI9341Driver,
+			wantWritten: []byte{0x01, 0x11, 0x3A, 0x55, 0x36, 0x48, 0x29},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a, sa := initTestTFTDriverWithStubbedAdaptor(tc.newFunc, "")
+			var delays []time.Duration
+			d.sleepFunc = func(delay time.Duration) { delays = append(delays, delay) }
+			// act
+			err := d.Start()
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantWritten, sa.spi.Written())
+			wantPinWrites := []gpioTestPinWrite{
+				{pin: "2", val: 1}, {pin: "2", val: 0}, {pin: "2", val: 1}, // hardware reset
+				{pin: "1", val: 0},                     // software reset
+				{pin: "1", val: 0},                     // sleep out
+				{pin: "1", val: 0}, {pin: "1", val: 1}, // pixel format
+				{pin: "1", val: 0}, {pin: "1", val: 1}, // memory access control
+				{pin: "1", val: 0}, // display on
+			}
+			assert.Equal(t, wantPinWrites, a.pinWrites)
+			wantDelays := []time.Duration{
+				10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond,
+				150 * time.Millisecond, 120 * time.Millisecond,
+			}
+			assert.Equal(t, wantDelays, delays)
+		})
+	}
+}
+
+func TestTFTFillRect(t *testing.T) {
+	// arrange
+	d, a, sa := initTestTFTDriverWithStubbedAdaptor(NewST7735Driver, "3", WithTFTOffset(2, 1))
+	require.NoError(t, d.Start())
+	sa.spi.Reset()
+	a.pinWrites = nil
+	// act
+	err := d.FillRect(1, 2, 2, 1, RGB565(0xFF, 0, 0))
+	// assert
+	require.NoError(t, err)
+	want := []byte{
+		0x2A, 0x00, 0x03, 0x00, 0x04, // column 1..2 + offset 2
+		0x2B, 0x00, 0x03, 0x00, 0x03, // row 2 + offset 1
+		0x2C,                   // memory write
+		0xF8, 0x00, 0xF8, 0x00, // 2 red pixels
+	}
+	assert.Equal(t, want, sa.spi.Written())
+	// each transfer is framed by the chip select
+	wantPinWrites := []gpioTestPinWrite{
+		{pin: "3", val: 0}, {pin: "1", val: 0}, {pin: "3", val: 1}, // column address set
+		{pin: "3", val: 0}, {pin: "1", val: 1}, {pin: "3", val: 1},
+		{pin: "3", val: 0}, {pin: "1", val: 0}, {pin: "3", val: 1}, // row address set
+		{pin: "3", val: 0}, {pin: "1", val: 1}, {pin: "3", val: 1},
+		{pin: "3", val: 0}, {pin: "1", val: 0}, {pin: "3", val: 1}, // memory write
+		{pin: "3", val: 0}, {pin: "1", val: 1}, {pin: "3", val: 1}, // pixel data
+	}
+	assert.Equal(t, wantPinWrites, a.pinWrites)
+}
+
+func TestTFTFillRect_invalid(t *testing.T) {
+	tests := map[string]struct {
+		x, y, w, h int
+		wantErr    string
+	}{
+		"error_negative_size": {
+			x: 0, y: 0, w: -2, h: -3,
+			wantErr: "width (-2) and height (-3) must be greater than zero",
+		},
+		"error_zero_height": {
+			x: 0, y: 0, w: 2, h: 0,
+			wantErr: "width (2) and height (0) must be greater than zero",
+		},
+		"error_outside": {
+			x: 100, y: 0, w: 1 << 30, h: 1 << 30,
+			wantErr: "is invalid or outside the display size 128x160 of 'ST7735",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, sa := initTestTFTDriverWithStubbedAdaptor(NewST7735Driver, "")
+			require.NoError(t, d.Start())
+			sa.spi.Reset()
+			// act
+			err := d.FillRect(tc.x, tc.y, tc.w, tc.h, 0xFFFF)
+			// assert
+			require.ErrorContains(t, err, tc.wantErr)
+			assert.Empty(t, sa.spi.Written())
+		})
+	}
+}
+
+func TestTFTBlit(t *testing.T) {
+	tests := map[string]struct {
+		x, y, w, h  int
+		pixels      []uint16
+		wantWritten []byte
+		wantErr     string
+	}{
+		"blit": {
+			x: 126, y: 159, w: 2, h: 1,
+			pixels: []uint16{0x1234, 0xABCD},
+			wantWritten: []byte{
+				0x2A, 0x00, 0x7E, 0x00, 0x7F, 0x2B, 0x00, 0x9F, 0x00, 0x9F, 0x2C,
+				0x12, 0x34, 0xAB, 0xCD,
+			},
+		},
+		"error_outside": {
+			x: 127, y: 0, w: 2, h: 1,
+			pixels:  []uint16{0, 0},
+			wantErr: "window (127, 0)-(128, 0) is invalid or outside the display size 128x160 of 'ST7735",
+		},
+		"error_pixel_count": {
+			x: 0, y: 0, w: 2, h: 2,
+			pixels:  []uint16{0, 0},
+			wantErr: "count of pixels (2) does not match the size 2x2",
+		},
+		"error_size": {
+			x: 0, y: 0, w: 0, h: 2,
+			wantErr: "width (0) and height (2) must be greater than zero",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, sa := initTestTFTDriverWithStubbedAdaptor(NewST7735Driver, "")
+			require.NoError(t, d.Start())
+			sa.spi.Reset()
+			// act
+			err := d.Blit(tc.x, tc.y, tc.w, tc.h, tc.pixels)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Empty(t, sa.spi.Written())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantWritten, sa.spi.Written())
+		})
+	}
+}
+
+func TestTFTBlit_chunks(t *testing.T) {
+	// arrange
+	d, _, sa := initTestTFTDriverWithStubbedAdaptor(NewILI9341Driver, "")
+	require.NoError(t, d.Start())
+	sa.spi.Reset()
+	// act
+	err := d.Clear(0xFFFF)
+	// assert
+	require.NoError(t, err)
+	assert.Len(t, sa.spi.Written(), 11+240*320*2)
+}
+
+func TestTFTDrawText(t *testing.T) {
+	// arrange
+	const fg, bg = 0xFFFF, 0x0000
+	d, _, sa := initTestTFTDriverWithStubbedAdaptor(NewST7735Driver, "")
+	require.NoError(t, d.Start())
+	sa.spi.Reset()
+	// act
+	err := d.DrawText(0, 0, "!", fg, bg)
+	// assert
+	require.NoError(t, err)
+	written := sa.spi.Written()
+	require.Len(t, written, 11+6*8*2)
+	assert.Equal(t, []byte{0x2A, 0x00, 0x00, 0x00, 0x05, 0x2B, 0x00, 0x00, 0x00, 0x07, 0x2C}, written[:11])
+	pixels := written[11:]
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 6; col++ {
+			// the glyph of '!' is a vertical line in the middle column with a gap in row 5
+			want := byte(0x00)
+			if col == 2 && row != 5 && row != 7 {
+				want = 0xFF
+			}
+			idx := 2 * (row*6 + col)
+			assert.Equal(t, want, pixels[idx], "row %d, col %d", row, col)
+		}
+	}
+	// act: text is clipped at the right border
+	sa.spi.Reset()
+	require.NoError(t, d.DrawText(120, 0, "ab", fg, bg))
+	assert.Len(t, sa.spi.Written(), 11+6*8*2)
+}
+
+func TestRGB565(t *testing.T) {
+	assert.Equal(t, uint16(0xF800), RGB565(0xFF, 0, 0))
+	assert.Equal(t, uint16(0x07E0), RGB565(0, 0xFF, 0))
+	assert.Equal(t, uint16(0x001F), RGB565(0, 0, 0xFF))
+	assert.Equal(t, uint16(0xFFFF), RGB565(0xFF, 0xFF, 0xFF))
+}
@@ -1,6 +1,9 @@
 package gobot
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 type eventChannel chan *Event
 
@@ -115,6 +118,7 @@ func (e *eventer) Subscribe() eventChannel {
 func (e *eventer) Unsubscribe(events eventChannel) {
 	e.eventsMutex.Lock()
 	defer e.eventsMutex.Unlock()
+
 	delete(e.outs, events)
 }
 
@@ -149,3 +153,72 @@ func (e *eventer) Once(n string, f func(s interface{})) error {
 
 	return nil
 }
+
+// SubscribeThrottled subscribes to the events with the given name of the given eventer, e.g. for a slow subscriber of
+// a fast sensor. At most one event per interval is delivered. Intermediate events are dropped, so the subscriber
+// always gets the newest one. The first event is delivered immediately. Other subscribers are not affected. The
+// returned function unsubscribes from the eventer, it needs to be used instead of Unsubscribe().
+func SubscribeThrottled(e Eventer, name string, minInterval time.Duration) (eventChannel, func()) {
+	in := e.Subscribe()
+	out := make(eventChannel, 1)
+	done := make(chan struct{})
+	var unsubscribeOnce sync.Once
+	unsubscribe := func() {
+		unsubscribeOnce.Do(func() {
+			e.Unsubscribe(in)
+			close(done)
+		})
+	}
+
+	go func() {
+		var pending *Event
+		var lastDelivery time.Time
+		var timerC <-chan time.Time
+		timer := time.NewTimer(minInterval)
+		timer.Stop()
+
+		for {
+			select {
+			case <-done:
+				timer.Stop()
+				return
+			case evt := <-in:
+				if evt.Name != name {
+					continue
+				}
+				if elapsed := time.Since(lastDelivery); elapsed >= minInterval {
+					deliverNewest(out, evt)
+					lastDelivery = time.Now()
+					continue
+				}
+				pending = evt
+				if timerC == nil {
+					timer.Reset(minInterval - time.Since(lastDelivery))
+					timerC = timer.C
+				}
+			case <-timerC:
+				timerC = nil
+				deliverNewest(out, pending)
+				pending = nil
+				lastDelivery = time.Now()
+			}
+		}
+	}()
+
+	return out, unsubscribe
+}
+
+// deliverNewest writes the event to the channel without blocking, an undelivered event is replaced
+func deliverNewest(out eventChannel, evt *Event) {
+	for {
+		select {
+		case out <- evt:
+			return
+		default:
+		}
+		select {
+		case <-out:
+		default:
+		}
+	}
+}
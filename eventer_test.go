@@ -76,3 +76,52 @@ func TestEventerOnce(t *testing.T) {
 	case <-time.After(10 * time.Millisecond):
 	}
 }
+
+func TestEventerSubscribeThrottled(t *testing.T) {
+	// arrange
+	const interval = 50 * time.Millisecond
+	e := NewEventer()
+	e.AddEvent("test")
+	throttled, unsubscribe := SubscribeThrottled(e, "test", interval)
+	exact := e.Subscribe()
+	// act
+	for i := 0; i < 10; i++ {
+		e.Publish("test", i)
+		e.Publish("other", i)
+	}
+	// assert: the exact subscriber is not affected
+	for i := 0; i < 10; i++ {
+		for _, name := range []string{"test", "other"} {
+			select {
+			case evt := <-exact:
+				assert.Equal(t, name, evt.Name)
+				assert.Equal(t, i, evt.Data)
+			case <-time.After(time.Second):
+				t.Fatalf("event %d was not published", i)
+			}
+		}
+	}
+	// assert: the first event is delivered immediately, the remaining burst is coalesced to the latest one
+	var got []interface{}
+	timeout := time.After(3 * interval)
+collect:
+	for {
+		select {
+		case evt := <-throttled:
+			assert.Equal(t, "test", evt.Name)
+			got = append(got, evt.Data)
+		case <-timeout:
+			break collect
+		}
+	}
+	assert.Equal(t, []interface{}{0, 9}, got)
+	// act & assert: no more events after unsubscribe
+	unsubscribe()
+	unsubscribe() // a second call does nothing
+	e.Publish("test", 10)
+	select {
+	case evt := <-throttled:
+		t.Errorf("unexpected event after unsubscribe: %v", evt)
+	case <-time.After(2 * interval):
+	}
+}
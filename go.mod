@@ -20,6 +20,7 @@ require (
 	gocv.io/x/gocv v0.35.0
 	golang.org/x/net v0.19.0
 	golang.org/x/sys v0.16.0
+	gopkg.in/yaml.v3 v3.0.1
 	periph.io/x/conn/v3 v3.7.0
 	periph.io/x/host/v3 v3.8.2
 	tinygo.org/x/bluetooth v0.8.0
@@ -44,5 +45,4 @@ require (
 	github.com/tinygo-org/cbgo v0.0.4 // indirect
 	golang.org/x/crypto v0.17.0 // indirect
 	golang.org/x/sync v0.6.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
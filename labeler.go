@@ -0,0 +1,44 @@
+package gobot
+
+import "sync"
+
+type labeler struct {
+	labels map[string]string
+	mutex  sync.Mutex
+}
+
+// Labeler is the interface which describes the behaviour for a Driver which can be tagged with labels, e.g.
+// "axis":"x", to group or filter the devices of a robot.
+type Labeler interface {
+	// SetLabel sets the label with the given key to the given value.
+	SetLabel(key, value string)
+	// Labels returns a copy of all labels.
+	Labels() (labels map[string]string)
+}
+
+// NewLabeler returns a new Labeler.
+func NewLabeler() Labeler {
+	return &labeler{
+		labels: make(map[string]string),
+	}
+}
+
+// SetLabel sets the label with the given key to the given value.
+func (l *labeler) SetLabel(key, value string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.labels[key] = value
+}
+
+// Labels returns a copy of all labels.
+func (l *labeler) Labels() map[string]string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	labels := make(map[string]string, len(l.labels))
+	for key, value := range l.labels {
+		labels[key] = value
+	}
+	return labels
+}
@@ -0,0 +1,21 @@
+package gobot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabeler(t *testing.T) {
+	// arrange
+	l := NewLabeler()
+	// act
+	l.SetLabel("axis", "x")
+	l.SetLabel("side", "left")
+	l.SetLabel("side", "right")
+	// assert
+	assert.Equal(t, map[string]string{"axis": "x", "side": "right"}, l.Labels())
+	// the returned labels are a copy
+	l.Labels()["axis"] = "y"
+	assert.Equal(t, "x", l.Labels()["axis"])
+}
@@ -0,0 +1,169 @@
+package gobot
+
+import (
+	"context"
+	"sync"
+)
+
+// EventsBackpressure defines the behavior of the aggregated event stream of the master, if the subscriber is slower
+// than the events are published.
+type EventsBackpressure int
+
+const (
+	// EventsBlock waits until the subscriber has read the event. This slows down the publishing robots and devices.
+	EventsBlock EventsBackpressure = iota
+	// EventsDrop discards events, which can not be buffered for the subscriber. Publishers are never slowed down.
+	EventsDrop
+)
+
+// masterEventsBufferSize is the size of the buffer of the aggregated event stream
+const masterEventsBufferSize = 100
+
+// SourcedEvent is an event of a robot or device, tagged with its source.
+type SourcedEvent struct {
+	Robot  string // name of the robot
+	Device string // name of the device, empty for events of the robot itself
+	*Event
+}
+
+// masterEventStream contains the state of an aggregated event stream
+type masterEventStream struct {
+	ctx          context.Context //nolint:containedctx // done by intention
+	out          chan *SourcedEvent
+	backpressure EventsBackpressure
+	wg           sync.WaitGroup
+	mutex        sync.Mutex
+	devices      map[string]chan struct{} // stop channels of the device subscriptions by "robot/device"
+}
+
+// Events returns a single stream of the events of all robots and their devices, e.g. for central logging or
+// monitoring. Each event is tagged with the name of the robot and device. Devices, which are added to a robot at
+// runtime by Robot.AddDeviceRuntime(), are included automatically and removed ones are excluded. Robots, which are
+// added to the master afterwards, are not included. The stream is closed after the given context is done.
+func (g *Master) Events(ctx context.Context, backpressure EventsBackpressure) <-chan *SourcedEvent {
+	s := &masterEventStream{
+		ctx:          ctx,
+		out:          make(chan *SourcedEvent, masterEventsBufferSize),
+		backpressure: backpressure,
+		devices:      make(map[string]chan struct{}),
+	}
+
+	g.robots.Each(func(r *Robot) {
+		s.forward(r, r.Name, "", nil, func(evt *Event) {
+			switch evt.Name {
+			case DeviceAdded:
+				if name, ok := evt.Data.(string); ok {
+					s.addDevice(r, r.Device(name))
+				}
+			case DeviceRemoved:
+				if name, ok := evt.Data.(string); ok {
+					s.removeDevice(r.Name, name)
+				}
+			}
+		})
+		r.Devices().Each(func(d Device) {
+			s.addDevice(r, d)
+		})
+	})
+
+	go func() {
+		<-ctx.Done()
+		s.wg.Wait()
+		close(s.out)
+	}()
+
+	return s.out
+}
+
+// addDevice starts forwarding the events of the given device, if it is an Eventer
+func (s *masterEventStream) addDevice(r *Robot, d Device) {
+	eventer, ok := d.(Eventer)
+	if !ok {
+		return
+	}
+
+	key := r.Name + "/" + d.Name()
+	stop := make(chan struct{})
+
+	s.mutex.Lock()
+	if _, exists := s.devices[key]; exists {
+		s.mutex.Unlock()
+		return
+	}
+	s.devices[key] = stop
+	s.mutex.Unlock()
+
+	s.forward(eventer, r.Name, d.Name(), stop, nil)
+}
+
+// removeDevice stops forwarding the events of the device with the given name
+func (s *masterEventStream) removeDevice(robotName, deviceName string) {
+	key := robotName + "/" + deviceName
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if stop, ok := s.devices[key]; ok {
+		close(stop)
+		delete(s.devices, key)
+	}
+}
+
+// forward subscribes to the given eventer and delivers its events tagged with the source, until the context is done
+// or the stop channel is closed. The optional function is called for each event before delivery.
+func (s *masterEventStream) forward(eventer Eventer, robotName, deviceName string, stop chan struct{},
+	onEvent func(*Event),
+) {
+	in := eventer.Subscribe()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			// drain the subscription, because the eventer blocks on a full channel while unsubscribing
+			unsubscribed := make(chan struct{})
+			go func() {
+				eventer.Unsubscribe(in)
+				close(unsubscribed)
+			}()
+			for {
+				select {
+				case <-in:
+				case <-unsubscribed:
+					return
+				}
+			}
+		}()
+
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-stop:
+				return
+			case evt := <-in:
+				if onEvent != nil {
+					onEvent(evt)
+				}
+				s.deliver(&SourcedEvent{Robot: robotName, Device: deviceName, Event: evt}, stop)
+			}
+		}
+	}()
+}
+
+// deliver writes the event to the stream according to the backpressure behavior
+func (s *masterEventStream) deliver(evt *SourcedEvent, stop chan struct{}) {
+	if s.backpressure == EventsDrop {
+		select {
+		case s.out <- evt:
+		default:
+		}
+		return
+	}
+
+	select {
+	case s.out <- evt:
+	case <-s.ctx.Done():
+	case <-stop:
+	}
+}
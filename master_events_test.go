@@ -0,0 +1,122 @@
+package gobot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEventerDriver struct {
+	*testDriver
+	Eventer
+}
+
+func newTestEventerDriver(name string) *testEventerDriver {
+	return &testEventerDriver{
+		testDriver: newTestDriver(newTestAdaptor("Connection1", "/dev/null"), name, "0"),
+		Eventer:    NewEventer(),
+	}
+}
+
+// nextSourcedEvent returns the next event of the stream with the given name
+func nextSourcedEvent(t *testing.T, events <-chan *SourcedEvent, name string) *SourcedEvent {
+	t.Helper()
+	for {
+		select {
+		case evt, ok := <-events:
+			require.True(t, ok, "stream was closed")
+			if evt.Name == name {
+				return evt
+			}
+		case <-time.After(time.Second):
+			require.Fail(t, "event was not delivered", name)
+			return nil
+		}
+	}
+}
+
+func TestMasterEvents(t *testing.T) {
+	// arrange
+	g := NewMaster()
+	d1 := newTestEventerDriver("Sensor1")
+	d2 := newTestEventerDriver("Sensor2")
+	r1 := g.AddRobot(NewRobot("Robot1", []Device{d1}))
+	r2 := g.AddRobot(NewRobot("Robot2", []Device{d2}))
+	ctx, cancel := context.WithCancel(context.Background())
+	events := g.Events(ctx, EventsBlock)
+	// act & assert: device events
+	d1.Publish("data", 1)
+	evt := nextSourcedEvent(t, events, "data")
+	assert.Equal(t, "Robot1", evt.Robot)
+	assert.Equal(t, "Sensor1", evt.Device)
+	assert.Equal(t, 1, evt.Data)
+	d2.Publish("data", 2)
+	evt = nextSourcedEvent(t, events, "data")
+	assert.Equal(t, "Robot2", evt.Robot)
+	assert.Equal(t, "Sensor2", evt.Device)
+	assert.Equal(t, 2, evt.Data)
+	// act & assert: robot events
+	r2.Publish(RobotReady, r2.Name)
+	evt = nextSourcedEvent(t, events, RobotReady)
+	assert.Equal(t, "Robot2", evt.Robot)
+	assert.Empty(t, evt.Device)
+	// act & assert: device added at runtime
+	require.NoError(t, r1.Start(false))
+	d3 := newTestEventerDriver("Sensor3")
+	_, err := r1.AddDeviceRuntime(d3)
+	require.NoError(t, err)
+	evt = nextSourcedEvent(t, events, DeviceAdded)
+	assert.Equal(t, "Robot1", evt.Robot)
+	d3.Publish("data", 3)
+	evt = nextSourcedEvent(t, events, "data")
+	assert.Equal(t, "Robot1", evt.Robot)
+	assert.Equal(t, "Sensor3", evt.Device)
+	// act & assert: stream is closed on cancel
+	cancel()
+	assert.Eventually(t, func() bool {
+		for {
+			select {
+			case _, ok := <-events:
+				if !ok {
+					return true
+				}
+			default:
+				return false
+			}
+		}
+	}, time.Second, time.Millisecond)
+	require.NoError(t, r1.Stop())
+}
+
+func TestMasterEvents_drop(t *testing.T) {
+	// arrange
+	g := NewMaster()
+	d := newTestEventerDriver("Sensor1")
+	g.AddRobot(NewRobot("Robot1", []Device{d}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := g.Events(ctx, EventsDrop)
+	// act: publish more events than the stream can buffer without reading
+	const count = masterEventsBufferSize + 50
+	for i := 0; i < count; i++ {
+		d.Publish("data", i)
+	}
+	// assert: the publisher was not blocked and events were dropped
+	received := 0
+	for {
+		select {
+		case evt := <-events:
+			if evt.Name == "data" {
+				received++
+			}
+			continue
+		case <-time.After(100 * time.Millisecond):
+		}
+		break
+	}
+	assert.Positive(t, received)
+	assert.Less(t, received, count)
+}
@@ -207,6 +207,24 @@ func (a *DigitalPinsAdaptor) DigitalRead(id string) (int, error) {
 	return pin.Read()
 }
 
+// DigitalReadOutput reads back the level of the given output pin, e.g. to verify a write. In contrast to DigitalRead()
+// the direction of the pin is not changed, so the pin needs to be written before.
+func (a *DigitalPinsAdaptor) DigitalReadOutput(id string) (int, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.pins == nil {
+		return 0, fmt.Errorf("not connected for pin %s", id)
+	}
+
+	pin := a.pins[id]
+	if pin == nil {
+		return 0, fmt.Errorf("pin %s was not written before, so it can not be read back", id)
+	}
+
+	return pin.Read()
+}
+
 // DigitalWrite writes digital value to specified pin
 func (a *DigitalPinsAdaptor) DigitalWrite(id string, val byte) error {
 	a.mutex.Lock()
@@ -219,6 +237,25 @@ func (a *DigitalPinsAdaptor) DigitalWrite(id string, val byte) error {
 	return pin.Write(int(val))
 }
 
+// SetupPins configures the direction of all given pins at once, outputs are initialized with the given value. This
+// is used by drivers to prevent glitches caused by the configuration of each pin at first usage.
+func (a *DigitalPinsAdaptor) SetupPins(pins []gobot.PinConfig) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for _, p := range pins {
+		opt := system.WithPinDirectionInput()
+		if p.Output {
+			opt = system.WithPinDirectionOutput(p.InitialValue)
+		}
+		if _, err := a.digitalPin(p.Pin, opt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (a *DigitalPinsAdaptor) digitalPin(
 	id string,
 	opts ...func(gobot.DigitalPinOptioner) bool,
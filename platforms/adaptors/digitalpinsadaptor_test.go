@@ -21,6 +21,8 @@ var (
 	_ gobot.DigitalPinnerProvider = (*DigitalPinsAdaptor)(nil)
 	_ gpio.DigitalReader          = (*DigitalPinsAdaptor)(nil)
 	_ gpio.DigitalWriter          = (*DigitalPinsAdaptor)(nil)
+	_ gpio.DigitalOutputReader    = (*DigitalPinsAdaptor)(nil)
+	_ gpio.PinSetupper            = (*DigitalPinsAdaptor)(nil)
 )
 
 func initTestDigitalPinsAdaptorWithMockedFilesystem(mockPaths []string) (*DigitalPinsAdaptor, *system.MockFilesystem) {
@@ -157,6 +159,63 @@ func TestDigitalRead(t *testing.T) {
 	require.ErrorContains(t, err, "write error")
 }
 
+func TestDigitalReadOutput(t *testing.T) {
+	// arrange
+	mockedPaths := []string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/unexport",
+		"/sys/class/gpio/gpio25/value",
+		"/sys/class/gpio/gpio25/direction",
+	}
+	a, fs := initTestDigitalPinsAdaptorWithMockedFilesystem(mockedPaths)
+	require.NoError(t, a.DigitalWrite("14", 1))
+	// act
+	got, err := a.DigitalReadOutput("14")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, got)
+	assert.Equal(t, "out", fs.Files["/sys/class/gpio/gpio25/direction"].Contents)
+	// assert error for a pin, which was not written before
+	_, err = a.DigitalReadOutput("15")
+	require.EqualError(t, err, "pin 15 was not written before, so it can not be read back")
+	// assert error bubbling for read errors
+	fs.WithReadError = true
+	_, err = a.DigitalReadOutput("14")
+	require.ErrorContains(t, err, "read error")
+}
+
+func TestDigitalPinsSetupPins(t *testing.T) {
+	// arrange
+	mockedPaths := []string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/unexport",
+		"/sys/class/gpio/gpio14/value",
+		"/sys/class/gpio/gpio14/direction",
+		"/sys/class/gpio/gpio15/value",
+		"/sys/class/gpio/gpio15/direction",
+		"/sys/class/gpio/gpio16/value",
+		"/sys/class/gpio/gpio16/direction",
+	}
+	a, fs := initTestDigitalPinsAdaptorWithMockedFilesystem(mockedPaths)
+	pins := []gobot.PinConfig{
+		{Pin: "3", Output: true},
+		{Pin: "4", Output: true, InitialValue: 1},
+		{Pin: "5"},
+	}
+	// act
+	err := a.SetupPins(pins)
+	// assert
+	require.NoError(t, err)
+	assert.Len(t, a.pins, 3)
+	assert.Equal(t, "out", fs.Files["/sys/class/gpio/gpio14/direction"].Contents)
+	assert.Equal(t, "0", fs.Files["/sys/class/gpio/gpio14/value"].Contents)
+	assert.Equal(t, "out", fs.Files["/sys/class/gpio/gpio15/direction"].Contents)
+	assert.Equal(t, "1", fs.Files["/sys/class/gpio/gpio15/value"].Contents)
+	assert.Equal(t, "in", fs.Files["/sys/class/gpio/gpio16/direction"].Contents)
+	// act & assert: error for unknown pins
+	require.ErrorContains(t, a.SetupPins([]gobot.PinConfig{{Pin: "x"}}), "not a valid pin")
+}
+
 func TestDigitalPinConcurrency(t *testing.T) {
 	oldProcs := runtime.GOMAXPROCS(0)
 	runtime.GOMAXPROCS(8)
@@ -11,28 +11,50 @@ import (
 	"gobot.io/x/gobot/v2/system"
 )
 
-type i2cBusNumberValidator func(busNumber int) error
+type (
+	i2cBusNumberValidator func(busNumber int) error
+	i2cBusSpeedApplier    func(busNumber int, hz int) error
+)
+
+// i2cBusSpeeds contains the supported speeds in Hz (standard mode, fast mode and fast mode plus)
+var i2cBusSpeeds = []int{100000, 400000, 1000000}
 
 // I2cBusAdaptor is a adaptor for i2c bus, normally used for composition in platforms.
 type I2cBusAdaptor struct {
 	sys              *system.Accesser
 	validateNumber   i2cBusNumberValidator
+	applySpeed       i2cBusSpeedApplier
 	defaultBusNumber int
 	mutex            sync.Mutex
 	buses            map[int]gobot.I2cSystemDevicer
+	speeds           map[int]int
 }
 
 // NewI2cBusAdaptor provides the access to i2c buses of the board. The validator is used to check the bus number,
 // which is given by user, to the abilities of the board.
-func NewI2cBusAdaptor(sys *system.Accesser, v i2cBusNumberValidator, defaultBusNr int) *I2cBusAdaptor {
+func NewI2cBusAdaptor(sys *system.Accesser, v i2cBusNumberValidator, defaultBusNr int,
+	opts ...func(*I2cBusAdaptor),
+) *I2cBusAdaptor {
 	a := &I2cBusAdaptor{
 		sys:              sys,
 		validateNumber:   v,
 		defaultBusNumber: defaultBusNr,
+		speeds:           make(map[int]int),
+	}
+	for _, o := range opts {
+		o(a)
 	}
 	return a
 }
 
+// WithI2cBusSpeedApplier sets the platform specific function to apply the speed of a bus, see SetBusSpeed(). The
+// function is called before the bus is opened for the first time.
+func WithI2cBusSpeedApplier(applier func(busNumber int, hz int) error) func(*I2cBusAdaptor) {
+	return func(a *I2cBusAdaptor) {
+		a.applySpeed = applier
+	}
+}
+
 // Connect prepares the connection to i2c buses.
 func (a *I2cBusAdaptor) Connect() error {
 	a.mutex.Lock()
@@ -74,6 +96,11 @@ func (a *I2cBusAdaptor) GetI2cConnection(address int, busNum int) (i2c.Connectio
 		if err != nil {
 			return nil, err
 		}
+		if hz, ok := a.speeds[busNum]; ok {
+			if err := a.applySpeed(busNum, hz); err != nil {
+				return nil, err
+			}
+		}
 		bus, err = a.sys.NewI2cDevice(fmt.Sprintf("/dev/i2c-%d", busNum))
 		if err != nil {
 			return nil, err
@@ -87,3 +114,38 @@ func (a *I2cBusAdaptor) GetI2cConnection(address int, busNum int) (i2c.Connectio
 func (a *I2cBusAdaptor) DefaultI2cBus() int {
 	return a.defaultBusNumber
 }
+
+// SetBusSpeed sets the speed in Hz of the given i2c bus, e.g. 100000 for long wires or slow devices. The speed is
+// applied before the first device on this bus is initialized, so it needs to be called before the start of the
+// devices. An error is returned for unsupported speeds, if the bus is already in use or if the adaptor does not
+// support to change the bus speed.
+func (a *I2cBusAdaptor) SetBusSpeed(busNum, hz int) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.applySpeed == nil {
+		return fmt.Errorf("setting the speed of i2c bus %d is not supported by the adaptor", busNum)
+	}
+	if err := a.validateNumber(busNum); err != nil {
+		return err
+	}
+	if !isSupportedI2cBusSpeed(hz) {
+		return fmt.Errorf("i2c bus speed %d Hz not supported, use one of %v", hz, i2cBusSpeeds)
+	}
+	if a.buses[busNum] != nil {
+		return fmt.Errorf("i2c bus %d is already in use, the speed needs to be set before the first device is started",
+			busNum)
+	}
+
+	a.speeds[busNum] = hz
+	return nil
+}
+
+func isSupportedI2cBusSpeed(hz int) bool {
+	for _, speed := range i2cBusSpeeds {
+		if speed == hz {
+			return true
+		}
+	}
+	return false
+}
@@ -113,3 +113,101 @@ func TestI2cGetDefaultBus(t *testing.T) {
 	a := NewI2cBusAdaptor(nil, nil, 2)
 	assert.Equal(t, 2, a.DefaultI2cBus())
 }
+
+func TestI2cSetBusSpeed(t *testing.T) {
+	tests := map[string]struct {
+		noApplier   bool
+		busNr       int
+		hz          int
+		openBefore  bool
+		applierErr  error
+		wantApplied []int
+		wantSetErr  string
+		wantOpenErr string
+	}{
+		"apply_before_open": {
+			busNr:       1,
+			hz:          400000,
+			wantApplied: []int{1, 400000},
+		},
+		"error_applier": {
+			busNr:       1,
+			hz:          100000,
+			applierErr:  fmt.Errorf("apply error"),
+			wantApplied: []int{1, 100000},
+			wantOpenErr: "apply error",
+		},
+		"error_not_supported_by_adaptor": {
+			noApplier:  true,
+			busNr:      1,
+			hz:         100000,
+			wantSetErr: "setting the speed of i2c bus 1 is not supported by the adaptor",
+		},
+		"error_invalid_bus": {
+			busNr:      2,
+			hz:         100000,
+			wantSetErr: "2 not valid",
+		},
+		"error_invalid_speed": {
+			busNr:      1,
+			hz:         200000,
+			wantSetErr: "i2c bus speed 200000 Hz not supported, use one of [100000 400000 1000000]",
+		},
+		"error_bus_in_use": {
+			busNr:      1,
+			hz:         100000,
+			openBefore: true,
+			wantSetErr: "i2c bus 1 is already in use, the speed needs to be set before the first device is started",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			sys := system.NewAccesser()
+			sys.UseMockSyscall()
+			_ = sys.UseMockFilesystem([]string{i2cBus1})
+			validator := func(busNr int) error {
+				if busNr > 1 {
+					return fmt.Errorf("%d not valid", busNr)
+				}
+				return nil
+			}
+			var applied []int
+			applierErr := tc.applierErr
+			var opts []func(*I2cBusAdaptor)
+			if !tc.noApplier {
+				opts = append(opts, WithI2cBusSpeedApplier(func(busNr int, hz int) error {
+					applied = append(applied, busNr, hz)
+					return applierErr
+				}))
+			}
+			a := NewI2cBusAdaptor(sys, validator, 1, opts...)
+			require.NoError(t, a.Connect())
+			if tc.openBefore {
+				_, err := a.GetI2cConnection(0xff, tc.busNr)
+				require.NoError(t, err)
+			}
+			// act
+			err := a.SetBusSpeed(tc.busNr, tc.hz)
+			// assert
+			if tc.wantSetErr != "" {
+				require.EqualError(t, err, tc.wantSetErr)
+				assert.Empty(t, a.speeds)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, applied)
+			// act & assert: the speed is applied before the bus is opened and only once
+			_, err = a.GetI2cConnection(0xff, tc.busNr)
+			if tc.wantOpenErr != "" {
+				require.EqualError(t, err, tc.wantOpenErr)
+				assert.Empty(t, a.buses)
+			} else {
+				require.NoError(t, err)
+				_, err = a.GetI2cConnection(0xfe, tc.busNr)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantApplied, applied)
+		})
+	}
+}
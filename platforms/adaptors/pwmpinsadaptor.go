@@ -250,6 +250,45 @@ func (a *PWMPinsAdaptor) SetPeriod(id string, period uint32) error {
 	return setPeriod(pin, period, a.pwmPinsCfg.adjustDutyOnSetPeriod)
 }
 
+// SetPWMPolarity sets the polarity of the specified PWM pin immediately. With "false" the output is inverted. The pin
+// is disabled during the change, because most PWM chips do not allow the change of a running output. It implements
+// the gpio.PwmPolaritySetter interface. A center-aligned mode is not supported by the sysfs PWM, so the interface
+// gpio.PwmCenterAligner is not implemented.
+func (a *PWMPinsAdaptor) SetPWMPolarity(id string, normal bool) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.pwmPinsCfg.usePiBlasterPin && !normal {
+		return fmt.Errorf("inverted polarity is not supported by pi-blaster for PWM pin id '%s'", id)
+	}
+
+	pin, err := a.pwmPin(id)
+	if err != nil {
+		return err
+	}
+
+	enabled, err := pin.Enabled()
+	if err != nil {
+		return err
+	}
+
+	if enabled {
+		if err := pin.SetEnabled(false); err != nil {
+			return err
+		}
+	}
+
+	if err := pin.SetPolarity(normal); err != nil {
+		return err
+	}
+
+	if enabled {
+		return pin.SetEnabled(true)
+	}
+
+	return nil
+}
+
 // PWMPin initializes the pin for PWM and returns matched pwmPin for specified pin number.
 // It implements the PWMPinnerProvider interface.
 func (a *PWMPinsAdaptor) PWMPin(id string) (gobot.PWMPinner, error) {
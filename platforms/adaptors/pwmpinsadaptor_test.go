@@ -50,6 +50,7 @@ var (
 	_ gobot.PWMPinnerProvider = (*PWMPinsAdaptor)(nil)
 	_ gpio.PwmWriter          = (*PWMPinsAdaptor)(nil)
 	_ gpio.ServoWriter        = (*PWMPinsAdaptor)(nil)
+	_ gpio.PwmPolaritySetter  = (*PWMPinsAdaptor)(nil)
 )
 
 func initTestPWMPinsAdaptorWithMockedFilesystem(mockPaths []string) (*PWMPinsAdaptor, *system.MockFilesystem) {
@@ -358,6 +359,56 @@ func TestSetPeriod(t *testing.T) {
 	require.ErrorContains(t, err, "'not_exist' is not a valid id of a PWM pin")
 }
 
+func TestSetPWMPolarity(t *testing.T) {
+	tests := map[string]struct {
+		pin              string
+		normal           bool
+		usePiBlaster     bool
+		simulateWriteErr bool
+		wantPolarity     string
+		wantErr          string
+	}{
+		"inverted": {
+			pin:          "33",
+			wantPolarity: "inversed",
+		},
+		"normal": {
+			pin:          "33",
+			normal:       true,
+			wantPolarity: "normal",
+		},
+		"error_pi_blaster_inverted": {
+			pin:          "33",
+			usePiBlaster: true,
+			wantPolarity: "normal",
+			wantErr:      "inverted polarity is not supported by pi-blaster for PWM pin id '33'",
+		},
+		"error_not_valid_pin": {
+			pin:          "notexist",
+			wantPolarity: "normal",
+			wantErr:      "'notexist' is not a valid id of a PWM pin",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, fs := initTestPWMPinsAdaptorWithMockedFilesystem(pwmMockPaths)
+			require.NoError(t, a.PwmWrite("33", 100))
+			a.pwmPinsCfg.usePiBlasterPin = tc.usePiBlaster
+			// act
+			err := a.SetPWMPolarity(tc.pin, tc.normal)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantPolarity, fs.Files[pwm44PolarityPath].Contents)
+			assert.Equal(t, "1", fs.Files[pwm44EnablePath].Contents)
+		})
+	}
+}
+
 func Test_PWMPin(t *testing.T) {
 	translateErr := "translator_error"
 	translator := func(string) (string, int, error) { return pwmDir, 44, nil }
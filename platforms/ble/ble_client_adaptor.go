@@ -1,6 +1,7 @@
 package ble
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -16,6 +17,18 @@ var (
 	bleMutex       sync.Mutex
 )
 
+// limits of the BLE specification and the unit of intervals
+const (
+	bleDefaultMTU            = 23
+	bleMaxMTU                = 517
+	bleMinConnectionInterval = 7500 * time.Microsecond
+	bleMaxConnectionInterval = 4 * time.Second
+	bleDurationUnit          = 625 * time.Microsecond // unit of bluetooth.Duration
+)
+
+// ErrUnknownCharacteristic is returned, if the requested characteristic was not discovered on the BLE peripheral
+var ErrUnknownCharacteristic = errors.New("Unknown characteristic")
+
 // BLEConnector is the interface that a BLE ClientAdaptor must implement
 type BLEConnector interface {
 	gobot.Adaptor
@@ -43,18 +56,26 @@ type ClientAdaptor struct {
 
 	connected        bool
 	withoutResponses bool
+
+	connParams bluetooth.ConnectionParams
+	mtu        int
+	requestMTU func(size int) (int, error)
 }
 
 // NewClientAdaptor returns a new ClientAdaptor given an address
 func NewClientAdaptor(address string) *ClientAdaptor {
-	return &ClientAdaptor{
+	b := &ClientAdaptor{
 		name:             gobot.DefaultName("BLEClient"),
 		address:          address,
 		AdapterName:      "default",
 		connected:        false,
 		withoutResponses: false,
 		characteristics:  make(map[string]bluetooth.DeviceCharacteristic),
+		mtu:              bleDefaultMTU,
 	}
+	b.requestMTU = b.negotiatedMTU
+
+	return b
 }
 
 // Name returns the name for the adaptor
@@ -70,6 +91,67 @@ func (b *ClientAdaptor) Address() string { return b.address }
 // writing characteristics for this device
 func (b *ClientAdaptor) WithoutResponses(use bool) { b.withoutResponses = use }
 
+// SetConnectionParams sets the preferred minimum and maximum connection interval, which is used on next Connect(). The
+// shorter the interval, the higher is the throughput but also the power consumption. A valid value is between 7.5 ms
+// and 4 s. Some stacks (e.g. BlueZ on Linux) ignore the preference and use its own default.
+func (b *ClientAdaptor) SetConnectionParams(minInterval, maxInterval time.Duration) error {
+	if minInterval < bleMinConnectionInterval || maxInterval > bleMaxConnectionInterval {
+		return fmt.Errorf("connection interval needs to be between %s and %s", bleMinConnectionInterval,
+			bleMaxConnectionInterval)
+	}
+	if minInterval > maxInterval {
+		return fmt.Errorf("minimum connection interval %s is greater than the maximum %s", minInterval, maxInterval)
+	}
+
+	b.connParams.MinInterval = bluetooth.NewDuration(minInterval)
+	b.connParams.MaxInterval = bluetooth.NewDuration(maxInterval)
+
+	return nil
+}
+
+// ConnectionParams returns the preferred minimum and maximum connection interval. Zero values means the default of
+// the stack is used.
+func (b *ClientAdaptor) ConnectionParams() (time.Duration, time.Duration) {
+	return time.Duration(b.connParams.MinInterval) * bleDurationUnit,
+		time.Duration(b.connParams.MaxInterval) * bleDurationUnit
+}
+
+// RequestMTU requests the given maximum transmission unit (MTU) for the connection and returns the negotiated value.
+// Larger values improve the throughput for data-heavy characteristics. A valid value is between 23 and 517 bytes. The
+// negotiated value is lower than the requested one, if the peripheral or the stack do not support the requested size.
+func (b *ClientAdaptor) RequestMTU(size int) (int, error) {
+	if !b.connected {
+		return 0, fmt.Errorf("Cannot request MTU from BLE device until connected")
+	}
+	if size < bleDefaultMTU || size > bleMaxMTU {
+		return 0, fmt.Errorf("MTU (%d) needs to be between %d and %d", size, bleDefaultMTU, bleMaxMTU)
+	}
+
+	negotiated, err := b.requestMTU(size)
+	if err != nil {
+		return 0, err
+	}
+	if negotiated > size {
+		negotiated = size
+	}
+	b.mtu = negotiated
+
+	return b.mtu, nil
+}
+
+// MTU returns the currently used maximum transmission unit, the default is 23 bytes.
+func (b *ClientAdaptor) MTU() int { return b.mtu }
+
+// negotiatedMTU returns the MTU, which was negotiated by the stack for the connection. The stack exchanges the largest
+// supported MTU already on connect, so the value is taken from the first discovered characteristic.
+func (b *ClientAdaptor) negotiatedMTU(int) (int, error) {
+	for _, char := range b.characteristics {
+		return characteristicMTU(char)
+	}
+
+	return 0, fmt.Errorf("no characteristic discovered to get the MTU from")
+}
+
 // Connect initiates a connection to the BLE peripheral. Returns true on successful connection.
 func (b *ClientAdaptor) Connect() error {
 	bleMutex.Lock()
@@ -103,7 +185,7 @@ func (b *ClientAdaptor) Connect() error {
 
 	// wait to connect to peripheral device
 	result := <-ch
-	b.device, err = b.adpt.Connect(result.Address, bluetooth.ConnectionParams{})
+	b.device, err = b.adpt.Connect(result.Address, b.connParams)
 	if err != nil {
 		return err
 	}
@@ -170,7 +252,7 @@ func (b *ClientAdaptor) ReadCharacteristic(cUUID string) ([]byte, error) {
 		return buf[:n], nil
 	}
 
-	return nil, fmt.Errorf("Unknown characteristic: %s", cUUID)
+	return nil, fmt.Errorf("%w: %s", ErrUnknownCharacteristic, cUUID)
 }
 
 // WriteCharacteristic writes bytes to the BLE device for the
@@ -190,7 +272,7 @@ func (b *ClientAdaptor) WriteCharacteristic(cUUID string, data []byte) error {
 		return nil
 	}
 
-	return fmt.Errorf("Unknown characteristic: %s", cUUID)
+	return fmt.Errorf("%w: %s", ErrUnknownCharacteristic, cUUID)
 }
 
 // Subscribe subscribes to notifications from the BLE device for the
@@ -209,7 +291,7 @@ func (b *ClientAdaptor) Subscribe(cUUID string, f func([]byte, error)) error {
 		return char.EnableNotifications(fn)
 	}
 
-	return fmt.Errorf("Unknown characteristic: %s", cUUID)
+	return fmt.Errorf("%w: %s", ErrUnknownCharacteristic, cUUID)
 }
 
 // getBLEAdapter is singleton for bluetooth adapter connection
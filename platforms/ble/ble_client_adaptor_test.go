@@ -1,10 +1,13 @@
 package ble
 
 import (
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
 )
@@ -22,3 +25,127 @@ func TestBLEClientAdaptorName(t *testing.T) {
 	a.SetName("awesome")
 	assert.Equal(t, "awesome", a.Name())
 }
+
+func TestBLEClientAdaptorRequestMTU(t *testing.T) {
+	tests := map[string]struct {
+		connected     bool
+		size          int
+		negotiated    int
+		negotiateErr  error
+		want          int
+		wantRequested int
+		wantErr       string
+	}{
+		"negotiated_as_requested": {
+			connected:     true,
+			size:          247,
+			negotiated:    517,
+			want:          247,
+			wantRequested: 247,
+		},
+		"negotiated_lower": {
+			connected:     true,
+			size:          517,
+			negotiated:    185,
+			want:          185,
+			wantRequested: 517,
+		},
+		"error_negotiation": {
+			connected:     true,
+			size:          100,
+			negotiateErr:  errors.New("negotiation error"),
+			want:          bleDefaultMTU,
+			wantRequested: 100,
+			wantErr:       "negotiation error",
+		},
+		"error_too_small": {
+			connected: true,
+			size:      22,
+			want:      bleDefaultMTU,
+			wantErr:   "MTU (22) needs to be between 23 and 517",
+		},
+		"error_too_big": {
+			connected: true,
+			size:      518,
+			want:      bleDefaultMTU,
+			wantErr:   "MTU (518) needs to be between 23 and 517",
+		},
+		"error_not_connected": {
+			size:    100,
+			want:    bleDefaultMTU,
+			wantErr: "Cannot request MTU from BLE device until connected",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewClientAdaptor("D7:99:5A:26:EC:38")
+			a.connected = tc.connected
+			var requested int
+			a.requestMTU = func(size int) (int, error) {
+				requested = size
+				return tc.negotiated, tc.negotiateErr
+			}
+			// act
+			got, err := a.RequestMTU(tc.size)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.Equal(t, tc.wantRequested, requested)
+			assert.Equal(t, tc.want, a.MTU())
+		})
+	}
+}
+
+func TestBLEClientAdaptorSetConnectionParams(t *testing.T) {
+	tests := map[string]struct {
+		min     time.Duration
+		max     time.Duration
+		wantMin time.Duration
+		wantMax time.Duration
+		wantErr string
+	}{
+		"valid": {
+			min:     7500 * time.Microsecond,
+			max:     30 * time.Millisecond,
+			wantMin: 7500 * time.Microsecond,
+			wantMax: 30 * time.Millisecond,
+		},
+		"error_too_short": {
+			min:     7 * time.Millisecond,
+			max:     30 * time.Millisecond,
+			wantErr: "connection interval needs to be between 7.5ms and 4s",
+		},
+		"error_too_long": {
+			min:     7500 * time.Microsecond,
+			max:     5 * time.Second,
+			wantErr: "connection interval needs to be between 7.5ms and 4s",
+		},
+		"error_min_greater_max": {
+			min:     40 * time.Millisecond,
+			max:     30 * time.Millisecond,
+			wantErr: "minimum connection interval 40ms is greater than the maximum 30ms",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewClientAdaptor("D7:99:5A:26:EC:38")
+			// act
+			err := a.SetConnectionParams(tc.min, tc.max)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			gotMin, gotMax := a.ConnectionParams()
+			assert.Equal(t, tc.wantMin, gotMin)
+			assert.Equal(t, tc.wantMax, gotMax)
+		})
+	}
+}
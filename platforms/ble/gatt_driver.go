@@ -0,0 +1,120 @@
+package ble
+
+import (
+	"errors"
+	"fmt"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const (
+	// GattNotification event, the data is the received value of the characteristic
+	GattNotification = "notification"
+	// GattError event, the data is the error received together with a notification
+	GattError = "error"
+)
+
+// BLEGattDriver represents a generic characteristic of a service for a BLE Peripheral. This can be used to talk to
+// arbitrary BLE peripherals without a specific driver.
+type BLEGattDriver struct {
+	name               string
+	connection         gobot.Connection
+	serviceUUID        string
+	characteristicUUID string
+	gobot.Eventer
+}
+
+// NewBLEGattDriver creates a BLEGattDriver for the given service and characteristic. The UUIDs can be given in short
+// form (e.g. "180f") or in long form with or without dashes.
+func NewBLEGattDriver(a BLEConnector, serviceUUID string, characteristicUUID string) *BLEGattDriver {
+	d := &BLEGattDriver{
+		name:               gobot.DefaultName("BLEGatt"),
+		connection:         a,
+		serviceUUID:        serviceUUID,
+		characteristicUUID: characteristicUUID,
+		Eventer:            gobot.NewEventer(),
+	}
+
+	d.AddEvent(GattNotification)
+	d.AddEvent(GattError)
+
+	return d
+}
+
+// Connection returns the Driver's Connection to the associated Adaptor
+func (d *BLEGattDriver) Connection() gobot.Connection { return d.connection }
+
+// Name returns the Driver name
+func (d *BLEGattDriver) Name() string { return d.name }
+
+// SetName sets the Driver name
+func (d *BLEGattDriver) SetName(n string) { d.name = n }
+
+// ServiceUUID returns the UUID of the service
+func (d *BLEGattDriver) ServiceUUID() string { return d.serviceUUID }
+
+// CharacteristicUUID returns the UUID of the characteristic
+func (d *BLEGattDriver) CharacteristicUUID() string { return d.characteristicUUID }
+
+// adaptor returns BLE adaptor
+func (d *BLEGattDriver) adaptor() BLEConnector {
+	//nolint:forcetypeassert // ok here
+	return d.Connection().(BLEConnector)
+}
+
+// Start tells driver to get ready to do work
+func (d *BLEGattDriver) Start() error { return nil }
+
+// Halt stops driver (void)
+func (d *BLEGattDriver) Halt() error { return nil }
+
+// ReadCharacteristic reads and returns the current value of the characteristic
+func (d *BLEGattDriver) ReadCharacteristic() ([]byte, error) {
+	data, err := d.adaptor().ReadCharacteristic(d.characteristicUUID)
+	if err != nil {
+		return nil, d.wrapError("read", err)
+	}
+
+	return data, nil
+}
+
+// WriteCharacteristic writes the given value to the characteristic
+func (d *BLEGattDriver) WriteCharacteristic(data []byte) error {
+	if err := d.adaptor().WriteCharacteristic(d.characteristicUUID, data); err != nil {
+		return d.wrapError("write", err)
+	}
+
+	return nil
+}
+
+// Subscribe enables the notifications of the characteristic.
+//
+// Emits the Events:
+//
+//	Notification []byte - On each notification with the new value of the characteristic
+//	Error error - On a notification with error
+func (d *BLEGattDriver) Subscribe() error {
+	err := d.adaptor().Subscribe(d.characteristicUUID, func(data []byte, err error) {
+		if err != nil {
+			d.Publish(d.Event(GattError), err)
+			return
+		}
+		d.Publish(d.Event(GattNotification), data)
+	})
+	if err != nil {
+		return d.wrapError("subscribe to", err)
+	}
+
+	return nil
+}
+
+// wrapError adds the context of the characteristic to the error, a missing characteristic is reported explicitly
+func (d *BLEGattDriver) wrapError(action string, err error) error {
+	if errors.Is(err, ErrUnknownCharacteristic) {
+		return fmt.Errorf("characteristic '%s' of service '%s' not found on '%s': %w", d.characteristicUUID,
+			d.serviceUUID, d.adaptor().Address(), err)
+	}
+
+	return fmt.Errorf("can not %s characteristic '%s' of service '%s': %w", action, d.characteristicUUID,
+		d.serviceUUID, err)
+}
@@ -0,0 +1,153 @@
+package ble
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*BLEGattDriver)(nil)
+
+func initTestBLEGattDriver() (*BLEGattDriver, *bleTestClientAdaptor) {
+	a := NewBleTestAdaptor()
+	return NewBLEGattDriver(a, "180f", "2a19"), a
+}
+
+func TestNewBLEGattDriver(t *testing.T) {
+	// arrange
+	a := NewBleTestAdaptor()
+	// act
+	d := NewBLEGattDriver(a, "180f", "2a19")
+	// assert
+	assert.True(t, strings.HasPrefix(d.Name(), "BLEGatt"))
+	assert.Equal(t, a, d.Connection())
+	assert.Equal(t, "180f", d.ServiceUUID())
+	assert.Equal(t, "2a19", d.CharacteristicUUID())
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Halt())
+	d.SetName("NewName")
+	assert.Equal(t, "NewName", d.Name())
+}
+
+func TestBLEGattDriverReadCharacteristic(t *testing.T) {
+	tests := map[string]struct {
+		readErr error
+		want    []byte
+		wantErr string
+	}{
+		"read": {
+			want: []byte{0x01, 0x02},
+		},
+		"error_not_found": {
+			readErr: fmt.Errorf("%w: %s", ErrUnknownCharacteristic, "2a19"),
+			wantErr: "characteristic '2a19' of service '180f' not found on '01:02:03:04:05:06': " +
+				"Unknown characteristic: 2a19",
+		},
+		"error_read": {
+			readErr: errors.New("read error"),
+			wantErr: "can not read characteristic '2a19' of service '180f': read error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestBLEGattDriver()
+			var gotUUID string
+			a.TestReadCharacteristic(func(cUUID string) ([]byte, error) {
+				gotUUID = cUUID
+				return tc.want, tc.readErr
+			})
+			// act
+			got, err := d.ReadCharacteristic()
+			// assert
+			assert.Equal(t, "2a19", gotUUID)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.ErrorIs(t, err, tc.readErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestBLEGattDriverWriteCharacteristic(t *testing.T) {
+	// arrange
+	d, a := initTestBLEGattDriver()
+	var gotUUID string
+	var gotData []byte
+	a.TestWriteCharacteristic(func(cUUID string, data []byte) error {
+		gotUUID = cUUID
+		gotData = data
+		return nil
+	})
+	// act
+	err := d.WriteCharacteristic([]byte{0x0A})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "2a19", gotUUID)
+	assert.Equal(t, []byte{0x0A}, gotData)
+	// arrange error
+	a.TestWriteCharacteristic(func(string, []byte) error {
+		return fmt.Errorf("%w: %s", ErrUnknownCharacteristic, "2a19")
+	})
+	// act & assert error
+	err = d.WriteCharacteristic([]byte{0x0A})
+	require.ErrorIs(t, err, ErrUnknownCharacteristic)
+	assert.Contains(t, err.Error(), "characteristic '2a19' of service '180f' not found")
+}
+
+func TestBLEGattDriverSubscribe(t *testing.T) {
+	// arrange
+	d, a := initTestBLEGattDriver()
+	var notify func([]byte, error)
+	a.TestSubscribe(func(cUUID string, f func([]byte, error)) error {
+		assert.Equal(t, "2a19", cUUID)
+		notify = f
+		return nil
+	})
+	notifications := make(chan interface{}, 2)
+	_ = d.On(GattNotification, func(data interface{}) { notifications <- data })
+	errs := make(chan interface{}, 1)
+	_ = d.On(GattError, func(data interface{}) { errs <- data })
+	// act
+	err := d.Subscribe()
+	require.NoError(t, err)
+	require.NotNil(t, notify)
+	notify([]byte{0x2A}, nil)
+	notify(nil, errors.New("notify error"))
+	// assert
+	select {
+	case data := <-notifications:
+		assert.Equal(t, []byte{0x2A}, data)
+	case <-time.After(time.Second):
+		require.Fail(t, "notification was not published")
+	}
+	select {
+	case data := <-errs:
+		assert.EqualError(t, data.(error), "notify error")
+	case <-time.After(time.Second):
+		require.Fail(t, "error was not published")
+	}
+}
+
+func TestBLEGattDriverSubscribe_error(t *testing.T) {
+	// arrange
+	d, a := initTestBLEGattDriver()
+	a.TestSubscribe(func(string, func([]byte, error)) error {
+		return errors.New("subscribe error")
+	})
+	// act
+	err := d.Subscribe()
+	// assert
+	require.EqualError(t, err, "can not subscribe to characteristic '2a19' of service '180f': subscribe error")
+}
@@ -12,6 +12,7 @@ type bleTestClientAdaptor struct {
 
 	testReadCharacteristic  func(string) ([]byte, error)
 	testWriteCharacteristic func(string, []byte) error
+	testSubscribe           func(string, func([]byte, error)) error
 }
 
 func (t *bleTestClientAdaptor) Connect() error            { return nil }
@@ -36,8 +37,12 @@ func (t *bleTestClientAdaptor) WriteCharacteristic(cUUID string, data []byte) er
 }
 
 func (t *bleTestClientAdaptor) Subscribe(cUUID string, f func([]byte, error)) error {
-	// TODO: implement this...
-	return nil
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.testSubscribe == nil {
+		return nil
+	}
+	return t.testSubscribe(cUUID, f)
 }
 
 func (t *bleTestClientAdaptor) TestReadCharacteristic(f func(cUUID string) (data []byte, err error)) {
@@ -52,6 +57,12 @@ func (t *bleTestClientAdaptor) TestWriteCharacteristic(f func(cUUID string, data
 	t.testWriteCharacteristic = f
 }
 
+func (t *bleTestClientAdaptor) TestSubscribe(f func(cUUID string, f func([]byte, error)) error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.testSubscribe = f
+}
+
 func NewBleTestAdaptor() *bleTestClientAdaptor {
 	return &bleTestClientAdaptor{
 		address: "01:02:03:04:05:06",
@@ -0,0 +1,12 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package ble
+
+import "tinygo.org/x/bluetooth"
+
+// characteristicMTU returns the MTU of the connection, which the characteristic belongs to
+func characteristicMTU(char bluetooth.DeviceCharacteristic) (int, error) {
+	mtu, err := char.GetMTU()
+	return int(mtu), err
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package ble
+
+import (
+	"fmt"
+
+	"tinygo.org/x/bluetooth"
+)
+
+// characteristicMTU is not supported by the stack of this platform
+func characteristicMTU(bluetooth.DeviceCharacteristic) (int, error) {
+	return 0, fmt.Errorf("reading the MTU is not supported on this platform")
+}
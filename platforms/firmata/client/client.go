@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -53,6 +54,30 @@ const (
 	I2CModeContinuousRead    byte = 0x02
 	I2CModeStopReading       byte = 0x03
 	ServoConfig              byte = 0x70
+	SamplingInterval         byte = 0x7A
+	OneWireData              byte = 0x73
+)
+
+// 1-Wire sub commands of ConfigurableFirmata
+const (
+	OneWireSearchRequest       byte = 0x40
+	OneWireConfigRequest       byte = 0x41
+	OneWireSearchReply         byte = 0x42
+	OneWireReadReply           byte = 0x43
+	OneWireSearchAlarmsRequest byte = 0x44
+	OneWireSearchAlarmsReply   byte = 0x45
+	OneWireResetRequestBit     byte = 0x01
+	OneWireSkipRequestBit      byte = 0x02
+	OneWireSelectRequestBit    byte = 0x04
+	OneWireReadRequestBit      byte = 0x08
+	OneWireDelayRequestBit     byte = 0x10
+	OneWireWriteRequestBit     byte = 0x20
+)
+
+// Range of the sampling interval in milliseconds
+const (
+	MinSamplingInterval = 1
+	MaxSamplingInterval = 0x3FFF
 )
 
 // Errors
@@ -71,6 +96,9 @@ type Client struct {
 	analogPins      []int
 	ConnectTimeout  time.Duration
 	gobot.Eventer
+
+	digitalPortsMutex sync.Mutex
+	digitalPorts      map[int]DigitalPortReport
 }
 
 // Pin represents a pin on the firmata board
@@ -82,6 +110,14 @@ type Pin struct {
 	AnalogChannel  int
 }
 
+// DigitalPortReport represents the state of the 8 pins of a digital port, which was reported by the board, together
+// with the time of reception
+type DigitalPortReport struct {
+	Port    int
+	Value   int
+	Updated time.Time
+}
+
 // I2cReply represents the response from an I2cReply message
 type I2cReply struct {
 	Address  int
@@ -89,6 +125,26 @@ type I2cReply struct {
 	Data     []byte
 }
 
+// OneWireSearchResult represents the response from an OneWireSearch message
+type OneWireSearchResult struct {
+	Pin     int
+	Devices [][8]byte
+}
+
+// OneWireReadResult represents the response from an OneWireRead message
+type OneWireReadResult struct {
+	Pin           int
+	CorrelationID int
+	Data          []byte
+}
+
+// SysexMessage represents a received sysex message with a command, which is not handled by the client itself. The
+// data bytes are decoded from the 7-bit pairs (LSB first).
+type SysexMessage struct {
+	Command byte
+	Data    []byte
+}
+
 // New returns a new Client
 func New() *Client {
 	c := &Client{
@@ -99,6 +155,7 @@ func New() *Client {
 		pins:            []Pin{},
 		analogPins:      []int{},
 		Eventer:         gobot.NewEventer(),
+		digitalPorts:    make(map[int]DigitalPortReport),
 	}
 
 	c.connecting.Store(false)
@@ -111,6 +168,10 @@ func New() *Client {
 		"ProtocolVersion",
 		"I2cReply",
 		"StringData",
+		"OneWireSearchReply",
+		"OneWireReadReply",
+		"Sysex",
+		"DigitalPortReport",
 		"Error",
 	} {
 		c.AddEvent(s)
@@ -301,6 +362,21 @@ func (b *Client) FirmwareQuery() error {
 	return b.WriteSysex([]byte{FirmwareQuery})
 }
 
+// CachedDigitalRead returns the last reported value of the digital pin together with the time of the report. An
+// error is returned, if there was no report for the port of the pin until now. The pin needs to be configured as
+// input and the reporting needs to be enabled, see ReportDigital().
+func (b *Client) CachedDigitalRead(pin int) (int, time.Time, error) {
+	b.digitalPortsMutex.Lock()
+	defer b.digitalPortsMutex.Unlock()
+
+	report, ok := b.digitalPorts[pin/8]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("no report received for port %d of pin %d", pin/8, pin)
+	}
+
+	return (report.Value >> (pin % 8)) & 0x01, report.Updated, nil
+}
+
 // PinStateQuery sends a PinStateQuery for pin.
 func (b *Client) PinStateQuery(pin int) error {
 	return b.WriteSysex([]byte{PinStateQuery, byte(pin)})
@@ -367,11 +443,98 @@ func (b *Client) togglePinReporting(pin int, state int, mode byte) error {
 	return b.write([]byte{mode | byte(pin), byte(state)})
 }
 
+// OneWireConfig sends the OneWireConfigRequest sysex code, which sets the pin to 1-Wire mode. With power, the bus is
+// driven high after each write, e.g. for devices with parasitic power supply.
+func (b *Client) OneWireConfig(pin int, power bool) error {
+	powerByte := byte(0)
+	if power {
+		powerByte = 1
+	}
+
+	return b.WriteSysex([]byte{OneWireData, OneWireConfigRequest, byte(pin), powerByte})
+}
+
+// OneWireSearch sends the OneWireSearchRequest sysex code. The addresses of the found devices are published by the
+// "OneWireSearchReply" event.
+func (b *Client) OneWireSearch(pin int) error {
+	return b.WriteSysex([]byte{OneWireData, OneWireSearchRequest, byte(pin)})
+}
+
+// OneWireReset sends a reset pulse to the 1-Wire bus.
+func (b *Client) OneWireReset(pin int) error {
+	return b.oneWireRequest(pin, OneWireResetRequestBit, nil, nil, 0, 0)
+}
+
+// OneWireWrite resets the 1-Wire bus, selects the device with the given address and writes the data. Without an
+// address (nil), all devices are addressed by "skip ROM".
+func (b *Client) OneWireWrite(pin int, device []byte, data []byte) error {
+	return b.oneWireRequest(pin, OneWireResetRequestBit, device, data, 0, 0)
+}
+
+// OneWireRead resets the 1-Wire bus, selects the device with the given address, writes the given command and reads
+// the given number of bytes. Without an address (nil), all devices are addressed by "skip ROM". The read data are
+// published by the "OneWireReadReply" event together with the given correlation id.
+func (b *Client) OneWireRead(pin int, device []byte, command []byte, numBytes int, correlationID int) error {
+	if numBytes <= 0 {
+		return fmt.Errorf("count of bytes to read (%d) must be greater than zero", numBytes)
+	}
+
+	return b.oneWireRequest(pin, OneWireResetRequestBit, device, command, numBytes, correlationID)
+}
+
+// SendSysex writes a custom Sysex command to the microcontroller, e.g. for an extension of the firmware. Each data byte
+// is encoded as two 7-bit bytes (LSB first), so the values are not restricted. The command needs to be a 7-bit value.
+func (b *Client) SendSysex(command byte, data []byte) error {
+	if command > 0x7F {
+		return fmt.Errorf("sysex command 0x%02X exceeds 7 bits", command)
+	}
+
+	return b.WriteSysex(append([]byte{command}, encodeSysexData(data)...))
+}
+
+// SetSamplingInterval sends the SamplingInterval sysex code, which sets the interval in milliseconds for the
+// reporting of analog pins and continuous i2c reads.
+func (b *Client) SetSamplingInterval(ms int) error {
+	if ms < MinSamplingInterval || ms > MaxSamplingInterval {
+		return fmt.Errorf("sampling interval (%d ms) must be between %d and %d ms", ms, MinSamplingInterval,
+			MaxSamplingInterval)
+	}
+
+	return b.WriteSysex([]byte{SamplingInterval, byte(ms & 0x7F), byte((ms >> 7) & 0x7F)})
+}
+
 // WriteSysex writes an arbitrary Sysex command to the microcontroller.
 func (b *Client) WriteSysex(data []byte) error {
 	return b.write(append([]byte{StartSysex}, append(data, EndSysex)...))
 }
 
+// oneWireRequest sends a 1-Wire command, all data are encoded by the firmata 7-bit encoding
+func (b *Client) oneWireRequest(pin int, command byte, device []byte, data []byte, numBytes int,
+	correlationID int,
+) error {
+	var payload []byte
+	switch {
+	case device != nil:
+		if len(device) != 8 {
+			return fmt.Errorf("1-Wire device address needs 8 bytes, got %d", len(device))
+		}
+		command |= OneWireSelectRequestBit
+		payload = append(payload, device...)
+	case numBytes > 0 || len(data) > 0:
+		command |= OneWireSkipRequestBit
+	}
+	if numBytes > 0 {
+		command |= OneWireReadRequestBit
+		payload = append(payload, byte(numBytes), byte(numBytes>>8), byte(correlationID), byte(correlationID>>8))
+	}
+	if len(data) > 0 {
+		command |= OneWireWriteRequestBit
+		payload = append(payload, data...)
+	}
+
+	return b.WriteSysex(append([]byte{OneWireData, command, byte(pin)}, encode7Bit(payload)...))
+}
+
 func (b *Client) write(data []byte) error {
 	_, err := b.connection.Write(data)
 	return err
@@ -423,16 +586,27 @@ func (b *Client) process() error {
 		}
 		port := messageType & 0x0F
 		portValue := buf[0] | (buf[1] << 7)
+		report := DigitalPortReport{Port: int(port), Value: int(portValue), Updated: time.Now()}
+
+		b.digitalPortsMutex.Lock()
+		_, reportedBefore := b.digitalPorts[report.Port]
+		b.digitalPorts[report.Port] = report
+		b.digitalPortsMutex.Unlock()
 
 		for i := 0; i < 8; i++ {
 			pinNumber := int((8*port + byte(i)))
 			if len(b.pins) > pinNumber {
 				if b.pins[pinNumber].Mode == Input {
-					b.pins[pinNumber].Value = int((portValue >> (byte(i) & 0x07)) & 0x01)
-					b.Publish(b.Event(fmt.Sprintf("DigitalRead%v", pinNumber)), b.pins[pinNumber].Value)
+					value := int((portValue >> (byte(i) & 0x07)) & 0x01)
+					// publish only changes, because the board reports all 8 pins of the port
+					if !reportedBefore || b.pins[pinNumber].Value != value {
+						b.pins[pinNumber].Value = value
+						b.Publish(b.Event(fmt.Sprintf("DigitalRead%v", pinNumber)), value)
+					}
 				}
 			}
 		}
+		b.Publish(b.Event("DigitalPortReport"), report)
 	case StartSysex == messageType:
 		buf, err := b.read(2)
 		if err != nil {
@@ -537,11 +711,114 @@ func (b *Client) process() error {
 		case StringData:
 			str := currentBuffer[2:]
 			b.Publish(b.Event("StringData"), string(str[:len(str)-1]))
+		case OneWireData:
+			b.processOneWire(currentBuffer)
 		default:
 			data := make([]byte, len(currentBuffer))
 			copy(data, currentBuffer)
 			b.Publish("SysexResponse", data)
+			b.Publish(b.Event("Sysex"), SysexMessage{
+				Command: command,
+				Data:    decodeSysexData(currentBuffer[2 : len(currentBuffer)-1]),
+			})
 		}
 	}
 	return nil
 }
+
+// processOneWire publishes the reply of a 1-Wire search or read
+func (b *Client) processOneWire(buf []byte) {
+	if len(buf) < 5 {
+		return
+	}
+
+	pin := int(buf[3])
+	data := decode7Bit(buf[4 : len(buf)-1])
+	switch buf[2] {
+	case OneWireSearchReply:
+		reply := OneWireSearchResult{Pin: pin, Devices: [][8]byte{}}
+		for i := 0; i+8 <= len(data); i += 8 {
+			var device [8]byte
+			copy(device[:], data[i:i+8])
+			reply.Devices = append(reply.Devices, device)
+		}
+		b.Publish(b.Event("OneWireSearchReply"), reply)
+	case OneWireReadReply:
+		if len(data) < 2 {
+			return
+		}
+		b.Publish(b.Event("OneWireReadReply"), OneWireReadResult{
+			Pin:           pin,
+			CorrelationID: int(data[0]) | int(data[1])<<8,
+			Data:          data[2:],
+		})
+	}
+}
+
+// encode7Bit packs the 8-bit bytes into a stream of 7-bit bytes, as used for 1-Wire by ConfigurableFirmata
+func encode7Bit(data []byte) []byte {
+	encoded := make([]byte, 0, (len(data)*8+6)/7)
+	var shift uint
+	var previous byte
+	for _, val := range data {
+		if shift == 0 {
+			encoded = append(encoded, val&0x7F)
+			shift++
+			previous = val >> 7
+			continue
+		}
+		encoded = append(encoded, (val<<shift)&0x7F|previous)
+		if shift == 6 {
+			encoded = append(encoded, val>>1)
+			shift = 0
+			continue
+		}
+		shift++
+		previous = val >> (8 - shift)
+	}
+	if shift > 0 {
+		encoded = append(encoded, previous)
+	}
+
+	return encoded
+}
+
+// decode7Bit unpacks a stream of 7-bit bytes to 8-bit bytes, see encode7Bit()
+func decode7Bit(encoded []byte) []byte {
+	decoded := make([]byte, len(encoded)*7/8)
+	for i := range decoded {
+		pos := i * 8 / 7
+		shift := uint(i * 8 % 7)
+		val := encoded[pos] >> shift
+		if pos+1 < len(encoded) {
+			val |= encoded[pos+1] << (7 - shift)
+		}
+		decoded[i] = val
+	}
+
+	return decoded
+}
+
+// encodeSysexData splits each byte into two 7-bit bytes, LSB first
+func encodeSysexData(data []byte) []byte {
+	encoded := make([]byte, 0, 2*len(data))
+	for _, val := range data {
+		encoded = append(encoded, val&0x7F, val>>7)
+	}
+
+	return encoded
+}
+
+// decodeSysexData combines each pair of 7-bit bytes (LSB first) to one byte, a single remaining byte is taken as is
+func decodeSysexData(data []byte) []byte {
+	decoded := make([]byte, 0, (len(data)+1)/2)
+	for i := 0; i < len(data); i += 2 {
+		if i+1 == len(data) {
+			decoded = append(decoded, data[i])
+			break
+		}
+		decoded = append(decoded, data[i]&0x7F|data[i+1]<<7)
+	}
+
+	return decoded
+}
@@ -2,7 +2,9 @@ package client
 
 import (
 	"bytes"
+	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -231,6 +233,65 @@ func TestProcessDigitalRead4(t *testing.T) {
 	}
 }
 
+func TestProcessDigitalReadOnlyChanges(t *testing.T) {
+	// arrange
+	b, rwc := initTestFirmataWithReadWriteCloser(t.Name(), testDataCapabilitiesResponse)
+	b.pins[2].Mode = Input
+	b.pins[3].Mode = Input
+	events := b.Subscribe()
+	defer b.Unsubscribe(events)
+	// act: pin 2 high, pin 3 low; pin 2 high, pin 3 high
+	rwc.addTestReadData([]byte{0x90, 0x04, 0x00, 0x90, 0x0C, 0x00})
+	require.NoError(t, b.process())
+	require.NoError(t, b.process())
+	// assert
+	var got []string
+	timeout := time.After(semPublishWait)
+	for len(got) < 5 {
+		select {
+		case evt := <-events:
+			if strings.HasPrefix(evt.Name, "Digital") {
+				got = append(got, fmt.Sprintf("%s=%v", evt.Name, reportValue(evt.Data)))
+			}
+		case <-timeout:
+			require.Failf(t, "missing events", "got: %v", got)
+		}
+	}
+	assert.Equal(t, []string{"DigitalRead2=1", "DigitalRead3=0", "DigitalPortReport=4", "DigitalRead3=1",
+		"DigitalPortReport=12"}, got)
+}
+
+func TestCachedDigitalRead(t *testing.T) {
+	// arrange
+	b, rwc := initTestFirmataWithReadWriteCloser(t.Name(), testDataCapabilitiesResponse)
+	_, _, err := b.CachedDigitalRead(10)
+	require.EqualError(t, err, "no report received for port 1 of pin 10")
+	before := time.Now()
+	// act: port 1 with pin 10 and 15 high
+	rwc.addTestReadData([]byte{0x91, 0x04, 0x01})
+	require.NoError(t, b.process())
+	// assert
+	val, updated, err := b.CachedDigitalRead(10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	assert.False(t, updated.Before(before))
+	val, _, err = b.CachedDigitalRead(11)
+	require.NoError(t, err)
+	assert.Equal(t, 0, val)
+	val, _, err = b.CachedDigitalRead(15)
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	_, _, err = b.CachedDigitalRead(2)
+	require.EqualError(t, err, "no report received for port 0 of pin 2")
+}
+
+func reportValue(data interface{}) interface{} {
+	if report, ok := data.(DigitalPortReport); ok {
+		return report.Value
+	}
+	return data
+}
+
 func TestDigitalWrite(t *testing.T) {
 	b, _ := initTestFirmataWithReadWriteCloser(t.Name(), testDataCapabilitiesResponse)
 	require.NoError(t, b.DigitalWrite(13, 0))
@@ -436,3 +497,239 @@ func TestProcessSysexData(t *testing.T) {
 		t.Errorf("SysexResponse was not published")
 	}
 }
+
+func TestSendSysex(t *testing.T) {
+	tests := map[string]struct {
+		command byte
+		data    []byte
+		want    []byte
+		wantErr string
+	}{
+		"7bit_encoding": {
+			command: 0x01,
+			data:    []byte{0x05, 0x7F, 0x80, 0xFF},
+			want:    []byte{0xF0, 0x01, 0x05, 0x00, 0x7F, 0x00, 0x00, 0x01, 0x7F, 0x01, 0xF7},
+		},
+		"no_data": {
+			command: 0x0F,
+			want:    []byte{0xF0, 0x0F, 0xF7},
+		},
+		"error_command": {
+			command: 0x80,
+			want:    []byte{},
+			wantErr: "sysex command 0x80 exceeds 7 bits",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			b, _ := initTestFirmataWithReadWriteCloser(t.Name())
+			writeDataMutex.Lock()
+			testWriteData.Reset()
+			writeDataMutex.Unlock()
+			// act
+			err := b.SendSysex(tc.command, tc.data)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			writeDataMutex.Lock()
+			assert.Equal(t, tc.want, testWriteData.Bytes())
+			writeDataMutex.Unlock()
+		})
+	}
+}
+
+func TestProcessSysexMessage(t *testing.T) {
+	sem := make(chan bool, 1)
+	b, rwc := initTestFirmataWithReadWriteCloser(t.Name())
+	rwc.addTestReadData([]byte{240, 17, 0x05, 0x00, 0x7F, 0x01, 0x02, 247})
+
+	_ = b.Once(b.Event("Sysex"), func(data interface{}) {
+		assert.Equal(t, SysexMessage{Command: 17, Data: []byte{0x05, 0xFF, 0x02}}, data)
+		sem <- true
+	})
+
+	_ = b.process()
+
+	select {
+	case <-sem:
+	case <-time.After(time.Second):
+		t.Errorf("Sysex was not published")
+	}
+}
+
+func TestSetSamplingInterval(t *testing.T) {
+	tests := map[string]struct {
+		ms      int
+		want    []byte
+		wantErr string
+	}{
+		"min":     {ms: 1, want: []byte{0xF0, 0x7A, 0x01, 0x00, 0xF7}},
+		"100ms":   {ms: 100, want: []byte{0xF0, 0x7A, 0x64, 0x00, 0xF7}},
+		"1000ms":  {ms: 1000, want: []byte{0xF0, 0x7A, 0x68, 0x07, 0xF7}},
+		"max":     {ms: 0x3FFF, want: []byte{0xF0, 0x7A, 0x7F, 0x7F, 0xF7}},
+		"error_0": {ms: 0, want: []byte{}, wantErr: "sampling interval (0 ms) must be between 1 and 16383 ms"},
+		"error_max": {
+			ms:      0x4000,
+			want:    []byte{},
+			wantErr: "sampling interval (16384 ms) must be between 1 and 16383 ms",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			b, _ := initTestFirmataWithReadWriteCloser(t.Name())
+			writeDataMutex.Lock()
+			testWriteData.Reset()
+			writeDataMutex.Unlock()
+			// act
+			err := b.SetSamplingInterval(tc.ms)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			writeDataMutex.Lock()
+			assert.Equal(t, tc.want, testWriteData.Bytes())
+			writeDataMutex.Unlock()
+		})
+	}
+}
+
+// two DS18B20 ROM codes
+var (
+	testOneWireROM1 = [8]byte{0x28, 0xFF, 0x4C, 0x7E, 0x71, 0x16, 0x04, 0x5A}
+	testOneWireROM2 = [8]byte{0x28, 0x61, 0x64, 0x12, 0x3C, 0x7C, 0x2F, 0x27}
+)
+
+func TestOneWireRequests(t *testing.T) {
+	tests := map[string]struct {
+		request func(b *Client) error
+		want    []byte
+		wantErr string
+	}{
+		"config": {
+			request: func(b *Client) error { return b.OneWireConfig(2, true) },
+			want:    []byte{0xF0, 0x73, 0x41, 0x02, 0x01, 0xF7},
+		},
+		"search": {
+			request: func(b *Client) error { return b.OneWireSearch(2) },
+			want:    []byte{0xF0, 0x73, 0x40, 0x02, 0xF7},
+		},
+		"reset": {
+			request: func(b *Client) error { return b.OneWireReset(2) },
+			want:    []byte{0xF0, 0x73, 0x01, 0x02, 0xF7},
+		},
+		"write_skip_rom": {
+			request: func(b *Client) error { return b.OneWireWrite(2, nil, []byte{0x44}) },
+			want:    []byte{0xF0, 0x73, 0x23, 0x02, 0x44, 0x00, 0xF7},
+		},
+		"read_select": {
+			request: func(b *Client) error { return b.OneWireRead(2, testOneWireROM1[:], []byte{0xBE}, 9, 0x012A) },
+			want: []byte{
+				0xF0, 0x73, 0x2D, 0x02,
+				0x28, 0x7E, 0x33, 0x72, 0x17, 0x4E, 0x05, 0x02, 0x5A, 0x12, 0x00, 0x50, 0x12, 0x40, 0x2F,
+				0xF7,
+			},
+		},
+		"error_read_no_bytes": {
+			request: func(b *Client) error { return b.OneWireRead(2, nil, nil, 0, 1) },
+			want:    []byte{},
+			wantErr: "count of bytes to read (0) must be greater than zero",
+		},
+		"error_address": {
+			request: func(b *Client) error { return b.OneWireWrite(2, []byte{0x28}, []byte{0x44}) },
+			want:    []byte{},
+			wantErr: "1-Wire device address needs 8 bytes, got 1",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			b, _ := initTestFirmataWithReadWriteCloser(t.Name())
+			writeDataMutex.Lock()
+			testWriteData.Reset()
+			writeDataMutex.Unlock()
+			// act
+			err := tc.request(b)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			writeDataMutex.Lock()
+			assert.Equal(t, tc.want, testWriteData.Bytes())
+			writeDataMutex.Unlock()
+		})
+	}
+}
+
+func TestProcessOneWireSearchReply(t *testing.T) {
+	sem := make(chan bool, 1)
+	b, rwc := initTestFirmataWithReadWriteCloser(t.Name())
+	rwc.addTestReadData([]byte{
+		0xF0, 0x73, 0x42, 0x02,
+		0x28, 0x7E, 0x33, 0x72, 0x17, 0x4E, 0x05, 0x02, 0x5A, 0x50, 0x04, 0x23, 0x26, 0x02, 0x0F, 0x3E, 0x2F, 0x4E, 0x00,
+		0xF7,
+	})
+
+	_ = b.Once(b.Event("OneWireSearchReply"), func(data interface{}) {
+		assert.Equal(t, OneWireSearchResult{Pin: 2, Devices: [][8]byte{testOneWireROM1, testOneWireROM2}}, data)
+		sem <- true
+	})
+
+	_ = b.process()
+
+	select {
+	case <-sem:
+	case <-time.After(time.Second):
+		t.Errorf("OneWireSearchReply was not published")
+	}
+}
+
+func TestProcessOneWireReadReply(t *testing.T) {
+	sem := make(chan bool, 1)
+	b, rwc := initTestFirmataWithReadWriteCloser(t.Name())
+	rwc.addTestReadData([]byte{
+		0xF0, 0x73, 0x43, 0x02,
+		0x2A, 0x02, 0x40, 0x2A, 0x30, 0x49, 0x51, 0x3F, 0x7F, 0x19, 0x40, 0x60, 0x01,
+		0xF7,
+	})
+
+	_ = b.Once(b.Event("OneWireReadReply"), func(data interface{}) {
+		want := OneWireReadResult{
+			Pin:           2,
+			CorrelationID: 0x012A,
+			Data:          []byte{0x50, 0x05, 0x4B, 0x46, 0x7F, 0xFF, 0x0C, 0x10, 0x1C},
+		}
+		assert.Equal(t, want, data)
+		sem <- true
+	})
+
+	_ = b.process()
+
+	select {
+	case <-sem:
+	case <-time.After(time.Second):
+		t.Errorf("OneWireReadReply was not published")
+	}
+}
+
+func Test7BitEncoding(t *testing.T) {
+	for n := 0; n <= 17; n++ {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = byte(0xFF - 13*i)
+		}
+		encoded := encode7Bit(data)
+		for _, val := range encoded {
+			assert.Less(t, val, byte(0x80))
+		}
+		assert.Equal(t, data, decode7Bit(encoded), "length %d", n)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"sync"
 	"time"
 
 	"go.bug.st/serial"
@@ -16,6 +17,8 @@ import (
 	"gobot.io/x/gobot/v2/platforms/firmata/client"
 )
 
+const digitalReportTimeout = time.Second
+
 type firmataBoard interface {
 	Connect(conn io.ReadWriteCloser) error
 	Disconnect() error
@@ -29,7 +32,15 @@ type firmataBoard interface {
 	I2cWrite(address int, data []byte) error
 	I2cConfig(delay int) error
 	ServoConfig(pin int, max int, min int) error
+	CachedDigitalRead(pin int) (int, time.Time, error)
 	WriteSysex(data []byte) error
+	SendSysex(command byte, data []byte) error
+	SetSamplingInterval(ms int) error
+	OneWireConfig(pin int, power bool) error
+	OneWireSearch(pin int) error
+	OneWireReset(pin int) error
+	OneWireWrite(pin int, device []byte, data []byte) error
+	OneWireRead(pin int, device []byte, command []byte, numBytes int, correlationID int) error
 	gobot.Eventer
 }
 
@@ -39,6 +50,7 @@ type FirmataAdaptor interface {
 	Name() string
 	SetName(n string)
 	WriteSysex(data []byte) error
+	SendSysex(command byte, data []byte) error
 	gobot.Eventer
 }
 
@@ -50,6 +62,8 @@ type Adaptor struct {
 	conn       io.ReadWriteCloser
 	PortOpener func(port string) (io.ReadWriteCloser, error)
 	gobot.Eventer
+	oneWireMutex         sync.Mutex // 1-Wire transactions needs to be sequential
+	oneWireCorrelationID int
 }
 
 // NewAdaptor returns a new Firmata Adaptor which optionally accepts:
@@ -72,6 +86,7 @@ func NewAdaptor(args ...interface{}) *Adaptor {
 		},
 		Eventer: gobot.NewEventer(),
 	}
+	f.AddEvent("Sysex")
 
 	for _, arg := range args {
 		switch a := arg.(type) {
@@ -98,8 +113,14 @@ func (f *Adaptor) Connect() error {
 		return err
 	}
 
-	return f.Board.On("SysexResponse", func(data interface{}) {
+	if err := f.Board.On("SysexResponse", func(data interface{}) {
 		f.Publish("SysexResponse", data)
+	}); err != nil {
+		return err
+	}
+
+	return f.Board.On("Sysex", func(data interface{}) {
+		f.Publish(f.Event("Sysex"), data)
 	})
 }
 
@@ -116,6 +137,48 @@ func (f *Adaptor) Finalize() error {
 	return f.Disconnect()
 }
 
+// ensureDigitalInput switches the pin to input and enables the reporting, if not already done
+func (f *Adaptor) ensureDigitalInput(pin int) error {
+	if f.Board.Pins()[pin].Mode == client.Input {
+		return nil
+	}
+
+	if err := f.Board.SetPinMode(pin, client.Input); err != nil {
+		return err
+	}
+	if err := f.Board.ReportDigital(pin, 1); err != nil {
+		return err
+	}
+	<-time.After(10 * time.Millisecond)
+
+	return nil
+}
+
+// waitForBoardEvent sends the request and waits for the first matching event of the board. The subscription is
+// done before sending, so no event can be missed.
+func (f *Adaptor) waitForBoardEvent(event string, timeout time.Duration, send func() error,
+	match func(data interface{}) bool,
+) (interface{}, error) {
+	events := f.Board.Subscribe()
+	defer f.Board.Unsubscribe(events)
+
+	if err := send(); err != nil {
+		return nil, err
+	}
+
+	timeoutChan := time.After(timeout)
+	for {
+		select {
+		case evt := <-events:
+			if evt.Name == event && match(evt.Data) {
+				return evt.Data, nil
+			}
+		case <-timeoutChan:
+			return nil, fmt.Errorf("no %s from the board within %s", event, timeout)
+		}
+	}
+}
+
 // Port returns the Firmata Adaptors port
 func (f *Adaptor) Port() string { return f.port }
 
@@ -185,7 +248,8 @@ func (f *Adaptor) DigitalWrite(pin string, level byte) error {
 	return f.Board.DigitalWrite(p, int(level))
 }
 
-// DigitalRead retrieves digital value from specified pin.
+// DigitalRead retrieves digital value from specified pin. The value is taken from the last report of the board, which
+// is received asynchronously on each change of the port. Use ReadFresh() to force a new report.
 // Returns -1 if the response from the board has timed out
 func (f *Adaptor) DigitalRead(pin string) (int, error) {
 	p, err := strconv.Atoi(pin)
@@ -193,19 +257,58 @@ func (f *Adaptor) DigitalRead(pin string) (int, error) {
 		return 0, err
 	}
 
-	if f.Board.Pins()[p].Mode != client.Input {
-		if err := f.Board.SetPinMode(p, client.Input); err != nil {
-			return 0, err
-		}
-		if err := f.Board.ReportDigital(p, 1); err != nil {
-			return 0, err
-		}
-		<-time.After(10 * time.Millisecond)
+	if err := f.ensureDigitalInput(p); err != nil {
+		return 0, err
+	}
+
+	if val, _, err := f.Board.CachedDigitalRead(p); err == nil {
+		return val, nil
 	}
 
 	return f.Board.Pins()[p].Value, nil
 }
 
+// CachedDigitalRead returns the last reported value of the digital pin together with the time of the report, so the
+// freshness of the value can be evaluated. An error is returned, if there was no report for the pin until now.
+func (f *Adaptor) CachedDigitalRead(pin string) (int, time.Time, error) {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if err := f.ensureDigitalInput(p); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return f.Board.CachedDigitalRead(p)
+}
+
+// ReadFresh forces the board to report the state of the port of the given digital pin and returns the value of the
+// pin from this new report. This needs a round-trip to the board, so DigitalRead() should be preferred if possible.
+func (f *Adaptor) ReadFresh(pin string) (int, error) {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := f.ensureDigitalInput(p); err != nil {
+		return 0, err
+	}
+
+	// enabling the reporting again causes the board to send the current state of the port
+	report, err := f.waitForBoardEvent("DigitalPortReport", digitalReportTimeout,
+		func() error { return f.Board.ReportDigital(p, 1) },
+		func(data interface{}) bool {
+			report, ok := data.(client.DigitalPortReport)
+			return ok && report.Port == p/8
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return (report.(client.DigitalPortReport).Value >> (p % 8)) & 0x01, nil //nolint:forcetypeassert // ok here
+}
+
 // AnalogRead retrieves value from analog pin.
 // Returns -1 if the response from the board has timed out
 func (f *Adaptor) AnalogRead(pin string) (int, error) {
@@ -234,6 +337,19 @@ func (f *Adaptor) WriteSysex(data []byte) error {
 	return f.Board.WriteSysex(data)
 }
 
+// SendSysex writes a custom Sysex command with the given data to the board, e.g. for an extension of the firmware.
+// The data bytes are encoded as 7-bit pairs. Received Sysex messages with commands, which are not handled by the
+// client, are published as "Sysex" event with a client.SysexMessage.
+func (f *Adaptor) SendSysex(command byte, data []byte) error {
+	return f.Board.SendSysex(command, data)
+}
+
+// SetSamplingInterval sets the interval in milliseconds for the reporting of analog pins and continuous i2c reads of
+// the board, e.g. to reduce the serial bandwidth. The default of the StandardFirmata sketch is 19 ms.
+func (f *Adaptor) SetSamplingInterval(ms int) error {
+	return f.Board.SetSamplingInterval(ms)
+}
+
 // digitalPin converts pin number to digital mapping
 func (f *Adaptor) digitalPin(pin int) int {
 	return pin + 14
@@ -7,8 +7,10 @@ package firmata
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -59,7 +61,11 @@ func (readWriteCloser) Close() error {
 type mockFirmataBoard struct {
 	disconnectError error
 	gobot.Eventer
-	pins []client.Pin
+	pins              []client.Pin
+	sentSysex         []byte
+	samplingInterval  int
+	digitalReports    map[int]client.DigitalPortReport
+	reportDigitalFunc func(pin int, state int) error
 }
 
 func newMockFirmataBoard() *mockFirmataBoard {
@@ -88,11 +94,42 @@ func (m mockFirmataBoard) Pins() []client.Pin {
 func (mockFirmataBoard) AnalogWrite(int, int) error      { return nil }
 func (mockFirmataBoard) SetPinMode(int, int) error       { return nil }
 func (mockFirmataBoard) ReportAnalog(int, int) error     { return nil }
-func (mockFirmataBoard) ReportDigital(int, int) error    { return nil }
 func (mockFirmataBoard) DigitalWrite(int, int) error     { return nil }
 func (mockFirmataBoard) ServoConfig(int, int, int) error { return nil }
 func (mockFirmataBoard) WriteSysex([]byte) error         { return nil }
 
+func (m *mockFirmataBoard) SendSysex(command byte, data []byte) error {
+	m.sentSysex = append([]byte{command}, data...)
+	return nil
+}
+
+func (m *mockFirmataBoard) SetSamplingInterval(ms int) error {
+	m.samplingInterval = ms
+	return nil
+}
+
+func (m *mockFirmataBoard) ReportDigital(pin int, state int) error {
+	if m.reportDigitalFunc != nil {
+		return m.reportDigitalFunc(pin, state)
+	}
+	return nil
+}
+
+func (m *mockFirmataBoard) CachedDigitalRead(pin int) (int, time.Time, error) {
+	report, ok := m.digitalReports[pin/8]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("no report for pin %d", pin)
+	}
+	return (report.Value >> (pin % 8)) & 0x01, report.Updated, nil
+}
+
+// 1-Wire functions unused in this test scenarios
+func (mockFirmataBoard) OneWireConfig(int, bool) error                   { return nil }
+func (mockFirmataBoard) OneWireSearch(int) error                         { return nil }
+func (mockFirmataBoard) OneWireReset(int) error                          { return nil }
+func (mockFirmataBoard) OneWireWrite(int, []byte, []byte) error          { return nil }
+func (mockFirmataBoard) OneWireRead(int, []byte, []byte, int, int) error { return nil }
+
 // i2c functions unused in this test scenarios
 func (mockFirmataBoard) I2cRead(int, int) error     { return nil }
 func (mockFirmataBoard) I2cWrite(int, []byte) error { return nil }
@@ -188,6 +225,66 @@ func TestAdaptorDigitalRead(t *testing.T) {
 	assert.Equal(t, 0, val)
 }
 
+func TestAdaptorDigitalRead_cached(t *testing.T) {
+	// arrange
+	a := initTestAdaptor()
+	updated := time.Now()
+	a.Board.(*mockFirmataBoard).digitalReports = map[int]client.DigitalPortReport{
+		1: {Port: 1, Value: 0x04, Updated: updated},
+	}
+	// act & assert: pin 1 is not reported, so the pin value is used
+	val, err := a.DigitalRead("1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	// act & assert: pin 10 is reported high
+	val, err = a.DigitalRead("10")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	val, gotUpdated, err := a.CachedDigitalRead("10")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, updated, gotUpdated)
+	val, _, err = a.CachedDigitalRead("11")
+	require.NoError(t, err)
+	assert.Equal(t, 0, val)
+	_, _, err = a.CachedDigitalRead("1")
+	require.EqualError(t, err, "no report for pin 1")
+}
+
+func TestAdaptorReadFresh(t *testing.T) {
+	// arrange
+	a := initTestAdaptor()
+	board := a.Board.(*mockFirmataBoard)
+	var reported []int
+	board.reportDigitalFunc = func(pin int, state int) error {
+		reported = append(reported, pin)
+		// the board answers with the current state of the port, the report of another port is ignored
+		board.Publish("DigitalPortReport", client.DigitalPortReport{Port: 0, Value: 0xFF})
+		board.Publish("DigitalPortReport", client.DigitalPortReport{Port: 1, Value: 0x08})
+		return nil
+	}
+	board.pins[11].Mode = client.Input
+	// act
+	val, err := a.ReadFresh("11")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, []int{11}, reported)
+}
+
+func TestAdaptorReadFresh_error(t *testing.T) {
+	// arrange
+	a := initTestAdaptor()
+	board := a.Board.(*mockFirmataBoard)
+	board.pins[11].Mode = client.Input
+	board.reportDigitalFunc = func(int, int) error { return errors.New("report error") }
+	// act & assert
+	_, err := a.ReadFresh("11")
+	require.EqualError(t, err, "report error")
+	_, err = a.ReadFresh("xyz")
+	require.Error(t, err)
+}
+
 func TestAdaptorDigitalReadBadPin(t *testing.T) {
 	a := initTestAdaptor()
 	_, err := a.DigitalRead("xyz")
@@ -220,3 +317,42 @@ func TestServoConfig(t *testing.T) {
 	err = a.ServoConfig("a", 0, 0)
 	require.ErrorContains(t, err, "invalid syntax")
 }
+
+func TestAdaptorSendSysex(t *testing.T) {
+	// arrange
+	a := initTestAdaptor()
+	// act
+	err := a.SendSysex(0x01, []byte{0x02, 0xFF})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x02, 0xFF}, a.Board.(*mockFirmataBoard).sentSysex)
+}
+
+func TestAdaptorSysexEvent(t *testing.T) {
+	// arrange
+	sem := make(chan interface{}, 1)
+	a := initTestAdaptor()
+	require.NoError(t, a.On(a.Event("Sysex"), func(data interface{}) {
+		sem <- data
+	}))
+	msg := client.SysexMessage{Command: 0x11, Data: []byte{0x05, 0xFF}}
+	// act
+	a.Board.Publish("Sysex", msg)
+	// assert
+	select {
+	case data := <-sem:
+		assert.Equal(t, msg, data)
+	case <-time.After(time.Second):
+		t.Errorf("Sysex was not published")
+	}
+}
+
+func TestAdaptorSetSamplingInterval(t *testing.T) {
+	// arrange
+	a := initTestAdaptor()
+	// act
+	err := a.SetSamplingInterval(100)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 100, a.Board.(*mockFirmataBoard).samplingInterval)
+}
@@ -58,6 +58,22 @@ func (i2cMockFirmataBoard) ServoConfig(int, int, int) error  { return nil }
 // WriteSysex of the client implementation not tested here
 func (i2cMockFirmataBoard) WriteSysex([]byte) error { return nil }
 
+// SendSysex of the client implementation not tested here
+func (i2cMockFirmataBoard) SendSysex(byte, []byte) error { return nil }
+
+// SetSamplingInterval of the client implementation not tested here
+func (i2cMockFirmataBoard) SetSamplingInterval(int) error { return nil }
+
+// CachedDigitalRead of the client implementation not tested here
+func (i2cMockFirmataBoard) CachedDigitalRead(int) (int, time.Time, error) { return 0, time.Time{}, nil }
+
+// 1-Wire functions unused in this test scenarios
+func (i2cMockFirmataBoard) OneWireConfig(int, bool) error                   { return nil }
+func (i2cMockFirmataBoard) OneWireSearch(int) error                         { return nil }
+func (i2cMockFirmataBoard) OneWireReset(int) error                          { return nil }
+func (i2cMockFirmataBoard) OneWireWrite(int, []byte, []byte) error          { return nil }
+func (i2cMockFirmataBoard) OneWireRead(int, []byte, []byte, int, int) error { return nil }
+
 func newI2cMockFirmataBoard() *i2cMockFirmataBoard {
 	m := &i2cMockFirmataBoard{
 		Eventer: gobot.NewEventer(),
@@ -0,0 +1,117 @@
+//go:build !windows
+// +build !windows
+
+package firmata
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+const oneWireReplyTimeout = time.Second
+
+// OneWirer is the interface for adaptors, which supports devices on a 1-Wire bus, e.g. DS18B20 temperature sensors.
+// The device addresses (ROM codes) have 8 bytes. Without an address (nil) all devices on the bus are addressed.
+type OneWirer interface {
+	OneWireConfig(pin string, power bool) error
+	OneWireSearch(pin string) ([][8]byte, error)
+	OneWireReset(pin string) error
+	OneWireWrite(pin string, device []byte, data []byte) error
+	OneWireRead(pin string, device []byte, command []byte, numBytes int) ([]byte, error)
+}
+
+// OneWireConfig configures the given pin for the 1-Wire bus. This is needed once before any other 1-Wire function is
+// used with this pin. With power, the bus is driven high after each write, e.g. for devices with parasitic power
+// supply. Needs the 1-Wire feature of ConfigurableFirmata.
+func (f *Adaptor) OneWireConfig(pin string, power bool) error {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return err
+	}
+
+	return f.Board.OneWireConfig(p, power)
+}
+
+// OneWireSearch returns the addresses of all devices at the 1-Wire bus of the given pin.
+func (f *Adaptor) OneWireSearch(pin string) ([][8]byte, error) {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return nil, err
+	}
+
+	f.oneWireMutex.Lock()
+	defer f.oneWireMutex.Unlock()
+
+	reply, err := f.waitForBoardEvent("OneWireSearchReply", oneWireReplyTimeout,
+		func() error { return f.Board.OneWireSearch(p) },
+		func(data interface{}) bool {
+			result, ok := data.(client.OneWireSearchResult)
+			return ok && result.Pin == p
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return reply.(client.OneWireSearchResult).Devices, nil //nolint:forcetypeassert // ok here
+}
+
+// OneWireReset sends a reset pulse to the 1-Wire bus of the given pin.
+func (f *Adaptor) OneWireReset(pin string) error {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return err
+	}
+
+	f.oneWireMutex.Lock()
+	defer f.oneWireMutex.Unlock()
+
+	return f.Board.OneWireReset(p)
+}
+
+// OneWireWrite resets the 1-Wire bus of the given pin, selects the device with the given address and writes the data.
+func (f *Adaptor) OneWireWrite(pin string, device []byte, data []byte) error {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return err
+	}
+
+	f.oneWireMutex.Lock()
+	defer f.oneWireMutex.Unlock()
+
+	return f.Board.OneWireWrite(p, device, data)
+}
+
+// OneWireRead resets the 1-Wire bus of the given pin, selects the device with the given address, writes the given
+// command (e.g. 0xBE to read the scratchpad of a DS18B20) and returns the given number of read bytes.
+func (f *Adaptor) OneWireRead(pin string, device []byte, command []byte, numBytes int) ([]byte, error) {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return nil, err
+	}
+
+	f.oneWireMutex.Lock()
+	defer f.oneWireMutex.Unlock()
+
+	f.oneWireCorrelationID = (f.oneWireCorrelationID + 1) & 0xFFFF
+	correlationID := f.oneWireCorrelationID
+
+	reply, err := f.waitForBoardEvent("OneWireReadReply", oneWireReplyTimeout,
+		func() error { return f.Board.OneWireRead(p, device, command, numBytes, correlationID) },
+		func(data interface{}) bool {
+			result, ok := data.(client.OneWireReadResult)
+			return ok && result.Pin == p && result.CorrelationID == correlationID
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	data := reply.(client.OneWireReadResult).Data //nolint:forcetypeassert // ok here
+	if len(data) != numBytes {
+		return nil, fmt.Errorf("Firmata 1-Wire read %d bytes, expected %d bytes", len(data), numBytes)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,160 @@
+//go:build !windows
+// +build !windows
+
+//nolint:forcetypeassert // ok here
+package firmata
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+// make sure that this Adaptor fulfills the 1-Wire interface
+var _ OneWirer = (*Adaptor)(nil)
+
+var testOneWireROM = [8]byte{0x28, 0xFF, 0x4C, 0x7E, 0x71, 0x16, 0x04, 0x5A}
+
+type oneWireMockFirmataBoard struct {
+	*mockFirmataBoard
+	requests  []string
+	readData  []byte
+	noReply   bool
+	sendError error
+}
+
+func newOneWireMockFirmataBoard() *oneWireMockFirmataBoard {
+	return &oneWireMockFirmataBoard{mockFirmataBoard: newMockFirmataBoard()}
+}
+
+func (m *oneWireMockFirmataBoard) OneWireConfig(pin int, power bool) error {
+	m.requests = append(m.requests, "config")
+	return m.sendError
+}
+
+func (m *oneWireMockFirmataBoard) OneWireSearch(pin int) error {
+	m.requests = append(m.requests, "search")
+	if m.sendError == nil && !m.noReply {
+		// a reply of another pin must be ignored
+		m.Publish("OneWireSearchReply", client.OneWireSearchResult{Pin: pin + 1})
+		m.Publish("OneWireSearchReply", client.OneWireSearchResult{Pin: pin, Devices: [][8]byte{testOneWireROM}})
+	}
+	return m.sendError
+}
+
+func (m *oneWireMockFirmataBoard) OneWireReset(int) error {
+	m.requests = append(m.requests, "reset")
+	return m.sendError
+}
+
+func (m *oneWireMockFirmataBoard) OneWireWrite(int, []byte, []byte) error {
+	m.requests = append(m.requests, "write")
+	return m.sendError
+}
+
+func (m *oneWireMockFirmataBoard) OneWireRead(pin int, _ []byte, _ []byte, _ int, correlationID int) error {
+	m.requests = append(m.requests, "read")
+	if m.sendError == nil && !m.noReply {
+		// a reply with another correlation id must be ignored
+		m.Publish("OneWireReadReply", client.OneWireReadResult{Pin: pin, CorrelationID: correlationID + 1})
+		m.Publish("OneWireReadReply", client.OneWireReadResult{Pin: pin, CorrelationID: correlationID, Data: m.readData})
+	}
+	return m.sendError
+}
+
+func initTestOneWireAdaptor() (*Adaptor, *oneWireMockFirmataBoard) {
+	a := NewAdaptor()
+	board := newOneWireMockFirmataBoard()
+	a.Board = board
+	return a, board
+}
+
+func TestOneWireSearch(t *testing.T) {
+	tests := map[string]struct {
+		noReply   bool
+		sendError error
+		want      [][8]byte
+		wantErr   string
+	}{
+		"search": {
+			want: [][8]byte{testOneWireROM},
+		},
+		"error_send": {
+			sendError: errors.New("write error"),
+			wantErr:   "write error",
+		},
+		"error_timeout": {
+			noReply: true,
+			wantErr: "no OneWireSearchReply from the board within 1s",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, board := initTestOneWireAdaptor()
+			board.noReply = tc.noReply
+			board.sendError = tc.sendError
+			// act
+			got, err := a.OneWireSearch("2")
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, []string{"search"}, board.requests)
+		})
+	}
+}
+
+func TestOneWireRead(t *testing.T) {
+	tests := map[string]struct {
+		readData []byte
+		want     []byte
+		wantErr  string
+	}{
+		"read": {
+			readData: []byte{0x50, 0x05, 0x4B, 0x46, 0x7F, 0xFF, 0x0C, 0x10, 0x1C},
+			want:     []byte{0x50, 0x05, 0x4B, 0x46, 0x7F, 0xFF, 0x0C, 0x10, 0x1C},
+		},
+		"error_count": {
+			readData: []byte{0x50, 0x05},
+			wantErr:  "Firmata 1-Wire read 2 bytes, expected 9 bytes",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, board := initTestOneWireAdaptor()
+			board.readData = tc.readData
+			// act
+			got, err := a.OneWireRead("2", testOneWireROM[:], []byte{0xBE}, 9)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, 1, a.oneWireCorrelationID)
+		})
+	}
+}
+
+func TestOneWireConfigResetWrite(t *testing.T) {
+	// arrange
+	a, board := initTestOneWireAdaptor()
+	// act & assert
+	require.NoError(t, a.OneWireConfig("2", false))
+	require.NoError(t, a.OneWireReset("2"))
+	require.NoError(t, a.OneWireWrite("2", nil, []byte{0x44}))
+	assert.Equal(t, []string{"config", "reset", "write"}, board.requests)
+	require.ErrorContains(t, a.OneWireConfig("x", false), "invalid syntax")
+	_, err := a.OneWireSearch("x")
+	require.ErrorContains(t, err, "invalid syntax")
+}
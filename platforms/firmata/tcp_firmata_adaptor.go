@@ -5,11 +5,17 @@ package firmata
 
 import (
 	"io"
+	"log"
 	"net"
+	"sync"
+	"time"
 
 	"gobot.io/x/gobot/v2"
 )
 
+// tcpReconnectOption is the type for enabling the reconnect of a dropped TCP connection
+type tcpReconnectOption time.Duration
+
 // TCPAdaptor represents a TCP based connection to a microcontroller running
 // WiFiFirmata
 type TCPAdaptor struct {
@@ -17,7 +23,9 @@ type TCPAdaptor struct {
 }
 
 // NewTCPAdaptor opens and uses a TCP connection to a microcontroller running
-// WiFiFirmata
+// WiFiFirmata. Optionally accepts:
+//
+//	WithTCPReconnect: redial the address, if the connection drops
 func NewTCPAdaptor(args ...interface{}) *TCPAdaptor {
 	address := args[0].(string) //nolint:forcetypeassert // ok here
 
@@ -25,11 +33,145 @@ func NewTCPAdaptor(args ...interface{}) *TCPAdaptor {
 	a.SetName(gobot.DefaultName("TCPFirmata"))
 	a.PortOpener = connect
 
+	for _, arg := range args[1:] {
+		if o, ok := arg.(tcpReconnectOption); ok {
+			interval := time.Duration(o)
+			a.PortOpener = func(address string) (io.ReadWriteCloser, error) {
+				return newTCPReconnectConn(address, interval, dialTCP)
+			}
+		}
+	}
+
 	return &TCPAdaptor{
 		Adaptor: a,
 	}
 }
 
+// WithTCPReconnect enables the reconnect of a dropped connection. The address is dialed again with the given interval
+// until the connection is established or the adaptor is finalized. The board is not reset and all layers above the
+// transport stay unchanged, so the reconnect is transparent for the drivers.
+func WithTCPReconnect(interval time.Duration) interface{} {
+	return tcpReconnectOption(interval)
+}
+
 func connect(address string) (io.ReadWriteCloser, error) {
+	return dialTCP(address)
+}
+
+func dialTCP(address string) (net.Conn, error) {
 	return net.Dial("tcp", address)
 }
+
+// tcpReconnectConn is a TCP connection, which redials the address on read or write errors
+type tcpReconnectConn struct {
+	address   string
+	interval  time.Duration
+	dial      func(address string) (net.Conn, error)
+	mutex     sync.Mutex
+	conn      net.Conn
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newTCPReconnectConn(
+	address string,
+	interval time.Duration,
+	dial func(address string) (net.Conn, error),
+) (*tcpReconnectConn, error) {
+	conn, err := dial(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tcpReconnectConn{address: address, interval: interval, dial: dial, conn: conn, done: make(chan struct{})}, nil
+}
+
+// Read implements the io.Reader interface.
+func (c *tcpReconnectConn) Read(p []byte) (int, error) {
+	for {
+		conn := c.current()
+		n, err := conn.Read(p)
+		if err == nil || n > 0 {
+			return n, nil
+		}
+		if rerr := c.redial(conn, err); rerr != nil {
+			return 0, rerr
+		}
+	}
+}
+
+// Write implements the io.Writer interface. The data is written again after a reconnect.
+func (c *tcpReconnectConn) Write(p []byte) (int, error) {
+	for {
+		conn := c.current()
+		n, err := conn.Write(p)
+		if err == nil {
+			return n, nil
+		}
+		if rerr := c.redial(conn, err); rerr != nil {
+			return n, rerr
+		}
+	}
+}
+
+// Close implements the io.Closer interface and stops a running reconnect.
+func (c *tcpReconnectConn) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.conn.Close()
+}
+
+func (c *tcpReconnectConn) current() net.Conn {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.conn
+}
+
+// redial replaces the broken connection, if not already done by a concurrent call. The given error is returned, if the
+// connection was closed in the meantime.
+func (c *tcpReconnectConn) redial(broken net.Conn, cause error) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.isClosed() {
+		return cause
+	}
+	if c.conn != broken {
+		return nil
+	}
+	_ = broken.Close()
+
+	for {
+		log.Printf("firmata TCP connection to '%s' dropped (%v), reconnect in %s\n", c.address, cause, c.interval)
+		select {
+		case <-c.done:
+			return cause
+		case <-time.After(c.interval):
+		}
+
+		conn, err := c.dial(c.address)
+		if err != nil {
+			cause = err
+			continue
+		}
+		if c.isClosed() {
+			_ = conn.Close()
+			return cause
+		}
+		c.conn = conn
+		return nil
+	}
+}
+
+func (c *tcpReconnectConn) isClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
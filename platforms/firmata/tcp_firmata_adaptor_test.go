@@ -4,16 +4,135 @@
 package firmata
 
 import (
+	"bufio"
+	"net"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
 )
 
 var _ gobot.Adaptor = (*TCPAdaptor)(nil)
 
+// fakeFirmataTCPServer accepts connections and answers the Firmata handshake like a board with one port of 8 digital
+// pins. All received digital messages are forwarded to the channel.
+type fakeFirmataTCPServer struct {
+	listener net.Listener
+	accepted chan net.Conn
+	digital  chan []byte
+	mutex    sync.Mutex
+	conns    []net.Conn
+	wg       sync.WaitGroup
+}
+
+func newFakeFirmataTCPServer(t *testing.T) *fakeFirmataTCPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeFirmataTCPServer{
+		listener: listener,
+		accepted: make(chan net.Conn, 10),
+		digital:  make(chan []byte, 10),
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mutex.Lock()
+			s.conns = append(s.conns, conn)
+			s.mutex.Unlock()
+			s.accepted <- conn
+			s.wg.Add(1)
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() {
+		_ = listener.Close()
+		s.mutex.Lock()
+		for _, conn := range s.conns {
+			_ = conn.Close()
+		}
+		s.mutex.Unlock()
+		s.wg.Wait()
+	})
+
+	return s
+}
+
+func (s *fakeFirmataTCPServer) address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeFirmataTCPServer) serve(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return
+		}
+		switch {
+		case b == client.ProtocolVersion:
+			_, _ = conn.Write([]byte{client.ProtocolVersion, 2, 5})
+		case b == client.StartSysex:
+			msg, err := r.ReadBytes(client.EndSysex)
+			if err != nil {
+				return
+			}
+			s.answerSysex(conn, msg[0])
+		case b&0xF0 == client.DigitalMessage:
+			data := make([]byte, 2)
+			if _, err := r.Read(data); err != nil {
+				return
+			}
+			s.digital <- append([]byte{b}, data...)
+		}
+	}
+}
+
+func (s *fakeFirmataTCPServer) answerSysex(conn net.Conn, command byte) {
+	var reply []byte
+	switch command {
+	case client.FirmwareQuery:
+		reply = []byte{client.FirmwareQuery, 2, 5, 'W', 0, 'i', 0, 'F', 0, 'i', 0}
+	case client.CapabilityQuery:
+		reply = []byte{client.CapabilityResponse}
+		for pin := 0; pin < 8; pin++ {
+			reply = append(reply, client.Input, 1, client.Output, 1, 127)
+		}
+	case client.AnalogMappingQuery:
+		reply = []byte{client.AnalogMappingResponse}
+		for pin := 0; pin < 8; pin++ {
+			reply = append(reply, 127)
+		}
+	default:
+		return
+	}
+	_, _ = conn.Write(append(append([]byte{client.StartSysex}, reply...), client.EndSysex))
+}
+
+func (s *fakeFirmataTCPServer) waitForConnection(t *testing.T) net.Conn {
+	select {
+	case conn := <-s.accepted:
+		return conn
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "no connection accepted")
+		return nil
+	}
+}
+
 func initTestTCPAdaptor() *TCPAdaptor {
 	a := NewTCPAdaptor("localhost:4567")
 	return a
@@ -23,3 +142,62 @@ func TestFirmataTCPAdaptor(t *testing.T) {
 	a := initTestTCPAdaptor()
 	assert.True(t, strings.HasPrefix(a.Name(), "TCPFirmata"))
 }
+
+func TestFirmataTCPAdaptorConnect(t *testing.T) {
+	// arrange
+	s := newFakeFirmataTCPServer(t)
+	a := NewTCPAdaptor(s.address())
+	// act
+	err := a.Connect()
+	// assert
+	require.NoError(t, err)
+	b := a.Board.(*client.Client)
+	assert.Equal(t, "WiFi", b.FirmwareName)
+	assert.Len(t, b.Pins(), 8)
+	require.NoError(t, a.Finalize())
+}
+
+func TestFirmataTCPAdaptorReconnect(t *testing.T) {
+	// arrange
+	s := newFakeFirmataTCPServer(t)
+	a := NewTCPAdaptor(s.address(), WithTCPReconnect(10*time.Millisecond))
+	require.NoError(t, a.Connect())
+	firstConn := s.waitForConnection(t)
+	// act
+	_ = firstConn.Close()
+	_ = s.waitForConnection(t)
+	err := a.DigitalWrite("1", 1)
+	// assert
+	require.NoError(t, err)
+	select {
+	case msg := <-s.digital:
+		assert.Equal(t, []byte{client.DigitalMessage, 0x02, 0x00}, msg)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "digital message not received after reconnect")
+	}
+	require.NoError(t, a.Finalize())
+}
+
+func TestFirmataTCPAdaptorReconnect_stopByClose(t *testing.T) {
+	// arrange
+	var dialCount int32
+	dial := func(string) (net.Conn, error) {
+		if atomic.AddInt32(&dialCount, 1) == 1 {
+			c, _ := net.Pipe()
+			return c, nil
+		}
+		return nil, assert.AnError
+	}
+	c, err := newTCPReconnectConn("fake", time.Millisecond, dial)
+	require.NoError(t, err)
+	_ = c.current().Close()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = c.Close()
+	}()
+	// act
+	_, err = c.Read(make([]byte, 1))
+	// assert
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Greater(t, atomic.LoadInt32(&dialCount), int32(1))
+}
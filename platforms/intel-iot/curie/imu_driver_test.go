@@ -5,6 +5,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -70,16 +71,24 @@ func (m mockFirmataBoard) Disconnect() error {
 func (m mockFirmataBoard) Pins() []client.Pin {
 	return m.pins
 }
-func (mockFirmataBoard) AnalogWrite(int, int) error      { return nil }
-func (mockFirmataBoard) SetPinMode(int, int) error       { return nil }
-func (mockFirmataBoard) ReportAnalog(int, int) error     { return nil }
-func (mockFirmataBoard) ReportDigital(int, int) error    { return nil }
-func (mockFirmataBoard) DigitalWrite(int, int) error     { return nil }
-func (mockFirmataBoard) I2cRead(int, int) error          { return nil }
-func (mockFirmataBoard) I2cWrite(int, []byte) error      { return nil }
-func (mockFirmataBoard) I2cConfig(int) error             { return nil }
-func (mockFirmataBoard) ServoConfig(int, int, int) error { return nil }
-func (mockFirmataBoard) WriteSysex(data []byte) error    { return nil }
+func (mockFirmataBoard) AnalogWrite(int, int) error                      { return nil }
+func (mockFirmataBoard) SetPinMode(int, int) error                       { return nil }
+func (mockFirmataBoard) ReportAnalog(int, int) error                     { return nil }
+func (mockFirmataBoard) ReportDigital(int, int) error                    { return nil }
+func (mockFirmataBoard) DigitalWrite(int, int) error                     { return nil }
+func (mockFirmataBoard) I2cRead(int, int) error                          { return nil }
+func (mockFirmataBoard) I2cWrite(int, []byte) error                      { return nil }
+func (mockFirmataBoard) I2cConfig(int) error                             { return nil }
+func (mockFirmataBoard) ServoConfig(int, int, int) error                 { return nil }
+func (mockFirmataBoard) WriteSysex(data []byte) error                    { return nil }
+func (mockFirmataBoard) SendSysex(byte, []byte) error                    { return nil }
+func (mockFirmataBoard) SetSamplingInterval(int) error                   { return nil }
+func (mockFirmataBoard) OneWireConfig(int, bool) error                   { return nil }
+func (mockFirmataBoard) OneWireSearch(int) error                         { return nil }
+func (mockFirmataBoard) OneWireReset(int) error                          { return nil }
+func (mockFirmataBoard) OneWireWrite(int, []byte, []byte) error          { return nil }
+func (mockFirmataBoard) OneWireRead(int, []byte, []byte, int, int) error { return nil }
+func (mockFirmataBoard) CachedDigitalRead(int) (int, time.Time, error)   { return 0, time.Time{}, nil }
 
 func initTestIMUDriver() *IMUDriver {
 	a := firmata.NewAdaptor("/dev/null")
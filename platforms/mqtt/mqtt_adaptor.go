@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"fmt"
 	"os"
+	"time"
 
 	paho "github.com/eclipse/paho.mqtt.golang"
 
@@ -14,6 +15,9 @@ import (
 // ErrNilClient is returned when a client action can't be taken because the struct has no client
 var ErrNilClient = fmt.Errorf("no MQTT client available")
 
+// ErrPublishTimeout is returned when a published message was not acknowledged by the broker within the timeout
+var ErrPublishTimeout = fmt.Errorf("MQTT publish was not acknowledged in time")
+
 // Message is a message received from the broker.
 type Message paho.Message
 
@@ -158,6 +162,26 @@ func (a *Adaptor) PublishWithQOS(topic string, qos int, message []byte) (paho.To
 	return token, nil
 }
 
+// PublishSync publishes a message under a specific topic and waits until the message is acknowledged by the broker
+// (QoS 1 and 2) or only sent (QoS 0). ErrPublishTimeout is returned, if this does not happen within the given timeout.
+// In contrast to Publish(), the caller knows that the message was delivered to the broker.
+func (a *Adaptor) PublishSync(topic string, payload []byte, qos byte, timeout time.Duration) error {
+	if qos > 2 {
+		return fmt.Errorf("invalid QoS %d for topic '%s', needs to be 0, 1 or 2", qos, topic)
+	}
+
+	token, err := a.PublishWithQOS(topic, int(qos), payload)
+	if err != nil {
+		return err
+	}
+
+	if !token.WaitTimeout(timeout) {
+		return fmt.Errorf("%w (topic '%s', timeout %s)", ErrPublishTimeout, topic, timeout)
+	}
+
+	return token.Error()
+}
+
 // OnWithQOS allows per-subscribe QOS values to be set and returns a paho.Token
 func (a *Adaptor) OnWithQOS(event string, qos int, f func(msg Message)) (paho.Token, error) {
 	if a.client == nil {
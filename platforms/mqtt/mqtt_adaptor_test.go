@@ -1,10 +1,13 @@
 package mqtt
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	paho "github.com/eclipse/paho.mqtt.golang"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -128,3 +131,116 @@ func TestMqttAdaptorQoS(t *testing.T) {
 	a.SetQoS(1)
 	assert.Equal(t, 1, a.qos)
 }
+
+// mqttTestToken is completed by closing the done channel, the error is returned afterwards
+type mqttTestToken struct {
+	done chan struct{}
+	err  error
+}
+
+func (t *mqttTestToken) Wait() bool {
+	<-t.done
+	return true
+}
+
+func (t *mqttTestToken) WaitTimeout(timeout time.Duration) bool {
+	select {
+	case <-t.done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func (t *mqttTestToken) Done() <-chan struct{} { return t.done }
+
+func (t *mqttTestToken) Error() error { return t.err }
+
+// mqttTestClient records the published messages and returns the given token, all other methods are not implemented
+type mqttTestClient struct {
+	paho.Client
+	token     *mqttTestToken
+	published []string
+	qos       []byte
+}
+
+func (c *mqttTestClient) Publish(topic string, qos byte, retained bool, payload interface{}) paho.Token {
+	c.published = append(c.published, topic+":"+string(payload.([]byte))) //nolint:forcetypeassert // ok here
+	c.qos = append(c.qos, qos)
+	return c.token
+}
+
+func TestMqttAdaptorPublishSync(t *testing.T) {
+	tests := map[string]struct {
+		ackDelay  time.Duration
+		noAck     bool
+		tokenErr  error
+		qos       byte
+		wantErr   string
+		wantErrIs error
+	}{
+		"acknowledged": {
+			qos: 1,
+		},
+		"acknowledged_delayed": {
+			qos:      2,
+			ackDelay: 10 * time.Millisecond,
+		},
+		"sent_qos0": {
+			qos: 0,
+		},
+		"error_token": {
+			qos:      1,
+			tokenErr: errors.New("connection lost"),
+			wantErr:  "connection lost",
+		},
+		"error_timeout": {
+			qos:       1,
+			noAck:     true,
+			wantErr:   "MQTT publish was not acknowledged in time (topic 'test', timeout 50ms)",
+			wantErrIs: ErrPublishTimeout,
+		},
+		"error_invalid_qos": {
+			qos:     3,
+			wantErr: "invalid QoS 3 for topic 'test', needs to be 0, 1 or 2",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := initTestMqttAdaptor()
+			token := &mqttTestToken{done: make(chan struct{}), err: tc.tokenErr}
+			client := &mqttTestClient{token: token}
+			a.client = client
+			if !tc.noAck {
+				time.AfterFunc(tc.ackDelay, func() { close(token.done) })
+			}
+			// act
+			err := a.PublishSync("test", []byte("o"), tc.qos, 50*time.Millisecond)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				if tc.wantErrIs != nil {
+					require.ErrorIs(t, err, tc.wantErrIs)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.qos > 2 {
+				assert.Empty(t, client.published)
+				return
+			}
+			assert.Equal(t, []string{"test:o"}, client.published)
+			assert.Equal(t, []byte{tc.qos}, client.qos)
+		})
+	}
+}
+
+func TestMqttAdaptorPublishSync_notConnected(t *testing.T) {
+	// arrange
+	a := initTestMqttAdaptor()
+	// act
+	err := a.PublishSync("test", []byte("o"), 1, time.Second)
+	// assert
+	require.ErrorIs(t, err, ErrNilClient)
+}
@@ -2,6 +2,7 @@ package raspi
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -15,6 +16,8 @@ import (
 
 const (
 	infoFile = "/proc/cpuinfo"
+	// i2cBaudratePath is the module parameter of the i2c driver for the speed of all i2c buses
+	i2cBaudratePath = "/sys/module/i2c_bcm2708/parameters/baudrate"
 
 	defaultSpiBusNumber  = 0
 	defaultSpiChipNumber = 0
@@ -77,7 +80,8 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, a.translateAnalogPin)
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.getPinTranslatorFunction(), digitalPinsOpts...)
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.getPinTranslatorFunction(), pwmPinsOpts...)
-	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, 1)
+	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, 1,
+		adaptors.WithI2cBusSpeedApplier(a.applyI2cBusSpeed))
 	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, a.validateSpiBusNumber, defaultSpiBusNumber, defaultSpiChipNumber,
 		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed)
 	return a
@@ -175,6 +179,25 @@ func (a *Adaptor) validateI2cBusNumber(busNr int) error {
 	return nil
 }
 
+// applyI2cBusSpeed writes the speed to the module parameter of the i2c driver, which affects all i2c buses. Newer
+// kernels with the driver "i2c_bcm2835" do not support a change at runtime, so the speed needs to be configured by
+// "dtparam=i2c_arm_baudrate" in "/boot/config.txt" in this case.
+func (a *Adaptor) applyI2cBusSpeed(busNr int, hz int) error {
+	if _, err := a.sys.Stat(i2cBaudratePath); err != nil {
+		return fmt.Errorf("speed of i2c bus %d can not be changed at runtime, use 'dtparam=i2c_arm_baudrate=%d' "+
+			"in '/boot/config.txt' instead: %w", busNr, hz, err)
+	}
+
+	fi, err := a.sys.OpenFile(i2cBaudratePath, os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer fi.Close()
+
+	_, err = fi.WriteString(strconv.Itoa(hz))
+	return err
+}
+
 func (a *Adaptor) translateAnalogPin(id string) (string, bool, bool, uint16, error) {
 	pinInfo, ok := analogPinDefinitions[id]
 	if !ok {
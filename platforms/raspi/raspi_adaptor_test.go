@@ -345,6 +345,40 @@ func TestI2cFinalizeWithErrors(t *testing.T) {
 	require.ErrorContains(t, err, "close error")
 }
 
+func TestI2cSetBusSpeed(t *testing.T) {
+	tests := map[string]struct {
+		mockPaths []string
+		wantErr   string
+	}{
+		"write_module_parameter": {
+			mockPaths: []string{"/dev/i2c-1", i2cBaudratePath},
+		},
+		"error_no_module_parameter": {
+			mockPaths: []string{"/dev/i2c-1"},
+			wantErr:   "use 'dtparam=i2c_arm_baudrate=400000' in '/boot/config.txt' instead",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAdaptor()
+			a.sys.UseMockSyscall()
+			fs := a.sys.UseMockFilesystem(tc.mockPaths)
+			require.NoError(t, a.Connect())
+			// act
+			require.NoError(t, a.SetBusSpeed(1, 400000))
+			_, err := a.GetI2cConnection(0xff, 1)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "400000", fs.Files[i2cBaudratePath].Contents)
+		})
+	}
+}
+
 func Test_validateSpiBusNumber(t *testing.T) {
 	tests := map[string]struct {
 		busNr   int
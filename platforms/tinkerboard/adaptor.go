@@ -1,6 +1,7 @@
 package tinkerboard
 
 import (
+	"encoding/binary"
 	"fmt"
 	"sync"
 
@@ -13,6 +14,8 @@ import (
 
 const (
 	defaultI2cBusNumber = 1
+	// i2cClockFrequencyPathPattern is the device tree property of the speed of the i2c bus with the given number
+	i2cClockFrequencyPathPattern = "/sys/class/i2c-adapter/i2c-%d/of_node/clock-frequency"
 
 	defaultSpiBusNumber  = 0
 	defaultSpiChipNumber = 0
@@ -93,7 +96,8 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, a.translateAnalogPin)
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.translateDigitalPin, digitalPinsOpts...)
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.translatePWMPin, pwmPinsOpts...)
-	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, defaultI2cBusNumber)
+	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, defaultI2cBusNumber,
+		adaptors.WithI2cBusSpeedApplier(a.applyI2cBusSpeed))
 	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, a.validateSpiBusNumber, defaultSpiBusNumber, defaultSpiChipNumber,
 		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed)
 	return a
@@ -171,6 +175,26 @@ func (a *Adaptor) validateI2cBusNumber(busNr int) error {
 	return nil
 }
 
+// applyI2cBusSpeed ensures that the bus runs with the given speed. The driver "i2c-rk3x" does not support a change
+// at runtime, so the speed of the device tree is compared and a device tree overlay needs to be used for changes.
+func (a *Adaptor) applyI2cBusSpeed(busNr int, hz int) error {
+	content, err := a.sys.ReadFile(fmt.Sprintf(i2cClockFrequencyPathPattern, busNr))
+	if err != nil {
+		return err
+	}
+	if len(content) != 4 {
+		return fmt.Errorf("unexpected clock frequency of i2c bus %d in device tree: %v", busNr, content)
+	}
+
+	// device tree properties are stored big endian
+	if dtHz := int(binary.BigEndian.Uint32(content)); dtHz != hz {
+		return fmt.Errorf("i2c bus %d is configured with %d Hz in device tree, a device tree overlay is needed "+
+			"to use %d Hz", busNr, dtHz, hz)
+	}
+
+	return nil
+}
+
 func (a *Adaptor) translateAnalogPin(id string) (string, bool, bool, uint16, error) {
 	pinInfo, ok := analogPinDefinitions[id]
 	if !ok {
@@ -287,6 +287,46 @@ func TestI2cFinalizeWithErrors(t *testing.T) {
 	require.ErrorContains(t, err, "close error")
 }
 
+func TestI2cSetBusSpeed(t *testing.T) {
+	const clockFrequencyPath = "/sys/class/i2c-adapter/i2c-4/of_node/clock-frequency"
+
+	tests := map[string]struct {
+		dtHz    []byte
+		wantErr string
+	}{
+		"matches_device_tree": {
+			dtHz: []byte{0x00, 0x01, 0x86, 0xA0},
+		},
+		"error_differs_from_device_tree": {
+			dtHz:    []byte{0x00, 0x06, 0x1A, 0x80},
+			wantErr: "i2c bus 4 is configured with 400000 Hz in device tree, a device tree overlay is needed to use 100000 Hz",
+		},
+		"error_invalid_device_tree_property": {
+			dtHz:    []byte{0x01},
+			wantErr: "unexpected clock frequency of i2c bus 4 in device tree: [1]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAdaptor()
+			a.sys.UseMockSyscall()
+			fs := a.sys.UseMockFilesystem([]string{"/dev/i2c-4", clockFrequencyPath})
+			fs.Files[clockFrequencyPath].Contents = string(tc.dtHz)
+			require.NoError(t, a.Connect())
+			// act
+			require.NoError(t, a.SetBusSpeed(4, 100000))
+			_, err := a.GetI2cConnection(0xff, 4)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_validateSpiBusNumber(t *testing.T) {
 	tests := map[string]struct {
 		busNr   int
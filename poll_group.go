@@ -0,0 +1,163 @@
+package gobot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PollError is the event of a PollGroup, which is published for each failed poll of a device. The data of the event
+// is the error, which contains the name of the device, if available.
+const PollError = "poll-error"
+
+// Poller is the interface for devices, which can be polled by a PollGroup. The device reads its current value(s) on
+// each call and publishes its events, like it would do with an own cyclic reading.
+type Poller interface {
+	// Poll reads the device once
+	Poll() error
+}
+
+// PollGroup polls all registered devices one after another on a single shared ticker. In comparison to a cyclic
+// reading in each driver, this reduces the count of goroutines and timers and aligns the sampling of all devices.
+type PollGroup struct {
+	Eventer
+	interval  time.Duration
+	mutex     sync.Mutex
+	pollers   []Poller
+	halt      chan struct{}
+	done      chan struct{}
+	newTicker func(interval time.Duration) (<-chan time.Time, func())
+}
+
+// NewPollGroup creates a new group, which polls all registered devices with the given interval after Start().
+func NewPollGroup(interval time.Duration) *PollGroup {
+	if interval <= 0 {
+		panic("the interval of a poll group needs to be greater than zero")
+	}
+
+	g := &PollGroup{
+		Eventer:  NewEventer(),
+		interval: interval,
+		newTicker: func(interval time.Duration) (<-chan time.Time, func()) {
+			ticker := time.NewTicker(interval)
+			return ticker.C, ticker.Stop
+		},
+	}
+	g.AddEvent(PollError)
+
+	return g
+}
+
+// Add registers the given devices to the group. Devices can be added also while the group is running.
+func (g *PollGroup) Add(pollers ...Poller) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.pollers = append(g.pollers, pollers...)
+}
+
+// Remove unregisters the given device from the group and returns false, if the device was not registered.
+func (g *PollGroup) Remove(poller Poller) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for i, p := range g.pollers {
+		if p == poller {
+			g.pollers = append(g.pollers[:i], g.pollers[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Len returns the count of registered devices.
+func (g *PollGroup) Len() int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return len(g.pollers)
+}
+
+// Interval returns the poll interval of the group.
+func (g *PollGroup) Interval() time.Duration {
+	return g.interval
+}
+
+// Running returns true, if the group was started and not stopped yet.
+func (g *PollGroup) Running() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.halt != nil
+}
+
+// Start starts the cyclic polling of all registered devices. The first poll is done with the first tick.
+func (g *PollGroup) Start() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.halt != nil {
+		return fmt.Errorf("poll group is already running")
+	}
+
+	g.halt = make(chan struct{})
+	g.done = make(chan struct{})
+	ticks, stopTicker := g.newTicker(g.interval)
+
+	go func(halt, done chan struct{}) {
+		defer close(done)
+		defer stopTicker()
+
+		for {
+			select {
+			case <-ticks:
+				g.PollAll()
+			case <-halt:
+				return
+			}
+		}
+	}(g.halt, g.done)
+
+	return nil
+}
+
+// Stop stops the cyclic polling and waits until the current poll cycle is finished.
+func (g *PollGroup) Stop() error {
+	g.mutex.Lock()
+	if g.halt == nil {
+		g.mutex.Unlock()
+		return nil
+	}
+	close(g.halt)
+	done := g.done
+	g.halt = nil
+	g.done = nil
+	g.mutex.Unlock()
+
+	<-done
+
+	return nil
+}
+
+// PollAll polls all registered devices once, one after another. A failed poll does not prevent the poll of the
+// remaining devices, but is published with the event PollError. The count of failed polls is returned.
+func (g *PollGroup) PollAll() int {
+	g.mutex.Lock()
+	pollers := make([]Poller, len(g.pollers))
+	copy(pollers, g.pollers)
+	g.mutex.Unlock()
+
+	var failed int
+	for _, p := range pollers {
+		if err := p.Poll(); err != nil {
+			failed++
+			if named, ok := p.(interface{ Name() string }); ok {
+				err = fmt.Errorf("poll of '%s' failed: %w", named.Name(), err)
+			}
+			g.Publish(g.Event(PollError), err)
+		}
+	}
+
+	return failed
+}
@@ -0,0 +1,126 @@
+package gobot
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPoller struct {
+	name  string
+	err   error
+	polls *[]string
+	mtx   *sync.Mutex
+}
+
+func (p *testPoller) Name() string { return p.name }
+
+func (p *testPoller) Poll() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	*p.polls = append(*p.polls, p.name)
+	return p.err
+}
+
+func TestNewPollGroup(t *testing.T) {
+	// act
+	g := NewPollGroup(5 * time.Millisecond)
+	// assert
+	assert.Equal(t, 5*time.Millisecond, g.Interval())
+	assert.Equal(t, 0, g.Len())
+	assert.False(t, g.Running())
+	assert.Equal(t, PollError, g.Event(PollError))
+	assert.PanicsWithValue(t, "the interval of a poll group needs to be greater than zero", func() {
+		_ = NewPollGroup(0)
+	})
+}
+
+func TestPollGroupStart_pollsAllDevicesOnOneTicker(t *testing.T) {
+	// arrange
+	var polls []string
+	var mtx sync.Mutex
+	g := NewPollGroup(time.Millisecond)
+	tickers := 0
+	ticks := make(chan time.Time)
+	tickerStopped := false
+	g.newTicker = func(interval time.Duration) (<-chan time.Time, func()) {
+		tickers++
+		assert.Equal(t, time.Millisecond, interval)
+		return ticks, func() { tickerStopped = true }
+	}
+	for _, name := range []string{"sensor1", "sensor2", "sensor3"} {
+		g.Add(&testPoller{name: name, polls: &polls, mtx: &mtx})
+	}
+	// act
+	require.NoError(t, g.Start())
+	require.EqualError(t, g.Start(), "poll group is already running")
+	for i := 0; i < 3; i++ {
+		ticks <- time.Now()
+	}
+	require.NoError(t, g.Stop())
+	// assert
+	assert.Equal(t, 1, tickers)
+	assert.True(t, tickerStopped)
+	assert.False(t, g.Running())
+	want := []string{
+		"sensor1", "sensor2", "sensor3",
+		"sensor1", "sensor2", "sensor3",
+		"sensor1", "sensor2", "sensor3",
+	}
+	assert.Equal(t, want, polls)
+	require.NoError(t, g.Stop())
+}
+
+func TestPollGroupStart_realTicker(t *testing.T) {
+	// arrange
+	var polls []string
+	var mtx sync.Mutex
+	g := NewPollGroup(2 * time.Millisecond)
+	g.Add(&testPoller{name: "sensor1", polls: &polls, mtx: &mtx}, &testPoller{name: "sensor2", polls: &polls, mtx: &mtx})
+	// act
+	require.NoError(t, g.Start())
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, g.Stop())
+	// assert
+	mtx.Lock()
+	defer mtx.Unlock()
+	require.GreaterOrEqual(t, len(polls), 4)
+	require.Equal(t, 0, len(polls)%2)
+	for i := 0; i < len(polls); i += 2 {
+		assert.Equal(t, []string{"sensor1", "sensor2"}, polls[i:i+2])
+	}
+}
+
+func TestPollGroupPollAll_error(t *testing.T) {
+	// arrange
+	var polls []string
+	var mtx sync.Mutex
+	g := NewPollGroup(time.Millisecond)
+	failing := &testPoller{name: "sensor1", err: errors.New("read error"), polls: &polls, mtx: &mtx}
+	g.Add(failing, &testPoller{name: "sensor2", polls: &polls, mtx: &mtx})
+	sem := make(chan interface{}, 1)
+	require.NoError(t, g.On(g.Event(PollError), func(data interface{}) {
+		sem <- data
+	}))
+	// act
+	failed := g.PollAll()
+	// assert
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, []string{"sensor1", "sensor2"}, polls)
+	select {
+	case data := <-sem:
+		require.EqualError(t, data.(error), "poll of 'sensor1' failed: read error")
+	case <-time.After(time.Second):
+		t.Error("PollError event was not published")
+	}
+	// act & assert: removed devices are not polled anymore
+	assert.True(t, g.Remove(failing))
+	assert.False(t, g.Remove(failing))
+	polls = nil
+	assert.Equal(t, 0, g.PollAll())
+	assert.Equal(t, []string{"sensor2"}, polls)
+}
@@ -1,16 +1,39 @@
 package gobot
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"sync/atomic"
 
 	multierror "github.com/hashicorp/go-multierror"
 )
 
+const (
+	// DeviceDisabled is the event of the robot, which is published after a device was halted by
+	// Robot.DisableDevice(). The data of the event is the name of the device.
+	DeviceDisabled = "device-disabled"
+	// DeviceEnabled is the event of the robot, which is published after a device was started again by
+	// Robot.EnableDevice(). The data of the event is the name of the device.
+	DeviceEnabled = "device-enabled"
+	// DeviceAdded is the event of the robot, which is published after a device was added to the running robot by
+	// Robot.AddDeviceRuntime(). The data of the event is the name of the device.
+	DeviceAdded = "device-added"
+	// DeviceRemoved is the event of the robot, which is published after a device was removed from the running robot by
+	// Robot.RemoveDevice(). The data of the event is the name of the device.
+	DeviceRemoved = "device-removed"
+	// RobotReady is the event of the robot, which is published after all devices are started successfully. The data
+	// of the event is the name of the robot.
+	RobotReady = "ready"
+	// RobotFailed is the event of the robot, which is published for each device which fails to start. The data of the
+	// event is the name of the device.
+	RobotFailed = "failed"
+)
+
 // JSONRobot a JSON representation of a Robot.
 type JSONRobot struct {
 	Name        string            `json:"name"`
@@ -48,6 +71,7 @@ type Robot struct {
 	Work               func()
 	connections        *Connections
 	devices            *Devices
+	devicesMutex       sync.Mutex
 	trap               func(chan os.Signal)
 	AutoRun            bool
 	running            atomic.Value
@@ -55,6 +79,12 @@ type Robot struct {
 	workRegistry       *RobotWorkRegistry
 	WorkEveryWaitGroup *sync.WaitGroup
 	WorkAfterWaitGroup *sync.WaitGroup
+	disabledDevices    map[string]bool
+	disabledMutex      sync.Mutex
+	deviceDependencies map[string][]string
+	readyChan          chan struct{} // closed after the start of all devices was done
+	readyErr           error
+	readyMutex         sync.Mutex
 	Commander
 	Eventer
 }
@@ -119,11 +149,21 @@ func NewRobot(v ...interface{}) *Robot {
 		trap: func(c chan os.Signal) {
 			signal.Notify(c, os.Interrupt)
 		},
-		AutoRun:   true,
-		Work:      nil,
-		Eventer:   NewEventer(),
-		Commander: NewCommander(),
+		AutoRun:            true,
+		Work:               nil,
+		disabledDevices:    make(map[string]bool),
+		deviceDependencies: make(map[string][]string),
+		readyChan:          make(chan struct{}),
+		Eventer:            NewEventer(),
+		Commander:          NewCommander(),
 	}
+	r.AddEvent(DeviceDisabled)
+	r.AddEvent(DeviceEnabled)
+	r.AddEvent(DeviceAdded)
+	r.AddEvent(DeviceRemoved)
+	r.AddEvent(RobotReady)
+	r.AddEvent(RobotFailed)
+	r.running.Store(false)
 
 	for i := range v {
 		switch val := v[i].(type) {
@@ -152,7 +192,6 @@ func NewRobot(v ...interface{}) *Robot {
 	r.WorkAfterWaitGroup = &sync.WaitGroup{}
 	r.WorkEveryWaitGroup = &sync.WaitGroup{}
 
-	r.running.Store(false)
 	log.Println("Robot", r.Name, "initialized.")
 
 	return r
@@ -169,16 +208,38 @@ func (r *Robot) Start(args ...interface{}) error {
 		}
 	}
 	log.Println("Starting Robot", r.Name, "...")
+	r.resetReady()
+
+	devices, err := r.devicesInStartOrder()
+	if err != nil {
+		log.Println(err)
+		r.setReady(err)
+		return err
+	}
+
 	if err := r.Connections().Start(); err != nil {
 		log.Println(err)
+		r.setReady(err)
 		return err
 	}
 
-	if err := r.Devices().Start(); err != nil {
+	var failedDevices []string
+	if err := devices.start(func(device Device) { failedDevices = append(failedDevices, device.Name()) }); err != nil {
 		log.Println(err)
+		r.setReady(err)
+		for _, name := range failedDevices {
+			r.Publish(RobotFailed, name)
+		}
 		return err
 	}
 
+	r.setReady(nil)
+	r.Publish(RobotReady, r.Name)
+
+	r.disabledMutex.Lock()
+	r.disabledDevices = make(map[string]bool)
+	r.disabledMutex.Unlock()
+
 	if r.Work == nil {
 		r.Work = func() {}
 	}
@@ -205,14 +266,65 @@ func (r *Robot) Start(args ...interface{}) error {
 	return r.Stop()
 }
 
+// WaitReady blocks until all devices of the robot are started or the given context is done. An error is returned, if
+// the start of the robot failed or the context is done before. If the robot is not started yet, the next start is
+// awaited.
+func (r *Robot) WaitReady(ctx context.Context) error {
+	r.readyMutex.Lock()
+	readyChan := r.readyChan
+	r.readyMutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-readyChan:
+		r.readyMutex.Lock()
+		defer r.readyMutex.Unlock()
+		return r.readyErr
+	}
+}
+
+// resetReady prepares a new barrier for WaitReady(), if the former was already released
+func (r *Robot) resetReady() {
+	r.readyMutex.Lock()
+	defer r.readyMutex.Unlock()
+
+	select {
+	case <-r.readyChan:
+		r.readyChan = make(chan struct{})
+		r.readyErr = nil
+	default:
+	}
+}
+
+// setReady releases the barrier for WaitReady() with the given result
+func (r *Robot) setReady(err error) {
+	r.readyMutex.Lock()
+	defer r.readyMutex.Unlock()
+
+	r.readyErr = err
+	close(r.readyChan)
+}
+
 // Stop stops a Robot's connections and devices. We try to stop all items and
 // collect all errors.
 func (r *Robot) Stop() error {
 	var err error
 	log.Println("Stopping Robot", r.Name, "...")
-	if e := r.Devices().Halt(); e != nil {
-		err = multierror.Append(err, e)
+	r.devicesMutex.Lock()
+	devices := append(Devices{}, *r.devices...)
+	r.devicesMutex.Unlock()
+	r.disabledMutex.Lock()
+	for _, device := range devices {
+		if r.disabledDevices[device.Name()] {
+			// already halted
+			continue
+		}
+		if e := device.Halt(); e != nil {
+			err = multierror.Append(err, e)
+		}
 	}
+	r.disabledMutex.Unlock()
 	if e := r.Connections().Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
@@ -227,23 +339,124 @@ func (r *Robot) Running() bool {
 	return r.running.Load().(bool) //nolint:forcetypeassert // no error return value, so there is no better way
 }
 
-// Devices returns all devices associated with this Robot.
+// Devices returns all devices associated with this Robot. The result is a copy, so it can be iterated safely also
+// while devices are added or removed at runtime.
 func (r *Robot) Devices() *Devices {
-	return r.devices
+	r.devicesMutex.Lock()
+	defer r.devicesMutex.Unlock()
+
+	devices := append(Devices{}, *r.devices...)
+
+	return &devices
 }
 
-// AddDevice adds a new Device to the robots collection of devices. Returns the
-// added device.
+// AddDevice adds a new Device to the robots collection of devices. Returns the added device. The device is not
+// started, so for a running robot AddDeviceRuntime() should be used instead.
 func (r *Robot) AddDevice(d Device) Device {
-	*r.devices = append(*r.Devices(), d)
+	r.devicesMutex.Lock()
+	defer r.devicesMutex.Unlock()
+
+	*r.devices = append(*r.devices, d)
 	return d
 }
 
+// AddDeviceRuntime adds a new Device to the robots collection of devices and returns it. If the robot is already
+// running, the device is started immediately and the event "device-added" is published afterwards. An error is
+// returned, if a device with the same name already exists or the device fails to start. In this case the device is
+// not added.
+func (r *Robot) AddDeviceRuntime(d Device) (Device, error) {
+	if r.Device(d.Name()) != nil {
+		return nil, fmt.Errorf("device '%s' already exists", d.Name())
+	}
+
+	running := r.Running()
+	if running {
+		log.Println("Starting device", d.Name(), "...")
+		if err := d.Start(); err != nil {
+			return nil, fmt.Errorf("device '%s' could not be started: %w", d.Name(), err)
+		}
+	}
+
+	r.devicesMutex.Lock()
+	for _, device := range *r.devices {
+		if device.Name() == d.Name() {
+			// added concurrently in the meantime
+			r.devicesMutex.Unlock()
+			if running {
+				if err := d.Halt(); err != nil {
+					log.Println(err)
+				}
+			}
+			return nil, fmt.Errorf("device '%s' already exists", d.Name())
+		}
+	}
+	*r.devices = append(*r.devices, d)
+	r.devicesMutex.Unlock()
+
+	if running {
+		r.Publish(DeviceAdded, d.Name())
+	}
+
+	return d, nil
+}
+
+// RemoveDevice detaches the device with the given name from the robot. If the robot is running, the device is halted
+// before, when not already disabled, and the event "device-removed" is published afterwards. If the halt fails, the
+// device stays attached.
+func (r *Robot) RemoveDevice(name string) error {
+	r.devicesMutex.Lock()
+	defer r.devicesMutex.Unlock()
+
+	idx := -1
+	for i, device := range *r.devices {
+		if device.Name() == name {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return fmt.Errorf("No device found with the name %s", name)
+	}
+	device := (*r.devices)[idx]
+
+	r.disabledMutex.Lock()
+	defer r.disabledMutex.Unlock()
+
+	running := r.Running()
+	if running && !r.disabledDevices[name] {
+		log.Println("Halting device", name, "...")
+		if err := device.Halt(); err != nil {
+			return fmt.Errorf("device '%s' could not be halted: %w", name, err)
+		}
+	}
+
+	*r.devices = append((*r.devices)[:idx], (*r.devices)[idx+1:]...)
+	delete(r.disabledDevices, name)
+	delete(r.deviceDependencies, name)
+	for dependent, dependsOn := range r.deviceDependencies {
+		remaining := dependsOn[:0]
+		for _, dep := range dependsOn {
+			if dep != name {
+				remaining = append(remaining, dep)
+			}
+		}
+		r.deviceDependencies[dependent] = remaining
+	}
+	if running {
+		r.Publish(DeviceRemoved, name)
+	}
+
+	return nil
+}
+
 // Device returns a device given a name. Returns nil if the Device does not exist.
 func (r *Robot) Device(name string) Device {
 	if r == nil {
 		return nil
 	}
+	r.devicesMutex.Lock()
+	defer r.devicesMutex.Unlock()
+
 	for _, device := range *r.devices {
 		if device.Name() == name {
 			return device
@@ -252,6 +465,177 @@ func (r *Robot) Device(name string) Device {
 	return nil
 }
 
+// DevicesByLabel returns all devices, which are tagged with the given label, see [gobot.Labeler].
+func (r *Robot) DevicesByLabel(key, value string) *Devices {
+	r.devicesMutex.Lock()
+	defer r.devicesMutex.Unlock()
+
+	devices := Devices{}
+	for _, device := range *r.devices {
+		labeler, ok := device.(Labeler)
+		if !ok {
+			continue
+		}
+		if v, ok := labeler.Labels()[key]; ok && v == value {
+			devices = append(devices, device)
+		}
+	}
+	return &devices
+}
+
+// AddDeviceDependency declares, that the device with the given name needs to be started after the given other
+// devices, e.g. a display after the configuration of its I2C bus multiplexer. Start() orders the devices accordingly
+// and returns an error for unknown devices or cyclic dependencies. Devices without dependencies keep the order of
+// adding.
+func (r *Robot) AddDeviceDependency(name string, dependsOn ...string) {
+	r.devicesMutex.Lock()
+	defer r.devicesMutex.Unlock()
+
+	r.deviceDependencies[name] = append(r.deviceDependencies[name], dependsOn...)
+}
+
+// devicesInStartOrder returns all devices, sorted by the declared dependencies
+func (r *Robot) devicesInStartOrder() (*Devices, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	r.devicesMutex.Lock()
+	devices := append(Devices{}, *r.devices...)
+	dependencies := make(map[string][]string, len(r.deviceDependencies))
+	for name, dependsOn := range r.deviceDependencies {
+		dependencies[name] = append([]string{}, dependsOn...)
+	}
+	r.devicesMutex.Unlock()
+
+	indexByName := make(map[string]int, len(devices))
+	for i, device := range devices {
+		if _, ok := indexByName[device.Name()]; !ok {
+			indexByName[device.Name()] = i
+		}
+	}
+
+	for name, dependsOn := range dependencies {
+		if _, ok := indexByName[name]; !ok {
+			return nil, fmt.Errorf("start dependency declared for unknown device '%s'", name)
+		}
+		for _, dep := range dependsOn {
+			if _, ok := indexByName[dep]; !ok {
+				return nil, fmt.Errorf("device '%s' depends on unknown device '%s'", name, dep)
+			}
+		}
+	}
+
+	ordered := make(Devices, 0, len(devices))
+	state := make([]int, len(devices))
+	var path []string
+
+	var visit func(idx int) error
+	visit = func(idx int) error {
+		name := devices[idx].Name()
+		switch state[idx] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := path
+			for i, n := range path {
+				if n == name {
+					cycle = path[i:]
+					break
+				}
+			}
+			return fmt.Errorf("cyclic start dependency between devices: %s -> %s", strings.Join(cycle, " -> "), name)
+		}
+
+		state[idx] = visiting
+		path = append(path, name)
+		for _, dep := range dependencies[name] {
+			if err := visit(indexByName[dep]); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[idx] = visited
+
+		ordered = append(ordered, devices[idx])
+		return nil
+	}
+
+	for i := range devices {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ordered, nil
+}
+
+// DisableDevice halts the device with the given name, while all other devices keep running. The event
+// "device-disabled" is published afterwards. Devices, which reports that they can not be restarted (see
+// [gobot.Restartable]), are not halted and an error is returned.
+func (r *Robot) DisableDevice(name string) error {
+	device := r.Device(name)
+	if device == nil {
+		return fmt.Errorf("No device found with the name %s", name)
+	}
+
+	if restartable, ok := device.(Restartable); ok && !restartable.Restartable() {
+		return fmt.Errorf("device '%s' does not support a restart, so it can not be disabled", name)
+	}
+
+	r.disabledMutex.Lock()
+	defer r.disabledMutex.Unlock()
+
+	if r.disabledDevices[name] {
+		return fmt.Errorf("device '%s' is already disabled", name)
+	}
+
+	log.Println("Disabling device", name, "...")
+	if err := device.Halt(); err != nil {
+		return err
+	}
+
+	r.disabledDevices[name] = true
+	r.Publish(DeviceDisabled, name)
+
+	return nil
+}
+
+// EnableDevice starts the device with the given name again, after it was disabled by DisableDevice(). The event
+// "device-enabled" is published afterwards. If the start fails, the device stays disabled.
+func (r *Robot) EnableDevice(name string) error {
+	device := r.Device(name)
+	if device == nil {
+		return fmt.Errorf("No device found with the name %s", name)
+	}
+
+	r.disabledMutex.Lock()
+	defer r.disabledMutex.Unlock()
+
+	if !r.disabledDevices[name] {
+		return fmt.Errorf("device '%s' is not disabled", name)
+	}
+
+	log.Println("Enabling device", name, "...")
+	if err := device.Start(); err != nil {
+		return fmt.Errorf("device '%s' could not be restarted: %w", name, err)
+	}
+
+	delete(r.disabledDevices, name)
+	r.Publish(DeviceEnabled, name)
+
+	return nil
+}
+
+// IsDeviceEnabled returns false, if the device with the given name was disabled by DisableDevice().
+func (r *Robot) IsDeviceEnabled(name string) bool {
+	r.disabledMutex.Lock()
+	defer r.disabledMutex.Unlock()
+
+	return !r.disabledDevices[name]
+}
+
 // Connections returns all connections associated with this robot.
 func (r *Robot) Connections() *Connections {
 	return r.connections
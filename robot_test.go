@@ -1,6 +1,10 @@
 package gobot
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -66,3 +70,454 @@ func TestRobotStartAutoRun(t *testing.T) {
 	require.NoError(t, r.Stop())
 	assert.False(t, r.Running())
 }
+
+type testNotRestartableDriver struct {
+	*testDriver
+}
+
+func (t *testNotRestartableDriver) Restartable() bool { return false }
+
+func TestRobotDisableDevice_EnableDevice(t *testing.T) {
+	// arrange
+	var starts, halts []string
+	oldStart, oldHalt := testDriverStart, testDriverHalt
+	defer func() { testDriverStart, testDriverHalt = oldStart, oldHalt }()
+	testDriverStart = func() error { starts = append(starts, "start"); return nil }
+	testDriverHalt = func() error { halts = append(halts, "halt"); return nil }
+	r := newTestRobot("Robot1")
+	events := r.Subscribe()
+	defer r.Unsubscribe(events)
+	require.NoError(t, r.Start(false))
+	require.Equal(t, RobotReady, (<-events).Name)
+	starts, halts = nil, nil
+	// act & assert: disable
+	require.NoError(t, r.DisableDevice("Device1"))
+	assert.Len(t, halts, 1)
+	assert.Empty(t, starts)
+	assert.False(t, r.IsDeviceEnabled("Device1"))
+	assert.True(t, r.IsDeviceEnabled("Device2"))
+	evt := <-events
+	assert.Equal(t, DeviceDisabled, evt.Name)
+	assert.Equal(t, "Device1", evt.Data)
+	require.EqualError(t, r.DisableDevice("Device1"), "device 'Device1' is already disabled")
+	// act & assert: enable
+	require.NoError(t, r.EnableDevice("Device1"))
+	assert.Len(t, halts, 1)
+	assert.Len(t, starts, 1)
+	assert.True(t, r.IsDeviceEnabled("Device1"))
+	evt = <-events
+	assert.Equal(t, DeviceEnabled, evt.Name)
+	assert.Equal(t, "Device1", evt.Data)
+	require.EqualError(t, r.EnableDevice("Device1"), "device 'Device1' is not disabled")
+	// act & assert: disabled devices are not halted again on stop
+	require.NoError(t, r.DisableDevice("Device2"))
+	halts = nil
+	require.NoError(t, r.Stop())
+	assert.Len(t, halts, 2)
+}
+
+func TestRobotDisableDevice_EnableDevice_errors(t *testing.T) {
+	// arrange
+	oldStart, oldHalt := testDriverStart, testDriverHalt
+	defer func() { testDriverStart, testDriverHalt = oldStart, oldHalt }()
+	r := newTestRobot("Robot1")
+	a := newTestAdaptor("Connection4", "/dev/null")
+	r.AddDevice(&testNotRestartableDriver{testDriver: newTestDriver(a, "NoRestart", "3")})
+	// act & assert: unknown device
+	require.EqualError(t, r.DisableDevice("Unknown"), "No device found with the name Unknown")
+	require.EqualError(t, r.EnableDevice("Unknown"), "No device found with the name Unknown")
+	// act & assert: device reports no support of restart
+	require.EqualError(t, r.DisableDevice("NoRestart"),
+		"device 'NoRestart' does not support a restart, so it can not be disabled")
+	assert.True(t, r.IsDeviceEnabled("NoRestart"))
+	// act & assert: halt error
+	testDriverHalt = func() error { return errors.New("halt error") }
+	require.EqualError(t, r.DisableDevice("Device1"), "halt error")
+	assert.True(t, r.IsDeviceEnabled("Device1"))
+	// act & assert: start error, device stays disabled
+	testDriverHalt = func() error { return nil }
+	testDriverStart = func() error { return errors.New("start error") }
+	require.NoError(t, r.DisableDevice("Device1"))
+	require.EqualError(t, r.EnableDevice("Device1"), "device 'Device1' could not be restarted: start error")
+	assert.False(t, r.IsDeviceEnabled("Device1"))
+}
+
+func TestRobotAddDevice_RemoveDevice(t *testing.T) {
+	// arrange
+	var starts, halts []string
+	oldStart, oldHalt := testDriverStart, testDriverHalt
+	defer func() { testDriverStart, testDriverHalt = oldStart, oldHalt }()
+	testDriverStart = func() error { starts = append(starts, "start"); return nil }
+	testDriverHalt = func() error { halts = append(halts, "halt"); return nil }
+	r := newTestRobot("Robot1")
+	events := r.Subscribe()
+	defer r.Unsubscribe(events)
+	require.NoError(t, r.Start(false))
+	require.Equal(t, RobotReady, (<-events).Name)
+	starts, halts = nil, nil
+	a := newTestAdaptor("Connection4", "/dev/null")
+	// act & assert: add to running robot
+	d := newTestDriver(a, "HotPlug", "4")
+	got, err := r.AddDeviceRuntime(d)
+	require.NoError(t, err)
+	assert.Equal(t, d, got)
+	assert.Len(t, starts, 1)
+	assert.Empty(t, halts)
+	assert.Equal(t, d, r.Device("HotPlug"))
+	evt := <-events
+	assert.Equal(t, DeviceAdded, evt.Name)
+	assert.Equal(t, "HotPlug", evt.Data)
+	// act & assert: duplicate name
+	got, err = r.AddDeviceRuntime(newTestDriver(a, "HotPlug", "5"))
+	require.EqualError(t, err, "device 'HotPlug' already exists")
+	assert.Nil(t, got)
+	assert.Len(t, starts, 1)
+	// act & assert: remove from running robot
+	require.NoError(t, r.RemoveDevice("HotPlug"))
+	assert.Len(t, halts, 1)
+	assert.Nil(t, r.Device("HotPlug"))
+	evt = <-events
+	assert.Equal(t, DeviceRemoved, evt.Name)
+	assert.Equal(t, "HotPlug", evt.Data)
+	require.EqualError(t, r.RemoveDevice("HotPlug"), "No device found with the name HotPlug")
+	// act & assert: disabled devices are not halted again on remove
+	require.NoError(t, r.DisableDevice("Device1"))
+	halts = nil
+	require.NoError(t, r.RemoveDevice("Device1"))
+	assert.Empty(t, halts)
+	// act & assert: removed devices are not halted on stop
+	require.NoError(t, r.Stop())
+	assert.Len(t, halts, r.Devices().Len())
+}
+
+func TestRobotAddDevice_RemoveDevice_errors(t *testing.T) {
+	// arrange
+	oldStart, oldHalt := testDriverStart, testDriverHalt
+	defer func() { testDriverStart, testDriverHalt = oldStart, oldHalt }()
+	r := newTestRobot("Robot1")
+	require.NoError(t, r.Start(false))
+	a := newTestAdaptor("Connection4", "/dev/null")
+	// act & assert: start error, device is not added
+	testDriverStart = func() error { return errors.New("start error") }
+	got, err := r.AddDeviceRuntime(newTestDriver(a, "HotPlug", "4"))
+	require.EqualError(t, err, "device 'HotPlug' could not be started: start error")
+	assert.Nil(t, got)
+	assert.Nil(t, r.Device("HotPlug"))
+	// act & assert: halt error, device stays attached
+	testDriverHalt = func() error { return errors.New("halt error") }
+	require.EqualError(t, r.RemoveDevice("Device1"), "device 'Device1' could not be halted: halt error")
+	assert.NotNil(t, r.Device("Device1"))
+	testDriverHalt = func() error { return nil }
+	require.NoError(t, r.Stop())
+}
+
+func TestRobotAddDeviceDependency_concurrent(t *testing.T) {
+	// arrange
+	r := newTestRobot("Robot1")
+	a := newTestAdaptor("Connection4", "/dev/null")
+	var wg sync.WaitGroup
+	// act: must not race, checked by "go test -race"
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("Extra%d", i)
+		r.AddDevice(newTestDriver(a, name, "4"))
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			r.AddDeviceDependency(name, "Device1")
+		}()
+		go func() {
+			defer wg.Done()
+			_ = r.RemoveDevice(name)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = r.devicesInStartOrder()
+		}()
+	}
+	wg.Wait()
+	// assert
+	assert.NotNil(t, r.Device("Device1"))
+}
+
+func TestRobotDevices_concurrent(t *testing.T) {
+	// arrange
+	r := newTestRobot("Robot1")
+	a := newTestAdaptor("Connection4", "/dev/null")
+	var wg sync.WaitGroup
+	// act: must not race, checked by "go test -race"
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("Extra%d", i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = r.AddDeviceRuntime(newTestDriver(a, name, "4"))
+			_ = r.RemoveDevice(name)
+		}()
+		go func() {
+			defer wg.Done()
+			r.Devices().Each(func(d Device) { _ = d.Name() })
+		}()
+	}
+	wg.Wait()
+	// assert
+	assert.Equal(t, 3, r.Devices().Len())
+	assert.NotSame(t, r.devices, r.Devices())
+}
+
+func TestRobotAddDevice_notRunning(t *testing.T) {
+	// arrange
+	var starts []string
+	oldStart := testDriverStart
+	defer func() { testDriverStart = oldStart }()
+	testDriverStart = func() error { starts = append(starts, "start"); return nil }
+	r := newTestRobot("Robot1")
+	a := newTestAdaptor("Connection4", "/dev/null")
+	// act & assert: the device is always returned, also for a duplicate name
+	d := newTestDriver(a, "Device1", "4")
+	assert.Equal(t, d, r.AddDevice(d))
+	// act & assert: runtime adding to a stopped robot does not start the device
+	d = newTestDriver(a, "Later", "5")
+	got, err := r.AddDeviceRuntime(d)
+	require.NoError(t, err)
+	assert.Equal(t, d, got)
+	assert.Equal(t, d, r.Device("Later"))
+	assert.Empty(t, starts)
+}
+
+func TestRobotRemoveDevice_notRunning(t *testing.T) {
+	// arrange
+	var halts []string
+	oldHalt := testDriverHalt
+	defer func() { testDriverHalt = oldHalt }()
+	testDriverHalt = func() error { halts = append(halts, "halt"); return nil }
+	r := newTestRobot("Robot1")
+	r.AddDeviceDependency("Device2", "Device1")
+	// act
+	require.NoError(t, r.RemoveDevice("Device1"))
+	// assert
+	assert.Empty(t, halts)
+	require.NoError(t, r.Start(false))
+	require.NoError(t, r.Stop())
+}
+
+type testLabeledDriver struct {
+	*testDriver
+	Labeler
+}
+
+func TestRobotDevicesByLabel(t *testing.T) {
+	// arrange
+	a := newTestAdaptor("Connection1", "/dev/null")
+	x := &testLabeledDriver{testDriver: newTestDriver(a, "MotorX", "1"), Labeler: NewLabeler()}
+	x.SetLabel("axis", "x")
+	y := &testLabeledDriver{testDriver: newTestDriver(a, "MotorY", "2"), Labeler: NewLabeler()}
+	y.SetLabel("axis", "y")
+	limitX := &testLabeledDriver{testDriver: newTestDriver(a, "LimitX", "3"), Labeler: NewLabeler()}
+	limitX.SetLabel("axis", "x")
+	limitX.SetLabel("kind", "switch")
+	r := NewRobot("Plotter", []Device{x, newTestDriver(a, "Unlabeled", "4"), y, limitX})
+	// act & assert
+	assert.Equal(t, &Devices{x, limitX}, r.DevicesByLabel("axis", "x"))
+	assert.Equal(t, &Devices{y}, r.DevicesByLabel("axis", "y"))
+	assert.Equal(t, &Devices{limitX}, r.DevicesByLabel("kind", "switch"))
+	assert.Equal(t, 0, r.DevicesByLabel("axis", "z").Len())
+	assert.Equal(t, 0, r.DevicesByLabel("color", "x").Len())
+	// act & assert: labels are part of the JSON representation
+	assert.Equal(t, map[string]string{"axis": "x", "kind": "switch"}, NewJSONDevice(limitX).Labels)
+	assert.Nil(t, NewJSONDevice(r.Device("Unlabeled")).Labels)
+}
+
+func TestRobotStart_deviceDependencies(t *testing.T) {
+	tests := map[string]struct {
+		dependencies map[string][]string
+		wantOrder    []string
+		wantErr      string
+	}{
+		"no_dependencies": {
+			wantOrder: []string{"Display", "Mux", "Bus", "Sensor"},
+		},
+		"valid_dependencies": {
+			dependencies: map[string][]string{
+				"Display": {"Mux"},
+				"Mux":     {"Bus"},
+				"Sensor":  {"Bus"},
+			},
+			wantOrder: []string{"Bus", "Mux", "Display", "Sensor"},
+		},
+		"multiple_dependencies": {
+			dependencies: map[string][]string{
+				"Bus": {"Sensor", "Display"},
+			},
+			wantOrder: []string{"Display", "Mux", "Sensor", "Bus"},
+		},
+		"error_cycle": {
+			dependencies: map[string][]string{
+				"Display": {"Mux"},
+				"Mux":     {"Bus"},
+				"Bus":     {"Display"},
+			},
+			wantErr: "cyclic start dependency between devices: Display -> Mux -> Bus -> Display",
+		},
+		"error_self_dependency": {
+			dependencies: map[string][]string{
+				"Sensor": {"Sensor"},
+			},
+			wantErr: "cyclic start dependency between devices: Sensor -> Sensor",
+		},
+		"error_unknown_dependency": {
+			dependencies: map[string][]string{
+				"Display": {"Keyboard"},
+			},
+			wantErr: "device 'Display' depends on unknown device 'Keyboard'",
+		},
+		"error_unknown_device": {
+			dependencies: map[string][]string{
+				"Keyboard": {"Bus"},
+			},
+			wantErr: "start dependency declared for unknown device 'Keyboard'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			var started []string
+			a := newTestAdaptor("Connection1", "/dev/null")
+			var devices []Device
+			for _, name := range []string{"Display", "Mux", "Bus", "Sensor"} {
+				devices = append(devices, &testStartRecordingDriver{
+					testDriver: newTestDriver(a, name, "1"),
+					started:    &started,
+				})
+			}
+			r := NewRobot("Robot1", []Connection{a}, devices)
+			for name, dependsOn := range tc.dependencies {
+				r.AddDeviceDependency(name, dependsOn...)
+			}
+			// act
+			err := r.Start(false)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Empty(t, started)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOrder, started)
+			require.NoError(t, r.Stop())
+		})
+	}
+}
+
+type testStartRecordingDriver struct {
+	*testDriver
+	started *[]string
+}
+
+func (t *testStartRecordingDriver) Start() error {
+	*t.started = append(*t.started, t.Name())
+	return nil
+}
+
+type testSlowDriver struct {
+	*testDriver
+	delay    time.Duration
+	startErr error
+	started  *int32
+	mtx      *sync.Mutex
+}
+
+func (t *testSlowDriver) Start() error {
+	time.Sleep(t.delay)
+	if t.startErr != nil {
+		return t.startErr
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	*t.started++
+	return nil
+}
+
+func TestRobotStart_readyEvent(t *testing.T) {
+	tests := map[string]struct {
+		failingDevice string
+		wantEvent     string
+		wantData      string
+		wantErr       string
+	}{
+		"ready": {
+			wantEvent: RobotReady,
+			wantData:  "Robot1",
+		},
+		"failed": {
+			failingDevice: "Slow2",
+			wantEvent:     RobotFailed,
+			wantData:      "Slow2",
+			wantErr:       "start error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			var started int32
+			var mtx sync.Mutex
+			a := newTestAdaptor("Connection1", "/dev/null")
+			var devices []Device
+			for i, delay := range []time.Duration{30 * time.Millisecond, 0, 10 * time.Millisecond} {
+				d := &testSlowDriver{
+					testDriver: newTestDriver(a, fmt.Sprintf("Slow%d", i+1), "1"),
+					delay:      delay,
+					started:    &started,
+					mtx:        &mtx,
+				}
+				if d.Name() == tc.failingDevice {
+					d.startErr = errors.New("start error")
+				}
+				devices = append(devices, d)
+			}
+			r := NewRobot("Robot1", []Connection{a}, devices)
+			events := r.Subscribe()
+			defer r.Unsubscribe(events)
+			// act
+			startErr := make(chan error, 1)
+			go func() { startErr <- r.Start(false) }()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err := r.WaitReady(ctx)
+			// assert
+			mtx.Lock()
+			startedAfterWait := started
+			mtx.Unlock()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Equal(t, int32(2), startedAfterWait)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int32(3), startedAfterWait)
+			}
+			evt := <-events
+			assert.Equal(t, tc.wantEvent, evt.Name)
+			assert.Equal(t, tc.wantData, evt.Data)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, <-startErr, tc.wantErr)
+			} else {
+				require.NoError(t, <-startErr)
+				require.NoError(t, r.Stop())
+			}
+		})
+	}
+}
+
+func TestRobotWaitReady_timeout(t *testing.T) {
+	// arrange
+	r := newTestRobot("Robot1")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	// act
+	err := r.WaitReady(ctx)
+	// assert
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	// act: after start, the wait returns immediately
+	require.NoError(t, r.Start(false))
+	require.NoError(t, r.WaitReady(context.Background()))
+	require.NoError(t, r.Stop())
+}
@@ -0,0 +1,378 @@
+package gobot
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// RobotRestarted is the event of a Supervisor, which is published after a failed robot was restarted. The data of
+	// the event is the name of the robot.
+	RobotRestarted = "robot_restarted"
+	// RobotGivenUp is the event of a Supervisor, which is published if a robot is still failing after the maximum
+	// count of restarts. The robot is not supervised anymore. The data of the event is the name of the robot.
+	RobotGivenUp = "robot_given_up"
+)
+
+const (
+	supervisorDefaultInitialBackoff = time.Second
+	supervisorDefaultMaxBackoff     = time.Minute
+)
+
+// Pinger is the interface for connections and devices, which can check whether the hardware is still responding.
+// It is used by the default health check of a Supervisor.
+type Pinger interface {
+	// Ping checks the hardware once
+	Ping() error
+}
+
+// supervisedRobot contains the state of the supervision of one robot
+type supervisedRobot struct {
+	restarts      int           // count of restarts since the robot was healthy the last time
+	backoff       time.Duration // delay before the next restart
+	restartAt     time.Time     // time of the pending restart, zero if no restart is pending
+	lastHeartbeat time.Time
+	givenUp       bool
+}
+
+// Supervisor checks the health of all robots of a Master cyclically and restarts failed robots with an exponential
+// backoff, while the other robots continue. After the maximum count of restarts without a healthy check in between,
+// the robot is given up. This improves the resilience of unattended deployments with many robots.
+//
+// A robot is healthy, if it is running and all of its connections and devices, which implement the Pinger interface,
+// are responding. Additionally the work of a robot can signal its liveness by calling Heartbeat(), see
+// SetHeartbeatTimeout(). The health check can be replaced by SetHealthCheck().
+type Supervisor struct {
+	Eventer
+	master           *Master
+	interval         time.Duration
+	maxRestarts      int
+	mutex            sync.Mutex
+	initialBackoff   time.Duration
+	maxBackoff       time.Duration
+	heartbeatTimeout time.Duration
+	healthCheck      func(r *Robot) error
+	robots           map[string]*supervisedRobot
+	halt             chan struct{}
+	done             chan struct{}
+	now              func() time.Time
+	newTicker        func(interval time.Duration) (<-chan time.Time, func())
+}
+
+// NewSupervisor creates a new supervisor for the robots of the given master, which checks all robots with the given
+// interval after Start() and restarts a failed robot up to the given count. Usually the master is started with
+// AutoRun = false before.
+func NewSupervisor(master *Master, interval time.Duration, maxRestarts int) *Supervisor {
+	if master == nil {
+		panic("the master is mandatory for a supervisor")
+	}
+	if interval <= 0 {
+		panic("the interval of a supervisor needs to be greater than zero")
+	}
+	if maxRestarts < 0 {
+		panic(fmt.Sprintf("max restarts (%d) of a supervisor must not be negative", maxRestarts))
+	}
+
+	s := &Supervisor{
+		Eventer:        NewEventer(),
+		master:         master,
+		interval:       interval,
+		maxRestarts:    maxRestarts,
+		initialBackoff: supervisorDefaultInitialBackoff,
+		maxBackoff:     supervisorDefaultMaxBackoff,
+		robots:         make(map[string]*supervisedRobot),
+		now:            time.Now,
+		newTicker: func(interval time.Duration) (<-chan time.Time, func()) {
+			ticker := time.NewTicker(interval)
+			return ticker.C, ticker.Stop
+		},
+	}
+	s.healthCheck = s.defaultHealthCheck
+	s.AddEvent(RobotRestarted)
+	s.AddEvent(RobotGivenUp)
+
+	return s
+}
+
+// Interval returns the check interval of the supervisor.
+func (s *Supervisor) Interval() time.Duration {
+	return s.interval
+}
+
+// MaxRestarts returns the count of restarts, after which a still failing robot is given up.
+func (s *Supervisor) MaxRestarts() int {
+	return s.maxRestarts
+}
+
+// SetBackoff sets the delay before the first restart of a failed robot (default 1s). The delay is doubled for each
+// further restart up to the given maximum (default 1min).
+func (s *Supervisor) SetBackoff(initial, maximum time.Duration) error {
+	if initial <= 0 {
+		return fmt.Errorf("initial backoff (%s) of supervisor must be greater than zero", initial)
+	}
+	if maximum < initial {
+		return fmt.Errorf("max backoff (%s) of supervisor must not be less than initial backoff (%s)", maximum, initial)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.initialBackoff = initial
+	s.maxBackoff = maximum
+
+	return nil
+}
+
+// Backoff returns the initial and the maximum delay before a restart, see SetBackoff().
+func (s *Supervisor) Backoff() (time.Duration, time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.initialBackoff, s.maxBackoff
+}
+
+// SetHeartbeatTimeout activates the check of the heartbeat for the default health check. A robot is failed, if
+// Heartbeat() was not called for the robot within the given duration, e.g. because the work has died. Zero (default)
+// deactivates the check.
+func (s *Supervisor) SetHeartbeatTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("heartbeat timeout (%s) of supervisor cannot be a negative value", timeout)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.heartbeatTimeout = timeout
+
+	return nil
+}
+
+// SetHealthCheck replaces the default health check. The robot is failed, if the function returns an error.
+func (s *Supervisor) SetHealthCheck(check func(r *Robot) error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if check == nil {
+		check = s.defaultHealthCheck
+	}
+	s.healthCheck = check
+}
+
+// Heartbeat signals the liveness of the robot with the given name, usually called by the work of the robot.
+func (s *Supervisor) Heartbeat(robotName string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.robotState(robotName).lastHeartbeat = s.now()
+}
+
+// Restarts returns the count of restarts of the robot since it was healthy the last time.
+func (s *Supervisor) Restarts(robotName string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.robotState(robotName).restarts
+}
+
+// GivenUp returns true, if the robot was given up after the maximum count of restarts.
+func (s *Supervisor) GivenUp(robotName string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.robotState(robotName).givenUp
+}
+
+// Running returns true, if the supervisor was started and not stopped yet.
+func (s *Supervisor) Running() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.halt != nil
+}
+
+// Start starts the cyclic check of all robots. The first check is done with the first tick.
+func (s *Supervisor) Start() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.halt != nil {
+		return fmt.Errorf("supervisor is already running")
+	}
+
+	s.halt = make(chan struct{})
+	s.done = make(chan struct{})
+	ticks, stopTicker := s.newTicker(s.interval)
+
+	go func(halt, done chan struct{}) {
+		defer close(done)
+		defer stopTicker()
+
+		for {
+			select {
+			case <-ticks:
+				s.CheckAll()
+			case <-halt:
+				return
+			}
+		}
+	}(s.halt, s.done)
+
+	return nil
+}
+
+// Stop stops the cyclic check and waits until a running check is finished. The robots are not stopped.
+func (s *Supervisor) Stop() error {
+	s.mutex.Lock()
+	halt := s.halt
+	done := s.done
+	s.halt = nil
+	s.done = nil
+	s.mutex.Unlock()
+
+	if halt == nil {
+		return fmt.Errorf("supervisor is not running")
+	}
+
+	close(halt)
+	<-done
+
+	return nil
+}
+
+// CheckAll checks all robots of the master once, a failed robot is scheduled for a restart and a robot with a due
+// restart is restarted. This is called cyclically after Start(), but can also be called directly.
+func (s *Supervisor) CheckAll() {
+	for _, r := range *s.master.Robots() {
+		s.check(r)
+	}
+}
+
+// check checks the robot and restarts it, if the restart is due
+func (s *Supervisor) check(r *Robot) {
+	s.mutex.Lock()
+	state := s.robotState(r.Name)
+	if state.givenUp {
+		s.mutex.Unlock()
+		return
+	}
+
+	if !state.restartAt.IsZero() {
+		if s.now().Before(state.restartAt) {
+			s.mutex.Unlock()
+			return
+		}
+		state.restartAt = time.Time{}
+		state.restarts++
+		state.lastHeartbeat = s.now()
+		s.mutex.Unlock()
+
+		s.restart(r)
+		return
+	}
+
+	healthCheck := s.healthCheck
+	s.mutex.Unlock()
+
+	err := healthCheck(r)
+
+	s.mutex.Lock()
+	if err == nil {
+		state.restarts = 0
+		state.backoff = 0
+		s.mutex.Unlock()
+		return
+	}
+
+	if state.restarts >= s.maxRestarts {
+		state.givenUp = true
+		s.mutex.Unlock()
+
+		log.Printf("Robot %s is given up after %d restarts: %v\n", r.Name, s.maxRestarts, err)
+		s.Publish(RobotGivenUp, r.Name)
+		return
+	}
+
+	if state.backoff == 0 {
+		state.backoff = s.initialBackoff
+	} else {
+		state.backoff *= 2
+	}
+	if state.backoff > s.maxBackoff {
+		state.backoff = s.maxBackoff
+	}
+	state.restartAt = s.now().Add(state.backoff)
+	backoff := state.backoff
+	s.mutex.Unlock()
+
+	log.Printf("Robot %s failed, restart in %s: %v\n", r.Name, backoff, err)
+}
+
+// restart stops the robot, if still running, and starts it again
+func (s *Supervisor) restart(r *Robot) {
+	if r.Running() {
+		if err := r.Stop(); err != nil {
+			log.Printf("Stop of robot %s before restart failed: %v\n", r.Name, err)
+		}
+	}
+
+	if err := r.Start(false); err != nil {
+		// the failure is detected by the next check
+		log.Printf("Restart of robot %s failed: %v\n", r.Name, err)
+	}
+
+	s.Publish(RobotRestarted, r.Name)
+}
+
+// defaultHealthCheck checks the running state, the heartbeat and each connection and device, which can be pinged
+func (s *Supervisor) defaultHealthCheck(r *Robot) error {
+	if !r.Running() {
+		return fmt.Errorf("robot '%s' is not running", r.Name)
+	}
+
+	s.mutex.Lock()
+	timeout := s.heartbeatTimeout
+	state := s.robotState(r.Name)
+	now := s.now()
+	if state.lastHeartbeat.IsZero() {
+		// the supervision starts now
+		state.lastHeartbeat = now
+	}
+	sinceHeartbeat := now.Sub(state.lastHeartbeat)
+	s.mutex.Unlock()
+
+	if timeout > 0 && sinceHeartbeat > timeout {
+		return fmt.Errorf("no heartbeat of robot '%s' since %s", r.Name, sinceHeartbeat)
+	}
+
+	var err error
+	r.Connections().Each(func(c Connection) {
+		if p, ok := c.(Pinger); ok && err == nil {
+			if e := p.Ping(); e != nil {
+				err = fmt.Errorf("connection '%s' of robot '%s' does not respond: %w", c.Name(), r.Name, e)
+			}
+		}
+	})
+	r.Devices().Each(func(d Device) {
+		if p, ok := d.(Pinger); ok && err == nil {
+			if e := p.Ping(); e != nil {
+				err = fmt.Errorf("device '%s' of robot '%s' does not respond: %w", d.Name(), r.Name, e)
+			}
+		}
+	})
+
+	return err
+}
+
+// robotState returns the state of the supervision for the robot, which is created on first call. The mutex needs to
+// be locked by the caller.
+func (s *Supervisor) robotState(robotName string) *supervisedRobot {
+	state, ok := s.robots[robotName]
+	if !ok {
+		state = &supervisedRobot{}
+		s.robots[robotName] = state
+	}
+
+	return state
+}
@@ -0,0 +1,260 @@
+package gobot
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPingDriver struct {
+	*testDriver
+	err error
+	mtx sync.Mutex
+}
+
+func (d *testPingDriver) Ping() error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.err
+}
+
+func (d *testPingDriver) setErr(err error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.err = err
+}
+
+// testSupervisorClock is a manual clock for the supervisor
+type testSupervisorClock struct {
+	now time.Time
+	mtx sync.Mutex
+}
+
+func (c *testSupervisorClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+func (c *testSupervisorClock) Add(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func initTestSupervisor(robots ...*Robot) (*Supervisor, *testSupervisorClock) {
+	m := NewMaster()
+	for _, r := range robots {
+		m.AddRobot(r)
+	}
+	clock := &testSupervisorClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewSupervisor(m, 10*time.Millisecond, 3)
+	s.now = clock.Now
+	return s, clock
+}
+
+// collectSupervisorEvents subscribes to the events and returns a function to get the collected event names
+func collectSupervisorEvents(s *Supervisor) func() []string {
+	var names []string
+	var mtx sync.Mutex
+	for _, name := range []string{RobotRestarted, RobotGivenUp} {
+		name := name
+		_ = s.On(name, func(data interface{}) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			names = append(names, name+":"+data.(string))
+		})
+	}
+
+	return func() []string {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return append([]string{}, names...)
+	}
+}
+
+func TestNewSupervisor(t *testing.T) {
+	// arrange
+	m := NewMaster()
+	// act
+	s := NewSupervisor(m, 5*time.Millisecond, 2)
+	// assert
+	assert.Equal(t, 5*time.Millisecond, s.Interval())
+	assert.Equal(t, 2, s.MaxRestarts())
+	initial, maximum := s.Backoff()
+	assert.Equal(t, time.Second, initial)
+	assert.Equal(t, time.Minute, maximum)
+	assert.False(t, s.Running())
+	assert.Equal(t, RobotRestarted, s.Event(RobotRestarted))
+	assert.Equal(t, RobotGivenUp, s.Event(RobotGivenUp))
+	assert.PanicsWithValue(t, "the master is mandatory for a supervisor", func() {
+		_ = NewSupervisor(nil, time.Second, 1)
+	})
+	assert.PanicsWithValue(t, "the interval of a supervisor needs to be greater than zero", func() {
+		_ = NewSupervisor(m, 0, 1)
+	})
+	assert.PanicsWithValue(t, "max restarts (-1) of a supervisor must not be negative", func() {
+		_ = NewSupervisor(m, time.Second, -1)
+	})
+}
+
+func TestSupervisorSetters(t *testing.T) {
+	// arrange
+	s, _ := initTestSupervisor()
+	// act & assert
+	require.NoError(t, s.SetBackoff(10*time.Millisecond, 80*time.Millisecond))
+	initial, maximum := s.Backoff()
+	assert.Equal(t, 10*time.Millisecond, initial)
+	assert.Equal(t, 80*time.Millisecond, maximum)
+	require.EqualError(t, s.SetBackoff(0, time.Second), "initial backoff (0s) of supervisor must be greater than zero")
+	require.EqualError(t, s.SetBackoff(time.Second, time.Millisecond),
+		"max backoff (1ms) of supervisor must not be less than initial backoff (1s)")
+	require.NoError(t, s.SetHeartbeatTimeout(time.Second))
+	assert.Equal(t, time.Second, s.heartbeatTimeout)
+	require.EqualError(t, s.SetHeartbeatTimeout(-time.Second),
+		"heartbeat timeout (-1s) of supervisor cannot be a negative value")
+}
+
+func TestSupervisorRestartWithBackoff(t *testing.T) {
+	// arrange
+	failing := newTestRobot("failing")
+	healthy := newTestRobot("healthy")
+	s, clock := initTestSupervisor(failing, healthy)
+	require.NoError(t, s.SetBackoff(10*time.Millisecond, 25*time.Millisecond))
+	var checks []string
+	var starts int32
+	failing.Work = func() { atomic.AddInt32(&starts, 1) }
+	var failErr error
+	s.SetHealthCheck(func(r *Robot) error {
+		checks = append(checks, r.Name)
+		if r == failing {
+			return failErr
+		}
+		return nil
+	})
+	events := collectSupervisorEvents(s)
+	require.NoError(t, failing.Start(false))
+	require.NoError(t, healthy.Start(false))
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&starts) == 1 }, time.Second, time.Millisecond)
+	// act & assert: healthy robots are not restarted
+	s.CheckAll()
+	assert.Equal(t, []string{"failing", "healthy"}, checks)
+	// act & assert: the failure is detected, the restart is scheduled after the initial backoff
+	failErr = errors.New("work died")
+	s.CheckAll()
+	clock.Add(9 * time.Millisecond)
+	s.CheckAll()
+	assert.Equal(t, 0, s.Restarts("failing"))
+	clock.Add(time.Millisecond)
+	s.CheckAll()
+	assert.Equal(t, 1, s.Restarts("failing"))
+	assert.True(t, failing.Running())
+	// act & assert: still failing, the backoff is doubled
+	s.CheckAll()
+	clock.Add(19 * time.Millisecond)
+	s.CheckAll()
+	assert.Equal(t, 1, s.Restarts("failing"))
+	clock.Add(time.Millisecond)
+	s.CheckAll()
+	assert.Equal(t, 2, s.Restarts("failing"))
+	// act & assert: the backoff is limited
+	s.CheckAll()
+	clock.Add(24 * time.Millisecond)
+	s.CheckAll()
+	assert.Equal(t, 2, s.Restarts("failing"))
+	clock.Add(time.Millisecond)
+	s.CheckAll()
+	assert.Equal(t, 3, s.Restarts("failing"))
+	// act & assert: given up after the max restarts
+	s.CheckAll()
+	assert.True(t, s.GivenUp("failing"))
+	clock.Add(time.Second)
+	s.CheckAll()
+	assert.Equal(t, 3, s.Restarts("failing"))
+	assert.False(t, s.GivenUp("healthy"))
+	assert.Equal(t, 0, s.Restarts("healthy"))
+	// the work was started again on each restart
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&starts) == 4 }, time.Second, time.Millisecond)
+	// the handlers of different events are called concurrently, so the order is not given
+	assert.Eventually(t, func() bool { return len(events()) == 4 }, time.Second, time.Millisecond)
+	assert.ElementsMatch(t, []string{
+		"robot_restarted:failing", "robot_restarted:failing", "robot_restarted:failing", "robot_given_up:failing",
+	}, events())
+}
+
+func TestSupervisorHealthyResetsRestarts(t *testing.T) {
+	// arrange
+	r := newTestRobot("flaky")
+	s, clock := initTestSupervisor(r)
+	require.NoError(t, s.SetBackoff(10*time.Millisecond, time.Second))
+	var failErr error
+	s.SetHealthCheck(func(*Robot) error { return failErr })
+	require.NoError(t, r.Start(false))
+	failErr = errors.New("flaky")
+	s.CheckAll()
+	clock.Add(10 * time.Millisecond)
+	s.CheckAll()
+	require.Equal(t, 1, s.Restarts("flaky"))
+	// act
+	failErr = nil
+	s.CheckAll()
+	// assert: the next failure starts with the initial backoff again
+	assert.Equal(t, 0, s.Restarts("flaky"))
+	failErr = errors.New("flaky again")
+	s.CheckAll()
+	clock.Add(10 * time.Millisecond)
+	s.CheckAll()
+	assert.Equal(t, 1, s.Restarts("flaky"))
+}
+
+func TestSupervisorDefaultHealthCheck(t *testing.T) {
+	// arrange
+	a := newTestAdaptor("adaptor", "/dev/null")
+	d := &testPingDriver{testDriver: newTestDriver(a, "pinger", "1")}
+	r := NewRobot("pinged", []Connection{a}, []Device{d})
+	s, clock := initTestSupervisor(r)
+	// act & assert: not running
+	require.EqualError(t, s.defaultHealthCheck(r), "robot 'pinged' is not running")
+	require.NoError(t, r.Start(false))
+	require.NoError(t, s.defaultHealthCheck(r))
+	// act & assert: the device does not respond
+	d.setErr(errors.New("timeout"))
+	require.EqualError(t, s.defaultHealthCheck(r), "device 'pinger' of robot 'pinged' does not respond: timeout")
+	d.setErr(nil)
+	// act & assert: heartbeat
+	require.NoError(t, s.SetHeartbeatTimeout(100*time.Millisecond))
+	s.Heartbeat("pinged")
+	clock.Add(100 * time.Millisecond)
+	require.NoError(t, s.defaultHealthCheck(r))
+	clock.Add(time.Millisecond)
+	require.EqualError(t, s.defaultHealthCheck(r), "no heartbeat of robot 'pinged' since 101ms")
+	s.Heartbeat("pinged")
+	require.NoError(t, s.defaultHealthCheck(r))
+}
+
+func TestSupervisorStartStop(t *testing.T) {
+	// arrange
+	r := newTestRobot("crashing")
+	m := NewMaster()
+	m.AddRobot(r)
+	s := NewSupervisor(m, 5*time.Millisecond, 1)
+	require.NoError(t, s.SetBackoff(time.Millisecond, time.Millisecond))
+	events := collectSupervisorEvents(s)
+	// act: the robot was not started, so it is failed and restarted
+	require.NoError(t, s.Start())
+	require.EqualError(t, s.Start(), "supervisor is already running")
+	// assert
+	assert.True(t, s.Running())
+	assert.Eventually(t, func() bool { return r.Running() }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return len(events()) > 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, "robot_restarted:crashing", events()[0])
+	require.NoError(t, s.Stop())
+	assert.False(t, s.Running())
+	require.EqualError(t, s.Stop(), "supervisor is not running")
+	require.NoError(t, r.Stop())
+}
@@ -18,13 +18,16 @@ const (
 	// ioctl signals
 	I2C_SLAVE = 0x0703
 	I2C_FUNCS = 0x0705
+	I2C_PEC   = 0x0708
 	I2C_SMBUS = 0x0720
 	// Read/write markers
 	I2C_SMBUS_READ  = 1
 	I2C_SMBUS_WRITE = 0
 
 	// From  /usr/include/linux/i2c.h:
+	I2C_SMBUS_BLOCK_MAX = 32 // as specified in SMBus standard
 	// Adapter functionality
+	I2C_FUNC_SMBUS_PEC              = 0x00000008
 	I2C_FUNC_SMBUS_READ_BYTE        = 0x00020000
 	I2C_FUNC_SMBUS_WRITE_BYTE       = 0x00040000
 	I2C_FUNC_SMBUS_READ_BYTE_DATA   = 0x00080000
@@ -169,6 +172,50 @@ func (d *i2cDevice) ReadBlockData(address int, reg uint8, data []byte) error {
 	return nil
 }
 
+// ReadSMBusBlockData reads a SMBus block from the given register of an i2c device. In contrast to ReadBlockData(),
+// the length of the block is given by the device with the first byte of the response, which is not part of the
+// returned data. The block is read in a single transfer with a repeated start. With pec, the packet error code is
+// requested from the device and validated by the kernel driver.
+func (d *i2cDevice) ReadSMBusBlockData(address int, reg uint8, pec bool) ([]byte, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.queryFunctionality(I2C_FUNC_SMBUS_READ_BLOCK_DATA, "read SMBus block data"); err != nil {
+		return nil, err
+	}
+
+	if pec {
+		if err := d.queryFunctionality(I2C_FUNC_SMBUS_PEC, "packet error checking"); err != nil {
+			return nil, err
+		}
+		if err := d.syscallIoctl(I2C_PEC, nil, 1, "Enabling packet error checking"); err != nil {
+			return nil, err
+		}
+	}
+
+	// the first element contains the length of the block, the last one is needed for the PEC at Kernel side
+	buf := make([]byte, I2C_SMBUS_BLOCK_MAX+2)
+	err := d.smbusAccess(address, I2C_SMBUS_READ, reg, I2C_SMBUS_BLOCK_DATA, unsafe.Pointer(&buf[0]))
+	if pec {
+		// the setting is valid for all transfers of the character device, so it needs to be reset
+		if pecErr := d.syscallIoctl(I2C_PEC, nil, 0, "Disabling packet error checking"); pecErr != nil && err == nil {
+			err = pecErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	count := int(buf[0])
+	if count == 0 || count > I2C_SMBUS_BLOCK_MAX {
+		return nil, fmt.Errorf("invalid SMBus block length %d, must be between 1 and %d", count, I2C_SMBUS_BLOCK_MAX)
+	}
+
+	data := make([]byte, count)
+	copy(data, buf[1:])
+	return data, nil
+}
+
 // WriteByte writes the given byte value to the current register of an i2c device.
 func (d *i2cDevice) WriteByte(address int, val byte) error {
 	d.mutex.Lock()
@@ -321,6 +321,98 @@ func TestReadBlockData(t *testing.T) {
 	}
 }
 
+func TestReadSMBusBlockData(t *testing.T) {
+	const reg = byte(0x20)
+	tests := map[string]struct {
+		funcs       uint64
+		pec         bool
+		dataSlice   []byte
+		syscallImpl func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (r1, r2 uintptr, err SyscallErrno)
+		want        []byte
+		wantErr     string
+	}{
+		"read_without_pec": {
+			funcs:     I2C_FUNC_SMBUS_READ_BLOCK_DATA,
+			dataSlice: []byte("bat"),
+			want:      []byte("bat"),
+		},
+		"read_with_pec": {
+			funcs:     I2C_FUNC_SMBUS_READ_BLOCK_DATA | I2C_FUNC_SMBUS_PEC,
+			pec:       true,
+			dataSlice: []byte("bat"),
+			want:      []byte("bat"),
+		},
+		"error_not_supported": {
+			funcs:     I2C_FUNC_SMBUS_READ_I2C_BLOCK,
+			dataSlice: []byte("bat"),
+			wantErr:   "SMBus read SMBus block data not supported",
+		},
+		"error_pec_not_supported": {
+			funcs:     I2C_FUNC_SMBUS_READ_BLOCK_DATA,
+			pec:       true,
+			dataSlice: []byte("bat"),
+			wantErr:   "SMBus packet error checking not supported",
+		},
+		"error_syscall": {
+			funcs:       I2C_FUNC_SMBUS_READ_BLOCK_DATA | I2C_FUNC_SMBUS_PEC,
+			pec:         true,
+			dataSlice:   []byte("bat"),
+			syscallImpl: getSyscallFuncImpl(0x04),
+			wantErr: "SMBus access r/w: 1, command: 32, protocol: 5, address: 5 " +
+				"failed with syscall.Errno operation not permitted",
+		},
+		"error_zero_length": {
+			funcs:     I2C_FUNC_SMBUS_READ_BLOCK_DATA,
+			dataSlice: []byte{},
+			wantErr:   "invalid SMBus block length 0, must be between 1 and 32",
+		},
+		"error_too_long": {
+			funcs:     I2C_FUNC_SMBUS_READ_BLOCK_DATA,
+			dataSlice: make([]byte, 33),
+			wantErr:   "invalid SMBus block length 33, must be between 1 and 32",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, msc := initTestI2cDeviceWithMockedSys()
+			msc.Impl = tc.syscallImpl
+			d.funcs = tc.funcs
+			msc.dataSlice = tc.dataSlice
+			var pecStates []uintptr
+			if tc.pec {
+				impl := msc.Impl
+				msc.Impl = func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (uintptr, uintptr, SyscallErrno) {
+					if a2 == I2C_PEC {
+						pecStates = append(pecStates, msc.pec)
+					}
+					if impl != nil {
+						return impl(trap, a1, a2, a3)
+					}
+					return 0, 0, 0
+				}
+			}
+			// act
+			got, err := d.ReadSMBusBlockData(5, reg, tc.pec)
+			// assert
+			if tc.pec && tc.funcs&I2C_FUNC_SMBUS_PEC != 0 {
+				// packet error checking is enabled for the transfer and disabled afterwards
+				assert.Equal(t, []uintptr{1, 0}, pecStates)
+			}
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, byte(I2C_SMBUS_READ), msc.smbus.readWrite)
+			assert.Equal(t, reg, msc.smbus.command)
+			assert.Equal(t, uint32(I2C_SMBUS_BLOCK_DATA), msc.smbus.protocol)
+		})
+	}
+}
+
 func TestWriteByte(t *testing.T) {
 	tests := map[string]struct {
 		funcs       uint64
@@ -29,6 +29,7 @@ type nativeSyscall struct{}
 // detect this problem and this unpack procedure would cause unpredictable results.
 // So the decision was taken to give the address here as a separate parameter, although it is not used in every call.
 // Note also, that the size of the address variable at Kernel side is u16, therefore uint16 is used here.
+// The parameter is used for the flag of "I2C_PEC" as well, which is given as value in the same way.
 //
 //nolint:nonamedreturns // useful here
 func (sys *nativeSyscall) syscall(
@@ -39,8 +40,8 @@ func (sys *nativeSyscall) syscall(
 	address uint16,
 ) (r1, r2 uintptr, err SyscallErrno) {
 	var errNo unix.Errno
-	if signal == I2C_SLAVE {
-		// this is the setup for the address, it just needs to be converted to an uintptr,
+	if signal == I2C_SLAVE || signal == I2C_PEC {
+		// this is the setup for the address or the packet error checking, it just needs to be converted to an uintptr,
 		// the given payload is not used in this case, see the comment on the function
 		r1, r2, errNo = unix.Syscall(trap, f.Fd(), signal, uintptr(address))
 	} else {
@@ -10,6 +10,7 @@ type mockSyscall struct {
 	lastFile   File
 	lastSignal uintptr
 	devAddress uintptr
+	pec        uintptr
 	smbus      *i2cSmbusIoctlData
 	sliceSize  uint8
 	dataSlice  []byte
@@ -36,6 +37,11 @@ func (sys *mockSyscall) syscall(
 		sys.devAddress = uintptr(address)
 	}
 
+	if signal == I2C_PEC {
+		// this is the setup for packet error checking, the flag is given like the address
+		sys.pec = uintptr(address)
+	}
+
 	if signal == I2C_SMBUS {
 		// set the I2C smbus data object reference to payload and fill with some data
 		sys.smbus = (*i2cSmbusIoctlData)(payload)
@@ -51,9 +57,15 @@ func (sys *mockSyscall) syscall(
 				// fill data object with data from given slice to simulate reading
 				if sys.dataSlice != nil {
 					slc := unsafe.Slice((*byte)(sys.smbus.data), sys.sliceSize)
-					if sys.smbus.protocol == I2C_SMBUS_BLOCK_DATA || sys.smbus.protocol == I2C_SMBUS_I2C_BLOCK_DATA {
+					switch sys.smbus.protocol {
+					case I2C_SMBUS_BLOCK_DATA:
+						// the length of the block is given by the device, so the full buffer is used
+						slc = unsafe.Slice((*byte)(sys.smbus.data), I2C_SMBUS_BLOCK_MAX+2)
+						slc[0] = byte(len(sys.dataSlice))
+						copy(slc[1:], sys.dataSlice)
+					case I2C_SMBUS_I2C_BLOCK_DATA:
 						copy(slc[1:], sys.dataSlice)
-					} else {
+					default:
 						copy(slc, sys.dataSlice)
 					}
 				}
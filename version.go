@@ -0,0 +1,88 @@
+package gobot
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+const modulePath = "gobot.io/x/gobot/v2"
+
+// The build information can be set at build time with the linker flags, e.g.:
+//
+//	go build -ldflags "-X gobot.io/x/gobot/v2.version=v2.4.0 -X gobot.io/x/gobot/v2.commit=0123abc"
+//
+// If not set, the values are taken from the build information of the binary, where possible.
+var (
+	version string
+	commit  string
+)
+
+// readBuildInfo can be replaced by tests
+var readBuildInfo = debug.ReadBuildInfo
+
+// BuildInfo contains the version information of gobot, which is embedded in the binary.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Version returns the version of gobot, which is embedded in the binary, e.g. "v2.4.0". "(devel)" is returned for a
+// build of gobot itself without the version set by linker flags, "unknown" if no information is available.
+func Version() string {
+	return CurrentBuildInfo().Version
+}
+
+// CurrentBuildInfo returns the version, the commit and the go version of the embedded gobot, e.g. for support
+// requests. The values, which are set by linker flags, take precedence over the build information of the binary.
+func CurrentBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := readBuildInfo(); ok {
+		if info.Version == "" {
+			info.Version = moduleVersion(bi)
+		}
+		if info.Commit == "" && bi.Main.Path == modulePath {
+			info.Commit = buildSetting(bi, "vcs.revision")
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = "unknown"
+	}
+
+	return info
+}
+
+// moduleVersion returns the version of gobot, which is the main module or a dependency of the binary
+func moduleVersion(bi *debug.BuildInfo) string {
+	if bi.Main.Path == modulePath {
+		return bi.Main.Version
+	}
+
+	for _, dep := range bi.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+
+	return ""
+}
+
+func buildSetting(bi *debug.BuildInfo, key string) string {
+	for _, s := range bi.Settings {
+		if s.Key == key {
+			return s.Value
+		}
+	}
+
+	return ""
+}
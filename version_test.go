@@ -0,0 +1,71 @@
+package gobot
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	tests := map[string]struct {
+		version   string
+		commit    string
+		buildInfo *debug.BuildInfo
+		want      BuildInfo
+	}{
+		"set_by_linker_flags": {
+			version: "v2.9.1",
+			commit:  "0123abc",
+			buildInfo: &debug.BuildInfo{
+				Main: debug.Module{Path: modulePath, Version: "(devel)"},
+			},
+			want: BuildInfo{Version: "v2.9.1", Commit: "0123abc"},
+		},
+		"main_module": {
+			buildInfo: &debug.BuildInfo{
+				Main:     debug.Module{Path: modulePath, Version: "(devel)"},
+				Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "4567def"}},
+			},
+			want: BuildInfo{Version: "(devel)", Commit: "4567def"},
+		},
+		"dependency": {
+			buildInfo: &debug.BuildInfo{
+				Main:     debug.Module{Path: "example.com/robot", Version: "(devel)"},
+				Deps:     []*debug.Module{{Path: "github.com/other", Version: "v1.0.0"}, {Path: modulePath, Version: "v2.3.0"}},
+				Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "4567def"}},
+			},
+			want: BuildInfo{Version: "v2.3.0"},
+		},
+		"replaced_dependency": {
+			buildInfo: &debug.BuildInfo{
+				Main: debug.Module{Path: "example.com/robot"},
+				Deps: []*debug.Module{{
+					Path: modulePath, Version: "v2.3.0",
+					Replace: &debug.Module{Path: "example.com/fork", Version: "v2.3.1"},
+				}},
+			},
+			want: BuildInfo{Version: "v2.3.1"},
+		},
+		"no_build_info": {
+			want: BuildInfo{Version: "unknown"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			origVersion, origCommit, origRead := version, commit, readBuildInfo
+			defer func() { version, commit, readBuildInfo = origVersion, origCommit, origRead }()
+			version = tc.version
+			commit = tc.commit
+			readBuildInfo = func() (*debug.BuildInfo, bool) { return tc.buildInfo, tc.buildInfo != nil }
+			tc.want.GoVersion = runtime.Version()
+			// act
+			got := CurrentBuildInfo()
+			// assert
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.want.Version, Version())
+		})
+	}
+}